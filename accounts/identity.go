@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 )
 
@@ -13,6 +14,7 @@ var (
 	ErrWalletNoAccount = errors.New("Wallet does not have any account")
 	ErrWalletNotOpen   = errors.New("Wallet is not open")
 	ErrWalletIsEmpty   = errors.New("Keystore does not have any wallets")
+	ErrNoSuchAccount   = errors.New("Keystore does not have such account")
 )
 
 func init() {
@@ -33,6 +35,9 @@ type Identity interface {
 	// Open opens loaded account
 	Open(passphrase string) error
 
+	// OpenAccount opens the account with given address
+	OpenAccount(addr common.Address, passphrase string) error
+
 	// Import imports existing account from given json and pass-phrase
 	Import(json []byte, passphrase string) error
 
@@ -79,6 +84,20 @@ func (idt *identityPassphrase) Open(passphrase string) error {
 	return idt.readPrivateKey(passphrase)
 }
 
+func (idt *identityPassphrase) OpenAccount(addr common.Address, passphrase string) error {
+	if len(idt.keystore.Wallets()) == 0 {
+		return ErrWalletIsEmpty
+	}
+
+	acc, err := idt.keystore.Find(accounts.Account{Address: addr})
+	if err != nil {
+		return ErrNoSuchAccount
+	}
+	idt.defaultAccount = acc
+
+	return idt.readPrivateKey(passphrase)
+}
+
 func (idt *identityPassphrase) GetPrivateKey() (*ecdsa.PrivateKey, error) {
 	if idt.privateKey == nil {
 		return nil, ErrWalletNotOpen
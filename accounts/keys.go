@@ -12,7 +12,10 @@ import (
 	"github.com/sonm-io/core/util"
 )
 
-const defaultKeystorePath = ".sonm/keystore/"
+const (
+	defaultKeystorePath     = ".sonm/keystore/"
+	defaultPassPhrasePrompt = "Key passphrase"
+)
 
 var (
 	errNoKeystoreDir = errors.New("keystore directory does not exist")
@@ -44,6 +47,7 @@ type KeyOpener interface {
 // defaultKeyOpener implements KeyOpener interface
 type defaultKeyOpener struct {
 	keyDirPath string
+	account    string
 	idt        Identity
 	pf         PassPhraser
 }
@@ -72,10 +76,16 @@ func (o *defaultKeyOpener) OpenKeystore() (bool, error) {
 	}
 
 	idt = NewIdentity(o.keyDirPath)
-	err = idt.Open(passPhrase)
+	err = o.openAccount(idt, passPhrase)
 	if err == nil {
 		return false, nil
 	} else {
+		// The requested account can not be substituted with a new one.
+		if err == ErrWalletIsEmpty && o.account != "" {
+			err = ErrNoSuchAccount
+			return false, err
+		}
+
 		if err == ErrWalletIsEmpty {
 			// pass passPhrase to createKey method to prevent double pass phrase reading
 			_, err = o.createNewKey(idt, passPhrase)
@@ -88,6 +98,27 @@ func (o *defaultKeyOpener) OpenKeystore() (bool, error) {
 	}
 }
 
+// openAccount opens either explicitly requested account, or the one
+// selected as default, or the first account found in the keystore.
+func (o *defaultKeyOpener) openAccount(idt Identity, passPhrase string) error {
+	if o.account != "" {
+		addr, err := ParseAddress(o.account)
+		if err != nil {
+			return err
+		}
+
+		return idt.OpenAccount(addr, passPhrase)
+	}
+
+	if addr, ok := readDefaultAccount(o.keyDirPath); ok {
+		if err := idt.OpenAccount(addr, passPhrase); err != ErrNoSuchAccount {
+			return err
+		}
+	}
+
+	return idt.Open(passPhrase)
+}
+
 func (o *defaultKeyOpener) GetKey() (*ecdsa.PrivateKey, error) {
 	if o.idt == nil {
 		return nil, ErrWalletNotOpen
@@ -112,8 +143,15 @@ func (o *defaultKeyOpener) createNewKey(idt Identity, passPhrease string) (*ecds
 
 // NewKeyOpener returns KeyOpener that able to open keys
 func NewKeyOpener(keyDir string, pf PassPhraser) KeyOpener {
+	return NewAccountKeyOpener(keyDir, "", pf)
+}
+
+// NewAccountKeyOpener returns KeyOpener that opens the key of given account.
+// Empty account means the account selected as default.
+func NewAccountKeyOpener(keyDir, account string, pf PassPhraser) KeyOpener {
 	ko := &defaultKeyOpener{
 		keyDirPath: keyDir,
+		account:    account,
 		idt:        nil,
 		pf:         pf,
 	}
@@ -124,12 +162,18 @@ func NewKeyOpener(keyDir string, pf PassPhraser) KeyOpener {
 // interactivePassPhraser implements the PassPhrase which allows to
 // read passphrase on terminal interactively
 type interactivePassPhraser struct {
+	prompt string
 	reader gopass.FdReader
 	writer io.Writer
 }
 
 func (pf *interactivePassPhraser) GetPassPhrase() (string, error) {
-	pw, err := gopass.GetPasswdPrompt("\r\nKey passphrase: ", false, pf.reader, pf.writer)
+	prompt := pf.prompt
+	if prompt == "" {
+		prompt = defaultPassPhrasePrompt
+	}
+
+	pw, err := gopass.GetPasswdPrompt(fmt.Sprintf("\r\n%s: ", prompt), false, pf.reader, pf.writer)
 	if err != nil {
 		return "", err
 	}
@@ -140,7 +184,14 @@ func (pf *interactivePassPhraser) GetPassPhrase() (string, error) {
 // NewInteractivePassPhraser implements PassPhraser that prompts user for pass-phrase
 // and read it from terminal's Stdin
 func NewInteractivePassPhraser() PassPhraser {
+	return NewPromptPassPhraser(defaultPassPhrasePrompt)
+}
+
+// NewPromptPassPhraser implements PassPhraser that reads pass-phrase
+// from terminal's Stdin showing the given prompt
+func NewPromptPassPhraser(prompt string) PassPhraser {
 	return &interactivePassPhraser{
+		prompt: prompt,
 		reader: os.Stdin,
 		writer: os.Stdout,
 	}
@@ -195,12 +246,49 @@ func NewSilentPrinter() Printer { return new(silentPrinter) }
 // DefaultKeyOpener return KeyOpener configured for using with pre-defined pass-phrase or
 // retrieve pass-phrase interactively
 func DefaultKeyOpener(p Printer, keyDir, passPhrase string) (KeyOpener, error) {
+	return DefaultAccountKeyOpener(p, keyDir, "", passPhrase)
+}
+
+// DefaultAccountKeyOpener is the same as DefaultKeyOpener, but opens the key
+// of given account instead of default one.
+func DefaultAccountKeyOpener(p Printer, keyDir, account, passPhrase string) (KeyOpener, error) {
+	keyDir, err := ensureKeyStoreDir(p, keyDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// ask for pass-phrase if not specified in config
+	var pf PassPhraser
+	if passPhrase == "" {
+		pf = NewInteractivePassPhraser()
+	} else {
+		pf = NewStaticPassPhraser(passPhrase)
+	}
+
+	ko := NewAccountKeyOpener(keyDir, account, pf)
+	return ko, nil
+}
+
+// DefaultAccountManager returns AccountManager for the given keystore dir,
+// using the default location when the dir is not specified.
+func DefaultAccountManager(p Printer, keyDir string) (AccountManager, error) {
+	keyDir, err := ensureKeyStoreDir(p, keyDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAccountManager(keyDir)
+}
+
+// ensureKeyStoreDir resolves keystore directory path and creates
+// the directory if it does not exist.
+func ensureKeyStoreDir(p Printer, keyDir string) (string, error) {
 	var err error
 	// use default key store dir if not specified in config
 	if keyDir == "" {
 		keyDir, err = getDefaultKeyStorePath()
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 	}
 
@@ -210,20 +298,11 @@ func DefaultKeyOpener(p Printer, keyDir, passPhrase string) (KeyOpener, error) {
 		p.Printf("KeyStore directory does not exist, try to create it...\r\n")
 		err = os.MkdirAll(keyDir, 0700)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 	}
 
-	// ask for pass-phrase if not specified in config
-	var pf PassPhraser
-	if passPhrase == "" {
-		pf = NewInteractivePassPhraser()
-	} else {
-		pf = NewStaticPassPhraser(passPhrase)
-	}
-
-	ko := NewKeyOpener(keyDir, pf)
-	return ko, nil
+	return keyDir, nil
 }
 
 func getDefaultKeyStorePath() (string, error) {
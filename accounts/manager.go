@@ -0,0 +1,179 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/sonm-io/core/util"
+)
+
+// defaultAccountFile is the name of the file that keeps the address of the
+// account used by default. The keystore ignores dot-files, so it can safely
+// live right within the keystore directory.
+const defaultAccountFile = ".default"
+
+// AccountManager manages a set of Eth accounts stored in the same keystore.
+type AccountManager interface {
+	// List returns addresses of all accounts found in the keystore.
+	List() []common.Address
+	// New creates a new account protected with given pass phrase.
+	New(passphrase string) (common.Address, error)
+	// Import imports an account from the given JSON key decrypting it with
+	// passphrase and encrypting back using newPassphrase.
+	Import(keyJSON []byte, passphrase, newPassphrase string) (common.Address, error)
+	// Export returns JSON key of the given account encrypted with newPassphrase.
+	Export(addr common.Address, passphrase, newPassphrase string) ([]byte, error)
+	// Update changes pass phrase of the given account.
+	Update(addr common.Address, passphrase, newPassphrase string) error
+	// GetKey decrypts and returns private key of the given account.
+	GetKey(addr common.Address, passphrase string) (*ecdsa.PrivateKey, error)
+	// Default returns address of the account used by default, which is
+	// either the account selected via SetDefault or the first one found.
+	Default() (common.Address, error)
+	// SetDefault selects the account to be used by default.
+	SetDefault(addr common.Address) error
+}
+
+type accountManager struct {
+	keyDir   string
+	keystore *keystore.KeyStore
+}
+
+// NewAccountManager returns AccountManager that operates on keys
+// from the given keystore directory.
+func NewAccountManager(keyDir string) (AccountManager, error) {
+	if !util.DirectoryExists(keyDir) {
+		return nil, errNoKeystoreDir
+	}
+
+	return &accountManager{
+		keyDir:   keyDir,
+		keystore: keystore.NewKeyStore(keyDir, keystore.LightScryptN, keystore.LightScryptP),
+	}, nil
+}
+
+func (m *accountManager) List() []common.Address {
+	var addrs []common.Address
+	for _, acc := range m.keystore.Accounts() {
+		addrs = append(addrs, acc.Address)
+	}
+
+	return addrs
+}
+
+func (m *accountManager) New(passphrase string) (common.Address, error) {
+	acc, err := m.keystore.NewAccount(passphrase)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return acc.Address, nil
+}
+
+func (m *accountManager) Import(keyJSON []byte, passphrase, newPassphrase string) (common.Address, error) {
+	acc, err := m.keystore.Import(keyJSON, passphrase, newPassphrase)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return acc.Address, nil
+}
+
+func (m *accountManager) Export(addr common.Address, passphrase, newPassphrase string) ([]byte, error) {
+	acc, err := m.find(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.keystore.Export(acc, passphrase, newPassphrase)
+}
+
+func (m *accountManager) Update(addr common.Address, passphrase, newPassphrase string) error {
+	acc, err := m.find(addr)
+	if err != nil {
+		return err
+	}
+
+	return m.keystore.Update(acc, passphrase, newPassphrase)
+}
+
+func (m *accountManager) GetKey(addr common.Address, passphrase string) (*ecdsa.PrivateKey, error) {
+	acc, err := m.find(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(acc.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keystore.DecryptKey(data, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.PrivateKey, nil
+}
+
+func (m *accountManager) Default() (common.Address, error) {
+	addr, ok := readDefaultAccount(m.keyDir)
+	if ok && m.keystore.HasAddress(addr) {
+		return addr, nil
+	}
+
+	accs := m.keystore.Accounts()
+	if len(accs) == 0 {
+		return common.Address{}, ErrWalletIsEmpty
+	}
+
+	return accs[0].Address, nil
+}
+
+func (m *accountManager) SetDefault(addr common.Address) error {
+	if _, err := m.find(addr); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(m.keyDir, defaultAccountFile), []byte(addr.Hex()), 0600)
+}
+
+func (m *accountManager) find(addr common.Address) (accounts.Account, error) {
+	acc, err := m.keystore.Find(accounts.Account{Address: addr})
+	if err != nil {
+		return accounts.Account{}, ErrNoSuchAccount
+	}
+
+	return acc, nil
+}
+
+// ParseAddress parses given hex-encoded account address.
+func ParseAddress(s string) (common.Address, error) {
+	if !common.IsHexAddress(s) {
+		return common.Address{}, errors.Errorf("invalid account address: %s", s)
+	}
+
+	return common.HexToAddress(s), nil
+}
+
+// readDefaultAccount reads address of the account selected as default within
+// given keystore directory.
+func readDefaultAccount(keyDir string) (common.Address, bool) {
+	data, err := ioutil.ReadFile(filepath.Join(keyDir, defaultAccountFile))
+	if err != nil {
+		return common.Address{}, false
+	}
+
+	addr, err := ParseAddress(strings.TrimSpace(string(data)))
+	if err != nil {
+		return common.Address{}, false
+	}
+
+	return addr, true
+}
@@ -0,0 +1,137 @@
+package accounts
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAccountManager(t *testing.T) (AccountManager, func()) {
+	dir, err := ioutil.TempDir("", "sonm-test-accounts")
+	require.NoError(t, err)
+
+	m, err := NewAccountManager(dir)
+	require.NoError(t, err)
+
+	return m, func() { os.RemoveAll(dir) }
+}
+
+func TestAccountManager_NoDir(t *testing.T) {
+	_, err := NewAccountManager("/tmp/sonm-test-accounts-non-existent")
+	assert.EqualError(t, err, errNoKeystoreDir.Error())
+}
+
+func TestAccountManager_DefaultIsFirst(t *testing.T) {
+	m, cleanup := newTestAccountManager(t)
+	defer cleanup()
+
+	_, err := m.Default()
+	assert.Equal(t, ErrWalletIsEmpty, err)
+
+	addr, err := m.New("testme")
+	require.NoError(t, err)
+
+	def, err := m.Default()
+	require.NoError(t, err)
+	assert.Equal(t, addr, def)
+}
+
+func TestAccountManager_SetDefault(t *testing.T) {
+	m, cleanup := newTestAccountManager(t)
+	defer cleanup()
+
+	_, err := m.New("first")
+	require.NoError(t, err)
+	second, err := m.New("second")
+	require.NoError(t, err)
+
+	assert.Len(t, m.List(), 2)
+
+	require.NoError(t, m.SetDefault(second))
+	def, err := m.Default()
+	require.NoError(t, err)
+	assert.Equal(t, second, def)
+
+	err = m.SetDefault(common.HexToAddress("0x7ec8da94172d848ede642b6fdc62a6124a750f44"))
+	assert.Equal(t, ErrNoSuchAccount, err)
+}
+
+func TestAccountManager_UpdateAndGetKey(t *testing.T) {
+	m, cleanup := newTestAccountManager(t)
+	defer cleanup()
+
+	addr, err := m.New("old")
+	require.NoError(t, err)
+
+	require.NoError(t, m.Update(addr, "old", "new"))
+
+	_, err = m.GetKey(addr, "old")
+	assert.Error(t, err)
+
+	key, err := m.GetKey(addr, "new")
+	require.NoError(t, err)
+	assert.NotNil(t, key)
+}
+
+func TestAccountManager_ExportImport(t *testing.T) {
+	src, cleanupSrc := newTestAccountManager(t)
+	defer cleanupSrc()
+	dst, cleanupDst := newTestAccountManager(t)
+	defer cleanupDst()
+
+	addr, err := src.New("testme")
+	require.NoError(t, err)
+
+	data, err := src.Export(addr, "testme", "exported")
+	require.NoError(t, err)
+
+	imported, err := dst.Import(data, "exported", "imported")
+	require.NoError(t, err)
+	assert.Equal(t, addr, imported)
+
+	_, err = dst.GetKey(addr, "imported")
+	assert.NoError(t, err)
+}
+
+func TestKeyOpener_OpensDefaultAccount(t *testing.T) {
+	m, cleanup := newTestAccountManager(t)
+	defer cleanup()
+
+	_, err := m.New("testme")
+	require.NoError(t, err)
+	second, err := m.New("testme")
+	require.NoError(t, err)
+	require.NoError(t, m.SetDefault(second))
+
+	ko := NewKeyOpener(m.(*accountManager).keyDir, NewStaticPassPhraser("testme"))
+	_, err = ko.OpenKeystore()
+	require.NoError(t, err)
+
+	key, err := ko.GetKey()
+	require.NoError(t, err)
+	assert.Equal(t, second, crypto.PubkeyToAddress(key.PublicKey))
+}
+
+func TestKeyOpener_RequestedAccountInEmptyKeystore(t *testing.T) {
+	m, cleanup := newTestAccountManager(t)
+	defer cleanup()
+
+	keyDir := m.(*accountManager).keyDir
+	ko := NewAccountKeyOpener(keyDir, "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD", NewStaticPassPhraser("testme"))
+
+	created, err := ko.OpenKeystore()
+	assert.Equal(t, ErrNoSuchAccount, err)
+	assert.False(t, created)
+
+	_, err = ko.GetKey()
+	assert.Equal(t, ErrWalletNotOpen, err)
+
+	files, err := ioutil.ReadDir(keyDir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
@@ -73,6 +73,10 @@ type Tokener interface {
 	Approve(ctx context.Context, key *ecdsa.PrivateKey, to string, amount *big.Int) (*types.Transaction, error)
 	// Transfer token from caller
 	Transfer(ctx context.Context, key *ecdsa.PrivateKey, to string, amount *big.Int) (*types.Transaction, error)
+	// TransferSigned transfers token from caller, passing the signed transaction
+	// to the given callback before sending it. The transaction is not sent if
+	// the callback fails
+	TransferSigned(ctx context.Context, key *ecdsa.PrivateKey, to string, amount *big.Int, signed func(tx *types.Transaction) error) (*types.Transaction, error)
 	// TransferFrom fallback function for contracts to transfer you allowance
 	TransferFrom(ctx context.Context, key *ecdsa.PrivateKey, from string, to string, amount *big.Int) (*types.Transaction, error)
 
@@ -93,6 +97,9 @@ type Blockchainer interface {
 	Tokener
 	// GetTxOpts return transaction options that used to perform operations into Ethereum blockchain
 	GetTxOpts(ctx context.Context, key *ecdsa.PrivateKey, gasLimit int64) *bind.TransactOpts
+	// GetTransactionReceipt returns the receipt of the mined transaction with the given hash,
+	// ethereum.NotFound is returned while the transaction is pending
+	GetTransactionReceipt(ctx context.Context, hash string) (*types.Receipt, error)
 }
 
 func initEthClient(ethEndpoint *string) (*ethclient.Client, error) {
@@ -117,6 +124,10 @@ func (bch *api) GetTxOpts(ctx context.Context, key *ecdsa.PrivateKey, gasLimit i
 	return opts
 }
 
+func (bch *api) GetTransactionReceipt(ctx context.Context, hash string) (*types.Receipt, error) {
+	return bch.client.TransactionReceipt(ctx, common.HexToHash(hash))
+}
+
 func getCallOptions(ctx context.Context) *bind.CallOpts {
 	return &bind.CallOpts{
 		Pending: true,
@@ -612,6 +623,25 @@ func (bch *api) Transfer(ctx context.Context, key *ecdsa.PrivateKey, to string,
 	return tx, err
 }
 
+func (bch *api) TransferSigned(ctx context.Context, key *ecdsa.PrivateKey, to string, amount *big.Int, signed func(tx *types.Transaction) error) (*types.Transaction, error) {
+	opts := bch.GetTxOpts(ctx, key, 50000)
+	signer := opts.Signer
+	opts.Signer = func(s types.Signer, addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		signedTx, err := signer(s, addr, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := signed(signedTx); err != nil {
+			return nil, err
+		}
+
+		return signedTx, nil
+	}
+
+	return bch.tokenContract.Transfer(opts, common.HexToAddress(to), amount)
+}
+
 func (bch *api) TransferFrom(ctx context.Context, key *ecdsa.PrivateKey, from string, to string, amount *big.Int) (*types.Transaction, error) {
 	opts := bch.GetTxOpts(ctx, key, 50000)
 
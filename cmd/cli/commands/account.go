@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/accounts"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	accountRootCmd.AddCommand(
+		accountListCmd,
+		accountNewCmd,
+		accountImportCmd,
+		accountExportCmd,
+		accountUseCmd,
+		accountPasswdCmd,
+	)
+}
+
+var accountRootCmd = &cobra.Command{
+	Use:   "account",
+	Short: "Manage Ethereum accounts",
+}
+
+// newAccountManager opens account manager on the keystore specified in config.
+func newAccountManager(cmd *cobra.Command) accounts.AccountManager {
+	m, err := accounts.DefaultAccountManager(accounts.NewSilentPrinter(), cfg.KeyStore())
+	if err != nil {
+		showError(cmd, "Cannot open KeyStore", err)
+		os.Exit(1)
+	}
+
+	return m
+}
+
+// readPassPhrase returns pass phrase from config if specified,
+// otherwise asks user interactively showing the given prompt.
+func readPassPhrase(cmd *cobra.Command, prompt string) string {
+	if cfg.PassPhrase() != "" {
+		return cfg.PassPhrase()
+	}
+
+	return readNewPassPhrase(cmd, prompt)
+}
+
+// readNewPassPhrase always asks user for the pass phrase interactively.
+func readNewPassPhrase(cmd *cobra.Command, prompt string) string {
+	pass, err := accounts.NewPromptPassPhraser(prompt).GetPassPhrase()
+	if err != nil {
+		showError(cmd, "Cannot read pass phrase", err)
+		os.Exit(1)
+	}
+
+	return pass
+}
+
+func parseAccountArg(cmd *cobra.Command, arg string) common.Address {
+	addr, err := accounts.ParseAddress(arg)
+	if err != nil {
+		showError(cmd, "Invalid account", err)
+		os.Exit(1)
+	}
+
+	return addr
+}
+
+var accountListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show accounts available in the keystore",
+	Run: func(cmd *cobra.Command, _ []string) {
+		m := newAccountManager(cmd)
+
+		def, err := m.Default()
+		if err != nil && err != accounts.ErrWalletIsEmpty {
+			showError(cmd, "Cannot get default account", err)
+			os.Exit(1)
+		}
+
+		printAccountList(cmd, m.List(), def)
+	},
+}
+
+var accountNewCmd = &cobra.Command{
+	Use:   "new",
+	Short: "Generate new account",
+	Run: func(cmd *cobra.Command, _ []string) {
+		m := newAccountManager(cmd)
+
+		addr, err := m.New(readPassPhrase(cmd, "New key passphrase"))
+		if err != nil {
+			showError(cmd, "Cannot create new account", err)
+			os.Exit(1)
+		}
+
+		printAccount(cmd, addr)
+	},
+}
+
+var accountImportCmd = &cobra.Command{
+	Use:   "import <key.json>",
+	Short: "Import account from the JSON key file",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		m := newAccountManager(cmd)
+
+		data, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			showError(cmd, "Cannot read key file", err)
+			os.Exit(1)
+		}
+
+		pass := readNewPassPhrase(cmd, "Imported key passphrase")
+		addr, err := m.Import(data, pass, readPassPhrase(cmd, "New key passphrase"))
+		if err != nil {
+			showError(cmd, "Cannot import account", err)
+			os.Exit(1)
+		}
+
+		printAccount(cmd, addr)
+	},
+}
+
+var accountExportCmd = &cobra.Command{
+	Use:   "export <addr> <key.json>",
+	Short: "Export account into the JSON key file",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		m := newAccountManager(cmd)
+
+		addr := parseAccountArg(cmd, args[0])
+		pass := readPassPhrase(cmd, "Key passphrase")
+		data, err := m.Export(addr, pass, readNewPassPhrase(cmd, "Exported key passphrase"))
+		if err != nil {
+			showError(cmd, "Cannot export account", err)
+			os.Exit(1)
+		}
+
+		if err := ioutil.WriteFile(args[1], data, 0600); err != nil {
+			showError(cmd, "Cannot write key file", err)
+			os.Exit(1)
+		}
+
+		showOk(cmd)
+	},
+}
+
+var accountUseCmd = &cobra.Command{
+	Use:   "use <addr>",
+	Short: "Select account used by default",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		m := newAccountManager(cmd)
+
+		addr := parseAccountArg(cmd, args[0])
+		if err := m.SetDefault(addr); err != nil {
+			showError(cmd, "Cannot select default account", err)
+			os.Exit(1)
+		}
+
+		showOk(cmd)
+	},
+}
+
+var accountPasswdCmd = &cobra.Command{
+	Use:   "passwd <addr>",
+	Short: "Change account pass phrase",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		m := newAccountManager(cmd)
+
+		addr := parseAccountArg(cmd, args[0])
+		pass := readPassPhrase(cmd, "Current key passphrase")
+		if err := m.Update(addr, pass, readNewPassPhrase(cmd, "New key passphrase")); err != nil {
+			showError(cmd, "Cannot change pass phrase", err)
+			os.Exit(1)
+		}
+
+		showOk(cmd)
+	},
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"github.com/sonm-io/core/insonmnia/node"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util/xgrpc"
 	"golang.org/x/net/context"
@@ -9,9 +10,14 @@ import (
 
 // newClientConn provides a single point for gPRC's ClientConn configuration.
 //
-// Note that `timeoutFlag` and `nodeAddressFlag` are set implicitly because it is global for all CLI-related stuff.
+// Note that `timeoutFlag`, `nodeAddressFlag` and `accountFlag` are set implicitly because it is global for all CLI-related stuff.
 func newClientConn(ctx context.Context) (*grpc.ClientConn, error) {
-	return xgrpc.NewClient(ctx, nodeAddressFlag, nil)
+	var opts []grpc.DialOption
+	if accountFlag != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(node.NewAccountCredentials(accountFlag)))
+	}
+
+	return xgrpc.NewClient(ctx, nodeAddressFlag, nil, opts...)
 }
 
 func newHubManagementClient(ctx context.Context) (pb.HubManagementClient, error) {
@@ -41,6 +47,15 @@ func newDealsClient(ctx context.Context) (pb.DealManagementClient, error) {
 	return pb.NewDealManagementClient(cc), nil
 }
 
+func newMarketAnalyticsClient(ctx context.Context) (pb.MarketAnalyticsClient, error) {
+	cc, err := newClientConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.NewMarketAnalyticsClient(cc), nil
+}
+
 func newTaskClient(ctx context.Context) (pb.TaskManagementClient, error) {
 	cc, err := newClientConn(ctx)
 	if err != nil {
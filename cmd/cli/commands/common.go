@@ -30,6 +30,7 @@ var (
 	// flags var
 	nodeAddressFlag string
 	outputModeFlag  string
+	accountFlag     string
 	timeoutFlag     = 60 * time.Second
 
 	// logging flag vars
@@ -52,8 +53,9 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&nodeAddressFlag, "node", "localhost:15030", "node endpoint")
 	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", 60*time.Second, "Connection timeout")
 	rootCmd.PersistentFlags().StringVar(&outputModeFlag, "out", "", "Output mode: simple or json")
+	rootCmd.PersistentFlags().StringVar(&accountFlag, "account", "", "Eth address of the account to act on behalf of, using default account if empty")
 
-	rootCmd.AddCommand(hubRootCmd, marketRootCmd, nodeDealsRootCmd, taskRootCmd)
+	rootCmd.AddCommand(hubRootCmd, marketRootCmd, nodeDealsRootCmd, taskRootCmd, accountRootCmd)
 	rootCmd.AddCommand(loginCmd, approveTokenCmd, getTokenCmd, versionCmd, autoCompleteCmd)
 }
 
@@ -142,7 +144,7 @@ func isSimpleFormat() bool {
 // Function loads and opens keystore. Also, storing opened key in "sessionKey" var
 // to be able to reuse it into cli during one session.
 func loadKeyStoreWrapper(cmd *cobra.Command, _ []string) {
-	ko, err := accounts.DefaultKeyOpener(accounts.NewSilentPrinter(), cfg.KeyStore(), cfg.PassPhrase())
+	ko, err := accounts.DefaultAccountKeyOpener(accounts.NewSilentPrinter(), cfg.KeyStore(), accountFlag, cfg.PassPhrase())
 	if err != nil {
 		showError(cmd, err.Error(), nil)
 		os.Exit(1)
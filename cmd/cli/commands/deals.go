@@ -12,8 +12,10 @@ import (
 )
 
 var (
-	dealListFlagFrom   string
-	dealListFlagStatus string
+	dealListFlagFrom     string
+	dealListFlagStatus   string
+	dealCloseFlagReason  string
+	dealAutoRenewDisable bool
 )
 
 func init() {
@@ -21,11 +23,18 @@ func init() {
 		"Transactions author, using self address if empty")
 	dealsListCmd.PersistentFlags().StringVar(&dealListFlagStatus, "status", "ANY",
 		"Transaction status (ANY, PENDING, ACCEPTED, CLOSED)")
+	dealsCloseCmd.PersistentFlags().StringVar(&dealCloseFlagReason, "reason", "",
+		"Reason to close the deal before its end time")
+	dealsAutoRenewCmd.PersistentFlags().BoolVar(&dealAutoRenewDisable, "disable", false,
+		"Stop renewing the deal automatically")
 
 	nodeDealsRootCmd.AddCommand(
 		dealsListCmd,
 		dealsStatusCmd,
 		dealsFinishCmd,
+		dealsCloseCmd,
+		dealsExtendCmd,
+		dealsAutoRenewCmd,
 	)
 }
 
@@ -62,7 +71,7 @@ var dealsListCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		printDealsList(cmd, deals.GetDeal())
+		printDealsList(cmd, deals)
 	},
 }
 
@@ -123,6 +132,93 @@ var dealsFinishCmd = &cobra.Command{
 	},
 }
 
+var dealsCloseCmd = &cobra.Command{
+	Use:   "close <deal_id>",
+	Short: "Close deal before its end time",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		dealer, err := newDealsClient(ctx)
+		if err != nil {
+			showError(cmd, "Cannot create client connection", err)
+			os.Exit(1)
+		}
+
+		id := args[0]
+		_, err = util.ParseBigInt(id)
+		if err != nil {
+			showError(cmd, "Cannot convert arg to number", err)
+			os.Exit(1)
+		}
+
+		settlement, err := dealer.Close(ctx, &pb.DealCloseRequest{Id: id, Reason: dealCloseFlagReason})
+		if err != nil {
+			showError(cmd, "Cannot close deal", err)
+			os.Exit(1)
+		}
+
+		printDealSettlement(cmd, settlement)
+	},
+}
+
+var dealsExtendCmd = &cobra.Command{
+	Use:   "extend <deal_id>",
+	Short: "Extend deal keeping its tasks running",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		dealer, err := newDealsClient(ctx)
+		if err != nil {
+			showError(cmd, "Cannot create client connection", err)
+			os.Exit(1)
+		}
+
+		id := args[0]
+		_, err = util.ParseBigInt(id)
+		if err != nil {
+			showError(cmd, "Cannot convert arg to number", err)
+			os.Exit(1)
+		}
+
+		continuation, err := dealer.Extend(ctx, &pb.DealExtendRequest{Id: id})
+		if err != nil {
+			showError(cmd, "Cannot extend deal", err)
+			os.Exit(1)
+		}
+
+		printID(cmd, continuation.GetId())
+	},
+}
+
+var dealsAutoRenewCmd = &cobra.Command{
+	Use:   "auto-renew <deal_id>",
+	Short: "Extend deal automatically while balance and allowance permit",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		dealer, err := newDealsClient(ctx)
+		if err != nil {
+			showError(cmd, "Cannot create client connection", err)
+			os.Exit(1)
+		}
+
+		id := args[0]
+		_, err = util.ParseBigInt(id)
+		if err != nil {
+			showError(cmd, "Cannot convert arg to number", err)
+			os.Exit(1)
+		}
+
+		_, err = dealer.AutoRenew(ctx, &pb.DealAutoRenewRequest{Id: id, AutoRenew: !dealAutoRenewDisable})
+		if err != nil {
+			showError(cmd, "Cannot change deal renewal", err)
+			os.Exit(1)
+		}
+
+		showOk(cmd)
+	},
+}
+
 func convertTransactionStatus(s string) pb.DealStatus {
 	s = strings.ToUpper(s)
 	// looks stupid, but more convenient to use and easy to type
@@ -16,6 +16,7 @@ func init() {
 		hubOrderRootCmd,
 		hubTasksRootCmd,
 		hubDeviceRootCmd,
+		hubRoutesCmd,
 	)
 }
 
@@ -44,3 +45,24 @@ var hubStatusCmd = &cobra.Command{
 		printHubStatus(cmd, status)
 	},
 }
+
+var hubRoutesCmd = &cobra.Command{
+	Use:   "routes",
+	Short: "Show routes of task services exposed by the hub",
+	Run: func(cmd *cobra.Command, _ []string) {
+		ctx := context.Background()
+		hub, err := newHubManagementClient(ctx)
+		if err != nil {
+			showError(cmd, "Cannot create client connection", err)
+			os.Exit(1)
+		}
+
+		routes, err := hub.Routes(ctx, &pb.Empty{})
+		if err != nil {
+			showError(cmd, "Cannot get hub routes", err)
+			os.Exit(1)
+		}
+
+		printHubRoutes(cmd, routes)
+	},
+}
@@ -13,7 +13,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var hubOrderPricingFlag string
+
 func init() {
+	hubOrderCreateCmd.PersistentFlags().StringVar(&hubOrderPricingFlag, "pricing", "",
+		"Path to pricing policy definition, the plan price is fixed if omitted")
+
 	hubOrderRootCmd.AddCommand(
 		hubOrderListCmd,
 		hubOrderCreateCmd,
@@ -84,6 +89,16 @@ var hubOrderCreateCmd = &cobra.Command{
 			req.BuyerID = addr.Hex()
 		}
 
+		if len(hubOrderPricingFlag) > 0 {
+			pricing, err := loadPricingFile(hubOrderPricingFlag)
+			if err != nil {
+				showError(cmd, "Cannot load pricing policy definition", err)
+				os.Exit(1)
+			}
+
+			req.Pricing = pricing
+		}
+
 		id, err := hub.CreateAskPlan(ctx, req)
 		if err != nil {
 			showError(cmd, "Cannot create new AskOrder", err)
@@ -132,6 +147,16 @@ func loadSlotFile(path string) (*structs.Slot, error) {
 	return slot, nil
 }
 
+func loadPricingFile(path string) (*pb.PricingPolicy, error) {
+	cfg := task_config.PricingConfig{}
+	err := util.LoadYamlFile(path, &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.IntoPricingPolicy()
+}
+
 func loadPropsFile(path string) (map[string]float64, error) {
 	props := map[string]float64{}
 	err := util.LoadYamlFile(path, &props)
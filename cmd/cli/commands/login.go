@@ -12,7 +12,7 @@ var loginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Open or generate Etherum keys",
 	Run: func(cmd *cobra.Command, _ []string) {
-		ko, err := accounts.DefaultKeyOpener(cmd, cfg.KeyStore(), cfg.PassPhrase())
+		ko, err := accounts.DefaultAccountKeyOpener(cmd, cfg.KeyStore(), accountFlag, cfg.PassPhrase())
 		if err != nil {
 			showError(cmd, "Cannot init KeyOpener", err)
 			os.Exit(1)
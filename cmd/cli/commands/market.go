@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/sonm-io/core/insonmnia/structs"
@@ -14,6 +15,10 @@ import (
 var (
 	ordersSearchLimit uint64 = 0
 	orderSearchType          = "ANY"
+	orderCreateCount  uint64 = 1
+	statsOrderType           = "ASK"
+	statsPeriod              = 24 * time.Hour
+	statsStep                = time.Hour
 )
 
 func init() {
@@ -21,6 +26,15 @@ func init() {
 		"Orders type to search: BID or ASK")
 	marketSearchCmd.PersistentFlags().Uint64Var(&ordersSearchLimit, "limit", 10,
 		"Orders count to show")
+	marketCreteCmd.PersistentFlags().Uint64Var(&orderCreateCount, "count", 1,
+		"Slots count that must be dealt at once, all or nothing")
+
+	marketStatsCmd.PersistentFlags().StringVar(&statsOrderType, "type", "ASK",
+		"Orders type to analyze: BID or ASK")
+	marketStatsCmd.PersistentFlags().DurationVar(&statsPeriod, "period", 24*time.Hour,
+		"How far back to show prices history")
+	marketStatsCmd.PersistentFlags().DurationVar(&statsStep, "step", time.Hour,
+		"Prices history resolution")
 
 	marketRootCmd.AddCommand(
 		marketSearchCmd,
@@ -28,6 +42,7 @@ func init() {
 		marketCreteCmd,
 		marketCancelCmd,
 		marketProcessingCmd,
+		marketStatsCmd,
 	)
 }
 
@@ -154,6 +169,7 @@ var marketCreteCmd = &cobra.Command{
 			PricePerSecond: pb.NewBigInt(bigPrice),
 			Slot:           slot.Unwrap(),
 			OrderType:      pb.OrderType_BID,
+			Count:          orderCreateCount,
 		}
 
 		if len(args) > 2 {
@@ -192,3 +208,55 @@ var marketCancelCmd = &cobra.Command{
 		showOk(cmd)
 	},
 }
+
+var marketStatsCmd = &cobra.Command{
+	Use:   "stats [slot.yaml]",
+	Short: "Show prices history and supply depth per resource class",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		analytics, err := newMarketAnalyticsClient(ctx)
+		if err != nil {
+			showError(cmd, "Cannot create client connection", err)
+			os.Exit(1)
+		}
+
+		ordType, err := structs.ParseOrderType(statsOrderType)
+		if err != nil || ordType == pb.OrderType_ANY {
+			showError(cmd, "Cannot parse order type", err)
+			os.Exit(1)
+		}
+
+		now := time.Now()
+		req := &pb.MarketStatsRequest{
+			OrderType: ordType,
+			From:      &pb.Timestamp{Seconds: now.Add(-statsPeriod).Unix()},
+			To:        &pb.Timestamp{Seconds: now.Unix()},
+			Step:      uint64(statsStep.Seconds()),
+		}
+
+		if len(args) > 0 {
+			slot, err := loadSlotFile(args[0])
+			if err != nil {
+				showError(cmd, "Cannot parse slot file", err)
+				os.Exit(1)
+			}
+
+			req.Resources = slot.Unwrap().GetResources()
+		}
+
+		history, err := analytics.PriceHistory(ctx, req)
+		if err != nil {
+			showError(cmd, "Cannot get prices history", err)
+			os.Exit(1)
+		}
+
+		depth, err := analytics.SupplyDepth(ctx, req)
+		if err != nil {
+			showError(cmd, "Cannot get supply depth", err)
+			os.Exit(1)
+		}
+
+		printMarketStats(cmd, history, depth)
+	},
+}
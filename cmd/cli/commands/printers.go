@@ -10,6 +10,7 @@ import (
 
 	ds "github.com/c2h5oh/datasize"
 	"github.com/docker/go-connections/nat"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/sonm-io/core/insonmnia/node"
 	pb "github.com/sonm-io/core/proto"
@@ -32,6 +33,9 @@ func printTaskStatus(cmd *cobra.Command, id string, taskStatus *pb.TaskStatusRep
 		cmd.Printf("  Image:  %s\r\n", taskStatus.GetImageName())
 		cmd.Printf("  Status: %s\r\n", taskStatus.GetStatus().String())
 		cmd.Printf("  Uptime: %s\r\n", time.Duration(taskStatus.GetUptime()).String())
+		if len(taskStatus.GetSidecars()) > 0 {
+			cmd.Printf("  Sidecars: %s\r\n", strings.Join(taskStatus.GetSidecars(), ", "))
+		}
 
 		if taskStatus.GetUsage() != nil {
 			cmd.Println("  Resources:")
@@ -57,6 +61,21 @@ func printTaskStatus(cmd *cobra.Command, id string, taskStatus *pb.TaskStatusRep
 				}
 			}
 		}
+
+		if len(taskStatus.GetHealth()) > 0 {
+			cmd.Printf("  Health:\r\n")
+			for _, health := range taskStatus.GetHealth() {
+				state := "healthy"
+				if !health.GetHealthy() {
+					state = "unhealthy"
+				}
+
+				cmd.Printf("    %s: %s %s (weight %d)\r\n", health.GetPort(), health.GetEndpoint(), state, health.GetWeight())
+				if health.GetError() != "" {
+					cmd.Printf("      Last error: %s\r\n", health.GetError())
+				}
+			}
+		}
 	} else {
 		v := map[string]interface{}{
 			"id":     id,
@@ -71,6 +90,9 @@ func printTaskStatus(cmd *cobra.Command, id string, taskStatus *pb.TaskStatusRep
 			v["mem"] = fmt.Sprintf("%d", taskStatus.GetUsage().GetMemory().GetMaxUsage())
 			v["net"] = taskStatus.GetUsage().GetNetwork()
 		}
+		if len(taskStatus.GetHealth()) > 0 {
+			v["health"] = taskStatus.GetHealth()
+		}
 
 		showJSON(cmd, v)
 	}
@@ -196,6 +218,23 @@ func printHubStatus(cmd *cobra.Command, stat *pb.HubStatusReply) {
 	}
 }
 
+func printHubRoutes(cmd *cobra.Command, reply *pb.RoutesReply) {
+	if isSimpleFormat() {
+		if len(reply.GetRoutes()) == 0 {
+			cmd.Printf("No routes registered.\r\n")
+			return
+		}
+
+		for _, route := range reply.GetRoutes() {
+			cmd.Printf("Task %s on worker %s:\r\n", route.GetTaskID(), route.GetMinerID())
+			cmd.Printf("  %s -> %s:%d -> %s:%d\r\n", route.GetContainerPort(),
+				route.GetHost(), route.GetPort(), route.GetBackendHost(), route.GetBackendPort())
+		}
+	} else {
+		showJSON(cmd, reply)
+	}
+}
+
 func printDeviceList(cmd *cobra.Command, devices *pb.DevicesReply) {
 	if isSimpleFormat() {
 		CPUs := devices.GetCPUs()
@@ -294,11 +333,48 @@ func printSearchResults(cmd *cobra.Command, orders []*pb.Order) {
 	}
 }
 
+func printMarketStats(cmd *cobra.Command, history *pb.PriceHistoryReply, depth *pb.SupplyDepthReply) {
+	if isSimpleFormat() {
+		if len(history.GetPoints()) == 0 {
+			cmd.Printf("No prices history found\r\n")
+		}
+
+		for _, point := range history.GetPoints() {
+			cmd.Printf("%s\r\n", point.GetTimestamp().Unix().Format(time.RFC822))
+			for _, stats := range point.GetStats() {
+				cmd.Printf("  %s: count = %d | min = %s | p50 = %s | p90 = %s | max = %s\r\n",
+					resourceClassString(stats.GetClass()), stats.GetCount(),
+					stats.GetMin().ToPriceString(), stats.GetP50().ToPriceString(),
+					stats.GetP90().ToPriceString(), stats.GetMax().ToPriceString())
+			}
+		}
+
+		cmd.Println()
+		cmd.Printf("Supply depth at %s\r\n", depth.GetTimestamp().Unix().Format(time.RFC822))
+		for _, classDepth := range depth.GetDepth() {
+			cmd.Printf("  %s: owners = %d\r\n", resourceClassString(classDepth.GetClass()), classDepth.GetOwners())
+			for _, level := range classDepth.GetLevels() {
+				cmd.Printf("    price = %s | orders = %d\r\n", level.GetPricePerSecond().ToPriceString(), level.GetCount())
+			}
+		}
+	} else {
+		showJSON(cmd, map[string]interface{}{"history": history, "depth": depth})
+	}
+}
+
+func resourceClassString(class *pb.ResourceClass) string {
+	return fmt.Sprintf("CPU %d, RAM %s, GPU %s",
+		class.GetCpuCores(), ds.ByteSize(class.GetRamBytes()).HR(), class.GetGpuCount().String())
+}
+
 func printOrderDetails(cmd *cobra.Command, order *pb.Order) {
 	if isSimpleFormat() {
 		cmd.Printf("ID:             %s\r\n", order.Id)
 		cmd.Printf("Type:           %s\r\n", order.OrderType.String())
 		cmd.Printf("Price:          %s\r\n", order.PricePerSecond.ToPriceString())
+		if order.GetCount() > 1 {
+			cmd.Printf("Count:          %d\r\n", order.GetCount())
+		}
 
 		cmd.Printf("SupplierID:     %s\r\n", order.SupplierID)
 		cmd.Printf("BuyerID:        %s\r\n", order.ByuerID)
@@ -393,7 +469,8 @@ func printVersion(cmd *cobra.Command, v string) {
 	}
 }
 
-func printDealsList(cmd *cobra.Command, deals []*pb.Deal) {
+func printDealsList(cmd *cobra.Command, reply *pb.DealListReply) {
+	deals := reply.GetDeal()
 	if isSimpleFormat() {
 		if len(deals) == 0 {
 			cmd.Println("No deals found")
@@ -404,8 +481,12 @@ func printDealsList(cmd *cobra.Command, deals []*pb.Deal) {
 			printDealInfo(cmd, deal)
 			cmd.Println()
 		}
+
+		for _, group := range reply.GetGroups() {
+			cmd.Printf("Group:    %s (%s)\r\n", group.GetBidID(), strings.Join(group.GetDealIDs(), ", "))
+		}
 	} else {
-		showJSON(cmd, map[string]interface{}{"deals": deals})
+		showJSON(cmd, map[string]interface{}{"deals": deals, "groups": reply.GetGroups()})
 	}
 
 }
@@ -476,6 +557,14 @@ func printDealDetails(cmd *cobra.Command, d *pb.DealStatusReply) {
 		printDealInfo(cmd, d.GetDeal())
 	}
 
+	if d.GetAutoRenew() {
+		cmd.Printf("Auto renew: enabled\r\n")
+	}
+
+	if len(d.GetInfo().GetExtensions()) > 0 {
+		cmd.Printf("Extensions: %s\r\n", strings.Join(d.GetInfo().GetExtensions(), ", "))
+	}
+
 	if d.GetInfo().GetOrder() != nil {
 		cmd.Printf("\r\n")
 		printOrderResources(cmd, d.GetInfo().GetOrder().GetSlot().GetResources())
@@ -490,4 +579,59 @@ func printDealDetails(cmd *cobra.Command, d *pb.DealStatusReply) {
 		cmd.Printf("\r\nCompleted tasks:\r\n")
 		printDealTasksShort(cmd, d.GetInfo().GetCompleted().GetStatuses())
 	}
+
+	if d.GetSettlement() != nil {
+		cmd.Printf("\r\nTermination requested:\r\n")
+		printDealSettlement(cmd, d.GetSettlement())
+	}
+}
+
+func printDealSettlement(cmd *cobra.Command, s *pb.DealSettlement) {
+	if isSimpleFormat() {
+		requested := s.GetRequestedAt().Unix()
+		workTime := time.Duration(s.GetWorkTime()) * time.Second
+
+		cmd.Printf("Initiator: %s\r\n", s.GetInitiator())
+		if s.GetReason() != "" {
+			cmd.Printf("Reason:    %s\r\n", s.GetReason())
+		}
+		cmd.Printf("Requested: %s\r\n", requested.Format(time.RFC3339))
+		cmd.Printf("Work time: %s\r\n", workTime.String())
+		cmd.Printf("Amount:    %s\r\n", s.GetAmount().ToPriceString())
+		cmd.Printf("Refund:    %s\r\n", s.GetRefund().ToPriceString())
+	} else {
+		showJSON(cmd, s)
+	}
+}
+
+func printAccountList(cmd *cobra.Command, addrs []common.Address, def common.Address) {
+	if isSimpleFormat() {
+		if len(addrs) == 0 {
+			cmd.Println("No accounts found")
+			return
+		}
+
+		for _, addr := range addrs {
+			mark := " "
+			if addr == def {
+				mark = "*"
+			}
+			cmd.Printf("%s %s\r\n", mark, addr.Hex())
+		}
+	} else {
+		list := make([]string, 0, len(addrs))
+		for _, addr := range addrs {
+			list = append(list, addr.Hex())
+		}
+
+		showJSON(cmd, map[string]interface{}{"accounts": list, "default": def.Hex()})
+	}
+}
+
+func printAccount(cmd *cobra.Command, addr common.Address) {
+	if isSimpleFormat() {
+		cmd.Printf("Eth address: %s\r\n", addr.Hex())
+	} else {
+		showJSON(cmd, map[string]string{"address": addr.Hex()})
+	}
 }
@@ -0,0 +1,338 @@
+package commands
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pb "github.com/sonm-io/core/proto"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+func init() {
+	taskRootCmd.AddCommand(taskCopyCmd)
+}
+
+var taskCopyCmd = &cobra.Command{
+	Use:   "cp <hub_addr> <src> <dst>",
+	Short: "Copy files between the task container and the local filesystem",
+	Long: `Copy files between the task container and the local filesystem. The
+container path is specified as <task_id>:<path>, exactly one of source and
+destination must be a container path.
+
+Source files or directories are copied into the destination directory, which
+must exist in the container and is created locally when missing.`,
+	Args: cobra.MinimumNArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		node, err := newTaskClient(ctx)
+		if err != nil {
+			showError(cmd, "Cannot connect to Node", err)
+			os.Exit(1)
+		}
+
+		hubAddr := args[0]
+		srcTask, srcPath := splitCopyPath(args[1])
+		dstTask, dstPath := splitCopyPath(args[2])
+
+		switch {
+		case srcTask == "" && dstTask != "":
+			err = copyToTask(ctx, node, hubAddr, dstTask, srcPath, dstPath)
+		case srcTask != "" && dstTask == "":
+			err = copyFromTask(ctx, node, hubAddr, srcTask, srcPath, dstPath)
+		default:
+			err = errors.New("exactly one of source and destination must be a container path")
+		}
+
+		if err != nil {
+			showError(cmd, "Cannot copy files", err)
+			os.Exit(1)
+		}
+
+		showOk(cmd)
+	},
+}
+
+// splitCopyPath splits "<task_id>:<path>" container path into the task ID
+// and the path. Local paths are returned with empty task ID.
+func splitCopyPath(arg string) (string, string) {
+	if filepath.IsAbs(arg) || strings.HasPrefix(arg, ".") {
+		return "", arg
+	}
+
+	parts := strings.SplitN(arg, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", arg
+	}
+
+	return parts[0], parts[1]
+}
+
+func copyToTask(ctx context.Context, node pb.TaskManagementClient, hubAddr, taskID, src, dst string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("hub", hubAddr, "task", taskID, "path", dst))
+	stream, err := node.CopyTo(ctx)
+	if err != nil {
+		return err
+	}
+
+	rd, wr := io.Pipe()
+	defer rd.Close()
+
+	go func() {
+		wr.CloseWithError(tarPath(wr, src))
+	}()
+
+	sent := int64(0)
+	sendErr := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 1*1024*1024)
+		for {
+			n, err := rd.Read(buf)
+			if n > 0 {
+				if err := stream.Send(&pb.Chunk{Chunk: buf[:n]}); err != nil {
+					sendErr <- err
+					return
+				}
+				sent += int64(n)
+			}
+
+			if err == io.EOF {
+				sendErr <- stream.CloseSend()
+				return
+			}
+
+			if err != nil {
+				sendErr <- err
+				cancel()
+				return
+			}
+		}
+	}()
+
+	committed := int64(0)
+	for {
+		progress, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			select {
+			case err := <-sendErr:
+				if err != nil {
+					return err
+				}
+			default:
+			}
+
+			return err
+		}
+
+		committed += progress.Size
+	}
+
+	if err := <-sendErr; err != nil {
+		return err
+	}
+
+	if committed != sent {
+		return fmt.Errorf("worker committed %d of %d bytes", committed, sent)
+	}
+
+	return nil
+}
+
+func copyFromTask(ctx context.Context, node pb.TaskManagementClient, hubAddr, taskID, src, dst string) error {
+	stream, err := node.CopyFrom(ctx, &pb.CopyFromRequest{
+		Id:      taskID,
+		HubAddr: hubAddr,
+		Path:    src,
+	})
+	if err != nil {
+		return err
+	}
+
+	return untar(pb.NewChunkReader(stream), dst)
+}
+
+// tarPath writes a tar archive of the given file or directory, rooted at
+// its base name.
+func tarPath(w io.Writer, src string) error {
+	src = filepath.Clean(src)
+	base := filepath.Dir(src)
+
+	wr := tar.NewWriter(w)
+
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+
+		name, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+
+		header.Name = filepath.ToSlash(name)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := wr.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(wr, file)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return wr.Close()
+}
+
+// untar extracts the tar archive into the given directory, refusing entries
+// and symbolic links that point outside of it.
+func untar(r io.Reader, dst string) error {
+	dst = filepath.Clean(dst)
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+
+	rd := tar.NewReader(r)
+	for {
+		header, err := rd.Next()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(dst, filepath.FromSlash(header.Name))
+		if !isWithinDir(dst, path) {
+			return fmt.Errorf("archive entry %s points outside of %s", header.Name, dst)
+		}
+
+		// Lexical checks can not tell where previously extracted symlinks
+		// actually point, so entries are never extracted through them.
+		if err := checkNoSymlinks(dst, filepath.Dir(path)); err != nil {
+			return fmt.Errorf("archive entry %s: %v", header.Name, err)
+		}
+
+		mode := os.FileMode(header.Mode).Perm()
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, mode); err != nil {
+				return err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			// Replace the symlink instead of writing through it.
+			if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSymlink != 0 {
+				if err := os.Remove(path); err != nil {
+					return err
+				}
+			}
+
+			if err := untarFile(rd, path, mode); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			target := filepath.Join(filepath.Dir(path), filepath.FromSlash(header.Linkname))
+			if filepath.IsAbs(header.Linkname) || !isWithinDir(dst, target) {
+				return fmt.Errorf("archive entry %s links outside of %s", header.Name, dst)
+			}
+
+			os.Remove(path)
+			if err := os.Symlink(header.Linkname, path); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func isWithinDir(dir, path string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+// checkNoSymlinks returns an error if any existing component of the path
+// below the dir is a symlink.
+func checkNoSymlinks(dir, path string) error {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return err
+	}
+
+	if rel == "." {
+		return nil
+	}
+
+	current := dir
+	for _, name := range strings.Split(rel, string(filepath.Separator)) {
+		current = filepath.Join(current, name)
+
+		info, err := os.Lstat(current)
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("%s is a symlink", current)
+		}
+	}
+
+	return nil
+}
+
+func untarFile(rd io.Reader, path string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, rd)
+	return err
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitCopyPath(t *testing.T) {
+	tests := []struct {
+		arg  string
+		task string
+		path string
+	}{
+		{"task:/data", "task", "/data"},
+		{"/data", "", "/data"},
+		{"./task:data", "", "./task:data"},
+		{"data", "", "data"},
+		{":data", "", ":data"},
+	}
+
+	for _, test := range tests {
+		task, path := splitCopyPath(test.arg)
+		assert.Equal(t, test.task, task, test.arg)
+		assert.Equal(t, test.path, path, test.arg)
+	}
+}
+
+func TestTarUntar(t *testing.T) {
+	dir, err := ioutil.TempDir("", "task_cp")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "src")
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "nested"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "nested", "input.txt"), []byte("input"), 0600))
+
+	archive := &bytes.Buffer{}
+	require.NoError(t, tarPath(archive, src))
+
+	dst := filepath.Join(dir, "dst")
+	require.NoError(t, untar(archive, dst))
+
+	path := filepath.Join(dst, "src", "nested", "input.txt")
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "input", string(data))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestUntarRefusesEscapingEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "task_cp")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, header := range []*tar.Header{
+		{Name: "../escaped", Typeflag: tar.TypeReg, Mode: 0644},
+		{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "../../etc"},
+		{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/etc"},
+	} {
+		archive := &bytes.Buffer{}
+		wr := tar.NewWriter(archive)
+		require.NoError(t, wr.WriteHeader(header))
+		require.NoError(t, wr.Close())
+
+		assert.Error(t, untar(archive, filepath.Join(dir, "dst")), header.Name)
+	}
+
+	_, err = os.Stat(filepath.Join(dir, "escaped"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestUntarRefusesEntriesThroughSymlinks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "task_cp")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Both links pass lexical checks, while "t" actually points to the
+	// parent of the destination.
+	archive := &bytes.Buffer{}
+	wr := tar.NewWriter(archive)
+	require.NoError(t, wr.WriteHeader(&tar.Header{Name: "s", Typeflag: tar.TypeSymlink, Linkname: "."}))
+	require.NoError(t, wr.WriteHeader(&tar.Header{Name: "t", Typeflag: tar.TypeSymlink, Linkname: "s/.."}))
+	require.NoError(t, wr.WriteHeader(&tar.Header{Name: "t/x", Typeflag: tar.TypeReg, Mode: 0644, Size: 1}))
+	_, err = wr.Write([]byte("x"))
+	require.NoError(t, err)
+	require.NoError(t, wr.Close())
+
+	assert.Error(t, untar(archive, filepath.Join(dir, "dst")))
+
+	_, err = os.Stat(filepath.Join(dir, "x"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestUntarReplacesSymlinkWithFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "task_cp")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dst := filepath.Join(dir, "dst")
+	require.NoError(t, os.MkdirAll(dst, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "outside"), []byte("outside"), 0644))
+	require.NoError(t, os.Symlink("../outside", filepath.Join(dst, "link")))
+
+	archive := &bytes.Buffer{}
+	wr := tar.NewWriter(archive)
+	require.NoError(t, wr.WriteHeader(&tar.Header{Name: "link", Typeflag: tar.TypeReg, Mode: 0644, Size: 6}))
+	_, err = wr.Write([]byte("inside"))
+	require.NoError(t, err)
+	require.NoError(t, wr.Close())
+
+	require.NoError(t, untar(archive, dst))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "outside"))
+	require.NoError(t, err)
+	assert.Equal(t, "outside", string(data))
+}
@@ -0,0 +1,249 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	pb "github.com/sonm-io/core/proto"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+var taskExecTTY bool
+
+func init() {
+	taskExecCmd.Flags().BoolVarP(&taskExecTTY, "tty", "t", false, "Allocate a pseudo-TTY")
+
+	taskRootCmd.AddCommand(
+		taskExecCmd,
+		taskForwardCmd,
+	)
+}
+
+var taskExecCmd = &cobra.Command{
+	Use:   "exec <hub_addr> <task_id> [-- <command>...]",
+	Short: "Execute a command inside the task container",
+	Long: `Execute a command inside the task container through the Hub, so the Worker
+is not required to be reachable directly. Without a command an interactive
+login shell is started. Exits with the exit code of the command.`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		node, err := newTaskClient(ctx)
+		if err != nil {
+			showError(cmd, "Cannot connect to Node", err)
+			os.Exit(1)
+		}
+
+		request := &pb.ExecRequest{
+			Id:      args[1],
+			HubAddr: args[0],
+			Cmd:     args[2:],
+			Tty:     taskExecTTY,
+		}
+
+		code, err := execTask(ctx, node, request)
+		if err != nil {
+			showError(cmd, "Cannot execute command", err)
+			os.Exit(1)
+		}
+
+		if code != 0 {
+			os.Exit(code)
+		}
+	},
+}
+
+// execTask relays the command session, returning the exit code of the
+// command.
+func execTask(ctx context.Context, node pb.TaskManagementClient, request *pb.ExecRequest) (int, error) {
+	fd := int(os.Stdin.Fd())
+	tty := request.Tty && terminal.IsTerminal(fd)
+
+	if tty {
+		request.Size = terminalSize(fd)
+		if term := os.Getenv("TERM"); term != "" {
+			request.Env = append(request.Env, "TERM="+term)
+		}
+	}
+
+	stream, err := node.Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Both the input and resize notifications are sent concurrently, while
+	// the stream is not safe for concurrent sending.
+	mu := sync.Mutex{}
+	send := func(request *pb.ExecRequest) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return stream.Send(request)
+	}
+
+	if err := send(request); err != nil {
+		return 0, err
+	}
+
+	if tty {
+		state, err := terminal.MakeRaw(fd)
+		if err != nil {
+			return 0, err
+		}
+		defer terminal.Restore(fd, state)
+
+		resized := make(chan os.Signal, 1)
+		notifyTerminalResize(resized)
+		go func() {
+			for range resized {
+				if size := terminalSize(fd); size != nil {
+					send(&pb.ExecRequest{Size: size})
+				}
+			}
+		}()
+	}
+
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				if err := send(&pb.ExecRequest{Stdin: buf[:n]}); err != nil {
+					return
+				}
+			}
+
+			if err != nil {
+				send(&pb.ExecRequest{StdinClosed: true})
+				return
+			}
+		}
+	}()
+
+	code := 0
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			return code, nil
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		os.Stdout.Write(reply.Stdout)
+		os.Stderr.Write(reply.Stderr)
+
+		if reply.Exit != nil {
+			code = int(reply.Exit.Code)
+		}
+	}
+}
+
+func terminalSize(fd int) *pb.TerminalSize {
+	width, height, err := terminal.GetSize(fd)
+	if err != nil {
+		return nil
+	}
+
+	return &pb.TerminalSize{Width: uint32(width), Height: uint32(height)}
+}
+
+var taskForwardCmd = &cobra.Command{
+	Use:   "forward <hub_addr> <task_id> <[local_addr:]local_port:remote_port>",
+	Short: "Forward a local port to the task port",
+	Long: `Forward connections accepted on the local port to the given TCP port of the
+task container through the Hub, so the Worker is not required to be reachable
+directly. Local address defaults to 127.0.0.1.`,
+	Args: cobra.MinimumNArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		node, err := newTaskClient(ctx)
+		if err != nil {
+			showError(cmd, "Cannot connect to Node", err)
+			os.Exit(1)
+		}
+
+		hubAddr := args[0]
+		taskID := args[1]
+		localAddr, remotePort, err := parsePortForwardSpec(args[2])
+		if err != nil {
+			showError(cmd, "Invalid port forwarding specification", err)
+			os.Exit(1)
+		}
+
+		listener, err := net.Listen("tcp", localAddr)
+		if err != nil {
+			showError(cmd, "Cannot listen on local address", err)
+			os.Exit(1)
+		}
+		defer listener.Close()
+
+		cmd.Printf("Forwarding %s -> %s\n", listener.Addr(), remotePort)
+
+		ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("hub", hubAddr, "task", taskID, "port", remotePort))
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				showError(cmd, "Cannot accept connection", err)
+				os.Exit(1)
+			}
+
+			go func() {
+				if err := forwardConnection(ctx, node, conn); err != nil {
+					showError(cmd, "Cannot forward connection", err)
+				}
+			}()
+		}
+	},
+}
+
+// parsePortForwardSpec parses "[local_addr:]local_port:remote_port"
+// specification, returning the local address to listen on and the task port.
+func parsePortForwardSpec(spec string) (string, string, error) {
+	id := strings.LastIndex(spec, ":")
+	if id < 0 {
+		return "", "", fmt.Errorf("expected local and remote ports separated by colon, got %s", spec)
+	}
+
+	local, remote := spec[:id], spec[id+1:]
+	if local == "" || remote == "" {
+		return "", "", fmt.Errorf("both local and remote ports are required, got %s", spec)
+	}
+
+	if !strings.Contains(local, ":") {
+		local = net.JoinHostPort("127.0.0.1", local)
+	}
+
+	return local, remote, nil
+}
+
+func forwardConnection(ctx context.Context, node pb.TaskManagementClient, conn net.Conn) error {
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := node.PortForward(ctx)
+	if err != nil {
+		return err
+	}
+
+	rw := pb.NewChunkReadWriter(stream)
+
+	go func() {
+		io.Copy(rw, conn)
+		stream.CloseSend()
+	}()
+
+	_, err = io.Copy(conn, rw)
+	return err
+}
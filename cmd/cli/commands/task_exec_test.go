@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePortForwardSpec(t *testing.T) {
+	local, remote, err := parsePortForwardSpec("8080:80")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:8080", local)
+	assert.Equal(t, "80", remote)
+
+	local, remote, err = parsePortForwardSpec("0.0.0.0:2222:22/tcp")
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0.0:2222", local)
+	assert.Equal(t, "22/tcp", remote)
+
+	_, _, err = parsePortForwardSpec("8080")
+	assert.Error(t, err)
+
+	_, _, err = parsePortForwardSpec(":80")
+	assert.Error(t, err)
+}
@@ -0,0 +1,14 @@
+// +build !windows
+
+package commands
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyTerminalResize relays terminal size changes to the given channel.
+func notifyTerminalResize(ch chan<- os.Signal) {
+	signal.Notify(ch, syscall.SIGWINCH)
+}
@@ -0,0 +1,9 @@
+package commands
+
+import (
+	"os"
+)
+
+// notifyTerminalResize does nothing, because there is no way to be notified
+// about terminal size changes on Windows.
+func notifyTerminalResize(ch chan<- os.Signal) {}
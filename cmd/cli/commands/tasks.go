@@ -121,6 +121,29 @@ var taskStartCmd = &cobra.Command{
 			})
 		}
 
+		healthChecks := make([]*pb.HealthCheck, 0)
+		for _, check := range taskDef.HealthChecks() {
+			healthChecks = append(healthChecks, &pb.HealthCheck{
+				Port:      check.Port,
+				Type:      check.Type,
+				Path:      check.Path,
+				Interval:  uint64(check.Interval.Seconds()),
+				Timeout:   uint64(check.Timeout.Seconds()),
+				Threshold: check.Threshold,
+			})
+		}
+
+		sidecars := make([]*pb.Container, 0)
+		for _, sidecar := range taskDef.Sidecars() {
+			sidecars = append(sidecars, &pb.Container{
+				Name:     sidecar.Name,
+				Image:    sidecar.Image,
+				Registry: sidecar.GetRegistryName(),
+				Auth:     sidecar.GetRegistryAuth(),
+				Env:      sidecar.Env,
+			})
+		}
+
 		var req = &pb.HubStartTaskRequest{
 			Deal: deal,
 			Container: &pb.Container{
@@ -133,7 +156,9 @@ var taskStartCmd = &cobra.Command{
 				Volumes:       volumes,
 				Mounts:        taskDef.Mounts(),
 				Networks:      networks,
+				HealthChecks:  healthChecks,
 			},
+			Sidecars: sidecars,
 		}
 
 		reply, err := node.Start(ctx, req)
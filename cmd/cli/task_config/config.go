@@ -4,6 +4,7 @@ import (
 	b64 "encoding/base64"
 	"encoding/json"
 	"os"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/jinzhu/configor"
@@ -22,6 +23,8 @@ type TaskConfig interface {
 	Volumes() map[string]volume
 	Mounts() []string
 	Networks() []network
+	HealthChecks() []healthCheck
+	Sidecars() []sidecar
 }
 
 type container struct {
@@ -33,6 +36,16 @@ type container struct {
 	Volumes      map[string]volume
 	Mounts       []string
 	Networks     []network
+	HealthChecks []healthCheck `yaml:"health_checks" required:"false"`
+}
+
+type healthCheck struct {
+	Port      string        `yaml:"port" required:"true"`
+	Type      string        `yaml:"type" required:"false"`
+	Path      string        `yaml:"path" required:"false"`
+	Interval  time.Duration `yaml:"interval" required:"false"`
+	Timeout   time.Duration `yaml:"timeout" required:"false"`
+	Threshold uint32        `yaml:"threshold" required:"false"`
 }
 
 type volume struct {
@@ -53,9 +66,44 @@ type registry struct {
 	Password string `yaml:"password" required:"false"`
 }
 
+func (r *registry) auth() string {
+	if r == nil {
+		return ""
+	}
+
+	auth := types.AuthConfig{
+		Username:      r.User,
+		Password:      r.Password,
+		ServerAddress: r.Name,
+	}
+	jsonAuth, _ := json.Marshal(auth)
+	return b64.StdEncoding.EncodeToString(jsonAuth)
+}
+
+// sidecar describes a container started together with the main one, sharing
+// its network namespace, volumes and resources.
+type sidecar struct {
+	Name     string            `yaml:"name" required:"true"`
+	Image    string            `yaml:"image" required:"true"`
+	Env      map[string]string `yaml:"env" required:"false"`
+	Registry *registry         `yaml:"registry,flow" required:"false"`
+}
+
+func (s *sidecar) GetRegistryName() string {
+	if s.Registry != nil {
+		return s.Registry.Name
+	}
+	return ""
+}
+
+func (s *sidecar) GetRegistryAuth() string {
+	return s.Registry.auth()
+}
+
 type task struct {
 	Container container `yaml:"container,flow" required:"true"`
 	Registry  *registry `yaml:"registry,flow" required:"false"`
+	Sidecars  []sidecar `yaml:"sidecars" required:"false"`
 }
 
 type YamlConfig struct {
@@ -86,16 +134,7 @@ func (yc *YamlConfig) GetRegistryName() string {
 }
 
 func (yc *YamlConfig) GetRegistryAuth() string {
-	if yc.Task.Registry != nil {
-		auth := types.AuthConfig{
-			Username:      yc.Task.Registry.User,
-			Password:      yc.Task.Registry.Password,
-			ServerAddress: yc.Task.Registry.Name,
-		}
-		jsonAuth, _ := json.Marshal(auth)
-		return b64.StdEncoding.EncodeToString(jsonAuth)
-	}
-	return ""
+	return yc.Task.Registry.auth()
 }
 
 func (yc *YamlConfig) Volumes() map[string]volume {
@@ -110,6 +149,14 @@ func (yc *YamlConfig) Networks() []network {
 	return yc.Task.Container.Networks
 }
 
+func (yc *YamlConfig) HealthChecks() []healthCheck {
+	return yc.Task.Container.HealthChecks
+}
+
+func (yc *YamlConfig) Sidecars() []sidecar {
+	return yc.Task.Sidecars
+}
+
 func LoadConfig(path string) (TaskConfig, error) {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return nil, err
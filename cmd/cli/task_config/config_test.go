@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"encoding/base64"
 	"encoding/json"
@@ -215,3 +216,75 @@ func TestTaskConfigEnv(t *testing.T) {
 	assert.Equal(t, "value1", env["key1"])
 	assert.Equal(t, "value2", env["key2"])
 }
+
+func TestTaskConfigHealthChecks(t *testing.T) {
+	createTestConfigFile(`task:
+  container:
+    name: user/image:v1
+    health_checks:
+      - port: 80/tcp
+        type: http
+        path: /health
+        interval: 30s
+      - port: "22"
+`)
+	defer deleteTestConfigFile()
+
+	cfg, err := LoadConfig(testCfgPath)
+	assert.NoError(t, err)
+
+	checks := cfg.HealthChecks()
+	assert.Len(t, checks, 2)
+
+	assert.Equal(t, "80/tcp", checks[0].Port)
+	assert.Equal(t, "http", checks[0].Type)
+	assert.Equal(t, "/health", checks[0].Path)
+	assert.Equal(t, 30*time.Second, checks[0].Interval)
+	assert.Equal(t, time.Duration(0), checks[0].Timeout)
+
+	assert.Equal(t, "22", checks[1].Port)
+	assert.Empty(t, checks[1].Type)
+}
+
+func TestTaskConfigSidecars(t *testing.T) {
+	createTestConfigFile(`task:
+  container:
+    name: user/image:v1
+  sidecars:
+    - name: logs
+      image: fluent/fluentd:v1.1
+      env:
+        LEVEL: info
+    - name: model
+      image: user/model:v2
+      registry:
+        name: registry.user.dev
+        user: name
+        password: secret
+`)
+	defer deleteTestConfigFile()
+
+	cfg, err := LoadConfig(testCfgPath)
+	assert.NoError(t, err)
+
+	sidecars := cfg.Sidecars()
+	assert.Len(t, sidecars, 2)
+
+	assert.Equal(t, "logs", sidecars[0].Name)
+	assert.Equal(t, "fluent/fluentd:v1.1", sidecars[0].Image)
+	assert.Equal(t, map[string]string{"LEVEL": "info"}, sidecars[0].Env)
+	assert.Equal(t, "", sidecars[0].GetRegistryName())
+	assert.Equal(t, "", sidecars[0].GetRegistryAuth())
+
+	assert.Equal(t, "model", sidecars[1].Name)
+	assert.Equal(t, "registry.user.dev", sidecars[1].GetRegistryName())
+
+	authDecoded, err := base64.StdEncoding.DecodeString(sidecars[1].GetRegistryAuth())
+	assert.NoError(t, err)
+
+	authConfig := types.AuthConfig{}
+	err = json.Unmarshal(authDecoded, &authConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "name", authConfig.Username)
+	assert.Equal(t, "secret", authConfig.Password)
+}
@@ -0,0 +1,59 @@
+package task_config
+
+import (
+	"fmt"
+
+	"github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+)
+
+// PricingConfig describes ask plan pricing policy. All prices are in SNM per
+// second.
+type PricingConfig struct {
+	CpuCore      string            `yaml:"cpu_core"`
+	RamGigabyte  string            `yaml:"ram_gb"`
+	Gpu          map[string]string `yaml:"gpu"`
+	Floor        string            `yaml:"floor"`
+	FollowMarket bool              `yaml:"follow_market"`
+}
+
+func (c *PricingConfig) IntoPricingPolicy() (*sonm.PricingPolicy, error) {
+	var err error
+	policy := &sonm.PricingPolicy{FollowMarket: c.FollowMarket}
+
+	if policy.PerCPUCore, err = parsePrice(c.CpuCore); err != nil {
+		return nil, fmt.Errorf("invalid CPU core price: %v", err)
+	}
+
+	if policy.PerRAMGigabyte, err = parsePrice(c.RamGigabyte); err != nil {
+		return nil, fmt.Errorf("invalid RAM price: %v", err)
+	}
+
+	if policy.Floor, err = parsePrice(c.Floor); err != nil {
+		return nil, fmt.Errorf("invalid floor price: %v", err)
+	}
+
+	if len(c.Gpu) > 0 {
+		policy.PerGPU = make(map[string]*sonm.BigInt, len(c.Gpu))
+		for model, price := range c.Gpu {
+			if policy.PerGPU[model], err = parsePrice(price); err != nil {
+				return nil, fmt.Errorf("invalid %s GPU price: %v", model, err)
+			}
+		}
+	}
+
+	return policy, nil
+}
+
+func parsePrice(price string) (*sonm.BigInt, error) {
+	if len(price) == 0 {
+		return nil, nil
+	}
+
+	v, err := util.StringToEtherPrice(price)
+	if err != nil {
+		return nil, err
+	}
+
+	return sonm.NewBigInt(v), nil
+}
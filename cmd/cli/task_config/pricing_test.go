@@ -0,0 +1,37 @@
+package task_config
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPricingConfig_IntoPricingPolicy(t *testing.T) {
+	c := &PricingConfig{
+		CpuCore:      "1",
+		Gpu:          map[string]string{"GeForce GTX 1080 Ti": "2", "*": "0.5"},
+		Floor:        "3",
+		FollowMarket: true,
+	}
+
+	policy, err := c.IntoPricingPolicy()
+	require.NoError(t, err)
+
+	assert.Equal(t, big.NewInt(params.Ether), policy.GetPerCPUCore().Unwrap())
+	assert.Nil(t, policy.GetPerRAMGigabyte())
+	assert.Equal(t, big.NewInt(2*params.Ether), policy.GetPerGPU()["GeForce GTX 1080 Ti"].Unwrap())
+	assert.Equal(t, big.NewInt(params.Ether/2), policy.GetPerGPU()["*"].Unwrap())
+	assert.Equal(t, big.NewInt(3*params.Ether), policy.GetFloor().Unwrap())
+	assert.True(t, policy.GetFollowMarket())
+}
+
+func TestPricingConfig_IntoPricingPolicy_InvalidPrice(t *testing.T) {
+	c := &PricingConfig{RamGigabyte: "-1"}
+
+	policy, err := c.IntoPricingPolicy()
+	assert.Error(t, err)
+	assert.Nil(t, policy)
+}
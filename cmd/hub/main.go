@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,6 +11,7 @@ import (
 	"github.com/sonm-io/core/cmd"
 	"github.com/sonm-io/core/insonmnia/hub"
 	"github.com/sonm-io/core/insonmnia/logging"
+	"github.com/sonm-io/core/insonmnia/tracing"
 	"github.com/sonm-io/core/util"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
@@ -34,8 +36,20 @@ func run() {
 		os.Exit(1)
 	}
 
-	logger := logging.BuildLogger(cfg.LogLevel())
-	ctx = log.WithLogger(ctx, logger)
+	logger, levels, err := logging.NewLogger(cfg.Logging.Config)
+	if err != nil {
+		fmt.Printf("failed to build logger: %s\r\n", err)
+		os.Exit(1)
+	}
+	ctx = log.WithLogger(ctx, logger.Named("hub"))
+	http.Handle("/logging", levels)
+
+	tracer, err := tracing.Setup("hub", cfg.Tracing)
+	if err != nil {
+		log.G(ctx).Error("failed to set up tracing", zap.Error(err))
+		os.Exit(1)
+	}
+	defer tracer.Close()
 
 	key, err := cfg.Eth.LoadKey()
 	if err != nil {
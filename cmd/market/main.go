@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/cmd"
+	"github.com/sonm-io/core/insonmnia/logging"
+	"github.com/sonm-io/core/insonmnia/market"
+	"github.com/sonm-io/core/util"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	configFlag  string
+	versionFlag bool
+	appVersion  string
+)
+
+func main() {
+	cmd.NewCmd("market", appVersion, &configFlag, &versionFlag, run).Execute()
+}
+
+func run() {
+	logger := logging.BuildLogger(zapcore.DebugLevel)
+	ctx := log.WithLogger(context.Background(), logger)
+
+	cfg, err := market.NewConfig(configFlag)
+	if err != nil {
+		log.G(ctx).Error("failed to load config", zap.Error(err))
+		os.Exit(1)
+	}
+
+	key, err := cfg.Eth.LoadKey()
+	if err != nil {
+		log.G(ctx).Error("failed load private key", zap.Error(err))
+		os.Exit(1)
+	}
+
+	mk, err := market.NewMarket(ctx, cfg, key)
+	if err != nil {
+		log.G(ctx).Error("cannot start Market service", zap.Error(err))
+		os.Exit(1)
+	}
+
+	go util.StartPrometheus(ctx, cfg.MetricsListenAddr)
+
+	log.G(ctx).Info("starting Market service", zap.String("bind_addr", cfg.ListenAddr))
+	if err := mk.Serve(); err != nil {
+		log.G(ctx).Error("cannot start Market service", zap.Error(err))
+		os.Exit(1)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -13,6 +14,7 @@ import (
 	"github.com/sonm-io/core/cmd"
 	"github.com/sonm-io/core/insonmnia/logging"
 	"github.com/sonm-io/core/insonmnia/miner"
+	"github.com/sonm-io/core/insonmnia/tracing"
 	"github.com/sonm-io/core/util"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
@@ -35,8 +37,20 @@ func run() {
 		os.Exit(1)
 	}
 
-	logger := logging.BuildLogger(cfg.LogLevel())
-	ctx := log.WithLogger(context.Background(), logger)
+	logger, levels, err := logging.NewLogger(cfg.Logging())
+	if err != nil {
+		fmt.Printf("cannot build logger: %s\r\n", err)
+		os.Exit(1)
+	}
+	ctx := log.WithLogger(context.Background(), logger.Named("miner"))
+	http.Handle("/logging", levels)
+
+	tracer, err := tracing.Setup("worker", cfg.Tracing())
+	if err != nil {
+		log.G(ctx).Error("cannot set up tracing", zap.Error(err))
+		os.Exit(1)
+	}
+	defer tracer.Close()
 
 	key, err := cfg.ETH().LoadKey()
 	if err != nil {
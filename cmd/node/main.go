@@ -3,6 +3,7 @@ package main
 import (
 	"crypto/ecdsa"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -12,6 +13,7 @@ import (
 	"github.com/sonm-io/core/cmd"
 	"github.com/sonm-io/core/insonmnia/logging"
 	"github.com/sonm-io/core/insonmnia/node"
+	"github.com/sonm-io/core/insonmnia/tracing"
 	"github.com/sonm-io/core/util"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
@@ -34,8 +36,20 @@ func run() {
 		os.Exit(1)
 	}
 
-	logger := logging.BuildLogger(cfg.LogLevel())
-	ctx := log.WithLogger(context.Background(), logger)
+	logger, levels, err := logging.NewLogger(cfg.Logging())
+	if err != nil {
+		fmt.Printf("cannot build logger: %s\r\n", err)
+		os.Exit(1)
+	}
+	ctx := log.WithLogger(context.Background(), logger.Named("node"))
+	http.Handle("/logging", levels)
+
+	tracer, err := tracing.Setup("node", cfg.Tracing())
+	if err != nil {
+		log.G(ctx).Error("cannot set up tracing", zap.Error(err))
+		os.Exit(1)
+	}
+	defer tracer.Close()
 
 	key, err := loadKeys(cfg)
 	if err != nil {
@@ -43,7 +57,13 @@ func run() {
 		os.Exit(1)
 	}
 
-	n, err := node.New(ctx, cfg, key)
+	extraKeys, err := loadAccountKeys(cfg)
+	if err != nil {
+		log.G(ctx).Error("cannot unlock accounts", zap.Error(err))
+		os.Exit(1)
+	}
+
+	n, err := node.New(ctx, cfg, key, extraKeys...)
 	if err != nil {
 		log.G(ctx).Error("cannot build node instance", zap.Error(err))
 		os.Exit(1)
@@ -60,6 +80,7 @@ func run() {
 
 	if err := n.Serve(); err != nil {
 		log.G(ctx).Error("node termination", zap.Error(err))
+		tracer.Close()
 		os.Exit(1)
 	}
 }
@@ -78,3 +99,27 @@ func loadKeys(c node.Config) (*ecdsa.PrivateKey, error) {
 
 	return ko.GetKey()
 }
+
+// loadAccountKeys unlocks additional accounts specified in config.
+func loadAccountKeys(c node.Config) ([]*ecdsa.PrivateKey, error) {
+	var keys []*ecdsa.PrivateKey
+	for _, acc := range c.Accounts() {
+		ko, err := accounts.DefaultAccountKeyOpener(accounts.NewFmtPrinter(), c.KeyStore(), acc.Address, acc.PassPhrase)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := ko.OpenKeystore(); err != nil {
+			return nil, fmt.Errorf("cannot open account %s: %v", acc.Address, err)
+		}
+
+		key, err := ko.GetKey()
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
@@ -10,6 +10,7 @@ import (
 const (
 	DefaultProtocol         = "tcp"
 	DefaultSchedulingMethod = "wrr"
+	DefaultWeight           = 100
 )
 
 // Possible validation errors.
@@ -75,6 +76,12 @@ func NewServiceOptions(host string, port uint16, protocol string) (*ServiceOptio
 	return options, nil
 }
 
+// ServiceState describes a virtual service found in the kernel.
+type ServiceState struct {
+	Options  *ServiceOptions
+	Backends []*RealOptions
+}
+
 // RealOptions describe a virtual service real.
 type RealOptions struct {
 	Host   string
@@ -110,8 +117,21 @@ func NewRealOptions(host string, port uint16, weight int32, vsID string) (*RealO
 	}
 
 	if options.Weight <= 0 {
-		options.Weight = 100
+		options.Weight = DefaultWeight
 	}
 
 	return options, nil
 }
+
+// GetOutboundIP returns the preferred outbound IP address of this host.
+func GetOutboundIP() (net.IP, error) {
+	conn, err := net.Dial("udp", "8.8.8.8:80")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	localAddr := conn.LocalAddr().(*net.UDPAddr)
+
+	return localAddr.IP, nil
+}
@@ -58,8 +58,10 @@ func NewGateway(ctx context.Context) (*Gateway, error) {
 		return nil, ErrIPVSFailed
 	}
 
-	if gateway.ipvs.Flush() != nil {
-		log.G(ctx).Error("failed to clean up IPVS pools - ensure `ip_vs` is loaded")
+	// IPVS pools are not flushed here to allow the Hub to reconcile
+	// services left after restart with its tasks.
+	if _, err := gateway.ipvs.GetPools(); err != nil {
+		log.G(ctx).Error("failed to list IPVS pools - ensure `ip_vs` is loaded")
 		gateway.Close()
 		return nil, ErrIPVSFailed
 	}
@@ -67,9 +69,146 @@ func NewGateway(ctx context.Context) (*Gateway, error) {
 	return gateway, nil
 }
 
-// TODO (3Hren): func (g *Gateway) GetServices() ([]string, error).
-// TODO (3Hren): func (g *Gateway) GetBackends(vsID string) ([]string, error).
-// TODO (3Hren): func (g *Gateway) GetBackend(vsID, rsID string) (*RealOptions, error).
+// GetServices returns IDs of registered virtual services.
+func (g *Gateway) GetServices() ([]string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var IDs []string
+	for vsID := range g.services {
+		IDs = append(IDs, vsID)
+	}
+
+	return IDs, nil
+}
+
+// GetBackends returns IDs of backends registered with the virtual service.
+func (g *Gateway) GetBackends(vsID string) ([]string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	vs, exists := g.services[vsID]
+	if !exists {
+		return nil, ErrServiceNotFound
+	}
+
+	var IDs []string
+	for rsID, backend := range g.backends {
+		if backend.service == vs {
+			IDs = append(IDs, rsID)
+		}
+	}
+
+	return IDs, nil
+}
+
+// GetBackend returns options of the backend registered with the virtual
+// service.
+func (g *Gateway) GetBackend(vsID, rsID string) (*RealOptions, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	vs, exists := g.services[vsID]
+	if !exists {
+		return nil, ErrServiceNotFound
+	}
+
+	backend, exists := g.backends[rsID]
+	if !exists || backend.service != vs {
+		return nil, ErrBackendNotFound
+	}
+
+	return backend.options, nil
+}
+
+// DumpServices enumerates virtual services registered in the kernel,
+// including ones unknown to this gateway, like left after restart.
+func (g *Gateway) DumpServices() ([]*ServiceState, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	pools, err := g.ipvs.GetPools()
+	if err != nil {
+		return nil, err
+	}
+
+	var states []*ServiceState
+	for _, pool := range pools {
+		// Firewall mark based services are never created by the gateway.
+		if pool.Service.VIP == "" {
+			continue
+		}
+
+		options, err := NewServiceOptions(pool.Service.VIP, pool.Service.Port, string(gnl2go.FromProtoNum(gnl2go.U16Type(pool.Service.Proto))))
+		if err != nil {
+			log.G(g.ctx).Debug("skipping unsupported virtual service",
+				zap.String("service", pool.Service.ToString()), zap.Error(err))
+			continue
+		}
+
+		state := &ServiceState{Options: options}
+		for _, dest := range pool.Dests {
+			realOptions, err := NewRealOptions(dest.IP, dest.Port, dest.Weight, "")
+			if err != nil {
+				return nil, err
+			}
+			// Keep zero weight of drained backends as is.
+			realOptions.Weight = dest.Weight
+
+			state.Backends = append(state.Backends, realOptions)
+		}
+
+		states = append(states, state)
+	}
+
+	return states, nil
+}
+
+// AdoptService registers the virtual service already existing in the
+// kernel along with its backends without touching IPVS.
+func (g *Gateway) AdoptService(vsID string, options *ServiceOptions, backends map[string]*RealOptions) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.services[vsID]; exists {
+		return fmt.Errorf("virtual service %s already exists", vsID)
+	}
+
+	vs := &service{options: options}
+	g.services[vsID] = vs
+	for rsID, realOptions := range backends {
+		realOptions.VsID = vsID
+		g.backends[rsID] = &backend{options: realOptions, service: vs}
+	}
+
+	log.G(g.ctx).Info("adopted virtual service",
+		zap.String("service", vsID),
+		zap.String("host", options.Host),
+		zap.Uint16("port", options.Port),
+		zap.Int("backends", len(backends)),
+	)
+
+	return nil
+}
+
+// RemoveOrphanedService removes the virtual service from the kernel, which
+// is unknown to this gateway.
+func (g *Gateway) RemoveOrphanedService(options *ServiceOptions) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	log.G(g.ctx).Info("removing orphaned virtual service",
+		zap.String("host", options.Host),
+		zap.Uint16("port", options.Port),
+		zap.String("protocol", options.Protocol),
+	)
+
+	if err := g.ipvs.DelService(options.host.String(), options.Port, options.protocol); err != nil {
+		return ErrIPVSFailed
+	}
+
+	return nil
+}
 
 // CreateService registers a new virtual service with IPVS.
 func (g *Gateway) CreateService(vsID string, options *ServiceOptions) error {
@@ -87,6 +226,15 @@ func (g *Gateway) CreateBackend(vsID, rsID string, options *RealOptions) error {
 	return g.createBackend(vsID, rsID, options)
 }
 
+// SetBackendWeight changes the weight of the backend, where zero weight
+// stops scheduling new connections to it.
+func (g *Gateway) SetBackendWeight(vsID, rsID string, weight int32) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.setBackendWeight(vsID, rsID, weight)
+}
+
 // RemoveService deregisters a virtual service.
 func (g *Gateway) RemoveService(vsID string) (*ServiceOptions, error) {
 	g.mu.Lock()
@@ -161,6 +309,33 @@ func (g *Gateway) createBackend(vsID, rsID string, options *RealOptions) error {
 	return nil
 }
 
+func (g *Gateway) setBackendWeight(vsID, rsID string, weight int32) error {
+	rs, exists := g.backends[rsID]
+	if !exists || rs.service != g.services[vsID] {
+		return ErrBackendNotFound
+	}
+
+	if rs.options.Weight == weight {
+		return nil
+	}
+
+	log.G(g.ctx).Info("changing backend weight",
+		zap.String("service", vsID),
+		zap.String("backend", rsID),
+		zap.Int32("weight", weight),
+	)
+
+	if err := g.ipvs.UpdateDestPort(rs.service.options.host.String(), rs.service.options.Port, rs.options.host.String(),
+		rs.options.Port, rs.service.options.protocol, weight, rs.options.methodID); err != nil {
+		log.G(g.ctx).Error("failed to change backend weight", zap.Error(err))
+		return ErrIPVSFailed
+	}
+
+	rs.options.Weight = weight
+
+	return nil
+}
+
 func (g *Gateway) removeService(vsID string) (*ServiceOptions, error) {
 	vs, exists := g.services[vsID]
 	if !exists {
@@ -274,15 +449,3 @@ func (g *Gateway) Close() {
 
 	g.ipvs.Exit()
 }
-
-func GetOutboundIP() (net.IP, error) {
-	conn, err := net.Dial("udp", "8.8.8.8:80")
-	if err != nil {
-		return nil, err
-	}
-	defer conn.Close()
-
-	localAddr := conn.LocalAddr().(*net.UDPAddr)
-
-	return localAddr.IP, nil
-}
@@ -2,16 +2,52 @@
 
 package gateway
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 const (
 	PlatformSupportIPVS = false
 )
 
+var (
+	ErrServiceNotFound = errors.New("virtual service not found")
+	ErrBackendNotFound = errors.New("backend not found")
+)
+
 type Gateway struct{}
 
 func NewGateway(context.Context) (*Gateway, error) {
 	return &Gateway{}, nil
 }
 
+func (g *Gateway) GetServices() ([]string, error) {
+	return nil, nil
+}
+
+func (g *Gateway) GetBackends(vsID string) ([]string, error) {
+	return nil, ErrServiceNotFound
+}
+
+func (g *Gateway) GetBackend(vsID, rsID string) (*RealOptions, error) {
+	return nil, ErrServiceNotFound
+}
+
+func (g *Gateway) DumpServices() ([]*ServiceState, error) {
+	return nil, nil
+}
+
+func (g *Gateway) AdoptService(vsID string, options *ServiceOptions, backends map[string]*RealOptions) error {
+	return nil
+}
+
+func (g *Gateway) RemoveOrphanedService(options *ServiceOptions) error {
+	return nil
+}
+
+func (g *Gateway) SetBackendWeight(vsID, rsID string, weight int32) error {
+	return ErrBackendNotFound
+}
+
 func (g *Gateway) Close() {}
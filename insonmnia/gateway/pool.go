@@ -10,6 +10,8 @@ import (
 )
 
 type PortPool struct {
+	init  uint16
+	size  uint16
 	queue *lane.Queue
 	used  map[string]uint16
 	mu    sync.Mutex
@@ -17,6 +19,8 @@ type PortPool struct {
 
 func NewPortPool(init, size uint16) *PortPool {
 	p := &PortPool{
+		init:  init,
+		size:  size,
 		queue: lane.NewQueue(),
 		used:  make(map[string]uint16, size),
 	}
@@ -59,3 +63,51 @@ func (p *PortPool) Retain(ID string) error {
 
 	return nil
 }
+
+// Reserve assigns the exact port, which is useful for restoring
+// allocations made before restart.
+func (p *PortPool) Reserve(ID string, port uint16) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.used[ID]; exists {
+		return errors.New("named port is already in use")
+	}
+
+	found := false
+	for n := p.queue.Size(); n > 0; n-- {
+		v := p.queue.Dequeue().(uint16)
+		if v == port && !found {
+			found = true
+			continue
+		}
+
+		p.queue.Enqueue(v)
+	}
+
+	if !found {
+		return errors.New("port is not available for allocation")
+	}
+
+	p.used[ID] = port
+
+	return nil
+}
+
+// Contains reports whether the port belongs to the pool range.
+func (p *PortPool) Contains(port uint16) bool {
+	return port >= p.init && uint32(port) < uint32(p.init)+uint32(p.size)
+}
+
+// Assigned returns currently assigned ports by their names.
+func (p *PortPool) Assigned() map[string]uint16 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	assigned := make(map[string]uint16, len(p.used))
+	for ID, port := range p.used {
+		assigned[ID] = port
+	}
+
+	return assigned
+}
@@ -56,3 +56,33 @@ func TestPoolAssignRetainAssign(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, port == 10)
 }
+
+func TestPoolReserve(t *testing.T) {
+	p := NewPortPool(10, 3)
+
+	assert.True(t, p.Contains(12))
+	assert.False(t, p.Contains(13))
+
+	err := p.Reserve("0", 11)
+	assert.NoError(t, err)
+
+	err = p.Reserve("1", 11)
+	assert.Error(t, err)
+
+	err = p.Reserve("0", 12)
+	assert.Error(t, err)
+
+	for _, ID := range []string{"1", "2"} {
+		port, err := p.Assign(ID)
+		assert.NoError(t, err)
+		assert.True(t, port == 10 || port == 12)
+	}
+
+	assert.Equal(t, uint16(11), p.Assigned()["0"])
+
+	err = p.Retain("0")
+	assert.NoError(t, err)
+
+	err = p.Reserve("3", 11)
+	assert.NoError(t, err)
+}
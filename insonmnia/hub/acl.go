@@ -19,6 +19,7 @@ import (
 var (
 	errNoPeerInfo       = status.Error(codes.Unauthenticated, "no peer info")
 	errNoDealProvided   = status.Error(codes.Unauthenticated, "no `deal` metadata provided")
+	errNoTaskProvided   = status.Error(codes.Unauthenticated, "no `task` metadata provided")
 	errNoDealFieldFound = status.Error(codes.Internal, "no `Deal` field found")
 	errInvalidDealField = status.Error(codes.Internal, "invalid `Deal` field type")
 	errNoTaskFieldFound = status.Errorf(codes.Internal, "no task `ID` field found")
@@ -202,6 +203,17 @@ func newFromTaskDealExtractor(hubState *state) DealExtractor {
 }
 
 func newFromNamedTaskDealExtractor(hubState *state, name string) DealExtractor {
+	return newTaskDealExtractor(name, hubState.GetTaskByID)
+}
+
+// newFromDealTaskExtractor constructs a deal id extractor like
+// newFromTaskDealExtractor does, but also accepting tasks that have already
+// finished while their deal is still active.
+func newFromDealTaskExtractor(hubState *state) DealExtractor {
+	return newTaskDealExtractor("Id", hubState.GetDealTask)
+}
+
+func newTaskDealExtractor(name string, getTask func(taskID string) (*TaskInfo, bool)) DealExtractor {
 	return func(ctx context.Context, request interface{}) (DealID, error) {
 		requestValue := reflect.Indirect(reflect.ValueOf(request))
 		taskID := reflect.Indirect(requestValue.FieldByName(name))
@@ -213,7 +225,7 @@ func newFromNamedTaskDealExtractor(hubState *state, name string) DealExtractor {
 			return "", errInvalidTaskField
 		}
 
-		taskInfo, ok := hubState.GetTaskByID(taskID.String())
+		taskInfo, ok := getTask(taskID.String())
 		if !ok {
 			return "", status.Errorf(codes.NotFound, "task %s not found", taskID.String())
 		}
@@ -222,6 +234,29 @@ func newFromNamedTaskDealExtractor(hubState *state, name string) DealExtractor {
 	}
 }
 
+// newContextTaskDealExtractor constructs a deal id extractor that requires
+// the task id to be passed via "task" metadata key.
+func newContextTaskDealExtractor(hubState *state) DealExtractor {
+	return func(ctx context.Context, request interface{}) (DealID, error) {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return "", errNoPeerInfo
+		}
+
+		taskMD := md["task"]
+		if len(taskMD) == 0 {
+			return "", errNoTaskProvided
+		}
+
+		taskInfo, ok := hubState.GetTaskByID(taskMD[0])
+		if !ok {
+			return "", status.Errorf(codes.NotFound, "task %s not found", taskMD[0])
+		}
+
+		return DealID(taskInfo.GetDealId()), nil
+	}
+}
+
 func newRequestDealExtractor(fn func(request interface{}) (DealID, error)) DealExtractor {
 	return newCustomDealExtractor(func(ctx context.Context, request interface{}) (DealID, error) {
 		return fn(request)
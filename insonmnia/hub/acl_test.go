@@ -98,6 +98,29 @@ func TestContextDealMetaData(t *testing.T) {
 	assert.Equal(t, DealID("0x42"), dealID)
 }
 
+func TestContextTaskMetaData(t *testing.T) {
+	hubState := &state{
+		tasks: map[string]*TaskInfo{
+			"task": {StartTaskRequest: structs.StartTaskRequest{HubStartTaskRequest: &pb.HubStartTaskRequest{
+				Deal: &pb.Deal{Id: "0x42"},
+			}}},
+		},
+	}
+
+	md := newContextTaskDealExtractor(hubState)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("task", "task"))
+	dealID, err := md(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, DealID("0x42"), dealID)
+
+	_, err = md(metadata.NewIncomingContext(context.Background(), metadata.Pairs("task", "unknown")), nil)
+	assert.Error(t, err)
+
+	_, err = md(metadata.NewIncomingContext(context.Background(), metadata.Pairs("deal", "0x42")), nil)
+	assert.Equal(t, errNoTaskProvided, err)
+}
+
 func TestDealAuthorization(t *testing.T) {
 	ctx := peer.NewContext(context.Background(), &peer.Peer{
 		AuthInfo: auth.EthAuthInfo{TLS: credentials.TLSInfo{}, Wallet: addr},
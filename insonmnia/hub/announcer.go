@@ -7,6 +7,7 @@ import (
 	"time"
 
 	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/insonmnia/locator"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
 	"go.uber.org/zap"
@@ -28,15 +29,19 @@ type locatorAnnouncer struct {
 	cluster Cluster
 	client  pb.LocatorClient
 	period  time.Duration
+	ttl     time.Duration
+	region  string
 	err     error
 }
 
-func newLocatorAnnouncer(key *ecdsa.PrivateKey, lc pb.LocatorClient, td time.Duration, cls Cluster) Announcer {
+func newLocatorAnnouncer(key *ecdsa.PrivateKey, lc pb.LocatorClient, cfg LocatorConfig, cls Cluster) Announcer {
 	return &locatorAnnouncer{
 		key:     key,
 		cluster: cls,
 		client:  lc,
-		period:  td,
+		period:  cfg.UpdatePeriod,
+		ttl:     cfg.TTL,
+		region:  cfg.Region,
 	}
 }
 
@@ -70,9 +75,13 @@ func (la *locatorAnnouncer) once(ctx context.Context) error {
 		return err
 	}
 
+	record, err := locator.NewRecord(la.key, la.endpoints(clientEndpoints), la.endpoints(workerEndpoints), la.ttl)
+	if err != nil {
+		return err
+	}
+
 	req := &pb.AnnounceRequest{
-		ClientEndpoints: clientEndpoints,
-		WorkerEndpoints: workerEndpoints,
+		Record: record,
 	}
 
 	log.G(ctx).Debug("announcing Hub endpoints",
@@ -85,6 +94,17 @@ func (la *locatorAnnouncer) once(ctx context.Context) error {
 	return err
 }
 
+// endpoints converts addresses into endpoints of equal preference, located
+// in the configured region.
+func (la *locatorAnnouncer) endpoints(addrs []string) []*pb.Endpoint {
+	endpoints := make([]*pb.Endpoint, 0, len(addrs))
+	for _, addr := range addrs {
+		endpoints = append(endpoints, &pb.Endpoint{Addr: addr, Region: la.region})
+	}
+
+	return endpoints
+}
+
 func (la *locatorAnnouncer) keepError(err error) {
 	la.mu.Lock()
 	defer la.mu.Unlock()
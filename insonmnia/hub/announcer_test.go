@@ -27,7 +27,7 @@ func TestAnnouncerOK(t *testing.T) {
 	lc := sonm.NewMockLocatorClient(ctrl)
 	lc.EXPECT().Announce(gomock.Any(), gomock.Any()).MinTimes(2).Return(&sonm.Empty{}, nil)
 
-	ann := newLocatorAnnouncer(key, lc, time.Second, c)
+	ann := newLocatorAnnouncer(key, lc, LocatorConfig{UpdatePeriod: time.Second, TTL: time.Minute}, c)
 	// announce once, look at error
 	err := ann.Once(ctx)
 	assert.NoError(t, err)
@@ -50,7 +50,7 @@ func TestAnnouncerHasError(t *testing.T) {
 	lc.EXPECT().Announce(gomock.Any(), gomock.Any()).MinTimes(2).
 		Return(nil, errors.New("test: cannot announce"))
 
-	ann := newLocatorAnnouncer(key, lc, time.Second, c)
+	ann := newLocatorAnnouncer(key, lc, LocatorConfig{UpdatePeriod: time.Second, TTL: time.Minute}, c)
 
 	err := ann.Once(ctx)
 	assert.EqualError(t, err, "test: cannot announce")
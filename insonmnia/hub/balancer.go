@@ -0,0 +1,92 @@
+package hub
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/sonm-io/core/insonmnia/gateway"
+)
+
+// balancer picks targets of userspace virtual services using smooth
+// weighted round-robin, skipping ones with zero weight.
+//
+// It is not safe for concurrent use, so virtual services guard it with
+// their own locks.
+type balancer struct {
+	// Real ID -> list of targets.
+	reals map[string][]*balancerTarget
+}
+
+type balancerTarget struct {
+	addr    string
+	weight  int32
+	current int32
+}
+
+func newBalancer() *balancer {
+	return &balancer{
+		reals: map[string][]*balancerTarget{},
+	}
+}
+
+func (b *balancer) add(ID string, host string, port uint16) {
+	b.reals[ID] = append(b.reals[ID], &balancerTarget{
+		addr:   net.JoinHostPort(host, strconv.Itoa(int(port))),
+		weight: gateway.DefaultWeight,
+	})
+}
+
+func (b *balancer) remove(ID string) {
+	delete(b.reals, ID)
+}
+
+// setWeight changes the weight of the target, returning false if there is
+// no such target.
+func (b *balancer) setWeight(host string, port uint16, weight int32) bool {
+	addr := net.JoinHostPort(host, strconv.Itoa(int(port)))
+
+	found := false
+	for _, targets := range b.reals {
+		for _, target := range targets {
+			if target.addr == addr {
+				target.weight = weight
+				target.current = 0
+				found = true
+			}
+		}
+	}
+
+	return found
+}
+
+// next returns the address of the next target.
+func (b *balancer) next() (string, bool) {
+	var (
+		best  *balancerTarget
+		total int32
+	)
+
+	for _, targets := range b.reals {
+		for _, target := range targets {
+			if target.weight <= 0 {
+				continue
+			}
+
+			target.current += target.weight
+			total += target.weight
+
+			// Ties are broken by address to keep the order stable.
+			if best == nil || target.current > best.current ||
+				target.current == best.current && target.addr < best.addr {
+				best = target
+			}
+		}
+	}
+
+	if best == nil {
+		return "", false
+	}
+
+	best.current -= total
+	return best.addr, true
+}
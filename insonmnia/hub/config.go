@@ -6,23 +6,52 @@ import (
 
 	"github.com/jinzhu/configor"
 	"github.com/sonm-io/core/accounts"
+	"github.com/sonm-io/core/insonmnia/ingress"
+	"github.com/sonm-io/core/insonmnia/locator/dht"
 	"github.com/sonm-io/core/insonmnia/logging"
 	"github.com/sonm-io/core/insonmnia/npp"
+	"github.com/sonm-io/core/insonmnia/tracing"
 	"go.uber.org/zap/zapcore"
 )
 
 type LoggingConfig struct {
-	Level       string `required:"true" default:"debug"`
-	parsedLevel zapcore.Level
+	logging.Config `yaml:",inline"`
+	parsedLevel    zapcore.Level
 }
 
+const (
+	// GatewayModeIPVS routes traffic using kernel IPVS, requiring root.
+	GatewayModeIPVS = "ipvs"
+	// GatewayModeProxy routes traffic using userspace L4 proxy.
+	GatewayModeProxy = "proxy"
+)
+
 type GatewayConfig struct {
-	Ports []uint16 `required:"true" yaml:"ports"`
+	Ports []uint16    `required:"true" yaml:"ports"`
+	Mode  string      `yaml:"mode" default:"ipvs"`
+	Proxy ProxyConfig `yaml:"proxy"`
+}
+
+// ProxyConfig describes userspace L4 proxy settings.
+type ProxyConfig struct {
+	// MaxConnections limits the number of concurrent TCP connections or UDP
+	// sessions per virtual service. Zero means no limit.
+	MaxConnections int `yaml:"max_connections" default:"1024"`
+	// IdleTimeout specifies how long TCP connections and UDP sessions are
+	// kept without any traffic.
+	IdleTimeout time.Duration `yaml:"idle_timeout" default:"5m"`
 }
 
 type LocatorConfig struct {
 	Endpoint     string        `yaml:"endpoint" required:"true"`
 	UpdatePeriod time.Duration `yaml:"update_period" required:"true" default:"10s"`
+	// TTL of announced records. Must be greater than the update period.
+	TTL time.Duration `yaml:"ttl" default:"1m"`
+	// Region the Hub is located in, used to prefer closer endpoints.
+	Region string `yaml:"region"`
+	// DHT enables peer-to-peer publishing of the Hub records instead of
+	// announcing them to the central Locator.
+	DHT *dht.Config `yaml:"dht"`
 }
 
 type MarketConfig struct {
@@ -63,6 +92,7 @@ type Config struct {
 	Endpoint          string             `required:"true" yaml:"endpoint"`
 	GatewayConfig     *GatewayConfig     `yaml:"gateway"`
 	Logging           LoggingConfig      `yaml:"logging"`
+	Tracing           tracing.Config     `yaml:"tracing"`
 	Eth               accounts.EthConfig `yaml:"ethereum"`
 	Locator           LocatorConfig      `yaml:"locator"`
 	Market            MarketConfig       `yaml:"market"`
@@ -70,6 +100,8 @@ type Config struct {
 	Whitelist         WhitelistConfig    `yaml:"whitelist"`
 	MetricsListenAddr string             `yaml:"metrics_listen_addr" default:"127.0.0.1:14000"`
 	NPP               npp.Config
+	// Ingress enables serving tasks' HTTP(S) services by host names.
+	Ingress *ingress.Config `yaml:"ingress"`
 }
 
 func (c *Config) LogLevel() zapcore.Level {
@@ -84,6 +116,14 @@ func NewConfig(path string) (*Config, error) {
 		return nil, err
 	}
 
+	if err := conf.Logging.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := conf.Tracing.Validate(); err != nil {
+		return nil, err
+	}
+
 	lvl, err := logging.ParseLogLevel(conf.Logging.Level)
 	if err != nil {
 		return nil, err
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	log "github.com/noxiouz/zapctx/ctxlog"
 	"github.com/sonm-io/core/blockchain"
@@ -41,6 +42,18 @@ type ETH interface {
 	AcceptDeal(id string) error
 	// CloseDeal closes the specified deal on Hub-side.
 	CloseDeal(id DealID) error
+	// Refund pays the given amount of tokens back to the buyer, used to
+	// settle deals closed before their end time.
+	//
+	// The deal contract has no partial payouts, so this is a plain token
+	// transfer from the Hub wallet rather than a deal operation. The hash of
+	// the signed transaction is passed to the given callback before the
+	// transaction is sent, which is aborted if the callback fails.
+	Refund(buyerID string, amount *big.Int, signed func(tx string) error) error
+	// RefundStatus checks whether the refund transaction with the given hash
+	// has succeeded. An error is returned while the transaction is not mined
+	// or its status can not be obtained.
+	RefundStatus(tx string) (bool, error)
 	// GetDeal checks whether a given deal exists.
 	GetDeal(id string) (*pb.Deal, error)
 	// Balance returns Hub's balance.
@@ -222,6 +235,22 @@ func (e *eth) CloseDeal(id DealID) error {
 	return err
 }
 
+func (e *eth) Refund(buyerID string, amount *big.Int, signed func(tx string) error) error {
+	_, err := e.bc.TransferSigned(e.ctx, e.key, buyerID, amount, func(tx *types.Transaction) error {
+		return signed(tx.Hash().Hex())
+	})
+	return err
+}
+
+func (e *eth) RefundStatus(tx string) (bool, error) {
+	receipt, err := e.bc.GetTransactionReceipt(e.ctx, tx)
+	if err != nil {
+		return false, err
+	}
+
+	return receipt.Status == types.ReceiptStatusSuccessful, nil
+}
+
 func (e *eth) GetDeal(id string) (*pb.Deal, error) {
 	bigID, err := util.ParseBigInt(id)
 	if err != nil {
@@ -0,0 +1,348 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/insonmnia/gateway"
+	pb "github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultHealthCheckInterval  = 10 * time.Second
+	defaultHealthCheckTimeout   = 5 * time.Second
+	defaultHealthCheckThreshold = 3
+)
+
+var (
+	errWeightsNotSupported = errors.New("router does not support weights of real services")
+)
+
+var healthCheckClient = &http.Client{
+	Transport: &http.Transport{DisableKeepAlives: true},
+	// Redirects are treated as a successful response.
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// validateHealthChecks verifies health checks declared in the task spec.
+func validateHealthChecks(checks []*pb.HealthCheck) error {
+	for _, check := range checks {
+		proto, port := nat.SplitProtoPort(check.GetPort())
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return fmt.Errorf("invalid health check port %q", check.GetPort())
+		}
+
+		if proto != "tcp" {
+			return fmt.Errorf("health checks are supported for TCP ports only, but %q specified", check.GetPort())
+		}
+
+		switch check.GetType() {
+		case "", "tcp", "http":
+		default:
+			return fmt.Errorf("unknown health check type %q", check.GetType())
+		}
+
+		// Paths not starting with a slash may change the host the check
+		// is sent to.
+		if path := check.GetPath(); path != "" && !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("health check path must start with a slash, but %q specified", path)
+		}
+	}
+
+	return nil
+}
+
+// healthMonitor checks health of the tasks endpoints reachable through the
+// router, draining ones failing checks by setting their weight to zero, so
+// replicas behind the same virtual service keep serving.
+type healthMonitor struct {
+	ctx    context.Context
+	router Router
+
+	mu    sync.Mutex
+	tasks map[string]*taskHealth
+}
+
+type taskHealth struct {
+	cancel    context.CancelFunc
+	endpoints []*endpointHealth
+}
+
+type endpointHealth struct {
+	check *pb.HealthCheck
+	route *Route
+
+	mu      sync.Mutex
+	healthy bool
+	weight  int32
+	// Number of consecutive check results contradicting current health.
+	streak  int
+	lastErr error
+}
+
+func newHealthMonitor(ctx context.Context, router Router) *healthMonitor {
+	return &healthMonitor{
+		ctx:    ctx,
+		router: router,
+		tasks:  map[string]*taskHealth{},
+	}
+}
+
+// Watch starts checking endpoints of the task routes matching the given
+// health checks, replacing checks that are already running for the task.
+func (m *healthMonitor) Watch(taskID string, checks []*pb.HealthCheck, routes []*Route) {
+	if m == nil || len(checks) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	task := &taskHealth{cancel: cancel}
+
+	for _, check := range checks {
+		for _, route := range routes {
+			if !healthCheckMatches(check, route) {
+				continue
+			}
+
+			// Endpoints are considered healthy until proven otherwise, which
+			// also restores weights possibly left zero before the Hub restart.
+			err := m.router.SetWeight(route.ID, route.BackendHost, route.BackendPort, gateway.DefaultWeight)
+			if err == errWeightsNotSupported {
+				log.G(m.ctx).Info("skipping health check of the endpoint not reachable from the Hub",
+					zap.String("task_id", taskID), zap.String("route", route.ID))
+				continue
+			}
+			if err != nil {
+				log.G(m.ctx).Warn("failed to reset endpoint weight", zap.String("route", route.ID), zap.Error(err))
+			}
+
+			endpoint := &endpointHealth{
+				check:   check,
+				route:   route,
+				healthy: true,
+				weight:  gateway.DefaultWeight,
+			}
+
+			task.endpoints = append(task.endpoints, endpoint)
+			go m.run(ctx, endpoint)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if previous, ok := m.tasks[taskID]; ok {
+		previous.cancel()
+	}
+	m.tasks[taskID] = task
+}
+
+// Forget stops checking endpoints of the task.
+func (m *healthMonitor) Forget(taskID string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if task, ok := m.tasks[taskID]; ok {
+		task.cancel()
+		delete(m.tasks, taskID)
+	}
+}
+
+// Status returns health of the task endpoints.
+func (m *healthMonitor) Status(taskID string) []*pb.EndpointHealth {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	task, ok := m.tasks[taskID]
+	m.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	var health []*pb.EndpointHealth
+	for _, endpoint := range task.endpoints {
+		health = append(health, endpoint.status())
+	}
+
+	return health
+}
+
+func (m *healthMonitor) run(ctx context.Context, endpoint *endpointHealth) {
+	ticker := time.NewTicker(secondsOrDefault(endpoint.check.GetInterval(), defaultHealthCheckInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := probeHealth(ctx, endpoint.check, endpoint.addr())
+		if ctx.Err() != nil {
+			return
+		}
+
+		if changed := endpoint.report(err); changed {
+			m.updateWeight(endpoint)
+		}
+	}
+}
+
+func (m *healthMonitor) updateWeight(endpoint *endpointHealth) {
+	endpoint.mu.Lock()
+	defer endpoint.mu.Unlock()
+
+	weight := int32(0)
+	if endpoint.healthy {
+		weight = gateway.DefaultWeight
+	}
+
+	route := endpoint.route
+	log.G(m.ctx).Info("endpoint health changed", zap.String("route", route.ID),
+		zap.String("endpoint", endpoint.addr()), zap.Bool("healthy", endpoint.healthy), zap.Error(endpoint.lastErr))
+
+	if err := m.router.SetWeight(route.ID, route.BackendHost, route.BackendPort, weight); err != nil {
+		log.G(m.ctx).Warn("failed to change endpoint weight", zap.String("route", route.ID), zap.Error(err))
+		return
+	}
+
+	endpoint.weight = weight
+}
+
+func (e *endpointHealth) addr() string {
+	return net.JoinHostPort(e.route.BackendHost, strconv.Itoa(int(e.route.BackendPort)))
+}
+
+// report accounts the check result, returning true if the endpoint health
+// has changed.
+func (e *endpointHealth) report(err error) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err != nil {
+		e.lastErr = err
+	}
+
+	if e.healthy == (err == nil) {
+		e.streak = 0
+		return false
+	}
+
+	e.streak++
+
+	threshold := int(e.check.GetThreshold())
+	if threshold == 0 {
+		threshold = defaultHealthCheckThreshold
+	}
+
+	if e.streak < threshold {
+		return false
+	}
+
+	e.healthy = !e.healthy
+	e.streak = 0
+
+	return true
+}
+
+func (e *endpointHealth) status() *pb.EndpointHealth {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	_, port, _ := splitServiceID(e.route.ID)
+
+	status := &pb.EndpointHealth{
+		Port:     string(port),
+		Endpoint: e.addr(),
+		Healthy:  e.healthy,
+		Weight:   e.weight,
+	}
+
+	if e.lastErr != nil {
+		status.Error = e.lastErr.Error()
+	}
+
+	return status
+}
+
+func healthCheckMatches(check *pb.HealthCheck, route *Route) bool {
+	_, port, ok := splitServiceID(route.ID)
+	if !ok {
+		return false
+	}
+
+	proto, checkPort := nat.SplitProtoPort(check.GetPort())
+
+	return port.Proto() == proto && port.Port() == checkPort
+}
+
+// probeHealth performs a single health check of the endpoint.
+func probeHealth(ctx context.Context, check *pb.HealthCheck, addr string) error {
+	ctx, cancel := context.WithTimeout(ctx, secondsOrDefault(check.GetTimeout(), defaultHealthCheckTimeout))
+	defer cancel()
+
+	switch check.GetType() {
+	case "", "tcp":
+		dialer := net.Dialer{}
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+	case "http":
+		path := check.GetPath()
+		if path == "" {
+			path = "/"
+		}
+
+		target := &url.URL{Scheme: "http", Host: addr, Path: path}
+		request, err := http.NewRequest("GET", target.String(), nil)
+		if err != nil {
+			return err
+		}
+
+		response, err := healthCheckClient.Do(request.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		response.Body.Close()
+
+		if response.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("unexpected HTTP status %d", response.StatusCode)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown health check type %q", check.GetType())
+	}
+}
+
+func secondsOrDefault(seconds uint64, defaultValue time.Duration) time.Duration {
+	if seconds == 0 {
+		return defaultValue
+	}
+
+	return time.Duration(seconds) * time.Second
+}
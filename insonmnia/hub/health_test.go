@@ -0,0 +1,138 @@
+package hub
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sonm-io/core/insonmnia/gateway"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalancer(t *testing.T) {
+	b := newBalancer()
+
+	_, ok := b.next()
+	assert.False(t, ok)
+
+	b.add("vs", "10.0.0.1", 80)
+	b.add("vs", "10.0.0.2", 80)
+	require.True(t, b.setWeight("10.0.0.2", 80, 2*gateway.DefaultWeight))
+
+	picked := map[string]int{}
+	for i := 0; i < 30; i++ {
+		addr, ok := b.next()
+		require.True(t, ok)
+		picked[addr]++
+	}
+
+	assert.Equal(t, 10, picked["10.0.0.1:80"])
+	assert.Equal(t, 20, picked["10.0.0.2:80"])
+
+	require.True(t, b.setWeight("10.0.0.2", 80, 0))
+	for i := 0; i < 3; i++ {
+		addr, _ := b.next()
+		assert.Equal(t, "10.0.0.1:80", addr)
+	}
+
+	assert.False(t, b.setWeight("10.0.0.3", 80, 0))
+
+	b.remove("vs")
+	_, ok = b.next()
+	assert.False(t, ok)
+}
+
+func TestValidateHealthChecks(t *testing.T) {
+	assert.NoError(t, validateHealthChecks([]*pb.HealthCheck{
+		{Port: "80"},
+		{Port: "8080/tcp", Type: "http"},
+		{Port: "8080", Type: "http", Path: "/health"},
+	}))
+
+	assert.Error(t, validateHealthChecks([]*pb.HealthCheck{{Port: "http"}}))
+	assert.Error(t, validateHealthChecks([]*pb.HealthCheck{{Port: "53/udp"}}))
+	assert.Error(t, validateHealthChecks([]*pb.HealthCheck{{Port: "80", Type: "icmp"}}))
+	assert.Error(t, validateHealthChecks([]*pb.HealthCheck{{Port: "80", Type: "http", Path: "health"}}))
+	assert.Error(t, validateHealthChecks([]*pb.HealthCheck{{Port: "80", Type: "http", Path: "@10.0.0.1:6379/"}}))
+}
+
+func TestProbeHealth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			http.Redirect(w, r, "/", http.StatusFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	addr := server.Listener.Addr().String()
+
+	ctx := context.Background()
+	assert.NoError(t, probeHealth(ctx, &pb.HealthCheck{}, addr))
+	assert.NoError(t, probeHealth(ctx, &pb.HealthCheck{Type: "http", Path: "/health"}, addr))
+	assert.Error(t, probeHealth(ctx, &pb.HealthCheck{Type: "http"}, addr))
+
+	server.Close()
+	assert.Error(t, probeHealth(ctx, &pb.HealthCheck{Timeout: 1}, addr))
+}
+
+func TestHealthMonitor(t *testing.T) {
+	healthy, healthyPort := newTCPEchoServer(t)
+	defer healthy.Close()
+	failing, failingPort := newTCPEchoServer(t)
+	failing.Close()
+
+	router := newProxyRouter(context.Background(), ProxyConfig{}, gateway.NewPortPool(freePort(t), 1))
+	defer router.Close()
+
+	vs, err := router.Register("task#80/tcp", "tcp")
+	require.NoError(t, err)
+
+	var routes []*Route
+	for _, port := range []uint16{healthyPort, failingPort} {
+		route, err := vs.AddReal("task#80/tcp", "127.0.0.1", port)
+		require.NoError(t, err)
+		routes = append(routes, route)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	monitor := newHealthMonitor(ctx, router)
+	monitor.Watch("task", []*pb.HealthCheck{{Port: "80/tcp", Interval: 1, Threshold: 1}}, routes)
+
+	failingAddr := net.JoinHostPort("127.0.0.1", strconv.Itoa(int(failingPort)))
+
+	var health []*pb.EndpointHealth
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(100 * time.Millisecond) {
+		health = monitor.Status("task")
+		if len(health) == 2 && !health[1].GetHealthy() {
+			break
+		}
+	}
+
+	require.Len(t, health, 2)
+	assert.True(t, health[0].GetHealthy())
+	assert.Equal(t, int32(gateway.DefaultWeight), health[0].GetWeight())
+	assert.False(t, health[1].GetHealthy())
+	assert.Equal(t, "80/tcp", health[1].GetPort())
+	assert.Equal(t, failingAddr, health[1].GetEndpoint())
+	assert.Equal(t, int32(0), health[1].GetWeight())
+	assert.NotEmpty(t, health[1].GetError())
+
+	// Traffic is routed to the healthy endpoint only.
+	for i := 0; i < 3; i++ {
+		target, ok := router.(*proxyRouter).services["task#80/tcp"].target()
+		require.True(t, ok)
+		assert.NotEqual(t, failingAddr, target)
+	}
+
+	monitor.Forget("task")
+	assert.Empty(t, monitor.Status("task"))
+}
@@ -54,6 +54,7 @@ type MinerCtx struct {
 	// Traffic routing.
 
 	router Router
+	health *healthMonitor
 
 	// Scheduling.
 
@@ -166,7 +167,7 @@ func (m *MinerCtx) handshake(h *Hub) error {
 	m.capabilities = capabilities
 	m.usage = resource.NewPool(capabilities)
 
-	if m.router, err = h.newRouter(m.uuid, resp.NatType); err != nil {
+	if m.router, err = h.newRouter(m.uuid, resp.NatType, m.Client); err != nil {
 		log.G(m.ctx).Warn("failed to create router for a miner",
 			zap.String("uuid", m.uuid),
 			zap.Error(err),
@@ -175,12 +176,40 @@ func (m *MinerCtx) handshake(h *Hub) error {
 		m.router = newDirectRouter()
 	}
 
+	if h.ingress != nil {
+		m.router = newIngressRouter(m.router, h.ingress)
+	}
+
+	m.health = newHealthMonitor(m.ctx, m.router)
+
 	return nil
 }
 
 // NewRouter constructs a new router that will route requests to bypass miner's firewall.
-func (h *Hub) newRouter(id string, natType pb.NATType) (Router, error) {
-	if h.gateway == nil || natType == pb.NATType_NONE {
+//
+// Miners with public IP are reached directly. Miners located behind NAT
+// that allows incoming packets from any host once the mapping is created
+// are reached using IPVS, while the rest, like behind symmetric NAT, get
+// their traffic relayed through the connection they have established with
+// the Hub.
+//
+// In the proxy gateway mode all reachable miners, including ones with public
+// IP, are reached through the userspace proxy to not expose their addresses.
+func (h *Hub) newRouter(id string, natType pb.NATType, client pb.MinerClient) (Router, error) {
+	if h.portPool == nil {
+		return newDirectRouter(), nil
+	}
+
+	switch natType {
+	case pb.NATType_SYMMETRIC, pb.NATType_SYMMETRIC_UDP_FIREWALL, pb.NATType_BLOCKED:
+		return newRelayRouter(h.ctx, client, h.portPool), nil
+	}
+
+	if h.proxyConfig != nil {
+		return newProxyRouter(h.ctx, *h.proxyConfig, h.portPool), nil
+	}
+
+	if natType == pb.NATType_NONE {
 		return newDirectRouter(), nil
 	}
 
@@ -188,11 +217,22 @@ func (h *Hub) newRouter(id string, natType pb.NATType) (Router, error) {
 		return newIPVSRouter(h.ctx, h.gateway, h.portPool), nil
 	}
 
-	return nil, errors.New("miner has firewall configured, but Hub's host OS has no IPVS support")
+	return newRelayRouter(h.ctx, client, h.portPool), nil
 }
 
-func (m *MinerCtx) deregisterRoute(ID string) error {
-	return m.router.Deregister(ID)
+// deregisterRoutes deregisters virtual services the given routes belong to.
+func (m *MinerCtx) deregisterRoutes(routes []*Route) {
+	deregistered := map[string]bool{}
+	for _, route := range routes {
+		if deregistered[route.ID] {
+			continue
+		}
+
+		if err := m.router.Deregister(route.ID); err != nil {
+			log.G(m.ctx).Warn("failed to deregister route", zap.String("id", route.ID), zap.Error(err))
+		}
+		deregistered[route.ID] = true
+	}
 }
 
 func (m *MinerCtx) initStatusClient() (statusClient pb.Miner_TasksStatusClient, err error) {
@@ -20,6 +20,7 @@ type options struct {
 	bcr           blockchain.Blockchainer
 	market        pb.MarketClient
 	locator       pb.LocatorClient
+	rating        pb.RatingClient
 	creds         credentials.TransportCredentials
 	rot           util.HitlessCertRotator
 	cluster       Cluster
@@ -60,6 +61,12 @@ func WithLocator(loc pb.LocatorClient) Option {
 	}
 }
 
+func WithRating(r pb.RatingClient) Option {
+	return func(o *options) {
+		o.rating = r
+	}
+}
+
 func WithPrivateKey(k *ecdsa.PrivateKey) Option {
 	return func(o *options) {
 		o.ethKey = k
@@ -0,0 +1,126 @@
+package hub
+
+import (
+	"math/big"
+
+	pb "github.com/sonm-io/core/proto"
+)
+
+const (
+	anyGPUModel = "*"
+	gigabyte    = 1 << 30
+)
+
+// planPrice calculates the price of the ask plan according to its pricing
+// policy.
+//
+// Unit prices, when specified, replace the fixed base price. Then, if the
+// policy follows the market, the price is set to the highest price among the
+// given BIDs. The result is never lower than the floor price.
+//
+// Workers are described by names of their GPU devices, which are required to
+// price GPUs by model. Since the hub can schedule the plan on any worker, the
+// most expensive one is taken.
+func planPrice(policy *pb.PricingPolicy, base *big.Int, resources *pb.Resources, workers [][]string, bids []*pb.Order) *big.Int {
+	price := big.NewInt(0).Set(base)
+
+	if unit := unitPrice(policy, resources, workers); unit != nil {
+		price = unit
+	}
+
+	if policy.GetFollowMarket() {
+		if highest := highestBidPrice(bids); highest != nil {
+			price = highest
+		}
+	}
+
+	if floor := policy.GetFloor(); floor != nil && price.Cmp(floor.Unwrap()) < 0 {
+		price = floor.Unwrap()
+	}
+
+	return price
+}
+
+// unitPrice sums up per-resource prices of the given resources. Returns nil
+// if the policy has no unit prices.
+func unitPrice(policy *pb.PricingPolicy, resources *pb.Resources, workers [][]string) *big.Int {
+	if policy.GetPerCPUCore() == nil && policy.GetPerRAMGigabyte() == nil && len(policy.GetPerGPU()) == 0 {
+		return nil
+	}
+
+	price := big.NewInt(0)
+	if perCore := policy.GetPerCPUCore(); perCore != nil {
+		cores := big.NewInt(0).SetUint64(resources.GetCpuCores())
+		price.Add(price, cores.Mul(cores, perCore.Unwrap()))
+	}
+
+	if perGB := policy.GetPerRAMGigabyte(); perGB != nil {
+		ram := big.NewInt(0).SetUint64(resources.GetRamBytes())
+		ram.Mul(ram, perGB.Unwrap())
+		price.Add(price, ram.Div(ram, big.NewInt(gigabyte)))
+	}
+
+	return price.Add(price, gpuPrice(policy, resources.GetGpuCount(), workers))
+}
+
+// gpuPrice returns the price of GPUs required by the plan when scheduled on
+// the most expensive of the given workers. A single GPU plan may get any
+// device of the worker, while a multiple GPU one gets all of them.
+func gpuPrice(policy *pb.PricingPolicy, count pb.GPUCount, workers [][]string) *big.Int {
+	max := big.NewInt(0)
+	if count == pb.GPUCount_NO_GPU || len(policy.GetPerGPU()) == 0 {
+		return max
+	}
+
+	if len(workers) == 0 {
+		return max.Add(max, devicePrice(policy, ""))
+	}
+
+	for _, devices := range workers {
+		price := big.NewInt(0)
+		for _, device := range devices {
+			devPrice := devicePrice(policy, device)
+			if count == pb.GPUCount_MULTIPLE_GPU {
+				price.Add(price, devPrice)
+			} else if devPrice.Cmp(price) > 0 {
+				price.Set(devPrice)
+			}
+		}
+
+		if price.Cmp(max) > 0 {
+			max = price
+		}
+	}
+
+	return max
+}
+
+func devicePrice(policy *pb.PricingPolicy, device string) *big.Int {
+	if price, ok := policy.GetPerGPU()[device]; ok {
+		return price.Unwrap()
+	}
+
+	if price, ok := policy.GetPerGPU()[anyGPUModel]; ok {
+		return price.Unwrap()
+	}
+
+	return big.NewInt(0)
+}
+
+// highestBidPrice returns the highest price among the given BIDs, nil if
+// there are none.
+func highestBidPrice(bids []*pb.Order) *big.Int {
+	var highest *big.Int
+	for _, bid := range bids {
+		if bid.GetPricePerSecond() == nil {
+			continue
+		}
+
+		price := bid.GetPricePerSecond().Unwrap()
+		if price.Sign() > 0 && (highest == nil || price.Cmp(highest) > 0) {
+			highest = price
+		}
+	}
+
+	return highest
+}
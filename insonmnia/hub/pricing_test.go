@@ -0,0 +1,67 @@
+package hub
+
+import (
+	"math/big"
+	"testing"
+
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanPriceFixed(t *testing.T) {
+	policy := &pb.PricingPolicy{}
+	resources := &pb.Resources{CpuCores: 2}
+
+	assert.Equal(t, big.NewInt(10), planPrice(policy, big.NewInt(10), resources, nil, nil))
+}
+
+func TestPlanPriceUnits(t *testing.T) {
+	policy := &pb.PricingPolicy{
+		PerCPUCore:     pb.NewBigIntFromInt(10),
+		PerRAMGigabyte: pb.NewBigIntFromInt(4),
+	}
+	resources := &pb.Resources{CpuCores: 2, RamBytes: 1 << 29}
+
+	assert.Equal(t, big.NewInt(22), planPrice(policy, big.NewInt(1000), resources, nil, nil))
+}
+
+func TestPlanPriceGPU(t *testing.T) {
+	policy := &pb.PricingPolicy{
+		PerGPU: map[string]*pb.BigInt{
+			"1080Ti":    pb.NewBigIntFromInt(100),
+			anyGPUModel: pb.NewBigIntFromInt(10),
+		},
+	}
+	workers := [][]string{
+		{"1080Ti", "1060"},
+		{"1060", "1060", "1060"},
+	}
+
+	single := &pb.Resources{GpuCount: pb.GPUCount_SINGLE_GPU}
+	assert.Equal(t, big.NewInt(100), planPrice(policy, big.NewInt(1), single, workers, nil))
+
+	multiple := &pb.Resources{GpuCount: pb.GPUCount_MULTIPLE_GPU}
+	assert.Equal(t, big.NewInt(110), planPrice(policy, big.NewInt(1), multiple, workers, nil))
+
+	// No workers connected yet.
+	assert.Equal(t, big.NewInt(10), planPrice(policy, big.NewInt(1), single, nil, nil))
+
+	none := &pb.Resources{GpuCount: pb.GPUCount_NO_GPU}
+	assert.Equal(t, big.NewInt(0), planPrice(policy, big.NewInt(1), none, workers, nil))
+}
+
+func TestPlanPriceMarket(t *testing.T) {
+	bids := []*pb.Order{
+		{PricePerSecond: pb.NewBigIntFromInt(30)},
+		{PricePerSecond: pb.NewBigIntFromInt(50)},
+		{},
+	}
+	resources := &pb.Resources{}
+
+	policy := &pb.PricingPolicy{FollowMarket: true}
+	assert.Equal(t, big.NewInt(50), planPrice(policy, big.NewInt(10), resources, nil, bids))
+	assert.Equal(t, big.NewInt(10), planPrice(policy, big.NewInt(10), resources, nil, nil))
+
+	policy.Floor = pb.NewBigIntFromInt(70)
+	assert.Equal(t, big.NewInt(70), planPrice(policy, big.NewInt(10), resources, nil, bids))
+}
@@ -3,6 +3,12 @@
 package hub
 
 import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
 	"github.com/sonm-io/core/insonmnia/gateway"
 )
 
@@ -34,6 +40,10 @@ type Router interface {
 	Register(ID string, protocol string) (VirtualService, error)
 	// Deregister deregisters a virtual service specified by the given ID.
 	Deregister(ID string) error
+	// SetWeight sets the weight of the real service endpoint within the
+	// virtual service specified by the given ID.
+	// Zero weight stops routing new connections to the endpoint.
+	SetWeight(ID string, host string, port uint16, weight int32) error
 	// GetMetrics returns gateway-specific metrics.
 	GetMetrics() (*gateway.Metrics, error)
 	// Close closes the router, freeing all associated resources.
@@ -55,6 +65,10 @@ func (r *directRouter) Deregister(ID string) error {
 	return nil
 }
 
+func (r *directRouter) SetWeight(ID string, host string, port uint16, weight int32) error {
+	return nil
+}
+
 func (r *directRouter) GetMetrics() (*gateway.Metrics, error) {
 	return &gateway.Metrics{}, nil
 }
@@ -88,3 +102,25 @@ func (s *directVirtualService) AddReal(ID string, host string, port uint16) (*Ro
 func (s *directVirtualService) RemoveReal(ID string) error {
 	return nil
 }
+
+// splitServiceID splits the "<task-id>#<port>/<proto>" virtual service ID
+// into the task ID and container port.
+func splitServiceID(ID string) (string, nat.Port, bool) {
+	parts := strings.SplitN(ID, "#", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+
+	port := nat.Port(parts[1])
+	if _, err := strconv.ParseUint(port.Port(), 10, 16); err != nil {
+		return "", "", false
+	}
+
+	return parts[0], port, true
+}
+
+// realID returns the ID of the real service endpoint, which is unique
+// within the virtual service.
+func realID(ID string, host string, port uint16) string {
+	return fmt.Sprintf("%s@%s", ID, net.JoinHostPort(host, strconv.Itoa(int(port))))
+}
@@ -0,0 +1,142 @@
+package hub
+
+import (
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/sonm-io/core/insonmnia/gateway"
+	"github.com/sonm-io/core/insonmnia/ingress"
+)
+
+// ingressRouter publishes TCP services of tasks on the HTTP(S) ingress in
+// addition to routing them using the wrapped router.
+type ingressRouter struct {
+	Router
+	ingress *ingress.Server
+
+	mu    sync.Mutex
+	tasks map[string]struct{}
+}
+
+func newIngressRouter(router Router, ingress *ingress.Server) Router {
+	return &ingressRouter{
+		Router:  router,
+		ingress: ingress,
+		tasks:   map[string]struct{}{},
+	}
+}
+
+func (r *ingressRouter) Register(ID string, protocol string) (VirtualService, error) {
+	vs, err := r.Router.Register(ID, protocol)
+	if err != nil || protocol != "tcp" {
+		return vs, err
+	}
+
+	return &ingressVirtualService{VirtualService: vs, router: r, reals: map[string]string{}}, nil
+}
+
+// Deregister deregisters the virtual service, where the ID may be either
+// a single service ID or the task ID to deregister all its services.
+func (r *ingressRouter) Deregister(ID string) error {
+	taskID, port, ok := splitServiceID(ID)
+	switch {
+	case !ok:
+		r.removeTask(ID)
+	case port.Proto() == "tcp":
+		r.ingress.RemoveBackends(taskID, uint16(port.Int()))
+	}
+
+	return r.Router.Deregister(ID)
+}
+
+func (r *ingressRouter) GetMetrics() (*gateway.Metrics, error) {
+	metrics, err := r.Router.GetMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for taskID := range r.tasks {
+		if routeMetrics, ok := r.ingress.Metrics(taskID); ok {
+			metrics.Add(&gateway.Metrics{
+				Connections: routeMetrics.Requests,
+				InBytes:     routeMetrics.InBytes,
+				OutBytes:    routeMetrics.OutBytes,
+			})
+		}
+	}
+
+	return metrics, nil
+}
+
+func (r *ingressRouter) Close() error {
+	r.mu.Lock()
+	for taskID := range r.tasks {
+		r.ingress.RemoveTask(taskID)
+	}
+	r.tasks = map[string]struct{}{}
+	r.mu.Unlock()
+
+	return r.Router.Close()
+}
+
+func (r *ingressRouter) addTask(taskID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tasks[taskID] = struct{}{}
+}
+
+func (r *ingressRouter) removeTask(taskID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.tasks, taskID)
+	r.ingress.RemoveTask(taskID)
+}
+
+type ingressVirtualService struct {
+	VirtualService
+	router *ingressRouter
+
+	mu sync.Mutex
+	// reals maps real service IDs to the ingress endpoints they are
+	// published at.
+	reals map[string]string
+}
+
+func (s *ingressVirtualService) AddReal(ID string, host string, port uint16) (*Route, error) {
+	route, err := s.VirtualService.AddReal(ID, host, port)
+	if err != nil {
+		return nil, err
+	}
+
+	if taskID, containerPort, ok := splitServiceID(s.ID()); ok {
+		endpoint := net.JoinHostPort(route.Host, strconv.Itoa(int(route.Port)))
+
+		s.mu.Lock()
+		s.reals[ID] = endpoint
+		s.mu.Unlock()
+
+		s.router.addTask(taskID)
+		s.router.ingress.AddBackend(taskID, uint16(containerPort.Int()), endpoint)
+	}
+
+	return route, nil
+}
+
+func (s *ingressVirtualService) RemoveReal(ID string) error {
+	s.mu.Lock()
+	endpoint, ok := s.reals[ID]
+	delete(s.reals, ID)
+	s.mu.Unlock()
+
+	if taskID, containerPort, valid := splitServiceID(s.ID()); ok && valid {
+		s.router.ingress.RemoveBackend(taskID, uint16(containerPort.Int()), endpoint)
+	}
+
+	return s.VirtualService.RemoveReal(ID)
+}
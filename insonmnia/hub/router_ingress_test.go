@@ -0,0 +1,82 @@
+package hub
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sonm-io/core/insonmnia/ingress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitServiceID(t *testing.T) {
+	taskID, port, ok := splitServiceID("task#8080/tcp")
+	require.True(t, ok)
+	assert.Equal(t, "task", taskID)
+	assert.Equal(t, 8080, port.Int())
+	assert.Equal(t, "tcp", port.Proto())
+
+	_, _, ok = splitServiceID("task")
+	assert.False(t, ok)
+
+	_, _, ok = splitServiceID("task#http/tcp")
+	assert.False(t, ok)
+}
+
+func TestIngressRouter(t *testing.T) {
+	server, err := ingress.NewServer(context.Background(), ingress.Config{
+		Domain:       "hub.example.com",
+		HTTPEndpoint: "127.0.0.1:0",
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	router := newIngressRouter(newDirectRouter(), server)
+
+	vs, err := router.Register("task#80/tcp", "tcp")
+	require.NoError(t, err)
+	_, err = vs.AddReal("task#80/tcp", "10.0.0.1", 32768)
+	require.NoError(t, err)
+
+	vs, err = router.Register("task#53/udp", "udp")
+	require.NoError(t, err)
+	_, err = vs.AddReal("task#53/udp", "10.0.0.1", 32769)
+	require.NoError(t, err)
+
+	_, ok := server.Metrics("task")
+	assert.True(t, ok)
+
+	metrics, err := router.GetMetrics()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), metrics.Connections)
+
+	require.NoError(t, router.Deregister("task"))
+	_, ok = server.Metrics("task")
+	assert.False(t, ok)
+}
+
+func TestIngressRouterRemoveReal(t *testing.T) {
+	server, err := ingress.NewServer(context.Background(), ingress.Config{
+		Domain:       "hub.example.com",
+		HTTPEndpoint: "127.0.0.1:0",
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	router := newIngressRouter(newDirectRouter(), server)
+
+	vs, err := router.Register("task#80/tcp", "tcp")
+	require.NoError(t, err)
+	_, err = vs.AddReal("real-1", "10.0.0.1", 32768)
+	require.NoError(t, err)
+	_, err = vs.AddReal("real-2", "10.0.0.2", 32768)
+	require.NoError(t, err)
+
+	require.NoError(t, vs.RemoveReal("real-1"))
+	_, ok := server.Metrics("task")
+	assert.True(t, ok)
+
+	require.NoError(t, vs.RemoveReal("real-2"))
+	_, ok = server.Metrics("task")
+	assert.False(t, ok)
+}
@@ -48,6 +48,7 @@ func (r *ipvsRouter) Register(ID string, protocol string) (VirtualService, error
 		vsID:    ID,
 		options: serviceOptions,
 		gateway: r.gateway,
+		reals:   map[string][]string{},
 	}
 
 	r.mu.Lock()
@@ -80,6 +81,10 @@ func (r *ipvsRouter) deregisterRoute(ID string) error {
 	return nil
 }
 
+func (r *ipvsRouter) SetWeight(ID string, host string, port uint16, weight int32) error {
+	return r.gateway.SetBackendWeight(ID, realID(ID, host, port), weight)
+}
+
 // GetMetrics collects network specific metrics that are associated with this router.
 func (r *ipvsRouter) GetMetrics() (*gateway.Metrics, error) {
 	r.mu.Lock()
@@ -128,6 +133,10 @@ type ipvsVirtualService struct {
 	vsID    string
 	options *gateway.ServiceOptions
 	gateway *gateway.Gateway
+
+	mu sync.Mutex
+	// Real ID -> list of gateway backend IDs, one per endpoint.
+	reals map[string][]string
 }
 
 func (s *ipvsVirtualService) ID() string {
@@ -135,15 +144,20 @@ func (s *ipvsVirtualService) ID() string {
 }
 
 func (s *ipvsVirtualService) AddReal(ID string, host string, port uint16) (*Route, error) {
-	realOptions, err := gateway.NewRealOptions(host, port, 100, s.vsID)
+	realOptions, err := gateway.NewRealOptions(host, port, gateway.DefaultWeight, s.vsID)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := s.gateway.CreateBackend(s.vsID, ID, realOptions); err != nil {
+	rsID := realID(ID, host, port)
+	if err := s.gateway.CreateBackend(s.vsID, rsID, realOptions); err != nil {
 		return nil, err
 	}
 
+	s.mu.Lock()
+	s.reals[ID] = append(s.reals[ID], rsID)
+	s.mu.Unlock()
+
 	route := &Route{
 		ID:          ID,
 		Protocol:    s.options.Protocol,
@@ -157,6 +171,15 @@ func (s *ipvsVirtualService) AddReal(ID string, host string, port uint16) (*Rout
 }
 
 func (s *ipvsVirtualService) RemoveReal(ID string) error {
-	_, err := s.gateway.RemoveBackend(s.vsID, ID)
-	return err
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rsID := range s.reals[ID] {
+		if _, err := s.gateway.RemoveBackend(s.vsID, rsID); err != nil {
+			return err
+		}
+	}
+
+	delete(s.reals, ID)
+	return nil
 }
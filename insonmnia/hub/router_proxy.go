@@ -0,0 +1,505 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/insonmnia/gateway"
+	"go.uber.org/zap"
+)
+
+const (
+	proxyDialTimeout  = 10 * time.Second
+	maxDatagramLength = 65535
+)
+
+// proxyRouter routes traffic using userspace L4 proxy as a portable
+// alternative to IPVS.
+//
+// Each virtual service listens on the Hub port, accepting TCP connections
+// or tracking UDP sessions by the client address, and passes the traffic
+// to real services, so the workers' addresses are never exposed.
+type proxyRouter struct {
+	ctx      context.Context
+	cfg      ProxyConfig
+	pool     *gateway.PortPool
+	services map[string]*proxyVirtualService
+	mu       sync.Mutex
+}
+
+func newProxyRouter(ctx context.Context, cfg ProxyConfig, pool *gateway.PortPool) Router {
+	return &proxyRouter{
+		ctx:      ctx,
+		cfg:      cfg,
+		pool:     pool,
+		services: make(map[string]*proxyVirtualService, 0),
+	}
+}
+
+func (r *proxyRouter) Register(ID string, protocol string) (VirtualService, error) {
+	if protocol != "tcp" && protocol != "udp" {
+		return nil, fmt.Errorf("proxied routing supports TCP and UDP only, but %s requested", protocol)
+	}
+
+	host, err := gateway.GetOutboundIP()
+	if err != nil {
+		return nil, err
+	}
+
+	port, err := r.pool.Assign(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(r.ctx)
+
+	virtualService := &proxyVirtualService{
+		vsID:     ID,
+		protocol: protocol,
+		host:     host.String(),
+		port:     port,
+		cfg:      r.cfg,
+		ctx:      ctx,
+		cancel:   cancel,
+		reals:    newBalancer(),
+		conns:    map[io.Closer]struct{}{},
+		sessions: map[string]*udpSession{},
+		metrics:  &gateway.Metrics{},
+	}
+
+	addr := net.JoinHostPort("", strconv.Itoa(int(port)))
+	if protocol == "tcp" {
+		virtualService.listener, err = net.Listen("tcp", addr)
+	} else {
+		virtualService.packetConn, err = net.ListenPacket("udp", addr)
+	}
+
+	if err != nil {
+		cancel()
+		r.pool.Retain(ID)
+		return nil, err
+	}
+
+	go virtualService.serve()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.services[ID] = virtualService
+
+	return virtualService, nil
+}
+
+func (r *proxyRouter) Deregister(ID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.deregisterRoute(ID)
+}
+
+func (r *proxyRouter) deregisterRoute(ID string) error {
+	virtualService, ok := r.services[ID]
+	if !ok {
+		return nil
+	}
+
+	virtualService.Close()
+	delete(r.services, ID)
+
+	return r.pool.Retain(ID)
+}
+
+func (r *proxyRouter) SetWeight(ID string, host string, port uint16, weight int32) error {
+	r.mu.Lock()
+	virtualService, ok := r.services[ID]
+	r.mu.Unlock()
+
+	if !ok {
+		return gateway.ErrServiceNotFound
+	}
+
+	return virtualService.setWeight(host, port, weight)
+}
+
+// GetMetrics collects network specific metrics that are associated with this router.
+func (r *proxyRouter) GetMetrics() (*gateway.Metrics, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	metrics := &gateway.Metrics{}
+	for _, virtualService := range r.services {
+		metrics.Add(virtualService.Metrics())
+	}
+
+	return metrics, nil
+}
+
+// Close deregisters all routes.
+func (r *proxyRouter) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for ID := range r.services {
+		r.deregisterRoute(ID)
+	}
+
+	return nil
+}
+
+type proxyVirtualService struct {
+	vsID     string
+	protocol string
+	host     string
+	port     uint16
+	cfg      ProxyConfig
+	ctx      context.Context
+	cancel   context.CancelFunc
+
+	listener   net.Listener
+	packetConn net.PacketConn
+
+	mu    sync.Mutex
+	reals *balancer
+	// Connections of active TCP sessions, closed along with the virtual
+	// service.
+	conns map[io.Closer]struct{}
+	// Number of accepted TCP connections being proxied.
+	active int
+	// Client address -> UDP session.
+	sessions map[string]*udpSession
+	metrics  *gateway.Metrics
+}
+
+func (s *proxyVirtualService) ID() string {
+	return s.vsID
+}
+
+func (s *proxyVirtualService) AddReal(ID string, host string, port uint16) (*Route, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reals.add(ID, host, port)
+
+	route := &Route{
+		ID:          ID,
+		Protocol:    s.protocol,
+		Host:        s.host,
+		Port:        s.port,
+		BackendHost: host,
+		BackendPort: port,
+	}
+
+	return route, nil
+}
+
+func (s *proxyVirtualService) RemoveReal(ID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reals.remove(ID)
+	return nil
+}
+
+func (s *proxyVirtualService) setWeight(host string, port uint16, weight int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.reals.setWeight(host, port, weight) {
+		return gateway.ErrBackendNotFound
+	}
+
+	return nil
+}
+
+func (s *proxyVirtualService) Metrics() *gateway.Metrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	metrics := *s.metrics
+	return &metrics
+}
+
+// Close stops listening and breaks all active connections and sessions.
+func (s *proxyVirtualService) Close() error {
+	s.cancel()
+
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+	}
+	if s.packetConn != nil {
+		err = s.packetConn.Close()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for conn := range s.conns {
+		conn.Close()
+	}
+	for _, session := range s.sessions {
+		session.backend.Close()
+	}
+
+	return err
+}
+
+func (s *proxyVirtualService) serve() {
+	if s.listener != nil {
+		s.serveTCP()
+	} else {
+		s.serveUDP()
+	}
+}
+
+// target picks the next real service target.
+func (s *proxyVirtualService) target() (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.reals.next()
+}
+
+// acquire starts tracking the accepted TCP connection, returning false if
+// the connections limit is reached.
+func (s *proxyVirtualService) acquire(conn io.Closer) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cfg.MaxConnections > 0 && s.active >= s.cfg.MaxConnections {
+		return false
+	}
+
+	s.active++
+	s.conns[conn] = struct{}{}
+	s.metrics.Add(&gateway.Metrics{Connections: 1})
+
+	return true
+}
+
+func (s *proxyVirtualService) release(conn io.Closer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.active--
+	delete(s.conns, conn)
+}
+
+// track remembers the connection to be closed along with the virtual
+// service.
+func (s *proxyVirtualService) track(conn io.Closer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.conns[conn] = struct{}{}
+}
+
+func (s *proxyVirtualService) untrack(conn io.Closer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.conns, conn)
+}
+
+func (s *proxyVirtualService) serveTCP() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.proxyTCP(conn)
+	}
+}
+
+func (s *proxyVirtualService) proxyTCP(conn net.Conn) {
+	defer conn.Close()
+
+	target, ok := s.target()
+	if !ok {
+		log.G(s.ctx).Warn("no real services to proxy connection to", zap.String("vsID", s.vsID))
+		return
+	}
+
+	if !s.acquire(conn) {
+		log.G(s.ctx).Warn("connections limit reached", zap.String("vsID", s.vsID))
+		return
+	}
+	defer s.release(conn)
+
+	backend, err := net.DialTimeout("tcp", target, proxyDialTimeout)
+	if err != nil {
+		log.G(s.ctx).Warn("failed to proxy connection", zap.String("target", target), zap.Error(err))
+		return
+	}
+	defer backend.Close()
+
+	s.track(backend)
+	defer s.untrack(backend)
+
+	client := &idleConn{Conn: conn, timeout: s.cfg.IdleTimeout}
+	server := &idleConn{Conn: backend, timeout: s.cfg.IdleTimeout}
+
+	done := make(chan struct{})
+	go func() {
+		n, _ := io.Copy(server, client)
+		s.addMetrics(&gateway.Metrics{InBytes: uint64(n)})
+		if tcpConn, ok := backend.(*net.TCPConn); ok {
+			tcpConn.CloseWrite()
+		}
+		close(done)
+	}()
+
+	n, _ := io.Copy(client, server)
+	s.addMetrics(&gateway.Metrics{OutBytes: uint64(n)})
+
+	conn.Close()
+	<-done
+}
+
+// udpSession is a pseudo-connection of the UDP client to the real service.
+type udpSession struct {
+	client  net.Addr
+	backend *net.UDPConn
+}
+
+func (s *proxyVirtualService) serveUDP() {
+	buf := make([]byte, maxDatagramLength)
+
+	for {
+		n, addr, err := s.packetConn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		session, err := s.session(addr)
+		if err != nil {
+			log.G(s.ctx).Warn("failed to proxy datagram", zap.String("vsID", s.vsID),
+				zap.Stringer("client", addr), zap.Error(err))
+			continue
+		}
+
+		s.addMetrics(&gateway.Metrics{InPackets: 1, InBytes: uint64(n)})
+
+		session.backend.SetReadDeadline(s.idleDeadline())
+		session.backend.Write(buf[:n])
+	}
+}
+
+// session returns the UDP session of the client, establishing a new one if
+// required.
+func (s *proxyVirtualService) session(addr net.Addr) (*udpSession, error) {
+	s.mu.Lock()
+	session, ok := s.sessions[addr.String()]
+	s.mu.Unlock()
+
+	if ok {
+		return session, nil
+	}
+
+	target, ok := s.target()
+	if !ok {
+		return nil, errors.New("no real services")
+	}
+
+	targetAddr, err := net.ResolveUDPAddr("udp", target)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := net.DialUDP("udp", nil, targetAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cfg.MaxConnections > 0 && len(s.sessions) >= s.cfg.MaxConnections {
+		backend.Close()
+		return nil, errors.New("sessions limit reached")
+	}
+
+	session = &udpSession{client: addr, backend: backend}
+	s.sessions[addr.String()] = session
+	s.metrics.Add(&gateway.Metrics{Connections: 1})
+
+	go s.replyUDP(session)
+
+	return session, nil
+}
+
+// replyUDP passes datagrams from the real service back to the client until
+// the session becomes idle.
+func (s *proxyVirtualService) replyUDP(session *udpSession) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.sessions, session.client.String())
+		s.mu.Unlock()
+
+		session.backend.Close()
+	}()
+
+	buf := make([]byte, maxDatagramLength)
+	for {
+		session.backend.SetReadDeadline(s.idleDeadline())
+
+		n, err := session.backend.Read(buf)
+		if err != nil {
+			return
+		}
+
+		s.addMetrics(&gateway.Metrics{OutPackets: 1, OutBytes: uint64(n)})
+
+		if _, err := s.packetConn.WriteTo(buf[:n], session.client); err != nil {
+			return
+		}
+	}
+}
+
+// idleDeadline returns the deadline UDP sessions expire at unless there is
+// more traffic, zero if sessions never expire.
+func (s *proxyVirtualService) idleDeadline() time.Time {
+	if s.cfg.IdleTimeout == 0 {
+		return time.Time{}
+	}
+
+	return time.Now().Add(s.cfg.IdleTimeout)
+}
+
+func (s *proxyVirtualService) addMetrics(metrics *gateway.Metrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.metrics.Add(metrics)
+}
+
+// idleConn is a connection that fails reads and writes after being idle
+// for the given timeout.
+type idleConn struct {
+	net.Conn
+	timeout time.Duration
+}
+
+func (c *idleConn) Read(p []byte) (int, error) {
+	if c.timeout > 0 {
+		c.Conn.SetDeadline(time.Now().Add(c.timeout))
+	}
+
+	return c.Conn.Read(p)
+}
+
+func (c *idleConn) Write(p []byte) (int, error) {
+	if c.timeout > 0 {
+		c.Conn.SetDeadline(time.Now().Add(c.timeout))
+	}
+
+	return c.Conn.Write(p)
+}
@@ -0,0 +1,198 @@
+package hub
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sonm-io/core/insonmnia/gateway"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTCPEchoServer(t *testing.T) (net.Listener, uint16) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	return listener, uint16(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func newUDPEchoServer(t *testing.T) (net.PacketConn, uint16) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			conn.WriteTo(buf[:n], addr)
+		}
+	}()
+
+	return conn, uint16(conn.LocalAddr().(*net.UDPAddr).Port)
+}
+
+func TestNewRouterProxyMode(t *testing.T) {
+	hub := &Hub{
+		ctx:         context.Background(),
+		portPool:    gateway.NewPortPool(10000, 10),
+		proxyConfig: &ProxyConfig{},
+	}
+
+	for _, natType := range []pb.NATType{pb.NATType_NONE, pb.NATType_FULL, pb.NATType_PORT_RESTRICTED} {
+		router, err := hub.newRouter("miner", natType, nil)
+		require.NoError(t, err)
+
+		_, ok := router.(*proxyRouter)
+		assert.True(t, ok, natType.String())
+	}
+
+	router, err := hub.newRouter("miner", pb.NATType_SYMMETRIC, nil)
+	require.NoError(t, err)
+
+	_, ok := router.(*relayRouter)
+	assert.True(t, ok)
+}
+
+func TestProxyRouterTCP(t *testing.T) {
+	backend, backendPort := newTCPEchoServer(t)
+	defer backend.Close()
+
+	router := newProxyRouter(context.Background(), ProxyConfig{IdleTimeout: time.Minute}, gateway.NewPortPool(freePort(t), 1))
+	defer router.Close()
+
+	_, err := router.Register("task#80/sctp", "sctp")
+	require.Error(t, err)
+
+	vs, err := router.Register("task#80/tcp", "tcp")
+	require.NoError(t, err)
+
+	route, err := vs.AddReal("task#80/tcp", "127.0.0.1", backendPort)
+	require.NoError(t, err)
+	assert.Equal(t, "tcp", route.Protocol)
+	assert.Equal(t, backendPort, route.BackendPort)
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(int(route.Port))), time.Second)
+	require.NoError(t, err)
+
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+	require.NoError(t, conn.(*net.TCPConn).CloseWrite())
+
+	data, err := ioutil.ReadAll(conn)
+	require.NoError(t, err)
+	conn.Close()
+	assert.Equal(t, "ping", string(data))
+
+	metrics, err := router.GetMetrics()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), metrics.Connections)
+	assert.Equal(t, uint64(4), metrics.InBytes)
+	assert.Equal(t, uint64(4), metrics.OutBytes)
+
+	require.NoError(t, router.Deregister("task#80/tcp"))
+
+	_, err = net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(int(route.Port))), time.Second)
+	assert.Error(t, err)
+}
+
+func TestProxyRouterTCPLimits(t *testing.T) {
+	backend, backendPort := newTCPEchoServer(t)
+	defer backend.Close()
+
+	cfg := ProxyConfig{MaxConnections: 1, IdleTimeout: 100 * time.Millisecond}
+	router := newProxyRouter(context.Background(), cfg, gateway.NewPortPool(freePort(t), 1))
+	defer router.Close()
+
+	vs, err := router.Register("task#80/tcp", "tcp")
+	require.NoError(t, err)
+
+	route, err := vs.AddReal("task#80/tcp", "127.0.0.1", backendPort)
+	require.NoError(t, err)
+
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(int(route.Port)))
+
+	first, err := net.DialTimeout("tcp", addr, time.Second)
+	require.NoError(t, err)
+	defer first.Close()
+
+	_, err = first.Write([]byte("ping"))
+	require.NoError(t, err)
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(first, buf)
+	require.NoError(t, err)
+
+	second, err := net.DialTimeout("tcp", addr, time.Second)
+	require.NoError(t, err)
+	defer second.Close()
+
+	// The second connection exceeds the limit and is closed immediately.
+	data, err := ioutil.ReadAll(second)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	// While the first one is closed after being idle.
+	first.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = first.Read(buf)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestProxyRouterUDP(t *testing.T) {
+	backend, backendPort := newUDPEchoServer(t)
+	defer backend.Close()
+
+	router := newProxyRouter(context.Background(), ProxyConfig{IdleTimeout: time.Minute}, gateway.NewPortPool(freePort(t), 1))
+	defer router.Close()
+
+	vs, err := router.Register("task#53/udp", "udp")
+	require.NoError(t, err)
+
+	route, err := vs.AddReal("task#53/udp", "127.0.0.1", backendPort)
+	require.NoError(t, err)
+	assert.Equal(t, "udp", route.Protocol)
+
+	conn, err := net.Dial("udp", net.JoinHostPort("127.0.0.1", strconv.Itoa(int(route.Port))))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	buf := make([]byte, 16)
+	for _, msg := range []string{"ping", "pong"} {
+		_, err = conn.Write([]byte(msg))
+		require.NoError(t, err)
+
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, err := conn.Read(buf)
+		require.NoError(t, err)
+		assert.Equal(t, msg, string(buf[:n]))
+	}
+
+	metrics, err := router.GetMetrics()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), metrics.Connections)
+	assert.Equal(t, uint64(2), metrics.InPackets)
+	assert.Equal(t, uint64(2), metrics.OutPackets)
+	assert.Equal(t, uint64(8), metrics.OutBytes)
+}
@@ -0,0 +1,250 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/insonmnia/gateway"
+	pb "github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+// relayRouter routes traffic to miners located behind NAT, which can not be
+// traversed, like symmetric one.
+//
+// Each virtual service listens for TCP connections on the Hub and relays
+// them through the Miner's gRPC connection, which has been already
+// established by the Miner itself.
+type relayRouter struct {
+	ctx      context.Context
+	client   pb.MinerClient
+	pool     *gateway.PortPool
+	services map[string]*relayVirtualService
+	mu       sync.Mutex
+}
+
+func newRelayRouter(ctx context.Context, client pb.MinerClient, pool *gateway.PortPool) Router {
+	return &relayRouter{
+		ctx:      ctx,
+		client:   client,
+		pool:     pool,
+		services: make(map[string]*relayVirtualService, 0),
+	}
+}
+
+func (r *relayRouter) Register(ID string, protocol string) (VirtualService, error) {
+	if protocol != "tcp" {
+		return nil, fmt.Errorf("relayed routing supports TCP only, but %s requested", protocol)
+	}
+
+	host, err := gateway.GetOutboundIP()
+	if err != nil {
+		return nil, err
+	}
+
+	port, err := r.pool.Assign(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(int(port))))
+	if err != nil {
+		r.pool.Retain(ID)
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(r.ctx)
+
+	virtualService := &relayVirtualService{
+		vsID:     ID,
+		host:     host.String(),
+		port:     port,
+		ctx:      ctx,
+		cancel:   cancel,
+		client:   r.client,
+		listener: listener,
+		reals:    newBalancer(),
+		metrics:  &gateway.Metrics{},
+	}
+
+	go virtualService.serve()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.services[ID] = virtualService
+
+	return virtualService, nil
+}
+
+func (r *relayRouter) Deregister(ID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.deregisterRoute(ID)
+}
+
+func (r *relayRouter) deregisterRoute(ID string) error {
+	virtualService, ok := r.services[ID]
+	if !ok {
+		return nil
+	}
+
+	virtualService.Close()
+	delete(r.services, ID)
+
+	return r.pool.Retain(ID)
+}
+
+// SetWeight is not supported, because real services are located behind
+// NAT, so the Hub can not check their health.
+func (r *relayRouter) SetWeight(ID string, host string, port uint16, weight int32) error {
+	return errWeightsNotSupported
+}
+
+// GetMetrics collects network specific metrics that are associated with this router.
+func (r *relayRouter) GetMetrics() (*gateway.Metrics, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	metrics := &gateway.Metrics{}
+	for _, virtualService := range r.services {
+		metrics.Add(virtualService.Metrics())
+	}
+
+	return metrics, nil
+}
+
+// Close deregisters all routes.
+func (r *relayRouter) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for ID := range r.services {
+		r.deregisterRoute(ID)
+	}
+
+	return nil
+}
+
+type relayVirtualService struct {
+	vsID     string
+	host     string
+	port     uint16
+	ctx      context.Context
+	cancel   context.CancelFunc
+	client   pb.MinerClient
+	listener net.Listener
+
+	mu sync.Mutex
+	// Targets on the Miner.
+	reals   *balancer
+	metrics *gateway.Metrics
+}
+
+func (s *relayVirtualService) ID() string {
+	return s.vsID
+}
+
+func (s *relayVirtualService) AddReal(ID string, host string, port uint16) (*Route, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reals.add(ID, host, port)
+
+	route := &Route{
+		ID:          ID,
+		Protocol:    "tcp",
+		Host:        s.host,
+		Port:        s.port,
+		BackendHost: host,
+		BackendPort: port,
+	}
+
+	return route, nil
+}
+
+func (s *relayVirtualService) RemoveReal(ID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reals.remove(ID)
+	return nil
+}
+
+func (s *relayVirtualService) Metrics() *gateway.Metrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	metrics := *s.metrics
+	return &metrics
+}
+
+func (s *relayVirtualService) Close() error {
+	s.cancel()
+	return s.listener.Close()
+}
+
+func (s *relayVirtualService) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.relay(conn)
+	}
+}
+
+// target picks the next real service target.
+func (s *relayVirtualService) target() (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.reals.next()
+}
+
+func (s *relayVirtualService) relay(conn net.Conn) {
+	defer conn.Close()
+
+	target, ok := s.target()
+	if !ok {
+		log.G(s.ctx).Warn("no real services to relay connection to", zap.String("vsID", s.vsID))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	stream, err := s.client.Forward(metadata.NewOutgoingContext(ctx, metadata.Pairs("target", target)))
+	if err != nil {
+		log.G(s.ctx).Warn("failed to relay connection", zap.String("target", target), zap.Error(err))
+		return
+	}
+
+	s.addMetrics(&gateway.Metrics{Connections: 1})
+
+	rw := pb.NewChunkReadWriter(stream)
+
+	go func() {
+		n, _ := io.Copy(rw, conn)
+		stream.CloseSend()
+		s.addMetrics(&gateway.Metrics{InBytes: uint64(n)})
+	}()
+
+	n, _ := io.Copy(conn, rw)
+	s.addMetrics(&gateway.Metrics{OutBytes: uint64(n)})
+}
+
+func (s *relayVirtualService) addMetrics(metrics *gateway.Metrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.metrics.Add(metrics)
+}
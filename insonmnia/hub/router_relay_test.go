@@ -0,0 +1,180 @@
+package hub
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sonm-io/core/insonmnia/gateway"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// echoMiner echoes forwarded streams back, prefixing them with the target.
+type echoMiner struct {
+	pb.MinerServer
+}
+
+func (m *echoMiner) Forward(stream pb.Miner_ForwardServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	if len(md["target"]) == 0 {
+		return status.Error(codes.InvalidArgument, "target is required")
+	}
+
+	rw := pb.NewChunkReadWriter(stream)
+	if _, err := rw.Write([]byte(md["target"][0] + "|")); err != nil {
+		return err
+	}
+
+	_, err := io.Copy(rw, rw)
+	return err
+}
+
+func newEchoMinerClient(t *testing.T) (pb.MinerClient, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	pb.RegisterMinerServer(server, &echoMiner{})
+	go server.Serve(listener)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+
+	return pb.NewMinerClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func freePort(t *testing.T) uint16 {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	return uint16(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func TestNewRouter(t *testing.T) {
+	hub := &Hub{
+		ctx:      context.Background(),
+		gateway:  &gateway.Gateway{},
+		portPool: gateway.NewPortPool(10000, 10),
+	}
+
+	tests := []struct {
+		natType pb.NATType
+		direct  bool
+		relay   bool
+	}{
+		{pb.NATType_NONE, true, false},
+		{pb.NATType_FULL, false, !gateway.PlatformSupportIPVS},
+		{pb.NATType_RESTRICTED, false, !gateway.PlatformSupportIPVS},
+		{pb.NATType_PORT_RESTRICTED, false, !gateway.PlatformSupportIPVS},
+		{pb.NATType_SYMMETRIC, false, true},
+		{pb.NATType_SYMMETRIC_UDP_FIREWALL, false, true},
+		{pb.NATType_BLOCKED, false, true},
+	}
+
+	for _, test := range tests {
+		router, err := hub.newRouter("miner", test.natType, nil)
+		require.NoError(t, err)
+
+		_, direct := router.(*directRouter)
+		_, relay := router.(*relayRouter)
+		assert.Equal(t, test.direct, direct, test.natType.String())
+		assert.Equal(t, test.relay, relay, test.natType.String())
+	}
+}
+
+func TestNewRouterWithoutGateway(t *testing.T) {
+	hub := &Hub{ctx: context.Background()}
+
+	router, err := hub.newRouter("miner", pb.NATType_SYMMETRIC, nil)
+	require.NoError(t, err)
+
+	_, ok := router.(*directRouter)
+	assert.True(t, ok)
+}
+
+func TestRelayRouter(t *testing.T) {
+	client, cleanup := newEchoMinerClient(t)
+	defer cleanup()
+
+	router := newRelayRouter(context.Background(), client, gateway.NewPortPool(freePort(t), 1))
+	defer router.Close()
+
+	_, err := router.Register("task#80/udp", "udp")
+	require.Error(t, err)
+
+	vs, err := router.Register("task#80/tcp", "tcp")
+	require.NoError(t, err)
+
+	route, err := vs.AddReal("task#80/tcp", "203.0.113.1", 32768)
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.1", route.BackendHost)
+	assert.Equal(t, uint16(32768), route.BackendPort)
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(int(route.Port))), time.Second)
+	require.NoError(t, err)
+
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+	require.NoError(t, conn.(*net.TCPConn).CloseWrite())
+
+	data, err := ioutil.ReadAll(conn)
+	require.NoError(t, err)
+	conn.Close()
+	assert.Equal(t, "203.0.113.1:32768|ping", string(data))
+
+	metrics, err := router.GetMetrics()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), metrics.Connections)
+
+	require.NoError(t, router.Deregister("task#80/tcp"))
+
+	_, err = net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(int(route.Port))), time.Second)
+	assert.Error(t, err)
+}
+
+func TestRelayRouterNoReals(t *testing.T) {
+	client, cleanup := newEchoMinerClient(t)
+	defer cleanup()
+
+	router := newRelayRouter(context.Background(), client, gateway.NewPortPool(freePort(t), 1))
+	defer router.Close()
+
+	vs, err := router.Register("task#80/tcp", "tcp")
+	require.NoError(t, err)
+	require.NoError(t, vs.RemoveReal("task#80/tcp"))
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(int(servicePort(t, router)))), time.Second)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	data, err := ioutil.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
+
+func servicePort(t *testing.T, router Router) uint16 {
+	r := router.(*relayRouter)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, vs := range r.services {
+		return vs.port
+	}
+
+	t.Fatal("no virtual services registered")
+	return 0
+}
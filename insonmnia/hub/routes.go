@@ -0,0 +1,108 @@
+package hub
+
+import (
+	"fmt"
+
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/insonmnia/gateway"
+	pb "github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// Routes returns the routing table of task services exposed by the Hub.
+func (h *Hub) Routes(ctx context.Context, request *pb.Empty) (*pb.RoutesReply, error) {
+	log.G(h.ctx).Info("handling Routes request")
+
+	return h.state.Routes(), nil
+}
+
+// reconcileRoutes synchronizes the gateway with routes of tasks restored
+// from the Hub state, which both may be outdated after the Hub crash.
+//
+// IPVS services of known tasks are adopted back with their ports reserved
+// in the pool, while orphaned ones are removed. Routes served by userspace
+// routers do not survive restart, so they are forgotten.
+func (h *Hub) reconcileRoutes() error {
+	if h.portPool == nil {
+		return nil
+	}
+
+	services := map[string]*gateway.ServiceState{}
+	if h.gateway != nil {
+		states, err := h.gateway.DumpServices()
+		if err != nil {
+			return err
+		}
+
+		for _, state := range states {
+			// Services outside of the pool range are not ours.
+			if !h.portPool.Contains(state.Options.Port) {
+				continue
+			}
+
+			services[serviceKey(state.Options.Host, state.Options.Protocol, state.Options.Port)] = state
+		}
+	}
+
+	adopted := 0
+	routesByService := map[string][]*Route{}
+	err := h.state.FilterRoutes(func(task *TaskInfo, route *Route) bool {
+		// Miners are reached directly, there is nothing to restore.
+		if route.Host == route.BackendHost && route.Port == route.BackendPort {
+			return true
+		}
+
+		key := serviceKey(route.Host, route.Protocol, route.Port)
+		if _, ok := services[key]; !ok {
+			log.G(h.ctx).Warn("forgetting stale route", zap.String("task_id", task.ID), zap.String("route", route.ID))
+			return false
+		}
+
+		routesByService[key] = append(routesByService[key], route)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for key, state := range services {
+		routes, ok := routesByService[key]
+		if !ok {
+			if err := h.gateway.RemoveOrphanedService(state.Options); err != nil {
+				log.G(h.ctx).Warn("failed to remove orphaned virtual service", zap.String("service", key), zap.Error(err))
+			}
+			continue
+		}
+
+		vsID := routes[0].ID
+		backends := map[string]*gateway.RealOptions{}
+		for _, route := range routes {
+			for _, backend := range state.Backends {
+				if backend.Host == route.BackendHost && backend.Port == route.BackendPort {
+					backends[realID(route.ID, route.BackendHost, route.BackendPort)] = backend
+				}
+			}
+		}
+
+		if err := h.gateway.AdoptService(vsID, state.Options, backends); err != nil {
+			log.G(h.ctx).Warn("failed to adopt virtual service", zap.String("service", key), zap.Error(err))
+			continue
+		}
+
+		if err := h.portPool.Reserve(vsID, state.Options.Port); err != nil {
+			log.G(h.ctx).Warn("failed to reserve virtual service port", zap.String("service", key), zap.Error(err))
+		}
+
+		adopted++
+	}
+
+	log.G(h.ctx).Info("reconciled task routes", zap.Int("adopted", adopted),
+		zap.Int("removed", len(services)-len(routesByService)))
+
+	return nil
+}
+
+func serviceKey(host, protocol string, port uint16) string {
+	return fmt.Sprintf("%s:%s:%d", host, protocol, port)
+}
@@ -0,0 +1,62 @@
+package hub
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sonm-io/core/insonmnia/gateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cluster := NewMockCluster(ctrl)
+	cluster.EXPECT().IsLeader().AnyTimes().Return(true)
+	cluster.EXPECT().Synchronize(gomock.Any()).Times(1).Return(nil)
+
+	st := &state{
+		ctx:     context.Background(),
+		cluster: cluster,
+		tasks: map[string]*TaskInfo{
+			"direct": {
+				ID:      "direct",
+				MinerId: "miner",
+				Routes: []*Route{
+					{ID: "direct#80/tcp", Protocol: "tcp", Host: "10.0.0.1", Port: 32768, BackendHost: "10.0.0.1", BackendPort: 32768},
+				},
+			},
+			"proxied": {
+				ID:      "proxied",
+				MinerId: "miner",
+				Routes: []*Route{
+					{ID: "proxied#53/udp", Protocol: "udp", Host: "10.0.0.2", Port: 10000, BackendHost: "10.0.0.1", BackendPort: 32769},
+					{ID: "proxied#22/tcp", Protocol: "tcp", Host: "10.0.0.2", Port: 10001, BackendHost: "10.0.0.1", BackendPort: 32770},
+				},
+			},
+		},
+	}
+
+	hub := &Hub{
+		ctx:      context.Background(),
+		state:    st,
+		portPool: gateway.NewPortPool(10000, 10),
+	}
+
+	require.NoError(t, hub.reconcileRoutes())
+
+	reply, err := hub.Routes(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, reply.GetRoutes(), 1)
+
+	route := reply.GetRoutes()[0]
+	assert.Equal(t, "direct", route.GetTaskID())
+	assert.Equal(t, "miner", route.GetMinerID())
+	assert.Equal(t, "80/tcp", route.GetContainerPort())
+	assert.Equal(t, "tcp", route.GetProtocol())
+	assert.Equal(t, uint32(32768), route.GetPort())
+	assert.Empty(t, st.tasks["proxied"].Routes)
+}
@@ -14,15 +14,20 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/grpc-ecosystem/go-grpc-prometheus"
 	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/opentracing/opentracing-go"
 	"github.com/pborman/uuid"
 	"github.com/pkg/errors"
 	"github.com/sonm-io/core/blockchain"
 	"github.com/sonm-io/core/insonmnia/auth"
 	"github.com/sonm-io/core/insonmnia/gateway"
+	"github.com/sonm-io/core/insonmnia/ingress"
+	"github.com/sonm-io/core/insonmnia/locator/dht"
 	"github.com/sonm-io/core/insonmnia/math"
 	"github.com/sonm-io/core/insonmnia/npp"
+	"github.com/sonm-io/core/insonmnia/rating"
 	"github.com/sonm-io/core/insonmnia/resource"
 	"github.com/sonm-io/core/insonmnia/structs"
+	"github.com/sonm-io/core/insonmnia/tracing"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
 	"github.com/sonm-io/core/util/xgrpc"
@@ -32,6 +37,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -61,6 +67,7 @@ var (
 		"Slots",
 		"InsertSlot",
 		"RemoveSlot",
+		"Routes",
 	}
 
 	orderPublishThresholdETH = new(big.Int).Mul(big.NewInt(10), big.NewInt(params.Finney))
@@ -82,6 +89,7 @@ type Hub struct {
 	cancel           context.CancelFunc
 	gateway          *gateway.Gateway
 	portPool         *gateway.PortPool
+	proxyConfig      *ProxyConfig
 	grpcEndpointAddr string
 	externalGrpc     *grpc.Server
 
@@ -92,6 +100,8 @@ type Hub struct {
 	ethAddr common.Address
 
 	announcer     Announcer
+	dht           *dht.Node
+	ingress       *ingress.Server
 	cluster       Cluster
 	clusterEvents <-chan ClusterEvent
 
@@ -104,6 +114,7 @@ type Hub struct {
 
 	eth    ETH
 	market pb.MarketClient
+	rating pb.RatingClient
 
 	// TLS certificate rotator
 	certRotator util.HitlessCertRotator
@@ -149,10 +160,18 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Hub, error) {
 
 	var gate *gateway.Gateway
 	var portPool *gateway.PortPool
+	var proxyConfig *ProxyConfig
 	if cfg.GatewayConfig != nil {
-		gate, err = gateway.NewGateway(ctx)
-		if err != nil {
-			return nil, err
+		switch cfg.GatewayConfig.Mode {
+		case GatewayModeIPVS:
+			gate, err = gateway.NewGateway(log.WithLogger(ctx, log.G(ctx).Named("gateway")))
+			if err != nil {
+				return nil, err
+			}
+		case GatewayModeProxy:
+			proxyConfig = &cfg.GatewayConfig.Proxy
+		default:
+			return nil, fmt.Errorf("unknown gateway mode: %s", cfg.GatewayConfig.Mode)
 		}
 
 		if len(cfg.GatewayConfig.Ports) != 2 {
@@ -176,6 +195,24 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Hub, error) {
 		return nil, err
 	}
 
+	var dhtNode *dht.Node
+	if defaults.locator == nil && cfg.Locator.DHT != nil {
+		dhtNode, err = dht.NewNode(ctx, *cfg.Locator.DHT, defaults.ethKey, defaults.creds)
+		if err != nil {
+			return nil, err
+		}
+
+		defaults.locator = dht.NewLocatorClient(dhtNode)
+	}
+
+	var ingressServer *ingress.Server
+	if cfg.Ingress != nil {
+		ingressServer, err = ingress.NewServer(log.WithLogger(ctx, log.G(ctx).Named("ingress")), *cfg.Ingress)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if defaults.locator == nil {
 		conn, err := xgrpc.NewWalletAuthenticatedClient(ctx, defaults.creds, cfg.Locator.Endpoint)
 		if err != nil {
@@ -185,6 +222,15 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Hub, error) {
 		defaults.locator = pb.NewLocatorClient(conn)
 	}
 
+	if defaults.rating == nil {
+		conn, err := xgrpc.NewWalletAuthenticatedClient(ctx, defaults.creds, cfg.Locator.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		defaults.rating = pb.NewRatingClient(conn)
+	}
+
 	if defaults.market == nil {
 		conn, err := xgrpc.NewWalletAuthenticatedClient(ctx, defaults.creds, cfg.Market.Endpoint)
 		if err != nil {
@@ -195,7 +241,7 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Hub, error) {
 	}
 
 	if defaults.cluster == nil {
-		defaults.cluster, defaults.clusterEvents, err = NewCluster(ctx, &cfg.Cluster, cfg.Endpoint, defaults.creds)
+		defaults.cluster, defaults.clusterEvents, err = NewCluster(log.WithLogger(ctx, log.G(ctx).Named("cluster")), &cfg.Cluster, cfg.Endpoint, defaults.creds)
 		if err != nil {
 			return nil, err
 		}
@@ -205,7 +251,7 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Hub, error) {
 		defaults.announcer = newLocatorAnnouncer(
 			defaults.ethKey,
 			defaults.locator,
-			cfg.Locator.UpdatePeriod,
+			cfg.Locator,
 			defaults.cluster)
 	}
 
@@ -219,7 +265,8 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Hub, error) {
 	}
 
 	wl := NewWhitelist(ctx, &cfg.Whitelist)
-	hubState, err := newState(ctx, acl, ethWrapper, defaults.market, defaults.cluster)
+	reporter := rating.NewReporter(defaults.ethKey, defaults.rating)
+	hubState, err := newState(ctx, acl, ethWrapper, defaults.market, defaults.cluster, reporter)
 	if err != nil {
 		return nil, err
 	}
@@ -230,6 +277,7 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Hub, error) {
 		cancel:           cancel,
 		gateway:          gate,
 		portPool:         portPool,
+		proxyConfig:      proxyConfig,
 		externalGrpc:     nil,
 		grpcEndpointAddr: grpcEndpointAddr,
 
@@ -239,11 +287,14 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Hub, error) {
 
 		eth:    ethWrapper,
 		market: defaults.market,
+		rating: defaults.rating,
 
 		certRotator: defaults.rot,
 		creds:       defaults.creds,
 
 		announcer:     defaults.announcer,
+		dht:           dhtNode,
+		ingress:       ingressServer,
 		cluster:       defaults.cluster,
 		clusterEvents: defaults.clusterEvents,
 
@@ -265,17 +316,33 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Hub, error) {
 		auth.Allow("StopTask").With(newDealAuthorization(ctx, hubState, newFromTaskDealExtractor(hubState))),
 		auth.Allow("JoinNetwork").With(newDealAuthorization(ctx, hubState, newFromNamedTaskDealExtractor(hubState, "TaskID"))),
 		auth.Allow("StartTask").With(newDealAuthorization(ctx, hubState, newFieldDealExtractor())),
-		auth.Allow("TaskLogs").With(newDealAuthorization(ctx, hubState, newFromTaskDealExtractor(hubState))),
+		auth.Allow("TaskLogs").With(newDealAuthorization(ctx, hubState, newFromDealTaskExtractor(hubState))),
+		auth.Allow("Exec").With(newDealAuthorization(ctx, hubState, newFromTaskDealExtractor(hubState))),
+		auth.Allow("PortForward").With(newDealAuthorization(ctx, hubState, newContextTaskDealExtractor(hubState))),
+		auth.Allow("CopyTo").With(newDealAuthorization(ctx, hubState, newContextTaskDealExtractor(hubState))),
+		auth.Allow("CopyFrom").With(newDealAuthorization(ctx, hubState, newFromTaskDealExtractor(hubState))),
 		auth.Allow("PushTask").With(newDealAuthorization(ctx, hubState, newContextDealExtractor())),
 		auth.Allow("PullTask").With(newDealAuthorization(ctx, hubState, newRequestDealExtractor(func(request interface{}) (DealID, error) {
 			return DealID(request.(*pb.PullTaskRequest).DealId), nil
 		}))),
+		auth.Allow("TerminateDeal").With(newMultiAuth(
+			auth.NewTransportAuthorization(h.ethAddr),
+			newDealAuthorization(ctx, hubState, newRequestDealExtractor(func(request interface{}) (DealID, error) {
+				return DealID(request.(*pb.DealCloseRequest).GetId()), nil
+			})),
+		)),
+		auth.Allow("ExtendDeal").With(newDealAuthorization(ctx, hubState, newRequestDealExtractor(func(request interface{}) (DealID, error) {
+			return DealID(request.(*pb.DealExtendRequest).GetId()), nil
+		}))),
 		auth.Allow("GetDealInfo").With(newDealAuthorization(ctx, hubState, newRequestDealExtractor(func(request interface{}) (DealID, error) {
 			return DealID(request.(*pb.ID).GetId()), nil
 		}))),
 		auth.Allow("ApproveDeal").With(newOrderAuthorization(hubState, OrderExtractor(func(request interface{}) (OrderID, error) {
 			return OrderID(request.(*pb.ApproveDealRequest).BidID), nil
 		}))),
+		auth.Allow("CancelProposal").With(newOrderAuthorization(hubState, OrderExtractor(func(request interface{}) (OrderID, error) {
+			return OrderID(request.(*pb.DealRequest).BidId), nil
+		}))),
 		auth.WithFallback(auth.NewDenyAuthorization()),
 	)
 
@@ -293,6 +360,10 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Hub, error) {
 	pb.RegisterHubServer(grpcServer, h)
 	grpc_prometheus.Register(grpcServer)
 
+	if err := h.reconcileRoutes(); err != nil {
+		log.G(h.ctx).Warn("failed to reconcile task routes", zap.Error(err))
+	}
+
 	return h, nil
 }
 
@@ -306,7 +377,21 @@ func (h *Hub) Serve() error {
 		return err
 	}
 
-	grpcL, err := npp.NewListener(h.ctx, h.cfg.Cluster.Endpoint, npp.WithRendezvous(rendezvousEndpoints, h.creds), npp.WithLogger(log.G(h.ctx)))
+	relayEndpoints, err := h.cfg.NPP.Relay.ConvertEndpoints()
+	if err != nil {
+		return err
+	}
+
+	nppOptions := []npp.Option{
+		npp.WithRendezvous(rendezvousEndpoints, h.creds),
+		npp.WithRelay(relayEndpoints, h.creds),
+		npp.WithLogger(log.G(h.ctx).Named("npp")),
+	}
+	if h.cfg.NPP.UDP {
+		nppOptions = append(nppOptions, npp.WithUDP())
+	}
+
+	grpcL, err := npp.NewListener(h.ctx, h.cfg.Cluster.Endpoint, nppOptions...)
 	if err != nil {
 		log.G(h.ctx).Error("failed to listen", zap.String("address", h.cfg.Cluster.Endpoint), zap.Error(err))
 		return err
@@ -343,6 +428,12 @@ func (h *Hub) Serve() error {
 
 	h.waiter.Go(h.runCluster)
 	h.waiter.Go(h.listenClusterEvents)
+	if h.dht != nil {
+		h.waiter.Go(h.dht.Serve)
+	}
+	if h.ingress != nil {
+		h.waiter.Go(h.ingress.Serve)
+	}
 	h.waiter.Go(h.startLocatorAnnouncer)
 
 	h.waiter.Wait()
@@ -615,7 +706,11 @@ func (h *Hub) generateTaskID() string {
 	return uuid.New()
 }
 
-func (h *Hub) startTask(ctx context.Context, request *structs.StartTaskRequest) (*pb.HubStartTaskReply, error) {
+func (h *Hub) startTask(ctx context.Context, request *structs.StartTaskRequest) (_ *pb.HubStartTaskReply, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "hub.startTask")
+	span.SetTag("deal_id", request.GetDealId())
+	defer func() { tracing.Finish(span, err) }()
+
 	allowed, ref, err := h.whitelist.Allowed(ctx, request.Container.Registry, request.Container.Image, request.Container.Auth)
 	if err != nil {
 		return nil, err
@@ -625,18 +720,40 @@ func (h *Hub) startTask(ctx context.Context, request *structs.StartTaskRequest)
 		return nil, errImageForbidden
 	}
 
-	deal, err := h.eth.GetDeal(request.GetDeal().Id)
-	if err != nil {
-		return nil, err
+	if err := validateHealthChecks(request.Container.GetHealthChecks()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := validateSidecars(request.GetSidecars()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	dealID := DealID(deal.GetId())
+	for _, sidecar := range request.GetSidecars() {
+		allowed, ref, err := h.whitelist.Allowed(ctx, sidecar.Registry, sidecar.Image, sidecar.Auth)
+		if err != nil {
+			return nil, err
+		}
+
+		if !allowed {
+			return nil, errImageForbidden
+		}
+
+		sidecar.Registry = reference.Domain(ref)
+		sidecar.Image = reference.Path(ref)
+	}
+
+	dealID := DealID(request.GetDeal().Id)
 	meta, err := h.state.GetDealMeta(dealID)
 	if err != nil {
 		// Hub knows nothing about this deal
 		return nil, errDealNotFound
 	}
 
+	// The deal may be extended, so check the one currently paying for it.
+	if _, err := h.eth.GetDeal(meta.activeID().String()); err != nil {
+		return nil, err
+	}
+
 	// Extract proper miner associated with the deal specified.
 	miner, usage, err := h.state.GetMinerByOrder(OrderID(meta.BidID))
 	if err != nil {
@@ -644,6 +761,7 @@ func (h *Hub) startTask(ctx context.Context, request *structs.StartTaskRequest)
 	}
 
 	taskID := h.generateTaskID()
+	span.SetTag("task_id", taskID)
 	container := request.Container
 	container.Registry = reference.Domain(ref)
 	container.Image = reference.Path(ref)
@@ -652,6 +770,7 @@ func (h *Hub) startTask(ctx context.Context, request *structs.StartTaskRequest)
 		OrderId:   request.GetDealId(), // TODO: WTF?
 		Id:        taskID,
 		Container: container,
+		Sidecars:  request.GetSidecars(),
 		Resources: &pb.TaskResourceRequirements{
 			CPUCores:   uint64(usage.NumCPUs),
 			MaxMemory:  usage.Memory,
@@ -668,20 +787,26 @@ func (h *Hub) startTask(ctx context.Context, request *structs.StartTaskRequest)
 		return nil, status.Errorf(codes.Internal, "failed to start %v", err)
 	}
 
-	info := TaskInfo{*request, *response, taskID, dealID, miner.uuid, nil}
+	routes := miner.registerRoutes(taskID, response.GetPortMap())
+
+	info := TaskInfo{*request, *response, taskID, dealID, miner.uuid, nil, time.Now(), nil}
+	for _, route := range routes {
+		info.Routes = append(info.Routes, route.route)
+	}
 
 	err = h.state.SaveTask(DealID(request.GetDealId()), &info)
 	if err != nil {
+		miner.deregisterRoutes(info.Routes)
 		miner.Client.Stop(ctx, &pb.ID{Id: taskID})
 		return nil, err
 	}
 
+	miner.health.Watch(taskID, container.GetHealthChecks(), info.Routes)
+
 	if err := h.state.Dump(); err != nil {
 		log.G(h.ctx).Error("failed to dump state", zap.Error(err))
 	}
 
-	routes := miner.registerRoutes(taskID, response.GetPortMap())
-
 	// TODO: Synchronize routes with the cluster.
 	reply := &pb.HubStartTaskReply{
 		Id:         taskID,
@@ -696,6 +821,10 @@ func (h *Hub) startTask(ctx context.Context, request *structs.StartTaskRequest)
 		)
 	}
 
+	if h.ingress != nil && len(routes) > 0 {
+		reply.Endpoint = append(reply.Endpoint, h.ingress.URL(taskID))
+	}
+
 	tasksGauge.Inc()
 
 	return reply, nil
@@ -715,6 +844,69 @@ func (h *Hub) StopTask(ctx context.Context, request *pb.ID) (*pb.Empty, error) {
 	return &pb.Empty{}, nil
 }
 
+// TerminateDeal records request to close the deal before its end time made
+// either by the buyer or by the Hub owner. The deal is settled and closed
+// asynchronously while monitoring the state.
+func (h *Hub) TerminateDeal(ctx context.Context, request *pb.DealCloseRequest) (*pb.DealSettlement, error) {
+	log.G(h.ctx).Info("handling TerminateDeal request", zap.Any("request", request))
+
+	wallet, err := auth.ExtractWalletFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settlement, err := h.state.TerminateDeal(DealID(request.GetId()), wallet.Hex(), request.GetReason())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.state.Dump(); err != nil {
+		log.G(h.ctx).Error("failed to dump state", zap.Error(err))
+	}
+
+	return settlement, nil
+}
+
+// ExtendDeal binds the continuation deal opened by the buyer to the given
+// one. Running tasks, networks and routes are kept when the current deal
+// expires, because the continuation is accepted instead of releasing
+// resources.
+func (h *Hub) ExtendDeal(ctx context.Context, request *pb.DealExtendRequest) (*pb.Empty, error) {
+	log.G(h.ctx).Info("handling ExtendDeal request", zap.Any("request", request))
+
+	meta, err := h.state.GetDealMeta(DealID(request.GetId()))
+	if err != nil {
+		return nil, err
+	}
+
+	continuationID := DealID(request.GetContinuationID())
+	buyerID := common.HexToAddress(meta.Order.GetByuerID())
+
+	deal, err := h.eth.WaitForDealCreated(continuationID, buyerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if deal.GetStatus() != pb.DealStatus_PENDING {
+		return nil, status.Errorf(codes.FailedPrecondition, "continuation deal must be pending, but it is %s", deal.GetStatus())
+	}
+
+	if cmp := deal.Price.Cmp(pb.NewBigInt(meta.Order.GetTotalPrice())); cmp != 0 {
+		return nil, fmt.Errorf("prices are not equal: %v != %v",
+			deal.Price.Unwrap().String(), meta.Order.GetTotalPrice())
+	}
+
+	if err := h.state.ExtendDeal(meta.ID, continuationID); err != nil {
+		return nil, err
+	}
+
+	if err := h.state.Dump(); err != nil {
+		log.G(h.ctx).Error("failed to dump state", zap.Error(err))
+	}
+
+	return &pb.Empty{}, nil
+}
+
 func (h *Hub) GetDealInfo(ctx context.Context, id *pb.ID) (*pb.DealInfoReply, error) {
 	meta, err := h.state.GetDealMeta(DealID(id.Id))
 	if err != nil {
@@ -728,6 +920,15 @@ func (h *Hub) GetDealInfo(ctx context.Context, id *pb.ID) (*pb.DealInfoReply, er
 		Completed: &pb.StatusMapReply{Statuses: make(map[string]*pb.TaskStatusReply)},
 	}
 
+	if meta.Termination != nil {
+		r.Settlement = meta.Termination.Settlement(meta)
+	}
+
+	r.ActiveID = meta.activeID().String()
+	for _, ext := range meta.Extensions {
+		r.Extensions = append(r.Extensions, ext.String())
+	}
+
 	for _, t := range meta.Tasks {
 		mctx, ok := h.state.GetMinerByID(t.MinerId)
 		if !ok {
@@ -773,7 +974,9 @@ func (h *Hub) TaskLogs(request *pb.TaskLogsRequest, server pb.Hub_TaskLogsServer
 		return err
 	}
 
-	task, ok := h.state.getTaskByID(request.Id)
+	// Logs of finished tasks are persisted by the Worker, so they are
+	// available while the deal is active.
+	task, ok := h.state.GetDealTask(request.Id)
 	if !ok {
 		return errors.Errorf("no such task: %s", request.Id)
 	}
@@ -802,6 +1005,225 @@ func (h *Hub) TaskLogs(request *pb.TaskLogsRequest, server pb.Hub_TaskLogsServer
 	}
 }
 
+// Exec executes a command inside the task container, relaying the session
+// to the Worker over its connection to the Hub, so the Worker is not
+// required to be reachable by the buyer.
+func (h *Hub) Exec(stream pb.Hub_ExecServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	log.G(h.ctx).Info("handling Exec request", zap.String("id", request.Id), zap.Strings("cmd", request.Cmd))
+
+	if err := h.eventAuthorization.Authorize(stream.Context(), auth.Event(hubAPIPrefix+"Exec"), request); err != nil {
+		return err
+	}
+
+	miner, err := h.state.GetMinerByTask(request.Id)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	client, err := miner.Client.Exec(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	if err := client.Send(request); err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			request, err := stream.Recv()
+			if err != nil {
+				client.CloseSend()
+				return
+			}
+
+			if err := client.Send(request); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		reply, err := client.Recv()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(reply); err != nil {
+			return err
+		}
+	}
+}
+
+// PortForward proxies a TCP connection to the task port through the Worker
+// running the task.
+//
+// The task ID and the port are passed via "task" and "port" metadata keys.
+func (h *Hub) PortForward(stream pb.Hub_PortForwardServer) error {
+	if err := h.eventAuthorization.Authorize(stream.Context(), auth.Event(hubAPIPrefix+"PortForward"), nil); err != nil {
+		return err
+	}
+
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	if len(md["port"]) == 0 {
+		return status.Error(codes.InvalidArgument, "port is required")
+	}
+
+	taskID, port := md["task"][0], md["port"][0]
+
+	log.G(h.ctx).Info("handling PortForward request", zap.String("id", taskID), zap.String("port", port))
+
+	task, ok := h.state.GetTaskByID(taskID)
+	if !ok {
+		return status.Errorf(codes.NotFound, "no such task: %s", taskID)
+	}
+
+	target, err := task.forwardTarget(port)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	miner, ok := h.state.GetMinerByID(task.MinerId)
+	if !ok {
+		return status.Errorf(codes.NotFound, "no miner %s for task %s", task.MinerId, taskID)
+	}
+
+	client, err := miner.Client.Forward(metadata.NewOutgoingContext(stream.Context(), metadata.Pairs("target", target)))
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			chunk, err := stream.Recv()
+			if err != nil {
+				client.CloseSend()
+				return
+			}
+
+			if err := client.Send(chunk); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		chunk, err := client.Recv()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// CopyTo extracts the tar archive streamed into the task container path on
+// the Worker, relaying the progress back.
+//
+// The task ID and the path are passed via "task" and "path" metadata keys.
+func (h *Hub) CopyTo(stream pb.Hub_CopyToServer) error {
+	if err := h.eventAuthorization.Authorize(stream.Context(), auth.Event(hubAPIPrefix+"CopyTo"), nil); err != nil {
+		return err
+	}
+
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	if len(md["path"]) == 0 {
+		return status.Error(codes.InvalidArgument, "path is required")
+	}
+
+	taskID, path := md["task"][0], md["path"][0]
+
+	log.G(h.ctx).Info("handling CopyTo request", zap.String("id", taskID), zap.String("path", path))
+
+	miner, err := h.state.GetMinerByTask(taskID)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	client, err := miner.Client.CopyTo(metadata.NewOutgoingContext(stream.Context(), metadata.Pairs("task", taskID, "path", path)))
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			chunk, err := stream.Recv()
+			if err != nil {
+				client.CloseSend()
+				return
+			}
+
+			if err := client.Send(chunk); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		progress, err := client.Recv()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(progress); err != nil {
+			return err
+		}
+	}
+}
+
+// CopyFrom streams a tar archive of the task container path from the Worker.
+func (h *Hub) CopyFrom(request *pb.CopyFromRequest, stream pb.Hub_CopyFromServer) error {
+	log.G(h.ctx).Info("handling CopyFrom request", zap.String("id", request.Id), zap.String("path", request.Path))
+
+	if err := h.eventAuthorization.Authorize(stream.Context(), auth.Event(hubAPIPrefix+"CopyFrom"), request); err != nil {
+		return err
+	}
+
+	miner, err := h.state.GetMinerByTask(request.Id)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	client, err := miner.Client.CopyFrom(stream.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := client.Recv()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+}
+
 func (h *Hub) ProposeDeal(ctx context.Context, r *pb.DealRequest) (*pb.Empty, error) {
 	log.G(h.ctx).Info("handling ProposeDeal request", zap.Any("request", r))
 	request, err := structs.NewDealRequest(r)
@@ -844,6 +1266,11 @@ func (h *Hub) ProposeDeal(ctx context.Context, r *pb.DealRequest) (*pb.Empty, er
 		return nil, status.Errorf(codes.InvalidArgument, "bid's duration must fit in ask")
 	}
 
+	// Verify that the buyer is trusted enough to sell resources to.
+	if err := rating.Check(ctx, h.rating, bidOrder.GetByuerID(), askOrder.GetSlot().GetBuyerRating()); err != nil {
+		return nil, err
+	}
+
 	// Verify that bid price >= ask price, i.e we're not selling our resources
 	// with lesser price than expected.
 	if bidOrder.PricePerSecond.Cmp(askOrder.PricePerSecond) < 0 {
@@ -897,9 +1324,30 @@ func (h *Hub) ProposeDeal(ctx context.Context, r *pb.DealRequest) (*pb.Empty, er
 	return &pb.Empty{}, nil
 }
 
-func (h *Hub) ApproveDeal(ctx context.Context, request *pb.ApproveDealRequest) (*pb.Empty, error) {
+// CancelProposal drops the reservation made by ProposeDeal, so the BID can be
+// proposed again without waiting for the reservation to expire.
+func (h *Hub) CancelProposal(ctx context.Context, request *pb.DealRequest) (*pb.Empty, error) {
+	log.G(h.ctx).Info("handling CancelProposal request", zap.Any("request", request))
+
+	if err := h.state.CancelOrder(OrderID(request.GetBidId())); err != nil {
+		return nil, status.Errorf(codes.NotFound, "%v", err)
+	}
+
+	if err := h.state.Dump(); err != nil {
+		log.G(h.ctx).Error("failed to dump state", zap.Error(err))
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (h *Hub) ApproveDeal(ctx context.Context, request *pb.ApproveDealRequest) (_ *pb.Empty, err error) {
 	log.G(h.ctx).Info("handling ApproveDeal request", zap.Any("request", request))
 
+	span, ctx := opentracing.StartSpanFromContext(ctx, "hub.approveDeal")
+	span.SetTag("bid_id", request.GetBidID())
+	span.SetTag("ask_id", request.GetAskID())
+	defer func() { tracing.Finish(span, err) }()
+
 	bidOrder, err := h.market.GetOrderByID(h.ctx, &pb.ID{Id: request.GetBidID()})
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "bid not found")
@@ -913,8 +1361,11 @@ func (h *Hub) ApproveDeal(ctx context.Context, request *pb.ApproveDealRequest) (
 	// Ensure that deal is created in BC, if not - wait.
 	dealID := DealID(request.GetDealID().Unwrap().String())
 	buyerID := common.HexToAddress(bidOrder.GetByuerID())
+	span.SetTag("deal_id", dealID.String())
 
+	waitSpan, _ := opentracing.StartSpanFromContext(ctx, "hub.waitForDealCreated")
 	deal, err := h.eth.WaitForDealCreated(dealID, buyerID)
+	tracing.Finish(waitSpan, err)
 	if err != nil {
 		log.G(h.ctx).Error("failed to find deal for approving",
 			zap.Stringer("dealID", dealID),
@@ -937,7 +1388,9 @@ func (h *Hub) ApproveDeal(ctx context.Context, request *pb.ApproveDealRequest) (
 	}
 
 	// Accept deal.
+	acceptSpan, _ := opentracing.StartSpanFromContext(ctx, "hub.acceptDeal")
 	err = h.eth.AcceptDeal(dealID.String())
+	tracing.Finish(acceptSpan, err)
 	if err != nil {
 		log.G(ctx).Error("failed to accept deal", zap.Stringer("dealID", dealID), zap.Error(err))
 		return nil, err
@@ -1050,7 +1503,7 @@ func (h *Hub) InsertSlot(ctx context.Context, request *pb.InsertSlotRequest) (*p
 		return nil, err
 	}
 
-	id, err := h.state.AddSlot(h.ctx, order)
+	id, err := h.state.AddSlot(h.ctx, order, request.GetPricing())
 	if err != nil {
 		return nil, err
 	}
@@ -1195,6 +1648,12 @@ func (h *Hub) Close() {
 	h.cancel()
 	h.externalGrpc.Stop()
 	h.minerListener.Close()
+	if h.dht != nil {
+		h.dht.Close()
+	}
+	if h.ingress != nil {
+		h.ingress.Close()
+	}
 	if h.gateway != nil {
 		h.gateway.Close()
 	}
@@ -0,0 +1,126 @@
+package hub
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	pb "github.com/sonm-io/core/proto"
+)
+
+// DealTermination describes a request to close the deal before its end time.
+type DealTermination struct {
+	// Initiator is the Eth address of the party requested termination.
+	Initiator   string
+	Reason      string
+	RequestedAt time.Time
+	// WorkTime is the total time tasks were running within the deal up to
+	// the termination request.
+	WorkTime time.Duration
+	// Refunded is set after the unused part of the deal price has been
+	// transferred back to the buyer from the Hub wallet.
+	Refunded bool
+	// RefundPending is persisted together with RefundTx right before the
+	// refund transaction is sent, so a Hub that takes over after a crash or
+	// a leadership loss in the middle checks its receipt instead of paying
+	// again.
+	RefundPending bool
+	// RefundTx is the hash of the refund transaction.
+	RefundTx string
+}
+
+// newDealTermination builds termination request for the given deal,
+// computing work time from the recorded task usage.
+func newDealTermination(meta *DealMeta, initiator, reason string, now time.Time) *DealTermination {
+	return &DealTermination{
+		Initiator:   initiator,
+		Reason:      reason,
+		RequestedAt: now,
+		WorkTime:    dealWorkTime(meta.Tasks, meta.ActiveSince, now),
+	}
+}
+
+// dealWorkTime returns the total time at least one task of the deal was
+// running since the given time. Overlapping tasks are accounted only once,
+// still running tasks are accounted up to now.
+func dealWorkTime(tasks []*TaskInfo, since, now time.Time) time.Duration {
+	type interval struct {
+		from, to time.Time
+	}
+
+	intervals := make([]interval, 0, len(tasks))
+	for _, task := range tasks {
+		if task.StartTime.IsZero() {
+			continue
+		}
+
+		to := now
+		if task.EndTime != nil && task.EndTime.Before(now) {
+			to = *task.EndTime
+		}
+
+		from := task.StartTime
+		if from.Before(since) {
+			from = since
+		}
+
+		if to.After(from) {
+			intervals = append(intervals, interval{from: from, to: to})
+		}
+	}
+
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].from.Before(intervals[j].from)
+	})
+
+	var total time.Duration
+	var last time.Time
+	for _, it := range intervals {
+		if it.from.Before(last) {
+			it.from = last
+		}
+
+		if it.to.After(it.from) {
+			total += it.to.Sub(it.from)
+			last = it.to
+		}
+	}
+
+	return total
+}
+
+// Settlement splits the price of the active deal between parties in
+// proportion to the work time.
+func (t *DealTermination) Settlement(meta *DealMeta) *pb.DealSettlement {
+	workTime := uint64(t.WorkTime / time.Second)
+
+	total := meta.Order.GetTotalPrice()
+	amount := big.NewInt(0).Mul(meta.Order.PricePerSecond.Unwrap(), big.NewInt(int64(workTime)))
+	if amount.Cmp(total) > 0 {
+		amount = total
+	}
+
+	return &pb.DealSettlement{
+		Initiator:   t.Initiator,
+		Reason:      t.Reason,
+		RequestedAt: &pb.Timestamp{Seconds: t.RequestedAt.Unix()},
+		WorkTime:    workTime,
+		Amount:      pb.NewBigInt(amount),
+		Refund:      pb.NewBigInt(big.NewInt(0).Sub(total, amount)),
+	}
+}
+
+// buyerOutcome returns the outcome of the released deal from the Hub's
+// point of view: the buyer is blamed only for closing the deal early.
+func buyerOutcome(meta *DealMeta) pb.DealOutcome {
+	if meta.Termination == nil {
+		return pb.DealOutcome_COMPLETED
+	}
+
+	if common.HexToAddress(meta.Termination.Initiator) == common.HexToAddress(meta.Order.GetByuerID()) {
+		return pb.DealOutcome_CLOSED_EARLY
+	}
+
+	return pb.DealOutcome_COMPLETED
+}
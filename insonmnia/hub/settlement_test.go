@@ -0,0 +1,181 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sonm-io/core/insonmnia/structs"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func timeRef(t time.Time) *time.Time {
+	return &t
+}
+
+func TestDealWorkTime(t *testing.T) {
+	now := time.Now()
+
+	tasks := []*TaskInfo{
+		// Overlaps with the next one.
+		{StartTime: now.Add(-100 * time.Second), EndTime: timeRef(now.Add(-60 * time.Second))},
+		{StartTime: now.Add(-80 * time.Second), EndTime: timeRef(now.Add(-50 * time.Second))},
+		// Still running.
+		{StartTime: now.Add(-20 * time.Second)},
+		// Restored from an old state, unknown start time.
+		{EndTime: timeRef(now.Add(-10 * time.Second))},
+	}
+
+	assert.Equal(t, 70*time.Second, dealWorkTime(tasks, time.Time{}, now))
+	assert.Equal(t, 40*time.Second, dealWorkTime(tasks, now.Add(-70*time.Second), now))
+	assert.Equal(t, time.Duration(0), dealWorkTime(nil, time.Time{}, now))
+}
+
+func TestDealTerminationSettlement(t *testing.T) {
+	meta := &DealMeta{
+		Order: structs.Order{Order: &pb.Order{
+			PricePerSecond: pb.NewBigInt(big.NewInt(10)),
+			Slot:           &pb.Slot{Duration: 100},
+		}},
+	}
+
+	termination := &DealTermination{Initiator: "0x1", Reason: "broken", WorkTime: 30 * time.Second}
+	settlement := termination.Settlement(meta)
+
+	assert.Equal(t, "0x1", settlement.GetInitiator())
+	assert.Equal(t, "broken", settlement.GetReason())
+	assert.Equal(t, uint64(30), settlement.GetWorkTime())
+	assert.Equal(t, big.NewInt(300), settlement.GetAmount().Unwrap())
+	assert.Equal(t, big.NewInt(700), settlement.GetRefund().Unwrap())
+}
+
+func TestDealTerminationSettlementNeverExceedsPrice(t *testing.T) {
+	meta := &DealMeta{
+		Order: structs.Order{Order: &pb.Order{
+			PricePerSecond: pb.NewBigInt(big.NewInt(10)),
+			Slot:           &pb.Slot{Duration: 100},
+		}},
+	}
+
+	termination := &DealTermination{WorkTime: 200 * time.Second}
+	settlement := termination.Settlement(meta)
+
+	assert.Equal(t, big.NewInt(1000), settlement.GetAmount().Unwrap())
+	assert.Equal(t, 0, settlement.GetRefund().Unwrap().Sign())
+}
+
+// refundingETH records refunds and closed deals, failing refunds while fail
+// is set. Refund transactions have the given status, being pending while
+// it is nil.
+type refundingETH struct {
+	ETH
+	fail     bool
+	status   *bool
+	refunds  []*big.Int
+	closed   []DealID
+	onRefund func()
+}
+
+func (e *refundingETH) Refund(buyerID string, amount *big.Int, signed func(tx string) error) error {
+	if e.fail {
+		return errors.New("failed to transfer")
+	}
+
+	if err := signed(fmt.Sprintf("0x%d", len(e.refunds))); err != nil {
+		return err
+	}
+
+	if e.onRefund != nil {
+		e.onRefund()
+	}
+
+	e.refunds = append(e.refunds, amount)
+	return nil
+}
+
+func (e *refundingETH) RefundStatus(tx string) (bool, error) {
+	if e.status == nil {
+		return false, errors.New("not found")
+	}
+
+	return *e.status, nil
+}
+
+func (e *refundingETH) CloseDeal(id DealID) error {
+	e.closed = append(e.closed, id)
+	return nil
+}
+
+func TestStateCloseTerminatedDealsRefundsOnce(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	cluster := NewMockCluster(mock)
+	cluster.EXPECT().IsLeader().AnyTimes().Return(true)
+	cluster.EXPECT().Synchronize(gomock.Any()).AnyTimes().Return(nil)
+
+	termination := &DealTermination{WorkTime: 30 * time.Second}
+	meta := &DealMeta{
+		ID: "1",
+		Order: structs.Order{Order: &pb.Order{
+			ByuerID:        "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD",
+			PricePerSecond: pb.NewBigInt(big.NewInt(10)),
+			Slot:           &pb.Slot{Duration: 100},
+		}},
+		Termination: termination,
+	}
+
+	eth := &refundingETH{fail: true}
+	// The transaction must be already recorded when it is sent.
+	eth.onRefund = func() { assert.True(t, termination.RefundPending) }
+
+	s := &state{
+		ctx:     context.Background(),
+		eth:     eth,
+		cluster: cluster,
+		deals:   map[DealID]*DealMeta{"1": meta},
+	}
+
+	// Failed transfers are not sent, so they are retried.
+	s.closeTerminatedDealsTS()
+	assert.Empty(t, eth.closed)
+	assert.False(t, termination.RefundPending)
+	assert.False(t, termination.Refunded)
+
+	eth.fail = false
+	s.closeTerminatedDealsTS()
+	assert.Equal(t, []*big.Int{big.NewInt(700)}, eth.refunds)
+	assert.Equal(t, "0x0", termination.RefundTx)
+
+	// Pending refund is never repeated, the deal stays open.
+	s.closeTerminatedDealsTS()
+	assert.Len(t, eth.refunds, 1)
+	assert.Empty(t, eth.closed)
+	assert.True(t, termination.RefundPending)
+	assert.False(t, termination.Refunded)
+
+	// Failed refund transaction is retried.
+	failed := false
+	eth.status = &failed
+	s.closeTerminatedDealsTS()
+	assert.Empty(t, eth.closed)
+	assert.False(t, termination.RefundPending)
+
+	eth.status = nil
+	s.closeTerminatedDealsTS()
+	assert.Len(t, eth.refunds, 2)
+	assert.Equal(t, "0x1", termination.RefundTx)
+
+	succeeded := true
+	eth.status = &succeeded
+	s.closeTerminatedDealsTS()
+	assert.Len(t, eth.refunds, 2)
+	assert.Equal(t, []DealID{"1"}, eth.closed)
+	assert.False(t, termination.RefundPending)
+	assert.True(t, termination.Refunded)
+}
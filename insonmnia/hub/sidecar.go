@@ -0,0 +1,47 @@
+package hub
+
+import (
+	"errors"
+	"fmt"
+
+	pb "github.com/sonm-io/core/proto"
+)
+
+// validateSidecars verifies sidecar containers declared in the task spec.
+//
+// Sidecars share the network namespace and volumes of the main container, so
+// they are not allowed to declare their own ones, as well as ports-related
+// settings like health checks.
+func validateSidecars(sidecars []*pb.Container) error {
+	names := map[string]bool{}
+	for _, sidecar := range sidecars {
+		name := sidecar.GetName()
+		if name == "" {
+			return errors.New("sidecar name is required")
+		}
+
+		if names[name] {
+			return fmt.Errorf("duplicate sidecar name %q", name)
+		}
+		names[name] = true
+
+		if sidecar.GetImage() == "" {
+			return fmt.Errorf("sidecar %q image is required", name)
+		}
+
+		switch {
+		case len(sidecar.GetVolumes()) > 0 || len(sidecar.GetMounts()) > 0:
+			return fmt.Errorf("sidecar %q can not declare volumes, they are shared with the main container", name)
+		case len(sidecar.GetNetworks()) > 0:
+			return fmt.Errorf("sidecar %q can not declare networks, they are shared with the main container", name)
+		case len(sidecar.GetHealthChecks()) > 0:
+			return fmt.Errorf("sidecar %q can not declare health checks", name)
+		case sidecar.GetPublicKeyData() != "":
+			return fmt.Errorf("sidecar %q can not declare SSH public key", name)
+		case sidecar.GetCommitOnStop():
+			return fmt.Errorf("sidecar %q can not be committed on stop", name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package hub
+
+import (
+	"testing"
+
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSidecars(t *testing.T) {
+	assert.NoError(t, validateSidecars(nil))
+	assert.NoError(t, validateSidecars([]*pb.Container{
+		{Name: "logs", Image: "fluentd", Env: map[string]string{"LEVEL": "info"}},
+		{Name: "model", Image: "tensorflow/serving"},
+	}))
+
+	invalid := [][]*pb.Container{
+		{{Image: "fluentd"}},
+		{{Name: "logs"}},
+		{{Name: "logs", Image: "fluentd"}, {Name: "logs", Image: "fluentd"}},
+		{{Name: "logs", Image: "fluentd", Mounts: []string{"data:/data:rw"}}},
+		{{Name: "logs", Image: "fluentd", Volumes: map[string]*pb.Volume{"data": {Driver: "cifs"}}}},
+		{{Name: "logs", Image: "fluentd", Networks: []*pb.NetworkSpec{{Type: "tinc"}}}},
+		{{Name: "logs", Image: "fluentd", HealthChecks: []*pb.HealthCheck{{Port: "80/tcp"}}}},
+		{{Name: "logs", Image: "fluentd", PublicKeyData: "ssh-rsa AAAA"}},
+		{{Name: "logs", Image: "fluentd", CommitOnStop: true}},
+	}
+
+	for _, sidecars := range invalid {
+		assert.Error(t, validateSidecars(sidecars), "%v", sidecars)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/pborman/uuid"
 	"github.com/pkg/errors"
 	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/insonmnia/rating"
 	"github.com/sonm-io/core/insonmnia/resource"
 	"github.com/sonm-io/core/insonmnia/structs"
 	pb "github.com/sonm-io/core/proto"
@@ -36,6 +39,11 @@ func (o *ReservedOrder) IsExpired() bool {
 type askPlan struct {
 	ID    string
 	Order *structs.Order
+	// Pricing describes how the plan is repriced, nil for fixed price plans.
+	Pricing *pb.PricingPolicy
+	// BasePrice is the price specified on plan creation, used when the
+	// policy has neither unit prices nor matching BIDs.
+	BasePrice *pb.BigInt
 }
 
 type stateJSON struct {
@@ -54,6 +62,7 @@ type state struct {
 	eth     ETH
 	cluster Cluster
 	market  pb.MarketClient
+	rating  *rating.Reporter
 
 	acl              *workerACLStorage
 	deals            map[DealID]*DealMeta
@@ -64,13 +73,14 @@ type state struct {
 	deviceProperties map[string]DeviceProperties
 }
 
-func newState(ctx context.Context, acl *workerACLStorage, eth ETH, market pb.MarketClient, cluster Cluster) (
-	*state, error) {
+func newState(ctx context.Context, acl *workerACLStorage, eth ETH, market pb.MarketClient, cluster Cluster,
+	reporter *rating.Reporter) (*state, error) {
 	out := &state{
 		ctx:     ctx,
 		eth:     eth,
 		cluster: cluster,
 		market:  market,
+		rating:  reporter,
 
 		acl:              acl,
 		deals:            make(map[DealID]*DealMeta),
@@ -187,6 +197,8 @@ func (s *state) monitoringStep() error {
 		return err
 	}
 
+	s.repricePlansTS()
+
 	if err := s.checkAnnouncesTS(); err != nil {
 		log.G(s.ctx).Error("failed to check announces", zap.Error(err))
 		return err
@@ -198,6 +210,7 @@ func (s *state) monitoringStep() error {
 	}
 
 	s.closeExpiredDealsTS()
+	s.closeTerminatedDealsTS()
 
 	s.mu.Lock()
 	if err := s.dump(); err != nil {
@@ -221,7 +234,7 @@ func (s *state) checkAcceptedDealsTS() error {
 	defer s.mu.Unlock()
 
 	for _, acceptedDeal := range acceptedDeals {
-		deal, ok := s.deals[DealID(acceptedDeal.Id)]
+		deal, ok := s.getDealMetaByActiveID(DealID(acceptedDeal.Id))
 		if !ok {
 			continue
 		}
@@ -252,14 +265,18 @@ func (s *state) checkClosedDealsTS() error {
 
 	ordersToRepublish := map[DealID]OrderID{}
 	for _, closedDeal := range closedDeals {
-		dealID := DealID(closedDeal.Id)
-		deal, ok := s.deals[dealID]
+		// Closing the deal that has been already superseded by its
+		// continuation must not affect running tasks.
+		deal, ok := s.getDealMetaByActiveID(DealID(closedDeal.Id))
 		if !ok {
 			continue
 		}
 
+		dealID := deal.ID
 		orderID := OrderID(deal.Order.GetID())
 
+		s.closeExtensions(deal)
+
 		if err := s.releaseDeal(dealID); err != nil {
 			log.G(s.ctx).Error("failed to release deal resources",
 				zap.Stringer("dealID", dealID),
@@ -269,6 +286,8 @@ func (s *state) checkClosedDealsTS() error {
 			continue
 		}
 
+		go s.rating.Report(s.ctx, dealID.String(), deal.Order.GetByuerID(), buyerOutcome(deal), "")
+
 		miner, ok := s.getMinerByID(deal.MinerID)
 		if !ok {
 			continue
@@ -339,6 +358,90 @@ func (s *state) checkAnnouncesTS() error {
 	return nil
 }
 
+// Synchronized by `s.mu`.
+func (s *state) repricePlansTS() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.cluster.IsLeader() {
+		log.S(s.ctx).Info("not a leader, skipping repricePlans()")
+		return
+	}
+
+	for _, plan := range s.askPlans {
+		if plan.Pricing == nil {
+			continue
+		}
+
+		price := s.planPrice(s.ctx, plan)
+		if price.Cmp(plan.Order.GetPricePerSecond().Unwrap()) == 0 {
+			continue
+		}
+
+		log.G(s.ctx).Info("repricing ask plan",
+			zap.String("id", plan.ID),
+			zap.String("old", plan.Order.GetPricePerSecond().Unwrap().String()),
+			zap.String("new", price.String()),
+		)
+
+		announced := plan.Order.Id != ""
+		if announced {
+			s.deannouncePlan(s.ctx, plan)
+			if plan.Order.Id != "" {
+				// Keep the old price until the order is cancelled.
+				continue
+			}
+		}
+
+		plan.Order.PricePerSecond = pb.NewBigInt(price)
+		if announced {
+			s.announcePlan(s.ctx, plan)
+		}
+	}
+}
+
+// planPrice calculates the current price of the given plan, fetching
+// matching BIDs from the market if its policy requires.
+func (s *state) planPrice(ctx context.Context, plan *askPlan) *big.Int {
+	var bids []*pb.Order
+	if plan.Pricing.GetFollowMarket() {
+		reply, err := s.market.GetOrders(ctx, &pb.GetOrdersRequest{
+			Order: &pb.Order{
+				OrderType: pb.OrderType_BID,
+				ByuerID:   plan.Order.GetByuerID(),
+				Slot:      plan.Order.Unwrap().GetSlot(),
+			},
+			Count: 100,
+		})
+		if err != nil {
+			log.G(ctx).Warn("failed to fetch BIDs from market", zap.String("plan", plan.ID), zap.Error(err))
+		} else {
+			bids = reply.GetOrders()
+		}
+	}
+
+	var workers [][]string
+	for _, miner := range s.miners {
+		if miner.capabilities == nil {
+			continue
+		}
+
+		devices := make([]string, 0, len(miner.capabilities.GPU))
+		for _, dev := range miner.capabilities.GPU {
+			devices = append(devices, dev.GetDeviceName())
+		}
+
+		workers = append(workers, devices)
+	}
+
+	base := plan.Order.GetPricePerSecond().Unwrap()
+	if plan.BasePrice != nil {
+		base = plan.BasePrice.Unwrap()
+	}
+
+	return planPrice(plan.Pricing, base, plan.Order.Unwrap().GetSlot().GetResources(), workers, bids)
+}
+
 // Synchronized by `s.mu`.
 func (s *state) checkOrdersTS() error {
 	s.mu.Lock()
@@ -386,18 +489,258 @@ func (s *state) closeExpiredDealsTS() {
 	}
 
 	now := time.Now()
-	for dealID, dealMeta := range s.deals {
+	for _, dealMeta := range s.deals {
 		if now.After(dealMeta.EndTime) {
+			dealID := dealMeta.activeID()
 			if err := s.eth.CloseDeal(dealID); err != nil {
 				log.G(s.ctx).Error("failed to close deal using blockchain API",
 					zap.Stringer("dealID", dealID),
 					zap.Error(err),
 				)
+				continue
 			}
+
+			s.activateExtension(dealMeta, now)
 		}
 	}
 }
 
+// activateExtension accepts the next continuation deal, so it becomes
+// active without touching running tasks. Continuations that cannot be
+// accepted are closed to release the buyer funds. Ones failed to close are
+// kept, so they are closed with the rest when the deal is released.
+func (s *state) activateExtension(dealMeta *DealMeta, now time.Time) {
+	var rejected []DealID
+	defer func() {
+		dealMeta.Extensions = append(rejected, dealMeta.Extensions...)
+	}()
+
+	for len(dealMeta.Extensions) > 0 {
+		nextID := dealMeta.Extensions[0]
+		dealMeta.Extensions = dealMeta.Extensions[1:]
+
+		if err := s.eth.AcceptDeal(nextID.String()); err != nil {
+			log.G(s.ctx).Error("failed to accept continuation deal",
+				zap.Stringer("dealID", dealMeta.ID),
+				zap.Stringer("continuationID", nextID),
+				zap.Error(err),
+			)
+
+			if err := s.eth.CloseDeal(nextID); err != nil {
+				log.G(s.ctx).Warn("failed to close rejected continuation deal",
+					zap.Stringer("dealID", dealMeta.ID),
+					zap.Stringer("continuationID", nextID),
+					zap.Error(err),
+				)
+				rejected = append(rejected, nextID)
+			}
+			continue
+		}
+
+		log.G(s.ctx).Info("deal has been extended",
+			zap.Stringer("dealID", dealMeta.ID),
+			zap.Stringer("continuationID", nextID),
+		)
+
+		dealMeta.ActiveID = nextID
+		dealMeta.ActiveSince = now
+		// Precise end time is updated from the Blockchain while checking
+		// accepted deals.
+		dealMeta.EndTime = now.Add(dealMeta.Order.GetDuration())
+		return
+	}
+}
+
+// closeExtensions closes continuation deals that are not going to be
+// accepted, because the deal they extend is released.
+func (s *state) closeExtensions(dealMeta *DealMeta) {
+	for _, id := range dealMeta.Extensions {
+		if err := s.eth.CloseDeal(id); err != nil {
+			log.G(s.ctx).Warn("failed to close continuation deal",
+				zap.Stringer("dealID", dealMeta.ID),
+				zap.Stringer("continuationID", id),
+				zap.Error(err),
+			)
+		}
+	}
+
+	dealMeta.Extensions = nil
+}
+
+// ExtendDeal binds the pending continuation deal to the given one.
+func (s *state) ExtendDeal(dealID, continuationID DealID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	meta, err := s.getDealMeta(dealID)
+	if err != nil {
+		return err
+	}
+
+	if meta.Termination != nil {
+		return status.Errorf(codes.FailedPrecondition, "deal %s is being terminated", dealID)
+	}
+
+	for _, other := range s.deals {
+		if other.ID == continuationID || other.activeID() == continuationID {
+			return status.Errorf(codes.AlreadyExists, "deal %s is already in use", continuationID)
+		}
+
+		for _, id := range other.Extensions {
+			if id == continuationID {
+				return status.Errorf(codes.AlreadyExists, "deal %s is already in use", continuationID)
+			}
+		}
+	}
+
+	meta.Extensions = append(meta.Extensions, continuationID)
+
+	return nil
+}
+
+// closeTerminatedDealsTS settles and closes deals whose early termination
+// has been requested.
+//
+// The contract pays the whole deal price to the Hub on close, so the unused
+// part is refunded to the buyer first with a token transfer from the Hub
+// wallet. The refund transaction is synchronized with the cluster before it
+// is sent, so the buyer is never paid twice, neither when closing fails and
+// is retried on the next step, nor when another Hub takes the leadership.
+func (s *state) closeTerminatedDealsTS() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.cluster.IsLeader() {
+		log.S(s.ctx).Info("not a leader, skipping closeTerminatedDeals()")
+		return
+	}
+
+	for dealID, dealMeta := range s.deals {
+		termination := dealMeta.Termination
+		if termination == nil {
+			continue
+		}
+
+		if !termination.Refunded {
+			if !s.refundTerminatedDeal(dealID, dealMeta) {
+				continue
+			}
+		}
+
+		if err := s.eth.CloseDeal(dealMeta.activeID()); err != nil {
+			log.G(s.ctx).Error("failed to close terminated deal using blockchain API",
+				zap.Stringer("dealID", dealID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// refundTerminatedDeal transfers the unused part of the terminated deal
+// price back to the buyer, returning whether the deal can be closed.
+//
+// A pending refund is never sent again. The deal stays open until its
+// transaction succeeds, or until an operator resolves it when the outcome
+// can not be obtained.
+func (s *state) refundTerminatedDeal(dealID DealID, dealMeta *DealMeta) bool {
+	termination := dealMeta.Termination
+	refund := termination.Settlement(dealMeta).GetRefund().Unwrap()
+
+	if termination.RefundPending {
+		if !s.checkPendingRefund(dealID, termination) {
+			return false
+		}
+	} else if refund.Sign() > 0 {
+		err := s.eth.Refund(dealMeta.Order.GetByuerID(), refund, func(tx string) error {
+			termination.RefundPending = true
+			termination.RefundTx = tx
+			if err := s.dump(); err != nil {
+				termination.RefundPending = false
+				termination.RefundTx = ""
+				return err
+			}
+			return nil
+		})
+
+		if err != nil {
+			log.G(s.ctx).Error("failed to refund terminated deal",
+				zap.Stringer("dealID", dealID),
+				zap.String("refund", refund.String()),
+				zap.String("tx", termination.RefundTx),
+				zap.Error(err),
+			)
+		}
+
+		// The transaction is known to be sent only when it is mined, so the
+		// result is checked on the next step.
+		return false
+	}
+
+	termination.Refunded = true
+	if err := s.dump(); err != nil {
+		log.G(s.ctx).Error("failed to dump state", zap.Error(err))
+	}
+
+	return true
+}
+
+// checkPendingRefund checks the receipt of the pending refund transaction,
+// returning whether it has succeeded. The refund is retried only when its
+// transaction is known to be failed.
+func (s *state) checkPendingRefund(dealID DealID, termination *DealTermination) bool {
+	if termination.RefundTx == "" {
+		log.G(s.ctx).Warn("refund of terminated deal has unknown transaction, verify it manually",
+			zap.Stringer("dealID", dealID),
+		)
+		return false
+	}
+
+	ok, err := s.eth.RefundStatus(termination.RefundTx)
+	if err != nil {
+		log.G(s.ctx).Warn("refund of terminated deal is not confirmed yet, verify it manually if it lasts",
+			zap.Stringer("dealID", dealID),
+			zap.String("tx", termination.RefundTx),
+			zap.Error(err),
+		)
+		return false
+	}
+
+	if !ok {
+		log.G(s.ctx).Warn("refund transaction of terminated deal has failed, retrying",
+			zap.Stringer("dealID", dealID),
+			zap.String("tx", termination.RefundTx),
+		)
+		termination.RefundPending = false
+		termination.RefundTx = ""
+		if err := s.dump(); err != nil {
+			log.G(s.ctx).Error("failed to dump state", zap.Error(err))
+		}
+		return false
+	}
+
+	termination.RefundPending = false
+	return true
+}
+
+// TerminateDeal records request to close the deal before its end time,
+// returning the proposed settlement. Repeated requests return the settlement
+// computed for the first one.
+func (s *state) TerminateDeal(dealID DealID, initiator, reason string) (*pb.DealSettlement, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	meta, err := s.getDealMeta(dealID)
+	if err != nil {
+		return nil, err
+	}
+
+	if meta.Termination == nil {
+		meta.Termination = newDealTermination(meta, initiator, reason, time.Now())
+	}
+
+	return meta.Termination.Settlement(meta), nil
+}
+
 func (s *state) MinersCount() int {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -450,6 +793,18 @@ func (s *state) getDealMeta(dealID DealID) (*DealMeta, error) {
 	return meta, nil
 }
 
+// getDealMetaByActiveID looks for the deal, which is currently paid by the
+// given on-chain deal.
+func (s *state) getDealMetaByActiveID(id DealID) (*DealMeta, bool) {
+	for _, meta := range s.deals {
+		if meta.activeID() == id {
+			return meta, true
+		}
+	}
+
+	return nil, false
+}
+
 func (s *state) PopDealHistory(dealID DealID) ([]*TaskInfo, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -612,6 +967,26 @@ func (s *state) CommitOrder(orderID OrderID) (ReservedOrder, error) {
 	return order, nil
 }
 
+// CancelOrder drops the specified reserved order, returning its resources
+// back to the miner.
+func (s *state) CancelOrder(orderID OrderID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[orderID]
+	if !ok {
+		return fmt.Errorf("order not found")
+	}
+
+	delete(s.orders, orderID)
+
+	if miner, ok := s.getMinerByID(order.MinerID); ok {
+		miner.Release(orderID)
+	}
+
+	return nil
+}
+
 func (s *state) PollCommitOrder(orderID OrderID, ethAddr common.Address) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -749,7 +1124,7 @@ func (s *state) DumpSlots() map[string]*pb.Slot {
 	return result
 }
 
-func (s *state) AddSlot(ctx context.Context, order *structs.Order) (string, error) {
+func (s *state) AddSlot(ctx context.Context, order *structs.Order, pricing *pb.PricingPolicy) (string, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -757,6 +1132,13 @@ func (s *state) AddSlot(ctx context.Context, order *structs.Order) (string, erro
 		id   = uuid.New()
 		plan = askPlan{ID: id, Order: order}
 	)
+
+	if pricing != nil {
+		plan.Pricing = pricing
+		plan.BasePrice = order.GetPricePerSecond()
+		order.PricePerSecond = pb.NewBigInt(s.planPrice(ctx, &plan))
+	}
+
 	s.askPlans[id] = &plan
 	if s.hasResources(plan.Order.GetSlot().GetResources()) {
 		s.announcePlan(ctx, &plan)
@@ -842,6 +1224,12 @@ func (s *state) RegisterMiner(miner *MinerCtx) {
 			miner.Consume(OrderID(dealMeta.Order.GetID()), &dealMeta.Usage)
 		}
 	}
+
+	for _, task := range s.tasks {
+		if task.MinerId == miner.uuid {
+			miner.health.Watch(task.ID, task.StartTaskRequest.GetContainer().GetHealthChecks(), task.Routes)
+		}
+	}
 }
 
 func (s *state) DeleteMiner(minerID string) {
@@ -880,6 +1268,27 @@ func (s *state) getTaskByID(taskID string) (*TaskInfo, bool) {
 	return taskInfo, ok
 }
 
+// GetDealTask returns the task, which is either running or has finished
+// while its deal is still active.
+func (s *state) GetDealTask(taskID string) (*TaskInfo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if task, ok := s.getTaskByID(taskID); ok {
+		return task, true
+	}
+
+	for _, meta := range s.deals {
+		for _, task := range meta.Tasks {
+			if task.ID == taskID {
+				return task, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
 func (s *state) GetTaskStatus(taskID string) (*pb.TaskStatusReply, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -901,6 +1310,7 @@ func (s *state) GetTaskStatus(taskID string) (*pb.TaskStatusReply, error) {
 	}
 
 	reply.MinerID = minerCtx.ID()
+	reply.Health = minerCtx.health.Status(taskID)
 	return reply, nil
 }
 
@@ -944,13 +1354,73 @@ func (s *state) stopTask(ctx context.Context, taskID string) error {
 		return status.Errorf(codes.NotFound, "failed to stop the task %s", task.ID)
 	}
 
-	miner.deregisterRoute(task.ID)
+	miner.health.Forget(task.ID)
+	miner.deregisterRoutes(task.Routes)
 	s.deleteTask(task.ID)
 	tasksGauge.Dec()
 
 	return nil
 }
 
+// Routes returns routes of running tasks ordered by task ID.
+func (s *state) Routes() *pb.RoutesReply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reply := &pb.RoutesReply{}
+	for _, task := range s.tasks {
+		for _, route := range task.Routes {
+			_, containerPort, _ := splitServiceID(route.ID)
+			reply.Routes = append(reply.Routes, &pb.TaskRoute{
+				TaskID:        task.ID,
+				MinerID:       task.MinerId,
+				ContainerPort: string(containerPort),
+				Protocol:      route.Protocol,
+				Host:          route.Host,
+				Port:          uint32(route.Port),
+				BackendHost:   route.BackendHost,
+				BackendPort:   uint32(route.BackendPort),
+			})
+		}
+	}
+
+	sort.Slice(reply.Routes, func(i, j int) bool {
+		if reply.Routes[i].TaskID != reply.Routes[j].TaskID {
+			return reply.Routes[i].TaskID < reply.Routes[j].TaskID
+		}
+		return reply.Routes[i].ContainerPort < reply.Routes[j].ContainerPort
+	})
+
+	return reply
+}
+
+// FilterRoutes keeps only task routes satisfying the predicate, dumping the
+// state if any route has been removed.
+func (s *state) FilterRoutes(keep func(task *TaskInfo, route *Route) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := false
+	for _, task := range s.tasks {
+		var routes []*Route
+		for _, route := range task.Routes {
+			if keep(task, route) {
+				routes = append(routes, route)
+			} else {
+				changed = true
+			}
+		}
+
+		task.Routes = routes
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return s.dump()
+}
+
 func (s *state) collectMinerCPUs(miner *MinerCtx, dst map[string]*pb.CPUDeviceInfo) {
 	for _, cpu := range miner.capabilities.CPU {
 		hash := hex.EncodeToString(cpu.Hash())
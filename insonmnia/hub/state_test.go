@@ -0,0 +1,142 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shirou/gopsutil/mem"
+	"github.com/sonm-io/core/insonmnia/hardware"
+	"github.com/sonm-io/core/insonmnia/hardware/cpu"
+	"github.com/sonm-io/core/insonmnia/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// acceptingETH records deals accepted via the Blockchain API, failing for
+// the ones specified.
+type acceptingETH struct {
+	ETH
+	fail     map[string]bool
+	accepted []string
+	closed   []DealID
+}
+
+func (e *acceptingETH) CloseDeal(id DealID) error {
+	if e.fail[id.String()+"/close"] {
+		return errors.New("failed to close")
+	}
+
+	e.closed = append(e.closed, id)
+	return nil
+}
+
+func (e *acceptingETH) AcceptDeal(id string) error {
+	if e.fail[id] {
+		return errors.New("failed to accept")
+	}
+
+	e.accepted = append(e.accepted, id)
+	return nil
+}
+
+func TestStateExtendDeal(t *testing.T) {
+	order := makeDefaultOrder(t, "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	s := &state{
+		deals: map[DealID]*DealMeta{
+			"1": {ID: "1", Order: *order},
+			"2": {ID: "2", Order: *order, ActiveID: "3"},
+		},
+	}
+
+	require.NoError(t, s.ExtendDeal("1", "4"))
+	assert.Equal(t, []DealID{"4"}, s.deals["1"].Extensions)
+
+	// Deals that are already in use cannot be continuations.
+	assert.Error(t, s.ExtendDeal("1", "2"))
+	assert.Error(t, s.ExtendDeal("1", "3"))
+	assert.Error(t, s.ExtendDeal("2", "4"))
+
+	assert.Equal(t, errDealNotFound, s.ExtendDeal("5", "6"))
+}
+
+func TestStateActivateExtension(t *testing.T) {
+	eth := &acceptingETH{fail: map[string]bool{"4": true}}
+	order := makeDefaultOrder(t, "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	meta := &DealMeta{ID: "1", Order: *order, Extensions: []DealID{"4", "5"}}
+	s := &state{
+		ctx:   context.Background(),
+		eth:   eth,
+		deals: map[DealID]*DealMeta{"1": meta},
+	}
+
+	now := time.Now()
+	s.activateExtension(meta, now)
+
+	assert.Equal(t, []string{"5"}, eth.accepted)
+	// Rejected continuations are closed to release the buyer funds.
+	assert.Equal(t, []DealID{"4"}, eth.closed)
+	assert.Equal(t, DealID("5"), meta.activeID())
+	assert.Equal(t, now, meta.ActiveSince)
+	assert.Equal(t, now.Add(order.GetDuration()), meta.EndTime)
+	assert.Empty(t, meta.Extensions)
+
+	found, ok := s.getDealMetaByActiveID("5")
+	require.True(t, ok)
+	assert.Equal(t, meta, found)
+
+	_, ok = s.getDealMetaByActiveID("1")
+	assert.False(t, ok)
+}
+
+func TestStateActivateExtensionKeepsUnclosedContinuations(t *testing.T) {
+	eth := &acceptingETH{fail: map[string]bool{"4": true, "4/close": true, "5": true}}
+	order := makeDefaultOrder(t, "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	meta := &DealMeta{ID: "1", Order: *order, Extensions: []DealID{"4", "5"}}
+	s := &state{
+		ctx:   context.Background(),
+		eth:   eth,
+		deals: map[DealID]*DealMeta{"1": meta},
+	}
+
+	s.activateExtension(meta, time.Now())
+
+	assert.Empty(t, eth.accepted)
+	assert.Equal(t, []DealID{"5"}, eth.closed)
+	assert.Equal(t, DealID("1"), meta.activeID())
+	// Released with the deal.
+	assert.Equal(t, []DealID{"4"}, meta.Extensions)
+}
+
+func TestStateCancelOrder(t *testing.T) {
+	miner := &MinerCtx{
+		ctx: context.Background(),
+		usage: resource.NewPool(&hardware.Hardware{
+			CPU:    []cpu.Device{{Cores: 4}},
+			Memory: &mem.VirtualMemoryStat{Total: 1 << 30},
+		}),
+		usageMapping: map[OrderID]resource.Resources{},
+	}
+	s := &state{
+		ctx:    context.Background(),
+		miners: map[string]*MinerCtx{"miner": miner},
+		orders: map[OrderID]ReservedOrder{},
+	}
+
+	usage := resource.NewResources(4, 1<<30, 0)
+	require.NoError(t, miner.Consume("bid", &usage))
+	require.NoError(t, s.ReserveOrder("bid", "miner", common.Address{}, time.Minute))
+
+	require.NoError(t, s.CancelOrder("bid"))
+	assert.False(t, s.OrderExists("bid"))
+	assert.False(t, miner.OrderExists("bid"))
+	assert.Equal(t, resource.Resources{}, miner.usage.GetUsage())
+
+	// The BID can be proposed again.
+	require.NoError(t, miner.Consume("bid", &usage))
+	require.NoError(t, s.ReserveOrder("bid", "miner", common.Address{}, time.Minute))
+
+	assert.Error(t, s.CancelOrder("other"))
+}
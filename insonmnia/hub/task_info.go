@@ -1,8 +1,13 @@
 package hub
 
 import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/docker/go-connections/nat"
 	"github.com/sonm-io/core/insonmnia/resource"
 	"github.com/sonm-io/core/insonmnia/structs"
 	pb "github.com/sonm-io/core/proto"
@@ -15,12 +20,38 @@ type TaskInfo struct {
 	DealId  DealID
 	MinerId string
 	EndTime *time.Time
+	// StartTime is the time the task was started at. Zero for tasks
+	// restored from the state written by older Hub versions.
+	StartTime time.Time
+	// Routes the task services are reachable through.
+	Routes []*Route
 }
 
 func (t TaskInfo) ContainerID() string {
 	return t.MinerStartReply.Container
 }
 
+// forwardTarget returns the "host:port" address the given container port,
+// like "22/tcp" or just "22", is published at by the Worker.
+func (t TaskInfo) forwardTarget(port string) (string, error) {
+	if !strings.Contains(port, "/") {
+		port += "/tcp"
+	}
+
+	if nat.Port(port).Proto() != "tcp" {
+		return "", fmt.Errorf("only TCP ports can be forwarded, got %s", port)
+	}
+
+	endpoints, ok := t.MinerStartReply.PortMap[port]
+	if !ok || len(endpoints.GetEndpoints()) == 0 {
+		return "", fmt.Errorf("port %s is not published by task %s", port, t.ID)
+	}
+
+	endpoint := endpoints.GetEndpoints()[0]
+
+	return net.JoinHostPort(endpoint.Addr, strconv.Itoa(int(endpoint.Port))), nil
+}
+
 type DealMeta struct {
 	ID      DealID
 	BidID   string
@@ -29,4 +60,27 @@ type DealMeta struct {
 	Usage   resource.Resources
 	Tasks   []*TaskInfo
 	EndTime time.Time
+	// Termination is set when either party requested to close the deal
+	// before its end time.
+	Termination *DealTermination
+	// ActiveID is the on-chain deal currently paying for the resources,
+	// empty until the deal is extended for the first time.
+	ActiveID DealID
+	// ActiveSince is the time the active continuation deal has been
+	// accepted at, zero if the deal has never been extended.
+	ActiveSince time.Time
+	// Extensions are pending continuation deals opened by the buyer to
+	// extend this one. They are accepted one by one as the active deal
+	// expires.
+	Extensions []DealID
+}
+
+// activeID returns the ID of the on-chain deal currently paying for the
+// resources.
+func (m *DealMeta) activeID() DealID {
+	if m.ActiveID == "" {
+		return m.ID
+	}
+
+	return m.ActiveID
 }
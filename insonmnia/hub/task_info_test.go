@@ -0,0 +1,35 @@
+package hub
+
+import (
+	"testing"
+
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskInfoForwardTarget(t *testing.T) {
+	task := TaskInfo{
+		MinerStartReply: pb.MinerStartReply{
+			PortMap: map[string]*pb.Endpoints{
+				"22/tcp": {Endpoints: []*pb.SocketAddr{{Addr: "46.148.198.133", Port: 32768}}},
+				"53/udp": {Endpoints: []*pb.SocketAddr{{Addr: "46.148.198.133", Port: 32769}}},
+			},
+		},
+		ID: "task",
+	}
+
+	target, err := task.forwardTarget("22")
+	require.NoError(t, err)
+	assert.Equal(t, "46.148.198.133:32768", target)
+
+	target, err = task.forwardTarget("22/tcp")
+	require.NoError(t, err)
+	assert.Equal(t, "46.148.198.133:32768", target)
+
+	_, err = task.forwardTarget("53/udp")
+	assert.Error(t, err)
+
+	_, err = task.forwardTarget("80")
+	assert.Error(t, err)
+}
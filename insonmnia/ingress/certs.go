@@ -0,0 +1,136 @@
+package ingress
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const selfSignedValidPeriod = 90 * 24 * time.Hour
+
+// certStore provides per-task TLS certificates, preferring the ones put
+// into the certificates directory and falling back to self-signed.
+type certStore struct {
+	dir    string
+	domain string
+
+	mu    sync.Mutex
+	certs map[string]*tls.Certificate
+}
+
+func newCertStore(dir, domain string) *certStore {
+	return &certStore{
+		dir:    dir,
+		domain: domain,
+		certs:  map[string]*tls.Certificate{},
+	}
+}
+
+// Get returns the certificate for the given task.
+func (m *certStore) Get(taskID string) (*tls.Certificate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cert, ok := m.certs[taskID]; ok && time.Now().Before(cert.Leaf.NotAfter) {
+		return cert, nil
+	}
+
+	cert, err := m.load(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cert == nil {
+		cert, err = m.generate(taskID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m.certs[taskID] = cert
+
+	return cert, nil
+}
+
+// Remove forgets the cached certificate of the given task.
+func (m *certStore) Remove(taskID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.certs, taskID)
+}
+
+// load loads the task certificate from the certificates directory,
+// returning nil if there is no such.
+func (m *certStore) load(taskID string) (*tls.Certificate, error) {
+	if m.dir == "" {
+		return nil, nil
+	}
+
+	certFile := filepath.Join(m.dir, taskID+".crt")
+	keyFile := filepath.Join(m.dir, taskID+".key")
+	if _, err := os.Stat(certFile); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+func (m *certStore) generate(taskID string) (*tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	host := taskID + "." + m.domain
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: host},
+		DNSNames:              []string{host, "*." + host},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidPeriod),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
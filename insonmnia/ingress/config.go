@@ -0,0 +1,17 @@
+package ingress
+
+// Config describes the HTTP(S) ingress settings.
+type Config struct {
+	// Domain the Hub is reachable at. Tasks are served at
+	// "<task-id>.<domain>", while "<port>.<task-id>.<domain>" selects the
+	// exact container port.
+	Domain string `yaml:"domain" required:"true"`
+	// HTTPEndpoint to serve plain HTTP requests on, disabled if empty.
+	HTTPEndpoint string `yaml:"http_endpoint" default:":80"`
+	// HTTPSEndpoint to serve HTTPS requests on, disabled if empty.
+	HTTPSEndpoint string `yaml:"https_endpoint" default:":443"`
+	// CertDir is a directory with per-task certificates named
+	// "<task-id>.crt" and "<task-id>.key". Tasks without them are served
+	// using self-signed certificates.
+	CertDir string `yaml:"cert_dir"`
+}
@@ -0,0 +1,93 @@
+package ingress
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	requestsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sonm_ingress_requests_total",
+		Help: "Number of requests routed to tasks through the ingress",
+	}, []string{"task"})
+	errorsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sonm_ingress_errors_total",
+		Help: "Number of ingress requests failed to be proxied or answered with 5xx status",
+	}, []string{"task"})
+	inBytesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sonm_ingress_in_bytes_total",
+		Help: "Number of bytes received from clients through the ingress",
+	}, []string{"task"})
+	outBytesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sonm_ingress_out_bytes_total",
+		Help: "Number of bytes sent to clients through the ingress",
+	}, []string{"task"})
+)
+
+func init() {
+	prometheus.MustRegister(requestsCounter)
+	prometheus.MustRegister(errorsCounter)
+	prometheus.MustRegister(inBytesCounter)
+	prometheus.MustRegister(outBytesCounter)
+}
+
+// RouteMetrics describes requests served through a task route.
+type RouteMetrics struct {
+	Requests uint64
+	// Errors is the number of requests failed to be proxied or answered
+	// with 5xx status by the task.
+	Errors   uint64
+	InBytes  uint64
+	OutBytes uint64
+}
+
+func (m *RouteMetrics) Add(metrics *RouteMetrics) {
+	m.Requests += metrics.Requests
+	m.Errors += metrics.Errors
+	m.InBytes += metrics.InBytes
+	m.OutBytes += metrics.OutBytes
+}
+
+// routeMetrics collects metrics of a single task route.
+type routeMetrics struct {
+	task     string
+	requests uint64
+	errors   uint64
+	inBytes  uint64
+	outBytes uint64
+}
+
+func (m *routeMetrics) request() {
+	atomic.AddUint64(&m.requests, 1)
+	requestsCounter.WithLabelValues(m.task).Inc()
+}
+
+func (m *routeMetrics) error() {
+	atomic.AddUint64(&m.errors, 1)
+	errorsCounter.WithLabelValues(m.task).Inc()
+}
+
+func (m *routeMetrics) transferred(in, out int64) {
+	atomic.AddUint64(&m.inBytes, uint64(in))
+	atomic.AddUint64(&m.outBytes, uint64(out))
+	inBytesCounter.WithLabelValues(m.task).Add(float64(in))
+	outBytesCounter.WithLabelValues(m.task).Add(float64(out))
+}
+
+func (m *routeMetrics) Unwrap() *RouteMetrics {
+	return &RouteMetrics{
+		Requests: atomic.LoadUint64(&m.requests),
+		Errors:   atomic.LoadUint64(&m.errors),
+		InBytes:  atomic.LoadUint64(&m.inBytes),
+		OutBytes: atomic.LoadUint64(&m.outBytes),
+	}
+}
+
+// forget removes the task series from the exported metrics.
+func (m *routeMetrics) forget() {
+	requestsCounter.DeleteLabelValues(m.task)
+	errorsCounter.DeleteLabelValues(m.task)
+	inBytesCounter.DeleteLabelValues(m.task)
+	outBytesCounter.DeleteLabelValues(m.task)
+}
@@ -0,0 +1,518 @@
+package ingress
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	stdlog "log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	dialTimeout   = 10 * time.Second
+	flushInterval = 100 * time.Millisecond
+)
+
+// Server is an L7 reverse proxy routing HTTP(S) requests to task services
+// by the requested host name.
+//
+// TLS is terminated using per-task certificates, HTTP/2 is negotiated
+// with clients via ALPN, while upgraded connections, like WebSocket ones,
+// are tunneled to the task as is.
+type Server struct {
+	cfg    Config
+	ctx    context.Context
+	cancel context.CancelFunc
+	certs  *certStore
+	// errorLog receives errors of HTTP servers and proxied requests.
+	errorLog *stdlog.Logger
+
+	httpListener  net.Listener
+	httpsListener net.Listener
+	httpServer    *http.Server
+	httpsServer   *http.Server
+
+	mu     sync.RWMutex
+	routes map[string]*route
+}
+
+// route describes how the task is reachable.
+type route struct {
+	// backends maps container ports to endpoints serving them.
+	backends map[uint16][]string
+	next     int
+	metrics  *routeMetrics
+}
+
+// NewServer constructs a new ingress server, listening on the configured
+// endpoints.
+func NewServer(ctx context.Context, cfg Config) (*Server, error) {
+	if cfg.Domain == "" {
+		return nil, errors.New("ingress domain is required")
+	}
+	if cfg.HTTPEndpoint == "" && cfg.HTTPSEndpoint == "" {
+		return nil, errors.New("at least one of ingress HTTP and HTTPS endpoints is required")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	m := &Server{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+		certs:  newCertStore(cfg.CertDir, strings.ToLower(cfg.Domain)),
+		routes: map[string]*route{},
+	}
+	m.errorLog = zap.NewStdLog(log.G(ctx))
+
+	if cfg.HTTPEndpoint != "" {
+		listener, err := net.Listen("tcp", cfg.HTTPEndpoint)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		m.httpListener = listener
+		m.httpServer = &http.Server{Handler: m, ErrorLog: m.errorLog}
+	}
+
+	if cfg.HTTPSEndpoint != "" {
+		listener, err := net.Listen("tcp", cfg.HTTPSEndpoint)
+		if err != nil {
+			m.closeListeners()
+			cancel()
+			return nil, err
+		}
+
+		m.httpsListener = listener
+		m.httpsServer = &http.Server{
+			Handler:  m,
+			ErrorLog: m.errorLog,
+			TLSConfig: &tls.Config{
+				GetCertificate: m.getCertificate,
+				NextProtos:     []string{"h2", "http/1.1"},
+			},
+		}
+	}
+
+	return m, nil
+}
+
+// Serve serves ingress requests until the server is closed.
+func (m *Server) Serve() error {
+	wg := errgroup.Group{}
+
+	if m.httpServer != nil {
+		wg.Go(func() error {
+			return filterClosed(m.httpServer.Serve(m.httpListener))
+		})
+	}
+	if m.httpsServer != nil {
+		wg.Go(func() error {
+			return filterClosed(m.httpsServer.ServeTLS(m.httpsListener, "", ""))
+		})
+	}
+
+	go func() {
+		<-m.ctx.Done()
+		m.closeServers()
+	}()
+
+	return wg.Wait()
+}
+
+// Close stops serving requests, breaking all active connections.
+func (m *Server) Close() {
+	m.cancel()
+	m.closeServers()
+	m.closeListeners()
+}
+
+func (m *Server) closeServers() {
+	if m.httpServer != nil {
+		m.httpServer.Close()
+	}
+	if m.httpsServer != nil {
+		m.httpsServer.Close()
+	}
+}
+
+func (m *Server) closeListeners() {
+	if m.httpListener != nil {
+		m.httpListener.Close()
+	}
+	if m.httpsListener != nil {
+		m.httpsListener.Close()
+	}
+}
+
+func filterClosed(err error) error {
+	if err == http.ErrServerClosed {
+		return nil
+	}
+
+	return err
+}
+
+// URL returns the URL the task is served at.
+func (m *Server) URL(taskID string) string {
+	if m.httpsServer != nil {
+		return "https://" + m.host(taskID, m.httpsListener)
+	}
+
+	return "http://" + m.host(taskID, m.httpListener)
+}
+
+func (m *Server) host(taskID string, listener net.Listener) string {
+	host := taskID + "." + m.cfg.Domain
+
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil || port == "80" || port == "443" {
+		return host
+	}
+
+	return net.JoinHostPort(host, port)
+}
+
+// AddBackend adds the endpoint serving the given container port of the
+// task.
+func (m *Server) AddBackend(taskID string, port uint16, endpoint string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rt, ok := m.routes[taskID]
+	if !ok {
+		rt = &route{
+			backends: map[uint16][]string{},
+			metrics:  &routeMetrics{task: taskID},
+		}
+		m.routes[taskID] = rt
+	}
+
+	for _, backend := range rt.backends[port] {
+		if backend == endpoint {
+			return
+		}
+	}
+
+	rt.backends[port] = append(rt.backends[port], endpoint)
+
+	log.G(m.ctx).Info("added ingress route", zap.String("task_id", taskID),
+		zap.Uint16("port", port), zap.String("endpoint", endpoint))
+}
+
+// RemoveBackend removes the endpoint serving the given container port of
+// the task.
+func (m *Server) RemoveBackend(taskID string, port uint16, endpoint string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rt, ok := m.routes[taskID]
+	if !ok {
+		return
+	}
+
+	backends := rt.backends[port][:0]
+	for _, backend := range rt.backends[port] {
+		if backend != endpoint {
+			backends = append(backends, backend)
+		}
+	}
+
+	if len(backends) == 0 {
+		delete(rt.backends, port)
+	} else {
+		rt.backends[port] = backends
+	}
+
+	if len(rt.backends) == 0 {
+		m.removeTask(taskID, rt)
+	}
+}
+
+// RemoveBackends removes all endpoints serving the given container port
+// of the task.
+func (m *Server) RemoveBackends(taskID string, port uint16) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rt, ok := m.routes[taskID]
+	if !ok {
+		return
+	}
+
+	delete(rt.backends, port)
+	if len(rt.backends) == 0 {
+		m.removeTask(taskID, rt)
+	}
+}
+
+// RemoveTask removes all routes of the given task.
+func (m *Server) RemoveTask(taskID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if rt, ok := m.routes[taskID]; ok {
+		m.removeTask(taskID, rt)
+	}
+}
+
+func (m *Server) removeTask(taskID string, rt *route) {
+	delete(m.routes, taskID)
+	rt.metrics.forget()
+	m.certs.Remove(taskID)
+
+	log.G(m.ctx).Info("removed ingress routes", zap.String("task_id", taskID))
+}
+
+// Metrics returns request metrics of the given task, if it is routed.
+func (m *Server) Metrics(taskID string) (*RouteMetrics, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rt, ok := m.routes[taskID]
+	if !ok {
+		return nil, false
+	}
+
+	return rt.metrics.Unwrap(), true
+}
+
+// parseHost extracts the task ID and optionally the container port from
+// either "<task-id>.<domain>" or "<port>.<task-id>.<domain>" host name.
+func (m *Server) parseHost(host string) (string, uint16, bool) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	suffix := "." + strings.ToLower(m.cfg.Domain)
+	if !strings.HasSuffix(host, suffix) {
+		return "", 0, false
+	}
+
+	labels := strings.Split(strings.TrimSuffix(host, suffix), ".")
+	switch len(labels) {
+	case 1:
+		return labels[0], 0, labels[0] != ""
+	case 2:
+		port, err := strconv.ParseUint(labels[0], 10, 16)
+		if err != nil {
+			return "", 0, false
+		}
+
+		return labels[1], uint16(port), labels[1] != ""
+	default:
+		return "", 0, false
+	}
+}
+
+// backend picks the endpoint to serve the request with. Zero port means
+// the lowest container port exposed by the task.
+func (m *Server) backend(taskID string, port uint16) (string, *routeMetrics, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rt, ok := m.routes[taskID]
+	if !ok {
+		return "", nil, false
+	}
+
+	if port == 0 && len(rt.backends) > 0 {
+		ports := make([]int, 0, len(rt.backends))
+		for p := range rt.backends {
+			ports = append(ports, int(p))
+		}
+		sort.Ints(ports)
+		port = uint16(ports[0])
+	}
+
+	backends := rt.backends[port]
+	if len(backends) == 0 {
+		return "", rt.metrics, false
+	}
+
+	rt.next++
+
+	return backends[rt.next%len(backends)], rt.metrics, true
+}
+
+func (m *Server) getCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	taskID, _, ok := m.parseHost(hello.ServerName)
+	if !ok {
+		return nil, errors.New("unknown server name")
+	}
+
+	m.mu.RLock()
+	_, ok = m.routes[taskID]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, errors.New("unknown task")
+	}
+
+	return m.certs.Get(taskID)
+}
+
+func (m *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	taskID, port, ok := m.parseHost(r.Host)
+	if !ok {
+		http.Error(w, "unknown host", http.StatusNotFound)
+		return
+	}
+
+	endpoint, metrics, ok := m.backend(taskID, port)
+	if metrics == nil {
+		http.Error(w, "task not found", http.StatusNotFound)
+		return
+	}
+
+	metrics.request()
+
+	if !ok {
+		metrics.error()
+		http.Error(w, "port is not exposed", http.StatusNotFound)
+		return
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	r.Header.Set("X-Forwarded-Host", r.Host)
+	r.Header.Set("X-Forwarded-Proto", scheme)
+
+	if isUpgrade(r) {
+		m.tunnel(w, r, endpoint, metrics)
+		return
+	}
+
+	body := &countingReader{ReadCloser: r.Body}
+	if r.Body != nil {
+		r.Body = body
+	}
+	rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+	proxy := &httputil.ReverseProxy{
+		Director: func(r *http.Request) {
+			r.URL.Scheme = "http"
+			r.URL.Host = endpoint
+		},
+		FlushInterval: flushInterval,
+		ErrorLog:      m.errorLog,
+	}
+	proxy.ServeHTTP(rw, r)
+
+	if rw.status >= http.StatusInternalServerError {
+		metrics.error()
+	}
+	metrics.transferred(body.n, rw.n)
+}
+
+// tunnel passes the upgraded connection to the task as is.
+func (m *Server) tunnel(w http.ResponseWriter, r *http.Request, endpoint string, metrics *routeMetrics) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		metrics.error()
+		http.Error(w, "connection upgrade is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	backend, err := net.DialTimeout("tcp", endpoint, dialTimeout)
+	if err != nil {
+		metrics.error()
+		http.Error(w, "failed to connect to the task", http.StatusBadGateway)
+		return
+	}
+	defer backend.Close()
+
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		metrics.error()
+		return
+	}
+	defer conn.Close()
+
+	if err := r.Write(backend); err != nil {
+		metrics.error()
+		return
+	}
+
+	// The client may have sent data along with the request, so read
+	// through the buffered reader.
+	var in, out int64
+	done := make(chan struct{})
+	go func() {
+		in, _ = io.Copy(backend, buf)
+		backend.(*net.TCPConn).CloseWrite()
+		close(done)
+	}()
+
+	out, _ = io.Copy(conn, backend)
+	conn.Close()
+	<-done
+
+	metrics.transferred(in, out)
+}
+
+func isUpgrade(r *http.Request) bool {
+	if r.Header.Get("Upgrade") == "" {
+		return false
+	}
+
+	for _, value := range r.Header["Connection"] {
+		for _, token := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+func (m *countingReader) Read(p []byte) (int, error) {
+	n, err := m.ReadCloser.Read(p)
+	m.n += int64(n)
+	return n, err
+}
+
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	n      int64
+}
+
+func (m *responseWriter) WriteHeader(status int) {
+	m.status = status
+	m.ResponseWriter.WriteHeader(status)
+}
+
+func (m *responseWriter) Write(p []byte) (int, error) {
+	n, err := m.ResponseWriter.Write(p)
+	m.n += int64(n)
+	return n, err
+}
+
+func (m *responseWriter) Flush() {
+	if flusher, ok := m.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
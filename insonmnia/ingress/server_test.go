@@ -0,0 +1,208 @@
+package ingress
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+)
+
+const testDomain = "hub.example.com"
+
+func newTestServer(t *testing.T) *Server {
+	server, err := NewServer(context.Background(), Config{
+		Domain:        testDomain,
+		HTTPEndpoint:  "127.0.0.1:0",
+		HTTPSEndpoint: "127.0.0.1:0",
+	})
+	require.NoError(t, err)
+
+	go server.Serve()
+
+	return server
+}
+
+func newTestBackend(name string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s %s", name, r.Header.Get("X-Forwarded-Proto"), r.URL.Path)
+	}))
+}
+
+func get(t *testing.T, client *http.Client, url, host string) (int, string) {
+	req, err := http.NewRequest("GET", url, nil)
+	require.NoError(t, err)
+	req.Host = host
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(body)
+}
+
+func TestParseHost(t *testing.T) {
+	server := &Server{cfg: Config{Domain: testDomain}}
+
+	cases := []struct {
+		host   string
+		taskID string
+		port   uint16
+		ok     bool
+	}{
+		{"task.hub.example.com", "task", 0, true},
+		{"Task.HUB.example.com:8080", "task", 0, true},
+		{"8080.task.hub.example.com", "task", 8080, true},
+		{"hub.example.com", "", 0, false},
+		{"task.other.com", "", 0, false},
+		{"web.task.hub.example.com", "", 0, false},
+		{"a.b.task.hub.example.com", "", 0, false},
+	}
+
+	for _, c := range cases {
+		taskID, port, ok := server.parseHost(c.host)
+		assert.Equal(t, c.ok, ok, c.host)
+		assert.Equal(t, c.taskID, taskID, c.host)
+		assert.Equal(t, c.port, port, c.host)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	web := newTestBackend("web")
+	defer web.Close()
+	api := newTestBackend("api")
+	defer api.Close()
+
+	server.AddBackend("task", 80, web.Listener.Addr().String())
+	server.AddBackend("task", 8080, api.Listener.Addr().String())
+
+	url := "http://" + server.httpListener.Addr().String() + "/path"
+
+	code, body := get(t, http.DefaultClient, url, "task."+testDomain)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "web http /path", body)
+
+	code, body = get(t, http.DefaultClient, url, "8080.task."+testDomain)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "api http /path", body)
+
+	code, _ = get(t, http.DefaultClient, url, "9090.task."+testDomain)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _ = get(t, http.DefaultClient, url, "unknown."+testDomain)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	metrics, ok := server.Metrics("task")
+	require.True(t, ok)
+	assert.Equal(t, uint64(3), metrics.Requests)
+	assert.Equal(t, uint64(1), metrics.Errors)
+	assert.Equal(t, uint64(len("web http /path")+len("api http /path")), metrics.OutBytes)
+
+	server.RemoveBackends("task", 80)
+	code, body = get(t, http.DefaultClient, url, "task."+testDomain)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "api http /path", body)
+
+	server.RemoveTask("task")
+	code, _ = get(t, http.DefaultClient, url, "task."+testDomain)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	_, ok = server.Metrics("task")
+	assert.False(t, ok)
+}
+
+func TestServeHTTPS(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	web := newTestBackend("web")
+	defer web.Close()
+
+	server.AddBackend("task", 80, web.Listener.Addr().String())
+
+	client := &http.Client{
+		Transport: &http2.Transport{
+			TLSClientConfig: &tls.Config{
+				ServerName:         "task." + testDomain,
+				InsecureSkipVerify: true,
+			},
+		},
+	}
+
+	url := "https://" + server.httpsListener.Addr().String() + "/"
+	code, body := get(t, client, url, "task."+testDomain)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "web https /", body)
+
+	cert, err := server.certs.Get("task")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"task." + testDomain, "*.task." + testDomain}, cert.Leaf.DNSNames)
+
+	_, err = tls.Dial("tcp", server.httpsListener.Addr().String(), &tls.Config{
+		ServerName:         "unknown." + testDomain,
+		InsecureSkipVerify: true,
+	})
+	assert.Error(t, err)
+}
+
+func TestServeUpgrade(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	backend, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer backend.Close()
+
+	go func() {
+		conn, err := backend.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		rd := bufio.NewReader(conn)
+		if _, err := http.ReadRequest(rd); err != nil {
+			return
+		}
+
+		io.WriteString(conn, "HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+		io.Copy(conn, rd)
+	}()
+
+	server.AddBackend("task", 80, backend.Addr().String())
+
+	conn, err := net.Dial("tcp", server.httpListener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = fmt.Fprintf(conn, "GET / HTTP/1.1\r\nHost: task.%s\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n", testDomain)
+	require.NoError(t, err)
+
+	rd := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(rd, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+	_, err = io.WriteString(conn, "ping")
+	require.NoError(t, err)
+
+	reply := make([]byte, 4)
+	_, err = io.ReadFull(rd, reply)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(reply))
+}
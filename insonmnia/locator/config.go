@@ -16,6 +16,7 @@ type storeConfig struct {
 type Config struct {
 	ListenAddr          string             `yaml:"address"`
 	NodeTTL             time.Duration      `yaml:"node_ttl"`
+	CleanupPeriod       time.Duration      `yaml:"cleanup_period" default:"1m"`
 	Eth                 accounts.EthConfig `required:"true" yaml:"ethereum"`
 	OnlyPublicClientIPs bool               `required:"false" yaml:"only_public_client_ips"`
 	Store               storeConfig        `required:"true" yaml:"store"`
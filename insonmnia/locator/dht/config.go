@@ -0,0 +1,32 @@
+package dht
+
+import (
+	"time"
+)
+
+// Config describes DHT node settings.
+type Config struct {
+	// Endpoint to serve DHT requests on. Nodes without endpoint only
+	// resolve and publish records without keeping records of others.
+	// Empty host means that the other nodes use the host they see
+	// requests coming from.
+	Endpoint string `yaml:"endpoint"`
+	// Bootstrap is a list of well-known nodes used to join the network in
+	// "ethaddr@host:port" format.
+	Bootstrap []string `yaml:"bootstrap"`
+	// RefreshInterval specifies how often the routing table is refreshed
+	// and expired records are removed.
+	RefreshInterval time.Duration `yaml:"refresh_interval" default:"10m"`
+	// MaxRecords limits the number of records of others stored by the
+	// node. Zero disables the limit.
+	MaxRecords int `yaml:"max_records" default:"10000"`
+	// MaxRecordTTL limits how long records of others are kept regardless
+	// of their own TTL. Zero disables the limit.
+	MaxRecordTTL time.Duration `yaml:"max_record_ttl" default:"1h"`
+	// StoreRate limits the number of store requests accepted from each
+	// node per minute. Zero disables the limit.
+	StoreRate int `yaml:"store_rate" default:"60"`
+	// OnlyPublicClientIPs hides private client endpoints of the resolved
+	// records, like the Locator does.
+	OnlyPublicClientIPs bool `yaml:"only_public_client_ips"`
+}
@@ -0,0 +1,177 @@
+package dht
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/protobuf/proto"
+	"github.com/sonm-io/core/insonmnia/locator"
+	pb "github.com/sonm-io/core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// watchPeriod specifies how often watched records are looked up again.
+const watchPeriod = 30 * time.Second
+
+type locatorClient struct {
+	node *Node
+}
+
+// NewLocatorClient wraps the DHT node into the locator client interface, so
+// it can be used as a drop-in replacement of the central locator.
+//
+// Note that only signed records can be announced through it.
+func NewLocatorClient(node *Node) pb.LocatorClient {
+	return &locatorClient{node: node}
+}
+
+func (m *locatorClient) Announce(ctx context.Context, request *pb.AnnounceRequest, opts ...grpc.CallOption) (*pb.Empty, error) {
+	if request.GetRecord() == nil {
+		return nil, status.Error(codes.InvalidArgument, "DHT locator requires signed record")
+	}
+
+	if err := m.node.Publish(ctx, request.GetRecord()); err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (m *locatorClient) Resolve(ctx context.Context, request *pb.ResolveRequest, opts ...grpc.CallOption) (*pb.ResolveReply, error) {
+	if !common.IsHexAddress(request.GetEthAddr()) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid ethaddress %s", request.GetEthAddr())
+	}
+
+	rec, err := m.node.Find(ctx, common.HexToAddress(request.GetEthAddr()))
+	if err != nil {
+		if err == errRecordNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	view := *rec
+	if m.node.cfg.OnlyPublicClientIPs {
+		view.ClientEndpoints = locator.PublicEndpoints(rec.ClientEndpoints)
+	}
+
+	endpoints, err := locator.ResolveEndpoints(&view, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &pb.ResolveReply{Endpoints: endpoints, Record: rec}, nil
+}
+
+func (m *locatorClient) WatchResolve(ctx context.Context, request *pb.ResolveRequest, opts ...grpc.CallOption) (pb.Locator_WatchResolveClient, error) {
+	if !common.IsHexAddress(request.GetEthAddr()) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid ethaddress %s", request.GetEthAddr())
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	stream := &watchResolveClient{
+		ctx:     ctx,
+		cancel:  cancel,
+		replies: make(chan *pb.ResolveReply),
+	}
+
+	go m.watch(stream, request)
+
+	return stream, nil
+}
+
+// watch periodically resolves the record, sending replies only when they
+// change. Lookup failures are not reported, because the network may recover.
+func (m *locatorClient) watch(stream *watchResolveClient, request *pb.ResolveRequest) {
+	defer close(stream.replies)
+
+	ticker := time.NewTicker(watchPeriod)
+	defer ticker.Stop()
+
+	var prev *pb.ResolveReply
+	for {
+		reply, err := m.Resolve(stream.ctx, request)
+		if status.Code(err) == codes.NotFound {
+			reply, err = &pb.ResolveReply{}, nil
+		}
+
+		if err == nil && (prev == nil || !proto.Equal(prev, reply)) {
+			select {
+			case stream.replies <- reply:
+				prev = reply
+			case <-stream.ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-stream.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchResolveClient is a local stream of resolve replies.
+type watchResolveClient struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	replies chan *pb.ResolveReply
+}
+
+func (m *watchResolveClient) Recv() (*pb.ResolveReply, error) {
+	reply, ok := <-m.replies
+	if !ok {
+		if m.ctx.Err() == context.Canceled {
+			return nil, status.Error(codes.Canceled, m.ctx.Err().Error())
+		}
+
+		return nil, io.EOF
+	}
+
+	return reply, nil
+}
+
+func (m *watchResolveClient) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+func (m *watchResolveClient) Trailer() metadata.MD {
+	return metadata.MD{}
+}
+
+func (m *watchResolveClient) CloseSend() error {
+	m.cancel()
+	return nil
+}
+
+func (m *watchResolveClient) Context() context.Context {
+	return m.ctx
+}
+
+func (m *watchResolveClient) SendMsg(msg interface{}) error {
+	return fmt.Errorf("sending messages is not supported")
+}
+
+func (m *watchResolveClient) RecvMsg(msg interface{}) error {
+	reply, err := m.Recv()
+	if err != nil {
+		return err
+	}
+
+	target, ok := msg.(*pb.ResolveReply)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", msg)
+	}
+
+	*target = *reply
+	return nil
+}
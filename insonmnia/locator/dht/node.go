@@ -0,0 +1,622 @@
+// Package dht implements Kademlia-style distributed locator, where hubs
+// publish their signed endpoint records keyed by ETH address and nodes
+// resolve them peer-to-peer without central locator service.
+package dht
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/insonmnia/locator"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"github.com/sonm-io/core/util/xgrpc"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	// k is the bucket size and the replication factor.
+	k = 20
+	// alpha is the number of concurrent requests during lookups.
+	alpha = 3
+	// requestTimeout limits each request to other nodes.
+	requestTimeout = 5 * time.Second
+	// storeRatePeriod is the period the store rate is limited within.
+	storeRatePeriod = time.Minute
+)
+
+var (
+	errRecordNotFound = errors.New("record with given Eth address cannot be found")
+	errNoContacts     = errors.New("no DHT nodes are known")
+	errStorageFull    = errors.New("DHT record storage is full")
+	errStoreRate      = errors.New("too many DHT store requests")
+)
+
+// Node is a DHT participant. Nodes with endpoint store records of others
+// and answer their requests, while nodes without it only act as clients.
+type Node struct {
+	cfg      Config
+	ctx      context.Context
+	cancel   context.CancelFunc
+	self     contact
+	creds    credentials.TransportCredentials
+	table    *table
+	server   *grpc.Server
+	listener net.Listener
+
+	mu      sync.Mutex
+	records map[common.Address]*storedRecord
+	stores  map[common.Address]*storeCounter
+	conns   map[common.Address]*conn
+}
+
+// storedRecord is a record kept by the node until it expires, which is no
+// later than the configured maximum TTL.
+type storedRecord struct {
+	*pb.LocatorRecord
+	expireAt time.Time
+}
+
+// storeCounter counts store requests of a sender within the current period.
+type storeCounter struct {
+	since time.Time
+	count int
+}
+
+// conn is a cached connection to the contact endpoint.
+type conn struct {
+	endpoint string
+	*grpc.ClientConn
+}
+
+// NewNode constructs a new DHT node, listening on the configured endpoint
+// if any.
+func NewNode(ctx context.Context, cfg Config, key *ecdsa.PrivateKey, creds credentials.TransportCredentials) (*Node, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	m := &Node{
+		cfg:     cfg,
+		ctx:     ctx,
+		cancel:  cancel,
+		self:    contact{Addr: util.PubKeyToAddr(key.PublicKey)},
+		creds:   creds,
+		records: map[common.Address]*storedRecord{},
+		stores:  map[common.Address]*storeCounter{},
+		conns:   map[common.Address]*conn{},
+	}
+	m.table = newTable(m.self.Addr, k)
+
+	if cfg.Endpoint != "" {
+		listener, err := net.Listen("tcp", cfg.Endpoint)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		host, _, err := net.SplitHostPort(cfg.Endpoint)
+		if err != nil {
+			listener.Close()
+			cancel()
+			return nil, err
+		}
+
+		m.listener = listener
+		m.self.Endpoint = net.JoinHostPort(host, strconv.Itoa(listener.Addr().(*net.TCPAddr).Port))
+		m.server = xgrpc.NewServer(log.GetLogger(ctx), xgrpc.Credentials(creds))
+		pb.RegisterDHTServer(m.server, &server{node: m})
+	}
+
+	return m, nil
+}
+
+// Addr returns the ETH address identifying the node.
+func (m *Node) Addr() common.Address {
+	return m.self.Addr
+}
+
+// Endpoint returns the endpoint the node is reachable at by other nodes,
+// which is empty for client-only nodes.
+func (m *Node) Endpoint() string {
+	return m.self.Endpoint
+}
+
+// Serve joins the network and serves DHT requests until the node is closed.
+func (m *Node) Serve() error {
+	if err := m.Bootstrap(m.ctx); err != nil {
+		log.G(m.ctx).Warn("failed to bootstrap DHT node", zap.Error(err))
+	}
+
+	go m.refresh()
+
+	if m.server == nil {
+		<-m.ctx.Done()
+		return nil
+	}
+
+	go func() {
+		<-m.ctx.Done()
+		m.server.Stop()
+	}()
+
+	return m.server.Serve(m.listener)
+}
+
+// Close stops the node and closes all connections to other nodes.
+func (m *Node) Close() {
+	m.cancel()
+
+	if m.server != nil {
+		m.server.Stop()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for addr, cc := range m.conns {
+		cc.Close()
+		delete(m.conns, addr)
+	}
+}
+
+// Bootstrap adds configured bootstrap nodes to the routing table and looks
+// up the node itself to populate the table with its neighbours.
+func (m *Node) Bootstrap(ctx context.Context) error {
+	for _, endpoint := range m.cfg.Bootstrap {
+		authEndpoint, err := auth.NewEndpoint(endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid bootstrap endpoint %s: %v", endpoint, err)
+		}
+
+		c := contact{Addr: authEndpoint.EthAddress, Endpoint: authEndpoint.Endpoint}
+		if c.Addr == m.self.Addr {
+			continue
+		}
+
+		if err := m.ping(ctx, c); err != nil {
+			log.G(ctx).Warn("bootstrap DHT node is unavailable", zap.String("endpoint", endpoint), zap.Error(err))
+			continue
+		}
+
+		m.observe(c)
+	}
+
+	if m.table.Len() == 0 {
+		return errNoContacts
+	}
+
+	_, _, err := m.lookup(ctx, m.self.Addr, false)
+	return err
+}
+
+// Publish stores the signed record on the nodes closest to its address.
+func (m *Node) Publish(ctx context.Context, rec *pb.LocatorRecord) error {
+	if err := locator.VerifyRecord(rec); err != nil {
+		return err
+	}
+
+	stored := 0
+	if m.server != nil {
+		if ok, err := m.store(rec); ok && err == nil {
+			stored++
+		}
+	}
+
+	contacts, _, err := m.lookup(ctx, common.HexToAddress(rec.GetEthAddr()), false)
+	if err != nil && stored == 0 {
+		return err
+	}
+
+	for _, c := range contacts {
+		if err := m.storeAt(ctx, c, rec); err != nil {
+			log.G(ctx).Debug("failed to store DHT record", zap.Stringer("node", c.Addr), zap.Error(err))
+			continue
+		}
+
+		stored++
+	}
+
+	if stored == 0 {
+		return fmt.Errorf("failed to store record on any of %d DHT nodes", len(contacts))
+	}
+
+	return nil
+}
+
+// Find looks up the most recent valid record published by the given
+// address.
+func (m *Node) Find(ctx context.Context, addr common.Address) (*pb.LocatorRecord, error) {
+	local := m.record(addr)
+
+	_, rec, err := m.lookup(ctx, addr, true)
+	if rec == nil || (local != nil && newer(local, rec)) {
+		rec = local
+	}
+
+	if rec == nil {
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, errRecordNotFound
+	}
+
+	return rec, nil
+}
+
+// lookup performs iterative lookup of the nodes closest to the target,
+// stopping early on the first round that returns a record of the target
+// if findValue is set.
+func (m *Node) lookup(ctx context.Context, target common.Address, findValue bool) ([]contact, *pb.LocatorRecord, error) {
+	shortlist := m.table.Closest(target, k)
+	if len(shortlist) == 0 {
+		return nil, nil, errNoContacts
+	}
+
+	seen := map[common.Address]bool{m.self.Addr: true}
+	for _, c := range shortlist {
+		seen[c.Addr] = true
+	}
+
+	queried := map[common.Address]bool{}
+	failed := map[common.Address]bool{}
+
+	type reply struct {
+		from     contact
+		contacts []contact
+		record   *pb.LocatorRecord
+		err      error
+	}
+
+	for {
+		var round []contact
+		for _, c := range shortlist {
+			if len(round) == alpha {
+				break
+			}
+
+			if !queried[c.Addr] {
+				queried[c.Addr] = true
+				round = append(round, c)
+			}
+		}
+
+		if len(round) == 0 {
+			break
+		}
+
+		replies := make(chan reply, len(round))
+		for _, c := range round {
+			go func(c contact) {
+				contacts, rec, err := m.find(ctx, c, target, findValue)
+				replies <- reply{from: c, contacts: contacts, record: rec, err: err}
+			}(c)
+		}
+
+		var found *pb.LocatorRecord
+		for range round {
+			r := <-replies
+			if r.err != nil {
+				log.G(ctx).Debug("DHT request failed", zap.Stringer("node", r.from.Addr), zap.Error(r.err))
+				failed[r.from.Addr] = true
+				m.table.Remove(r.from.Addr)
+				continue
+			}
+
+			if r.record != nil && (found == nil || newer(r.record, found)) {
+				found = r.record
+			}
+
+			for _, c := range r.contacts {
+				if !seen[c.Addr] {
+					seen[c.Addr] = true
+					shortlist = append(shortlist, c)
+				}
+			}
+		}
+
+		if found != nil {
+			return nil, found, nil
+		}
+
+		alive := shortlist[:0]
+		for _, c := range shortlist {
+			if !failed[c.Addr] {
+				alive = append(alive, c)
+			}
+		}
+		shortlist = alive
+
+		sortByDistance(target, shortlist)
+		if len(shortlist) > k {
+			shortlist = shortlist[:k]
+		}
+	}
+
+	if len(shortlist) == 0 {
+		return nil, nil, errNoContacts
+	}
+
+	return shortlist, nil, nil
+}
+
+// find requests the contact for the nodes closest to the target or for the
+// target record itself.
+func (m *Node) find(ctx context.Context, c contact, target common.Address, findValue bool) ([]contact, *pb.LocatorRecord, error) {
+	client, err := m.client(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	request := &pb.DHTFindRequest{Sender: m.self.IntoProto(), Target: target.Hex()}
+
+	var contacts []*pb.DHTContact
+	var rec *pb.LocatorRecord
+	if findValue {
+		reply, err := client.FindValue(ctx, request)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		contacts, rec = reply.GetContacts(), reply.GetRecord()
+	} else {
+		reply, err := client.FindNode(ctx, request)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		contacts = reply.GetContacts()
+	}
+
+	m.observe(c)
+
+	if rec != nil {
+		if common.HexToAddress(rec.GetEthAddr()) != target || locator.VerifyRecord(rec) != nil {
+			log.G(ctx).Warn("DHT node returned invalid record", zap.Stringer("node", c.Addr), zap.Stringer("target", target))
+			rec = nil
+		}
+	}
+
+	result := make([]contact, 0, len(contacts))
+	for _, contact := range contacts {
+		if c, ok := newContact(contact); ok && c.Addr != m.self.Addr {
+			result = append(result, c)
+		}
+	}
+
+	return result, rec, nil
+}
+
+func (m *Node) ping(ctx context.Context, c contact) error {
+	client, err := m.client(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	_, err = client.Ping(ctx, &pb.DHTPingRequest{Sender: m.self.IntoProto()})
+	return err
+}
+
+func (m *Node) storeAt(ctx context.Context, c contact, rec *pb.LocatorRecord) error {
+	client, err := m.client(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	_, err = client.Store(ctx, &pb.DHTStoreRequest{Sender: m.self.IntoProto(), Record: rec})
+	return err
+}
+
+// client returns DHT client for the given contact, reusing connections.
+func (m *Node) client(c contact) (pb.DHTClient, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cc, ok := m.conns[c.Addr]; ok {
+		if cc.endpoint == c.Endpoint {
+			return pb.NewDHTClient(cc.ClientConn), nil
+		}
+
+		cc.Close()
+		delete(m.conns, c.Addr)
+	}
+
+	cc, err := xgrpc.NewClient(m.ctx, c.Endpoint, auth.NewWalletAuthenticator(m.creds, c.Addr))
+	if err != nil {
+		return nil, err
+	}
+
+	m.conns[c.Addr] = &conn{endpoint: c.Endpoint, ClientConn: cc}
+	return pb.NewDHTClient(cc), nil
+}
+
+// observe updates the routing table with the contact that is known to be
+// alive. If the corresponding bucket is full its least recently seen
+// contact is pinged and replaced if it does not respond.
+func (m *Node) observe(c contact) {
+	oldest, full := m.table.Update(c)
+	if !full {
+		return
+	}
+
+	go func() {
+		if err := m.ping(m.ctx, oldest); err != nil {
+			m.table.Replace(oldest, c)
+			return
+		}
+
+		m.table.Update(oldest)
+	}()
+}
+
+// store saves the record locally unless there is a more recent one,
+// returning whether the record has been saved. Records of new addresses
+// are refused once the storage is full.
+func (m *Node) store(rec *pb.LocatorRecord) (bool, error) {
+	addr := common.HexToAddress(rec.GetEthAddr())
+	now := time.Now()
+
+	expireAt := recordExpireAt(rec)
+	if m.cfg.MaxRecordTTL > 0 && expireAt.After(now.Add(m.cfg.MaxRecordTTL)) {
+		expireAt = now.Add(m.cfg.MaxRecordTTL)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.records[addr]
+	if ok && !newer(rec, current.LocatorRecord) {
+		return false, nil
+	}
+
+	if !ok && m.cfg.MaxRecords > 0 && len(m.records) >= m.cfg.MaxRecords {
+		m.removeExpired(now)
+		if len(m.records) >= m.cfg.MaxRecords {
+			return false, errStorageFull
+		}
+	}
+
+	m.records[addr] = &storedRecord{LocatorRecord: rec, expireAt: expireAt}
+	return true, nil
+}
+
+// allowStore reports whether one more store request from the given sender
+// fits into the configured rate.
+func (m *Node) allowStore(sender common.Address) bool {
+	if m.cfg.StoreRate <= 0 {
+		return true
+	}
+
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counter, ok := m.stores[sender]
+	if !ok || now.Sub(counter.since) >= storeRatePeriod {
+		counter = &storeCounter{since: now}
+		m.stores[sender] = counter
+	}
+
+	if counter.count >= m.cfg.StoreRate {
+		return false
+	}
+
+	counter.count++
+	return true
+}
+
+// record returns the locally stored valid record of the given address.
+func (m *Node) record(addr common.Address) *pb.LocatorRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rec, ok := m.records[addr]
+	if !ok {
+		return nil
+	}
+
+	if !time.Now().Before(rec.expireAt) {
+		delete(m.records, addr)
+		return nil
+	}
+
+	if err := locator.VerifyRecord(rec.LocatorRecord); err != nil {
+		delete(m.records, addr)
+		return nil
+	}
+
+	return rec.LocatorRecord
+}
+
+// refresh periodically removes expired records and refreshes the routing
+// table by looking up the node itself.
+func (m *Node) refresh() {
+	if m.cfg.RefreshInterval <= 0 {
+		return
+	}
+
+	timer := time.NewTicker(m.cfg.RefreshInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-timer.C:
+			m.cleanup()
+
+			if m.table.Len() == 0 {
+				if err := m.Bootstrap(m.ctx); err != nil {
+					log.G(m.ctx).Warn("failed to bootstrap DHT node", zap.Error(err))
+				}
+				continue
+			}
+
+			if _, _, err := m.lookup(m.ctx, m.self.Addr, false); err != nil {
+				log.G(m.ctx).Warn("failed to refresh DHT routing table", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (m *Node) cleanup() {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.removeExpired(now)
+
+	for sender, counter := range m.stores {
+		if now.Sub(counter.since) >= storeRatePeriod {
+			delete(m.stores, sender)
+		}
+	}
+}
+
+// removeExpired removes records that are expired or exceeded the maximum
+// TTL.
+//
+// Synchronized by `m.mu`.
+func (m *Node) removeExpired(now time.Time) {
+	for addr, rec := range m.records {
+		if !now.Before(rec.expireAt) {
+			delete(m.records, addr)
+		}
+	}
+}
+
+// recordExpireAt returns the time the record expires at.
+func recordExpireAt(rec *pb.LocatorRecord) time.Time {
+	ts := time.Unix(rec.GetTs().GetSeconds(), int64(rec.GetTs().GetNanos()))
+	return ts.Add(time.Duration(rec.GetTtl()) * time.Second)
+}
+
+// newer reports whether the record a is more recent than b.
+func newer(a, b *pb.LocatorRecord) bool {
+	if a.GetTs().GetSeconds() != b.GetTs().GetSeconds() {
+		return a.GetTs().GetSeconds() > b.GetTs().GetSeconds()
+	}
+
+	return a.GetTs().GetNanos() > b.GetTs().GetNanos()
+}
@@ -0,0 +1,208 @@
+package dht
+
+import (
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sonm-io/core/insonmnia/locator"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func newTestNode(t *testing.T, endpoint string, bootstrap ...*Node) *Node {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	_, TLSConfig, err := util.NewHitlessCertRotator(context.Background(), key)
+	require.NoError(t, err)
+
+	cfg := Config{Endpoint: endpoint}
+	for _, node := range bootstrap {
+		cfg.Bootstrap = append(cfg.Bootstrap, node.Addr().Hex()+"@"+node.Endpoint())
+	}
+
+	node, err := NewNode(context.Background(), cfg, key, util.NewTLS(TLSConfig))
+	require.NoError(t, err)
+
+	if len(bootstrap) > 0 {
+		require.NoError(t, node.Bootstrap(context.Background()))
+	}
+
+	go node.Serve()
+
+	return node
+}
+
+// newTestNetwork starts n serving nodes, joined through the first one.
+func newTestNetwork(t *testing.T, n int) []*Node {
+	nodes := []*Node{newTestNode(t, "127.0.0.1:0")}
+	for i := 1; i < n; i++ {
+		nodes = append(nodes, newTestNode(t, "127.0.0.1:0", nodes[0]))
+	}
+
+	return nodes
+}
+
+func closeNodes(nodes []*Node) {
+	for _, node := range nodes {
+		node.Close()
+	}
+}
+
+func newTestRecord(t *testing.T, key *ecdsa.PrivateKey, addr string) *pb.LocatorRecord {
+	rec, err := locator.NewRecord(key, []*pb.Endpoint{{Addr: addr}}, nil, time.Minute)
+	require.NoError(t, err)
+
+	return rec
+}
+
+func TestNodePublishFind(t *testing.T) {
+	nodes := newTestNetwork(t, 6)
+	defer closeNodes(nodes)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := util.PubKeyToAddr(key.PublicKey)
+
+	require.NoError(t, nodes[3].Publish(context.Background(), newTestRecord(t, key, "10.0.0.1:10001")))
+
+	client := newTestNode(t, "", nodes[0])
+	defer client.Close()
+
+	rec, err := client.Find(context.Background(), addr)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:10001", rec.GetClientEndpoints()[0].GetAddr())
+
+	// More recent record replaces the previous one.
+	require.NoError(t, nodes[5].Publish(context.Background(), newTestRecord(t, key, "10.0.0.2:10001")))
+
+	rec, err = client.Find(context.Background(), addr)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.2:10001", rec.GetClientEndpoints()[0].GetAddr())
+
+	_, err = client.Find(context.Background(), common.HexToAddress("0x42"))
+	assert.Equal(t, errRecordNotFound, err)
+}
+
+func TestNodePublishInvalid(t *testing.T) {
+	nodes := newTestNetwork(t, 2)
+	defer closeNodes(nodes)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	rec := newTestRecord(t, key, "10.0.0.1:10001")
+	rec.ClientEndpoints[0].Addr = "10.0.0.66:10001"
+
+	assert.Error(t, nodes[1].Publish(context.Background(), rec))
+
+	// Forged records are rejected by other nodes too.
+	require.NoError(t, nodes[1].storeAt(context.Background(), nodes[0].self, newTestRecord(t, key, "10.0.0.1:10001")))
+	assert.Error(t, nodes[1].storeAt(context.Background(), nodes[0].self, rec))
+	assert.Equal(t, "10.0.0.1:10001", nodes[0].record(util.PubKeyToAddr(key.PublicKey)).GetClientEndpoints()[0].GetAddr())
+}
+
+func TestLocatorClient(t *testing.T) {
+	nodes := newTestNetwork(t, 3)
+	defer closeNodes(nodes)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	ethAddr := util.PubKeyToAddr(key.PublicKey).Hex()
+
+	lc := NewLocatorClient(nodes[1])
+
+	_, err = lc.Announce(context.Background(), &pb.AnnounceRequest{ClientEndpoints: []string{"10.0.0.1:10001"}})
+	assert.Error(t, err)
+
+	_, err = lc.Announce(context.Background(), &pb.AnnounceRequest{Record: newTestRecord(t, key, "10.0.0.1:10001")})
+	require.NoError(t, err)
+
+	reply, err := NewLocatorClient(nodes[2]).Resolve(context.Background(), &pb.ResolveRequest{EthAddr: ethAddr, EndpointType: pb.ResolveRequest_CLIENT})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1:10001"}, reply.GetEndpoints())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := NewLocatorClient(nodes[2]).WatchResolve(ctx, &pb.ResolveRequest{EthAddr: ethAddr, EndpointType: pb.ResolveRequest_CLIENT})
+	require.NoError(t, err)
+
+	reply, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1:10001"}, reply.GetEndpoints())
+
+	require.NoError(t, stream.CloseSend())
+	_, err = stream.Recv()
+	assert.Error(t, err)
+}
+
+func newTestStorage(t *testing.T, cfg Config) *Node {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	node, err := NewNode(context.Background(), cfg, key, nil)
+	require.NoError(t, err)
+
+	return node
+}
+
+func TestNodeStoreLimitsRecords(t *testing.T) {
+	node := newTestStorage(t, Config{MaxRecords: 2})
+	defer node.Close()
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for id := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[id] = key
+	}
+
+	for _, key := range keys[:2] {
+		ok, err := node.store(newTestRecord(t, key, "10.0.0.1:10001"))
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	_, err := node.store(newTestRecord(t, keys[2], "10.0.0.1:10001"))
+	assert.Equal(t, errStorageFull, err)
+
+	// Known addresses are still updated.
+	ok, err := node.store(newTestRecord(t, keys[0], "10.0.0.2:10001"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestNodeStoreLimitsTTL(t *testing.T) {
+	node := newTestStorage(t, Config{MaxRecordTTL: 50 * time.Millisecond})
+	defer node.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	_, err = node.store(newTestRecord(t, key, "10.0.0.1:10001"))
+	require.NoError(t, err)
+	assert.NotNil(t, node.record(util.PubKeyToAddr(key.PublicKey)))
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Nil(t, node.record(util.PubKeyToAddr(key.PublicKey)))
+}
+
+func TestNodeStoreRate(t *testing.T) {
+	node := newTestStorage(t, Config{StoreRate: 2})
+	defer node.Close()
+
+	sender := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	other := common.HexToAddress("0x9125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+
+	assert.True(t, node.allowStore(sender))
+	assert.True(t, node.allowStore(sender))
+	assert.False(t, node.allowStore(sender))
+	assert.True(t, node.allowStore(other))
+}
@@ -0,0 +1,144 @@
+package dht
+
+import (
+	"net"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/insonmnia/locator"
+	pb "github.com/sonm-io/core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// server answers DHT requests of other nodes.
+type server struct {
+	node *Node
+}
+
+func (m *server) Ping(ctx context.Context, request *pb.DHTPingRequest) (*pb.Empty, error) {
+	if err := m.observe(ctx, request.GetSender()); err != nil {
+		return nil, err
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (m *server) FindNode(ctx context.Context, request *pb.DHTFindRequest) (*pb.DHTFindNodeReply, error) {
+	if err := m.observe(ctx, request.GetSender()); err != nil {
+		return nil, err
+	}
+
+	target, err := parseAddr(request.GetTarget())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DHTFindNodeReply{Contacts: m.closest(target)}, nil
+}
+
+func (m *server) FindValue(ctx context.Context, request *pb.DHTFindRequest) (*pb.DHTFindValueReply, error) {
+	if err := m.observe(ctx, request.GetSender()); err != nil {
+		return nil, err
+	}
+
+	target, err := parseAddr(request.GetTarget())
+	if err != nil {
+		return nil, err
+	}
+
+	if rec := m.node.record(target); rec != nil {
+		return &pb.DHTFindValueReply{Record: rec}, nil
+	}
+
+	return &pb.DHTFindValueReply{Contacts: m.closest(target)}, nil
+}
+
+func (m *server) Store(ctx context.Context, request *pb.DHTStoreRequest) (*pb.Empty, error) {
+	if err := m.observe(ctx, request.GetSender()); err != nil {
+		return nil, err
+	}
+
+	wallet, err := auth.ExtractWalletFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if !m.node.allowStore(*wallet) {
+		return nil, status.Error(codes.ResourceExhausted, errStoreRate.Error())
+	}
+
+	if err := locator.VerifyRecord(request.GetRecord()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if _, err := m.node.store(request.GetRecord()); err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	return &pb.Empty{}, nil
+}
+
+// observe checks that the sender is the authenticated caller and adds it
+// to the routing table unless it is a client-only node.
+//
+// An empty host in the sender endpoint is replaced with the one the
+// request came from.
+func (m *server) observe(ctx context.Context, sender *pb.DHTContact) error {
+	wallet, err := auth.ExtractWalletFromContext(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if common.HexToAddress(sender.GetEthAddr()) != *wallet {
+		return status.Errorf(codes.PermissionDenied, "sender %s does not match caller %s", sender.GetEthAddr(), wallet.Hex())
+	}
+
+	if sender.GetEndpoint() == "" {
+		return nil
+	}
+
+	host, port, err := net.SplitHostPort(sender.GetEndpoint())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid sender endpoint: %v", err)
+	}
+
+	if ip := net.ParseIP(host); host == "" || ip != nil && ip.IsUnspecified() {
+		peerInfo, ok := peer.FromContext(ctx)
+		if !ok {
+			return status.Error(codes.InvalidArgument, "failed to determine sender host")
+		}
+
+		peerHost, _, err := net.SplitHostPort(peerInfo.Addr.String())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "failed to determine sender host: %v", err)
+		}
+
+		host = peerHost
+	}
+
+	m.node.observe(contact{Addr: *wallet, Endpoint: net.JoinHostPort(host, port)})
+
+	return nil
+}
+
+func (m *server) closest(target common.Address) []*pb.DHTContact {
+	contacts := m.node.table.Closest(target, k)
+
+	result := make([]*pb.DHTContact, 0, len(contacts))
+	for _, c := range contacts {
+		result = append(result, c.IntoProto())
+	}
+
+	return result
+}
+
+func parseAddr(addr string) (common.Address, error) {
+	if !common.IsHexAddress(addr) {
+		return common.Address{}, status.Errorf(codes.InvalidArgument, "invalid target address: %s", addr)
+	}
+
+	return common.HexToAddress(addr), nil
+}
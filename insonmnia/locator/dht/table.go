@@ -0,0 +1,168 @@
+package dht
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	pb "github.com/sonm-io/core/proto"
+)
+
+const idBits = common.AddressLength * 8
+
+// contact describes a DHT node.
+type contact struct {
+	Addr     common.Address
+	Endpoint string
+}
+
+func newContact(c *pb.DHTContact) (contact, bool) {
+	if c == nil || !common.IsHexAddress(c.GetEthAddr()) || c.GetEndpoint() == "" {
+		return contact{}, false
+	}
+
+	return contact{Addr: common.HexToAddress(c.GetEthAddr()), Endpoint: c.GetEndpoint()}, true
+}
+
+func (m contact) IntoProto() *pb.DHTContact {
+	return &pb.DHTContact{EthAddr: m.Addr.Hex(), Endpoint: m.Endpoint}
+}
+
+// distance returns XOR distance between two IDs.
+func distance(a, b common.Address) common.Address {
+	var d common.Address
+	for i := range d {
+		d[i] = a[i] ^ b[i]
+	}
+
+	return d
+}
+
+// closer reports whether a is closer to the target than b.
+func closer(target, a, b common.Address) bool {
+	da, db := distance(target, a), distance(target, b)
+	return bytes.Compare(da[:], db[:]) < 0
+}
+
+// bucketIndex returns the index of the bucket the ID belongs to, which is
+// the position of the highest bit differing from self. Returns -1 for self.
+func bucketIndex(self, id common.Address) int {
+	d := distance(self, id)
+	for i, b := range d {
+		for bit := 7; bit >= 0; bit-- {
+			if b&(1<<uint(bit)) != 0 {
+				return idBits - 1 - (i*8 + 7 - bit)
+			}
+		}
+	}
+
+	return -1
+}
+
+func sortByDistance(target common.Address, contacts []contact) {
+	sort.Slice(contacts, func(i, j int) bool {
+		return closer(target, contacts[i].Addr, contacts[j].Addr)
+	})
+}
+
+// table is a Kademlia routing table consisting of k-buckets ordered from the
+// least recently seen contact to the most recently seen one.
+type table struct {
+	self    common.Address
+	k       int
+	mu      sync.Mutex
+	buckets [idBits][]contact
+}
+
+func newTable(self common.Address, k int) *table {
+	return &table{self: self, k: k}
+}
+
+// Update marks the contact as the most recently seen one. If the bucket is
+// full the least recently seen contact is returned, which should be pinged
+// and replaced with the new one if it is dead.
+func (m *table) Update(c contact) (contact, bool) {
+	idx := bucketIndex(m.self, c.Addr)
+	if idx < 0 {
+		return contact{}, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bucket := m.buckets[idx]
+	for i, known := range bucket {
+		if known.Addr == c.Addr {
+			m.buckets[idx] = append(append(bucket[:i:i], bucket[i+1:]...), c)
+			return contact{}, false
+		}
+	}
+
+	if len(bucket) < m.k {
+		m.buckets[idx] = append(bucket, c)
+		return contact{}, false
+	}
+
+	return bucket[0], true
+}
+
+// Replace replaces the dead contact with the new one if the dead one is
+// still in the table.
+func (m *table) Replace(dead, c contact) {
+	if m.Remove(dead.Addr) {
+		m.Update(c)
+	}
+}
+
+// Remove removes the contact from the table, returning false if there was
+// no such contact.
+func (m *table) Remove(addr common.Address) bool {
+	idx := bucketIndex(m.self, addr)
+	if idx < 0 {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bucket := m.buckets[idx]
+	for i, known := range bucket {
+		if known.Addr == addr {
+			m.buckets[idx] = append(bucket[:i:i], bucket[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Closest returns up to n known contacts closest to the target.
+func (m *table) Closest(target common.Address, n int) []contact {
+	m.mu.Lock()
+	var contacts []contact
+	for _, bucket := range m.buckets {
+		contacts = append(contacts, bucket...)
+	}
+	m.mu.Unlock()
+
+	sortByDistance(target, contacts)
+	if len(contacts) > n {
+		contacts = contacts[:n]
+	}
+
+	return contacts
+}
+
+// Len returns the number of known contacts.
+func (m *table) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := 0
+	for _, bucket := range m.buckets {
+		n += len(bucket)
+	}
+
+	return n
+}
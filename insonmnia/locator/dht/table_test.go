@@ -0,0 +1,59 @@
+package dht
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketIndex(t *testing.T) {
+	self := common.Address{}
+
+	assert.Equal(t, -1, bucketIndex(self, self))
+	assert.Equal(t, 0, bucketIndex(self, common.Address{19: 0x01}))
+	assert.Equal(t, 7, bucketIndex(self, common.Address{19: 0x80}))
+	assert.Equal(t, idBits-1, bucketIndex(self, common.Address{0: 0x80}))
+}
+
+func TestTableUpdate(t *testing.T) {
+	tb := newTable(common.Address{}, 2)
+
+	a := contact{Addr: common.Address{0: 0x80}, Endpoint: "a"}
+	b := contact{Addr: common.Address{0: 0x81}, Endpoint: "b"}
+	c := contact{Addr: common.Address{0: 0x82}, Endpoint: "c"}
+
+	_, full := tb.Update(a)
+	assert.False(t, full)
+	_, full = tb.Update(b)
+	assert.False(t, full)
+
+	oldest, full := tb.Update(c)
+	assert.True(t, full)
+	assert.Equal(t, a, oldest)
+
+	// Seeing "a" again makes "b" the least recently seen one.
+	tb.Update(a)
+	oldest, full = tb.Update(c)
+	assert.True(t, full)
+	assert.Equal(t, b, oldest)
+
+	tb.Replace(b, c)
+	assert.Equal(t, []contact{a, c}, tb.Closest(common.Address{0: 0x80}, 3))
+	assert.Equal(t, 2, tb.Len())
+}
+
+func TestTableClosest(t *testing.T) {
+	tb := newTable(common.Address{}, k)
+
+	for i := 1; i <= 8; i++ {
+		tb.Update(contact{Addr: common.Address{19: byte(i)}})
+	}
+
+	closest := tb.Closest(common.Address{19: 0x05}, 3)
+	assert.Equal(t, []contact{
+		{Addr: common.Address{19: 0x05}},
+		{Addr: common.Address{19: 0x04}},
+		{Addr: common.Address{19: 0x07}},
+	}, closest)
+}
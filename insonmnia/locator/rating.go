@@ -0,0 +1,131 @@
+package locator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/docker/libkv/store"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/insonmnia/rating"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const ratingKeyPrefix = "rating/"
+
+// Report records the signed outcome of a deal. Only the authenticated
+// reporter itself may report outcomes signed by its key, while both the
+// reporter and the subject must be parties of the deal. Each party reports
+// the deal once.
+func (l *Locator) Report(ctx context.Context, entry *pb.RatingEntry) (*pb.Empty, error) {
+	ethAddr, err := l.extractEthAddr(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log.G(l.ctx).Info("handling Report request",
+		zap.Stringer("eth", ethAddr),
+		zap.String("subject", entry.GetSubject()),
+		zap.Stringer("outcome", entry.GetOutcome()),
+	)
+
+	if common.HexToAddress(entry.GetReporter()) != ethAddr {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot report on behalf of %s", entry.GetReporter())
+	}
+
+	if err := rating.Verify(entry); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := l.verifyDealParties(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err := l.storage.AtomicPut(ratingKey(entry), value, nil, nil); err != nil {
+		if err == store.ErrKeyExists {
+			return nil, status.Errorf(codes.AlreadyExists, "deal %s has been already reported", entry.GetDealID())
+		}
+
+		return nil, err
+	}
+
+	return &pb.Empty{}, nil
+}
+
+// verifyDealParties checks that the reporter and the subject of the entry
+// are the buyer and the supplier of the deal it describes.
+func (l *Locator) verifyDealParties(ctx context.Context, entry *pb.RatingEntry) error {
+	id, err := util.ParseBigInt(entry.GetDealID())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid deal id %s", entry.GetDealID())
+	}
+
+	deal, err := l.eth.GetDealInfo(ctx, id)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "cannot get deal %s: %v", entry.GetDealID(), err)
+	}
+
+	reporter := common.HexToAddress(entry.GetReporter())
+	subject := common.HexToAddress(entry.GetSubject())
+	buyer := common.HexToAddress(deal.GetBuyerID())
+	supplier := common.HexToAddress(deal.GetSupplierID())
+
+	if (reporter != buyer || subject != supplier) && (reporter != supplier || subject != buyer) {
+		return status.Errorf(codes.PermissionDenied, "%s and %s are not parties of deal %s",
+			entry.GetReporter(), entry.GetSubject(), entry.GetDealID())
+	}
+
+	return nil
+}
+
+func (l *Locator) GetRating(ctx context.Context, req *pb.GetRatingRequest) (*pb.RatingReply, error) {
+	log.G(l.ctx).Info("handling GetRating request", zap.String("eth", req.GetEthAddr()))
+
+	if !common.IsHexAddress(req.GetEthAddr()) {
+		return nil, fmt.Errorf("invalid ethaddress %s", req.GetEthAddr())
+	}
+
+	ethAddr := common.HexToAddress(req.GetEthAddr())
+
+	pairs, err := l.storage.List(ratingKeyPrefix + ethAddr.Hex() + "/")
+	if err != nil && err != store.ErrKeyNotFound {
+		return nil, err
+	}
+
+	entries := make([]*pb.RatingEntry, 0, len(pairs))
+	for _, pair := range pairs {
+		entry := &pb.RatingEntry{}
+		if err := json.Unmarshal(pair.Value, entry); err != nil {
+			log.G(l.ctx).Warn("malformed rating entry", zap.String("key", pair.Key), zap.Error(err))
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return rating.Compute(ethAddr.Hex(), entries), nil
+}
+
+// ratingKey returns the storage key of the given entry. Any outcome of the
+// deal reported by the same party is stored under the same key, so each
+// party is able to report the deal only once.
+func ratingKey(entry *pb.RatingEntry) string {
+	id := crypto.Keccak256(
+		common.HexToAddress(entry.GetReporter()).Bytes(),
+		[]byte(entry.GetDealID()),
+	)
+
+	return ratingKeyPrefix + common.HexToAddress(entry.GetSubject()).Hex() + "/" + hex.EncodeToString(id)
+}
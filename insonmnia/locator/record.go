@@ -0,0 +1,180 @@
+package locator
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+)
+
+// maxClockSkew is the maximum allowed difference between the record
+// timestamp and the local time for records from the future.
+const maxClockSkew = time.Minute
+
+var (
+	errInvalidRecordSignature = errors.New("locator record signature is invalid")
+	errRecordExpired          = errors.New("locator record is expired")
+	errRecordFromFuture       = errors.New("locator record timestamp is in the future")
+)
+
+// recordHash returns the hash of the record fields covered by the signature.
+func recordHash(rec *pb.LocatorRecord) []byte {
+	data := common.HexToAddress(rec.GetEthAddr()).Bytes()
+	data = appendEndpoints(data, rec.GetClientEndpoints())
+	data = appendEndpoints(data, rec.GetWorkerEndpoints())
+	data = appendUint64(data, uint64(rec.GetTs().GetSeconds()))
+	data = appendUint64(data, uint64(rec.GetTs().GetNanos()))
+	data = appendUint64(data, rec.GetTtl())
+
+	return crypto.Keccak256(data)
+}
+
+func appendEndpoints(data []byte, endpoints []*pb.Endpoint) []byte {
+	data = appendUint64(data, uint64(len(endpoints)))
+	for _, endpoint := range endpoints {
+		data = appendString(data, endpoint.GetAddr())
+		data = appendUint64(data, uint64(endpoint.GetPriority()))
+		data = appendUint64(data, uint64(endpoint.GetWeight()))
+		data = appendString(data, endpoint.GetRegion())
+	}
+
+	return data
+}
+
+func appendString(data []byte, value string) []byte {
+	return append(appendUint64(data, uint64(len(value))), value...)
+}
+
+func appendUint64(data []byte, value uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, value)
+	return append(data, buf...)
+}
+
+// NewRecord creates a record announcing the given endpoints, which is valid
+// for the given TTL and signed by the announcer's key.
+func NewRecord(key *ecdsa.PrivateKey, clientEndpoints, workerEndpoints []*pb.Endpoint, ttl time.Duration) (*pb.LocatorRecord, error) {
+	rec := &pb.LocatorRecord{
+		EthAddr:         util.PubKeyToAddr(key.PublicKey).Hex(),
+		ClientEndpoints: clientEndpoints,
+		WorkerEndpoints: workerEndpoints,
+		Ts:              newTimestamp(time.Now()),
+		Ttl:             uint64(ttl / time.Second),
+	}
+
+	signature, err := crypto.Sign(recordHash(rec), key)
+	if err != nil {
+		return nil, err
+	}
+
+	rec.Signature = signature
+	return rec, nil
+}
+
+// VerifyRecord checks that the record is signed by the party it describes
+// and is not expired yet.
+func VerifyRecord(rec *pb.LocatorRecord) error {
+	if !common.IsHexAddress(rec.GetEthAddr()) {
+		return fmt.Errorf("invalid locator record address: %s", rec.GetEthAddr())
+	}
+
+	pub, err := crypto.SigToPub(recordHash(rec), rec.GetSignature())
+	if err != nil {
+		return errInvalidRecordSignature
+	}
+
+	if crypto.PubkeyToAddress(*pub) != common.HexToAddress(rec.GetEthAddr()) {
+		return errInvalidRecordSignature
+	}
+
+	now := time.Now()
+	if recordTime(rec).After(now.Add(maxClockSkew)) {
+		return errRecordFromFuture
+	}
+
+	if recordExpired(rec, now) {
+		return errRecordExpired
+	}
+
+	return nil
+}
+
+func newTimestamp(t time.Time) *pb.Timestamp {
+	return &pb.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+}
+
+func recordTime(rec *pb.LocatorRecord) time.Time {
+	return time.Unix(rec.GetTs().GetSeconds(), int64(rec.GetTs().GetNanos()))
+}
+
+func recordTTL(rec *pb.LocatorRecord) time.Duration {
+	return time.Duration(rec.GetTtl()) * time.Second
+}
+
+func recordExpired(rec *pb.LocatorRecord, now time.Time) bool {
+	return !recordTime(rec).Add(recordTTL(rec)).After(now)
+}
+
+// legacyEndpoints converts plain addresses into endpoints of equal
+// preference.
+func legacyEndpoints(addrs []string) []*pb.Endpoint {
+	endpoints := make([]*pb.Endpoint, 0, len(addrs))
+	for _, addr := range addrs {
+		endpoints = append(endpoints, &pb.Endpoint{Addr: addr})
+	}
+
+	return endpoints
+}
+
+// ResolveEndpoints returns addresses of the record endpoints of the
+// requested type, ordered by preference.
+func ResolveEndpoints(rec *pb.LocatorRecord, req *pb.ResolveRequest) ([]string, error) {
+	var endpoints []*pb.Endpoint
+	switch req.EndpointType {
+	case pb.ResolveRequest_CLIENT:
+		endpoints = rec.ClientEndpoints
+	case pb.ResolveRequest_WORKER:
+		endpoints = rec.WorkerEndpoints
+	case pb.ResolveRequest_ANY:
+		endpoints = append(append([]*pb.Endpoint{}, rec.ClientEndpoints...), rec.WorkerEndpoints...)
+	default:
+		return nil, fmt.Errorf("unknown endpoint type: %d", req.EndpointType)
+	}
+
+	return sortEndpoints(endpoints, req.GetRegion()), nil
+}
+
+// sortEndpoints returns addresses of the given endpoints ordered by
+// priority, then by the region preference and then by weight.
+func sortEndpoints(endpoints []*pb.Endpoint, region string) []string {
+	sorted := append([]*pb.Endpoint{}, endpoints...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.GetPriority() != b.GetPriority() {
+			return a.GetPriority() < b.GetPriority()
+		}
+
+		if region != "" {
+			aLocal, bLocal := a.GetRegion() == region, b.GetRegion() == region
+			if aLocal != bLocal {
+				return aLocal
+			}
+		}
+
+		return a.GetWeight() > b.GetWeight()
+	})
+
+	addrs := make([]string, 0, len(sorted))
+	for _, endpoint := range sorted {
+		addrs = append(addrs, endpoint.GetAddr())
+	}
+
+	return addrs
+}
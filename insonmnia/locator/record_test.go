@@ -0,0 +1,50 @@
+package locator
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordVerify(t *testing.T) {
+	rec, err := NewRecord(key, legacyEndpoints([]string{"8.8.8.8:10001"}), nil, time.Minute)
+	require.NoError(t, err)
+	assert.NoError(t, VerifyRecord(rec))
+
+	rec.ClientEndpoints[0].Priority = 1
+	assert.Equal(t, errInvalidRecordSignature, VerifyRecord(rec))
+}
+
+func TestRecordVerifyForeignKey(t *testing.T) {
+	rec, err := NewRecord(key, legacyEndpoints([]string{"8.8.8.8:10001"}), nil, time.Minute)
+	require.NoError(t, err)
+
+	other, err := NewRecord(getTestKey(), rec.ClientEndpoints, nil, time.Minute)
+	require.NoError(t, err)
+
+	rec.Signature = other.Signature
+	assert.Equal(t, errInvalidRecordSignature, VerifyRecord(rec))
+}
+
+func TestRecordVerifyExpired(t *testing.T) {
+	rec, err := NewRecord(key, nil, nil, 0)
+	require.NoError(t, err)
+	assert.Equal(t, errRecordExpired, VerifyRecord(rec))
+}
+
+func TestSortEndpoints(t *testing.T) {
+	endpoints := []*pb.Endpoint{
+		{Addr: "backup:1", Priority: 1, Weight: 100},
+		{Addr: "light:1", Weight: 10, Region: "eu"},
+		{Addr: "heavy:1", Weight: 100, Region: "us"},
+		{Addr: "local:1", Weight: 1, Region: "eu"},
+	}
+
+	assert.Equal(t, []string{"heavy:1", "light:1", "local:1", "backup:1"}, sortEndpoints(endpoints, ""))
+	assert.Equal(t, []string{"light:1", "local:1", "heavy:1", "backup:1"}, sortEndpoints(endpoints, "eu"))
+	// The order of the source endpoints is kept.
+	assert.Equal(t, "backup:1", endpoints[0].Addr)
+}
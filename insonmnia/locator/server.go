@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/docker/libkv"
@@ -13,9 +14,11 @@ import (
 	"github.com/docker/libkv/store/boltdb"
 	"github.com/docker/libkv/store/consul"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/protobuf/proto"
 	"github.com/grpc-ecosystem/go-grpc-prometheus"
 	log "github.com/noxiouz/zapctx/ctxlog"
 	"github.com/pkg/errors"
+	"github.com/sonm-io/core/blockchain"
 	"github.com/sonm-io/core/insonmnia/auth"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
@@ -29,14 +32,14 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-var errNodeNotFound = errors.New("record with given Eth address cannot be found")
+const recordKeyPrefix = "record/"
 
-type record struct {
-	EthAddr         common.Address
-	ClientEndpoints []string
-	WorkerEndpoints []string
-	TS              time.Time
-}
+// watchPeriod specifies how often watched records are re-read from the
+// storage to notice expiration and changes made by other locator instances
+// sharing it.
+const watchPeriod = 5 * time.Second
+
+var errNodeNotFound = errors.New("record with given Eth address cannot be found")
 
 type Locator struct {
 	conf                *Config
@@ -46,6 +49,10 @@ type Locator struct {
 	creds               credentials.TransportCredentials
 	onlyPublicClientIPs bool
 	storage             store.Store
+	eth                 blockchain.Blockchainer
+
+	watchMu  sync.Mutex
+	watchers map[common.Address]map[chan struct{}]struct{}
 }
 
 func (l *Locator) Announce(ctx context.Context, req *pb.AnnounceRequest) (*pb.Empty, error) {
@@ -54,52 +61,64 @@ func (l *Locator) Announce(ctx context.Context, req *pb.AnnounceRequest) (*pb.Em
 		return nil, err
 	}
 
-	clientEndpoints, err := l.filterEndpoints(ethAddr, req.ClientEndpoints, l.onlyPublicClientIPs)
+	rec := req.GetRecord()
+	if rec == nil {
+		rec = &pb.LocatorRecord{
+			EthAddr:         ethAddr.Hex(),
+			ClientEndpoints: legacyEndpoints(req.ClientEndpoints),
+			WorkerEndpoints: legacyEndpoints(req.WorkerEndpoints),
+		}
+	} else {
+		if common.HexToAddress(rec.GetEthAddr()) != ethAddr {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot announce on behalf of %s", rec.GetEthAddr())
+		}
+
+		if err := VerifyRecord(rec); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	clientEndpoints, err := l.filterEndpoints(ethAddr, rec.GetClientEndpoints(), l.onlyPublicClientIPs)
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid client endpoints")
 	}
 
-	workerEndpoints, err := l.filterEndpoints(ethAddr, req.WorkerEndpoints, false)
+	workerEndpoints, err := l.filterEndpoints(ethAddr, rec.GetWorkerEndpoints(), false)
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid worker endpoints")
 	}
 
 	log.G(l.ctx).Info("handling Announce request",
 		zap.Stringer("eth", ethAddr),
-		zap.Strings("client_endpoints", clientEndpoints),
-		zap.Strings("worker_endpoints", workerEndpoints),
+		zap.Bool("signed", len(rec.GetSignature()) != 0),
+		zap.Uint64("ttl", rec.GetTtl()),
+		zap.Any("client_endpoints", clientEndpoints),
+		zap.Any("worker_endpoints", workerEndpoints),
 	)
 
-	err = l.put(&record{
-		EthAddr:         ethAddr,
-		ClientEndpoints: clientEndpoints,
-		WorkerEndpoints: workerEndpoints,
-	})
-
-	if err != nil {
+	if err := l.put(rec); err != nil {
 		return nil, err
 	}
 
 	return &pb.Empty{}, nil
 }
 
-func (l *Locator) filterEndpoints(ethAddr common.Address, endpoints []string, onlyPublic bool) ([]string, error) {
-	var okEndpoints, skippedEndpoints []string
+// filterEndpoints returns endpoints that are allowed to be resolved.
+// Signed records are stored intact, because they can not be modified without
+// breaking the signature, so this filter is applied again while resolving.
+func (l *Locator) filterEndpoints(ethAddr common.Address, endpoints []*pb.Endpoint, onlyPublic bool) ([]*pb.Endpoint, error) {
+	var okEndpoints []*pb.Endpoint
+	var skippedEndpoints []string
 	for _, endpoint := range endpoints {
-		strIP, _, err := net.SplitHostPort(endpoint)
-		if err != nil {
-			skippedEndpoints = append(skippedEndpoints, endpoint)
+		if _, _, err := net.SplitHostPort(endpoint.GetAddr()); err != nil {
+			skippedEndpoints = append(skippedEndpoints, endpoint.GetAddr())
 			continue
 		}
 
-		if onlyPublic {
-			if ip := net.ParseIP(strIP); ip != nil && !util.IsPrivateIP(ip) {
-				okEndpoints = append(okEndpoints, endpoint)
-			} else {
-				skippedEndpoints = append(skippedEndpoints, endpoint)
-			}
-		} else {
+		if !onlyPublic || isPublicEndpoint(endpoint) {
 			okEndpoints = append(okEndpoints, endpoint)
+		} else {
+			skippedEndpoints = append(skippedEndpoints, endpoint.GetAddr())
 		}
 	}
 
@@ -116,6 +135,32 @@ func (l *Locator) filterEndpoints(ethAddr common.Address, endpoints []string, on
 	return okEndpoints, nil
 }
 
+// PublicEndpoints returns endpoints with public IP addresses only.
+//
+// Signed records are stored intact, because they can not be modified
+// without breaking the signature, so private endpoints are filtered out
+// while resolving.
+func PublicEndpoints(endpoints []*pb.Endpoint) []*pb.Endpoint {
+	var result []*pb.Endpoint
+	for _, endpoint := range endpoints {
+		if isPublicEndpoint(endpoint) {
+			result = append(result, endpoint)
+		}
+	}
+
+	return result
+}
+
+func isPublicEndpoint(endpoint *pb.Endpoint) bool {
+	strIP, _, err := net.SplitHostPort(endpoint.GetAddr())
+	if err != nil {
+		return false
+	}
+
+	ip := net.ParseIP(strIP)
+	return ip != nil && !util.IsPrivateIP(ip)
+}
+
 func (l *Locator) Resolve(ctx context.Context, req *pb.ResolveRequest) (*pb.ResolveReply, error) {
 	log.G(l.ctx).Info("handling Resolve request", zap.String("eth", req.EthAddr))
 
@@ -128,22 +173,113 @@ func (l *Locator) Resolve(ctx context.Context, req *pb.ResolveRequest) (*pb.Reso
 		return nil, err
 	}
 
-	var endpoints []string
-	switch req.EndpointType {
-	case pb.ResolveRequest_CLIENT:
-		endpoints = rec.ClientEndpoints
-	case pb.ResolveRequest_WORKER:
-		endpoints = rec.WorkerEndpoints
-	case pb.ResolveRequest_ANY:
-		endpoints = append(rec.ClientEndpoints, rec.WorkerEndpoints...)
-	default:
-		return nil, fmt.Errorf("unknown endpoint type: %d", req.EndpointType)
+	return l.newResolveReply(rec, req)
+}
+
+func (l *Locator) newResolveReply(rec *pb.LocatorRecord, req *pb.ResolveRequest) (*pb.ResolveReply, error) {
+	view := *rec
+	if l.onlyPublicClientIPs {
+		view.ClientEndpoints = PublicEndpoints(rec.ClientEndpoints)
 	}
 
-	return &pb.ResolveReply{Endpoints: endpoints}, nil
+	endpoints, err := ResolveEndpoints(&view, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ResolveReply{Endpoints: endpoints, Record: rec}, nil
+}
+
+// WatchResolve sends the resolved endpoints first and then each time they
+// change. An empty reply is sent when there is no such record or it has been
+// expired.
+func (l *Locator) WatchResolve(req *pb.ResolveRequest, stream pb.Locator_WatchResolveServer) error {
+	log.G(l.ctx).Info("handling WatchResolve request", zap.String("eth", req.EthAddr))
+
+	if !common.IsHexAddress(req.EthAddr) {
+		return fmt.Errorf("invalid ethaddress %s", req.EthAddr)
+	}
+
+	ethAddr := common.HexToAddress(req.EthAddr)
+
+	notify := l.watch(ethAddr)
+	defer l.unwatch(ethAddr, notify)
+
+	ticker := time.NewTicker(watchPeriod)
+	defer ticker.Stop()
+
+	var prev *pb.ResolveReply
+	for {
+		reply := &pb.ResolveReply{}
+		rec, err := l.get(ethAddr)
+		switch err {
+		case nil:
+			if reply, err = l.newResolveReply(rec, req); err != nil {
+				return err
+			}
+		case errNodeNotFound:
+		default:
+			return err
+		}
+
+		if prev == nil || !proto.Equal(prev, reply) {
+			if err := stream.Send(reply); err != nil {
+				return err
+			}
+			prev = reply
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-l.ctx.Done():
+			return l.ctx.Err()
+		case <-notify:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *Locator) watch(ethAddr common.Address) chan struct{} {
+	l.watchMu.Lock()
+	defer l.watchMu.Unlock()
+
+	notify := make(chan struct{}, 1)
+	if l.watchers[ethAddr] == nil {
+		l.watchers[ethAddr] = map[chan struct{}]struct{}{}
+	}
+	l.watchers[ethAddr][notify] = struct{}{}
+
+	return notify
+}
+
+func (l *Locator) unwatch(ethAddr common.Address, notify chan struct{}) {
+	l.watchMu.Lock()
+	defer l.watchMu.Unlock()
+
+	delete(l.watchers[ethAddr], notify)
+	if len(l.watchers[ethAddr]) == 0 {
+		delete(l.watchers, ethAddr)
+	}
+}
+
+func (l *Locator) notify(ethAddr common.Address) {
+	l.watchMu.Lock()
+	defer l.watchMu.Unlock()
+
+	for notify := range l.watchers[ethAddr] {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	}
 }
 
 func (l *Locator) Serve() error {
+	if l.conf.CleanupPeriod > 0 {
+		go l.cleanup()
+	}
+
 	lis, err := net.Listen("tcp", l.conf.ListenAddr)
 	if err != nil {
 		return err
@@ -166,19 +302,30 @@ func (l *Locator) extractEthAddr(ctx context.Context) (common.Address, error) {
 	}
 }
 
-func (l *Locator) put(rec *record) error {
-	rec.TS = time.Now()
-	key := rec.EthAddr.Hex()
+// put saves the record. Unsigned records get the current timestamp, while
+// the TTL of any record is limited by the configured node TTL.
+func (l *Locator) put(rec *pb.LocatorRecord) error {
+	if len(rec.GetSignature()) == 0 {
+		rec.Ts = newTimestamp(time.Now())
+		rec.Ttl = 0
+	}
+
+	ethAddr := common.HexToAddress(rec.GetEthAddr())
 	value, err := json.Marshal(rec)
 	if err != nil {
 		return err
 	}
 
-	return l.storage.Put(key, value, nil)
+	if err := l.storage.Put(recordKey(ethAddr), value, nil); err != nil {
+		return err
+	}
+
+	l.notify(ethAddr)
+	return nil
 }
 
-func (l *Locator) get(ethAddr common.Address) (*record, error) {
-	key := ethAddr.Hex()
+func (l *Locator) get(ethAddr common.Address) (*pb.LocatorRecord, error) {
+	key := recordKey(ethAddr)
 
 	pair, err := l.storage.Get(key)
 	if err != nil {
@@ -186,13 +333,12 @@ func (l *Locator) get(ethAddr common.Address) (*record, error) {
 		return nil, errNodeNotFound
 	}
 
-	rec := &record{}
+	rec := &pb.LocatorRecord{}
 	if err = json.Unmarshal(pair.Value, rec); err != nil {
 		return nil, err
 	}
 
-	notBefore := time.Now().Add(-1 * l.conf.NodeTTL)
-	if rec.TS.Before(notBefore) {
+	if l.expired(rec, time.Now()) {
 		log.G(l.ctx).Debug("record is expired", zap.String("key", key))
 		l.storage.Delete(pair.Key)
 		return nil, errNodeNotFound
@@ -201,6 +347,63 @@ func (l *Locator) get(ethAddr common.Address) (*record, error) {
 	return rec, nil
 }
 
+// expired checks whether the record is expired using its own TTL, which is
+// limited by the configured node TTL. Records without TTL live for the node
+// TTL.
+func (l *Locator) expired(rec *pb.LocatorRecord, now time.Time) bool {
+	ttl := recordTTL(rec)
+	if ttl == 0 || ttl > l.conf.NodeTTL {
+		ttl = l.conf.NodeTTL
+	}
+
+	return !recordTime(rec).Add(ttl).After(now)
+}
+
+// cleanup periodically removes expired records from the storage.
+func (l *Locator) cleanup() {
+	ticker := time.NewTicker(l.conf.CleanupPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.cleanupExpired(); err != nil {
+				log.G(l.ctx).Warn("failed to cleanup expired records", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (l *Locator) cleanupExpired() error {
+	pairs, err := l.storage.List(recordKeyPrefix)
+	if err != nil && err != store.ErrKeyNotFound {
+		return err
+	}
+
+	now := time.Now()
+	for _, pair := range pairs {
+		rec := &pb.LocatorRecord{}
+		if err := json.Unmarshal(pair.Value, rec); err != nil {
+			log.G(l.ctx).Warn("malformed locator record", zap.String("key", pair.Key), zap.Error(err))
+			continue
+		}
+
+		if l.expired(rec, now) {
+			log.G(l.ctx).Debug("removing expired record", zap.String("key", pair.Key))
+			l.storage.Delete(pair.Key)
+			l.notify(common.HexToAddress(rec.GetEthAddr()))
+		}
+	}
+
+	return nil
+}
+
+func recordKey(ethAddr common.Address) string {
+	return recordKeyPrefix + ethAddr.Hex()
+}
+
 func initStorage(ctx context.Context, conf storeConfig) (store.Store, error) {
 	consul.Register()
 	boltdb.Register()
@@ -232,6 +435,7 @@ func NewLocator(ctx context.Context, conf *Config, key *ecdsa.PrivateKey) (l *Lo
 		conf:                conf,
 		ctx:                 ctx,
 		onlyPublicClientIPs: conf.OnlyPublicClientIPs,
+		watchers:            map[common.Address]map[chan struct{}]struct{}{},
 	}
 
 	var TLSConfig *tls.Config
@@ -246,6 +450,12 @@ func NewLocator(ctx context.Context, conf *Config, key *ecdsa.PrivateKey) (l *Lo
 	}
 
 	l.storage = s
+
+	l.eth, err = blockchain.NewAPI(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	l.creds = util.NewTLS(TLSConfig)
 	l.grpc = xgrpc.NewServer(log.GetLogger(l.ctx),
 		xgrpc.Credentials(l.creds),
@@ -253,6 +463,7 @@ func NewLocator(ctx context.Context, conf *Config, key *ecdsa.PrivateKey) (l *Lo
 	)
 
 	pb.RegisterLocatorServer(l.grpc, l)
+	pb.RegisterRatingServer(l.grpc, l)
 	grpc_prometheus.Register(l.grpc)
 
 	return l, nil
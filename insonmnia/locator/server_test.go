@@ -3,18 +3,28 @@ package locator
 import (
 	"crypto/ecdsa"
 	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"math/big"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang/mock/gomock"
+	"github.com/sonm-io/core/blockchain"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/insonmnia/rating"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
 	"github.com/sonm-io/core/util/xgrpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -37,14 +47,14 @@ func TestLocator_Announce(t *testing.T) {
 	}
 
 	for _, addr := range put {
-		err := lc.put(&record{EthAddr: common.HexToAddress(addr)})
+		err := lc.put(&pb.LocatorRecord{EthAddr: common.HexToAddress(addr).Hex()})
 		require.NoError(t, err)
 	}
 
 	for _, addr := range put {
 		rk, err := lc.get(common.HexToAddress(addr))
 		require.NoError(t, err)
-		assert.Equal(t, rk.EthAddr, common.HexToAddress(addr))
+		assert.Equal(t, rk.EthAddr, common.HexToAddress(addr).Hex())
 	}
 }
 
@@ -55,7 +65,7 @@ func TestLocator_Resolve(t *testing.T) {
 		return
 	}
 
-	n := &record{EthAddr: common.HexToAddress("123"), ClientEndpoints: []string{"111", "222"}}
+	n := &pb.LocatorRecord{EthAddr: common.HexToAddress("123").Hex(), ClientEndpoints: legacyEndpoints([]string{"111", "222"})}
 	lc.put(n)
 
 	n2, err := lc.get(common.HexToAddress("123"))
@@ -70,7 +80,7 @@ func TestLocator_Resolve2(t *testing.T) {
 		return
 	}
 
-	n := &record{EthAddr: common.HexToAddress("123"), ClientEndpoints: []string{"111", "222"}}
+	n := &pb.LocatorRecord{EthAddr: common.HexToAddress("123").Hex(), ClientEndpoints: legacyEndpoints([]string{"111", "222"})}
 	lc.put(n)
 
 	n2, err := lc.get(common.HexToAddress("666"))
@@ -85,11 +95,11 @@ func TestLocator_Expire(t *testing.T) {
 		return
 	}
 
-	lc.put(&record{EthAddr: common.HexToAddress("111")})
+	lc.put(&pb.LocatorRecord{EthAddr: common.HexToAddress("111").Hex()})
 	time.Sleep(500 * time.Millisecond)
 	rec, err := lc.get(common.HexToAddress("111"))
 	assert.NoError(t, err)
-	assert.Equal(t, rec.EthAddr, common.HexToAddress("111"))
+	assert.Equal(t, rec.EthAddr, common.HexToAddress("111").Hex())
 
 	time.Sleep(1000 * time.Millisecond)
 	rec, err = lc.get(common.HexToAddress("111"))
@@ -149,9 +159,9 @@ func TestLocator_AnnounceExternal(t *testing.T) {
 	rec, err := lc.get(util.PubKeyToAddr(key.PublicKey))
 	assert.NoError(t, err)
 
-	assert.Equal(t, rec.EthAddr, util.PubKeyToAddr(key.PublicKey))
-	assert.Equal(t, rec.ClientEndpoints, []string{"192.168.0.1:10001"})
-	assert.Equal(t, rec.WorkerEndpoints, []string{"192.168.0.1:10002"})
+	assert.Equal(t, rec.EthAddr, util.PubKeyToAddr(key.PublicKey).Hex())
+	assert.Equal(t, sortEndpoints(rec.ClientEndpoints, ""), []string{"192.168.0.1:10001"})
+	assert.Equal(t, sortEndpoints(rec.WorkerEndpoints, ""), []string{"192.168.0.1:10002"})
 
 	if err := conn.Close(); err != nil {
 		t.Error(err)
@@ -214,12 +224,237 @@ func TestLocator_SkipPrivateIP(t *testing.T) {
 			WorkerEndpoints: []string{"42.42.42.42:10002", "192.168.0.0:10002"}})
 	assert.NoError(t, err)
 
-	rec, err := lc.get(util.PubKeyToAddr(key.PublicKey))
+	ethAddr := util.PubKeyToAddr(key.PublicKey).Hex()
+
+	reply, err := lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: ethAddr, EndpointType: pb.ResolveRequest_CLIENT})
+	assert.NoError(t, err)
+	assert.Equal(t, reply.Endpoints, []string{"42.42.42.42:10001"})
+
+	reply, err = lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: ethAddr, EndpointType: pb.ResolveRequest_WORKER})
 	assert.NoError(t, err)
-	assert.Equal(t, rec.ClientEndpoints, []string{"42.42.42.42:10001"})
-	assert.Equal(t, rec.WorkerEndpoints, []string{"42.42.42.42:10002", "192.168.0.0:10002"})
+	assert.Equal(t, reply.Endpoints, []string{"42.42.42.42:10002", "192.168.0.0:10002"})
 
 	if err := conn.Close(); err != nil {
 		t.Error(err)
 	}
 }
+
+func TestLocator_Rating(t *testing.T) {
+	cfg := testConfig(":9090")
+	cfg.Store.Endpoint += "-rating"
+
+	lc, err := NewLocator(context.Background(), cfg, key)
+	require.NoError(t, err)
+
+	defer os.Remove(cfg.Store.Endpoint)
+
+	reporter := getTestKey()
+	subject := util.PubKeyToAddr(getTestKey().PublicKey).Hex()
+
+	for _, outcome := range []pb.DealOutcome{pb.DealOutcome_COMPLETED, pb.DealOutcome_COMPLETED} {
+		entry, err := rating.NewEntry(reporter, "1", subject, outcome, "")
+		require.NoError(t, err)
+
+		value, err := json.Marshal(entry)
+		require.NoError(t, err)
+		require.NoError(t, lc.storage.Put(ratingKey(entry), value, nil))
+	}
+
+	entry, err := rating.NewEntry(reporter, "2", subject, pb.DealOutcome_SLA_BREACH, "")
+	require.NoError(t, err)
+	value, err := json.Marshal(entry)
+	require.NoError(t, err)
+	require.NoError(t, lc.storage.Put(ratingKey(entry), value, nil))
+
+	reply, err := lc.GetRating(context.Background(), &pb.GetRatingRequest{EthAddr: subject})
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), reply.GetRating())
+	// Duplicate reports are accounted once.
+	assert.Equal(t, uint64(1), reply.GetOutcomes()["COMPLETED"])
+
+	reply, err = lc.GetRating(context.Background(), &pb.GetRatingRequest{EthAddr: util.PubKeyToAddr(reporter.PublicKey).Hex()})
+	require.NoError(t, err)
+	assert.Equal(t, int64(rating.DefaultRating), reply.GetRating())
+	assert.Empty(t, reply.GetOutcomes())
+}
+
+func TestLocator_Report(t *testing.T) {
+	cfg := testConfig(":9090")
+	cfg.Store.Endpoint += "-report"
+
+	lc, err := NewLocator(context.Background(), cfg, key)
+	require.NoError(t, err)
+
+	defer os.Remove(cfg.Store.Endpoint)
+
+	buyer := getTestKey()
+	supplier := getTestKey()
+	stranger := getTestKey()
+	buyerAddr := util.PubKeyToAddr(buyer.PublicKey).Hex()
+	supplierAddr := util.PubKeyToAddr(supplier.PublicKey).Hex()
+	strangerAddr := util.PubKeyToAddr(stranger.PublicKey).Hex()
+
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	eth := blockchain.NewMockBlockchainer(mock)
+	eth.EXPECT().GetDealInfo(gomock.Any(), big.NewInt(1)).AnyTimes().
+		Return(&pb.Deal{Id: "1", BuyerID: buyerAddr, SupplierID: supplierAddr}, nil)
+	eth.EXPECT().GetDealInfo(gomock.Any(), big.NewInt(2)).AnyTimes().
+		Return(nil, errors.New("deal not found"))
+	lc.eth = eth
+
+	report := func(key *ecdsa.PrivateKey, dealID, subject string, outcome pb.DealOutcome) error {
+		entry, err := rating.NewEntry(key, dealID, subject, outcome, "")
+		require.NoError(t, err)
+
+		_, err = lc.Report(walletContext(key), entry)
+		return err
+	}
+
+	// Only parties of the existing deal can rate each other.
+	assert.Error(t, report(stranger, "1", supplierAddr, pb.DealOutcome_SLA_BREACH))
+	assert.Error(t, report(buyer, "1", strangerAddr, pb.DealOutcome_SLA_BREACH))
+	assert.Error(t, report(buyer, "2", supplierAddr, pb.DealOutcome_SLA_BREACH))
+
+	require.NoError(t, report(buyer, "1", supplierAddr, pb.DealOutcome_SLA_BREACH))
+	require.NoError(t, report(supplier, "1", buyerAddr, pb.DealOutcome_COMPLETED))
+
+	// Once per deal.
+	err = report(buyer, "1", supplierAddr, pb.DealOutcome_SLA_BREACH)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	reply, err := lc.GetRating(context.Background(), &pb.GetRatingRequest{EthAddr: supplierAddr})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), reply.GetRating())
+	assert.Equal(t, uint64(1), reply.GetOutcomes()["SLA_BREACH"])
+}
+
+func walletContext(key *ecdsa.PrivateKey) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: auth.EthAuthInfo{Wallet: util.PubKeyToAddr(key.PublicKey)},
+	})
+}
+
+func TestLocator_AnnounceSigned(t *testing.T) {
+	cfg := testConfig(":9090")
+	cfg.NodeTTL = time.Hour
+	cfg.Store.Endpoint += "-signed"
+
+	lc, err := NewLocator(context.Background(), cfg, key)
+	require.NoError(t, err)
+
+	defer os.Remove(cfg.Store.Endpoint)
+
+	announcer := getTestKey()
+	ethAddr := util.PubKeyToAddr(announcer.PublicKey).Hex()
+
+	clients := []*pb.Endpoint{
+		{Addr: "42.42.42.42:10001", Priority: 1, Region: "eu"},
+		{Addr: "42.42.42.43:10001", Region: "us"},
+	}
+	rec, err := NewRecord(announcer, clients, legacyEndpoints([]string{"42.42.42.42:10002"}), time.Minute)
+	require.NoError(t, err)
+
+	// Nobody can announce on behalf of others.
+	_, err = lc.Announce(walletContext(getTestKey()), &pb.AnnounceRequest{Record: rec})
+	assert.Error(t, err)
+
+	_, err = lc.Announce(walletContext(announcer), &pb.AnnounceRequest{Record: rec})
+	require.NoError(t, err)
+
+	reply, err := lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: ethAddr, Region: "eu"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"42.42.42.43:10001", "42.42.42.42:10001"}, reply.Endpoints)
+	// The record is handed out intact, so it can be verified by anyone.
+	assert.NoError(t, VerifyRecord(reply.Record))
+
+	rec.WorkerEndpoints[0].Addr = "42.42.42.44:10002"
+	_, err = lc.Announce(walletContext(announcer), &pb.AnnounceRequest{Record: rec})
+	assert.Error(t, err)
+}
+
+func TestLocator_RecordTTL(t *testing.T) {
+	cfg := testConfig(":9090")
+	cfg.NodeTTL = time.Hour
+	cfg.Store.Endpoint += "-ttl"
+
+	lc, err := NewLocator(context.Background(), cfg, key)
+	require.NoError(t, err)
+
+	defer os.Remove(cfg.Store.Endpoint)
+
+	rec, err := NewRecord(key, legacyEndpoints([]string{"42.42.42.42:10001"}), nil, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, lc.put(rec))
+
+	now := time.Now()
+	assert.False(t, lc.expired(rec, now))
+	assert.True(t, lc.expired(rec, now.Add(2*time.Minute)))
+
+	// TTL is limited by the node TTL.
+	rec.Ttl = uint64((2 * time.Hour) / time.Second)
+	assert.True(t, lc.expired(rec, now.Add(90*time.Minute)))
+}
+
+type watchResolveStream struct {
+	pb.Locator_WatchResolveServer
+	ctx     context.Context
+	replies chan *pb.ResolveReply
+}
+
+func (m *watchResolveStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *watchResolveStream) Send(reply *pb.ResolveReply) error {
+	m.replies <- reply
+	return nil
+}
+
+func TestLocator_WatchResolve(t *testing.T) {
+	cfg := testConfig(":9090")
+	cfg.NodeTTL = time.Hour
+	cfg.Store.Endpoint += "-watch"
+
+	lc, err := NewLocator(context.Background(), cfg, key)
+	require.NoError(t, err)
+
+	defer os.Remove(cfg.Store.Endpoint)
+
+	announcer := getTestKey()
+	ethAddr := util.PubKeyToAddr(announcer.PublicKey).Hex()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &watchResolveStream{ctx: ctx, replies: make(chan *pb.ResolveReply, 1)}
+
+	done := make(chan error)
+	go func() {
+		done <- lc.WatchResolve(&pb.ResolveRequest{EthAddr: ethAddr, EndpointType: pb.ResolveRequest_WORKER}, stream)
+	}()
+
+	receive := func() *pb.ResolveReply {
+		select {
+		case reply := <-stream.replies:
+			return reply
+		case <-time.After(time.Second):
+			t.Fatal("no reply received")
+			return nil
+		}
+	}
+
+	assert.Empty(t, receive().Endpoints)
+
+	for _, addr := range []string{"42.42.42.42:10002", "42.42.42.43:10002"} {
+		rec, err := NewRecord(announcer, legacyEndpoints([]string{addr}), legacyEndpoints([]string{addr}), time.Minute)
+		require.NoError(t, err)
+
+		_, err = lc.Announce(walletContext(announcer), &pb.AnnounceRequest{Record: rec})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{addr}, receive().Endpoints)
+	}
+
+	cancel()
+	assert.Error(t, <-done)
+}
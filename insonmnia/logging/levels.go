@@ -0,0 +1,191 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Levels holds levels of the root logger and its named subsystems, which
+// can be changed at runtime.
+//
+// Subsystems are identified by the dot-separated logger names, like
+// "hub.cluster". Unless overridden, a subsystem inherits the level of its
+// closest parent, ending with the root one.
+type Levels struct {
+	mu    sync.RWMutex
+	root  zapcore.Level
+	named map[string]zapcore.Level
+}
+
+// NewLevels constructs new levels with the given root level.
+func NewLevels(level zapcore.Level) *Levels {
+	return &Levels{
+		root:  level,
+		named: map[string]zapcore.Level{},
+	}
+}
+
+// Level returns the level of the named logger, where the empty name means
+// the root logger.
+func (l *Levels) Level(name string) zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for name != "" {
+		if level, ok := l.named[name]; ok {
+			return level
+		}
+
+		idx := strings.LastIndex(name, ".")
+		if idx < 0 {
+			break
+		}
+		name = name[:idx]
+	}
+
+	return l.root
+}
+
+// SetLevel changes the level of the named logger, where the empty name
+// means the root logger.
+func (l *Levels) SetLevel(name string, level zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if name == "" {
+		l.root = level
+	} else {
+		l.named[name] = level
+	}
+}
+
+// ResetLevel removes the level override of the named logger, so it
+// inherits the level of its parent again.
+func (l *Levels) ResetLevel(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.named, name)
+}
+
+// minLevel returns the lowest level enabled by any logger.
+func (l *Levels) minLevel() zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	min := l.root
+	for _, level := range l.named {
+		if level < min {
+			min = level
+		}
+	}
+
+	return min
+}
+
+type levelsState struct {
+	Level  string            `json:"level"`
+	Levels map[string]string `json:"levels"`
+}
+
+type levelRequest struct {
+	// Name of the logger, empty for the root one.
+	Name string `json:"name"`
+	// Level to set, empty to reset the override of the named logger.
+	Level string `json:"level"`
+}
+
+func (l *Levels) state() *levelsState {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	state := &levelsState{
+		Level:  l.root.String(),
+		Levels: map[string]string{},
+	}
+	for name, level := range l.named {
+		state.Levels[name] = level.String()
+	}
+
+	return state
+}
+
+// ServeHTTP exposes levels over HTTP.
+//
+// GET returns levels of the root logger and overridden subsystems, while
+// PUT changes the level of a single logger using the JSON request like
+// {"name": "hub.cluster", "level": "info"}.
+func (l *Levels) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if err := l.update(r); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf("method %s is not allowed", r.Method)})
+		return
+	}
+
+	json.NewEncoder(w).Encode(l.state())
+}
+
+func (l *Levels) update(r *http.Request) error {
+	request := levelRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return err
+	}
+
+	if request.Level == "" {
+		if request.Name == "" {
+			return fmt.Errorf("level of the root logger is required")
+		}
+
+		l.ResetLevel(request.Name)
+		return nil
+	}
+
+	level, err := ParseLogLevel(request.Level)
+	if err != nil {
+		return err
+	}
+
+	l.SetLevel(request.Name, level)
+	return nil
+}
+
+// levelCore filters entries using levels of the loggers they are written
+// by.
+type levelCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.levels.minLevel().Enabled(level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{
+		Core:   c.Core.With(fields),
+		levels: c.levels,
+	}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.Level(entry.LoggerName).Enabled(entry.Level) {
+		return ce
+	}
+
+	return c.Core.Check(entry, ce)
+}
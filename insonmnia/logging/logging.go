@@ -1,31 +1,127 @@
 package logging
 
 import (
-	"strings"
-
 	"fmt"
+	"os"
+	"strings"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-var (
-	atom = zap.NewAtomicLevel()
+const (
+	EncodingConsole = "console"
+	EncodingJSON    = "json"
 )
 
-// BuildLogger return new zap.Logger instance with given severity and debug settings
-func BuildLogger(level zapcore.Level) *zap.Logger {
-	atom.SetLevel(level)
-	loggerConfig := zap.Config{
-		Development:      false,
-		Level:            atom,
-		OutputPaths:      []string{"stdout"},
-		ErrorOutputPaths: []string{"stderr"},
-		Encoding:         "console",
-		EncoderConfig:    zap.NewDevelopmentEncoderConfig(),
+// Config describes logging settings.
+type Config struct {
+	// Level is the level of the root logger.
+	Level string `yaml:"level" required:"true" default:"debug"`
+	// Encoding is either "console" for human-readable output or "json".
+	Encoding string `yaml:"encoding" default:"console"`
+	// Output is either "stdout", "stderr" or the path to a log file.
+	Output string `yaml:"output" default:"stdout"`
+	// Rotation describes rotation of the log file.
+	Rotation RotationConfig `yaml:"rotation"`
+	// Levels overrides levels of named subsystems, like "hub.cluster".
+	Levels map[string]string `yaml:"levels"`
+}
+
+// RotationConfig describes rotation of the log file.
+type RotationConfig struct {
+	// MaxSize is the size in megabytes the file is rotated at. Zero disables
+	// rotation.
+	MaxSize int `yaml:"max_size" default:"100"`
+	// MaxBackups is the number of rotated files to keep.
+	MaxBackups int `yaml:"max_backups" default:"5"`
+}
+
+// Validate checks the config, returning an error if it is malformed.
+func (c *Config) Validate() error {
+	_, err := c.levels()
+	if err != nil {
+		return err
+	}
+
+	switch c.Encoding {
+	case "", EncodingConsole, EncodingJSON:
+	default:
+		return fmt.Errorf("unknown log encoding \"%s\"", c.Encoding)
+	}
+
+	return nil
+}
+
+func (c *Config) levels() (*Levels, error) {
+	level, err := ParseLogLevel(c.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := NewLevels(level)
+	for name, value := range c.Levels {
+		level, err := ParseLogLevel(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid level of \"%s\" logger: %s", name, err)
+		}
+
+		levels.SetLevel(name, level)
+	}
+
+	return levels, nil
+}
+
+// NewLogger builds a new logger from the config, returning it along with
+// its levels, which can be changed at runtime.
+func NewLogger(cfg Config) (*zap.Logger, *Levels, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	levels, err := cfg.levels()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var encoder zapcore.Encoder
+	if cfg.Encoding == EncodingJSON {
+		encoderConfig := zap.NewProductionEncoderConfig()
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	} else {
+		encoder = zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	}
+
+	output, err := openOutput(cfg.Output, cfg.Rotation)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	log, _ := loggerConfig.Build()
+	core := &levelCore{
+		Core:   zapcore.NewCore(encoder, output, zapcore.DebugLevel),
+		levels: levels,
+	}
+
+	logger := zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel), zap.ErrorOutput(zapcore.Lock(os.Stderr)))
+
+	return logger, levels, nil
+}
+
+func openOutput(path string, rotation RotationConfig) (zapcore.WriteSyncer, error) {
+	switch path {
+	case "", "stdout":
+		return zapcore.Lock(os.Stdout), nil
+	case "stderr":
+		return zapcore.Lock(os.Stderr), nil
+	default:
+		return openRotatingFile(path, int64(rotation.MaxSize)*1024*1024, rotation.MaxBackups)
+	}
+}
+
+// BuildLogger return new zap.Logger instance with given severity and debug settings
+func BuildLogger(level zapcore.Level) *zap.Logger {
+	log, _, _ := NewLogger(Config{Level: level.String(), Encoding: EncodingConsole, Output: "stdout"})
 	return log
 }
 
@@ -1,9 +1,18 @@
 package logging
 
 import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -33,3 +42,124 @@ func TestParseLogLevel(t *testing.T) {
 		}
 	}
 }
+
+func TestLevels(t *testing.T) {
+	levels := NewLevels(zapcore.InfoLevel)
+	levels.SetLevel("hub.cluster", zapcore.DebugLevel)
+	levels.SetLevel("hub", zapcore.WarnLevel)
+
+	assert.Equal(t, zapcore.InfoLevel, levels.Level(""))
+	assert.Equal(t, zapcore.InfoLevel, levels.Level("miner.overseer"))
+	assert.Equal(t, zapcore.WarnLevel, levels.Level("hub"))
+	assert.Equal(t, zapcore.WarnLevel, levels.Level("hub.npp"))
+	assert.Equal(t, zapcore.DebugLevel, levels.Level("hub.cluster"))
+	assert.Equal(t, zapcore.DebugLevel, levels.Level("hub.cluster.store"))
+	assert.Equal(t, zapcore.DebugLevel, levels.minLevel())
+
+	levels.ResetLevel("hub.cluster")
+	assert.Equal(t, zapcore.WarnLevel, levels.Level("hub.cluster"))
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, (&Config{Level: "info", Encoding: "json"}).Validate())
+	assert.Error(t, (&Config{Level: "info", Encoding: "xml"}).Validate())
+	assert.Error(t, (&Config{Level: "info", Levels: map[string]string{"hub": "wtf"}}).Validate())
+}
+
+func TestNewLoggerJSON(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logging")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "hub.log")
+
+	logger, levels, err := NewLogger(Config{
+		Level:    "info",
+		Encoding: EncodingJSON,
+		Output:   path,
+		Levels:   map[string]string{"hub.cluster": "debug"},
+	})
+	require.NoError(t, err)
+
+	hub := logger.Named("hub")
+	hub.Debug("dropped")
+	hub.Info("root")
+	hub.Named("cluster").Debug("cluster")
+
+	levels.SetLevel("hub", zapcore.DebugLevel)
+	hub.Named("npp").Debug("npp")
+	logger.Sync()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var messages []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		messages = append(messages, entry["logger"].(string)+": "+entry["msg"].(string))
+	}
+
+	assert.Equal(t, []string{"hub: root", "hub.cluster: cluster", "hub.npp: npp"}, messages)
+}
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logging")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "hub.log")
+
+	file, err := openRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := file.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, file.Sync())
+
+	for path, expected := range map[string]string{path: "fourth\n", path + ".1": "third\n", path + ".2": "second\n"} {
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+	}
+
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLevelsServeHTTP(t *testing.T) {
+	levels := NewLevels(zapcore.InfoLevel)
+
+	server := httptest.NewServer(levels)
+	defer server.Close()
+
+	put := func(body string) int {
+		request, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(body))
+		require.NoError(t, err)
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		response.Body.Close()
+		return response.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, put(`{"name": "hub.cluster", "level": "debug"}`))
+	assert.Equal(t, http.StatusOK, put(`{"level": "warn"}`))
+	assert.Equal(t, http.StatusBadRequest, put(`{"name": "hub", "level": "wtf"}`))
+	assert.Equal(t, http.StatusBadRequest, put(`{"name": ""}`))
+
+	response, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	state := levelsState{}
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&state))
+	assert.Equal(t, "warn", state.Level)
+	assert.Equal(t, map[string]string{"hub.cluster": "debug"}, state.Levels)
+
+	assert.Equal(t, http.StatusOK, put(`{"name": "hub.cluster"}`))
+	assert.Equal(t, zapcore.WarnLevel, levels.Level("hub.cluster"))
+}
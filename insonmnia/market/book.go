@@ -0,0 +1,131 @@
+package market
+
+import (
+	"sort"
+	"time"
+
+	"github.com/sonm-io/core/insonmnia/structs"
+	pb "github.com/sonm-io/core/proto"
+)
+
+// entry is an order kept in the order book.
+type entry struct {
+	Order *pb.Order `json:"order"`
+	// Created is used to order orders with the same price.
+	Created time.Time `json:"created"`
+	// Deadline is the time the order expires at unless touched.
+	Deadline time.Time `json:"deadline"`
+}
+
+func (m *entry) expired(now time.Time) bool {
+	return !m.Deadline.After(now)
+}
+
+// orderBook keeps orders in memory. It is not safe for concurrent use.
+type orderBook struct {
+	orders map[string]*entry
+}
+
+func newOrderBook() *orderBook {
+	return &orderBook{orders: map[string]*entry{}}
+}
+
+// Match returns up to count live orders satisfying the request ordered by
+// the price, the best offer first. Zero count means no limit.
+//
+// Searching for ASKs returns orders with at least the requested resources
+// and not more expensive than the requested price if any, cheapest first.
+// Searching for BIDs returns orders that fit into the requested resources
+// and pay at least the requested price if any, most generous first.
+func (m *orderBook) Match(request *pb.Order, count uint64, now time.Time) ([]*pb.Order, error) {
+	var slot *structs.Slot
+	if request.GetSlot() != nil {
+		var err error
+		if slot, err = structs.NewSlot(request.GetSlot()); err != nil {
+			return nil, err
+		}
+	}
+
+	var matched []*entry
+	for _, e := range m.orders {
+		if e.expired(now) || !matches(request, slot, e.Order) {
+			continue
+		}
+
+		matched = append(matched, e)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		if cmp := a.Order.GetPricePerSecond().Cmp(b.Order.GetPricePerSecond()); cmp != 0 {
+			if a.Order.GetOrderType() == pb.OrderType_BID {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+
+		return a.Created.Before(b.Created)
+	})
+
+	if count > 0 && uint64(len(matched)) > count {
+		matched = matched[:count]
+	}
+
+	orders := make([]*pb.Order, 0, len(matched))
+	for _, e := range matched {
+		orders = append(orders, e.Order)
+	}
+
+	return orders, nil
+}
+
+func matches(request *pb.Order, slot *structs.Slot, order *pb.Order) bool {
+	if request.GetOrderType() != pb.OrderType_ANY && request.GetOrderType() != order.GetOrderType() {
+		return false
+	}
+
+	if request.GetByuerID() != "" && request.GetByuerID() != order.GetByuerID() {
+		return false
+	}
+
+	if request.GetSupplierID() != "" && request.GetSupplierID() != order.GetSupplierID() {
+		return false
+	}
+
+	price := request.GetPricePerSecond()
+	orderSlot, err := structs.NewSlot(order.GetSlot())
+	if err != nil {
+		return false
+	}
+
+	switch order.GetOrderType() {
+	case pb.OrderType_ASK:
+		if price != nil && order.GetPricePerSecond().Cmp(price) > 0 {
+			return false
+		}
+		if slot != nil && !slot.Compare(orderSlot) {
+			return false
+		}
+	case pb.OrderType_BID:
+		if price != nil && order.GetPricePerSecond().Cmp(price) < 0 {
+			return false
+		}
+		if slot != nil && !orderSlot.Compare(slot) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Expired returns IDs of the expired orders.
+func (m *orderBook) Expired(now time.Time) []string {
+	var ids []string
+	for id, e := range m.orders {
+		if e.expired(now) {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,102 @@
+package market
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOrder(id string, orderType pb.OrderType, cpu uint64, price int64) *pb.Order {
+	return &pb.Order{
+		Id:             id,
+		OrderType:      orderType,
+		PricePerSecond: pb.NewBigIntFromInt(price),
+		Slot: &pb.Slot{
+			Duration:  uint64((time.Hour).Seconds()),
+			Resources: &pb.Resources{CpuCores: cpu, RamBytes: 1 << 20},
+		},
+	}
+}
+
+func newTestBook(orders ...*pb.Order) *orderBook {
+	now := time.Now()
+
+	book := newOrderBook()
+	for i, order := range orders {
+		created := now.Add(time.Duration(i) * time.Millisecond)
+		book.orders[order.Id] = &entry{Order: order, Created: created, Deadline: now.Add(time.Minute)}
+	}
+
+	return book
+}
+
+func ids(orders []*pb.Order) []string {
+	result := make([]string, 0, len(orders))
+	for _, order := range orders {
+		result = append(result, order.Id)
+	}
+
+	return result
+}
+
+func TestOrderBookMatchAsks(t *testing.T) {
+	book := newTestBook(
+		newTestOrder("ask-1", pb.OrderType_ASK, 1, 30),
+		newTestOrder("ask-2", pb.OrderType_ASK, 4, 20),
+		newTestOrder("ask-3", pb.OrderType_ASK, 8, 10),
+		newTestOrder("ask-4", pb.OrderType_ASK, 8, 20),
+		newTestOrder("bid-1", pb.OrderType_BID, 2, 40),
+	)
+
+	request := newTestOrder("", pb.OrderType_ASK, 2, 0)
+	request.PricePerSecond = nil
+
+	orders, err := book.Match(request, 0, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ask-3", "ask-2", "ask-4"}, ids(orders))
+
+	orders, err = book.Match(request, 2, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ask-3", "ask-2"}, ids(orders))
+
+	request.PricePerSecond = pb.NewBigIntFromInt(15)
+	orders, err = book.Match(request, 0, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ask-3"}, ids(orders))
+}
+
+func TestOrderBookMatchBids(t *testing.T) {
+	book := newTestBook(
+		newTestOrder("bid-1", pb.OrderType_BID, 1, 10),
+		newTestOrder("bid-2", pb.OrderType_BID, 2, 30),
+		newTestOrder("bid-3", pb.OrderType_BID, 8, 50),
+		newTestOrder("ask-1", pb.OrderType_ASK, 1, 1),
+	)
+	book.orders["bid-1"].Order.ByuerID = "0x1"
+
+	orders, err := book.Match(newTestOrder("", pb.OrderType_BID, 4, 1), 0, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bid-2", "bid-1"}, ids(orders))
+
+	orders, err = book.Match(&pb.Order{OrderType: pb.OrderType_BID, ByuerID: "0x1"}, 0, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bid-1"}, ids(orders))
+}
+
+func TestOrderBookMatchExpired(t *testing.T) {
+	book := newTestBook(newTestOrder("ask-1", pb.OrderType_ASK, 1, 1))
+
+	later := time.Now().Add(time.Hour)
+	orders, err := book.Match(&pb.Order{}, 0, later)
+	require.NoError(t, err)
+	assert.Empty(t, orders)
+	assert.Equal(t, []string{"ask-1"}, book.Expired(later))
+}
+
+func TestOrderBookMatchInvalidSlot(t *testing.T) {
+	_, err := newTestBook().Match(&pb.Order{Slot: &pb.Slot{}}, 0, time.Now())
+	assert.Error(t, err)
+}
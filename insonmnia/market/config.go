@@ -0,0 +1,36 @@
+package market
+
+import (
+	"time"
+
+	"github.com/jinzhu/configor"
+	"github.com/sonm-io/core/accounts"
+)
+
+type storeConfig struct {
+	Type     string `required:"true" default:"boltdb" yaml:"type"`
+	Endpoint string `required:"true" yaml:"endpoint"`
+	Bucket   string `required:"true" default:"sonm" yaml:"bucket"`
+}
+
+type Config struct {
+	ListenAddr string `yaml:"address" default:"127.0.0.1:15021"`
+	// OrderTTL specifies how long orders live unless touched.
+	OrderTTL time.Duration `yaml:"order_ttl" default:"5m"`
+	// CleanupPeriod specifies how often expired orders are removed.
+	CleanupPeriod     time.Duration      `yaml:"cleanup_period" default:"1m"`
+	Eth               accounts.EthConfig `required:"true" yaml:"ethereum"`
+	Store             storeConfig        `required:"true" yaml:"store"`
+	MetricsListenAddr string             `yaml:"metrics_listen_addr" default:"127.0.0.1:14004"`
+}
+
+// NewConfig loads a market config from the specified YAML file.
+func NewConfig(path string) (*Config, error) {
+	cfg := &Config{}
+	err := configor.Load(cfg, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
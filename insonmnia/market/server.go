@@ -0,0 +1,352 @@
+package market
+
+import (
+	"crypto/ecdsa"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/libkv"
+	"github.com/docker/libkv/store"
+	"github.com/docker/libkv/store/boltdb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/grpc-ecosystem/go-grpc-prometheus"
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/pborman/uuid"
+	"github.com/pkg/errors"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/insonmnia/structs"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"github.com/sonm-io/core/util/xgrpc"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const orderKeyPrefix = "order/"
+
+// Market is a marketplace server keeping the order book of BIDs and ASKs.
+//
+// Orders can be created and cancelled only by the parties they are placed
+// on behalf of, i.e. the buyer for BIDs and the supplier for ASKs. Orders
+// live for the configured TTL unless touched by their owner.
+type Market struct {
+	conf        *Config
+	ctx         context.Context
+	grpc        *grpc.Server
+	certRotator util.HitlessCertRotator
+	storage     store.Store
+
+	mu   sync.Mutex
+	book *orderBook
+}
+
+// NewMarket constructs a new marketplace server, loading previously
+// placed orders from the storage.
+func NewMarket(ctx context.Context, conf *Config, key *ecdsa.PrivateKey) (*Market, error) {
+	if key == nil {
+		return nil, errors.New("private key should be provided")
+	}
+
+	m := &Market{
+		conf: conf,
+		ctx:  ctx,
+		book: newOrderBook(),
+	}
+
+	var TLSConfig *tls.Config
+	var err error
+	m.certRotator, TLSConfig, err = util.NewHitlessCertRotator(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	m.storage, err = initStorage(ctx, conf.Store)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+
+	m.grpc = xgrpc.NewServer(log.GetLogger(ctx),
+		xgrpc.Credentials(util.NewTLS(TLSConfig)),
+		xgrpc.DefaultTraceInterceptor(),
+	)
+
+	pb.RegisterMarketServer(m.grpc, m)
+	grpc_prometheus.Register(m.grpc)
+
+	return m, nil
+}
+
+func initStorage(ctx context.Context, conf storeConfig) (store.Store, error) {
+	boltdb.Register()
+
+	log.G(ctx).Info("creating store", zap.Any("store", conf))
+
+	config := store.Config{
+		Bucket: conf.Bucket,
+	}
+
+	return libkv.NewStore(store.Backend(conf.Type), []string{conf.Endpoint}, &config)
+}
+
+// load restores the order book from the storage.
+func (m *Market) load() error {
+	pairs, err := m.storage.List(orderKeyPrefix)
+	if err != nil && err != store.ErrKeyNotFound {
+		return err
+	}
+
+	for _, pair := range pairs {
+		e := &entry{}
+		if err := json.Unmarshal(pair.Value, e); err != nil {
+			log.G(m.ctx).Warn("malformed order", zap.String("key", pair.Key), zap.Error(err))
+			continue
+		}
+
+		m.book.orders[e.Order.GetId()] = e
+	}
+
+	log.G(m.ctx).Info("order book loaded", zap.Int("count", len(m.book.orders)))
+
+	return nil
+}
+
+func (m *Market) Serve() error {
+	if m.conf.CleanupPeriod > 0 {
+		go m.cleanup()
+	}
+
+	lis, err := net.Listen("tcp", m.conf.ListenAddr)
+	if err != nil {
+		return err
+	}
+
+	return m.grpc.Serve(lis)
+}
+
+func (m *Market) Close() {
+	m.grpc.Stop()
+	m.certRotator.Close()
+	m.storage.Close()
+}
+
+func (m *Market) GetOrders(ctx context.Context, req *pb.GetOrdersRequest) (*pb.GetOrdersReply, error) {
+	if req.GetOrder() == nil {
+		return nil, status.Error(codes.InvalidArgument, "order is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	orders, err := m.book.Match(req.GetOrder(), req.GetCount(), time.Now())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &pb.GetOrdersReply{Orders: orders}, nil
+}
+
+func (m *Market) GetOrderByID(ctx context.Context, req *pb.ID) (*pb.Order, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.book.orders[req.GetId()]
+	if !ok || e.expired(time.Now()) {
+		return nil, status.Errorf(codes.NotFound, "order %s not found", req.GetId())
+	}
+
+	return e.Order, nil
+}
+
+func (m *Market) CreateOrder(ctx context.Context, req *pb.Order) (*pb.Order, error) {
+	wallet, err := auth.ExtractWalletFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	order, err := structs.NewOrder(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	switch order.GetOrderType() {
+	case pb.OrderType_BID:
+		if order.ByuerID == "" {
+			order.ByuerID = wallet.Hex()
+		}
+	case pb.OrderType_ASK:
+		if order.SupplierID == "" {
+			order.SupplierID = wallet.Hex()
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "order type must be either BID or ASK")
+	}
+
+	if owner(order.Unwrap()) != *wallet {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot place order on behalf of %s", owner(order.Unwrap()).Hex())
+	}
+
+	if order.Id == "" {
+		order.Id = uuid.New()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.book.orders[order.Id]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "order %s already exists", order.Id)
+	}
+
+	now := time.Now()
+	e := &entry{Order: order.Unwrap(), Created: now, Deadline: now.Add(m.conf.OrderTTL)}
+	if err := m.put(e); err != nil {
+		return nil, err
+	}
+
+	log.G(m.ctx).Info("order created", zap.String("id", order.Id), zap.Stringer("type", order.GetOrderType()))
+
+	return e.Order, nil
+}
+
+func (m *Market) CancelOrder(ctx context.Context, req *pb.Order) (*pb.Empty, error) {
+	wallet, err := auth.ExtractWalletFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.book.orders[req.GetId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "order %s not found", req.GetId())
+	}
+
+	if owner(e.Order) != *wallet {
+		return nil, status.Errorf(codes.PermissionDenied, "order %s is owned by %s", req.GetId(), owner(e.Order).Hex())
+	}
+
+	if err := m.remove(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	log.G(m.ctx).Info("order cancelled", zap.String("id", req.GetId()))
+
+	return &pb.Empty{}, nil
+}
+
+// TouchOrders prolongs the caller's orders for another TTL. Unknown or
+// expired orders are reported as not found after touching the others, so
+// callers know they must be placed again.
+func (m *Market) TouchOrders(ctx context.Context, req *pb.TouchOrdersRequest) (*pb.Empty, error) {
+	wallet, err := auth.ExtractWalletFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	var missing []string
+	for _, id := range req.GetIDs() {
+		e, ok := m.book.orders[id]
+		if !ok || e.expired(now) {
+			missing = append(missing, id)
+			continue
+		}
+
+		if owner(e.Order) != *wallet {
+			return nil, status.Errorf(codes.PermissionDenied, "order %s is owned by %s", id, owner(e.Order).Hex())
+		}
+
+		e.Deadline = now.Add(m.conf.OrderTTL)
+		if err := m.put(e); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, status.Errorf(codes.NotFound, "orders not found: %s", strings.Join(missing, ", "))
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (m *Market) GetProcessing(ctx context.Context, req *pb.Empty) (*pb.GetProcessingReply, error) {
+	return nil, status.Error(codes.Unimplemented, "orders processing is tracked by the Node")
+}
+
+// owner returns the address of the party the order is placed on behalf of.
+func owner(order *pb.Order) common.Address {
+	if order.GetOrderType() == pb.OrderType_BID {
+		return common.HexToAddress(order.GetByuerID())
+	}
+
+	return common.HexToAddress(order.GetSupplierID())
+}
+
+// put saves the order both into the book and the storage. Must be called
+// with the lock held.
+func (m *Market) put(e *entry) error {
+	value, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	if err := m.storage.Put(orderKeyPrefix+e.Order.GetId(), value, nil); err != nil {
+		return err
+	}
+
+	m.book.orders[e.Order.GetId()] = e
+	return nil
+}
+
+// remove deletes the order both from the book and the storage. Must be
+// called with the lock held.
+func (m *Market) remove(id string) error {
+	if err := m.storage.Delete(orderKeyPrefix + id); err != nil && err != store.ErrKeyNotFound {
+		return err
+	}
+
+	delete(m.book.orders, id)
+	return nil
+}
+
+// cleanup periodically removes expired orders.
+func (m *Market) cleanup() {
+	ticker := time.NewTicker(m.conf.CleanupPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.cleanupExpired()
+		}
+	}
+}
+
+func (m *Market) cleanupExpired() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range m.book.Expired(time.Now()) {
+		log.G(m.ctx).Debug("removing expired order", zap.String("id", id))
+		if err := m.remove(id); err != nil {
+			log.G(m.ctx).Warn("failed to remove expired order", zap.String("id", id), zap.Error(err))
+		}
+	}
+}
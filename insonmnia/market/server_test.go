@@ -0,0 +1,164 @@
+package market
+
+import (
+	"crypto/ecdsa"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sonm-io/core/insonmnia/auth"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func testConfig(name string) *Config {
+	path := "/tmp/sonm/bolt-market-test-" + name
+	os.Remove(path)
+
+	return &Config{
+		ListenAddr: "127.0.0.1:0",
+		OrderTTL:   time.Minute,
+		Store: storeConfig{
+			Type:     "boltdb",
+			Endpoint: path,
+			Bucket:   "sonm",
+		},
+	}
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	return key
+}
+
+func walletContext(key *ecdsa.PrivateKey) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: auth.EthAuthInfo{Wallet: util.PubKeyToAddr(key.PublicKey)},
+	})
+}
+
+func TestMarketCreateOrder(t *testing.T) {
+	m, err := NewMarket(context.Background(), testConfig("create"), newTestKey(t))
+	require.NoError(t, err)
+	defer m.Close()
+
+	buyer, supplier := newTestKey(t), newTestKey(t)
+
+	bid, err := m.CreateOrder(walletContext(buyer), newTestOrder("", pb.OrderType_BID, 1, 10))
+	require.NoError(t, err)
+	assert.NotEmpty(t, bid.Id)
+	assert.Equal(t, util.PubKeyToAddr(buyer.PublicKey).Hex(), bid.ByuerID)
+
+	order, err := m.GetOrderByID(context.Background(), &pb.ID{Id: bid.Id})
+	require.NoError(t, err)
+	assert.Equal(t, bid, order)
+
+	// Orders can not be placed on behalf of others.
+	ask := newTestOrder("", pb.OrderType_ASK, 1, 10)
+	ask.SupplierID = util.PubKeyToAddr(supplier.PublicKey).Hex()
+	_, err = m.CreateOrder(walletContext(buyer), ask)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = m.CreateOrder(walletContext(supplier), ask)
+	require.NoError(t, err)
+
+	_, err = m.CreateOrder(context.Background(), newTestOrder("", pb.OrderType_BID, 1, 10))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = m.CreateOrder(walletContext(buyer), newTestOrder("", pb.OrderType_BID, 1, 0))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	reply, err := m.GetOrders(context.Background(), &pb.GetOrdersRequest{Order: &pb.Order{OrderType: pb.OrderType_ASK}})
+	require.NoError(t, err)
+	require.Len(t, reply.Orders, 1)
+	assert.Equal(t, ask.SupplierID, reply.Orders[0].SupplierID)
+}
+
+func TestMarketCancelOrder(t *testing.T) {
+	m, err := NewMarket(context.Background(), testConfig("cancel"), newTestKey(t))
+	require.NoError(t, err)
+	defer m.Close()
+
+	buyer := newTestKey(t)
+
+	bid, err := m.CreateOrder(walletContext(buyer), newTestOrder("", pb.OrderType_BID, 1, 10))
+	require.NoError(t, err)
+
+	_, err = m.CancelOrder(walletContext(newTestKey(t)), &pb.Order{Id: bid.Id})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = m.CancelOrder(walletContext(buyer), &pb.Order{Id: bid.Id})
+	require.NoError(t, err)
+
+	_, err = m.GetOrderByID(context.Background(), &pb.ID{Id: bid.Id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = m.CancelOrder(walletContext(buyer), &pb.Order{Id: bid.Id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestMarketTouchOrders(t *testing.T) {
+	cfg := testConfig("touch")
+	cfg.OrderTTL = 500 * time.Millisecond
+
+	m, err := NewMarket(context.Background(), cfg, newTestKey(t))
+	require.NoError(t, err)
+	defer m.Close()
+
+	supplier := newTestKey(t)
+
+	first, err := m.CreateOrder(walletContext(supplier), newTestOrder("", pb.OrderType_ASK, 1, 10))
+	require.NoError(t, err)
+	second, err := m.CreateOrder(walletContext(supplier), newTestOrder("", pb.OrderType_ASK, 1, 10))
+	require.NoError(t, err)
+
+	time.Sleep(300 * time.Millisecond)
+
+	_, err = m.TouchOrders(walletContext(newTestKey(t)), &pb.TouchOrdersRequest{IDs: []string{first.Id}})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = m.TouchOrders(walletContext(supplier), &pb.TouchOrdersRequest{IDs: []string{first.Id}})
+	require.NoError(t, err)
+
+	time.Sleep(300 * time.Millisecond)
+
+	_, err = m.GetOrderByID(context.Background(), &pb.ID{Id: first.Id})
+	assert.NoError(t, err)
+	_, err = m.GetOrderByID(context.Background(), &pb.ID{Id: second.Id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = m.TouchOrders(walletContext(supplier), &pb.TouchOrdersRequest{IDs: []string{first.Id, second.Id}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	m.cleanupExpired()
+	assert.Len(t, m.book.orders, 1)
+}
+
+func TestMarketPersistence(t *testing.T) {
+	cfg := testConfig("persistence")
+
+	m, err := NewMarket(context.Background(), cfg, newTestKey(t))
+	require.NoError(t, err)
+
+	bid, err := m.CreateOrder(walletContext(newTestKey(t)), newTestOrder("", pb.OrderType_BID, 1, 10))
+	require.NoError(t, err)
+	m.Close()
+
+	m, err = NewMarket(context.Background(), cfg, newTestKey(t))
+	require.NoError(t, err)
+	defer m.Close()
+
+	order, err := m.GetOrderByID(context.Background(), &pb.ID{Id: bid.Id})
+	require.NoError(t, err)
+	assert.Equal(t, bid.PricePerSecond.Unwrap(), order.PricePerSecond.Unwrap())
+	assert.Equal(t, bid.ByuerID, order.ByuerID)
+}
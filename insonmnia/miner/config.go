@@ -1,12 +1,16 @@
 package miner
 
 import (
+	"path/filepath"
+	"time"
+
 	"github.com/jinzhu/configor"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"github.com/sonm-io/core/accounts"
 	"github.com/sonm-io/core/insonmnia/logging"
 	"github.com/sonm-io/core/insonmnia/miner/plugin"
+	"github.com/sonm-io/core/insonmnia/tracing"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -22,6 +26,9 @@ type HubConfig struct {
 type FirewallConfig struct {
 	// STUN server endpoint (with port).
 	Server string `yaml:"server"`
+	// DetectInterval specifies how often NAT type is re-detected after
+	// startup. Zero disables periodic detection.
+	DetectInterval time.Duration `yaml:"detect_interval" default:"10m"`
 }
 
 type SSHConfig struct {
@@ -30,8 +37,8 @@ type SSHConfig struct {
 }
 
 type LoggingConfig struct {
-	Level       string `required:"true" default:"debug"`
-	parsedLevel zapcore.Level
+	logging.Config `yaml:",inline"`
+	parsedLevel    zapcore.Level
 }
 
 type ResourcesConfig struct {
@@ -48,17 +55,32 @@ type DevConfig struct {
 	Insecure bool   `yaml:"insecure"`
 }
 
+// TaskLogsConfig describes how stdout and stderr of tasks are persisted, so
+// they can be retrieved after containers are removed.
+type TaskLogsConfig struct {
+	// Dir is the directory logs are persisted to, the "task_logs" directory
+	// next to the config file by default.
+	Dir string `yaml:"dir"`
+	// DealBudget is the size in megabytes logs of all tasks of a deal are
+	// allowed to take. The oldest logs are dropped when it is exceeded.
+	DealBudget int64 `yaml:"deal_budget" default:"100"`
+	// Retention specifies how long logs are kept after the last write.
+	Retention time.Duration `yaml:"retention" default:"72h"`
+}
+
 type config struct {
 	HubConfig               HubConfig           `required:"true" yaml:"hub"`
 	FirewallConfig          *FirewallConfig     `required:"false" yaml:"firewall"`
 	Eth                     *accounts.EthConfig `yaml:"ethereum"`
 	SSHConfig               *SSHConfig          `required:"false" yaml:"ssh"`
 	LoggingConfig           LoggingConfig       `yaml:"logging"`
+	TracingConfig           tracing.Config      `yaml:"tracing"`
 	LocatorConfig           *LocatorConfig      `required:"true" yaml:"locator"`
 	UUIDPathConfig          string              `required:"false" yaml:"uuid_path"`
 	PublicIPsConfig         []string            `required:"false" yaml:"public_ip_addrs"`
 	MetricsListenAddrConfig string              `yaml:"metrics_listen_addr" default:"127.0.0.1:14001"`
 	PluginsConfig           plugin.Config       `yaml:"plugins"`
+	TaskLogsConfig          TaskLogsConfig      `yaml:"task_logs"`
 	DevConfig               *DevConfig          `yaml:"yes_i_want_to_use_dev-only_features"`
 }
 
@@ -66,6 +88,14 @@ func (c *config) LogLevel() zapcore.Level {
 	return c.LoggingConfig.parsedLevel
 }
 
+func (c *config) Logging() logging.Config {
+	return c.LoggingConfig.Config
+}
+
+func (c *config) Tracing() tracing.Config {
+	return c.TracingConfig
+}
+
 func (c *config) HubResolveEndpoints() bool {
 	return c.HubConfig.ResolveEndpoints
 }
@@ -114,6 +144,10 @@ func (c *config) Plugins() plugin.Config {
 	return c.PluginsConfig
 }
 
+func (c *config) TaskLogs() TaskLogsConfig {
+	return c.TaskLogsConfig
+}
+
 func (c *config) Dev() *DevConfig {
 	return c.DevConfig
 }
@@ -141,6 +175,9 @@ func NewConfig(path string) (Config, error) {
 	if cfg.UUIDPath() == "" {
 		cfg.UUIDPathConfig = path + ".uuid"
 	}
+	if cfg.TaskLogsConfig.Dir == "" {
+		cfg.TaskLogsConfig.Dir = filepath.Join(filepath.Dir(path), "task_logs")
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +186,14 @@ func NewConfig(path string) (Config, error) {
 		return nil, err
 	}
 
+	if err := cfg.LoggingConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.TracingConfig.Validate(); err != nil {
+		return nil, err
+	}
+
 	lvl, err := logging.ParseLogLevel(cfg.LoggingConfig.Level)
 	if err != nil {
 		return nil, err
@@ -161,6 +206,10 @@ func NewConfig(path string) (Config, error) {
 // Config represents a Miner configuration interface.
 type Config interface {
 	logging.Leveler
+	// Logging returns logging settings.
+	Logging() logging.Config
+	// Tracing returns tracing settings.
+	Tracing() tracing.Config
 
 	// HubEndpoints returns a string representation of a Hub endpoint to communicate with.
 	HubEndpoints() []string
@@ -187,6 +236,8 @@ type Config interface {
 	MetricsListenAddr() string
 	// Plugins returns plugins settings.
 	Plugins() plugin.Config
+	// TaskLogs returns settings of task logs persistence.
+	TaskLogs() TaskLogsConfig
 	// DevAddr to listen on. For dev purposes only!
 	Dev() *DevConfig
 }
@@ -27,6 +27,9 @@ type containerDescriptor struct {
 	ID          string
 	description Description
 	stats       types.StatsJSON
+	// sidecars describes sidecar containers of the task group, protected by
+	// the overseer mutex.
+	sidecars []*containerDescriptor
 
 	cleanup plugin.Cleanup
 }
@@ -57,11 +60,8 @@ func newContainer(ctx context.Context, dockerClient *client.Client, d Descriptio
 
 	// NOTE: all ports are EXPOSE as PublishAll
 	// TODO: detect network network mode and interface
-	logOpts := make(map[string]string)
-	// TODO: Move to StartTask?
-	logOpts["max-size"] = "100m"
 	var hostConfig = container.HostConfig{
-		LogConfig:       container.LogConfig{Type: "json-file", Config: logOpts},
+		LogConfig:       newLogConfig(),
 		PublishAllPorts: true,
 		RestartPolicy:   d.RestartPolicy,
 		// NOTE: we perform cleanup after commit manually
@@ -100,6 +100,59 @@ func newContainer(ctx context.Context, dockerClient *client.Client, d Descriptio
 	return &cont, nil
 }
 
+// newSidecarContainer creates a sidecar container of the task group. It
+// joins the network namespace of the main container, mounts its volumes and
+// is placed into the same cgroup, so the group shares a single resource
+// allocation.
+func newSidecarContainer(ctx context.Context, dockerClient *client.Client, d Description, mainID string) (*containerDescriptor, error) {
+	log.G(ctx).Info("start sidecar container", zap.String("name", d.Name), zap.String("main", mainID))
+
+	ctx, cancel := context.WithCancel(ctx)
+	cont := containerDescriptor{
+		ctx:         ctx,
+		cancel:      cancel,
+		client:      dockerClient,
+		description: d,
+	}
+
+	var config = container.Config{
+		Image:  filepath.Join(d.Registry, d.Image),
+		Labels: map[string]string{overseerTag: "", sidecarTag: d.Name},
+		Env:    d.FormatEnv(),
+	}
+
+	var hostConfig = container.HostConfig{
+		LogConfig:     newLogConfig(),
+		NetworkMode:   container.NetworkMode("container:" + mainID),
+		VolumesFrom:   []string{mainID},
+		RestartPolicy: d.RestartPolicy,
+		AutoRemove:    false,
+		Resources: container.Resources{
+			CgroupParent: d.Resources.CgroupParent,
+		},
+	}
+
+	resp, err := cont.client.ContainerCreate(ctx, &config, &hostConfig, &network.NetworkingConfig{}, "")
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	cont.ID = resp.ID
+	cont.ctx = log.WithLogger(cont.ctx, log.G(ctx).With(zap.String("id", cont.ID)))
+	if len(resp.Warnings) > 0 {
+		log.G(ctx).Warn("ContainerCreate finished with warnings", zap.Strings("warnings", resp.Warnings))
+	}
+
+	return &cont, nil
+}
+
+func newLogConfig() container.LogConfig {
+	logOpts := make(map[string]string)
+	// TODO: Move to StartTask?
+	logOpts["max-size"] = "100m"
+	return container.LogConfig{Type: "json-file", Config: logOpts}
+}
+
 func (c *containerDescriptor) startContainer() error {
 	var options types.ContainerStartOptions
 	if err := c.client.ContainerStart(c.ctx, c.ID, options); err != nil {
@@ -110,7 +163,7 @@ func (c *containerDescriptor) startContainer() error {
 	return nil
 }
 
-func (c *containerDescriptor) execCommand(cmd []string, env []string, isTty bool, wCh <-chan ssh.Window) (conn types.HijackedResponse, err error) {
+func (c *containerDescriptor) execCommand(cmd []string, env []string, isTty bool, wCh <-chan ssh.Window) (conn types.HijackedResponse, execID string, err error) {
 	cfg := types.ExecConfig{
 		User:         "root",
 		Tty:          isTty,
@@ -130,6 +183,8 @@ func (c *containerDescriptor) execCommand(cmd []string, env []string, isTty bool
 		return
 	}
 
+	execID = execId.ID
+
 	conn, err = c.client.ContainerExecAttach(c.ctx, execId.ID, cfg)
 	if err != nil {
 		log.G(c.ctx).Warn("ContainerExecAttach finished with error", zap.Error(err))
@@ -176,6 +231,10 @@ func (c *containerDescriptor) remove() {
 }
 
 func (c *containerDescriptor) Cleanup() error {
+	// Sidecars are not tuned by plugins, so there is nothing to clean up.
+	if c.cleanup == nil {
+		return nil
+	}
 	return c.cleanup.Close()
 }
 
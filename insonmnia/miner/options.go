@@ -4,10 +4,10 @@ import (
 	"crypto/ecdsa"
 	"net"
 
-	"github.com/ccding/go-stun/stun"
 	log "github.com/noxiouz/zapctx/ctxlog"
 	"github.com/pkg/errors"
 	"github.com/sonm-io/core/insonmnia/hardware"
+	"github.com/sonm-io/core/insonmnia/miner/stun"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
 	"golang.org/x/net/context"
@@ -16,7 +16,7 @@ import (
 type options struct {
 	ctx           context.Context
 	hardware      hardware.Info
-	nat           stun.NATType
+	nat           pb.NATType
 	ovs           Overseer
 	uuid          string
 	ssh           SSH
@@ -34,23 +34,22 @@ func (o *options) setupNetworkOptions(cfg Config) error {
 	if cfg.Firewall() != nil {
 		log.G(o.ctx).Debug("discovering public IP address with NAT type, this might be slow")
 
-		client := stun.NewClient()
-		if cfg.Firewall().Server != "" {
-			client.SetServerAddr(cfg.Firewall().Server)
-		}
-
-		nat, addr, err := client.Discover()
+		result, err := stun.NewDetector(cfg.Firewall().Server).Detect(o.ctx)
 		if err != nil {
 			return err
 		}
 
-		pubIPs = append(pubIPs, addr.IP())
-		o.nat, o.publicIPs = nat, SortedIPs(pubIPs)
+		if result.PublicAddr == nil {
+			return errors.Errorf("failed to discover public IP: %s", result.Type)
+		}
+
+		pubIPs = append(pubIPs, result.PublicAddr.IP.String())
+		o.nat, o.publicIPs = result.Type, SortedIPs(pubIPs)
 
 		return nil
 	}
 
-	o.nat = stun.NATNone
+	o.nat = pb.NATType_NONE
 
 	// Use public IPs from config (if provided).
 	pubIPs = cfg.PublicIPs()
@@ -90,7 +89,7 @@ func WithHardware(hardwareInfo hardware.Info) Option {
 	}
 }
 
-func WithNat(nat stun.NATType) Option {
+func WithNat(nat pb.NATType) Option {
 	return func(opts *options) {
 		opts.nat = nat
 	}
@@ -22,14 +22,21 @@ import (
 	"github.com/docker/go-connections/nat"
 	"github.com/gliderlabs/ssh"
 	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/opentracing/opentracing-go"
 	"github.com/sonm-io/core/insonmnia/miner/gpu"
 	"github.com/sonm-io/core/insonmnia/resource"
+	"github.com/sonm-io/core/insonmnia/tracing"
 	pb "github.com/sonm-io/core/proto"
 )
 
 const overseerTag = "sonm.overseer"
+const sidecarTag = "sonm.sidecar"
 const dieEvent = "die"
 
+// execInspectInterval specifies how often the executed command is checked
+// for exiting after its output is closed.
+const execInspectInterval = 100 * time.Millisecond
+
 // Description for a target application.
 type Description struct {
 	Registry      string
@@ -42,6 +49,11 @@ type Description struct {
 	TaskId        string
 	DealId        string
 	CommitOnStop  bool
+	// Name identifies a sidecar within the task group.
+	Name string
+	// Sidecars describes containers started together with this one, sharing
+	// its network namespace, volumes and cgroup.
+	Sidecars []Description
 
 	GPURequired bool
 
@@ -96,6 +108,8 @@ type ContainerInfo struct {
 	Cgroup       string
 	CgroupParent string
 	NetworkIDs   []string
+	// Sidecars contains names of the sidecar containers of the task group.
+	Sidecars []string
 }
 
 // ContainerMetrics are metrics collected from Docker about running containers
@@ -142,16 +156,26 @@ type Overseer interface {
 	// Spool prepares an application for its further start.
 	//
 	// For Docker containers this is an equivalent of pulling from the registry.
+	// Images of the sidecars are pulled as well.
 	Spool(ctx context.Context, d Description) error
 
 	// Start attempts to start an application using the specified description.
 	//
 	// After successful starting an application becomes a target for accepting request, but not guarantees
 	// to complete them.
+	//
+	// Sidecars are started after the main container and share its status: a
+	// dead sidecar breaks the whole group, while stopping the main container
+	// stops its sidecars.
 	Start(ctx context.Context, description Description) (chan pb.TaskStatusReply_Status, ContainerInfo, error)
 
-	// Exec a given command in running container
-	Exec(ctx context.Context, Id string, cmd []string, env []string, isTty bool, wCh <-chan ssh.Window) (types.HijackedResponse, error)
+	// Exec a given command in running container, returning the connection
+	// to its standard streams and the exec ID.
+	Exec(ctx context.Context, Id string, cmd []string, env []string, isTty bool, wCh <-chan ssh.Window) (types.HijackedResponse, string, error)
+
+	// ExecExitCode waits for the executed command to exit, returning its
+	// exit code.
+	ExecExitCode(ctx context.Context, execID string) (int, error)
 
 	// Stop terminates the container.
 	Stop(ctx context.Context, containerID string) error
@@ -164,6 +188,13 @@ type Overseer interface {
 	// Fetch logs of the container
 	Logs(ctx context.Context, id string, opts types.ContainerLogsOptions) (io.ReadCloser, error)
 
+	// CopyTo extracts the tar archive from the given reader into the
+	// container path.
+	CopyTo(ctx context.Context, id string, path string, rd io.Reader) error
+
+	// CopyFrom returns a tar archive of the container path.
+	CopyFrom(ctx context.Context, id string, path string) (io.ReadCloser, error)
+
 	// Close terminates all associated asynchronous operations and prepares the Overseer for shutting down.
 	Close() error
 }
@@ -182,6 +213,8 @@ type overseer struct {
 	mu         sync.Mutex
 	containers map[string]*containerDescriptor
 	statuses   map[string]chan pb.TaskStatusReply_Status
+	// sidecars maps sidecar container IDs to their main container IDs.
+	sidecars map[string]string
 }
 
 func (o *overseer) supportGPU() bool {
@@ -203,6 +236,7 @@ func NewOverseer(ctx context.Context, plugins *plugin.Repository) (Overseer, err
 		client:     dockerClient,
 		containers: make(map[string]*containerDescriptor),
 		statuses:   make(map[string]chan pb.TaskStatusReply_Status),
+		sidecars:   make(map[string]string),
 	}
 
 	go ovr.collectStats()
@@ -259,12 +293,20 @@ func (o *overseer) handleStreamingEvents(ctx context.Context, sinceUnix int64, f
 				id := message.Actor.ID
 				log.G(ctx).Info("container has died", zap.String("id", id))
 
+				if o.handleSidecarDeath(ctx, id) {
+					continue
+				}
+
 				var c *containerDescriptor
 				o.mu.Lock()
 				c, containerFound := o.containers[id]
 				s, statusFound := o.statuses[id]
 				delete(o.containers, id)
 				delete(o.statuses, id)
+				var sidecars []*containerDescriptor
+				if containerFound {
+					sidecars = c.sidecars
+				}
 				o.mu.Unlock()
 
 				if !containerFound {
@@ -278,6 +320,10 @@ func (o *overseer) handleStreamingEvents(ctx context.Context, sinceUnix int64, f
 					close(s)
 				}
 				go func() {
+					for _, sidecar := range sidecars {
+						sidecar.Kill()
+						sidecar.cancel()
+					}
 					if c.description.CommitOnStop {
 						log.G(ctx).Info("trying to upload container")
 						err := c.upload()
@@ -297,6 +343,28 @@ func (o *overseer) handleStreamingEvents(ctx context.Context, sinceUnix int64, f
 	}
 }
 
+// handleSidecarDeath reports whether the dead container is a sidecar. The
+// whole task group is stopped when one of its sidecars dies, while the main
+// container status is reported as broken by its own death.
+func (o *overseer) handleSidecarDeath(ctx context.Context, id string) bool {
+	o.mu.Lock()
+	mainID, ok := o.sidecars[id]
+	delete(o.sidecars, id)
+	main, alive := o.containers[mainID]
+	o.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	if alive {
+		log.G(ctx).Warn("sidecar container has died, stopping the task group", zap.String("id", id), zap.String("main", mainID))
+		main.Kill()
+	}
+
+	return true
+}
+
 func (o *overseer) watchEvents() {
 	backoff := NewBackoffTimer(time.Second, time.Second*32)
 	defer backoff.Stop()
@@ -414,7 +482,26 @@ func (o *overseer) Save(ctx context.Context, imageID string) (types.ImageInspect
 	return imageInspect, rd, nil
 }
 
-func (o *overseer) Spool(ctx context.Context, d Description) error {
+func (o *overseer) Spool(ctx context.Context, d Description) (err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "overseer.Spool")
+	span.SetTag("task_id", d.TaskId)
+	span.SetTag("image", filepath.Join(d.Registry, d.Image))
+	defer func() { tracing.Finish(span, err) }()
+
+	if err = o.pull(ctx, d); err != nil {
+		return err
+	}
+
+	for _, sidecar := range d.Sidecars {
+		if err = o.pull(ctx, sidecar); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *overseer) pull(ctx context.Context, d Description) error {
 	log.G(ctx).Info("pull the application image")
 	options := types.ImagePullOptions{
 		All:          false,
@@ -438,6 +525,10 @@ func (o *overseer) Spool(ctx context.Context, d Description) error {
 }
 
 func (o *overseer) Start(ctx context.Context, description Description) (status chan pb.TaskStatusReply_Status, cinfo ContainerInfo, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "overseer.Start")
+	span.SetTag("task_id", description.TaskId)
+	defer func() { tracing.Finish(span, err) }()
+
 	// TODO: do we really need this check in that place?
 	// TODO: maybe will be better to check somewhere into the "newContainer()" method?
 	if description.GPURequired {
@@ -471,6 +562,16 @@ func (o *overseer) Start(ctx context.Context, description Description) (status c
 		return
 	}
 
+	if err = o.startSidecars(ctx, pr); err != nil {
+		// Nobody listens for the status yet, so forget it before the main
+		// container dies.
+		o.mu.Lock()
+		delete(o.statuses, pr.ID)
+		o.mu.Unlock()
+		pr.Kill()
+		return
+	}
+
 	var cpuCount int
 	if description.Resources.NanoCPUs > 0 {
 		cpuCount = int(description.Resources.NanoCPUs / 1000000000)
@@ -502,10 +603,45 @@ func (o *overseer) Start(ctx context.Context, description Description) (status c
 		NetworkIDs:   networkIDs,
 	}
 
+	for _, sidecar := range description.Sidecars {
+		cinfo.Sidecars = append(cinfo.Sidecars, sidecar.Name)
+	}
+
 	return status, cinfo, nil
 }
 
-func (o *overseer) Exec(ctx context.Context, id string, cmd []string, env []string, isTty bool, wCh <-chan ssh.Window) (ret types.HijackedResponse, err error) {
+// startSidecars starts sidecar containers of the task group in the order
+// they are declared. Sidecars are attached to the main container before
+// starting, so their early death is not mistaken for an orphan, while ones
+// that failed to start are detached and removed here.
+func (o *overseer) startSidecars(ctx context.Context, main *containerDescriptor) error {
+	for _, d := range main.description.Sidecars {
+		sidecar, err := newSidecarContainer(ctx, o.client, d, main.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create sidecar %s: %v", d.Name, err)
+		}
+
+		o.mu.Lock()
+		o.sidecars[sidecar.ID] = main.ID
+		o.mu.Unlock()
+
+		if err := sidecar.startContainer(); err != nil {
+			o.mu.Lock()
+			delete(o.sidecars, sidecar.ID)
+			o.mu.Unlock()
+			containerRemove(ctx, o.client, sidecar.ID)
+			return fmt.Errorf("failed to start sidecar %s: %v", d.Name, err)
+		}
+
+		o.mu.Lock()
+		main.sidecars = append(main.sidecars, sidecar)
+		o.mu.Unlock()
+	}
+
+	return nil
+}
+
+func (o *overseer) Exec(ctx context.Context, id string, cmd []string, env []string, isTty bool, wCh <-chan ssh.Window) (ret types.HijackedResponse, execID string, err error) {
 	o.mu.Lock()
 	descriptor, dok := o.containers[id]
 	o.mu.Unlock()
@@ -513,10 +649,29 @@ func (o *overseer) Exec(ctx context.Context, id string, cmd []string, env []stri
 		err = fmt.Errorf("no such container %s", id)
 		return
 	}
-	ret, err = descriptor.execCommand(cmd, env, isTty, wCh)
+	ret, execID, err = descriptor.execCommand(cmd, env, isTty, wCh)
 	return
 }
 
+func (o *overseer) ExecExitCode(ctx context.Context, execID string) (int, error) {
+	for {
+		inspect, err := o.client.ContainerExecInspect(ctx, execID)
+		if err != nil {
+			return 0, err
+		}
+
+		if !inspect.Running {
+			return inspect.ExitCode, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(execInspectInterval):
+		}
+	}
+}
+
 func (o *overseer) Stop(ctx context.Context, containerid string) error {
 	o.mu.Lock()
 
@@ -540,3 +695,12 @@ func (o *overseer) Stop(ctx context.Context, containerid string) error {
 func (o *overseer) Logs(ctx context.Context, id string, opts types.ContainerLogsOptions) (io.ReadCloser, error) {
 	return o.client.ContainerLogs(ctx, id, opts)
 }
+
+func (o *overseer) CopyTo(ctx context.Context, id string, path string, rd io.Reader) error {
+	return o.client.CopyToContainer(ctx, id, path, rd, types.CopyToContainerOptions{})
+}
+
+func (o *overseer) CopyFrom(ctx context.Context, id string, path string) (io.ReadCloser, error) {
+	rd, _, err := o.client.CopyFromContainer(ctx, id, path)
+	return rd, err
+}
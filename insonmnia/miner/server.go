@@ -10,19 +10,21 @@ import (
 	"sync"
 	"time"
 
-	"github.com/ccding/go-stun/stun"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gliderlabs/ssh"
 	"github.com/grpc-ecosystem/go-grpc-prometheus"
 	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/opentracing/opentracing-go"
 	"github.com/pborman/uuid"
 	"github.com/pkg/errors"
 	"github.com/sonm-io/core/insonmnia/auth"
 	"github.com/sonm-io/core/insonmnia/hardware"
 	"github.com/sonm-io/core/insonmnia/miner/plugin"
+	"github.com/sonm-io/core/insonmnia/miner/stun"
 	"github.com/sonm-io/core/insonmnia/miner/volume"
 	"github.com/sonm-io/core/insonmnia/resource"
 	"github.com/sonm-io/core/insonmnia/structs"
@@ -57,11 +59,15 @@ type Miner struct {
 	hubKey *ecdsa.PublicKey
 
 	publicIPs []string
-	natType   stun.NATType
+
+	natMu   sync.Mutex
+	natType pb.NATType
 
 	listener net.Listener
 
 	ovs Overseer
+	// Logs of tasks persisted to outlive their containers.
+	taskLogs *taskLogStore
 
 	mu sync.Mutex
 	// One-to-one mapping between container IDs and userland task names.
@@ -189,9 +195,14 @@ func NewMiner(cfg Config, opts ...Option) (m *Miner, err error) {
 		o.ssh = nilSSH{}
 	}
 
+	taskLogs, err := newTaskLogStore(cfg.TaskLogs())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set up task logs persistence")
+	}
+
 	ctx, cancel := context.WithCancel(o.ctx)
 	if o.ovs == nil {
-		o.ovs, err = NewOverseer(ctx, plugins)
+		o.ovs, err = NewOverseer(log.WithLogger(ctx, log.G(ctx).Named("overseer")), plugins)
 		if err != nil {
 			return nil, err
 		}
@@ -205,6 +216,7 @@ func NewMiner(cfg Config, opts ...Option) (m *Miner, err error) {
 
 		grpcServer: grpcServer,
 		ovs:        o.ovs,
+		taskLogs:   taskLogs,
 
 		plugins: plugins,
 
@@ -357,12 +369,59 @@ func (m *Miner) Handshake(ctx context.Context, request *pb.MinerHandshakeRequest
 	resp := &pb.MinerHandshakeReply{
 		Miner:        m.name,
 		Capabilities: m.hardware.IntoProto(),
-		NatType:      pb.NewNATType(m.natType),
+		NatType:      m.currentNATType(),
 	}
 
 	return resp, nil
 }
 
+func (m *Miner) currentNATType() pb.NATType {
+	m.natMu.Lock()
+	defer m.natMu.Unlock()
+
+	return m.natType
+}
+
+// detectNAT periodically re-detects the type of NAT the miner is located
+// behind of, because it may change during the miner lifetime, for example
+// after the router reboot. Hubs receive the actual value during handshake.
+func (m *Miner) detectNAT() {
+	cfg := m.cfg.Firewall()
+	if cfg == nil || cfg.DetectInterval == 0 {
+		return
+	}
+
+	detector := stun.NewDetector(cfg.Server)
+
+	ticker := time.NewTicker(cfg.DetectInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := detector.Detect(m.ctx)
+			if err != nil {
+				log.G(m.ctx).Warn("failed to detect NAT type", zap.Error(err))
+				continue
+			}
+
+			m.natMu.Lock()
+			prev := m.natType
+			m.natType = result.Type
+			m.natMu.Unlock()
+
+			if prev != result.Type {
+				log.G(m.ctx).Info("NAT type has changed",
+					zap.Stringer("prev", prev),
+					zap.Stringer("nat", result.Type),
+				)
+			}
+		}
+	}
+}
+
 func (m *Miner) scheduleStatusPurge(id string) {
 	t := time.NewTimer(time.Second * 3600)
 	defer t.Stop()
@@ -518,6 +577,21 @@ func (m *Miner) Start(ctx context.Context, request *pb.MinerStartRequest) (*pb.M
 		networks:      networks,
 	}
 
+	// Sidecars have no own limits, sharing the cgroup of the task group.
+	for _, sidecar := range request.GetSidecars() {
+		d.Sidecars = append(d.Sidecars, Description{
+			Name:          sidecar.GetName(),
+			Image:         sidecar.GetImage(),
+			Registry:      sidecar.GetRegistry(),
+			Auth:          sidecar.GetAuth(),
+			Env:           sidecar.GetEnv(),
+			RestartPolicy: d.RestartPolicy,
+			Resources:     container.Resources{CgroupParent: d.Resources.CgroupParent},
+			DealId:        d.DealId,
+			TaskId:        d.TaskId,
+		})
+	}
+
 	// TODO: Detect whether it's the first time allocation. If so - release resources on error.
 
 	m.setStatus(&pb.TaskStatusReply{Status: pb.TaskStatusReply_SPOOLING}, request.Id)
@@ -531,7 +605,9 @@ func (m *Miner) Start(ctx context.Context, request *pb.MinerStartRequest) (*pb.M
 
 	m.setStatus(&pb.TaskStatusReply{Status: pb.TaskStatusReply_SPAWNING}, request.Id)
 	log.G(ctx).Info("spawning an image")
-	statusListener, containerInfo, err := m.ovs.Start(m.ctx, d)
+	// The container outlives the request, so it is bound to the Miner context
+	// keeping the request span only to trace spawning.
+	statusListener, containerInfo, err := m.ovs.Start(opentracing.ContextWithSpan(m.ctx, opentracing.SpanFromContext(ctx)), d)
 	if err != nil {
 		log.G(ctx).Error("failed to spawn an image", zap.Error(err))
 		m.setStatus(&pb.TaskStatusReply{Status: pb.TaskStatusReply_BROKEN}, request.Id)
@@ -541,6 +617,8 @@ func (m *Miner) Start(ctx context.Context, request *pb.MinerStartRequest) (*pb.M
 	containerInfo.StartAt = time.Now()
 	containerInfo.ImageName = d.Image
 
+	go m.taskLogs.capture(m.ctx, m.ovs, d.DealId, d.TaskId, containerInfo.ID)
+
 	var reply = pb.MinerStartReply{
 		Container:  containerInfo.ID,
 		PortMap:    make(map[string]*pb.Endpoints, 0),
@@ -687,13 +765,10 @@ func (m *Miner) sendUpdatesOnRequest(server pb.Miner_TasksStatusServer) {
 	}
 }
 
-// TaskLogs returns logs from container
+// TaskLogs returns logs from container, falling back to the persisted ones
+// when the container is gone.
 func (m *Miner) TaskLogs(request *pb.TaskLogsRequest, server pb.Miner_TaskLogsServer) error {
 	log.G(m.ctx).Info("handling TaskLogs request", zap.Any("request", request))
-	cid, ok := m.getContainerIdByTaskId(request.Id)
-	if !ok {
-		return status.Errorf(codes.NotFound, "no job with id %s", request.Id)
-	}
 	opts := types.ContainerLogsOptions{
 		ShowStdout: request.Type == pb.TaskLogsRequest_STDOUT || request.Type == pb.TaskLogsRequest_BOTH,
 		ShowStderr: request.Type == pb.TaskLogsRequest_STDERR || request.Type == pb.TaskLogsRequest_BOTH,
@@ -703,8 +778,25 @@ func (m *Miner) TaskLogs(request *pb.TaskLogsRequest, server pb.Miner_TaskLogsSe
 		Tail:       request.Tail,
 		Details:    request.Details,
 	}
-	reader, err := m.ovs.Logs(server.Context(), cid, opts)
-	if err != nil {
+
+	var (
+		reader io.ReadCloser
+		err    error
+	)
+
+	cid, ok := m.getContainerIdByTaskId(request.Id)
+	if ok {
+		reader, err = m.ovs.Logs(server.Context(), cid, opts)
+	}
+
+	if !ok || err != nil {
+		reader, err = m.taskLogs.open(request.Id, opts)
+	}
+
+	switch {
+	case err == errTaskLogsNotFound:
+		return status.Errorf(codes.NotFound, "no job with id %s", request.Id)
+	case err != nil:
 		return err
 	}
 	defer reader.Close()
@@ -723,6 +815,251 @@ func (m *Miner) TaskLogs(request *pb.TaskLogsRequest, server pb.Miner_TaskLogsSe
 	}
 }
 
+// Forward proxies a TCP connection from the Hub to one of the ports
+// published by tasks running on this miner.
+//
+// This allows the Hub to route traffic to miners located behind NAT, which
+// can not be traversed using IPVS.
+func (m *Miner) Forward(stream pb.Miner_ForwardServer) error {
+	md, ok := metadata.FromIncomingContext(stream.Context())
+	if !ok || len(md["target"]) == 0 {
+		return status.Error(codes.InvalidArgument, "target is required")
+	}
+
+	target := md["target"][0]
+
+	log.G(m.ctx).Info("handling Forward request", zap.String("target", target))
+
+	port, err := m.publishedPort(target)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", port), 10*time.Second)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "failed to connect to %s: %v", target, err)
+	}
+	defer conn.Close()
+
+	rw := pb.NewChunkReadWriter(stream)
+
+	go func() {
+		if _, err := io.Copy(conn, rw); err != nil {
+			conn.Close()
+			return
+		}
+
+		conn.(*net.TCPConn).CloseWrite()
+	}()
+
+	_, err = io.Copy(rw, conn)
+	return err
+}
+
+// publishedPort checks that the given "host:port" address is published by
+// one of the running tasks over TCP, returning its host port.
+func (m *Miner) publishedPort(target string) (string, error) {
+	host, port, err := net.SplitHostPort(target)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid target: %v", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, info := range m.containers {
+		for containerPort, bindings := range info.Ports {
+			if containerPort.Proto() != "tcp" {
+				continue
+			}
+
+			for _, binding := range bindings {
+				if binding.HostIP == host && binding.HostPort == port {
+					return port, nil
+				}
+			}
+		}
+	}
+
+	return "", status.Errorf(codes.PermissionDenied, "%s is not published by any task", target)
+}
+
+// Exec executes a command inside the task container, relaying its standard
+// streams over the given stream.
+//
+// The first request specifies the task and the command, subsequent ones
+// carry the standard input and terminal size changes. The final reply
+// carries the exit code of the command.
+func (m *Miner) Exec(stream pb.Miner_ExecServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	log.G(m.ctx).Info("handling Exec request", zap.String("id", request.Id), zap.Strings("cmd", request.Cmd))
+
+	cid, ok := m.getContainerIdByTaskId(request.Id)
+	if !ok {
+		return status.Errorf(codes.NotFound, "no job with id %s", request.Id)
+	}
+
+	cmd := request.Cmd
+	if len(cmd) == 0 {
+		cmd = []string{"login", "-f", "root"}
+	}
+
+	tty := request.Tty
+	wCh := make(chan ssh.Window, 1)
+
+	conn, execID, err := m.ovs.Exec(stream.Context(), cid, cmd, request.Env, tty, wCh)
+	if err != nil {
+		close(wCh)
+		return status.Errorf(codes.Internal, "failed to execute command: %v", err)
+	}
+	defer conn.Close()
+
+	go m.execInput(stream, request, conn, wCh)
+
+	stdout := &execReplyWriter{stream: stream}
+	stderr := &execReplyWriter{stream: stream, stderr: true}
+
+	if tty {
+		_, err = io.Copy(stdout, conn.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, conn.Reader)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	code, err := m.ovs.ExecExitCode(stream.Context(), execID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to inspect command: %v", err)
+	}
+
+	return stream.Send(&pb.ExecReply{Exit: &pb.ExecExit{Code: int32(code)}})
+}
+
+// execInput feeds the standard input and terminal size changes received
+// from the stream to the executed command until the stream is closed.
+func (m *Miner) execInput(stream pb.Miner_ExecServer, request *pb.ExecRequest, conn types.HijackedResponse, wCh chan<- ssh.Window) {
+	defer close(wCh)
+
+	stdinClosed := false
+	defer func() {
+		if !stdinClosed {
+			conn.CloseWrite()
+		}
+	}()
+
+	for {
+		if len(request.Stdin) > 0 && !stdinClosed {
+			if _, err := conn.Conn.Write(request.Stdin); err != nil {
+				return
+			}
+		}
+
+		if request.Size != nil {
+			select {
+			case wCh <- terminalWindow(request.Size):
+			case <-stream.Context().Done():
+				return
+			}
+		}
+
+		if request.StdinClosed && !stdinClosed {
+			stdinClosed = true
+			conn.CloseWrite()
+		}
+
+		var err error
+		if request, err = stream.Recv(); err != nil {
+			return
+		}
+	}
+}
+
+// CopyTo extracts the tar archive streamed into the task container path,
+// reporting progress for each consumed chunk.
+func (m *Miner) CopyTo(stream pb.Miner_CopyToServer) error {
+	md, ok := metadata.FromIncomingContext(stream.Context())
+	if !ok || len(md["task"]) == 0 || len(md["path"]) == 0 {
+		return status.Error(codes.InvalidArgument, "task and path are required")
+	}
+
+	taskID, path := md["task"][0], md["path"][0]
+
+	log.G(m.ctx).Info("handling CopyTo request", zap.String("id", taskID), zap.String("path", path))
+
+	cid, ok := m.getContainerIdByTaskId(taskID)
+	if !ok {
+		return status.Errorf(codes.NotFound, "no job with id %s", taskID)
+	}
+
+	return m.ovs.CopyTo(stream.Context(), cid, path, newChunkReader(stream))
+}
+
+// CopyFrom streams a tar archive of the task container path.
+func (m *Miner) CopyFrom(request *pb.CopyFromRequest, stream pb.Miner_CopyFromServer) error {
+	log.G(m.ctx).Info("handling CopyFrom request", zap.String("id", request.Id), zap.String("path", request.Path))
+
+	cid, ok := m.getContainerIdByTaskId(request.Id)
+	if !ok {
+		return status.Errorf(codes.NotFound, "no job with id %s", request.Id)
+	}
+
+	rd, err := m.ovs.CopyFrom(stream.Context(), cid, request.Path)
+	if err != nil {
+		return err
+	}
+	defer rd.Close()
+
+	buf := make([]byte, 1*1024*1024)
+	for {
+		n, err := rd.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.Chunk{Chunk: buf[:n]}); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func terminalWindow(size *pb.TerminalSize) ssh.Window {
+	return ssh.Window{Width: int(size.Width), Height: int(size.Height)}
+}
+
+// execReplyWriter sends everything written to it as either stdout or stderr
+// of the Exec reply.
+type execReplyWriter struct {
+	stream pb.Miner_ExecServer
+	stderr bool
+}
+
+func (m *execReplyWriter) Write(p []byte) (int, error) {
+	reply := &pb.ExecReply{}
+	if m.stderr {
+		reply.Stderr = p
+	} else {
+		reply.Stdout = p
+	}
+
+	if err := m.stream.Send(reply); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
 func (m *Miner) DiscoverHub(ctx context.Context, request *pb.DiscoverHubRequest) (*pb.Empty, error) {
 	log.G(m.ctx).Info("discovered new hub", zap.String("address", request.Endpoint))
 	go m.connectToHub(request.Endpoint)
@@ -795,6 +1132,7 @@ func (m *Miner) TaskDetails(ctx context.Context, req *pb.ID) (*pb.TaskStatusRepl
 			Cgroup:       info.ID,
 			CgroupParent: info.CgroupParent,
 		},
+		Sidecars: info.Sidecars,
 	}
 
 	return reply, nil
@@ -804,6 +1142,10 @@ func (m *Miner) manageConnections() {
 	t := time.NewTicker(time.Second * 15)
 	defer t.Stop()
 
+	if m.cfg.HubResolveEndpoints() {
+		go m.watchHubEndpoints()
+	}
+
 	if err := m.setupHubConnections(); err != nil {
 		log.G(m.ctx).Error("failed to setup hub connections", zap.Error(err))
 	}
@@ -844,6 +1186,44 @@ func (m *Miner) setupHubConnections() error {
 	return nil
 }
 
+// watchHubEndpoints connects to the Hub endpoints as soon as the Hub
+// announces them, which allows to follow the Hub when it moves without
+// waiting for the next periodic resolve.
+func (m *Miner) watchHubEndpoints() {
+	for {
+		if err := m.watchHubEndpointsOnce(); err != nil {
+			log.G(m.ctx).Warn("failed to watch hub endpoints", zap.Error(err))
+		}
+
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-time.After(15 * time.Second):
+		}
+	}
+}
+
+func (m *Miner) watchHubEndpointsOnce() error {
+	stream, err := m.locatorClient.WatchResolve(m.ctx,
+		&pb.ResolveRequest{EthAddr: m.cfg.HubEthAddr(), EndpointType: pb.ResolveRequest_WORKER})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resolved, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		log.G(m.ctx).Info("hub endpoints changed", zap.Strings("endpoints", resolved.Endpoints))
+
+		for _, endpoint := range resolved.Endpoints {
+			go m.connectToHub(endpoint)
+		}
+	}
+}
+
 func (m *Miner) connectToHub(endpoint string) {
 	rl, ok := m.listener.(*reverseListener)
 	if !ok {
@@ -924,6 +1304,8 @@ func (m *Miner) Serve() error {
 	go func() { m.manageConnections() }()
 	go func() { m.startSSH() }()
 	go func() { m.grpcServer.Serve(m.listener) }()
+	go func() { m.detectNAT() }()
+	go func() { m.taskLogs.collectGarbage(m.ctx) }()
 
 	<-m.ctx.Done()
 	return m.ctx.Err()
@@ -1,15 +1,20 @@
 package miner
 
 import (
+	"bufio"
+	"bytes"
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/golang/mock/gomock"
 	"github.com/shirou/gopsutil/mem"
@@ -22,6 +27,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -58,6 +67,7 @@ func defaultMockCfg(mock *gomock.Controller) *MockConfig {
 	cfg.EXPECT().LocatorEndpoint().AnyTimes().Return("127.0.0.1:9090")
 	cfg.EXPECT().PublicIPs().AnyTimes().Return([]string{"192.168.70.17", "46.148.198.133"})
 	cfg.EXPECT().Plugins().AnyTimes().Return(plugin.Config{})
+	cfg.EXPECT().TaskLogs().AnyTimes().Return(TaskLogsConfig{})
 	return cfg
 }
 
@@ -203,6 +213,200 @@ func TestMinerStart(t *testing.T) {
 	assert.Equal(t, id, "test")
 }
 
+func TestMinerStartSidecars(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	var description Description
+	ovs := NewMockOverseer(mock)
+	ovs.EXPECT().Spool(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+	ovs.EXPECT().Start(gomock.Any(), gomock.Any()).Times(1).
+		Do(func(ctx context.Context, d Description) { description = d }).
+		Return(make(chan pb.TaskStatusReply_Status), ContainerInfo{
+			status:   &pb.TaskStatusReply{Status: pb.TaskStatusReply_RUNNING},
+			ID:       "container",
+			Sidecars: []string{"logs"},
+		}, nil)
+	ovs.EXPECT().Info(gomock.Any()).AnyTimes().Return(map[string]ContainerMetrics{"container": {}}, nil)
+
+	m, err := NewMiner(defaultMockCfg(mock), WithKey(key), WithOverseer(ovs),
+		WithUUID("deadbeef-cafe-dead-beef-cafedeadbeef"), WithLocatorClient(pb.NewMockLocatorClient(mock)), WithHardware(magicHardware(mock)))
+	require.NoError(t, err)
+
+	_, err = m.Start(context.Background(), &pb.MinerStartRequest{
+		Id:        "task",
+		OrderId:   "deal",
+		Resources: &pb.TaskResourceRequirements{CPUCores: 2, MaxMemory: 1 << 30},
+		Container: &pb.Container{Image: "user/app"},
+		Sidecars: []*pb.Container{
+			{Name: "logs", Image: "fluentd", Registry: "registry.user.dev", Env: map[string]string{"LEVEL": "info"}},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, description.Sidecars, 1)
+	sidecar := description.Sidecars[0]
+	assert.Equal(t, "logs", sidecar.Name)
+	assert.Equal(t, "fluentd", sidecar.Image)
+	assert.Equal(t, "registry.user.dev", sidecar.Registry)
+	assert.Equal(t, map[string]string{"LEVEL": "info"}, sidecar.Env)
+	assert.Equal(t, "task", sidecar.TaskId)
+	assert.Equal(t, "deal", sidecar.DealId)
+	// The deal cgroup limits the group as a whole.
+	assert.Equal(t, description.Resources.CgroupParent, sidecar.Resources.CgroupParent)
+	assert.Equal(t, int64(1<<30), description.Resources.Memory)
+	assert.Zero(t, sidecar.Resources.Memory)
+	assert.Zero(t, sidecar.Resources.NanoCPUs)
+	assert.Zero(t, sidecar.Resources.CPUQuota)
+
+	reply, err := m.TaskDetails(context.Background(), &pb.ID{Id: "task"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"logs"}, reply.GetSidecars())
+}
+
+// newTestMinerClient serves the Miner with the given Overseer, having the
+// "task" task running in the "container" container.
+func newTestMinerClient(t *testing.T, mock *gomock.Controller, ovs Overseer) (pb.MinerClient, func()) {
+	m, err := NewMiner(defaultMockCfg(mock), WithKey(key), WithOverseer(ovs),
+		WithUUID("deadbeef-cafe-dead-beef-cafedeadbeef"), WithLocatorClient(pb.NewMockLocatorClient(mock)), WithHardware(magicHardware(mock)))
+	require.NoError(t, err)
+	m.saveContainerInfo("task", ContainerInfo{ID: "container"})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	pb.RegisterMinerServer(server, m)
+	go server.Serve(listener)
+
+	cc, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+
+	return pb.NewMinerClient(cc), func() {
+		cc.Close()
+		server.Stop()
+	}
+}
+
+func TestMinerExec(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	// The container side of the hijacked connection echoes the whole input
+	// back to stdout once it is closed.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		input, _ := ioutil.ReadAll(conn)
+		stdcopy.NewStdWriter(conn, stdcopy.Stdout).Write(input)
+		stdcopy.NewStdWriter(conn, stdcopy.Stderr).Write([]byte("done"))
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+
+	ovs := NewMockOverseer(mock)
+	ovs.EXPECT().Exec(gomock.Any(), "container", []string{"cat"}, gomock.Any(), false, gomock.Any()).
+		Times(1).Return(types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(conn)}, "exec", nil)
+	ovs.EXPECT().ExecExitCode(gomock.Any(), "exec").Times(1).Return(3, nil)
+
+	client, cleanup := newTestMinerClient(t, mock, ovs)
+	defer cleanup()
+
+	stream, err := client.Exec(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pb.ExecRequest{Id: "task", Cmd: []string{"cat"}, Stdin: []byte("hello ")}))
+	require.NoError(t, stream.Send(&pb.ExecRequest{Stdin: []byte("world"), StdinClosed: true}))
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	var exit *pb.ExecExit
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		stdout.Write(reply.Stdout)
+		stderr.Write(reply.Stderr)
+		if reply.Exit != nil {
+			exit = reply.Exit
+		}
+	}
+
+	assert.Equal(t, "hello world", stdout.String())
+	assert.Equal(t, "done", stderr.String())
+	require.NotNil(t, exit)
+	assert.Equal(t, int32(3), exit.Code)
+
+	stream, err = client.Exec(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pb.ExecRequest{Id: "unknown"}))
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestMinerCopy(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	copied := &bytes.Buffer{}
+
+	ovs := NewMockOverseer(mock)
+	ovs.EXPECT().CopyTo(gomock.Any(), "container", "/data", gomock.Any()).Times(1).
+		Do(func(ctx context.Context, id, path string, rd io.Reader) {
+			io.Copy(copied, rd)
+		}).Return(nil)
+	ovs.EXPECT().CopyFrom(gomock.Any(), "container", "/data").Times(1).
+		Return(ioutil.NopCloser(bytes.NewBufferString("archive")), nil)
+
+	client, cleanup := newTestMinerClient(t, mock, ovs)
+	defer cleanup()
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("task", "task", "path", "/data"))
+	stream, err := client.CopyTo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pb.Chunk{Chunk: []byte("arch")}))
+	require.NoError(t, stream.Send(&pb.Chunk{Chunk: []byte("ive")}))
+	require.NoError(t, stream.CloseSend())
+
+	committed := int64(0)
+	for {
+		progress, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		committed += progress.Size
+	}
+
+	assert.Equal(t, int64(7), committed)
+	assert.Equal(t, "archive", copied.String())
+
+	copyFrom, err := client.CopyFrom(context.Background(), &pb.CopyFromRequest{Id: "task", Path: "/data"})
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadAll(pb.NewChunkReader(copyFrom))
+	require.NoError(t, err)
+	assert.Equal(t, "archive", string(data))
+
+	copyFrom, err = client.CopyFrom(context.Background(), &pb.CopyFromRequest{Id: "unknown", Path: "/data"})
+	require.NoError(t, err)
+
+	_, err = copyFrom.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
 func TestTransformEnvVars(t *testing.T) {
 	vars := map[string]string{
 		"key1": "value1",
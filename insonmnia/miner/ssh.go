@@ -95,7 +95,7 @@ func (s *sshServer) process(session ssh.Session) (status int) {
 		log.G(s.miner.ctx).Warn(msg)
 		return
 	}
-	stream, err := s.miner.ovs.Exec(s.miner.ctx, cid, cmd, session.Environ(), isTty, wCh)
+	stream, _, err := s.miner.ovs.Exec(s.miner.ctx, cid, cmd, session.Environ(), isTty, wCh)
 	if err != nil {
 		session.Write([]byte(err.Error()))
 		return
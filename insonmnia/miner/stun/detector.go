@@ -0,0 +1,229 @@
+// NAT type detection.
+//
+// This package implements the classic NAT classification algorithm described
+// in RFC 3489 over a minimal STUN client. The server must support
+// CHANGE-REQUEST attribute and have an alternate address, otherwise only the
+// fact of being behind a NAT can be detected.
+//
+// The algorithm is the following:
+//  - Test I: send a binding request to the primary server address. No
+//    response means that UDP is blocked. The mapped address equal to the
+//    local one means that there is no NAT.
+//  - Test II: ask the server to respond from its alternate IP and port. When
+//    there is no NAT a response means open Internet, otherwise a symmetric
+//    UDP firewall. When behind a NAT a response means full cone NAT.
+//  - Test I repeated to the alternate server address: a different mapped
+//    address means symmetric NAT.
+//  - Test III: ask the server to respond from the alternate port only. A
+//    response means restricted NAT, otherwise port restricted NAT.
+
+package stun
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/sonm-io/core/proto"
+)
+
+const (
+	// DefaultServer is the STUN server used when no server is configured.
+	DefaultServer = "stun.ekiga.net:3478"
+
+	defaultTimeout  = 500 * time.Millisecond
+	defaultAttempts = 3
+)
+
+var errNoResponse = errors.New("no response from STUN server")
+
+// Result describes the NAT detection result.
+type Result struct {
+	// Type is the type of NAT detected.
+	Type sonm.NATType
+	// PublicAddr is the public address of the socket used for detection.
+	// Nil if UDP is blocked.
+	PublicAddr *net.UDPAddr
+}
+
+// Detector detects the type of NAT the host is located behind of.
+type Detector struct {
+	server   string
+	timeout  time.Duration
+	attempts int
+}
+
+// NewDetector constructs a new NAT detector that uses the specified STUN
+// server in "host:port" format. Empty value means the default server.
+func NewDetector(server string) *Detector {
+	if server == "" {
+		server = DefaultServer
+	}
+
+	return &Detector{
+		server:   server,
+		timeout:  defaultTimeout,
+		attempts: defaultAttempts,
+	}
+}
+
+// Detect performs the NAT type detection.
+func (m *Detector) Detect(ctx context.Context) (*Result, error) {
+	serverAddr, err := net.ResolveUDPAddr("udp", m.server)
+	if err != nil {
+		return nil, err
+	}
+
+	localIP, err := outboundIP(serverAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: localIP})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	localAddr := conn.LocalAddr().(*net.UDPAddr)
+
+	// Test I.
+	reply, err := m.request(ctx, conn, serverAddr, 0)
+	if err == errNoResponse {
+		return &Result{Type: sonm.NATType_BLOCKED}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{PublicAddr: reply.mappedAddr}
+	behindNAT := !equalAddr(reply.mappedAddr, localAddr)
+
+	if reply.otherAddr == nil {
+		if behindNAT {
+			result.Type = sonm.NATType_UNKNOWN
+		} else {
+			result.Type = sonm.NATType_NONE
+		}
+		return result, nil
+	}
+
+	// Test II.
+	_, err = m.request(ctx, conn, serverAddr, changeIP|changePort)
+	if err != nil && err != errNoResponse {
+		return nil, err
+	}
+	changedReplied := err == nil
+
+	if !behindNAT {
+		if changedReplied {
+			result.Type = sonm.NATType_NONE
+		} else {
+			result.Type = sonm.NATType_SYMMETRIC_UDP_FIREWALL
+		}
+		return result, nil
+	}
+
+	if changedReplied {
+		result.Type = sonm.NATType_FULL
+		return result, nil
+	}
+
+	// Test I to the alternate address.
+	otherReply, err := m.request(ctx, conn, reply.otherAddr, 0)
+	if err == errNoResponse {
+		result.Type = sonm.NATType_UNKNOWN
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !equalAddr(otherReply.mappedAddr, reply.mappedAddr) {
+		result.Type = sonm.NATType_SYMMETRIC
+		return result, nil
+	}
+
+	// Test III.
+	_, err = m.request(ctx, conn, serverAddr, changePort)
+	switch err {
+	case nil:
+		result.Type = sonm.NATType_RESTRICTED
+	case errNoResponse:
+		result.Type = sonm.NATType_PORT_RESTRICTED
+	default:
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// request sends a binding request with the given CHANGE-REQUEST flags,
+// retransmitting it until the response arrives, possibly from another
+// address.
+func (m *Detector) request(ctx context.Context, conn *net.UDPConn, addr *net.UDPAddr, change uint32) (*message, error) {
+	id, err := newTxID()
+	if err != nil {
+		return nil, err
+	}
+
+	request := (&message{kind: typeBindingRequest, id: id, change: change}).marshal()
+
+	defer conn.SetReadDeadline(time.Time{})
+
+	buf := make([]byte, 1500)
+	for attempt := 0; attempt < m.attempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if _, err := conn.WriteToUDP(request, addr); err != nil {
+			return nil, err
+		}
+
+		deadline := time.Now().Add(m.timeout)
+		if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+			deadline = ctxDeadline
+		}
+		conn.SetReadDeadline(deadline)
+
+		for {
+			n, _, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+					break
+				}
+				return nil, err
+			}
+
+			reply, err := unmarshalMessage(buf[:n])
+			if err != nil || reply.kind != typeBindingResponse || reply.id != id {
+				continue
+			}
+
+			if reply.mappedAddr == nil {
+				return nil, errors.New("STUN server has replied without mapped address")
+			}
+
+			return reply, nil
+		}
+	}
+
+	return nil, errNoResponse
+}
+
+// outboundIP returns the local IP address used to reach the given address.
+func outboundIP(addr *net.UDPAddr) (net.IP, error) {
+	conn, err := net.DialUDP("udp", nil, addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
+
+func equalAddr(a, b *net.UDPAddr) bool {
+	return a.IP.Equal(b.IP) && a.Port == b.Port
+}
@@ -0,0 +1,237 @@
+package stun
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var publicIP = net.IPv4(203, 0, 113, 1)
+
+// natBehavior emulates NAT located between the detector and the server.
+type natBehavior struct {
+	blocked bool
+	// noOther hides the alternate address of the server.
+	noOther bool
+	// Map returns the address the server observes for the request sent from
+	// the source to the destination with the given index.
+	Map func(src *net.UDPAddr, dst int) *net.UDPAddr
+	// Filter reports whether the response is passed through when it is sent
+	// from the other IP and/or port than the request was sent to.
+	Filter func(otherIP, otherPort bool) bool
+}
+
+func noNAT(src *net.UDPAddr, dst int) *net.UDPAddr {
+	return src
+}
+
+func coneNAT(src *net.UDPAddr, dst int) *net.UDPAddr {
+	return &net.UDPAddr{IP: publicIP, Port: 40000}
+}
+
+func symmetricNAT(src *net.UDPAddr, dst int) *net.UDPAddr {
+	return &net.UDPAddr{IP: publicIP, Port: 40000 + dst}
+}
+
+func passAll(otherIP, otherPort bool) bool {
+	return true
+}
+
+func passSameIP(otherIP, otherPort bool) bool {
+	return !otherIP
+}
+
+func passSameAddr(otherIP, otherPort bool) bool {
+	return !otherIP && !otherPort
+}
+
+// standIn is a local STUN server listening on two IPs and two ports.
+type standIn struct {
+	conns    [2][2]*net.UDPConn
+	behavior natBehavior
+}
+
+func newStandIn(t *testing.T, behavior natBehavior) *standIn {
+	ips := [2]net.IP{net.IPv4(127, 0, 0, 1), net.IPv4(127, 0, 0, 2)}
+
+	m := &standIn{behavior: behavior}
+	for attempt := 0; attempt < 10; attempt++ {
+		if m.listen(ips) {
+			for ipID := range m.conns {
+				for portID := range m.conns[ipID] {
+					go m.serve(ipID, portID)
+				}
+			}
+			return m
+		}
+	}
+
+	t.Fatal("failed to listen on the stand-in STUN server addresses")
+	return nil
+}
+
+func (m *standIn) listen(ips [2]net.IP) bool {
+	var ports [2]int
+	for portID := range ports {
+		conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: ips[0]})
+		if err != nil {
+			return false
+		}
+		m.conns[0][portID] = conn
+		ports[portID] = conn.LocalAddr().(*net.UDPAddr).Port
+	}
+
+	for portID, port := range ports {
+		conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: ips[1], Port: port})
+		if err != nil {
+			m.Close()
+			return false
+		}
+		m.conns[1][portID] = conn
+	}
+
+	return true
+}
+
+func (m *standIn) addr(ipID, portID int) *net.UDPAddr {
+	return m.conns[ipID][portID].LocalAddr().(*net.UDPAddr)
+}
+
+func (m *standIn) serve(ipID, portID int) {
+	buf := make([]byte, 1500)
+	for {
+		n, src, err := m.conns[ipID][portID].ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+
+		request, err := unmarshalMessage(buf[:n])
+		if err != nil || request.kind != typeBindingRequest || m.behavior.blocked {
+			continue
+		}
+
+		otherIP := request.change&changeIP != 0
+		otherPort := request.change&changePort != 0
+		if !m.behavior.Filter(otherIP, otherPort) {
+			continue
+		}
+
+		reply := &message{
+			kind:       typeBindingResponse,
+			id:         request.id,
+			mappedAddr: m.behavior.Map(src, 2*ipID+portID),
+		}
+		if !m.behavior.noOther {
+			reply.otherAddr = m.addr(1-ipID, 1-portID)
+		}
+
+		replyIP, replyPort := ipID, portID
+		if otherIP {
+			replyIP = 1 - ipID
+		}
+		if otherPort {
+			replyPort = 1 - portID
+		}
+
+		m.conns[replyIP][replyPort].WriteToUDP(reply.marshal(), src)
+	}
+}
+
+func (m *standIn) Close() {
+	for ipID := range m.conns {
+		for _, conn := range m.conns[ipID] {
+			if conn != nil {
+				conn.Close()
+			}
+		}
+	}
+}
+
+func detect(t *testing.T, server *standIn) *Result {
+	detector := NewDetector(server.addr(0, 0).String())
+	detector.timeout = 100 * time.Millisecond
+	detector.attempts = 2
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := detector.Detect(ctx)
+	require.NoError(t, err)
+	return result
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		behavior natBehavior
+		expected sonm.NATType
+	}{
+		{"none", natBehavior{Map: noNAT, Filter: passAll}, sonm.NATType_NONE},
+		{"udp_firewall", natBehavior{Map: noNAT, Filter: passSameAddr}, sonm.NATType_SYMMETRIC_UDP_FIREWALL},
+		{"full", natBehavior{Map: coneNAT, Filter: passAll}, sonm.NATType_FULL},
+		{"restricted", natBehavior{Map: coneNAT, Filter: passSameIP}, sonm.NATType_RESTRICTED},
+		{"port_restricted", natBehavior{Map: coneNAT, Filter: passSameAddr}, sonm.NATType_PORT_RESTRICTED},
+		{"symmetric", natBehavior{Map: symmetricNAT, Filter: passSameAddr}, sonm.NATType_SYMMETRIC},
+		{"blocked", natBehavior{blocked: true, Map: noNAT, Filter: passAll}, sonm.NATType_BLOCKED},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newStandIn(t, test.behavior)
+			defer server.Close()
+
+			result := detect(t, server)
+			assert.Equal(t, test.expected, result.Type)
+
+			if test.expected == sonm.NATType_BLOCKED {
+				assert.Nil(t, result.PublicAddr)
+			} else {
+				require.NotNil(t, result.PublicAddr)
+			}
+		})
+	}
+}
+
+func TestDetectPublicAddr(t *testing.T) {
+	server := newStandIn(t, natBehavior{Map: coneNAT, Filter: passAll})
+	defer server.Close()
+
+	result := detect(t, server)
+	assert.True(t, publicIP.Equal(result.PublicAddr.IP))
+	assert.Equal(t, 40000, result.PublicAddr.Port)
+}
+
+func TestDetectWithoutAlternateAddress(t *testing.T) {
+	server := newStandIn(t, natBehavior{noOther: true, Map: coneNAT, Filter: passAll})
+	defer server.Close()
+
+	assert.Equal(t, sonm.NATType_UNKNOWN, detect(t, server).Type)
+}
+
+func TestMessageMarshal(t *testing.T) {
+	id, err := newTxID()
+	require.NoError(t, err)
+
+	msg := &message{
+		kind:       typeBindingResponse,
+		id:         id,
+		mappedAddr: &net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 3478},
+		otherAddr:  &net.UDPAddr{IP: publicIP, Port: 3479},
+		change:     changeIP | changePort,
+	}
+
+	decoded, err := unmarshalMessage(msg.marshal())
+	require.NoError(t, err)
+	assert.Equal(t, msg.kind, decoded.kind)
+	assert.Equal(t, msg.id, decoded.id)
+	assert.Equal(t, msg.change, decoded.change)
+	assert.True(t, msg.mappedAddr.IP.Equal(decoded.mappedAddr.IP))
+	assert.Equal(t, msg.mappedAddr.Port, decoded.mappedAddr.Port)
+	assert.True(t, msg.otherAddr.IP.Equal(decoded.otherAddr.IP))
+	assert.Equal(t, msg.otherAddr.Port, decoded.otherAddr.Port)
+}
@@ -0,0 +1,218 @@
+package stun
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"net"
+)
+
+const (
+	magicCookie = 0x2112A442
+	headerSize  = 20
+	txIDSize    = 12
+)
+
+const (
+	typeBindingRequest  uint16 = 0x0001
+	typeBindingResponse uint16 = 0x0101
+)
+
+const (
+	attrMappedAddress    uint16 = 0x0001
+	attrChangeRequest    uint16 = 0x0003
+	attrChangedAddress   uint16 = 0x0005
+	attrXORMappedAddress uint16 = 0x0020
+	attrOtherAddress     uint16 = 0x802C
+)
+
+const (
+	changeIP   uint32 = 0x04
+	changePort uint32 = 0x02
+)
+
+const (
+	familyIPv4 = 0x01
+	familyIPv6 = 0x02
+)
+
+var errMalformed = errors.New("malformed STUN message")
+
+type txID [txIDSize]byte
+
+func newTxID() (txID, error) {
+	id := txID{}
+	_, err := rand.Read(id[:])
+	return id, err
+}
+
+// message is a minimal STUN message supporting only attributes required for
+// NAT classification.
+type message struct {
+	kind uint16
+	id   txID
+	// MappedAddr is the address the server observed the request from.
+	mappedAddr *net.UDPAddr
+	// OtherAddr is the alternate address of the server, which has both IP
+	// and port different from the primary one.
+	otherAddr *net.UDPAddr
+	// Flags of the CHANGE-REQUEST attribute.
+	change uint32
+}
+
+func (m *message) marshal() []byte {
+	var attrs []byte
+	if m.change != 0 {
+		value := make([]byte, 4)
+		binary.BigEndian.PutUint32(value, m.change)
+		attrs = appendAttr(attrs, attrChangeRequest, value)
+	}
+	if m.mappedAddr != nil {
+		attrs = appendAttr(attrs, attrXORMappedAddress, marshalAddr(m.mappedAddr, m.id, true))
+		attrs = appendAttr(attrs, attrMappedAddress, marshalAddr(m.mappedAddr, m.id, false))
+	}
+	if m.otherAddr != nil {
+		attrs = appendAttr(attrs, attrOtherAddress, marshalAddr(m.otherAddr, m.id, false))
+	}
+
+	data := make([]byte, headerSize, headerSize+len(attrs))
+	binary.BigEndian.PutUint16(data[0:], m.kind)
+	binary.BigEndian.PutUint16(data[2:], uint16(len(attrs)))
+	binary.BigEndian.PutUint32(data[4:], magicCookie)
+	copy(data[8:], m.id[:])
+
+	return append(data, attrs...)
+}
+
+func appendAttr(data []byte, kind uint16, value []byte) []byte {
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint16(header[0:], kind)
+	binary.BigEndian.PutUint16(header[2:], uint16(len(value)))
+
+	data = append(data, header...)
+	data = append(data, value...)
+	for i := len(value); i%4 != 0; i++ {
+		data = append(data, 0)
+	}
+
+	return data
+}
+
+func unmarshalMessage(data []byte) (*message, error) {
+	if len(data) < headerSize || binary.BigEndian.Uint32(data[4:]) != magicCookie {
+		return nil, errMalformed
+	}
+
+	size := int(binary.BigEndian.Uint16(data[2:]))
+	if len(data) < headerSize+size {
+		return nil, errMalformed
+	}
+
+	m := &message{kind: binary.BigEndian.Uint16(data[0:])}
+	copy(m.id[:], data[8:headerSize])
+
+	var mappedAddr *net.UDPAddr
+	attrs := data[headerSize : headerSize+size]
+	for len(attrs) >= 4 {
+		kind := binary.BigEndian.Uint16(attrs[0:])
+		length := int(binary.BigEndian.Uint16(attrs[2:]))
+		if len(attrs) < 4+length {
+			return nil, errMalformed
+		}
+		value := attrs[4 : 4+length]
+
+		var err error
+		switch kind {
+		case attrXORMappedAddress:
+			m.mappedAddr, err = unmarshalAddr(value, m.id, true)
+		case attrMappedAddress:
+			mappedAddr, err = unmarshalAddr(value, m.id, false)
+		case attrOtherAddress, attrChangedAddress:
+			m.otherAddr, err = unmarshalAddr(value, m.id, false)
+		case attrChangeRequest:
+			if length != 4 {
+				return nil, errMalformed
+			}
+			m.change = binary.BigEndian.Uint32(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		padded := (length + 3) &^ 3
+		if len(attrs) < 4+padded {
+			break
+		}
+		attrs = attrs[4+padded:]
+	}
+
+	// Legacy RFC 3489 servers report MAPPED-ADDRESS only.
+	if m.mappedAddr == nil {
+		m.mappedAddr = mappedAddr
+	}
+
+	return m, nil
+}
+
+func marshalAddr(addr *net.UDPAddr, id txID, xor bool) []byte {
+	ip := addr.IP.To4()
+	family := byte(familyIPv4)
+	if ip == nil {
+		ip = addr.IP.To16()
+		family = familyIPv6
+	}
+
+	value := make([]byte, 4+len(ip))
+	value[1] = family
+	binary.BigEndian.PutUint16(value[2:], uint16(addr.Port))
+	copy(value[4:], ip)
+
+	if xor {
+		xorAddr(value, id)
+	}
+
+	return value
+}
+
+func unmarshalAddr(value []byte, id txID, xor bool) (*net.UDPAddr, error) {
+	if len(value) < 4 {
+		return nil, errMalformed
+	}
+
+	switch value[1] {
+	case familyIPv4:
+		if len(value) != 4+net.IPv4len {
+			return nil, errMalformed
+		}
+	case familyIPv6:
+		if len(value) != 4+net.IPv6len {
+			return nil, errMalformed
+		}
+	default:
+		return nil, errMalformed
+	}
+
+	value = append([]byte{}, value...)
+	if xor {
+		xorAddr(value, id)
+	}
+
+	return &net.UDPAddr{
+		IP:   net.IP(value[4:]),
+		Port: int(binary.BigEndian.Uint16(value[2:])),
+	}, nil
+}
+
+// xorAddr obfuscates the address value as described in RFC 5389 in place.
+// The operation is symmetric.
+func xorAddr(value []byte, id txID) {
+	key := make([]byte, 4+txIDSize)
+	binary.BigEndian.PutUint32(key, magicCookie)
+	copy(key[4:], id[:])
+
+	value[2] ^= key[0]
+	value[3] ^= key[1]
+	for i := 4; i < len(value); i++ {
+		value[i] ^= key[i-4]
+	}
+}
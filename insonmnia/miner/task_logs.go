@@ -0,0 +1,567 @@
+package miner
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	timetypes "github.com/docker/docker/api/types/time"
+	"github.com/docker/docker/pkg/stdcopy"
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+const (
+	taskLogSuffix = ".log"
+	// Number of segments the deal budget is split to, so the oldest logs
+	// can be dropped without losing the recent ones.
+	taskLogSegments       = 8
+	minTaskLogSegmentSize = 64 * 1024
+	taskLogGCPeriod       = time.Hour
+)
+
+var (
+	errTaskLogsNotFound = errors.New("no logs persisted for the task")
+)
+
+// taskLogRecord is a single line of the task output, encoded the same way
+// Docker "json-file" logging driver does.
+type taskLogRecord struct {
+	Log    string    `json:"log"`
+	Stream string    `json:"stream"`
+	Time   time.Time `json:"time"`
+}
+
+// taskLogStore persists logs of tasks to rotating files grouped by deals.
+//
+// Logs of a task are written to "<dir>/<deal>/<task>.<N>.log" segments,
+// where N increases with each rotation.
+type taskLogStore struct {
+	dir         string
+	budget      int64
+	segmentSize int64
+	retention   time.Duration
+
+	mu sync.Mutex
+	// Paths of segments being written, which must never be removed.
+	active map[string]struct{}
+}
+
+func newTaskLogStore(cfg TaskLogsConfig) (*taskLogStore, error) {
+	if cfg.Dir == "" {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(cfg.Dir, 0750); err != nil {
+		return nil, err
+	}
+
+	budget := cfg.DealBudget << 20
+	segmentSize := budget / taskLogSegments
+	if segmentSize < minTaskLogSegmentSize {
+		segmentSize = minTaskLogSegmentSize
+	}
+
+	return &taskLogStore{
+		dir:         cfg.Dir,
+		budget:      budget,
+		segmentSize: segmentSize,
+		retention:   cfg.Retention,
+		active:      map[string]struct{}{},
+	}, nil
+}
+
+// capture persists logs of the container until it stops.
+func (s *taskLogStore) capture(ctx context.Context, ovs Overseer, dealID, taskID, containerID string) {
+	if s == nil {
+		return
+	}
+
+	ctx = log.WithLogger(ctx, log.G(ctx).With(zap.String("task_id", taskID)))
+
+	writer, err := s.newWriter(dealID, taskID)
+	if err != nil {
+		log.G(ctx).Warn("failed to persist task logs", zap.Error(err))
+		return
+	}
+	defer writer.Close()
+
+	reader, err := ovs.Logs(ctx, containerID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Timestamps: true,
+	})
+	if err != nil {
+		log.G(ctx).Warn("failed to attach to task logs", zap.Error(err))
+		return
+	}
+	defer reader.Close()
+
+	stdout := writer.stream("stdout")
+	stderr := writer.stream("stderr")
+	defer stdout.flush()
+	defer stderr.flush()
+
+	if _, err := stdcopy.StdCopy(stdout, stderr, reader); err != nil && ctx.Err() == nil {
+		log.G(ctx).Warn("failed to persist task logs", zap.Error(err))
+	}
+}
+
+// open returns persisted logs of the task filtered according to the given
+// options, multiplexed the same way Docker does.
+func (s *taskLogStore) open(taskID string, opts types.ContainerLogsOptions) (io.ReadCloser, error) {
+	if s == nil {
+		return nil, errTaskLogsNotFound
+	}
+
+	if !isSafeName(taskID) {
+		return nil, errTaskLogsNotFound
+	}
+
+	segments, err := s.taskSegments(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+	if opts.Since != "" {
+		timestamp, err := timetypes.GetTimestamp(opts.Since, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		seconds, nanoseconds, err := timetypes.ParseTimestamps(timestamp, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		since = time.Unix(seconds, nanoseconds)
+	}
+
+	tail := -1
+	if opts.Tail != "" && opts.Tail != "all" {
+		tail, err = strconv.Atoi(opts.Tail)
+		if err != nil || tail < 0 {
+			return nil, fmt.Errorf("invalid tail value %q", opts.Tail)
+		}
+	}
+
+	filter := func(record *taskLogRecord) bool {
+		switch record.Stream {
+		case "stdout":
+			if !opts.ShowStdout {
+				return false
+			}
+		case "stderr":
+			if !opts.ShowStderr {
+				return false
+			}
+		}
+
+		return !record.Time.Before(since)
+	}
+
+	rd, wr := io.Pipe()
+	go func() {
+		wr.CloseWithError(copyTaskLogs(wr, segments, filter, tail, opts.Timestamps))
+	}()
+
+	return rd, nil
+}
+
+// collectGarbage periodically removes logs of deals not written for longer
+// than the retention period.
+func (s *taskLogStore) collectGarbage(ctx context.Context) {
+	if s == nil {
+		return
+	}
+
+	ticker := time.NewTicker(taskLogGCPeriod)
+	defer ticker.Stop()
+
+	for {
+		if err := s.removeExpired(time.Now()); err != nil {
+			log.G(ctx).Warn("failed to remove expired task logs", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *taskLogStore) removeExpired(now time.Time) error {
+	dirs, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+
+		dealDir := filepath.Join(s.dir, dir.Name())
+		files, err := ioutil.ReadDir(dealDir)
+		if err != nil {
+			return err
+		}
+
+		expired := true
+		for _, file := range files {
+			_, active := s.active[filepath.Join(dealDir, file.Name())]
+			if active || now.Sub(file.ModTime()) < s.retention {
+				expired = false
+				break
+			}
+		}
+
+		if expired {
+			if err := os.RemoveAll(dealDir); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *taskLogStore) newWriter(dealID, taskID string) (*taskLogWriter, error) {
+	if !isSafeName(dealID) || !isSafeName(taskID) {
+		return nil, fmt.Errorf("invalid task %q of deal %q", taskID, dealID)
+	}
+
+	dealDir := filepath.Join(s.dir, dealID)
+	if err := os.MkdirAll(dealDir, 0750); err != nil {
+		return nil, err
+	}
+
+	writer := &taskLogWriter{
+		store:   s,
+		dealDir: dealDir,
+		taskID:  taskID,
+	}
+
+	// Continue numbering segments persisted before, if any.
+	segments, err := s.taskSegments(taskID)
+	if err == nil {
+		writer.seq = segmentSeq(segments[len(segments)-1]) + 1
+	}
+
+	return writer, nil
+}
+
+// taskSegments returns paths of the task log segments from the oldest to
+// the newest one.
+func (s *taskLogStore) taskSegments(taskID string) ([]string, error) {
+	segments, err := filepath.Glob(filepath.Join(s.dir, "*", taskID+".*"+taskLogSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(segments) == 0 {
+		return nil, errTaskLogsNotFound
+	}
+
+	sort.Slice(segments, func(i, j int) bool {
+		return segmentSeq(segments[i]) < segmentSeq(segments[j])
+	})
+
+	return segments, nil
+}
+
+// enforceBudget removes the oldest segments of the deal until logs fit the
+// budget.
+func (s *taskLogStore) enforceBudget(dealDir string) error {
+	files, err := ioutil.ReadDir(dealDir)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var total int64
+	for _, file := range files {
+		total += file.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	for _, file := range files {
+		if total <= s.budget {
+			break
+		}
+
+		path := filepath.Join(dealDir, file.Name())
+		if _, ok := s.active[path]; ok {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+
+		total -= file.Size()
+	}
+
+	return nil
+}
+
+func (s *taskLogStore) activate(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.active[path] = struct{}{}
+}
+
+func (s *taskLogStore) deactivate(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.active, path)
+}
+
+// taskLogWriter writes records of a single task, rotating segments.
+type taskLogWriter struct {
+	store   *taskLogStore
+	dealDir string
+	taskID  string
+
+	mu   sync.Mutex
+	file *os.File
+	seq  int
+	size int64
+}
+
+func (w *taskLogWriter) stream(name string) *taskLogStream {
+	return &taskLogStream{writer: w, name: name}
+}
+
+func (w *taskLogWriter) write(record *taskLogRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file != nil && w.size+int64(len(data)) > w.store.segmentSize {
+		w.closeSegment()
+		w.seq++
+
+		if err := w.store.enforceBudget(w.dealDir); err != nil {
+			return err
+		}
+	}
+
+	if w.file == nil {
+		path := filepath.Join(w.dealDir, fmt.Sprintf("%s.%d%s", w.taskID, w.seq, taskLogSuffix))
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0640)
+		if err != nil {
+			return err
+		}
+
+		w.store.activate(path)
+		w.file = file
+		w.size = 0
+	}
+
+	n, err := w.file.Write(data)
+	w.size += int64(n)
+
+	return err
+}
+
+func (w *taskLogWriter) closeSegment() {
+	w.file.Close()
+	w.store.deactivate(w.file.Name())
+	w.file = nil
+}
+
+func (w *taskLogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file != nil {
+		w.closeSegment()
+	}
+
+	return nil
+}
+
+// taskLogStream splits the output stream of the task into lines, writing
+// them as records.
+type taskLogStream struct {
+	writer *taskLogWriter
+	name   string
+	buf    []byte
+}
+
+func (s *taskLogStream) Write(p []byte) (int, error) {
+	s.buf = append(s.buf, p...)
+
+	for {
+		idx := bytes.IndexByte(s.buf, '\n')
+		if idx < 0 {
+			break
+		}
+
+		if err := s.record(s.buf[:idx+1]); err != nil {
+			return 0, err
+		}
+		s.buf = s.buf[idx+1:]
+	}
+
+	return len(p), nil
+}
+
+// flush writes the last line of the stream not terminated by a newline.
+func (s *taskLogStream) flush() error {
+	if len(s.buf) == 0 {
+		return nil
+	}
+
+	err := s.record(s.buf)
+	s.buf = nil
+
+	return err
+}
+
+func (s *taskLogStream) record(line []byte) error {
+	record := &taskLogRecord{Stream: s.name, Time: time.Now().UTC()}
+
+	// Lines are prefixed with timestamps, because they are requested.
+	if idx := bytes.IndexByte(line, ' '); idx > 0 {
+		if timestamp, err := time.Parse(time.RFC3339Nano, string(line[:idx])); err == nil {
+			record.Time = timestamp
+			line = line[idx+1:]
+		}
+	}
+
+	record.Log = string(line)
+
+	return s.writer.write(record)
+}
+
+func copyTaskLogs(wr io.Writer, segments []string, filter func(*taskLogRecord) bool, tail int, timestamps bool) error {
+	stdout := stdcopy.NewStdWriter(wr, stdcopy.Stdout)
+	stderr := stdcopy.NewStdWriter(wr, stdcopy.Stderr)
+
+	emit := func(record *taskLogRecord) error {
+		line := record.Log
+		if timestamps {
+			line = record.Time.Format(time.RFC3339Nano) + " " + line
+		}
+
+		out := stdout
+		if record.Stream == "stderr" {
+			out = stderr
+		}
+
+		_, err := io.WriteString(out, line)
+		return err
+	}
+
+	var tailed []*taskLogRecord
+	for _, segment := range segments {
+		err := readTaskLogSegment(segment, func(record *taskLogRecord) error {
+			if !filter(record) {
+				return nil
+			}
+
+			if tail < 0 {
+				return emit(record)
+			}
+
+			if tail > 0 {
+				if len(tailed) == tail {
+					tailed = tailed[1:]
+				}
+				tailed = append(tailed, record)
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, record := range tailed {
+		if err := emit(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readTaskLogSegment(path string, fn func(*taskLogRecord) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		// The segment may be removed to fit the budget in the meantime.
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			record := &taskLogRecord{}
+			// The last line may be partially written at the moment, so
+			// malformed records are skipped.
+			if json.Unmarshal(line, record) == nil {
+				if err := fn(record); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func segmentSeq(path string) int {
+	name := strings.TrimSuffix(filepath.Base(path), taskLogSuffix)
+	seq, _ := strconv.Atoi(name[strings.LastIndexByte(name, '.')+1:])
+	return seq
+}
+
+// isSafeName checks whether the ID can be used as a file name.
+func isSafeName(ID string) bool {
+	return ID != "" && ID != "." && ID != ".." && !strings.ContainsAny(ID, `/\*?[`)
+}
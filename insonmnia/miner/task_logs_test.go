@@ -0,0 +1,140 @@
+package miner
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func newTestTaskLogStore(t *testing.T, budget int64) (*taskLogStore, func()) {
+	dir, err := ioutil.TempDir("", "task_logs")
+	require.NoError(t, err)
+
+	store, err := newTaskLogStore(TaskLogsConfig{Dir: dir, DealBudget: budget, Retention: time.Hour})
+	require.NoError(t, err)
+
+	return store, func() { os.RemoveAll(dir) }
+}
+
+func readTaskLogs(t *testing.T, store *taskLogStore, taskID string, opts types.ContainerLogsOptions) (string, string) {
+	reader, err := store.open(taskID, opts)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	_, err = stdcopy.StdCopy(stdout, stderr, reader)
+	require.NoError(t, err)
+
+	return stdout.String(), stderr.String()
+}
+
+func TestTaskLogsCapture(t *testing.T) {
+	store, cleanup := newTestTaskLogStore(t, 1)
+	defer cleanup()
+
+	started := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Docker multiplexes streams, prefixing lines with timestamps.
+	logs := &bytes.Buffer{}
+	stdout := stdcopy.NewStdWriter(logs, stdcopy.Stdout)
+	stderr := stdcopy.NewStdWriter(logs, stdcopy.Stderr)
+	fmt.Fprintf(stdout, "%s starting\n", started.Format(time.RFC3339Nano))
+	fmt.Fprintf(stderr, "%s warning\n", started.Add(time.Second).Format(time.RFC3339Nano))
+	fmt.Fprintf(stdout, "%s done", started.Add(2*time.Second).Format(time.RFC3339Nano))
+
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+
+	ovs := NewMockOverseer(mock)
+	ovs.EXPECT().Logs(gomock.Any(), "container", gomock.Any()).Return(ioutil.NopCloser(logs), nil)
+
+	store.capture(context.Background(), ovs, "deal", "task", "container")
+
+	out, errOut := readTaskLogs(t, store, "task", types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true})
+	assert.Equal(t, "starting\ndone", out)
+	assert.Equal(t, "warning\n", errOut)
+
+	out, errOut = readTaskLogs(t, store, "task", types.ContainerLogsOptions{ShowStderr: true, Timestamps: true})
+	assert.Empty(t, out)
+	assert.Equal(t, started.Add(time.Second).Format(time.RFC3339Nano)+" warning\n", errOut)
+
+	out, _ = readTaskLogs(t, store, "task", types.ContainerLogsOptions{ShowStdout: true, Tail: "1"})
+	assert.Equal(t, "done", out)
+
+	out, _ = readTaskLogs(t, store, "task", types.ContainerLogsOptions{
+		ShowStdout: true,
+		Since:      started.Add(time.Second).Format(time.RFC3339),
+	})
+	assert.Equal(t, "done", out)
+
+	_, err := store.open("unknown", types.ContainerLogsOptions{})
+	assert.Equal(t, errTaskLogsNotFound, err)
+
+	_, err = store.open("../deal", types.ContainerLogsOptions{})
+	assert.Equal(t, errTaskLogsNotFound, err)
+}
+
+func TestTaskLogsBudget(t *testing.T) {
+	store, cleanup := newTestTaskLogStore(t, 1)
+	defer cleanup()
+
+	writer, err := store.newWriter("deal", "task")
+	require.NoError(t, err)
+
+	stream := writer.stream("stdout")
+	line := bytes.Repeat([]byte("x"), 1023)
+	for id := 0; id < 2048; id++ {
+		_, err := stream.Write(append([]byte(fmt.Sprintf("%04d", id)), append(line, '\n')...))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	var total int64
+	files, err := ioutil.ReadDir(filepath.Join(store.dir, "deal"))
+	require.NoError(t, err)
+	for _, file := range files {
+		total += file.Size()
+	}
+
+	assert.True(t, total <= store.budget+store.segmentSize)
+	assert.True(t, len(files) > 1)
+
+	// The most recent logs are kept.
+	out, _ := readTaskLogs(t, store, "task", types.ContainerLogsOptions{ShowStdout: true, Tail: "1"})
+	assert.Equal(t, "2047", out[:4])
+}
+
+func TestTaskLogsRemoveExpired(t *testing.T) {
+	store, cleanup := newTestTaskLogStore(t, 1)
+	defer cleanup()
+
+	writer, err := store.newWriter("deal", "task")
+	require.NoError(t, err)
+	require.NoError(t, writer.write(&taskLogRecord{Log: "line\n", Stream: "stdout", Time: time.Now()}))
+
+	// Logs being written are never removed.
+	require.NoError(t, store.removeExpired(time.Now().Add(2*time.Hour)))
+	_, err = os.Stat(filepath.Join(store.dir, "deal"))
+	require.NoError(t, err)
+
+	require.NoError(t, writer.Close())
+
+	require.NoError(t, store.removeExpired(time.Now()))
+	_, err = os.Stat(filepath.Join(store.dir, "deal"))
+	require.NoError(t, err)
+
+	require.NoError(t, store.removeExpired(time.Now().Add(2*time.Hour)))
+	_, err = os.Stat(filepath.Join(store.dir, "deal"))
+	assert.True(t, os.IsNotExist(err))
+}
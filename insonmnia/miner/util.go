@@ -99,12 +99,19 @@ func isIPv4(ip net.IP) bool {
 	return ip.To4() != nil
 }
 
+// chunkStream describes a server stream receiving chunks and reporting
+// progress back, like both Load and CopyTo.
+type chunkStream interface {
+	Send(*sonm.Progress) error
+	Recv() (*sonm.Chunk, error)
+}
+
 type chunkReader struct {
-	stream sonm.Miner_LoadServer
+	stream chunkStream
 	buf    []byte
 }
 
-func newChunkReader(stream sonm.Miner_LoadServer) io.Reader {
+func newChunkReader(stream chunkStream) io.Reader {
 	return &chunkReader{stream: stream, buf: nil}
 }
 
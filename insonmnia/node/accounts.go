@@ -0,0 +1,127 @@
+package node
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/accounts"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"github.com/sonm-io/core/util/xgrpc"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AccountMetadataKey is the gRPC metadata key clients use to select the
+// account on behalf of which the Node must act.
+const AccountMetadataKey = "account"
+
+// accountCredentials implements credentials.PerRPCCredentials by attaching
+// the selected account to each request.
+type accountCredentials struct {
+	addr string
+}
+
+func (c *accountCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{AccountMetadataKey: c.addr}, nil
+}
+
+func (c *accountCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// NewAccountCredentials returns per-RPC credentials that select the given
+// account for each request sent to the Node.
+func NewAccountCredentials(addr string) credentials.PerRPCCredentials {
+	return &accountCredentials{addr: addr}
+}
+
+// accountFromContext extracts the account selected by the caller.
+// Bool result is false if the caller did not select any account.
+func accountFromContext(ctx context.Context) (common.Address, bool, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return common.Address{}, false, nil
+	}
+
+	values := md[AccountMetadataKey]
+	if len(values) == 0 || values[0] == "" {
+		return common.Address{}, false, nil
+	}
+
+	addr, err := accounts.ParseAddress(values[0])
+	if err != nil {
+		return common.Address{}, false, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return addr, true, nil
+}
+
+// addAccount makes the Node able to act on behalf of one more account.
+//
+// Remote services authenticate callers by their wallet, so each account
+// requires its own transport credentials and Market connection.
+func (r *remoteOptions) addAccount(key *ecdsa.PrivateKey) error {
+	_, TLSConfig, err := util.NewHitlessCertRotator(r.ctx, key)
+	if err != nil {
+		return err
+	}
+
+	creds := util.NewTLS(TLSConfig)
+	marketCC, err := xgrpc.NewWalletAuthenticatedClient(r.ctx, creds, r.conf.MarketEndpoint())
+	if err != nil {
+		return err
+	}
+
+	// Outcomes are reported on behalf of the authenticated party only.
+	ratingCC, err := xgrpc.NewWalletAuthenticatedClient(r.ctx, creds, r.conf.LocatorEndpoint())
+	if err != nil {
+		return err
+	}
+
+	opts := *r
+	opts.key = key
+	opts.creds = creds
+	opts.market = pb.NewMarketClient(marketCC)
+	opts.rating = pb.NewRatingClient(ratingCC)
+	opts.hubCreator = newHubClientCreator(r.ctx, creds)
+	opts.accounts = nil
+
+	r.accounts[util.PubKeyToAddr(key.PublicKey)] = &opts
+	return nil
+}
+
+// account returns remote options bound to the account selected by the
+// caller via gRPC metadata, or the default account if nothing is selected.
+func (r *remoteOptions) account(ctx context.Context) (*remoteOptions, error) {
+	addr, ok, err := accountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok || addr == util.PubKeyToAddr(r.key.PublicKey) {
+		return r, nil
+	}
+
+	opts, ok := r.accounts[addr]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("account %s is not unlocked on this node", addr.Hex()))
+	}
+
+	return opts, nil
+}
+
+// allAccounts returns remote options for each account served by the Node,
+// starting from the default one.
+func (r *remoteOptions) allAccounts() []*remoteOptions {
+	all := []*remoteOptions{r}
+	for _, opts := range r.accounts {
+		all = append(all, opts)
+	}
+
+	return all
+}
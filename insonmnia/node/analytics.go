@@ -0,0 +1,339 @@
+package node
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/insonmnia/structs"
+	pb "github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultStatsPeriod = 24 * time.Hour
+	defaultStatsStep   = time.Hour
+	// maxStatsPoints limits the time series length to be produced at once.
+	maxStatsPoints = 10000
+	// snapshotOrdersLimit limits how many orders of each type are
+	// snapshotted at once.
+	snapshotOrdersLimit = 1000
+)
+
+var errAnalyticsDisabled = status.Error(codes.Unavailable, "market analytics is disabled")
+
+type analyticsAPI struct {
+	ctx     context.Context
+	remotes *remoteOptions
+	conf    AnalyticsConfig
+	store   *snapshotStore
+}
+
+// run periodically snapshots Marketplace orders until the Node is stopped.
+func (a *analyticsAPI) run() {
+	a.snapshotOnce()
+
+	tk := time.NewTicker(a.conf.Interval)
+	defer tk.Stop()
+
+	for {
+		select {
+		case <-tk.C:
+			a.snapshotOnce()
+		case <-a.ctx.Done():
+			return
+		}
+	}
+}
+
+func (a *analyticsAPI) snapshotOnce() {
+	snapshot, err := a.snapshot()
+	if err != nil {
+		log.G(a.ctx).Warn("cannot snapshot market orders", zap.Error(err))
+		return
+	}
+
+	if err := a.store.Save(snapshot); err != nil {
+		log.G(a.ctx).Warn("cannot save market orders snapshot", zap.Error(err))
+	}
+
+	if err := a.store.Cleanup(time.Now().Add(-a.conf.Retention)); err != nil {
+		log.G(a.ctx).Warn("cannot remove outdated market orders snapshots", zap.Error(err))
+	}
+}
+
+// snapshot fetches ASK and BID orders currently placed on Marketplace.
+func (a *analyticsAPI) snapshot() (*ordersSnapshot, error) {
+	snapshot := &ordersSnapshot{Ts: time.Now()}
+
+	for _, orderType := range []pb.OrderType{pb.OrderType_ASK, pb.OrderType_BID} {
+		req := &pb.GetOrdersRequest{
+			Order: &pb.Order{OrderType: orderType},
+			Count: snapshotOrdersLimit,
+		}
+
+		reply, err := a.remotes.market.GetOrders(a.ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, order := range reply.GetOrders() {
+			if _, err := structs.NewOrder(order); err != nil {
+				log.G(a.ctx).Debug("skipping malformed order", zap.String("order_id", order.GetId()), zap.Error(err))
+				continue
+			}
+
+			snapshot.Samples = append(snapshot.Samples, newOrderSample(order))
+		}
+	}
+
+	log.G(a.ctx).Debug("market orders snapshotted", zap.Int("order_count", len(snapshot.Samples)))
+
+	return snapshot, nil
+}
+
+// statsQuery is a normalized market statistics request.
+type statsQuery struct {
+	orderType pb.OrderType
+	resources *pb.Resources
+	from      time.Time
+	to        time.Time
+	step      time.Duration
+}
+
+func newStatsQuery(req *pb.MarketStatsRequest, now time.Time) (*statsQuery, error) {
+	query := &statsQuery{
+		orderType: req.GetOrderType(),
+		resources: req.GetResources(),
+		to:        now,
+		step:      defaultStatsStep,
+	}
+
+	if query.orderType == pb.OrderType_ANY {
+		query.orderType = pb.OrderType_ASK
+	}
+	if req.GetTo() != nil {
+		query.to = req.GetTo().Unix()
+	}
+	query.from = query.to.Add(-defaultStatsPeriod)
+	if req.GetFrom() != nil {
+		query.from = req.GetFrom().Unix()
+	}
+	if req.GetStep() > 0 {
+		query.step = time.Duration(req.GetStep()) * time.Second
+	}
+
+	if !query.from.Before(query.to) {
+		return nil, status.Error(codes.InvalidArgument, "time range is empty")
+	}
+	if query.to.Sub(query.from)/query.step > maxStatsPoints {
+		return nil, status.Errorf(codes.InvalidArgument, "too many points requested, max is %d", maxStatsPoints)
+	}
+
+	return query, nil
+}
+
+func (q *statsQuery) matches(sample *orderSample) bool {
+	return sample.Type == q.orderType && sample.Class.fits(q.resources)
+}
+
+// better reports whether the first price is better than the second one
+// for the one who is looking for orders of the queried type.
+func (q *statsQuery) better(a, b *big.Int) bool {
+	if q.orderType == pb.OrderType_BID {
+		return a.Cmp(b) > 0
+	}
+
+	return a.Cmp(b) < 0
+}
+
+func (a *analyticsAPI) PriceHistory(ctx context.Context, req *pb.MarketStatsRequest) (*pb.PriceHistoryReply, error) {
+	if !a.conf.Enabled {
+		return nil, errAnalyticsDisabled
+	}
+
+	query, err := newStatsQuery(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := a.store.Range(query.from, query.to)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPriceHistory(query, snapshots), nil
+}
+
+func newPriceHistory(query *statsQuery, snapshots []*ordersSnapshot) *pb.PriceHistoryReply {
+	points := map[int64]map[resourceClass][]*big.Int{}
+	for _, snapshot := range snapshots {
+		idx := int64(snapshot.Ts.Sub(query.from) / query.step)
+		for _, sample := range snapshot.Samples {
+			if !query.matches(sample) {
+				continue
+			}
+
+			if points[idx] == nil {
+				points[idx] = map[resourceClass][]*big.Int{}
+			}
+			points[idx][sample.Class] = append(points[idx][sample.Class], sample.Price.Unwrap())
+		}
+	}
+
+	indexes := make([]int64, 0, len(points))
+	for idx := range points {
+		indexes = append(indexes, idx)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	reply := &pb.PriceHistoryReply{}
+	for _, idx := range indexes {
+		ts := query.from.Add(time.Duration(idx) * query.step)
+		point := &pb.PriceHistoryPoint{Timestamp: &pb.Timestamp{Seconds: ts.Unix()}}
+
+		classes := make([]resourceClass, 0, len(points[idx]))
+		for class := range points[idx] {
+			classes = append(classes, class)
+		}
+		sortClasses(classes)
+
+		for _, class := range classes {
+			point.Stats = append(point.Stats, newPriceStats(class, points[idx][class]))
+		}
+
+		reply.Points = append(reply.Points, point)
+	}
+
+	return reply
+}
+
+func newPriceStats(class resourceClass, prices []*big.Int) *pb.PriceStats {
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Cmp(prices[j]) < 0 })
+
+	return &pb.PriceStats{
+		Class: class.Unwrap(),
+		Count: uint64(len(prices)),
+		Min:   pb.NewBigInt(prices[0]),
+		P50:   pb.NewBigInt(percentile(prices, 50)),
+		P90:   pb.NewBigInt(percentile(prices, 90)),
+		Max:   pb.NewBigInt(prices[len(prices)-1]),
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted non-empty
+// values.
+func percentile(sorted []*big.Int, p int) *big.Int {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+func (a *analyticsAPI) SupplyDepth(ctx context.Context, req *pb.MarketStatsRequest) (*pb.SupplyDepthReply, error) {
+	if !a.conf.Enabled {
+		return nil, errAnalyticsDisabled
+	}
+
+	query, err := newStatsQuery(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := a.store.Last(query.to)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot == nil {
+		return nil, status.Error(codes.NotFound, "no market orders snapshotted yet")
+	}
+
+	return newSupplyDepth(query, snapshot), nil
+}
+
+func newSupplyDepth(query *statsQuery, snapshot *ordersSnapshot) *pb.SupplyDepthReply {
+	var classes []resourceClass
+	samplesByClass := map[resourceClass][]*orderSample{}
+	for _, sample := range snapshot.Samples {
+		if !query.matches(sample) {
+			continue
+		}
+
+		if _, ok := samplesByClass[sample.Class]; !ok {
+			classes = append(classes, sample.Class)
+		}
+		samplesByClass[sample.Class] = append(samplesByClass[sample.Class], sample)
+	}
+	sortClasses(classes)
+
+	reply := &pb.SupplyDepthReply{
+		Timestamp: &pb.Timestamp{Seconds: snapshot.Ts.Unix()},
+	}
+
+	for _, class := range classes {
+		samples := samplesByClass[class]
+		sort.Slice(samples, func(i, j int) bool {
+			return query.better(samples[i].Price.Unwrap(), samples[j].Price.Unwrap())
+		})
+
+		depth := &pb.SupplyDepth{Class: class.Unwrap()}
+		owners := map[string]bool{}
+		for idx, sample := range samples {
+			owners[sample.Owner] = true
+
+			levels := depth.GetLevels()
+			if len(levels) > 0 && levels[len(levels)-1].GetPricePerSecond().Cmp(sample.Price) == 0 {
+				levels[len(levels)-1].Count = uint64(idx + 1)
+				continue
+			}
+
+			depth.Levels = append(depth.Levels, &pb.SupplyDepthLevel{
+				PricePerSecond: sample.Price,
+				Count:          uint64(idx + 1),
+			})
+		}
+
+		depth.Owners = uint64(len(owners))
+		reply.Depth = append(reply.Depth, depth)
+	}
+
+	return reply
+}
+
+func sortClasses(classes []resourceClass) {
+	sort.Slice(classes, func(i, j int) bool { return classes[i].less(classes[j]) })
+}
+
+func newAnalyticsAPI(opts *remoteOptions) (pb.MarketAnalyticsServer, error) {
+	conf := opts.conf.Analytics()
+	if !conf.Enabled {
+		return &analyticsAPI{ctx: opts.ctx, remotes: opts, conf: conf}, nil
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := newSnapshotStore(conf.StorePath)
+	if err != nil {
+		return nil, err
+	}
+
+	api := &analyticsAPI{
+		ctx:     opts.ctx,
+		remotes: opts,
+		conf:    conf,
+		store:   snapshots,
+	}
+
+	go api.run()
+
+	return api, nil
+}
@@ -0,0 +1,203 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func makeSample(orderType pb.OrderType, owner string, cores uint64, price int64) *orderSample {
+	return &orderSample{
+		Type:  orderType,
+		Owner: owner,
+		Class: resourceClass{CpuCores: cores},
+		Price: pb.NewBigIntFromInt(price),
+	}
+}
+
+func TestSnapshotStore(t *testing.T) {
+	snapshots, err := newSnapshotStore(getTestStorePath())
+	require.NoError(t, err)
+
+	now := time.Date(2018, 3, 1, 12, 30, 0, 0, time.UTC)
+	for _, ts := range []time.Time{now.Add(-2 * time.Hour), now.Add(-time.Hour), now} {
+		require.NoError(t, snapshots.Save(&ordersSnapshot{
+			Ts:      ts,
+			Samples: []*orderSample{makeSample(pb.OrderType_ASK, "0x1", 1, 100)},
+		}))
+	}
+
+	found, err := snapshots.Range(now.Add(-90*time.Minute), now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.True(t, found[0].Ts.Equal(now.Add(-time.Hour)))
+	assert.True(t, found[1].Ts.Equal(now))
+
+	last, err := snapshots.Last(now)
+	require.NoError(t, err)
+	require.NotNil(t, last)
+	assert.True(t, last.Ts.Equal(now.Add(-time.Hour)))
+
+	require.NoError(t, snapshots.Cleanup(now.Add(-time.Hour)))
+
+	found, err = snapshots.Range(now.Add(-24*time.Hour), now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+
+	last, err = snapshots.Last(now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Nil(t, last)
+}
+
+func TestNewPriceHistory(t *testing.T) {
+	from := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	query := &statsQuery{
+		orderType: pb.OrderType_ASK,
+		from:      from,
+		to:        from.Add(2 * time.Hour),
+		step:      time.Hour,
+	}
+
+	snapshots := []*ordersSnapshot{
+		{
+			Ts: from.Add(10 * time.Minute),
+			Samples: []*orderSample{
+				makeSample(pb.OrderType_ASK, "0x1", 2, 300),
+				makeSample(pb.OrderType_ASK, "0x2", 1, 200),
+				makeSample(pb.OrderType_BID, "0x3", 1, 900),
+			},
+		},
+		{
+			Ts: from.Add(40 * time.Minute),
+			Samples: []*orderSample{
+				makeSample(pb.OrderType_ASK, "0x2", 1, 100),
+				makeSample(pb.OrderType_ASK, "0x4", 1, 400),
+			},
+		},
+		{
+			Ts: from.Add(70 * time.Minute),
+			Samples: []*orderSample{
+				makeSample(pb.OrderType_ASK, "0x2", 1, 500),
+			},
+		},
+	}
+
+	reply := newPriceHistory(query, snapshots)
+	require.Len(t, reply.GetPoints(), 2)
+
+	first := reply.GetPoints()[0]
+	assert.Equal(t, from.Unix(), first.GetTimestamp().GetSeconds())
+	require.Len(t, first.GetStats(), 2)
+
+	stats := first.GetStats()[0]
+	assert.Equal(t, uint64(1), stats.GetClass().GetCpuCores())
+	assert.Equal(t, uint64(3), stats.GetCount())
+	assert.Equal(t, int64(100), stats.GetMin().Unwrap().Int64())
+	assert.Equal(t, int64(200), stats.GetP50().Unwrap().Int64())
+	assert.Equal(t, int64(400), stats.GetP90().Unwrap().Int64())
+	assert.Equal(t, int64(400), stats.GetMax().Unwrap().Int64())
+	assert.Equal(t, uint64(2), first.GetStats()[1].GetClass().GetCpuCores())
+
+	second := reply.GetPoints()[1]
+	assert.Equal(t, from.Add(time.Hour).Unix(), second.GetTimestamp().GetSeconds())
+	require.Len(t, second.GetStats(), 1)
+	assert.Equal(t, int64(500), second.GetStats()[0].GetP50().Unwrap().Int64())
+}
+
+func TestNewSupplyDepth(t *testing.T) {
+	query := &statsQuery{
+		orderType: pb.OrderType_ASK,
+		resources: &pb.Resources{CpuCores: 2},
+	}
+
+	snapshot := &ordersSnapshot{
+		Ts: time.Now(),
+		Samples: []*orderSample{
+			makeSample(pb.OrderType_ASK, "0x1", 2, 300),
+			makeSample(pb.OrderType_ASK, "0x2", 2, 100),
+			makeSample(pb.OrderType_ASK, "0x2", 2, 100),
+			makeSample(pb.OrderType_ASK, "0x3", 1, 50),
+		},
+	}
+
+	reply := newSupplyDepth(query, snapshot)
+	require.Len(t, reply.GetDepth(), 1)
+
+	depth := reply.GetDepth()[0]
+	assert.Equal(t, uint64(2), depth.GetOwners())
+	require.Len(t, depth.GetLevels(), 2)
+	assert.Equal(t, int64(100), depth.GetLevels()[0].GetPricePerSecond().Unwrap().Int64())
+	assert.Equal(t, uint64(2), depth.GetLevels()[0].GetCount())
+	assert.Equal(t, int64(300), depth.GetLevels()[1].GetPricePerSecond().Unwrap().Int64())
+	assert.Equal(t, uint64(3), depth.GetLevels()[1].GetCount())
+}
+
+func TestNewStatsQuery(t *testing.T) {
+	now := time.Now()
+
+	query, err := newStatsQuery(&pb.MarketStatsRequest{}, now)
+	require.NoError(t, err)
+	assert.Equal(t, pb.OrderType_ASK, query.orderType)
+	assert.Equal(t, defaultStatsStep, query.step)
+	assert.Equal(t, defaultStatsPeriod, query.to.Sub(query.from))
+
+	_, err = newStatsQuery(&pb.MarketStatsRequest{From: &pb.Timestamp{Seconds: now.Unix() + 1}}, now)
+	assert.Error(t, err)
+
+	_, err = newStatsQuery(&pb.MarketStatsRequest{Step: 1}, now)
+	assert.Error(t, err)
+}
+
+func TestAnalyticsSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	opts := getTestRemotes(ctx, ctrl)
+
+	ask := makeAsk("ask-1", "0x1")
+	broken := makeAsk("ask-2", "0x1")
+	broken.PricePerSecond = nil
+
+	market := pb.NewMockMarketClient(ctrl)
+	market.EXPECT().GetOrders(gomock.Any(), &pb.GetOrdersRequest{
+		Order: &pb.Order{OrderType: pb.OrderType_ASK},
+		Count: snapshotOrdersLimit,
+	}).Times(1).Return(&pb.GetOrdersReply{Orders: []*pb.Order{ask, broken}}, nil)
+	market.EXPECT().GetOrders(gomock.Any(), &pb.GetOrdersRequest{
+		Order: &pb.Order{OrderType: pb.OrderType_BID},
+		Count: snapshotOrdersLimit,
+	}).Times(1).Return(&pb.GetOrdersReply{}, nil)
+	opts.market = market
+
+	api := &analyticsAPI{ctx: ctx, remotes: opts}
+	snapshot, err := api.snapshot()
+	require.NoError(t, err)
+	require.Len(t, snapshot.Samples, 1)
+	assert.Equal(t, "0x1", snapshot.Samples[0].Owner)
+	assert.Equal(t, pb.OrderType_ASK, snapshot.Samples[0].Type)
+}
+
+func TestAnalyticsConfigValidate(t *testing.T) {
+	assert.NoError(t, (&AnalyticsConfig{}).Validate())
+	assert.NoError(t, (&AnalyticsConfig{Enabled: true, Interval: time.Minute, Retention: time.Hour}).Validate())
+	assert.Error(t, (&AnalyticsConfig{Enabled: true, Retention: time.Hour}).Validate())
+	assert.Error(t, (&AnalyticsConfig{Enabled: true, Interval: time.Minute}).Validate())
+}
+
+func TestAnalyticsDisabled(t *testing.T) {
+	api := &analyticsAPI{ctx: context.Background()}
+
+	_, err := api.PriceHistory(context.Background(), &pb.MarketStatsRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	_, err = api.SupplyDepth(context.Background(), &pb.MarketStatsRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
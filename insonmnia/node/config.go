@@ -1,9 +1,14 @@
 package node
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/jinzhu/configor"
 	"github.com/sonm-io/core/accounts"
+	"github.com/sonm-io/core/insonmnia/locator/dht"
 	"github.com/sonm-io/core/insonmnia/logging"
+	"github.com/sonm-io/core/insonmnia/tracing"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -17,13 +22,68 @@ type Config interface {
 	HubEndpoint() string
 	// LocatorEndpoint is Locator service gRPC endpoint
 	LocatorEndpoint() string
+	// LocatorDHT returns DHT settings used to resolve Hubs peer-to-peer
+	// instead of asking the Locator service, nil if disabled.
+	LocatorDHT() *dht.Config
 	// MetricsListenAddr returns the address that can be used by Prometheus to get
 	// metrics.
 	MetricsListenAddr() string
+	// StorePath returns path to the local storage keeping orders
+	// processing state between restarts.
+	StorePath() string
+	// Analytics returns settings of Marketplace orders snapshotting used
+	// to provide market statistics.
+	Analytics() AnalyticsConfig
+	// Accounts returns additional accounts the Node should unlock to be
+	// able to act on behalf of them.
+	Accounts() []AccountConfig
 	// KeyStorager included into config because of
 	// Node instance must know how to open the keystore
 	accounts.KeyStorager
 	logging.Leveler
+	// Logging returns logging settings.
+	Logging() logging.Config
+	// Tracing returns tracing settings.
+	Tracing() tracing.Config
+}
+
+// AccountConfig describes an additional account served by the Node.
+type AccountConfig struct {
+	// Address is Eth address of the account.
+	Address string `required:"true" yaml:"address"`
+	// PassPhrase is the pass phrase of account's key, if empty it will be
+	// asked interactively.
+	PassPhrase string `required:"false" default:"" yaml:"pass_phrase"`
+}
+
+// AnalyticsConfig describes how Marketplace orders are snapshotted to
+// provide market statistics.
+type AnalyticsConfig struct {
+	// Enabled turns snapshotting and market statistics on.
+	Enabled bool `yaml:"enabled"`
+	// StorePath is path to the local storage keeping snapshots.
+	StorePath string `required:"true" default:"/tmp/sonm/node_analytics_boltdb" yaml:"store_path"`
+	// Interval specifies how often orders are snapshotted.
+	Interval time.Duration `yaml:"interval" default:"5m"`
+	// Retention specifies how long snapshots are kept.
+	Retention time.Duration `yaml:"retention" default:"720h"`
+}
+
+// Validate checks the config, returning an error if it is malformed.
+func (c *AnalyticsConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Interval <= 0 {
+		return fmt.Errorf("analytics interval must be positive, but %v specified", c.Interval)
+	}
+
+	if c.Retention <= 0 {
+		return fmt.Errorf("analytics retention must be positive, but %v specified", c.Retention)
+	}
+
+	return nil
 }
 
 type nodeConfig struct {
@@ -39,21 +99,30 @@ type hubConfig struct {
 }
 
 type logConfig struct {
-	Level       string `required:"true" default:"debug" yaml:"level"`
-	parsedLevel zapcore.Level
+	logging.Config `yaml:",inline"`
+	parsedLevel    zapcore.Level
 }
 
 type locatorConfig struct {
-	Endpoint string `required:"true" default:"" yaml:"endpoint"`
+	Endpoint string      `required:"true" default:"" yaml:"endpoint"`
+	DHT      *dht.Config `required:"false" yaml:"dht"`
+}
+
+type storeConfig struct {
+	Path string `required:"true" default:"/tmp/sonm/node_boltdb" yaml:"path"`
 }
 
 type yamlConfig struct {
 	Node                    nodeConfig         `yaml:"node"`
 	Market                  marketConfig       `required:"true" yaml:"market"`
 	Log                     logConfig          `required:"true" yaml:"log"`
+	TracingConfig           tracing.Config     `yaml:"tracing"`
 	Locator                 locatorConfig      `required:"true" yaml:"locator"`
 	Eth                     accounts.EthConfig `required:"false" yaml:"ethereum"`
 	Hub                     *hubConfig         `required:"false" yaml:"hub"`
+	Store                   storeConfig        `yaml:"store"`
+	AnalyticsConfig         AnalyticsConfig    `yaml:"analytics"`
+	AccountsConfig          []AccountConfig    `required:"false" yaml:"accounts"`
 	MetricsListenAddrConfig string             `yaml:"metrics_listen_addr" default:"127.0.0.1:14003"`
 }
 
@@ -69,6 +138,10 @@ func (y *yamlConfig) LocatorEndpoint() string {
 	return y.Locator.Endpoint
 }
 
+func (y *yamlConfig) LocatorDHT() *dht.Config {
+	return y.Locator.DHT
+}
+
 func (y *yamlConfig) HubEndpoint() string {
 	if y.Hub != nil {
 		return y.Hub.Endpoint
@@ -76,10 +149,30 @@ func (y *yamlConfig) HubEndpoint() string {
 	return ""
 }
 
+func (y *yamlConfig) StorePath() string {
+	return y.Store.Path
+}
+
+func (y *yamlConfig) Analytics() AnalyticsConfig {
+	return y.AnalyticsConfig
+}
+
+func (y *yamlConfig) Accounts() []AccountConfig {
+	return y.AccountsConfig
+}
+
 func (y *yamlConfig) LogLevel() zapcore.Level {
 	return y.Log.parsedLevel
 }
 
+func (y *yamlConfig) Logging() logging.Config {
+	return y.Log.Config
+}
+
+func (y *yamlConfig) Tracing() tracing.Config {
+	return y.TracingConfig
+}
+
 func (y *yamlConfig) KeyStore() string {
 	return y.Eth.Keystore
 }
@@ -101,6 +194,18 @@ func NewConfig(path string) (Config, error) {
 		return nil, err
 	}
 
+	if err := cfg.Log.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.TracingConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.AnalyticsConfig.Validate(); err != nil {
+		return nil, err
+	}
+
 	lvl, err := logging.ParseLogLevel(cfg.Log.Level)
 	if err != nil {
 		return nil, err
@@ -1,17 +1,45 @@
 package node
 
 import (
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/insonmnia/rating"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	log "github.com/noxiouz/zapctx/ctxlog"
 	"go.uber.org/zap"
 )
 
+const (
+	// autoRenewInterval is how often deals marked for automatic renewal
+	// are checked.
+	autoRenewInterval = time.Minute
+	// autoRenewThreshold is how long before the end of the deal it is
+	// extended automatically.
+	autoRenewThreshold = 10 * time.Minute
+)
+
 type dealsAPI struct {
 	ctx     context.Context
 	remotes *remoteOptions
+	// orders keeps the state of BID orders processed by the Node, binding
+	// deals opened for orders with multiple slots, as well as deals marked
+	// for automatic renewal.
+	orders *orderStore
+
+	mu sync.Mutex
+	// autoRenew maps IDs of deals marked for automatic renewal to the
+	// account acting as the buyer.
+	autoRenew map[string]*remoteOptions
 }
 
 func (d *dealsAPI) List(ctx context.Context, req *pb.DealListRequest) (*pb.DealListReply, error) {
@@ -35,7 +63,47 @@ func (d *dealsAPI) List(ctx context.Context, req *pb.DealListRequest) (*pb.DealL
 		deals = append(deals, deal)
 	}
 
-	return &pb.DealListReply{Deal: deals}, nil
+	groups, err := d.dealGroups(deals)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DealListReply{Deal: deals, Groups: groups}, nil
+}
+
+// dealGroups returns groups of deals opened for BID orders with multiple
+// slots having any of the given deals.
+func (d *dealsAPI) dealGroups(deals []*pb.Deal) ([]*pb.DealGroup, error) {
+	if d.orders == nil {
+		return nil, nil
+	}
+
+	states, err := d.orders.List()
+	if err != nil {
+		return nil, err
+	}
+
+	listed := map[string]bool{}
+	for _, deal := range deals {
+		listed[deal.GetId()] = true
+	}
+
+	var groups []*pb.DealGroup
+	for _, state := range states {
+		if state.Status != statusDone || len(state.Deals) < 2 {
+			continue
+		}
+
+		dealIDs := slotDealIDs(state.Deals)
+		for _, id := range dealIDs {
+			if listed[id] {
+				groups = append(groups, &pb.DealGroup{BidID: state.Order.GetId(), DealIDs: dealIDs})
+				break
+			}
+		}
+	}
+
+	return groups, nil
 }
 
 func (d *dealsAPI) Status(ctx context.Context, id *pb.ID) (*pb.DealStatusReply, error) {
@@ -53,13 +121,20 @@ func (d *dealsAPI) Status(ctx context.Context, id *pb.ID) (*pb.DealStatusReply,
 		Deal: deal,
 	}
 
+	rm, err := d.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	if deal.GetStatus() == pb.DealStatus_ACCEPTED || deal.GetStatus() == pb.DealStatus_PENDING {
-		hubClient, closr, err := getHubClientByEthAddr(ctx, d.remotes, deal.GetSupplierID())
+		hubClient, closr, err := getHubClientByEthAddr(ctx, rm, deal.GetSupplierID())
 		if err == nil {
 			defer closr.Close()
 			dealInfo, err := hubClient.GetDealInfo(ctx, id)
 			if err == nil {
 				reply.Info = dealInfo
+				reply.Settlement = dealInfo.GetSettlement()
+				reply.AutoRenew = d.isAutoRenew(id.GetId())
 			} else {
 				log.G(ctx).Info("cannot get deal details from hub", zap.Error(err))
 			}
@@ -71,23 +146,420 @@ func (d *dealsAPI) Status(ctx context.Context, id *pb.ID) (*pb.DealStatusReply,
 	return reply, nil
 }
 
+// reportOutcome rates the supplier once the buyer closes the accepted deal,
+// judging by the deal details provided by its Hub.
+//
+// The breach is reported only when the Hub confirms it does not serve the
+// deal it is paid for. Any other Hub error, like a network failure, proves
+// nothing, so the deal is not reported at all.
+func (d *dealsAPI) reportOutcome(rm *remoteOptions, deal *pb.Deal, info *pb.DealInfoReply, hubErr error) {
+	if common.HexToAddress(deal.GetBuyerID()) != util.PubKeyToAddr(rm.key.PublicKey) {
+		return
+	}
+
+	outcome, details, ok := dealOutcome(deal, info, hubErr)
+	if !ok {
+		log.G(d.ctx).Info("cannot determine deal outcome", zap.String("deal_id", deal.GetId()), zap.Error(hubErr))
+		return
+	}
+
+	reporter := rating.NewReporter(rm.key, rm.rating)
+	go reporter.Report(d.ctx, deal.GetId(), deal.GetSupplierID(), outcome, details)
+}
+
+// dealOutcome returns the final outcome of the accepted deal for the buyer.
+// Bool result is false if the outcome is unknown.
+func dealOutcome(deal *pb.Deal, info *pb.DealInfoReply, hubErr error) (pb.DealOutcome, string, bool) {
+	if hubErr != nil {
+		if status.Code(hubErr) == codes.NotFound {
+			return pb.DealOutcome_SLA_BREACH, "", true
+		}
+
+		return 0, "", false
+	}
+
+	var failed []string
+	for taskID, task := range info.GetCompleted().GetStatuses() {
+		if task.GetStatus() == pb.TaskStatusReply_BROKEN {
+			failed = append(failed, taskID)
+		}
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return pb.DealOutcome_TASK_FAILED, strings.Join(failed, ","), true
+	}
+
+	settlement := info.GetSettlement()
+	if settlement != nil && common.HexToAddress(settlement.GetInitiator()) == common.HexToAddress(deal.GetSupplierID()) {
+		return pb.DealOutcome_CLOSED_EARLY, "", true
+	}
+
+	return pb.DealOutcome_COMPLETED, "", true
+}
+
 func (d *dealsAPI) Finish(ctx context.Context, id *pb.ID) (*pb.Empty, error) {
 	bigID, err := util.ParseBigInt(id.Id)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = d.remotes.eth.CloseDeal(ctx, d.remotes.key, bigID)
+	rm, err := d.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deal, err := rm.eth.GetDealInfo(ctx, bigID)
+	if err != nil {
+		return nil, err
+	}
+
+	info, hubErr := d.hubDealInfo(ctx, rm, deal)
+
+	_, err = rm.eth.CloseDeal(ctx, rm.key, bigID)
+	if err != nil {
+		return nil, err
+	}
+
+	if deal.GetStatus() == pb.DealStatus_ACCEPTED {
+		d.reportOutcome(rm, deal, info, hubErr)
+	}
+
+	return &pb.Empty{}, nil
+}
+
+// hubDealInfo returns details of the deal known by its Hub.
+func (d *dealsAPI) hubDealInfo(ctx context.Context, rm *remoteOptions, deal *pb.Deal) (*pb.DealInfoReply, error) {
+	hubClient, closr, err := getHubClientByEthAddr(ctx, rm, deal.GetSupplierID())
+	if err != nil {
+		return nil, err
+	}
+	defer closr.Close()
+
+	return hubClient.GetDealInfo(ctx, &pb.ID{Id: deal.GetId()})
+}
+
+func (d *dealsAPI) Close(ctx context.Context, req *pb.DealCloseRequest) (*pb.DealSettlement, error) {
+	bigID, err := util.ParseBigInt(req.GetId())
 	if err != nil {
 		return nil, err
 	}
 
+	rm, err := d.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deal, err := rm.eth.GetDealInfo(ctx, bigID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch deal.GetStatus() {
+	case pb.DealStatus_ACCEPTED:
+		// Only the Hub knows how long tasks were running, so it computes
+		// the settlement and closes the deal itself.
+		hubClient, closr, err := getHubClientByEthAddr(ctx, rm, deal.GetSupplierID())
+		if err != nil {
+			return nil, err
+		}
+		defer closr.Close()
+
+		info, hubErr := hubClient.GetDealInfo(ctx, &pb.ID{Id: req.GetId()})
+
+		settlement, err := hubClient.TerminateDeal(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		d.reportOutcome(rm, deal, info, hubErr)
+
+		return settlement, nil
+	case pb.DealStatus_PENDING:
+		// Nothing has been done within the deal yet, so the buyer is
+		// refunded completely.
+		if _, err := rm.eth.CloseDeal(ctx, rm.key, bigID); err != nil {
+			return nil, err
+		}
+
+		return &pb.DealSettlement{
+			Initiator:   util.PubKeyToAddr(rm.key.PublicKey).Hex(),
+			Reason:      req.GetReason(),
+			RequestedAt: &pb.Timestamp{Seconds: time.Now().Unix()},
+			Amount:      pb.NewBigInt(big.NewInt(0)),
+			Refund:      deal.GetPrice(),
+		}, nil
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot close deal with %s status", deal.GetStatus())
+	}
+}
+
+func (d *dealsAPI) Extend(ctx context.Context, req *pb.DealExtendRequest) (*pb.ID, error) {
+	rm, err := d.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	continuationID, err := d.extend(ctx, rm, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ID{Id: continuationID.String()}, nil
+}
+
+func (d *dealsAPI) AutoRenew(ctx context.Context, req *pb.DealAutoRenewRequest) (*pb.Empty, error) {
+	if _, err := util.ParseBigInt(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	rm, err := d.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if req.GetAutoRenew() {
+		if d.orders != nil {
+			state := &autoRenewState{DealID: req.GetId(), Account: util.PubKeyToAddr(rm.key.PublicKey).Hex()}
+			if err := d.orders.SaveAutoRenew(state); err != nil {
+				return nil, err
+			}
+		}
+
+		d.autoRenew[req.GetId()] = rm
+	} else {
+		if err := d.forgetAutoRenew(req.GetId()); err != nil {
+			return nil, err
+		}
+	}
+
 	return &pb.Empty{}, nil
 }
 
-func newDealsAPI(opts *remoteOptions) (pb.DealManagementServer, error) {
-	return &dealsAPI{
-		remotes: opts,
-		ctx:     opts.ctx,
-	}, nil
+// forgetAutoRenew unmarks the deal for automatic renewal. Must be called
+// with the mutex held.
+func (d *dealsAPI) forgetAutoRenew(id string) error {
+	if d.orders != nil {
+		if err := d.orders.RemoveAutoRenew(id); err != nil {
+			return err
+		}
+	}
+
+	delete(d.autoRenew, id)
+	return nil
+}
+
+// restoreAutoRenew loads deals marked for automatic renewal before the Node
+// restart. Deals of accounts that are not unlocked anymore are skipped.
+func (d *dealsAPI) restoreAutoRenew() error {
+	if d.orders == nil {
+		return nil
+	}
+
+	states, err := d.orders.ListAutoRenew()
+	if err != nil {
+		return err
+	}
+
+	accounts := map[string]*remoteOptions{}
+	for _, rm := range d.remotes.allAccounts() {
+		accounts[util.PubKeyToAddr(rm.key.PublicKey).Hex()] = rm
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, state := range states {
+		rm, ok := accounts[state.Account]
+		if !ok {
+			log.G(d.ctx).Warn("cannot renew deal of locked account",
+				zap.String("deal_id", state.DealID), zap.String("account", state.Account))
+			continue
+		}
+
+		d.autoRenew[state.DealID] = rm
+	}
+
+	return nil
+}
+
+func (d *dealsAPI) isAutoRenew(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, ok := d.autoRenew[id]
+	return ok
+}
+
+// extend opens a continuation deal with the same terms as the given one and
+// binds it on the Hub.
+func (d *dealsAPI) extend(ctx context.Context, rm *remoteOptions, id string) (*big.Int, error) {
+	bigID, err := util.ParseBigInt(id)
+	if err != nil {
+		return nil, err
+	}
+
+	deal, err := rm.eth.GetDealInfo(ctx, bigID)
+	if err != nil {
+		return nil, err
+	}
+
+	buyerID := util.PubKeyToAddr(rm.key.PublicKey).Hex()
+	if deal.GetBuyerID() != buyerID {
+		return nil, status.Errorf(codes.PermissionDenied, "deal %s belongs to another buyer", id)
+	}
+
+	balance, allowance, err := rm.loadBalanceAndAllowance(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !checkBalanceAndAllowance(deal.GetPrice().Unwrap(), balance, allowance) {
+		return nil, status.Errorf(codes.FailedPrecondition, "lack of balance or allowance to extend deal %s", id)
+	}
+
+	hubClient, closr, err := getHubClientByEthAddr(ctx, rm, deal.GetSupplierID())
+	if err != nil {
+		return nil, err
+	}
+	defer closr.Close()
+
+	continuation := &pb.Deal{
+		WorkTime:          deal.GetWorkTime(),
+		SupplierID:        deal.GetSupplierID(),
+		BuyerID:           buyerID,
+		Price:             deal.GetPrice(),
+		Status:            pb.DealStatus_PENDING,
+		SpecificationHash: deal.GetSpecificationHash(),
+	}
+
+	continuationID, err := rm.eth.OpenDealPending(ctx, rm.key, continuation, 180*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = hubClient.ExtendDeal(ctx, &pb.DealExtendRequest{Id: id, ContinuationID: continuationID.String()})
+	if err != nil {
+		if err := rm.eth.CloseDealPending(ctx, rm.key, continuationID, 180*time.Second); err != nil {
+			log.G(ctx).Warn("cannot close unused continuation deal",
+				zap.String("continuation_id", continuationID.String()), zap.Error(err))
+		}
+
+		return nil, err
+	}
+
+	log.G(ctx).Info("deal extended", zap.String("deal_id", id),
+		zap.String("continuation_id", continuationID.String()))
+
+	return continuationID, nil
+}
+
+func (d *dealsAPI) runAutoRenew() {
+	tk := time.NewTicker(autoRenewInterval)
+	defer tk.Stop()
+
+	for {
+		select {
+		case <-tk.C:
+			d.autoRenewOnce()
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *dealsAPI) autoRenewOnce() {
+	d.mu.Lock()
+	deals := make(map[string]*remoteOptions, len(d.autoRenew))
+	for id, rm := range d.autoRenew {
+		deals[id] = rm
+	}
+	d.mu.Unlock()
+
+	for id, rm := range deals {
+		renew, err := d.shouldRenew(rm, id)
+		if err != nil {
+			log.G(d.ctx).Warn("cannot check deal for renewal", zap.String("deal_id", id), zap.Error(err))
+			continue
+		}
+
+		if !renew {
+			continue
+		}
+
+		if _, err := d.extend(d.ctx, rm, id); err != nil {
+			log.G(d.ctx).Warn("cannot renew deal", zap.String("deal_id", id), zap.Error(err))
+		}
+	}
+}
+
+// shouldRenew checks whether the deal is going to expire soon and has no
+// continuation yet. Deals that are not known by the Hub anymore are
+// unmarked.
+func (d *dealsAPI) shouldRenew(rm *remoteOptions, id string) (bool, error) {
+	bigID, err := util.ParseBigInt(id)
+	if err != nil {
+		return false, err
+	}
+
+	deal, err := rm.eth.GetDealInfo(d.ctx, bigID)
+	if err != nil {
+		return false, err
+	}
+
+	hubClient, closr, err := getHubClientByEthAddr(d.ctx, rm, deal.GetSupplierID())
+	if err != nil {
+		return false, err
+	}
+	defer closr.Close()
+
+	info, err := hubClient.GetDealInfo(d.ctx, &pb.ID{Id: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			log.G(d.ctx).Info("deal is released, stop renewing it", zap.String("deal_id", id))
+			d.mu.Lock()
+			if err := d.forgetAutoRenew(id); err != nil {
+				log.G(d.ctx).Warn("cannot unmark deal for renewal", zap.String("deal_id", id), zap.Error(err))
+			}
+			d.mu.Unlock()
+		}
+
+		return false, err
+	}
+
+	if len(info.GetExtensions()) > 0 || info.GetSettlement() != nil {
+		return false, nil
+	}
+
+	activeID, err := util.ParseBigInt(info.GetActiveID())
+	if err != nil {
+		return false, err
+	}
+
+	active, err := rm.eth.GetDealInfo(d.ctx, activeID)
+	if err != nil {
+		return false, err
+	}
+
+	return time.Until(active.GetEndTime().Unix()) < autoRenewThreshold, nil
+}
+
+func newDealsAPI(opts *remoteOptions, orders *orderStore) (pb.DealManagementServer, error) {
+	api := &dealsAPI{
+		remotes:   opts,
+		ctx:       opts.ctx,
+		orders:    orders,
+		autoRenew: make(map[string]*remoteOptions),
+	}
+
+	if err := api.restoreAutoRenew(); err != nil {
+		return nil, err
+	}
+
+	go api.runAutoRenew()
+
+	return api, nil
 }
@@ -0,0 +1,50 @@
+package node
+
+import (
+	"errors"
+	"testing"
+
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDealOutcome(t *testing.T) {
+	deal := &pb.Deal{
+		Id:         "1",
+		BuyerID:    "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD",
+		SupplierID: "0x9A8568CD389580B6737FF56b61BE4F4eE802E2Db",
+	}
+
+	// Transport failures prove nothing.
+	_, _, ok := dealOutcome(deal, nil, status.Error(codes.Unavailable, "connection refused"))
+	assert.False(t, ok)
+	_, _, ok = dealOutcome(deal, nil, errors.New("cannot resolve hub address"))
+	assert.False(t, ok)
+
+	outcome, _, ok := dealOutcome(deal, nil, status.Error(codes.NotFound, "deal not found"))
+	assert.True(t, ok)
+	assert.Equal(t, pb.DealOutcome_SLA_BREACH, outcome)
+
+	outcome, _, ok = dealOutcome(deal, &pb.DealInfoReply{}, nil)
+	assert.True(t, ok)
+	assert.Equal(t, pb.DealOutcome_COMPLETED, outcome)
+
+	outcome, _, ok = dealOutcome(deal, &pb.DealInfoReply{
+		Settlement: &pb.DealSettlement{Initiator: "0x9a8568cd389580b6737ff56b61be4f4ee802e2db"},
+	}, nil)
+	assert.True(t, ok)
+	assert.Equal(t, pb.DealOutcome_CLOSED_EARLY, outcome)
+
+	outcome, details, ok := dealOutcome(deal, &pb.DealInfoReply{
+		Completed: &pb.StatusMapReply{Statuses: map[string]*pb.TaskStatusReply{
+			"task-2": {Status: pb.TaskStatusReply_BROKEN},
+			"task-1": {Status: pb.TaskStatusReply_BROKEN},
+			"task-3": {Status: pb.TaskStatusReply_FINISHED},
+		}},
+	}, nil)
+	assert.True(t, ok)
+	assert.Equal(t, pb.DealOutcome_TASK_FAILED, outcome)
+	assert.Equal(t, "task-1,task-2", details)
+}
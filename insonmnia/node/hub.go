@@ -22,8 +22,8 @@ type hubAPI struct {
 	ctx     context.Context
 }
 
-func (h *hubAPI) getClient() (pb.HubClient, io.Closer, error) {
-	cc, err := xgrpc.NewClient(h.ctx, h.remotes.conf.HubEndpoint(), h.remotes.creds,
+func (h *hubAPI) getClient(rm *remoteOptions) (pb.HubClient, io.Closer, error) {
+	cc, err := xgrpc.NewClient(h.ctx, rm.conf.HubEndpoint(), rm.creds,
 		grpc.WithBlock(), grpc.WithTimeout(15*time.Second))
 	if err != nil {
 		return nil, nil, err
@@ -46,7 +46,12 @@ func (h *hubAPI) intercept(ctx context.Context, req interface{}, info *grpc.Unar
 		return nil, errors.New("hub endpoint is not configured, please check Node settings")
 	}
 
-	cli, cc, err := h.getClient()
+	rm, err := h.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, cc, err := h.getClient(rm)
 	if err != nil {
 		return nil, fmt.Errorf("cannot connect to hub at %s, please check Node settings", h.remotes.conf.HubEndpoint())
 	}
@@ -85,6 +90,7 @@ var hubToNodeMethods = map[string]string{
 	"RemoveAskPlan":        "RemoveSlot",
 	"TaskList":             "TaskList",
 	"TaskStatus":           "TaskStatus",
+	"Routes":               "Routes",
 }
 
 func newHubAPI(opts *remoteOptions) pb.HubManagementServer {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,17 +14,22 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sonm-io/core/blockchain"
 	"github.com/sonm-io/core/blockchain/tsc"
+	"github.com/sonm-io/core/insonmnia/rating"
 	"github.com/sonm-io/core/insonmnia/structs"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
 	errProposeNotAccepted = errors.New("no hub accept proposed deal")
 	errLackOfBalance      = errors.New("lack of balance or allowance for order")
 	errNoAskFound         = errors.New("cannot find matching ASK order")
+	errLackOfRating       = errors.New("no supplier has required rating")
+	errOrderNotPlaced     = errors.New("order is no longer placed on market")
 )
 
 type HandlerStatus uint8
@@ -37,6 +43,7 @@ func (h HandlerStatus) String() string {
 		statusWaitForApprove: "Waiting for approve",
 		statusDone:           "Done",
 		statusFailed:         "Failed",
+		statusCancelled:      "Cancelled",
 	}
 
 	s, ok := m[h]
@@ -46,6 +53,10 @@ func (h HandlerStatus) String() string {
 	return s
 }
 
+// orderHistoryTTL specifies how long finished orders are kept in the local
+// storage to be shown as processed.
+const orderHistoryTTL = 7 * 24 * time.Hour
+
 const (
 	orderPollPeriod = 5 * time.Second
 
@@ -56,8 +67,14 @@ const (
 	statusWaitForApprove
 	statusDone
 	statusFailed
+	statusCancelled
 )
 
+// finished reports whether the order processing is over.
+func (h HandlerStatus) finished() bool {
+	return h == statusDone || h == statusCancelled
+}
+
 // orderHandler is wrapper over Order
 // allows to keep order execution status
 //
@@ -75,6 +92,12 @@ const (
 // 5. If propose is completed -> status = "Done"
 //
 // In any internal error -> status = "Failed"
+//
+// Orders with multiple slots are dealt all or nothing: a deal is opened
+// for each slot, and all of them are closed if any fails to be approved.
+//
+// Handler state is persisted on each change, so the processing is resumed
+// after the Node restarts.
 
 type orderHandler struct {
 	sync.Mutex
@@ -85,9 +108,13 @@ type orderHandler struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
-	err    error
-	dealID string
+	err error
+	// deals are the deals opened for the order slots.
+	deals    []*slotDeal
+	attempts uint64
 
+	account    string
+	store      *orderStore
 	locator    pb.LocatorClient
 	bc         blockchain.Blockchainer
 	hubCreator hubClientCreator
@@ -115,6 +142,43 @@ func newOrderHandler(ctx context.Context, loc pb.LocatorClient, bc blockchain.Bl
 	return t, nil
 }
 
+// restore continues from the previously persisted state.
+func (h *orderHandler) restore(state *orderState) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.status = state.Status
+	h.ts = state.Ts
+	h.deals = state.Deals
+	h.attempts = state.Attempts
+	if state.Error != "" {
+		h.err = errors.New(state.Error)
+	}
+}
+
+// save persists the handler state. Must be called with the lock held.
+func (h *orderHandler) save() {
+	if h.store == nil {
+		return
+	}
+
+	state := &orderState{
+		Order:    h.order,
+		Account:  h.account,
+		Status:   h.status,
+		Deals:    h.deals,
+		Attempts: h.attempts,
+		Ts:       h.ts,
+	}
+	if h.err != nil {
+		state.Error = h.err.Error()
+	}
+
+	if err := h.store.Save(state); err != nil {
+		log.G(h.ctx).Warn("cannot save order handler state", zap.String("order_id", h.id), zap.Error(err))
+	}
+}
+
 // setError keeps error into handler struct and
 // changes task status to "failed"
 func (h *orderHandler) setError(err error) {
@@ -123,6 +187,7 @@ func (h *orderHandler) setError(err error) {
 
 	h.status = statusFailed
 	h.err = err
+	h.save()
 }
 
 func (h *orderHandler) setStatus(s HandlerStatus) {
@@ -130,6 +195,92 @@ func (h *orderHandler) setStatus(s HandlerStatus) {
 	defer h.Unlock()
 
 	h.status = s
+	h.save()
+}
+
+// attempt counts the next processing attempt.
+func (h *orderHandler) attempt() {
+	h.Lock()
+	defer h.Unlock()
+
+	h.attempts++
+	h.save()
+}
+
+// slotCount returns how many slots must be dealt for the order.
+func (h *orderHandler) slotCount() int {
+	if count := h.order.GetCount(); count > 1 {
+		return int(count)
+	}
+
+	return 1
+}
+
+// addDeal remembers the deal opened for the given ASK, which must be
+// closed unless approved by the Hub.
+func (h *orderHandler) addDeal(ask *pb.Order, dealID *big.Int) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.deals = append(h.deals, &slotDeal{Ask: ask, DealID: dealID.String()})
+	h.save()
+}
+
+// pendingDeals returns IDs of the opened, but not approved deals.
+func (h *orderHandler) pendingDeals() []*big.Int {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.status == statusDone {
+		return nil
+	}
+
+	var ids []*big.Int
+	for _, deal := range h.deals {
+		if id, ok := big.NewInt(0).SetString(deal.DealID, 10); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+func (h *orderHandler) removeDeal(dealID *big.Int) {
+	h.Lock()
+	defer h.Unlock()
+
+	for i, deal := range h.deals {
+		if deal.DealID == dealID.String() {
+			h.deals = append(h.deals[:i], h.deals[i+1:]...)
+			break
+		}
+	}
+	h.save()
+}
+
+func (h *orderHandler) dealIDs() []string {
+	h.Lock()
+	defer h.Unlock()
+
+	return slotDealIDs(h.deals)
+}
+
+func slotDealIDs(deals []*slotDeal) []string {
+	ids := make([]string, 0, len(deals))
+	for _, deal := range deals {
+		ids = append(ids, deal.DealID)
+	}
+
+	return ids
+}
+
+func (h *orderHandler) setDone() {
+	h.Lock()
+	defer h.Unlock()
+
+	h.err = nil
+	h.status = statusDone
+	h.save()
 }
 
 func (h *orderHandler) getStatus() HandlerStatus {
@@ -165,36 +316,8 @@ func (h *orderHandler) search(m pb.MarketClient) ([]*pb.Order, error) {
 	return reply.Orders, nil
 }
 
-// resolveHubAddr resolving Hub IP addr from Hub's Eth address
-// via Locator service
-func (h *orderHandler) resolveHubAddr(ethAddr string) (string, error) {
-	req := &pb.ResolveRequest{EthAddr: ethAddr}
-	reply, err := h.locator.Resolve(h.ctx, req)
-	if err != nil {
-		return "", err
-	}
-
-	ip := reply.Endpoints[0]
-	log.G(h.ctx).Info("hub ip resolved successful", zap.String("ip", ip))
-
-	return ip, nil
-}
-
 func (h *orderHandler) makeHubClient(ethAddr string) (pb.HubClient, io.Closer, error) {
-	hubIP, err := h.resolveHubAddr(ethAddr)
-	if err != nil {
-		log.G(h.ctx).Info("cannot resolve Hub IP", zap.Error(err))
-		return nil, nil, err
-	}
-
-	hub, cc, err := h.hubCreator(hubIP)
-	if err != nil {
-		log.G(h.ctx).Info("cannot create Hub gRPC client", zap.Error(err))
-		return nil, nil, err
-	}
-
-	log.G(h.ctx).Info("hub connection built", zap.String("hub_ip", hubIP))
-	return hub, cc, nil
+	return connectHub(h.ctx, h.locator, h.hubCreator, ethAddr)
 }
 
 // openDeal creates deal on Ethereum blockchain
@@ -221,12 +344,27 @@ func (h *orderHandler) openDeal(order *pb.Order, key *ecdsa.PrivateKey, wait tim
 	return dealID, nil
 }
 
-// approveOnHub send deal to the Hub and wait for approval on Hub-side
-func (h *orderHandler) approveOnHub(req *pb.ApproveDealRequest, hub pb.HubClient) error {
+// approveOnHub send deal to the Hub and wait for approval on Hub-side.
+// The Hub may move while the deal is being opened, so its endpoints are
+// resolved again once it becomes unavailable.
+func (h *orderHandler) approveOnHub(req *pb.ApproveDealRequest, hub pb.HubClient, ethAddr string) error {
 	log.G(h.ctx).Info("waiting for deal become approved")
 	h.setStatus(statusWaitForApprove)
 
 	_, err := hub.ApproveDeal(h.ctx, req)
+	if status.Code(err) == codes.Unavailable {
+		log.G(h.ctx).Info("hub is unavailable, resolving it again", zap.Error(err))
+
+		var cc io.Closer
+		hub, cc, err = h.makeHubClient(ethAddr)
+		if err != nil {
+			return err
+		}
+		defer cc.Close()
+
+		_, err = hub.ApproveDeal(h.ctx, req)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -235,8 +373,8 @@ func (h *orderHandler) approveOnHub(req *pb.ApproveDealRequest, hub pb.HubClient
 	return nil
 }
 
-func (m *marketAPI) closeUnapprovedDeal(dealID *big.Int) error {
-	err := m.remotes.eth.CloseDealPending(m.ctx, m.remotes.key, dealID, time.Duration(180*time.Second))
+func (m *marketAPI) closeUnapprovedDeal(rm *remoteOptions, dealID *big.Int) error {
+	err := rm.eth.CloseDealPending(m.ctx, rm.key, dealID, time.Duration(180*time.Second))
 	if err != nil {
 		return err
 	}
@@ -244,9 +382,26 @@ func (m *marketAPI) closeUnapprovedDeal(dealID *big.Int) error {
 	return nil
 }
 
+// closePendingDeals closes all the deals opened for the order, but not
+// approved yet, so either all slots are dealt or none of them.
+func (m *marketAPI) closePendingDeals(rm *remoteOptions, handler *orderHandler) error {
+	for _, dealID := range handler.pendingDeals() {
+		if err := m.closeUnapprovedDeal(rm, dealID); err != nil {
+			log.G(handler.ctx).Warn("cannot close unapproved deal", zap.String("deal_id", dealID.String()), zap.Error(err))
+			return err
+		}
+
+		handler.removeDeal(dealID)
+		log.G(handler.ctx).Info("unapproved deal closed", zap.String("deal_id", dealID.String()))
+	}
+
+	return nil
+}
+
 type marketAPI struct {
 	remotes *remoteOptions
 	ctx     context.Context
+	store   *orderStore
 
 	taskMux sync.Mutex
 	tasks   map[string]*orderHandler
@@ -282,11 +437,21 @@ func (m *marketAPI) countHandlers() int {
 }
 
 func (m *marketAPI) GetOrders(ctx context.Context, req *pb.GetOrdersRequest) (*pb.GetOrdersReply, error) {
-	return m.remotes.market.GetOrders(ctx, req)
+	rm, err := m.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return rm.market.GetOrders(ctx, req)
 }
 
 func (m *marketAPI) GetOrderByID(ctx context.Context, req *pb.ID) (*pb.Order, error) {
-	return m.remotes.market.GetOrderByID(ctx, req)
+	rm, err := m.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return rm.market.GetOrderByID(ctx, req)
 }
 
 func (m *marketAPI) CreateOrder(ctx context.Context, req *pb.Order) (*pb.Order, error) {
@@ -298,8 +463,13 @@ func (m *marketAPI) CreateOrder(ctx context.Context, req *pb.Order) (*pb.Order,
 		return nil, err
 	}
 
-	req.ByuerID = util.PubKeyToAddr(m.remotes.key.PublicKey).Hex()
-	created, err := m.remotes.market.CreateOrder(ctx, req)
+	rm, err := m.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.ByuerID = util.PubKeyToAddr(rm.key.PublicKey).Hex()
+	created, err := rm.market.CreateOrder(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -307,27 +477,120 @@ func (m *marketAPI) CreateOrder(ctx context.Context, req *pb.Order) (*pb.Order,
 	// Marketplace knows nothing about the required duration, we must bypass it by hand.
 	// Looks awful, but nevermind, it feels like out timing system is broken by design.
 	created.Slot.Duration = req.GetSlot().GetDuration()
-	go m.startExecOrderHandler(created)
+	created.Count = req.GetCount()
+	go m.startExecOrderHandler(rm, created)
 
 	return created, nil
 }
 
-func (m *marketAPI) startExecOrderHandler(ord *pb.Order) {
+// startExecOrderHandler processes the given order on behalf of
+// the account bound to the given remote options.
+func (m *marketAPI) startExecOrderHandler(rm *remoteOptions, ord *pb.Order) {
 	log.G(m.ctx).Info("starting ExecOrder")
 
-	handler, err := newOrderHandler(m.ctx, m.remotes.locator, m.remotes.eth, m.remotes.hubCreator, ord)
+	handler, err := m.newOrderHandler(rm, ord)
 	if err != nil {
 		// push failed handler too, because we need to show error
-		failedHandler := &orderHandler{id: ord.GetId(), err: err, status: statusFailed}
+		failedHandler := &orderHandler{id: ord.GetId(), err: err, status: statusFailed, ts: time.Now()}
 		m.registerHandler(ord.Id, failedHandler)
 		log.G(m.ctx).Info("cannot create new bg handler from order", zap.Error(err))
 		return
 	}
 
+	handler.setStatus(statusNew)
 	m.registerHandler(handler.id, handler)
+	m.runOrderHandler(rm, handler)
+}
+
+func (m *marketAPI) newOrderHandler(rm *remoteOptions, ord *pb.Order) (*orderHandler, error) {
+	handler, err := newOrderHandler(m.ctx, rm.locator, rm.eth, rm.hubCreator, ord)
+	if err != nil {
+		return nil, err
+	}
+
+	handler.account = util.PubKeyToAddr(rm.key.PublicKey).Hex()
+	handler.store = m.store
+
+	return handler, nil
+}
+
+// resumeOrderHandler continues processing of the order from the state
+// persisted before the Node restart.
+func (m *marketAPI) resumeOrderHandler(rm *remoteOptions, state *orderState, placed bool) {
+	log.G(m.ctx).Info("resuming order processing", zap.String("order_id", state.Order.GetId()),
+		zap.Stringer("status", state.Status), zap.Strings("deal_ids", slotDealIDs(state.Deals)))
+
+	handler, err := m.newOrderHandler(rm, state.Order)
+	if err != nil {
+		log.G(m.ctx).Warn("cannot resume order processing", zap.String("order_id", state.Order.GetId()), zap.Error(err))
+		return
+	}
+
+	handler.restore(state)
+	m.registerHandler(handler.id, handler)
+
+	done, err := m.settlePendingDeals(rm, handler)
+	if err != nil {
+		handler.setError(err)
+	}
+	if done {
+		m.cancelOnMarket(rm, handler)
+		return
+	}
+
+	if !placed {
+		handler.Lock()
+		handler.status = statusCancelled
+		handler.err = errOrderNotPlaced
+		handler.save()
+		handler.Unlock()
+
+		m.deregisterHandler(handler.id)
+		return
+	}
 
+	m.runOrderHandler(rm, handler)
+}
+
+// settlePendingDeals deals with the deals opened before the restart, whose
+// approval is unknown. The handler is done if a deal has been accepted for
+// each slot, otherwise the deals are closed.
+func (m *marketAPI) settlePendingDeals(rm *remoteOptions, handler *orderHandler) (bool, error) {
+	dealIDs := handler.pendingDeals()
+	if len(dealIDs) == 0 {
+		return handler.getStatus() == statusDone, nil
+	}
+
+	approved := len(dealIDs) == handler.slotCount()
+	for _, dealID := range dealIDs {
+		deal, err := rm.eth.GetDealInfo(m.ctx, dealID)
+		if err != nil {
+			return false, err
+		}
+
+		if deal.GetStatus() != pb.DealStatus_ACCEPTED {
+			approved = false
+			break
+		}
+	}
+
+	if approved {
+		handler.setDone()
+		log.G(handler.ctx).Info("pending deals have been approved", zap.Strings("deal_ids", handler.dealIDs()))
+		return true, nil
+	}
+
+	if err := m.closePendingDeals(rm, handler); err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+// runOrderHandler processes the order until success or cancellation.
+func (m *marketAPI) runOrderHandler(rm *remoteOptions, handler *orderHandler) {
 	// process order (search -> propose -> deal)
-	if ok := m.executeOrderOnceWithCancel(handler); ok {
+	if ok := m.executeOrderOnceWithCancel(rm, handler); ok {
 		return
 	}
 
@@ -341,21 +604,21 @@ func (m *marketAPI) startExecOrderHandler(ord *pb.Order) {
 			log.G(handler.ctx).Info("order handler is cancelled", zap.String("order_id", handler.id))
 			return
 		case <-tk.C:
-			if ok := m.executeOrderOnceWithCancel(handler); ok {
+			if ok := m.executeOrderOnceWithCancel(rm, handler); ok {
 				return
 			}
 		}
 	}
 }
 
-func (m *marketAPI) loadBalanceAndAllowance() (*big.Int, *big.Int, error) {
-	addr := util.PubKeyToAddr(m.remotes.key.PublicKey).Hex()
-	balance, err := m.remotes.eth.BalanceOf(m.ctx, addr)
+func (r *remoteOptions) loadBalanceAndAllowance(ctx context.Context) (*big.Int, *big.Int, error) {
+	addr := util.PubKeyToAddr(r.key.PublicKey).Hex()
+	balance, err := r.eth.BalanceOf(ctx, addr)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	allowance, err := m.remotes.eth.AllowanceOf(m.ctx, addr, tsc.DealsAddress)
+	allowance, err := r.eth.AllowanceOf(ctx, addr, tsc.DealsAddress)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -363,7 +626,7 @@ func (m *marketAPI) loadBalanceAndAllowance() (*big.Int, *big.Int, error) {
 	return balance, allowance, nil
 }
 
-func (m *marketAPI) checkBalanceAndAllowance(price, balance, allowance *big.Int) bool {
+func checkBalanceAndAllowance(price, balance, allowance *big.Int) bool {
 	if balance.Cmp(price) == -1 || allowance.Cmp(price) == -1 {
 		return false
 	}
@@ -376,7 +639,7 @@ func (m *marketAPI) filterOrdersByPriceAndAllowance(ctx context.Context, balance
 
 	for _, ord := range orders {
 		price := structs.CalculateTotalPrice(ord)
-		if !m.checkBalanceAndAllowance(price, balance, allowance) {
+		if !checkBalanceAndAllowance(price, balance, allowance) {
 			log.G(ctx).Info("lack of balance or allowance for order, skip",
 				zap.String("orderID", ord.Id),
 				zap.String("price", price.String()),
@@ -424,8 +687,8 @@ func (m *marketAPI) proposeDeal(h *orderHandler, ord *pb.Order) (*pb.Order, pb.H
 	return ord, hubClient, cc
 }
 
-func (m *marketAPI) executeOrderOnceWithCancel(handler *orderHandler) bool {
-	err := m.executeOrder(handler)
+func (m *marketAPI) executeOrderOnceWithCancel(rm *remoteOptions, handler *orderHandler) bool {
+	err := m.executeOrder(rm, handler)
 
 	if err != nil {
 		if err != errNoAskFound {
@@ -437,26 +700,64 @@ func (m *marketAPI) executeOrderOnceWithCancel(handler *orderHandler) bool {
 
 	log.G(handler.ctx).Debug("order loop complete at n=1 iteration, exiting")
 
-	if _, err := m.remotes.market.CancelOrder(m.ctx, handler.order); err != nil {
+	m.cancelOnMarket(rm, handler)
+
+	return true
+}
+
+func (m *marketAPI) cancelOnMarket(rm *remoteOptions, handler *orderHandler) {
+	if _, err := rm.market.CancelOrder(m.ctx, handler.order); err != nil {
 		log.G(handler.ctx).Warn("cannot cancel order on market",
 			zap.String("order_id", handler.id),
 			zap.Error(err))
 	}
+}
 
-	return true
+// filterOrdersByRating drops orders of suppliers whose rating is lower than
+// required by the BID.
+func (m *marketAPI) filterOrdersByRating(ctx context.Context, rm *remoteOptions, minRating int64, orders []*pb.Order) ([]*pb.Order, error) {
+	if minRating <= 0 {
+		return orders, nil
+	}
+
+	var matched []*pb.Order
+	for _, ord := range orders {
+		if err := rating.Check(ctx, rm.rating, ord.GetSupplierID(), minRating); err != nil {
+			log.G(ctx).Info("supplier rating does not fit, skip",
+				zap.String("orderID", ord.Id),
+				zap.String("supplierID", ord.GetSupplierID()),
+				zap.Error(err))
+			continue
+		}
+
+		matched = append(matched, ord)
+	}
+
+	if len(matched) == 0 {
+		return nil, errLackOfRating
+	}
+
+	return matched, nil
 }
 
 // executeOrder searching for orders, iterate found orders and trying to propose deal
-func (m *marketAPI) executeOrder(handler *orderHandler) error {
+func (m *marketAPI) executeOrder(rm *remoteOptions, handler *orderHandler) error {
 	log.G(handler.ctx).Info("starting executeOrder", zap.String("id", handler.id))
+	handler.attempt()
 
-	balance, allowance, err := m.loadBalanceAndAllowance()
+	// Deals opened by the previous attempt must be closed before opening
+	// another ones.
+	if err := m.closePendingDeals(rm, handler); err != nil {
+		return err
+	}
+
+	balance, allowance, err := rm.loadBalanceAndAllowance(m.ctx)
 	if err != nil {
 		log.G(handler.ctx).Error("cannot load balance and allowance", zap.Error(err))
 		return err
 	}
 
-	orders, err := handler.search(m.remotes.market)
+	orders, err := handler.search(rm.market)
 	if err != nil {
 		log.G(handler.ctx).Info("cannot get orders", zap.Error(err))
 		return err
@@ -469,66 +770,136 @@ func (m *marketAPI) executeOrder(handler *orderHandler) error {
 		return err
 	}
 
-	var (
-		orderToDeal *pb.Order
-		hubClient   pb.HubClient
-		cc          io.Closer
-	)
+	ordersForProposeDeal, err = m.filterOrdersByRating(handler.ctx, rm, handler.order.GetSlot().GetSupplierRating(), ordersForProposeDeal)
+	if err != nil {
+		return err
+	}
+
+	count := handler.slotCount()
+	if count > 1 {
+		price := structs.CalculateTotalPrice(handler.order)
+		price.Mul(price, big.NewInt(int64(count)))
+		if !checkBalanceAndAllowance(price, balance, allowance) {
+			return errLackOfBalance
+		}
+	}
 
-	// iterate orders #2, try to propose order
+	// iterate orders #2, try to propose order for each slot
+	proposals := make([]*proposal, 0, count)
+	suppliers := map[string]bool{}
 	for _, ord := range ordersForProposeDeal {
-		orderToDeal, hubClient, cc = m.proposeDeal(handler, ord)
-		if orderToDeal != nil {
+		if len(proposals) == count {
 			break
 		}
+
+		// Hubs track the reserved resources by the BID, hence each slot
+		// is dealt with a distinct Hub.
+		if suppliers[ord.GetSupplierID()] {
+			continue
+		}
+
+		orderToDeal, hubClient, cc := m.proposeDeal(handler, ord)
+		if orderToDeal != nil {
+			suppliers[ord.GetSupplierID()] = true
+			proposals = append(proposals, &proposal{ask: orderToDeal, hub: hubClient, cc: cc})
+		}
 	}
 
-	// order still nil - deal cannot be proposed, failing the handler
-	if orderToDeal == nil {
+	for _, p := range proposals {
+		defer p.cc.Close()
+	}
+
+	// deal cannot be proposed for every slot, failing the handler
+	if len(proposals) < count {
+		m.cancelProposals(handler, proposals)
 		return errProposeNotAccepted
 	}
 
-	defer cc.Close()
+	for _, p := range proposals {
+		dealID, err := handler.openDeal(p.ask, rm.key, rm.dealCreateTimeout)
+		if err != nil {
+			m.cancelProposals(handler, proposals)
+			if closeErr := m.closePendingDeals(rm, handler); closeErr != nil {
+				return closeErr
+			}
 
-	dealID, err := handler.openDeal(orderToDeal, m.remotes.key, m.remotes.dealCreateTimeout)
-	if err != nil {
-		return err
-	}
+			return err
+		}
 
-	approveRequest := &pb.ApproveDealRequest{
-		DealID: pb.NewBigInt(dealID),
-		AskID:  orderToDeal.GetId(),
-		BidID:  handler.order.GetId(),
+		handler.addDeal(p.ask, dealID)
+		p.dealID = dealID
 	}
 
-	err = handler.approveOnHub(approveRequest, hubClient)
-	if err != nil {
-		log.G(handler.ctx).Info("hub cannot approve deal, need to close deal", zap.Error(err))
+	for _, p := range proposals {
+		approveRequest := &pb.ApproveDealRequest{
+			DealID: pb.NewBigInt(p.dealID),
+			AskID:  p.ask.GetId(),
+			BidID:  handler.order.GetId(),
+		}
 
-		err = m.closeUnapprovedDeal(dealID)
+		err = handler.approveOnHub(approveRequest, p.hub, p.ask.GetSupplierID())
 		if err != nil {
-			log.G(handler.ctx).Warn("cannot close unapproved deal", zap.Error(err))
-			return err
+			log.G(handler.ctx).Info("hub cannot approve deal, need to close deals", zap.Error(err))
+
+			m.cancelProposals(handler, proposals)
+			if err := m.closePendingDeals(rm, handler); err != nil {
+				return err
+			}
+
+			return errors.New("deal is not approved on the hub")
 		}
 
-		log.G(handler.ctx).Info("unapproved deal closed")
-		return errors.New("deal is not approved on the hub")
+		p.approved = true
 	}
 
-	handler.Lock()
-	handler.err = nil
-	handler.dealID = dealID.String()
-	handler.status = statusDone
-	handler.Unlock()
+	handler.setDone()
 
 	log.G(handler.ctx).Info("handler done",
 		zap.String("order_id", handler.id),
-		zap.String("deal_id", dealID.String()))
+		zap.Strings("deal_ids", handler.dealIDs()))
 	return nil
 }
 
+// cancelProposals drops proposals that are not approved yet, releasing the
+// resources reserved by Hubs for them, so the order can be proposed again
+// without waiting for reservations to expire.
+func (m *marketAPI) cancelProposals(handler *orderHandler, proposals []*proposal) {
+	for _, p := range proposals {
+		if p.approved {
+			continue
+		}
+
+		request := &pb.DealRequest{
+			AskId:    p.ask.GetId(),
+			BidId:    handler.order.GetId(),
+			SpecHash: handler.slotSpecHash(),
+		}
+
+		if _, err := p.hub.CancelProposal(handler.ctx, request); err != nil {
+			log.G(handler.ctx).Warn("cannot cancel deal proposal on the hub",
+				zap.String("ask_id", p.ask.GetId()),
+				zap.String("supplier_id", p.ask.GetSupplierID()),
+				zap.Error(err))
+		}
+	}
+}
+
+// proposal is a deal proposed to the Hub for one of the order slots.
+type proposal struct {
+	ask      *pb.Order
+	hub      pb.HubClient
+	cc       io.Closer
+	dealID   *big.Int
+	approved bool
+}
+
 func (m *marketAPI) CancelOrder(ctx context.Context, order *pb.Order) (*pb.Empty, error) {
-	order, err := m.GetOrderByID(ctx, &pb.ID{Id: order.Id})
+	rm, err := m.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err = m.GetOrderByID(ctx, &pb.ID{Id: order.Id})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to resolve order type")
 	}
@@ -538,11 +909,12 @@ func (m *marketAPI) CancelOrder(ctx context.Context, order *pb.Order) (*pb.Empty
 			"can only remove bids via Market API; please use Hub ask-plan API to manage asks")
 	}
 
-	repl, err := m.remotes.market.CancelOrder(ctx, order)
+	repl, err := rm.market.CancelOrder(ctx, order)
 	if err == nil {
 		handler, ok := m.getHandler(order.Id)
 		if ok {
 			handler.cancel()
+			handler.setStatus(statusCancelled)
 			m.deregisterHandler(order.Id)
 		} else {
 			log.G(m.ctx).Info("no order handler found", zap.String("order_id", order.Id))
@@ -553,74 +925,167 @@ func (m *marketAPI) CancelOrder(ctx context.Context, order *pb.Order) (*pb.Empty
 }
 
 func (m *marketAPI) TouchOrders(ctx context.Context, req *pb.TouchOrdersRequest) (*pb.Empty, error) {
-	return m.remotes.market.TouchOrders(ctx, req)
+	rm, err := m.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return rm.market.TouchOrders(ctx, req)
 }
 
+// GetProcessing returns both currently processed orders and the history
+// of orders processed before.
 func (m *marketAPI) GetProcessing(ctx context.Context, req *pb.Empty) (*pb.GetProcessingReply, error) {
-	m.taskMux.Lock()
-	defer m.taskMux.Unlock()
-
 	reply := &pb.GetProcessingReply{
 		Orders: make(map[string]*pb.GetProcessingReply_ProcessedOrder),
 	}
 
-	for id, task := range m.tasks {
-		var extra string
-		if task.err != nil {
-			extra = fmt.Sprintf("error: %s", task.err.Error())
-		} else if task.dealID != "" {
-			extra = fmt.Sprintf("deal ID: %s", task.dealID)
-		}
+	states, err := m.store.List()
+	if err != nil {
+		log.G(m.ctx).Warn("cannot load orders history", zap.Error(err))
+	}
 
-		reply.Orders[id] = &pb.GetProcessingReply_ProcessedOrder{
-			Id:        id,
-			Status:    uint32(task.status),
-			Timestamp: &pb.Timestamp{Seconds: task.ts.Unix()},
-			Extra:     extra,
+	for _, state := range states {
+		var err error
+		if state.Error != "" {
+			err = errors.New(state.Error)
 		}
+
+		reply.Orders[state.Order.GetId()] = newProcessedOrder(state.Order.GetId(), state.Status, state.Ts, err, slotDealIDs(state.Deals))
+	}
+
+	m.taskMux.Lock()
+	defer m.taskMux.Unlock()
+
+	for id, task := range m.tasks {
+		task.Lock()
+		reply.Orders[id] = newProcessedOrder(id, task.status, task.ts, task.err, slotDealIDs(task.deals))
+		task.Unlock()
 	}
 
 	return reply, nil
 }
 
+func newProcessedOrder(id string, status HandlerStatus, ts time.Time, err error, dealIDs []string) *pb.GetProcessingReply_ProcessedOrder {
+	var extra string
+	if err != nil {
+		extra = fmt.Sprintf("error: %s", err.Error())
+	} else if len(dealIDs) != 0 {
+		extra = fmt.Sprintf("deal ID: %s", strings.Join(dealIDs, ", "))
+	}
+
+	return &pb.GetProcessingReply_ProcessedOrder{
+		Id:        id,
+		Status:    uint32(status),
+		Timestamp: &pb.Timestamp{Seconds: ts.Unix()},
+		Extra:     extra,
+	}
+}
+
 // getMyOrders query Marketplace service for orders
-// with type == BID and that placed with current eth address
-func (m *marketAPI) getMyOrders() (*pb.GetOrdersReply, error) {
+// with type == BID and that placed with given account's eth address
+func (m *marketAPI) getMyOrders(rm *remoteOptions) (*pb.GetOrdersReply, error) {
 	req := &pb.GetOrdersRequest{
 		Order: &pb.Order{
-			ByuerID:   util.PubKeyToAddr(m.remotes.key.PublicKey).Hex(),
+			ByuerID:   util.PubKeyToAddr(rm.key.PublicKey).Hex(),
 			OrderType: pb.OrderType_BID,
 		},
 	}
 
-	return m.remotes.market.GetOrders(m.ctx, req)
+	return rm.market.GetOrders(m.ctx, req)
 }
 
-// restartOrdersProcessing loads BIDs for current account
-// and restarts background processing for that orders
+// restartOrdersProcessing loads BIDs for each account served by the Node
+// and restarts background processing for that orders, resuming them from
+// the persisted state if any.
 func (m *marketAPI) restartOrdersProcessing() func() error {
 	return func() error {
-		orders, err := m.getMyOrders()
+		states, err := m.loadOrderStates()
 		if err != nil {
 			return err
 		}
 
-		log.G(m.ctx).Info("restart order processing",
-			zap.Int("order_count", len(orders.GetOrders())))
+		for _, rm := range m.remotes.allAccounts() {
+			account := util.PubKeyToAddr(rm.key.PublicKey).Hex()
+
+			orders, err := m.getMyOrders(rm)
+			if err != nil {
+				return err
+			}
+
+			log.G(m.ctx).Info("restart order processing",
+				zap.String("eth_addr", account),
+				zap.Int("order_count", len(orders.GetOrders())))
+
+			placed := map[string]bool{}
+			for _, o := range orders.GetOrders() {
+				placed[o.GetId()] = true
+
+				state, ok := states[o.GetId()]
+				if ok && state.Account == account {
+					continue
+				}
+
+				go m.startExecOrderHandler(rm, o)
+			}
 
-		for _, o := range orders.GetOrders() {
-			go m.startExecOrderHandler(o)
+			for _, state := range states {
+				if state.Account != account {
+					continue
+				}
+
+				if !state.Status.finished() {
+					go m.resumeOrderHandler(rm, state, placed[state.Order.GetId()])
+					continue
+				}
+
+				// The order could be left on market if the Node has been
+				// stopped right after the deal.
+				if state.Status == statusDone && placed[state.Order.GetId()] {
+					if _, err := rm.market.CancelOrder(m.ctx, state.Order); err != nil {
+						log.G(m.ctx).Warn("cannot cancel order on market", zap.String("order_id", state.Order.GetId()), zap.Error(err))
+					}
+				}
+			}
 		}
 
 		return nil
 	}
+}
+
+// loadOrderStates returns persisted states of orders, removing the outdated
+// history.
+func (m *marketAPI) loadOrderStates() (map[string]*orderState, error) {
+	states, err := m.store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]*orderState{}
+	for _, state := range states {
+		if state.Status.finished() && time.Since(state.Ts) > orderHistoryTTL {
+			if err := m.store.Remove(state.Order.GetId()); err != nil {
+				log.G(m.ctx).Warn("cannot remove outdated order state", zap.Error(err))
+			}
+			continue
+		}
+
+		result[state.Order.GetId()] = state
+	}
 
+	return result, nil
 }
 
 func newMarketAPI(opts *remoteOptions) (pb.MarketServer, error) {
+	orders, err := newOrderStore(opts.conf.StorePath())
+	if err != nil {
+		return nil, err
+	}
+
 	return &marketAPI{
 		remotes: opts,
 		ctx:     opts.ctx,
+		store:   orders,
 		tasks:   make(map[string]*orderHandler),
 	}, nil
 }
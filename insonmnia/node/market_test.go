@@ -1,12 +1,13 @@
 package node
 
 import (
-	"context"
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/big"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -20,6 +21,8 @@ import (
 	"github.com/sonm-io/core/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/grpclog"
 )
 
@@ -105,14 +108,26 @@ func getTestLocator(ctrl *gomock.Controller) pb.LocatorClient {
 func getTestConfig(ctrl *gomock.Controller) Config {
 	cfg := NewMockConfig(ctrl)
 	cfg.EXPECT().LocatorEndpoint().AnyTimes().Return("127.0.0.1:9090")
+	cfg.EXPECT().LocatorDHT().AnyTimes().Return(nil)
 	cfg.EXPECT().MarketEndpoint().AnyTimes().Return("127.0.0.1:9095")
+	cfg.EXPECT().StorePath().AnyTimes().Return(getTestStorePath())
 	return cfg
 }
 
+func getTestStorePath() string {
+	dir, err := ioutil.TempDir("", "sonm-node-test")
+	if err != nil {
+		panic(err)
+	}
+
+	return filepath.Join(dir, "boltdb")
+}
+
 func getTestHubClient(ctrl *gomock.Controller) (pb.HubClient, io.Closer) {
 	hub := NewMockHubClient(ctrl)
 	hub.EXPECT().ProposeDeal(gomock.Any(), gomock.Any()).AnyTimes().Return(&pb.Empty{}, nil)
 	hub.EXPECT().ApproveDeal(gomock.Any(), gomock.Any()).AnyTimes().Return(&pb.Empty{}, nil)
+	hub.EXPECT().CancelProposal(gomock.Any(), gomock.Any()).AnyTimes().Return(&pb.Empty{}, nil)
 
 	return hub, &mockConn{}
 }
@@ -164,7 +179,7 @@ func TestCreateOrder_FullAsyncOrderHandler(t *testing.T) {
 
 	assert.Equal(t, statusDone, handlr.getStatus(),
 		fmt.Sprintf("Wait for status %s, but has %s", statusDone.String(), handlr.getStatus().String()))
-	assert.Equal(t, "1", handlr.dealID)
+	assert.Equal(t, []string{"1"}, handlr.dealIDs())
 }
 
 func TestCreateOrder_CannotCreateHandler(t *testing.T) {
@@ -369,6 +384,7 @@ func TestCreateOrder_CannotWaitForApprove(t *testing.T) {
 		hub.EXPECT().ProposeDeal(gomock.Any(), gomock.Any()).AnyTimes().Return(&pb.Empty{}, nil)
 		hub.EXPECT().ApproveDeal(gomock.Any(), gomock.Any()).AnyTimes().Return(
 			nil, errors.New("TEST: cannot approve deal"))
+		hub.EXPECT().CancelProposal(gomock.Any(), gomock.Any()).AnyTimes().Return(&pb.Empty{}, nil)
 		return hub, &mockConn{}, nil
 	}
 
@@ -570,6 +586,7 @@ func TestCreateOrder_NotApprovedAndNotCancelled(t *testing.T) {
 		hub.EXPECT().ProposeDeal(gomock.Any(), gomock.Any()).AnyTimes().Return(&pb.Empty{}, nil)
 		hub.EXPECT().ApproveDeal(gomock.Any(), gomock.Any()).AnyTimes().Return(
 			nil, errors.New("TEST: cannot approve deal"))
+		hub.EXPECT().CancelProposal(gomock.Any(), gomock.Any()).AnyTimes().Return(&pb.Empty{}, nil)
 
 		return hub, &mockConn{}, nil
 	}
@@ -705,9 +722,376 @@ func TestDealCreatedOnFirstTryAndOrderIsCancelled(t *testing.T) {
 
 	assert.Equal(t, statusDone, handlr.getStatus(),
 		fmt.Sprintf("Wait for status %s, but has %s", statusDone.String(), handlr.getStatus().String()))
-	assert.Equal(t, "1", handlr.dealID)
+	assert.Equal(t, []string{"1"}, handlr.dealIDs())
 }
 
 type mockConn struct{}
 
 func (c *mockConn) Close() error { return nil }
+
+func TestRestartOrdersProcessing_PendingDealApproved(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := getTestRemotes(ctx, ctrl)
+
+	server, err := newMarketAPI(opts)
+	require.NoError(t, err)
+
+	api := server.(*marketAPI)
+
+	ord := makeOrder()
+	ord.Id = "my-order-id"
+	require.NoError(t, api.store.Save(&orderState{
+		Order:    ord,
+		Account:  util.PubKeyToAddr(opts.key.PublicKey).Hex(),
+		Status:   statusWaitForApprove,
+		Deals:    []*slotDeal{{DealID: "1"}},
+		Attempts: 1,
+	}))
+
+	require.NoError(t, api.restartOrdersProcessing()())
+
+	time.Sleep(50 * time.Millisecond)
+	h, ok := api.getHandler("my-order-id")
+	require.True(t, ok)
+	assert.Equal(t, statusDone, h.getStatus())
+	assert.Equal(t, []string{"1"}, h.dealIDs())
+	assert.Equal(t, uint64(1), h.attempts)
+}
+
+func TestRestartOrdersProcessing_PendingDealClosed(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := getTestRemotes(ctx, ctrl)
+
+	eth := blockchain.NewMockBlockchainer(ctrl)
+	eth.EXPECT().BalanceOf(ctx, gomock.Any()).AnyTimes().
+		Return(big.NewInt(9999999999), nil)
+	eth.EXPECT().AllowanceOf(ctx, gomock.Any(), gomock.Any()).AnyTimes().
+		Return(big.NewInt(9999999999), nil)
+	eth.EXPECT().GetDealInfo(ctx, big.NewInt(1)).Times(1).
+		Return(&pb.Deal{Id: "1", Status: pb.DealStatus_PENDING}, nil)
+	eth.EXPECT().CloseDealPending(gomock.Any(), gomock.Any(), big.NewInt(1), gomock.Any()).Times(1).
+		Return(nil)
+	eth.EXPECT().OpenDealPending(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+		Return(big.NewInt(2), nil)
+	opts.eth = eth
+
+	server, err := newMarketAPI(opts)
+	require.NoError(t, err)
+
+	api := server.(*marketAPI)
+
+	ord := makeOrder()
+	ord.Id = "my-order-id"
+	require.NoError(t, api.store.Save(&orderState{
+		Order:   ord,
+		Account: util.PubKeyToAddr(opts.key.PublicKey).Hex(),
+		Status:  statusWaitForApprove,
+		Deals:   []*slotDeal{{DealID: "1"}},
+	}))
+
+	require.NoError(t, api.restartOrdersProcessing()())
+
+	time.Sleep(100 * time.Millisecond)
+	h, ok := api.getHandler("my-order-id")
+	require.True(t, ok)
+	assert.Equal(t, statusDone, h.getStatus())
+	assert.Equal(t, []string{"2"}, h.dealIDs())
+}
+
+func TestGetProcessing_History(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := getTestRemotes(ctx, ctrl)
+
+	server, err := newMarketAPI(opts)
+	require.NoError(t, err)
+
+	api := server.(*marketAPI)
+
+	ord := makeOrder()
+	ord.Id = "cancelled-order-id"
+	require.NoError(t, api.store.Save(&orderState{
+		Order:   ord,
+		Account: util.PubKeyToAddr(opts.key.PublicKey).Hex(),
+		Status:  statusCancelled,
+		Error:   errOrderNotPlaced.Error(),
+		Ts:      time.Now(),
+	}))
+
+	created, err := api.CreateOrder(ctx, makeOrder())
+	require.NoError(t, err)
+
+	time.Sleep(50 * time.Millisecond)
+
+	reply, err := api.GetProcessing(ctx, &pb.Empty{})
+	require.NoError(t, err)
+	require.Len(t, reply.Orders, 2)
+	assert.Equal(t, uint32(statusCancelled), reply.Orders["cancelled-order-id"].Status)
+	assert.Equal(t, "error: "+errOrderNotPlaced.Error(), reply.Orders["cancelled-order-id"].Extra)
+	assert.Equal(t, uint32(statusDone), reply.Orders[created.Id].Status)
+}
+
+func makeAsk(id, supplierID string) *pb.Order {
+	ask := makeOrder()
+	ask.Id = id
+	ask.OrderType = pb.OrderType_ASK
+	ask.SupplierID = supplierID
+	return ask
+}
+
+func getMultipleSlotsMarket(ctrl *gomock.Controller) pb.MarketClient {
+	bid := makeOrder()
+	bid.Id = "my-order-id"
+	bid.Count = 2
+
+	m := pb.NewMockMarketClient(ctrl)
+	m.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).AnyTimes().
+		Return(bid, nil)
+	m.EXPECT().GetOrders(gomock.Any(), gomock.Any()).AnyTimes().
+		Return(&pb.GetOrdersReply{Orders: []*pb.Order{
+			makeAsk("ask-1", "0x1"),
+			makeAsk("ask-2", "0x1"),
+			makeAsk("ask-3", "0x2"),
+		}}, nil)
+	m.EXPECT().CancelOrder(gomock.Any(), gomock.Any()).AnyTimes().
+		Return(&pb.Empty{}, nil)
+	return m
+}
+
+func TestCreateOrder_MultipleSlots(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := getTestRemotes(ctx, ctrl)
+	opts.market = getMultipleSlotsMarket(ctrl)
+
+	eth := blockchain.NewMockBlockchainer(ctrl)
+	eth.EXPECT().BalanceOf(ctx, gomock.Any()).AnyTimes().
+		Return(big.NewInt(9999999999), nil)
+	eth.EXPECT().AllowanceOf(ctx, gomock.Any(), gomock.Any()).AnyTimes().
+		Return(big.NewInt(9999999999), nil)
+	gomock.InOrder(
+		eth.EXPECT().OpenDealPending(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			Return(big.NewInt(1), nil),
+		eth.EXPECT().OpenDealPending(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			Return(big.NewInt(2), nil),
+	)
+	eth.EXPECT().GetDeals(gomock.Any(), gomock.Any()).Times(1).
+		Return([]*big.Int{big.NewInt(1), big.NewInt(2)}, nil)
+	eth.EXPECT().GetDealInfo(gomock.Any(), big.NewInt(1)).Times(1).
+		Return(&pb.Deal{Id: "1", Status: pb.DealStatus_ACCEPTED}, nil)
+	eth.EXPECT().GetDealInfo(gomock.Any(), big.NewInt(2)).Times(1).
+		Return(&pb.Deal{Id: "2", Status: pb.DealStatus_ACCEPTED}, nil)
+	opts.eth = eth
+
+	server, err := newMarketAPI(opts)
+	require.NoError(t, err)
+
+	api := server.(*marketAPI)
+	order := makeOrder()
+	order.Count = 2
+	created, err := api.CreateOrder(ctx, order)
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+	h, ok := api.getHandler(created.Id)
+	require.True(t, ok)
+	assert.Equal(t, statusDone, h.getStatus())
+	assert.Equal(t, []string{"1", "2"}, h.dealIDs())
+
+	deals, err := newDealsAPI(opts, api.store)
+	require.NoError(t, err)
+
+	reply, err := deals.List(ctx, &pb.DealListRequest{})
+	require.NoError(t, err)
+	require.Len(t, reply.GetDeal(), 2)
+	require.Len(t, reply.GetGroups(), 1)
+	assert.Equal(t, "my-order-id", reply.GetGroups()[0].GetBidID())
+	assert.Equal(t, []string{"1", "2"}, reply.GetGroups()[0].GetDealIDs())
+}
+
+func TestCreateOrder_MultipleSlotsRollback(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := getTestRemotes(ctx, ctrl)
+	opts.market = getMultipleSlotsMarket(ctrl)
+
+	eth := blockchain.NewMockBlockchainer(ctrl)
+	eth.EXPECT().BalanceOf(ctx, gomock.Any()).AnyTimes().
+		Return(big.NewInt(9999999999), nil)
+	eth.EXPECT().AllowanceOf(ctx, gomock.Any(), gomock.Any()).AnyTimes().
+		Return(big.NewInt(9999999999), nil)
+	gomock.InOrder(
+		eth.EXPECT().OpenDealPending(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			Return(big.NewInt(1), nil),
+		eth.EXPECT().OpenDealPending(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			Return(big.NewInt(2), nil),
+	)
+	eth.EXPECT().CloseDealPending(gomock.Any(), gomock.Any(), big.NewInt(1), gomock.Any()).Times(1).
+		Return(nil)
+	eth.EXPECT().CloseDealPending(gomock.Any(), gomock.Any(), big.NewInt(2), gomock.Any()).Times(1).
+		Return(nil)
+	opts.eth = eth
+
+	// The second Hub fails to approve the deal.
+	hubs := 0
+	opts.hubCreator = func(addr string) (pb.HubClient, io.Closer, error) {
+		hubs++
+
+		hub := NewMockHubClient(ctrl)
+		hub.EXPECT().ProposeDeal(gomock.Any(), gomock.Any()).AnyTimes().Return(&pb.Empty{}, nil)
+		if hubs == 1 {
+			hub.EXPECT().ApproveDeal(gomock.Any(), gomock.Any()).AnyTimes().Return(&pb.Empty{}, nil)
+		} else {
+			hub.EXPECT().ApproveDeal(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, errors.New("TEST: cannot approve deal"))
+			// Only the proposal left unapproved is dropped.
+			hub.EXPECT().CancelProposal(gomock.Any(), gomock.Any()).Times(1).Return(&pb.Empty{}, nil)
+		}
+
+		return hub, &mockConn{}, nil
+	}
+
+	server, err := newMarketAPI(opts)
+	require.NoError(t, err)
+
+	api := server.(*marketAPI)
+	order := makeOrder()
+	order.Count = 2
+	created, err := api.CreateOrder(ctx, order)
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+	h, ok := api.getHandler(created.Id)
+	require.True(t, ok)
+	h.cancel()
+
+	assert.Equal(t, statusFailed, h.getStatus())
+	assert.Empty(t, h.dealIDs())
+	assert.EqualError(t, h.err, "deal is not approved on the hub")
+}
+
+// supplierLocator resolves suppliers to endpoints named after them.
+type supplierLocator struct {
+	pb.LocatorClient
+}
+
+func (supplierLocator) Resolve(ctx context.Context, in *pb.ResolveRequest, opts ...grpc.CallOption) (*pb.ResolveReply, error) {
+	return &pb.ResolveReply{Endpoints: []string{in.GetEthAddr()}}, nil
+}
+
+// reservingHub reserves resources for proposed BIDs like Hubs do, rejecting
+// proposals while it is unavailable.
+type reservingHub struct {
+	pb.HubClient
+	available bool
+	reserved  map[string]bool
+}
+
+func (h *reservingHub) ProposeDeal(ctx context.Context, in *pb.DealRequest, opts ...grpc.CallOption) (*pb.Empty, error) {
+	if !h.available {
+		return nil, errors.New("TEST: not enough resources")
+	}
+	if h.reserved[in.GetBidId()] {
+		return nil, errors.New("TEST: order already exists")
+	}
+
+	h.reserved[in.GetBidId()] = true
+	return &pb.Empty{}, nil
+}
+
+func (h *reservingHub) CancelProposal(ctx context.Context, in *pb.DealRequest, opts ...grpc.CallOption) (*pb.Empty, error) {
+	delete(h.reserved, in.GetBidId())
+	return &pb.Empty{}, nil
+}
+
+func (h *reservingHub) ApproveDeal(ctx context.Context, in *pb.ApproveDealRequest, opts ...grpc.CallOption) (*pb.Empty, error) {
+	delete(h.reserved, in.GetBidID())
+	return &pb.Empty{}, nil
+}
+
+func TestExecuteOrder_RetriesAfterProposalsNotAccepted(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := getTestRemotes(ctx, ctrl)
+	opts.market = getMultipleSlotsMarket(ctrl)
+	opts.locator = supplierLocator{}
+
+	hubs := map[string]*reservingHub{
+		"0x1": {available: true, reserved: map[string]bool{}},
+		"0x2": {available: false, reserved: map[string]bool{}},
+	}
+	opts.hubCreator = func(addr string) (pb.HubClient, io.Closer, error) {
+		return hubs[addr], &mockConn{}, nil
+	}
+
+	eth := blockchain.NewMockBlockchainer(ctrl)
+	eth.EXPECT().BalanceOf(ctx, gomock.Any()).AnyTimes().
+		Return(big.NewInt(9999999999), nil)
+	eth.EXPECT().AllowanceOf(ctx, gomock.Any(), gomock.Any()).AnyTimes().
+		Return(big.NewInt(9999999999), nil)
+	gomock.InOrder(
+		eth.EXPECT().OpenDealPending(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			Return(big.NewInt(1), nil),
+		eth.EXPECT().OpenDealPending(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			Return(big.NewInt(2), nil),
+	)
+	opts.eth = eth
+
+	server, err := newMarketAPI(opts)
+	require.NoError(t, err)
+	api := server.(*marketAPI)
+
+	order := makeOrder()
+	order.Id = "my-order-id"
+	order.Count = 2
+	handler, err := api.newOrderHandler(opts, order)
+	require.NoError(t, err)
+
+	// Only one of two Hubs accepts the proposal, which is dropped then.
+	assert.Equal(t, errProposeNotAccepted, api.executeOrder(opts, handler))
+	assert.Empty(t, hubs["0x1"].reserved)
+
+	hubs["0x2"].available = true
+	require.NoError(t, api.executeOrder(opts, handler))
+	assert.Equal(t, statusDone, handler.getStatus())
+	assert.Equal(t, []string{"1", "2"}, handler.dealIDs())
+}
+
+func TestConnectHubSkipsUnreachableEndpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	loc := pb.NewMockLocatorClient(ctrl)
+	loc.EXPECT().Resolve(gomock.Any(), gomock.Any()).
+		Return(&pb.ResolveReply{Endpoints: []string{"127.0.0.1:10001", "127.0.0.1:10002"}}, nil)
+
+	var dialed []string
+	creator := func(addr string) (pb.HubClient, io.Closer, error) {
+		dialed = append(dialed, addr)
+		if addr == "127.0.0.1:10001" {
+			return nil, nil, errors.New("TEST: connection refused")
+		}
+
+		hub, cc := getTestHubClient(ctrl)
+		return hub, cc, nil
+	}
+
+	hub, cc, err := connectHub(context.Background(), loc, creator, "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	require.NoError(t, err)
+	assert.NotNil(t, hub)
+	assert.NotNil(t, cc)
+	assert.Equal(t, []string{"127.0.0.1:10001", "127.0.0.1:10002"}, dialed)
+}
@@ -0,0 +1,151 @@
+package node
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/libkv"
+	"github.com/docker/libkv/store"
+	"github.com/docker/libkv/store/boltdb"
+	pb "github.com/sonm-io/core/proto"
+)
+
+const (
+	orderStateKeyPrefix = "order/"
+	autoRenewKeyPrefix  = "autorenew/"
+)
+
+// orderState is a persisted snapshot of the order handler, allowing to
+// resume orders processing after the Node restarts.
+type orderState struct {
+	Order *pb.Order `json:"order"`
+	// Account is Eth address of the account the order is processed on
+	// behalf of.
+	Account string        `json:"account"`
+	Status  HandlerStatus `json:"status"`
+	// Deals are the deals opened for the order slots. Until the status is
+	// "Done" the deals are not approved yet and must be closed if the
+	// processing fails.
+	Deals    []*slotDeal `json:"deals,omitempty"`
+	Error    string      `json:"error,omitempty"`
+	Attempts uint64      `json:"attempts"`
+	Ts       time.Time   `json:"ts"`
+}
+
+// slotDeal is a deal opened for one of the slots ordered by the BID.
+type slotDeal struct {
+	// Ask is the ASK order the deal has been proposed for.
+	Ask    *pb.Order `json:"ask"`
+	DealID string    `json:"deal_id"`
+}
+
+// autoRenewState marks the deal for automatic renewal, allowing to continue
+// renewing it after the Node restarts.
+type autoRenewState struct {
+	DealID string `json:"deal_id"`
+	// Account is Eth address of the account acting as the deal buyer.
+	Account string `json:"account"`
+}
+
+// orderStore keeps order handlers state in the local storage.
+type orderStore struct {
+	storage store.Store
+}
+
+func newOrderStore(path string) (*orderStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	boltdb.Register()
+
+	storage, err := libkv.NewStore(store.BOLTDB, []string{path}, &store.Config{Bucket: "sonm_node_orders"})
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderStore{storage: storage}, nil
+}
+
+func (s *orderStore) Save(state *orderState) error {
+	value, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return s.storage.Put(orderStateKeyPrefix+state.Order.GetId(), value, nil)
+}
+
+func (s *orderStore) Remove(id string) error {
+	err := s.storage.Delete(orderStateKeyPrefix + id)
+	if err == store.ErrKeyNotFound {
+		return nil
+	}
+
+	return err
+}
+
+func (s *orderStore) List() ([]*orderState, error) {
+	pairs, err := s.storage.List(orderStateKeyPrefix)
+	if err == store.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]*orderState, 0, len(pairs))
+	for _, pair := range pairs {
+		state := &orderState{}
+		// Malformed states are skipped to not block resuming the others.
+		if err := json.Unmarshal(pair.Value, state); err != nil || state.Order == nil {
+			continue
+		}
+
+		states = append(states, state)
+	}
+
+	return states, nil
+}
+
+func (s *orderStore) SaveAutoRenew(state *autoRenewState) error {
+	value, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return s.storage.Put(autoRenewKeyPrefix+state.DealID, value, nil)
+}
+
+func (s *orderStore) RemoveAutoRenew(dealID string) error {
+	err := s.storage.Delete(autoRenewKeyPrefix + dealID)
+	if err == store.ErrKeyNotFound {
+		return nil
+	}
+
+	return err
+}
+
+func (s *orderStore) ListAutoRenew() ([]*autoRenewState, error) {
+	pairs, err := s.storage.List(autoRenewKeyPrefix)
+	if err == store.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]*autoRenewState, 0, len(pairs))
+	for _, pair := range pairs {
+		state := &autoRenewState{}
+		if err := json.Unmarshal(pair.Value, state); err != nil || state.DealID == "" {
+			continue
+		}
+
+		states = append(states, state)
+	}
+
+	return states, nil
+}
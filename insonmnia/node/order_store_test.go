@@ -0,0 +1,40 @@
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderStoreAutoRenew(t *testing.T) {
+	dir, err := ioutil.TempDir("", "node_orders")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := newOrderStore(filepath.Join(dir, "orders.boltdb"))
+	require.NoError(t, err)
+
+	states, err := s.ListAutoRenew()
+	require.NoError(t, err)
+	assert.Empty(t, states)
+
+	require.NoError(t, s.SaveAutoRenew(&autoRenewState{DealID: "1", Account: "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD"}))
+	require.NoError(t, s.SaveAutoRenew(&autoRenewState{DealID: "2", Account: "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD"}))
+	require.NoError(t, s.RemoveAutoRenew("1"))
+	require.NoError(t, s.RemoveAutoRenew("3"))
+
+	states, err = s.ListAutoRenew()
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, "2", states[0].DealID)
+	assert.Equal(t, "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD", states[0].Account)
+
+	// Orders are kept separately.
+	orders, err := s.List()
+	require.NoError(t, err)
+	assert.Empty(t, orders)
+}
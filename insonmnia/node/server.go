@@ -8,9 +8,11 @@ import (
 	"net"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/grpc-ecosystem/go-grpc-prometheus"
 	log "github.com/noxiouz/zapctx/ctxlog"
 	"github.com/sonm-io/core/blockchain"
+	"github.com/sonm-io/core/insonmnia/locator/dht"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
 	"github.com/sonm-io/core/util/xgrpc"
@@ -21,6 +23,9 @@ import (
 	"google.golang.org/grpc/credentials"
 )
 
+// hubDialTimeout limits connecting to each of the resolved Hub endpoints.
+const hubDialTimeout = 10 * time.Second
+
 type hubClientCreator func(addr string) (pb.HubClient, io.Closer, error)
 
 // remoteOptions describe options related to remove gRPC services
@@ -30,11 +35,16 @@ type remoteOptions struct {
 	conf               Config
 	creds              credentials.TransportCredentials
 	locator            pb.LocatorClient
+	rating             pb.RatingClient
 	market             pb.MarketClient
 	eth                blockchain.Blockchainer
 	hubCreator         hubClientCreator
+	dht                *dht.Node
 	dealApproveTimeout time.Duration
 	dealCreateTimeout  time.Duration
+	// accounts keeps remote options bound to additional accounts
+	// served by the Node, keyed by their Eth address.
+	accounts map[common.Address]*remoteOptions
 }
 
 func newRemoteOptions(ctx context.Context, key *ecdsa.PrivateKey, conf Config, creds credentials.TransportCredentials) (*remoteOptions, error) {
@@ -53,13 +63,18 @@ func newRemoteOptions(ctx context.Context, key *ecdsa.PrivateKey, conf Config, c
 		return nil, err
 	}
 
-	hc := func(addr string) (pb.HubClient, io.Closer, error) {
-		cc, err := xgrpc.NewClient(ctx, addr, creds)
+	locator := pb.NewLocatorClient(locatorCC)
+
+	var dhtNode *dht.Node
+	if cfg := conf.LocatorDHT(); cfg != nil {
+		dhtNode, err = dht.NewNode(ctx, *cfg, key, creds)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
-		return pb.NewHubClient(cc), cc, nil
+		go dhtNode.Serve()
+
+		locator = dht.NewLocatorClient(dhtNode)
 	}
 
 	return &remoteOptions{
@@ -67,15 +82,60 @@ func newRemoteOptions(ctx context.Context, key *ecdsa.PrivateKey, conf Config, c
 		conf:               conf,
 		ctx:                ctx,
 		creds:              creds,
-		locator:            pb.NewLocatorClient(locatorCC),
+		locator:            locator,
+		rating:             pb.NewRatingClient(locatorCC),
 		market:             pb.NewMarketClient(marketCC),
 		eth:                bcAPI,
 		dealApproveTimeout: 900 * time.Second,
 		dealCreateTimeout:  180 * time.Second,
-		hubCreator:         hc,
+		hubCreator:         newHubClientCreator(ctx, creds),
+		dht:                dhtNode,
+		accounts:           make(map[common.Address]*remoteOptions),
 	}, nil
 }
 
+// newHubClientCreator returns a creator connecting to the Hub eagerly, so
+// unreachable endpoints are detected before the first call.
+func newHubClientCreator(ctx context.Context, creds credentials.TransportCredentials) hubClientCreator {
+	return func(addr string) (pb.HubClient, io.Closer, error) {
+		ctx, cancel := context.WithTimeout(ctx, hubDialTimeout)
+		defer cancel()
+
+		cc, err := xgrpc.NewClient(ctx, addr, creds, grpc.WithBlock())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return pb.NewHubClient(cc), cc, nil
+	}
+}
+
+// connectHub resolves the Hub endpoints via the Locator and connects to the
+// first reachable one. Endpoints are resolved for each connection, which
+// follows the Hub when it moves.
+func connectHub(ctx context.Context, locator pb.LocatorClient, creator hubClientCreator, ethAddr string) (pb.HubClient, io.Closer, error) {
+	reply, err := locator.Resolve(ctx, &pb.ResolveRequest{EthAddr: ethAddr})
+	if err != nil {
+		log.G(ctx).Info("cannot resolve Hub endpoints", zap.Error(err))
+		return nil, nil, err
+	}
+
+	err = errors.New("no hub endpoints resolved")
+	for _, endpoint := range reply.GetEndpoints() {
+		hub, cc, dialErr := creator(endpoint)
+		if dialErr != nil {
+			log.G(ctx).Info("cannot connect to the Hub", zap.String("endpoint", endpoint), zap.Error(dialErr))
+			err = dialErr
+			continue
+		}
+
+		log.G(ctx).Info("hub connection built", zap.String("endpoint", endpoint))
+		return hub, cc, nil
+	}
+
+	return nil, nil, err
+}
+
 // Node is LocalNode instance
 type Node struct {
 	lis4, lis6 net.Listener
@@ -83,6 +143,7 @@ type Node struct {
 	srv        *grpc.Server
 	ctx        context.Context
 	privKey    *ecdsa.PrivateKey
+	dht        *dht.Node
 	// processorRestarter must start together with node .Serve (not .New).
 	// This func must fetch orders from the Market and restart it background processing.
 	processorRestarter func() error
@@ -90,8 +151,11 @@ type Node struct {
 
 // New creates new Local Node instance
 // also method starts internal gRPC client connections
-// to the external services like Market and Hub
-func New(ctx context.Context, c Config, key *ecdsa.PrivateKey) (*Node, error) {
+// to the external services like Market and Hub.
+//
+// The Node acts on behalf of the account owning the given key unless
+// the client selects one of the extra accounts via gRPC metadata.
+func New(ctx context.Context, c Config, key *ecdsa.PrivateKey, extraKeys ...*ecdsa.PrivateKey) (*Node, error) {
 	_, TLSConfig, err := util.NewHitlessCertRotator(ctx, key)
 	if err != nil {
 		return nil, err
@@ -103,6 +167,14 @@ func New(ctx context.Context, c Config, key *ecdsa.PrivateKey) (*Node, error) {
 		return nil, err
 	}
 
+	for _, extraKey := range extraKeys {
+		if err := opts.addAccount(extraKey); err != nil {
+			return nil, err
+		}
+
+		log.G(ctx).Info("account unlocked", zap.String("eth_addr", util.PubKeyToAddr(extraKey.PublicKey).Hex()))
+	}
+
 	hub := newHubAPI(opts)
 
 	market, err := newMarketAPI(opts)
@@ -110,7 +182,7 @@ func New(ctx context.Context, c Config, key *ecdsa.PrivateKey) (*Node, error) {
 		return nil, err
 	}
 
-	deals, err := newDealsAPI(opts)
+	deals, err := newDealsAPI(opts, market.(*marketAPI).store)
 	if err != nil {
 		return nil, err
 	}
@@ -120,6 +192,11 @@ func New(ctx context.Context, c Config, key *ecdsa.PrivateKey) (*Node, error) {
 		return nil, err
 	}
 
+	analytics, err := newAnalyticsAPI(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	logger := log.GetLogger(ctx)
 	srv := xgrpc.NewServer(
 		logger,
@@ -140,12 +217,16 @@ func New(ctx context.Context, c Config, key *ecdsa.PrivateKey) (*Node, error) {
 	pb.RegisterTaskManagementServer(srv, tasks)
 	log.G(ctx).Info("tasks service registered")
 
+	pb.RegisterMarketAnalyticsServer(srv, analytics)
+	log.G(ctx).Info("market analytics service registered")
+
 	grpc_prometheus.Register(srv)
 
 	return &Node{
 		cfg:                c,
 		ctx:                ctx,
 		srv:                srv,
+		dht:                opts.dht,
 		processorRestarter: market.(*marketAPI).restartOrdersProcessing(),
 	}, nil
 }
@@ -219,4 +300,8 @@ func (n *Node) Close() {
 			log.G(n.ctx).Warn("cannot close ipv4 listener", zap.Error(err))
 		}
 	}
+
+	if n.dht != nil {
+		n.dht.Close()
+	}
 }
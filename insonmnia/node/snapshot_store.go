@@ -0,0 +1,301 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/docker/libkv"
+	"github.com/docker/libkv/store"
+	"github.com/docker/libkv/store/boltdb"
+	pb "github.com/sonm-io/core/proto"
+)
+
+const (
+	snapshotKeyPrefix = "snapshot/"
+	// snapshotHoursKey keeps the list of hours having snapshots, allowing
+	// to scan only the requested time range.
+	snapshotHoursKey   = "hours"
+	snapshotHourLayout = "2006010215"
+)
+
+// resourceClass groups orders having the same resources.
+type resourceClass struct {
+	CpuCores uint64      `json:"cpu_cores"`
+	RamBytes uint64      `json:"ram_bytes"`
+	GpuCount pb.GPUCount `json:"gpu_count"`
+}
+
+func newResourceClass(r *pb.Resources) resourceClass {
+	return resourceClass{
+		CpuCores: r.GetCpuCores(),
+		RamBytes: r.GetRamBytes(),
+		GpuCount: r.GetGpuCount(),
+	}
+}
+
+// fits reports whether the class provides at least the given resources.
+func (c resourceClass) fits(r *pb.Resources) bool {
+	return c.CpuCores >= r.GetCpuCores() && c.RamBytes >= r.GetRamBytes() && c.GpuCount >= r.GetGpuCount()
+}
+
+func (c resourceClass) less(other resourceClass) bool {
+	if c.CpuCores != other.CpuCores {
+		return c.CpuCores < other.CpuCores
+	}
+	if c.RamBytes != other.RamBytes {
+		return c.RamBytes < other.RamBytes
+	}
+	return c.GpuCount < other.GpuCount
+}
+
+func (c resourceClass) Unwrap() *pb.ResourceClass {
+	return &pb.ResourceClass{
+		CpuCores: c.CpuCores,
+		RamBytes: c.RamBytes,
+		GpuCount: c.GpuCount,
+	}
+}
+
+// orderSample is an order observed on Marketplace reduced to dimensions
+// the market statistics are computed by.
+type orderSample struct {
+	Type pb.OrderType `json:"type"`
+	// Owner is the supplier of ASK or the buyer of BID order.
+	Owner string        `json:"owner"`
+	Class resourceClass `json:"class"`
+	Price *pb.BigInt    `json:"price"`
+}
+
+func newOrderSample(o *pb.Order) *orderSample {
+	owner := o.GetSupplierID()
+	if o.GetOrderType() == pb.OrderType_BID {
+		owner = o.GetByuerID()
+	}
+
+	return &orderSample{
+		Type:  o.GetOrderType(),
+		Owner: owner,
+		Class: newResourceClass(o.GetSlot().GetResources()),
+		Price: o.GetPricePerSecond(),
+	}
+}
+
+// ordersSnapshot contains Marketplace orders observed at once.
+type ordersSnapshot struct {
+	Ts      time.Time      `json:"ts"`
+	Samples []*orderSample `json:"samples"`
+}
+
+// snapshotStore keeps orders snapshots in the local storage grouped by
+// hours.
+type snapshotStore struct {
+	mu      sync.Mutex
+	storage store.Store
+}
+
+func newSnapshotStore(path string) (*snapshotStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	boltdb.Register()
+
+	config := &store.Config{
+		Bucket:            "sonm_node_snapshots",
+		PersistConnection: true,
+	}
+
+	storage, err := libkv.NewStore(store.BOLTDB, []string{path}, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshotStore{storage: storage}, nil
+}
+
+func snapshotHour(ts time.Time) string {
+	return ts.UTC().Format(snapshotHourLayout)
+}
+
+func snapshotHourPrefix(hour string) string {
+	return snapshotKeyPrefix + hour + "/"
+}
+
+func (s *snapshotStore) Save(snapshot *ordersSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	hour := snapshotHour(snapshot.Ts)
+	key := fmt.Sprintf("%s%020d", snapshotHourPrefix(hour), snapshot.Ts.UnixNano())
+	if err := s.storage.Put(key, value, nil); err != nil {
+		return err
+	}
+
+	hours, err := s.hours()
+	if err != nil {
+		return err
+	}
+
+	idx := sort.SearchStrings(hours, hour)
+	if idx < len(hours) && hours[idx] == hour {
+		return nil
+	}
+
+	hours = append(hours, "")
+	copy(hours[idx+1:], hours[idx:])
+	hours[idx] = hour
+
+	return s.putHours(hours)
+}
+
+// Range returns snapshots taken within [from, to) ordered by time.
+func (s *snapshotStore) Range(from, to time.Time) ([]*ordersSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hours, err := s.hours()
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []*ordersSnapshot
+	for _, hour := range hours {
+		if hour < snapshotHour(from) || hour > snapshotHour(to) {
+			continue
+		}
+
+		loaded, err := s.load(hour)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, snapshot := range loaded {
+			if !snapshot.Ts.Before(from) && snapshot.Ts.Before(to) {
+				snapshots = append(snapshots, snapshot)
+			}
+		}
+	}
+
+	return snapshots, nil
+}
+
+// Last returns the latest snapshot taken before the given time, nil if
+// there is no such.
+func (s *snapshotStore) Last(before time.Time) (*ordersSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hours, err := s.hours()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(hours) - 1; i >= 0; i-- {
+		if hours[i] > snapshotHour(before) {
+			continue
+		}
+
+		loaded, err := s.load(hours[i])
+		if err != nil {
+			return nil, err
+		}
+
+		for j := len(loaded) - 1; j >= 0; j-- {
+			if loaded[j].Ts.Before(before) {
+				return loaded[j], nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// Cleanup removes snapshots of hours passed before the given time.
+func (s *snapshotStore) Cleanup(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hours, err := s.hours()
+	if err != nil {
+		return err
+	}
+
+	idx := sort.SearchStrings(hours, snapshotHour(before))
+	if idx == 0 {
+		return nil
+	}
+
+	for _, hour := range hours[:idx] {
+		pairs, err := s.storage.List(snapshotHourPrefix(hour))
+		if err != nil && err != store.ErrKeyNotFound {
+			return err
+		}
+
+		for _, pair := range pairs {
+			if err := s.storage.Delete(pair.Key); err != nil && err != store.ErrKeyNotFound {
+				return err
+			}
+		}
+	}
+
+	return s.putHours(hours[idx:])
+}
+
+func (s *snapshotStore) load(hour string) ([]*ordersSnapshot, error) {
+	pairs, err := s.storage.List(snapshotHourPrefix(hour))
+	if err == store.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*ordersSnapshot, 0, len(pairs))
+	for _, pair := range pairs {
+		snapshot := &ordersSnapshot{}
+		// Malformed snapshots are skipped to not break the whole history.
+		if err := json.Unmarshal(pair.Value, snapshot); err != nil {
+			continue
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+func (s *snapshotStore) hours() ([]string, error) {
+	pair, err := s.storage.Get(snapshotHoursKey)
+	if err == store.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var hours []string
+	if err := json.Unmarshal(pair.Value, &hours); err != nil {
+		return nil, err
+	}
+
+	return hours, nil
+}
+
+func (s *snapshotStore) putHours(hours []string) error {
+	value, err := json.Marshal(hours)
+	if err != nil {
+		return err
+	}
+
+	return s.storage.Put(snapshotHoursKey, value, nil)
+}
@@ -8,7 +8,6 @@ import (
 	log "github.com/noxiouz/zapctx/ctxlog"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
-	"github.com/sonm-io/core/util/xgrpc"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
@@ -22,10 +21,15 @@ type tasksAPI struct {
 }
 
 func (t *tasksAPI) List(ctx context.Context, req *pb.TaskListRequest) (*pb.TaskListReply, error) {
+	rm, err := t.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// has hubID, can perform direct request
 	if req.GetHubID() != "" {
 		log.G(t.ctx).Info("has HubAddr, performing direct request")
-		hubClient, cc, err := getHubClientByEthAddr(ctx, t.remotes, req.GetHubID())
+		hubClient, cc, err := getHubClientByEthAddr(ctx, rm, req.GetHubID())
 		if err != nil {
 			return nil, err
 		}
@@ -34,7 +38,7 @@ func (t *tasksAPI) List(ctx context.Context, req *pb.TaskListRequest) (*pb.TaskL
 		return hubClient.TaskList(ctx, &pb.Empty{})
 	}
 
-	clientAddr := util.PubKeyToAddr(t.remotes.key.PublicKey)
+	clientAddr := util.PubKeyToAddr(rm.key.PublicKey)
 	// get all accepted deals, because only on the accepted deals client can start the payloads.
 	dealIDs, err := t.remotes.eth.GetAcceptedDeal(ctx, "", clientAddr.Hex())
 	if err != nil {
@@ -60,14 +64,14 @@ func (t *tasksAPI) List(ctx context.Context, req *pb.TaskListRequest) (*pb.TaskL
 
 	tasks := make(map[string]*pb.TaskListReply_TaskInfo)
 	for _, deal := range activeDeals {
-		t.getSupplierTasks(ctx, tasks, deal)
+		t.getSupplierTasks(ctx, rm, tasks, deal)
 	}
 
 	return &pb.TaskListReply{Info: tasks}, nil
 }
 
-func (t *tasksAPI) getSupplierTasks(ctx context.Context, tasks map[string]*pb.TaskListReply_TaskInfo, deal *pb.Deal) {
-	hub, cc, err := getHubClientByEthAddr(ctx, t.remotes, deal.GetSupplierID())
+func (t *tasksAPI) getSupplierTasks(ctx context.Context, rm *remoteOptions, tasks map[string]*pb.TaskListReply_TaskInfo, deal *pb.Deal) {
+	hub, cc, err := getHubClientByEthAddr(ctx, rm, deal.GetSupplierID())
 	if err != nil {
 		log.G(t.ctx).Error("cannot resolve hub address",
 			zap.String("hub_eth", deal.GetSupplierID()),
@@ -88,7 +92,12 @@ func (t *tasksAPI) getSupplierTasks(ctx context.Context, tasks map[string]*pb.Ta
 }
 
 func (t *tasksAPI) Start(ctx context.Context, req *pb.HubStartTaskRequest) (*pb.HubStartTaskReply, error) {
-	hub, cc, err := getHubClientForDeal(ctx, t.remotes, req.Deal.GetId())
+	rm, err := t.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hub, cc, err := getHubClientForDeal(ctx, rm, req.Deal.GetId())
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +112,12 @@ func (t *tasksAPI) Start(ctx context.Context, req *pb.HubStartTaskRequest) (*pb.
 }
 
 func (t *tasksAPI) JoinNetwork(ctx context.Context, request *pb.JoinNetworkRequest) (*pb.NetworkSpec, error) {
-	hub, cc, err := getHubClientByEthAddr(ctx, t.remotes, request.TaskID.HubAddr)
+	rm, err := t.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hub, cc, err := getHubClientByEthAddr(ctx, rm, request.TaskID.HubAddr)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +135,12 @@ func (t *tasksAPI) JoinNetwork(ctx context.Context, request *pb.JoinNetworkReque
 }
 
 func (t *tasksAPI) Status(ctx context.Context, id *pb.TaskID) (*pb.TaskStatusReply, error) {
-	hubClient, cc, err := getHubClientByEthAddr(ctx, t.remotes, id.HubAddr)
+	rm, err := t.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hubClient, cc, err := getHubClientByEthAddr(ctx, rm, id.HubAddr)
 	if err != nil {
 		return nil, err
 	}
@@ -133,7 +152,12 @@ func (t *tasksAPI) Status(ctx context.Context, id *pb.TaskID) (*pb.TaskStatusRep
 func (t *tasksAPI) Logs(req *pb.TaskLogsRequest, srv pb.TaskManagement_LogsServer) error {
 	log.G(t.ctx).Info("handling Logs request", zap.Any("request", req))
 
-	hubClient, cc, err := getHubClientByEthAddr(srv.Context(), t.remotes, req.HubAddr)
+	rm, err := t.remotes.account(srv.Context())
+	if err != nil {
+		return err
+	}
+
+	hubClient, cc, err := getHubClientByEthAddr(srv.Context(), rm, req.HubAddr)
 	if err != nil {
 		return err
 	}
@@ -162,7 +186,12 @@ func (t *tasksAPI) Logs(req *pb.TaskLogsRequest, srv pb.TaskManagement_LogsServe
 }
 
 func (t *tasksAPI) Stop(ctx context.Context, id *pb.TaskID) (*pb.Empty, error) {
-	hubClient, cc, err := getHubClientByEthAddr(ctx, t.remotes, id.HubAddr)
+	rm, err := t.remotes.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hubClient, cc, err := getHubClientByEthAddr(ctx, rm, id.HubAddr)
 	if err != nil {
 		return nil, err
 	}
@@ -179,7 +208,12 @@ func (t *tasksAPI) PushTask(clientStream pb.TaskManagement_PushTaskServer) error
 
 	log.G(t.ctx).Info("handling PushTask request", zap.String("deal_id", meta.dealID))
 
-	hub, cc, err := getHubClientForDeal(meta.ctx, t.remotes, meta.dealID)
+	rm, err := t.remotes.account(clientStream.Context())
+	if err != nil {
+		return err
+	}
+
+	hub, cc, err := getHubClientForDeal(meta.ctx, rm, meta.dealID)
 	if err != nil {
 		return err
 	}
@@ -256,8 +290,13 @@ func (t *tasksAPI) PushTask(clientStream pb.TaskManagement_PushTaskServer) error
 }
 
 func (t *tasksAPI) PullTask(req *pb.PullTaskRequest, srv pb.TaskManagement_PullTaskServer) error {
+	rm, err := t.remotes.account(srv.Context())
+	if err != nil {
+		return err
+	}
+
 	ctx := context.Background()
-	hub, cc, err := getHubClientForDeal(ctx, t.remotes, req.GetDealId())
+	hub, cc, err := getHubClientForDeal(ctx, rm, req.GetDealId())
 	if err != nil {
 		return err
 	}
@@ -297,34 +336,247 @@ func (t *tasksAPI) PullTask(req *pb.PullTaskRequest, srv pb.TaskManagement_PullT
 	}
 }
 
-func getHubClientForDeal(ctx context.Context, rm *remoteOptions, id string) (pb.HubClient, io.Closer, error) {
-	bigID, err := util.ParseBigInt(id)
+func (t *tasksAPI) Exec(clientStream pb.TaskManagement_ExecServer) error {
+	request, err := clientStream.Recv()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	dealInfo, err := rm.eth.GetDealInfo(ctx, bigID)
+	log.G(t.ctx).Info("handling Exec request", zap.String("id", request.Id), zap.Strings("cmd", request.Cmd))
+
+	rm, err := t.remotes.account(clientStream.Context())
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	return getHubClientByEthAddr(ctx, rm, dealInfo.GetSupplierID())
+	hub, cc, err := getHubClientByEthAddr(clientStream.Context(), rm, request.HubAddr)
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	hubStream, err := hub.Exec(clientStream.Context())
+	if err != nil {
+		return err
+	}
+
+	if err := hubStream.Send(request); err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			request, err := clientStream.Recv()
+			if err != nil {
+				hubStream.CloseSend()
+				return
+			}
+
+			if err := hubStream.Send(request); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		reply, err := hubStream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := clientStream.Send(reply); err != nil {
+			return err
+		}
+	}
 }
 
-func getHubClientByEthAddr(ctx context.Context, rm *remoteOptions, eth string) (pb.HubClient, io.Closer, error) {
-	resolve := &pb.ResolveRequest{EthAddr: eth}
-	addrReply, err := rm.locator.Resolve(ctx, resolve)
+func (t *tasksAPI) PortForward(clientStream pb.TaskManagement_PortForwardServer) error {
+	md, ok := metadata.FromIncomingContext(clientStream.Context())
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "metadata required")
+	}
+
+	for _, key := range []string{"hub", "task", "port"} {
+		if len(md[key]) == 0 {
+			return status.Errorf(codes.InvalidArgument, "`%s` required", key)
+		}
+	}
+
+	log.G(t.ctx).Info("handling PortForward request",
+		zap.String("id", md["task"][0]), zap.String("port", md["port"][0]))
+
+	rm, err := t.remotes.account(clientStream.Context())
+	if err != nil {
+		return err
+	}
+
+	hub, cc, err := getHubClientByEthAddr(clientStream.Context(), rm, md["hub"][0])
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	ctx := metadata.NewOutgoingContext(clientStream.Context(), metadata.New(map[string]string{
+		"task": md["task"][0],
+		"port": md["port"][0],
+	}))
+
+	hubStream, err := hub.PortForward(ctx)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			chunk, err := clientStream.Recv()
+			if err != nil {
+				hubStream.CloseSend()
+				return
+			}
+
+			if err := hubStream.Send(chunk); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		chunk, err := hubStream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := clientStream.Send(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+func (t *tasksAPI) CopyTo(clientStream pb.TaskManagement_CopyToServer) error {
+	md, ok := metadata.FromIncomingContext(clientStream.Context())
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "metadata required")
+	}
+
+	for _, key := range []string{"hub", "task", "path"} {
+		if len(md[key]) == 0 {
+			return status.Errorf(codes.InvalidArgument, "`%s` required", key)
+		}
+	}
+
+	log.G(t.ctx).Info("handling CopyTo request",
+		zap.String("id", md["task"][0]), zap.String("path", md["path"][0]))
+
+	rm, err := t.remotes.account(clientStream.Context())
+	if err != nil {
+		return err
+	}
+
+	hub, cc, err := getHubClientByEthAddr(clientStream.Context(), rm, md["hub"][0])
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	ctx := metadata.NewOutgoingContext(clientStream.Context(), metadata.New(map[string]string{
+		"task": md["task"][0],
+		"path": md["path"][0],
+	}))
+
+	hubStream, err := hub.CopyTo(ctx)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			chunk, err := clientStream.Recv()
+			if err != nil {
+				hubStream.CloseSend()
+				return
+			}
+
+			if err := hubStream.Send(chunk); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		progress, err := hubStream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := clientStream.Send(progress); err != nil {
+			return err
+		}
+	}
+}
+
+func (t *tasksAPI) CopyFrom(req *pb.CopyFromRequest, srv pb.TaskManagement_CopyFromServer) error {
+	log.G(t.ctx).Info("handling CopyFrom request", zap.String("id", req.Id), zap.String("path", req.Path))
+
+	rm, err := t.remotes.account(srv.Context())
+	if err != nil {
+		return err
+	}
+
+	hubClient, cc, err := getHubClientByEthAddr(srv.Context(), rm, req.HubAddr)
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	copyClient, err := hubClient.CopyFrom(srv.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := copyClient.Recv()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := srv.Send(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+func getHubClientForDeal(ctx context.Context, rm *remoteOptions, id string) (pb.HubClient, io.Closer, error) {
+	bigID, err := util.ParseBigInt(id)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// Maybe blocking connection required?
-	cc, err := xgrpc.NewClient(ctx, addrReply.Endpoints[0], rm.creds)
+	dealInfo, err := rm.eth.GetDealInfo(ctx, bigID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return pb.NewHubClient(cc), cc, nil
+	return getHubClientByEthAddr(ctx, rm, dealInfo.GetSupplierID())
+}
+
+func getHubClientByEthAddr(ctx context.Context, rm *remoteOptions, eth string) (pb.HubClient, io.Closer, error) {
+	return connectHub(ctx, rm.locator, rm.hubCreator, eth)
 }
 
 type streamMeta struct {
@@ -7,18 +7,34 @@ type RendezvousConfig struct {
 }
 
 func (m *RendezvousConfig) ConvertEndpoints() ([]auth.Endpoint, error) {
-	var endpoints []auth.Endpoint
-	for _, endpoint := range m.Endpoints {
+	return convertEndpoints(m.Endpoints)
+}
+
+type RelayConfig struct {
+	Endpoints []string
+}
+
+func (m *RelayConfig) ConvertEndpoints() ([]auth.Endpoint, error) {
+	return convertEndpoints(m.Endpoints)
+}
+
+func convertEndpoints(endpoints []string) ([]auth.Endpoint, error) {
+	var result []auth.Endpoint
+	for _, endpoint := range endpoints {
 		addr, err := auth.NewEndpoint(endpoint)
 		if err != nil {
 			return nil, err
 		}
-		endpoints = append(endpoints, *addr)
+		result = append(result, *addr)
 	}
 
-	return endpoints, nil
+	return result, nil
 }
 
 type Config struct {
 	Rendezvous RendezvousConfig
+	Relay      RelayConfig
+	// UDP activates UDP hole punching, which is tried when TCP punching
+	// fails.
+	UDP bool
 }
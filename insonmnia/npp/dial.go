@@ -4,9 +4,18 @@ package npp
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/opentracing/opentracing-go"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/insonmnia/relay"
+	"github.com/sonm-io/core/insonmnia/tracing"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
 )
 
 // Dialer represents an NPP dialer.
@@ -14,9 +23,17 @@ import (
 // This structure acts like an usual dialer with an exception that the address
 // must be an authenticated endpoint and the connection establishment process
 // is done via NAT Punching Protocol.
+// When TCP punching fails, UDP punching is tried if activated, and then the
+// connection is established through the relay server if configured.
 type Dialer struct {
-	ctx     context.Context
-	puncher NATPuncher
+	ctx context.Context
+	log *zap.Logger
+
+	puncher    *lazyPuncher
+	udpPuncher *lazyPuncher
+
+	relayEndpoints   []auth.Endpoint
+	relayCredentials credentials.TransportCredentials
 }
 
 // NewDialer constructs a new dialer that is aware of NAT Punching Protocol.
@@ -30,19 +47,179 @@ func NewDialer(ctx context.Context, options ...Option) (*Dialer, error) {
 	}
 
 	return &Dialer{
-		ctx:     ctx,
-		puncher: opts.puncher,
+		ctx:              ctx,
+		log:              opts.log,
+		puncher:          newLazyPuncher(opts.puncher, opts.puncherNew),
+		udpPuncher:       newLazyPuncher(nil, opts.udpPuncherFactory()),
+		relayEndpoints:   opts.relayEndpoints,
+		relayCredentials: opts.relayCredentials,
 	}, nil
 }
 
 // Dial dials the given verified address using NPP.
+//
+// The returned connection is of *Conn type, allowing to check the path it
+// has been established through.
 func (m *Dialer) Dial(addr common.Address) (net.Conn, error) {
-	return m.puncher.Dial(addr)
+	return m.DialContext(m.ctx, addr)
+}
+
+// DialContext dials the given verified address using NPP, tracing the
+// connection establishment as a child of the span in the given context.
+func (m *Dialer) DialContext(ctx context.Context, addr common.Address) (_ net.Conn, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "npp.Dial")
+	span.SetTag("addr", addr.Hex())
+	defer func() { tracing.Finish(span, err) }()
+
+	conn, err := m.punch(addr)
+	if err == nil {
+		m.log.Info("connected using NPP", zap.Stringer("addr", addr), zap.Any("path", ConnPath(conn)))
+		span.SetTag("path", string(ConnPath(conn)))
+		return conn, nil
+	}
+
+	if len(m.relayEndpoints) == 0 {
+		return nil, err
+	}
+
+	m.log.Info("failed to punch the network, falling back to relay", zap.Stringer("addr", addr), zap.Error(err))
+	span.LogFields(otlog.String("event", "punch failed"), otlog.Error(err))
+
+	conn, relayErr := m.relay(ctx, addr)
+	if relayErr != nil {
+		return nil, fmt.Errorf("failed to connect using both NPP (%v) and relay (%v)", err, relayErr)
+	}
+
+	m.log.Info("connected using relay", zap.Stringer("addr", addr), zap.Stringer("relay", conn.RemoteAddr()))
+	span.SetTag("path", string(PathRelay))
+	return conn, nil
+}
+
+func (m *Dialer) punch(addr common.Address) (net.Conn, error) {
+	conn, err := m.puncher.Dial(addr)
+	if err == nil || !m.udpPuncher.Available() {
+		return conn, err
+	}
+
+	m.log.Info("failed to punch the network using TCP, trying UDP", zap.Stringer("addr", addr), zap.Error(err))
+
+	conn, udpErr := m.udpPuncher.Dial(addr)
+	if udpErr != nil {
+		return nil, fmt.Errorf("failed to punch the network using both TCP (%v) and UDP (%v)", err, udpErr)
+	}
+
+	return conn, nil
+}
+
+func (m *Dialer) relay(ctx context.Context, addr common.Address) (net.Conn, error) {
+	var errs []error
+	for _, endpoint := range m.relayEndpoints {
+		conn, err := relay.Dial(ctx, endpoint, m.relayCredentials, addr)
+		if err == nil {
+			return newConn(conn, PathRelay), nil
+		}
+
+		errs = append(errs, err)
+	}
+
+	return nil, fmt.Errorf("all relay servers have failed: %+v", errs)
 }
 
 // Close closes the dialer.
 //
 // Any blocked operations will be unblocked and return errors.
 func (m *Dialer) Close() error {
+	var errs []error
+
+	if err := m.puncher.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := m.udpPuncher.Close(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to close dialer: %+v", errs)
+	}
+
+	return nil
+}
+
+// lazyPuncher constructs the puncher on demand, reconstructing it after
+// transport errors.
+type lazyPuncher struct {
+	mu         sync.Mutex
+	puncher    NATPuncher
+	puncherNew func() (NATPuncher, error)
+}
+
+func newLazyPuncher(puncher NATPuncher, puncherNew func() (NATPuncher, error)) *lazyPuncher {
+	return &lazyPuncher{
+		puncher:    puncher,
+		puncherNew: puncherNew,
+	}
+}
+
+// Available reports whether the puncher can be used.
+func (m *lazyPuncher) Available() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.puncher != nil || m.puncherNew != nil
+}
+
+func (m *lazyPuncher) Dial(addr common.Address) (net.Conn, error) {
+	puncher, err := m.get()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := puncher.Dial(addr)
+	if _, ok := err.(TransportError); ok {
+		m.reset(puncher)
+	}
+
+	return conn, err
+}
+
+func (m *lazyPuncher) get() (NATPuncher, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.puncher != nil {
+		return m.puncher, nil
+	}
+
+	if m.puncherNew == nil {
+		return nil, fmt.Errorf("no rendezvous servers configured")
+	}
+
+	puncher, err := m.puncherNew()
+	if err != nil {
+		return nil, err
+	}
+
+	m.puncher = puncher
+	return puncher, nil
+}
+
+func (m *lazyPuncher) reset(puncher NATPuncher) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.puncher == puncher {
+		m.puncher.Close()
+		m.puncher = nil
+	}
+}
+
+func (m *lazyPuncher) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.puncher == nil {
+		return nil
+	}
+
 	return m.puncher.Close()
 }
@@ -9,7 +9,10 @@ import (
 	"net"
 	"time"
 
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/insonmnia/relay"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
 )
 
 type connTuple struct {
@@ -43,8 +46,9 @@ func (m *connTuple) unwrap() (net.Conn, error) {
 //
 // Options are: rendezvous server, private IPs usage, relay server(s) if any.
 type Listener struct {
-	ctx context.Context
-	log *zap.Logger
+	ctx    context.Context
+	cancel context.CancelFunc
+	log    *zap.Logger
 
 	listener        net.Listener
 	listenerChannel chan connTuple
@@ -53,6 +57,12 @@ type Listener struct {
 	puncherNew func() (NATPuncher, error)
 	nppChannel chan connTuple
 
+	udpPuncherNew func() (NATPuncher, error)
+
+	relayEndpoints   []auth.Endpoint
+	relayCredentials credentials.TransportCredentials
+	relayChannel     chan connTuple
+
 	minBackoffInterval time.Duration
 	maxBackoffInterval time.Duration
 }
@@ -76,8 +86,11 @@ func NewListener(ctx context.Context, addr string, options ...Option) (net.Liste
 		return nil, err
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
+
 	m := &Listener{
 		ctx:             ctx,
+		cancel:          cancel,
 		log:             opts.log,
 		listenerChannel: channel,
 		listener:        listener,
@@ -85,12 +98,20 @@ func NewListener(ctx context.Context, addr string, options ...Option) (net.Liste
 		puncherNew:      opts.puncherNew,
 		nppChannel:      make(chan connTuple, opts.nppBacklog),
 
+		udpPuncherNew: opts.udpPuncherFactory(),
+
+		relayEndpoints:   opts.relayEndpoints,
+		relayCredentials: opts.relayCredentials,
+		relayChannel:     make(chan connTuple, 1),
+
 		minBackoffInterval: 500 * time.Millisecond,
 		maxBackoffInterval: 8000 * time.Millisecond,
 	}
 
 	go m.listen()
 	go m.listenPuncher(ctx)
+	go m.listenUDPPuncher(ctx)
+	go m.listenRelay(ctx)
 
 	return m, nil
 }
@@ -144,6 +165,107 @@ func (m *Listener) listenPuncher(ctx context.Context) error {
 	}
 }
 
+// listenUDPPuncher publishes the listener on the rendezvous using UDP in
+// parallel with TCP, delivering successfully punched connections only.
+// Failures are just logged, because TCP NPP is the primary path.
+func (m *Listener) listenUDPPuncher(ctx context.Context) error {
+	if m.udpPuncherNew == nil {
+		return nil
+	}
+
+	var puncher NATPuncher
+	defer func() {
+		if puncher != nil {
+			puncher.Close()
+		}
+	}()
+
+	timeout := m.minBackoffInterval
+	for {
+		timer := time.NewTimer(timeout)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if puncher == nil {
+			var err error
+			puncher, err = m.udpPuncherNew()
+			if err != nil {
+				m.log.Warn("failed to construct an UDP puncher", zap.Error(err))
+				if timeout < m.maxBackoffInterval {
+					timeout = 2 * timeout
+				}
+				continue
+			}
+		}
+
+		conn, err := puncher.AcceptContext(ctx)
+		if err != nil {
+			m.log.Warn("failed to accept UDP NPP connection", zap.Error(err))
+			if _, ok := err.(TransportError); ok {
+				puncher.Close()
+				puncher = nil
+			}
+			if timeout < m.maxBackoffInterval {
+				timeout = 2 * timeout
+			}
+			continue
+		}
+
+		timeout = m.minBackoffInterval
+
+		select {
+		case m.nppChannel <- newConnTuple(conn, nil):
+		case <-ctx.Done():
+			conn.Close()
+			return ctx.Err()
+		}
+	}
+}
+
+// listenRelay keeps a pending connection to one of the relay servers,
+// rotating them on failures, to be reachable even if NAT punching fails.
+func (m *Listener) listenRelay(ctx context.Context) error {
+	if len(m.relayEndpoints) == 0 {
+		return nil
+	}
+
+	timeout := m.minBackoffInterval
+	for id := 0; ; id = (id + 1) % len(m.relayEndpoints) {
+		endpoint := m.relayEndpoints[id]
+		conn, err := relay.Accept(ctx, endpoint, m.relayCredentials)
+		if err != nil {
+			m.log.Warn("failed to accept connection on relay", zap.Stringer("relay", endpoint), zap.Error(err))
+
+			timer := time.NewTimer(timeout)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+
+			if timeout < m.maxBackoffInterval {
+				timeout = 2 * timeout
+			}
+			continue
+		}
+
+		timeout = m.minBackoffInterval
+
+		select {
+		case m.relayChannel <- newConnTuple(newConn(conn, PathRelay), nil):
+		case <-ctx.Done():
+			conn.Close()
+			return ctx.Err()
+		}
+	}
+}
+
 // Accept waits for and returns the next connection to the listener.
 //
 // This method will firstly check whether there are pending sockets in the
@@ -154,6 +276,11 @@ func (m *Listener) listenPuncher(ctx context.Context) error {
 // Simultaneously additional sockets are constructed after resolution to make
 // punching mechanism work. This can consume a meaningful amount of file
 // descriptors, so be prepared to enlarge your limits.
+// Connections punched using UDP, if activated, and relayed through the relay
+// servers are accepted as well.
+//
+// Connections established using either NPP or relay are of *Conn type,
+// allowing to check the path they have been established through.
 func (m *Listener) Accept() (net.Conn, error) {
 	// Act as a listener if there is no puncher specified.
 	// Check for acceptor listenerChannel, if there is a connection - return immediately.
@@ -176,13 +303,21 @@ func (m *Listener) Accept() (net.Conn, error) {
 				m.puncher.Close()
 				m.puncher = nil
 			} else {
+				if conn.Error() == nil {
+					m.log.Info("connected using NPP", zap.Any("path", ConnPath(conn.Conn)))
+				}
 				return conn.unwrap()
 			}
+		case conn := <-m.relayChannel:
+			m.log.Info("received relayed peer", zap.Stringer("remote", conn.RemoteAddr()))
+			return conn.unwrap()
 		}
 	}
 }
 
 func (m *Listener) Close() error {
+	m.cancel()
+
 	var errs []error
 
 	if err := m.listener.Close(); err != nil {
@@ -13,6 +13,7 @@ import (
 )
 
 const protocol = "tcp"
+const protocolUDP = "udp"
 const tcpKeepAliveInterval = 15 * time.Second
 
 type Port uint16
@@ -53,9 +54,11 @@ func privateAddrs(addr net.Addr) ([]net.Addr, error) {
 		ips = filteredIPs(ips, ip)
 	}
 
+	network := addr.Network()
+
 	var addrs []net.Addr
 	for _, ip := range ips {
-		addr, err := net.ResolveTCPAddr(protocol, fmt.Sprintf("%s:%d", ip, uint16(port)))
+		addr, err := resolveAddr(network, fmt.Sprintf("%s:%d", ip, uint16(port)))
 		if err != nil {
 			return nil, err
 		}
@@ -66,6 +69,14 @@ func privateAddrs(addr net.Addr) ([]net.Addr, error) {
 	return addrs, nil
 }
 
+func resolveAddr(network, addr string) (net.Addr, error) {
+	if network == protocolUDP {
+		return net.ResolveUDPAddr(network, addr)
+	}
+
+	return net.ResolveTCPAddr(protocol, addr)
+}
+
 func filteredIPs(ips []net.IP, target net.IP) []net.IP {
 	var filtered []net.IP
 	for _, ip := range ips {
@@ -18,15 +18,32 @@ type options struct {
 	puncher    NATPuncher
 	puncherNew func() (NATPuncher, error)
 	nppBacklog int
+
+	udp           bool
+	udpPuncherNew func() (NATPuncher, error)
+
+	relayEndpoints   []auth.Endpoint
+	relayCredentials credentials.TransportCredentials
 }
 
 func newOptions(ctx context.Context) *options {
 	return &options{
 		ctx:        ctx,
+		log:        zap.NewNop(),
 		nppBacklog: 128,
 	}
 }
 
+// udpPuncherFactory returns the UDP puncher constructor if UDP punching is
+// activated.
+func (o *options) udpPuncherFactory() func() (NATPuncher, error) {
+	if !o.udp {
+		return nil
+	}
+
+	return o.udpPuncherNew
+}
+
 // WithRendezvous is an option that specifies Rendezvous client settings.
 //
 // Without this option no intermediate server will be used for obtaining
@@ -45,6 +62,42 @@ func WithRendezvous(addrs []auth.Endpoint, credentials credentials.TransportCred
 			return nil, fmt.Errorf("failed to connect to %+v", addrs)
 		}
 
+		o.udpPuncherNew = func() (NATPuncher, error) {
+			for _, addr := range addrs {
+				client, err := newRendezvousClient(o.ctx, addr, credentials)
+				if err == nil {
+					return newUDPPuncher(o.ctx, client)
+				}
+			}
+
+			return nil, fmt.Errorf("failed to connect to %+v", addrs)
+		}
+
+		return nil
+	}
+}
+
+// WithUDP is an option that activates UDP hole punching using the same
+// rendezvous servers, which is tried when TCP punching fails.
+//
+// Requires WithRendezvous option to be specified.
+func WithUDP() Option {
+	return func(o *options) error {
+		o.udp = true
+		return nil
+	}
+}
+
+// WithRelay is an option that specifies Relay servers used as a last resort
+// when NAT punching fails, for example when a peer is located behind a
+// symmetric NAT.
+//
+// Servers are tried in the order specified. The credentials are used to
+// authenticate both the relay server and the peer itself.
+func WithRelay(addrs []auth.Endpoint, credentials credentials.TransportCredentials) Option {
+	return func(o *options) error {
+		o.relayEndpoints = addrs
+		o.relayCredentials = credentials
 		return nil
 	}
 }
@@ -55,6 +108,10 @@ func WithRendezvous(addrs []auth.Endpoint, credentials credentials.TransportCred
 // entirely.
 func WithLogger(log *zap.Logger) Option {
 	return func(o *options) error {
+		if log == nil {
+			log = zap.NewNop()
+		}
+
 		o.log = log
 		return nil
 	}
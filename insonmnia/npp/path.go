@@ -0,0 +1,55 @@
+package npp
+
+import (
+	"net"
+)
+
+// Path describes the way a connection has been established.
+type Path string
+
+const (
+	// PathDirect means that the connection has been accepted on the
+	// listening socket directly.
+	PathDirect Path = "direct"
+	// PathPrivate means that peers have connected using their private
+	// addresses, i.e. they are located within the same LAN.
+	PathPrivate Path = "private"
+	// PathNAT means that the NAT has been successfully punched.
+	PathNAT Path = "nat"
+	// PathUDP means that the NAT has been punched using UDP, carrying a
+	// reliable stream over it.
+	PathUDP Path = "udp"
+	// PathRelay means that the traffic is forwarded through the relay
+	// server.
+	PathRelay Path = "relay"
+)
+
+// Conn is a connection established using NPP, aware of the path it has been
+// established through.
+type Conn struct {
+	net.Conn
+	path Path
+}
+
+func newConn(conn net.Conn, path Path) net.Conn {
+	if conn == nil {
+		return nil
+	}
+
+	return &Conn{Conn: conn, path: path}
+}
+
+// Path returns the path the connection has been established through.
+func (m *Conn) Path() Path {
+	return m.path
+}
+
+// ConnPath returns the path the given connection has been established
+// through. Connections established without NPP are considered direct.
+func ConnPath(conn net.Conn) Path {
+	if conn, ok := conn.(*Conn); ok {
+		return conn.Path()
+	}
+
+	return PathDirect
+}
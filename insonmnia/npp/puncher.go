@@ -143,7 +143,6 @@ func (m *natPuncher) AcceptContext(ctx context.Context) (net.Conn, error) {
 		return nil, err
 	}
 
-	// TODO: At last when there is no hope, use relay server.
 	return conn, nil
 }
 
@@ -194,7 +193,7 @@ func convertAddrs(addrs []net.Addr) ([]*sonm.Addr, error) {
 		}
 
 		result = append(result, &sonm.Addr{
-			Protocol: protocol,
+			Protocol: addr.Network(),
 			Addr: &sonm.SocketAddr{
 				Addr: host.String(),
 				Port: uint32(port),
@@ -218,7 +217,7 @@ func (m *natPuncher) punch(ctx context.Context, addrs *sonm.RendezvousReply) (ne
 		go func() {
 			conn, err := m.punchAddr(ctx, addrs.PublicAddr)
 			m.log.Info("using NAT", zap.Any("addr", addrs.PublicAddr), zap.Error(err))
-			pending <- newConnTuple(conn, err)
+			pending <- newConnTuple(newConn(conn, PathNAT), err)
 		}()
 	}
 
@@ -226,7 +225,7 @@ func (m *natPuncher) punch(ctx context.Context, addrs *sonm.RendezvousReply) (ne
 		go func(addr *sonm.Addr) {
 			conn, err := m.punchAddr(ctx, addr)
 			m.log.Info("using private address", zap.Any("addr", addr), zap.Error(err))
-			pending <- newConnTuple(conn, err)
+			pending <- newConnTuple(newConn(conn, PathPrivate), err)
 		}(addr)
 	}
 
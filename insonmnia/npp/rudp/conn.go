@@ -0,0 +1,652 @@
+// Reliable stream transport over UDP.
+//
+// This package implements a minimal selective repeat ARQ protocol, which
+// turns a punched UDP socket into an ordered reliable byte stream suitable
+// for TLS and gRPC on top of it. Every data segment is acknowledged
+// individually, while each packet also carries the cumulative
+// acknowledgement, so lost ACKs are recovered without retransmission.
+// Retransmission timeouts are calculated from the smoothed RTT like in TCP.
+//
+// Like in TCP, each packet advertises the receive window, so a fast sender
+// never outgrows the receive buffer, while the congestion window grows
+// with slow start and congestion avoidance and collapses on timeouts.
+//
+// Established transports like KCP or QUIC are preferred here, but neither
+// is vendored yet, so this implementation is deliberately kept small and
+// hidden behind net.Conn to be replaced with one of them without touching
+// the NPP code.
+
+package rudp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// windowSize is the maximum number of segments in flight, as well as
+	// the receive buffer size in segments.
+	windowSize        = 128
+	rcvBufSize        = windowSize * MSS
+	initialCwnd       = 4
+	minSsthresh       = 2
+	minRTO            = 100 * time.Millisecond
+	maxRTO            = 3 * time.Second
+	initialRTO        = 500 * time.Millisecond
+	maxRetransmits    = 10
+	tickInterval      = 10 * time.Millisecond
+	keepaliveInterval = 5 * time.Second
+	deadTimeout       = 30 * time.Second
+	lingerTimeout     = 3 * time.Second
+)
+
+var (
+	errClosed      = errors.New("use of closed connection")
+	errUnreachable = errors.New("peer is unreachable")
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+type segment struct {
+	seq   uint32
+	kind  byte
+	data  []byte
+	xmit  int
+	sent  time.Time
+	rtoAt time.Time
+}
+
+// Conn is a reliable stream connection over UDP.
+//
+// The connection owns the underlying socket and closes it after all
+// outstanding data is acknowledged by the peer or the linger timeout
+// expires.
+type Conn struct {
+	conn   *net.UDPConn
+	remote *net.UDPAddr
+	peers  []*net.UDPAddr
+
+	mu sync.Mutex
+	// Sender state.
+	sndNext uint32
+	sndUna  uint32
+	sndBuf  []*segment
+	srtt    time.Duration
+	rttvar  time.Duration
+	rto     time.Duration
+	// rmtWnd is the receive window advertised by the peer.
+	rmtWnd uint32
+	// Congestion control state. The window is measured in segments,
+	// while the recovery point prevents shrinking it more than once per
+	// loss event.
+	cwnd      uint32
+	cwndAcked uint32
+	ssthresh  uint32
+	recover   uint32
+	// Receiver state.
+	rcvNext     uint32
+	rcvQueue    map[uint32]*segment
+	readBuf     bytes.Buffer
+	finReceived bool
+	advertised  uint32
+
+	lastRecv      time.Time
+	lastSend      time.Time
+	err           error
+	closed        bool
+	readDeadline  time.Time
+	writeDeadline time.Time
+	readEvent     chan struct{}
+	writeEvent    chan struct{}
+
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// NewConn constructs a new reliable connection over the given socket to the
+// remote address.
+//
+// Packets are accepted only from the given peer addresses, which are
+// usually the candidates collected during punching. Probes received from
+// them are acknowledged, so the remote side can finish its punching.
+func NewConn(conn *net.UDPConn, remote *net.UDPAddr, peers []*net.UDPAddr) *Conn {
+	now := time.Now()
+
+	m := &Conn{
+		conn:       conn,
+		remote:     remote,
+		peers:      append([]*net.UDPAddr{remote}, peers...),
+		rto:        initialRTO,
+		rmtWnd:     windowSize,
+		cwnd:       initialCwnd,
+		ssthresh:   windowSize,
+		advertised: windowSize,
+		rcvQueue:   map[uint32]*segment{},
+		lastRecv:   now,
+		lastSend:   now,
+		readEvent:  make(chan struct{}),
+		writeEvent: make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+
+	go m.recvLoop()
+	go m.timerLoop()
+
+	return m
+}
+
+// Read reads data from the connection.
+//
+// Returns io.EOF after the peer has closed its side and all the data
+// received is consumed.
+func (m *Conn) Read(b []byte) (int, error) {
+	for {
+		m.mu.Lock()
+		if m.closed {
+			m.mu.Unlock()
+			return 0, errClosed
+		}
+		if m.readBuf.Len() > 0 {
+			n, err := m.readBuf.Read(b)
+			m.updateWindow()
+			m.mu.Unlock()
+			return n, err
+		}
+		if m.finReceived {
+			m.mu.Unlock()
+			return 0, io.EOF
+		}
+		if m.err != nil {
+			err := m.err
+			m.mu.Unlock()
+			return 0, err
+		}
+
+		event, deadline := m.readEvent, m.readDeadline
+		m.mu.Unlock()
+
+		if err := m.wait(event, deadline); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Write writes data to the connection.
+//
+// Blocks while the send window is full.
+func (m *Conn) Write(b []byte) (int, error) {
+	written := 0
+	for len(b) > 0 {
+		m.mu.Lock()
+		if m.closed {
+			m.mu.Unlock()
+			return written, errClosed
+		}
+		if m.err != nil {
+			err := m.err
+			m.mu.Unlock()
+			return written, err
+		}
+
+		if m.windowFull() {
+			event, deadline := m.writeEvent, m.writeDeadline
+			m.mu.Unlock()
+
+			if err := m.wait(event, deadline); err != nil {
+				return written, err
+			}
+			continue
+		}
+
+		n := len(b)
+		if n > MSS {
+			n = MSS
+		}
+
+		m.enqueue(typeData, append([]byte{}, b[:n]...))
+		m.mu.Unlock()
+
+		b = b[n:]
+		written += n
+	}
+
+	return written, nil
+}
+
+// Close closes the connection.
+//
+// Any blocked Read or Write operations will be unblocked and return errors.
+// The remaining data is still delivered in background.
+func (m *Conn) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return errClosed
+	}
+
+	m.closed = true
+	m.broadcast()
+
+	if m.err != nil {
+		m.shutdown()
+		return nil
+	}
+
+	m.enqueue(typeFin, nil)
+	time.AfterFunc(lingerTimeout, m.shutdown)
+
+	return nil
+}
+
+// LocalAddr returns the local network address.
+func (m *Conn) LocalAddr() net.Addr {
+	return m.conn.LocalAddr()
+}
+
+// RemoteAddr returns the remote network address.
+func (m *Conn) RemoteAddr() net.Addr {
+	return m.remote
+}
+
+// SetDeadline sets the read and write deadlines associated with the
+// connection.
+func (m *Conn) SetDeadline(t time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.readDeadline = t
+	m.writeDeadline = t
+	m.broadcast()
+
+	return nil
+}
+
+// SetReadDeadline sets the deadline for future Read calls and any
+// currently-blocked Read call.
+func (m *Conn) SetReadDeadline(t time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.readDeadline = t
+	m.broadcast()
+
+	return nil
+}
+
+// SetWriteDeadline sets the deadline for future Write calls and any
+// currently-blocked Write call.
+func (m *Conn) SetWriteDeadline(t time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.writeDeadline = t
+	m.broadcast()
+
+	return nil
+}
+
+func (m *Conn) wait(event <-chan struct{}, deadline time.Time) error {
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		duration := time.Until(deadline)
+		if duration <= 0 {
+			return timeoutError{}
+		}
+
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-event:
+	case <-m.done:
+	case <-timeout:
+		return timeoutError{}
+	}
+
+	return nil
+}
+
+// Synchronized by `m.mu`.
+func (m *Conn) broadcast() {
+	close(m.readEvent)
+	close(m.writeEvent)
+	m.readEvent = make(chan struct{})
+	m.writeEvent = make(chan struct{})
+}
+
+// Synchronized by `m.mu`.
+func (m *Conn) fail(err error) {
+	if m.err == nil {
+		m.err = err
+	}
+
+	m.broadcast()
+	m.shutdown()
+}
+
+func (m *Conn) shutdown() {
+	m.doneOnce.Do(func() {
+		close(m.done)
+		m.conn.Close()
+	})
+}
+
+// windowFull reports whether either the congestion window is exhausted by
+// segments in flight or the receive window advertised by the peer is.
+//
+// Synchronized by `m.mu`.
+func (m *Conn) windowFull() bool {
+	return uint32(len(m.sndBuf)) >= m.cwnd || m.sndNext-m.sndUna >= m.rmtWnd
+}
+
+// rcvWindow returns the number of segments the receive buffer is able to
+// accommodate.
+//
+// Synchronized by `m.mu`.
+func (m *Conn) rcvWindow() uint32 {
+	free := rcvBufSize - m.readBuf.Len()
+	if free < 0 {
+		return 0
+	}
+
+	return uint32(free / MSS)
+}
+
+// updateWindow notifies the peer when reading has opened the receive window
+// it has been told is almost closed. Lost updates are recovered by
+// keepalives.
+//
+// Synchronized by `m.mu`.
+func (m *Conn) updateWindow() {
+	if m.advertised < windowSize/2 && m.rcvWindow() >= windowSize/2 {
+		m.send(&packet{kind: typePing, una: m.rcvNext})
+	}
+}
+
+// Synchronized by `m.mu`.
+func (m *Conn) enqueue(kind byte, data []byte) {
+	seg := &segment{seq: m.sndNext, kind: kind, data: data}
+	m.sndNext++
+	m.sndBuf = append(m.sndBuf, seg)
+	m.transmit(seg, time.Now())
+}
+
+// Synchronized by `m.mu`.
+func (m *Conn) transmit(seg *segment, now time.Time) {
+	rto := m.rto << uint(seg.xmit)
+	if rto > maxRTO || rto <= 0 {
+		rto = maxRTO
+	}
+
+	seg.xmit++
+	seg.sent = now
+	seg.rtoAt = now.Add(rto)
+
+	m.send(&packet{kind: seg.kind, seq: seg.seq, una: m.rcvNext, payload: seg.data})
+}
+
+// Synchronized by `m.mu`.
+func (m *Conn) send(p *packet) {
+	m.advertised = m.rcvWindow()
+	p.wnd = uint16(m.advertised)
+
+	m.lastSend = time.Now()
+	m.conn.WriteToUDP(p.marshal(), m.remote)
+}
+
+func (m *Conn) recvLoop() {
+	buf := make([]byte, 2048)
+	for {
+		n, addr, err := m.conn.ReadFromUDP(buf)
+		if err != nil {
+			m.mu.Lock()
+			m.fail(err)
+			m.mu.Unlock()
+			return
+		}
+
+		if !hasAddr(m.peers, addr) {
+			continue
+		}
+
+		p, ok := unmarshalPacket(buf[:n])
+		if !ok {
+			continue
+		}
+
+		m.mu.Lock()
+		m.handle(p, addr)
+		m.mu.Unlock()
+	}
+}
+
+// Synchronized by `m.mu`.
+func (m *Conn) handle(p *packet, addr *net.UDPAddr) {
+	m.lastRecv = time.Now()
+
+	if p.kind != typePunch && p.kind != typePunchAck && uint32(p.wnd) != m.rmtWnd {
+		m.rmtWnd = uint32(p.wnd)
+		m.broadcast()
+	}
+
+	switch p.kind {
+	case typePunch:
+		m.conn.WriteToUDP((&packet{kind: typePunchAck}).marshal(), addr)
+	case typeData, typeFin:
+		m.acknowledge(p.una)
+		m.receive(p)
+	case typeAck:
+		m.acknowledge(p.una)
+		m.acknowledgeSegment(p.seq)
+	case typePing:
+		m.acknowledge(p.una)
+	}
+}
+
+// receive queues the segment for reading. Segments beyond the receive
+// window are dropped to bound the memory, letting the peer know the actual
+// window instead.
+//
+// Synchronized by `m.mu`.
+func (m *Conn) receive(p *packet) {
+	if !before(p.seq, m.rcvNext+m.rcvWindow()) {
+		m.send(&packet{kind: typePing, una: m.rcvNext})
+		return
+	}
+
+	if !before(p.seq, m.rcvNext) {
+		if _, ok := m.rcvQueue[p.seq]; !ok {
+			m.rcvQueue[p.seq] = &segment{seq: p.seq, kind: p.kind, data: append([]byte{}, p.payload...)}
+		}
+
+		for {
+			seg, ok := m.rcvQueue[m.rcvNext]
+			if !ok {
+				break
+			}
+
+			delete(m.rcvQueue, m.rcvNext)
+			m.rcvNext++
+
+			if seg.kind == typeFin {
+				m.finReceived = true
+			} else if !m.finReceived {
+				m.readBuf.Write(seg.data)
+			}
+		}
+
+		m.broadcast()
+	}
+
+	m.send(&packet{kind: typeAck, seq: p.seq, una: m.rcvNext})
+}
+
+// acknowledge removes all segments preceding the given sequence number from
+// the send buffer.
+//
+// Synchronized by `m.mu`.
+func (m *Conn) acknowledge(una uint32) {
+	if before(m.sndUna, una) {
+		m.sndUna = una
+	}
+
+	id := 0
+	for id < len(m.sndBuf) && before(m.sndBuf[id].seq, una) {
+		id++
+	}
+
+	if id > 0 {
+		m.sndBuf = m.sndBuf[id:]
+		m.grow(id)
+		m.broadcast()
+	}
+}
+
+// grow opens the congestion window for the given number of acknowledged
+// segments: exponentially during slow start and by one segment per window
+// during congestion avoidance.
+//
+// Synchronized by `m.mu`.
+func (m *Conn) grow(acked int) {
+	for ; acked > 0 && m.cwnd < windowSize; acked-- {
+		if m.cwnd < m.ssthresh {
+			m.cwnd++
+			continue
+		}
+
+		m.cwndAcked++
+		if m.cwndAcked >= m.cwnd {
+			m.cwndAcked = 0
+			m.cwnd++
+		}
+	}
+}
+
+// backoff collapses the congestion window after a retransmission timeout,
+// halving the slow start threshold.
+//
+// Synchronized by `m.mu`.
+func (m *Conn) backoff() {
+	m.ssthresh = uint32(len(m.sndBuf)) / 2
+	if m.ssthresh < minSsthresh {
+		m.ssthresh = minSsthresh
+	}
+
+	m.cwnd = 1
+	m.cwndAcked = 0
+	m.recover = m.sndNext
+}
+
+// Synchronized by `m.mu`.
+func (m *Conn) acknowledgeSegment(seq uint32) {
+	for id, seg := range m.sndBuf {
+		if seg.seq != seq {
+			continue
+		}
+
+		// Karn's algorithm: RTT of retransmitted segments is ambiguous.
+		if seg.xmit == 1 {
+			m.updateRTO(time.Since(seg.sent))
+		}
+
+		m.sndBuf = append(m.sndBuf[:id], m.sndBuf[id+1:]...)
+		m.grow(1)
+		m.broadcast()
+		return
+	}
+}
+
+// Synchronized by `m.mu`.
+func (m *Conn) updateRTO(rtt time.Duration) {
+	if m.srtt == 0 {
+		m.srtt = rtt
+		m.rttvar = rtt / 2
+	} else {
+		delta := m.srtt - rtt
+		if delta < 0 {
+			delta = -delta
+		}
+
+		m.rttvar = (3*m.rttvar + delta) / 4
+		m.srtt = (7*m.srtt + rtt) / 8
+	}
+
+	m.rto = m.srtt + 4*m.rttvar
+	if m.rto < minRTO {
+		m.rto = minRTO
+	}
+	if m.rto > maxRTO {
+		m.rto = maxRTO
+	}
+}
+
+func (m *Conn) timerLoop() {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.done:
+			return
+		case now := <-ticker.C:
+			m.mu.Lock()
+			m.tick(now)
+			m.mu.Unlock()
+		}
+	}
+}
+
+// Synchronized by `m.mu`.
+func (m *Conn) tick(now time.Time) {
+	if now.Sub(m.lastRecv) > deadTimeout {
+		m.fail(timeoutError{})
+		return
+	}
+
+	// Retransmissions are limited by the congestion window as well, while
+	// the rest of expired segments wait for the next tick.
+	retransmitted := uint32(0)
+	for _, seg := range m.sndBuf {
+		if now.Before(seg.rtoAt) {
+			continue
+		}
+
+		if seg.xmit > maxRetransmits {
+			m.fail(errUnreachable)
+			return
+		}
+
+		// Segments sent before the previous backoff belong to the same
+		// loss event.
+		if !before(seg.seq, m.recover) {
+			m.backoff()
+		}
+
+		if retransmitted >= m.cwnd {
+			break
+		}
+
+		m.transmit(seg, now)
+		retransmitted++
+	}
+
+	if m.closed && len(m.sndBuf) == 0 {
+		m.shutdown()
+		return
+	}
+
+	if now.Sub(m.lastSend) > keepaliveInterval {
+		m.send(&packet{kind: typePing, una: m.rcvNext})
+	}
+}
@@ -0,0 +1,152 @@
+package rudp
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSocket(t *testing.T) *net.UDPConn {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	return conn
+}
+
+func punchPair(t *testing.T) (*Conn, *Conn) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c1 := newSocket(t)
+	c2 := newSocket(t)
+
+	type result struct {
+		conn *Conn
+		err  error
+	}
+
+	punch := func(conn *net.UDPConn, addr net.Addr, c chan<- result) {
+		remote, peers, err := Punch(ctx, conn, []*net.UDPAddr{addr.(*net.UDPAddr)})
+		if err != nil {
+			c <- result{err: err}
+			return
+		}
+		c <- result{conn: NewConn(conn, remote, peers)}
+	}
+
+	r1 := make(chan result, 1)
+	r2 := make(chan result, 1)
+	go punch(c1, c2.LocalAddr(), r1)
+	go punch(c2, c1.LocalAddr(), r2)
+
+	res1 := <-r1
+	res2 := <-r2
+	require.NoError(t, res1.err)
+	require.NoError(t, res2.err)
+
+	return res1.conn, res2.conn
+}
+
+func TestPunchAndStream(t *testing.T) {
+	c1, c2 := punchPair(t)
+	defer c2.Close()
+
+	data := make([]byte, 1<<20)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	go func() {
+		c1.Write(data)
+		c1.Close()
+	}()
+
+	received, err := ioutil.ReadAll(c2)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(data, received))
+}
+
+func TestPunchTimeout(t *testing.T) {
+	conn := newSocket(t)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	_, _, err := Punch(ctx, conn, []*net.UDPAddr{{IP: net.IPv4(127, 0, 0, 1), Port: 1}})
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestReadDeadline(t *testing.T) {
+	c1, c2 := punchPair(t)
+	defer c1.Close()
+	defer c2.Close()
+
+	c2.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+	_, err := c2.Read(make([]byte, 1))
+	require.Error(t, err)
+	netErr, ok := err.(net.Error)
+	require.True(t, ok)
+	assert.True(t, netErr.Timeout())
+}
+
+func TestCloseUnblocksRead(t *testing.T) {
+	c1, c2 := punchPair(t)
+	defer c1.Close()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		c2.Close()
+	}()
+
+	_, err := c2.Read(make([]byte, 1))
+	assert.Error(t, err)
+	assert.NotEqual(t, io.EOF, err)
+}
+
+func TestSlowReaderBoundsBuffer(t *testing.T) {
+	c1, c2 := punchPair(t)
+	defer c1.Close()
+	defer c2.Close()
+
+	go c1.Write(make([]byte, 4*rcvBufSize))
+
+	time.Sleep(500 * time.Millisecond)
+
+	c2.mu.Lock()
+	buffered := c2.readBuf.Len() + len(c2.rcvQueue)*MSS
+	c2.mu.Unlock()
+
+	assert.True(t, buffered <= rcvBufSize)
+	assert.True(t, buffered > 0)
+
+	_, err := io.ReadFull(c2, make([]byte, 4*rcvBufSize))
+	require.NoError(t, err)
+}
+
+func TestIgnoreForeignPort(t *testing.T) {
+	c1, c2 := punchPair(t)
+	defer c1.Close()
+	defer c2.Close()
+
+	foreign := newSocket(t)
+	defer foreign.Close()
+
+	p := &packet{kind: typeData, payload: []byte("spoofed")}
+	_, err := foreign.WriteToUDP(p.marshal(), c2.conn.LocalAddr().(*net.UDPAddr))
+	require.NoError(t, err)
+
+	_, err = c1.Write([]byte("genuine"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 16)
+	n, err := c2.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "genuine", string(buf[:n]))
+}
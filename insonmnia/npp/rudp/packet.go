@@ -0,0 +1,72 @@
+package rudp
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+const (
+	typePunch    byte = 0x01
+	typePunchAck byte = 0x02
+	typeData     byte = 0x10
+	typeAck      byte = 0x11
+	typeFin      byte = 0x12
+	typePing     byte = 0x13
+)
+
+const (
+	headerSize = 15
+	// MSS is the maximum payload size of a single datagram, chosen to fit
+	// into the minimum IPv6 MTU.
+	MSS = 1200
+)
+
+var magic = []byte("SNPP")
+
+// packet represents a single datagram.
+//
+// The wire format is: magic (4 bytes), type (1 byte), seq (4 bytes), una (4
+// bytes), wnd (2 bytes), payload. Seq is the sequence number of data
+// segments or the number of the segment acknowledged. Una is the next
+// sequence number the sender expects to receive, acknowledging all the
+// previous segments. Wnd is the number of segments starting from una the
+// sender is able to receive.
+type packet struct {
+	kind    byte
+	seq     uint32
+	una     uint32
+	wnd     uint16
+	payload []byte
+}
+
+func (m *packet) marshal() []byte {
+	data := make([]byte, headerSize+len(m.payload))
+	copy(data, magic)
+	data[4] = m.kind
+	binary.BigEndian.PutUint32(data[5:], m.seq)
+	binary.BigEndian.PutUint32(data[9:], m.una)
+	binary.BigEndian.PutUint16(data[13:], m.wnd)
+	copy(data[headerSize:], m.payload)
+
+	return data
+}
+
+func unmarshalPacket(data []byte) (*packet, bool) {
+	if len(data) < headerSize || !bytes.HasPrefix(data, magic) {
+		return nil, false
+	}
+
+	return &packet{
+		kind:    data[4],
+		seq:     binary.BigEndian.Uint32(data[5:]),
+		una:     binary.BigEndian.Uint32(data[9:]),
+		wnd:     binary.BigEndian.Uint16(data[13:]),
+		payload: data[headerSize:],
+	}, true
+}
+
+// before reports whether sequence number a precedes b, accounting for
+// wrapping.
+func before(a, b uint32) bool {
+	return int32(a-b) < 0
+}
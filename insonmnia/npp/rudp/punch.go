@@ -0,0 +1,90 @@
+package rudp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+)
+
+const punchInterval = 100 * time.Millisecond
+
+// Punch penetrates NAT by sending probes from the given socket to all of the
+// specified addresses, simultaneously replying to the probes received from
+// the remote peer, until one of them acknowledges our probe.
+//
+// Probes received from unknown ports of the candidates' hosts are treated as
+// new candidates, because symmetric NATs allocate a new port for each
+// destination.
+//
+// Returns the address of the peer that acknowledged the probe and the list
+// of all the candidates collected.
+func Punch(ctx context.Context, conn *net.UDPConn, addrs []*net.UDPAddr) (*net.UDPAddr, []*net.UDPAddr, error) {
+	if len(addrs) == 0 {
+		return nil, nil, errors.New("no addresses to punch")
+	}
+
+	candidates := append([]*net.UDPAddr{}, addrs...)
+
+	defer conn.SetReadDeadline(time.Time{})
+
+	probe := (&packet{kind: typePunch}).marshal()
+	ack := (&packet{kind: typePunchAck}).marshal()
+	buf := make([]byte, 2048)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, candidates, err
+		}
+
+		for _, addr := range candidates {
+			conn.WriteToUDP(probe, addr)
+		}
+
+		conn.SetReadDeadline(time.Now().Add(punchInterval))
+		for {
+			n, addr, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+					break
+				}
+				return nil, candidates, err
+			}
+
+			p, ok := unmarshalPacket(buf[:n])
+			if !ok || !hasHost(candidates, addr) {
+				continue
+			}
+
+			switch p.kind {
+			case typePunch:
+				if !hasAddr(candidates, addr) {
+					candidates = append(candidates, addr)
+				}
+				conn.WriteToUDP(ack, addr)
+			case typePunchAck:
+				return addr, candidates, nil
+			}
+		}
+	}
+}
+
+func hasAddr(addrs []*net.UDPAddr, addr *net.UDPAddr) bool {
+	for _, candidate := range addrs {
+		if candidate.IP.Equal(addr.IP) && candidate.Port == addr.Port {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasHost(addrs []*net.UDPAddr, addr *net.UDPAddr) bool {
+	for _, candidate := range addrs {
+		if candidate.IP.Equal(addr.IP) {
+			return true
+		}
+	}
+
+	return false
+}
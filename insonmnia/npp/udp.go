@@ -0,0 +1,192 @@
+package npp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/insonmnia/npp/rudp"
+	"github.com/sonm-io/core/insonmnia/rendezvous"
+	"github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+)
+
+// udpPuncher penetrates NAT using UDP, which succeeds more often than TCP
+// simultaneous open, because most NATs keep UDP mappings independent of the
+// destination.
+//
+// Each connection gets its own UDP socket, whose public address is
+// discovered using the rendezvous server reflection before exchanging
+// endpoints. After punching the socket carries a reliable stream.
+type udpPuncher struct {
+	ctx context.Context
+	log *zap.Logger
+
+	client  *rendezvousClient
+	timeout time.Duration
+}
+
+func newUDPPuncher(ctx context.Context, client *rendezvousClient) (NATPuncher, error) {
+	return &udpPuncher{
+		ctx:     ctx,
+		log:     ctxlog.G(ctx),
+		client:  client,
+		timeout: maxConnectTimeout,
+	}, nil
+}
+
+func (m *udpPuncher) Dial(addr common.Address) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(m.ctx, m.timeout)
+	defer cancel()
+
+	return m.DialContext(ctx, addr)
+}
+
+func (m *udpPuncher) DialContext(ctx context.Context, addr common.Address) (net.Conn, error) {
+	conn, privateAddrs, publicAddr, err := m.bind(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &sonm.ConnectRequest{
+		Protocol:     protocolUDP,
+		PrivateAddrs: privateAddrs,
+		PublicAddr:   publicAddr,
+		ID:           addr.String(),
+	}
+
+	addrs, err := m.client.Resolve(ctx, request)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return m.punch(ctx, conn, addrs)
+}
+
+func (m *udpPuncher) Accept() (net.Conn, error) {
+	return m.AcceptContext(m.ctx)
+}
+
+func (m *udpPuncher) AcceptContext(ctx context.Context) (net.Conn, error) {
+	conn, privateAddrs, publicAddr, err := m.bind(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &sonm.PublishRequest{
+		Protocol:     protocolUDP,
+		PrivateAddrs: privateAddrs,
+		PublicAddr:   publicAddr,
+	}
+
+	addrs, err := m.client.Publish(ctx, request)
+	if err != nil {
+		conn.Close()
+		m.log.Error("failed to publish itself on the rendezvous", zap.Error(err))
+		return nil, TransportError{err}
+	}
+
+	m.log.Info("received remote peer UDP endpoints", zap.Any("addrs", addrs))
+
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	return m.punch(ctx, conn, addrs)
+}
+
+// bind creates a new UDP socket, collecting its private addresses and
+// discovering the public one.
+func (m *udpPuncher) bind(ctx context.Context) (*net.UDPConn, []*sonm.Addr, *sonm.Addr, error) {
+	rvAddr, err := m.rendezvousAddr()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	conn, err := net.ListenUDP(protocolUDP, &net.UDPAddr{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	addrs, err := privateAddrs(conn.LocalAddr())
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, err
+	}
+
+	privateAddrs, err := convertAddrs(addrs)
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, err
+	}
+
+	addr, err := rendezvous.ReflectUDPAddr(ctx, conn, rvAddr)
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, fmt.Errorf("failed to discover public UDP address: %v", err)
+	}
+
+	publicAddr, err := sonm.NewAddr(addr)
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, err
+	}
+
+	return conn, privateAddrs, publicAddr, nil
+}
+
+// rendezvousAddr returns the UDP address of the rendezvous server, which
+// shares the port with its gRPC endpoint.
+func (m *udpPuncher) rendezvousAddr() (*net.UDPAddr, error) {
+	addr, ok := m.client.RemoteAddr().(*net.TCPAddr)
+	if !ok {
+		return nil, fmt.Errorf("unsupported rendezvous address: %s", m.client.RemoteAddr())
+	}
+
+	return &net.UDPAddr{IP: addr.IP, Port: addr.Port, Zone: addr.Zone}, nil
+}
+
+func (m *udpPuncher) punch(ctx context.Context, conn *net.UDPConn, addrs *sonm.RendezvousReply) (net.Conn, error) {
+	if addrs.Empty() {
+		conn.Close()
+		return nil, fmt.Errorf("no addresses resolved")
+	}
+
+	var candidates []*net.UDPAddr
+	for _, addr := range append([]*sonm.Addr{addrs.PublicAddr}, addrs.PrivateAddrs...) {
+		if !addr.IsValid() {
+			continue
+		}
+
+		udpAddr, err := addr.IntoUDP()
+		if err != nil {
+			m.log.Debug("skipping invalid UDP address", zap.Any("addr", addr), zap.Error(err))
+			continue
+		}
+
+		candidates = append(candidates, udpAddr)
+	}
+
+	m.log.Debug("punching UDP", zap.Any("addrs", candidates))
+
+	remote, peers, err := rudp.Punch(ctx, conn, candidates)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to punch the network using UDP: %v", err)
+	}
+
+	m.log.Info("UDP hole has been punched", zap.Stringer("addr", remote))
+
+	return newConn(rudp.NewConn(conn, remote, peers), PathUDP), nil
+}
+
+func (m *udpPuncher) RemoteAddr() net.Addr {
+	return m.client.RemoteAddr()
+}
+
+func (m *udpPuncher) Close() error {
+	return m.client.Close()
+}
@@ -0,0 +1,192 @@
+package rating
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	log "github.com/noxiouz/zapctx/ctxlog"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultRating is the neutral rating of parties without reported deals,
+// so newcomers are not filtered out by moderate requirements.
+const DefaultRating = 50
+
+var (
+	errInvalidSignature = errors.New("rating entry signature is invalid")
+	errSelfReport       = errors.New("reporter cannot rate itself")
+)
+
+// hash returns the hash of the entry fields covered by the signature.
+func hash(entry *pb.RatingEntry) []byte {
+	ts := make([]byte, 8)
+	binary.BigEndian.PutUint64(ts, uint64(entry.GetTs().GetSeconds()))
+
+	outcome := make([]byte, 4)
+	binary.BigEndian.PutUint32(outcome, uint32(entry.GetOutcome()))
+
+	return crypto.Keccak256(
+		[]byte(entry.GetDealID()),
+		common.HexToAddress(entry.GetReporter()).Bytes(),
+		common.HexToAddress(entry.GetSubject()).Bytes(),
+		outcome,
+		[]byte(entry.GetDetails()),
+		ts,
+	)
+}
+
+// NewEntry creates an outcome of the deal made with the given subject,
+// signed by the reporter's key.
+func NewEntry(key *ecdsa.PrivateKey, dealID, subject string, outcome pb.DealOutcome, details string) (*pb.RatingEntry, error) {
+	entry := &pb.RatingEntry{
+		DealID:   dealID,
+		Reporter: util.PubKeyToAddr(key.PublicKey).Hex(),
+		Subject:  common.HexToAddress(subject).Hex(),
+		Outcome:  outcome,
+		Details:  details,
+		Ts:       &pb.Timestamp{Seconds: time.Now().Unix()},
+	}
+
+	signature, err := crypto.Sign(hash(entry), key)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Signature = signature
+	return entry, nil
+}
+
+// Verify checks that the entry is signed by its reporter.
+func Verify(entry *pb.RatingEntry) error {
+	if !common.IsHexAddress(entry.GetReporter()) || !common.IsHexAddress(entry.GetSubject()) {
+		return fmt.Errorf("invalid rating entry addresses: %s -> %s", entry.GetReporter(), entry.GetSubject())
+	}
+
+	if common.HexToAddress(entry.GetReporter()) == common.HexToAddress(entry.GetSubject()) {
+		return errSelfReport
+	}
+
+	pub, err := crypto.SigToPub(hash(entry), entry.GetSignature())
+	if err != nil {
+		return errInvalidSignature
+	}
+
+	if crypto.PubkeyToAddress(*pub) != common.HexToAddress(entry.GetReporter()) {
+		return errInvalidSignature
+	}
+
+	return nil
+}
+
+// Compute aggregates the given entries into the rating reply.
+//
+// The rating is the percentage of deals reported without any failures, so
+// a single failure spoils the whole deal regardless of how many times its
+// completion has been reported. Parties without deals get the default
+// rating.
+func Compute(ethAddr string, entries []*pb.RatingEntry) *pb.RatingReply {
+	reply := &pb.RatingReply{
+		EthAddr:  ethAddr,
+		Outcomes: make(map[string]uint64),
+	}
+
+	succeeded := map[string]bool{}
+	for _, entry := range entries {
+		reply.Outcomes[entry.GetOutcome().String()]++
+
+		ok, seen := succeeded[entry.GetDealID()]
+		succeeded[entry.GetDealID()] = (ok || !seen) && entry.GetOutcome() == pb.DealOutcome_COMPLETED
+	}
+
+	var completed int64
+	for _, ok := range succeeded {
+		if ok {
+			completed++
+		}
+	}
+
+	reply.Rating = DefaultRating
+	if len(succeeded) > 0 {
+		reply.Rating = 100 * completed / int64(len(succeeded))
+	}
+
+	return reply
+}
+
+// Check verifies that the party with given Eth address has at least the
+// specified rating. Zero minimum rating means no requirements.
+func Check(ctx context.Context, client pb.RatingClient, ethAddr string, min int64) error {
+	if min <= 0 {
+		return nil
+	}
+
+	if client == nil {
+		return status.Errorf(codes.Unavailable, "rating service is not configured")
+	}
+
+	reply, err := client.GetRating(ctx, &pb.GetRatingRequest{EthAddr: ethAddr})
+	if err != nil {
+		return err
+	}
+
+	if reply.GetRating() < min {
+		return status.Errorf(codes.PermissionDenied, "rating of %s is %d, while at least %d is required",
+			ethAddr, reply.GetRating(), min)
+	}
+
+	return nil
+}
+
+// Reporter signs deal outcomes with the given key and reports them to the
+// rating service.
+type Reporter struct {
+	key    *ecdsa.PrivateKey
+	client pb.RatingClient
+}
+
+// NewReporter constructs a new Reporter. Nil client makes it discard all
+// reports.
+func NewReporter(key *ecdsa.PrivateKey, client pb.RatingClient) *Reporter {
+	return &Reporter{key: key, client: client}
+}
+
+// Report records the outcome of the deal made with the given subject.
+// Each party is able to report the deal only once, so the final outcome
+// must be reported. Errors are logged only, because ratings must never break the deal flow.
+func (r *Reporter) Report(ctx context.Context, dealID, subject string, outcome pb.DealOutcome, details string) {
+	if r == nil || r.client == nil {
+		return
+	}
+
+	entry, err := NewEntry(r.key, dealID, subject, outcome, details)
+	if err != nil {
+		log.G(ctx).Warn("cannot sign rating entry", zap.Error(err))
+		return
+	}
+
+	if _, err := r.client.Report(ctx, entry); err != nil {
+		log.G(ctx).Warn("cannot report deal outcome",
+			zap.String("dealID", dealID),
+			zap.String("subject", subject),
+			zap.Stringer("outcome", outcome),
+			zap.Error(err),
+		)
+		return
+	}
+
+	log.G(ctx).Debug("deal outcome reported",
+		zap.String("dealID", dealID),
+		zap.String("subject", subject),
+		zap.Stringer("outcome", outcome),
+	)
+}
@@ -0,0 +1,58 @@
+package rating
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const subject = "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD"
+
+func TestEntrySignVerify(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	entry, err := NewEntry(key, "42", subject, pb.DealOutcome_TASK_FAILED, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, util.PubKeyToAddr(key.PublicKey).Hex(), entry.GetReporter())
+	assert.NoError(t, Verify(entry))
+
+	entry.Outcome = pb.DealOutcome_COMPLETED
+	assert.Equal(t, errInvalidSignature, Verify(entry))
+}
+
+func TestEntryVerifySelfReport(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	entry, err := NewEntry(key, "42", util.PubKeyToAddr(key.PublicKey).Hex(), pb.DealOutcome_COMPLETED, "")
+	require.NoError(t, err)
+	assert.Equal(t, errSelfReport, Verify(entry))
+}
+
+func TestCompute(t *testing.T) {
+	entries := []*pb.RatingEntry{
+		{DealID: "1", Outcome: pb.DealOutcome_COMPLETED},
+		{DealID: "2", Outcome: pb.DealOutcome_COMPLETED},
+		{DealID: "2", Outcome: pb.DealOutcome_TASK_FAILED, Details: "task-1"},
+		{DealID: "3", Outcome: pb.DealOutcome_SLA_BREACH},
+		{DealID: "4", Outcome: pb.DealOutcome_COMPLETED},
+	}
+
+	reply := Compute(subject, entries)
+	assert.Equal(t, int64(50), reply.GetRating())
+	assert.Equal(t, uint64(3), reply.GetOutcomes()["COMPLETED"])
+	assert.Equal(t, uint64(1), reply.GetOutcomes()["TASK_FAILED"])
+	assert.Equal(t, uint64(1), reply.GetOutcomes()["SLA_BREACH"])
+
+	assert.Equal(t, int64(DefaultRating), Compute(subject, nil).GetRating())
+}
+
+func TestCheckWithoutRequirements(t *testing.T) {
+	assert.NoError(t, Check(nil, nil, subject, 0))
+	assert.Error(t, Check(nil, nil, subject, 10))
+}
@@ -0,0 +1,95 @@
+package relay
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+)
+
+// Dial connects to the server with the given ETH address through the relay
+// server located at the specified endpoint.
+//
+// Blocks until the server is matched or the relay gives up waiting for it.
+func Dial(ctx context.Context, endpoint auth.Endpoint, credentials credentials.TransportCredentials, addr common.Address) (net.Conn, error) {
+	return connect(ctx, endpoint, credentials, &sonm.RelayHandshake{
+		PeerType: sonm.RelayHandshake_CLIENT,
+		Addr:     addr.Hex(),
+	})
+}
+
+// Accept publishes the caller as a server on the relay server located at the
+// specified endpoint, blocking until a client connects.
+//
+// The caller is identified by the ETH address of its credentials.
+func Accept(ctx context.Context, endpoint auth.Endpoint, credentials credentials.TransportCredentials) (net.Conn, error) {
+	return connect(ctx, endpoint, credentials, &sonm.RelayHandshake{
+		PeerType: sonm.RelayHandshake_SERVER,
+	})
+}
+
+func connect(ctx context.Context, endpoint auth.Endpoint, credentials credentials.TransportCredentials, request *sonm.RelayHandshake) (net.Conn, error) {
+	dialer := net.Dialer{KeepAlive: tcpKeepAliveInterval}
+	rawConn, err := dialer.DialContext(ctx, "tcp", endpoint.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Interrupt blocking operations when the context is cancelled.
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	done := make(chan struct{})
+	go func() {
+		defer wg.Done()
+		select {
+		case <-ctx.Done():
+			rawConn.SetDeadline(time.Unix(1, 0))
+		case <-done:
+		}
+	}()
+
+	conn, err := handshake(ctx, rawConn, endpoint, credentials, request)
+
+	close(done)
+	wg.Wait()
+
+	if err != nil {
+		rawConn.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+
+	rawConn.SetDeadline(time.Time{})
+
+	return conn, nil
+}
+
+func handshake(ctx context.Context, rawConn net.Conn, endpoint auth.Endpoint, credentials credentials.TransportCredentials, request *sonm.RelayHandshake) (net.Conn, error) {
+	conn, _, err := auth.NewWalletAuthenticator(credentials, endpoint.EthAddress).ClientHandshake(ctx, endpoint.Endpoint, rawConn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeFrame(conn, request); err != nil {
+		return nil, err
+	}
+
+	reply := &sonm.RelayHandshakeReply{}
+	if err := readFrame(conn, reply); err != nil {
+		return nil, err
+	}
+
+	if len(reply.GetError()) > 0 {
+		return nil, errors.New(reply.GetError())
+	}
+
+	return conn, nil
+}
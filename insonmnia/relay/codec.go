@@ -0,0 +1,48 @@
+package relay
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// maxFrameSize limits the size of handshake frames to prevent peers from
+// exhausting the server's memory.
+const maxFrameSize = 4096
+
+// writeFrame writes the given message prefixed with its length.
+func writeFrame(wr io.Writer, message proto.Message) error {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	frame := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(frame, uint32(len(data)))
+	copy(frame[4:], data)
+
+	_, err = wr.Write(frame)
+	return err
+}
+
+// readFrame reads a length-prefixed message written by writeFrame.
+func readFrame(rd io.Reader, message proto.Message) error {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(rd, header); err != nil {
+		return err
+	}
+
+	size := binary.BigEndian.Uint32(header)
+	if size > maxFrameSize {
+		return fmt.Errorf("frame size %d exceeds the limit of %d bytes", size, maxFrameSize)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(rd, data); err != nil {
+		return err
+	}
+
+	return proto.Unmarshal(data, message)
+}
@@ -0,0 +1,37 @@
+package relay
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+)
+
+type options struct {
+	log         *zap.Logger
+	credentials credentials.TransportCredentials
+}
+
+func newOptions() *options {
+	return &options{
+		log: zap.NewNop(),
+	}
+}
+
+// Option is a function that configures the server.
+type Option func(options *options)
+
+// WithLogger is an option that specifies provided logger used for the internal
+// logging.
+func WithLogger(log *zap.Logger) Option {
+	return func(options *options) {
+		options.log = log
+	}
+}
+
+// WithCredentials is an option that specifies transport credentials used for
+// authenticating peers. Note that we use ETH based TLS credentials, because
+// servers are identified by their ETH addresses.
+func WithCredentials(credentials credentials.TransportCredentials) Option {
+	return func(options *options) {
+		options.credentials = credentials
+	}
+}
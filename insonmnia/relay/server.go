@@ -0,0 +1,420 @@
+// Relay server implementation.
+//
+// Relay is the last resort for connecting peers when NAT penetration fails,
+// for example when one of them is located behind a symmetric NAT. Both peers
+// connect to the relay server, authenticating with their ETH based TLS
+// credentials. Servers wait for clients under their ETH address, while
+// clients specify the address of the server they want to connect to. Once
+// matched, the relay splices both TCP streams transparently, so peers can
+// establish their own secure session over the relayed connection.
+
+package relay
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	handshakeTimeout     = 30 * time.Second
+	tcpKeepAliveInterval = 15 * time.Second
+)
+
+// candidate is a peer waiting for its counterpart.
+type candidate struct {
+	conn net.Conn
+	// C receives the counterpart connection when matched.
+	C chan net.Conn
+}
+
+func newCandidate(conn net.Conn) *candidate {
+	return &candidate{conn: conn, C: make(chan net.Conn, 1)}
+}
+
+type meeting struct {
+	servers []*candidate
+	clients []*candidate
+}
+
+func (m *meeting) queue(peerType sonm.RelayHandshake_PeerType) *[]*candidate {
+	if peerType == sonm.RelayHandshake_SERVER {
+		return &m.servers
+	}
+
+	return &m.clients
+}
+
+func (m *meeting) empty() bool {
+	return len(m.servers) == 0 && len(m.clients) == 0
+}
+
+// Server represents a relay server.
+type Server struct {
+	cfg         Config
+	log         *zap.Logger
+	credentials credentials.TransportCredentials
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	listener net.Listener
+	meetings map[common.Address]*meeting
+}
+
+// NewServer constructs a new relay server using specified config and
+// options.
+//
+// Transport credentials passed using WithCredentials option are required,
+// because peers are identified by their ETH addresses.
+func NewServer(cfg Config, options ...Option) (*Server, error) {
+	opts := newOptions()
+	for _, option := range options {
+		option(opts)
+	}
+
+	if opts.credentials == nil {
+		return nil, errors.New("transport credentials are required")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Server{
+		cfg:         cfg,
+		log:         opts.log,
+		credentials: opts.credentials,
+		ctx:         ctx,
+		cancel:      cancel,
+		meetings:    map[common.Address]*meeting{},
+	}, nil
+}
+
+// Run starts accepting incoming connections, serving them by blocking the
+// caller execution context until either explicitly terminated using Stop
+// or some critical error occurred.
+//
+// Always returns non-nil error.
+func (m *Server) Run() error {
+	listener, err := net.Listen(m.cfg.Addr.Network(), m.cfg.Addr.String())
+	if err != nil {
+		return err
+	}
+
+	return m.Serve(listener)
+}
+
+// Serve accepts incoming connections on the given listener.
+//
+// Always returns non-nil error.
+func (m *Server) Serve(listener net.Listener) error {
+	m.mu.Lock()
+	m.listener = listener
+	m.mu.Unlock()
+
+	m.log.Info("relay is ready to serve", zap.Stringer("endpoint", listener.Addr()))
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		if tcpConn, ok := conn.(*net.TCPConn); ok {
+			tcpConn.SetKeepAlive(true)
+			tcpConn.SetKeepAlivePeriod(tcpKeepAliveInterval)
+		}
+
+		go m.serve(conn)
+	}
+}
+
+func (m *Server) serve(rawConn net.Conn) {
+	log := m.log.With(zap.Stringer("remote", rawConn.RemoteAddr()))
+
+	peerType, addr, conn, err := m.handshake(rawConn)
+	if err != nil {
+		log.Warn("handshake failed", zap.Error(err))
+		rawConn.Close()
+		return
+	}
+
+	log.Info("peer connected", zap.Stringer("type", peerType), zap.Stringer("addr", addr))
+
+	m.meet(conn, peerType, addr)
+}
+
+// handshake authenticates the peer and reads its role.
+func (m *Server) handshake(rawConn net.Conn) (sonm.RelayHandshake_PeerType, common.Address, net.Conn, error) {
+	rawConn.SetDeadline(time.Now().Add(handshakeTimeout))
+
+	conn, authInfo, err := m.credentials.ServerHandshake(rawConn)
+	if err != nil {
+		return 0, common.Address{}, nil, err
+	}
+
+	info, ok := authInfo.(auth.EthAuthInfo)
+	if !ok {
+		return 0, common.Address{}, nil, fmt.Errorf("unsupported auth info %T", authInfo)
+	}
+
+	request := &sonm.RelayHandshake{}
+	if err := readFrame(conn, request); err != nil {
+		return 0, common.Address{}, nil, err
+	}
+
+	conn.SetDeadline(time.Time{})
+
+	switch request.GetPeerType() {
+	case sonm.RelayHandshake_SERVER:
+		return request.GetPeerType(), info.Wallet, conn, nil
+	case sonm.RelayHandshake_CLIENT:
+		if !common.IsHexAddress(request.GetAddr()) {
+			writeFrame(conn, &sonm.RelayHandshakeReply{Error: "invalid server address"})
+			return 0, common.Address{}, nil, fmt.Errorf("invalid server address: %s", request.GetAddr())
+		}
+
+		return request.GetPeerType(), common.HexToAddress(request.GetAddr()), conn, nil
+	default:
+		return 0, common.Address{}, nil, fmt.Errorf("unknown peer type: %s", request.GetPeerType())
+	}
+}
+
+// meet matches the peer with a waiting counterpart, otherwise the peer
+// waits for it. Clients wait for the configured timeout, while servers wait
+// until they disconnect.
+func (m *Server) meet(conn net.Conn, peerType sonm.RelayHandshake_PeerType, addr common.Address) {
+	m.mu.Lock()
+	if other := m.pop(opposite(peerType), addr); other != nil {
+		m.mu.Unlock()
+		other.C <- conn
+		return
+	}
+
+	c := newCandidate(conn)
+	m.push(peerType, addr, c)
+	m.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if peerType == sonm.RelayHandshake_CLIENT && m.cfg.WaitTimeout > 0 {
+		timer := time.NewTimer(m.cfg.WaitTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	disconnected := watch(conn)
+
+	select {
+	case peer := <-c.C:
+		m.matched(conn, peer, peerType, addr, disconnected)
+	case err := <-disconnected:
+		m.log.Info("peer has disconnected while waiting", zap.Stringer("addr", addr), zap.Error(err))
+		conn.Close()
+		if peer := m.abandon(c, peerType, addr); peer != nil {
+			m.meet(peer, opposite(peerType), addr)
+		}
+	case <-timeout:
+		if peer := m.abandon(c, peerType, addr); peer != nil {
+			m.matched(conn, peer, peerType, addr, disconnected)
+			return
+		}
+
+		m.log.Info("no server appeared in time", zap.Stringer("addr", addr))
+		unwatch(conn, disconnected)
+		writeFrame(conn, &sonm.RelayHandshakeReply{Error: "no server available"})
+		conn.Close()
+	case <-m.ctx.Done():
+		if peer := m.abandon(c, peerType, addr); peer != nil {
+			peer.Close()
+		}
+		conn.Close()
+	}
+}
+
+// matched starts relaying between the waiting peer and its counterpart,
+// making the counterpart wait for another match if the waiting peer has gone
+// meanwhile.
+func (m *Server) matched(conn, peer net.Conn, peerType sonm.RelayHandshake_PeerType, addr common.Address, disconnected <-chan error) {
+	if err := unwatch(conn, disconnected); err != nil {
+		m.log.Info("peer has disconnected while waiting", zap.Stringer("addr", addr), zap.Error(err))
+		conn.Close()
+		m.meet(peer, opposite(peerType), addr)
+		return
+	}
+
+	if peerType == sonm.RelayHandshake_SERVER {
+		m.relay(addr, conn, peer)
+	} else {
+		m.relay(addr, peer, conn)
+	}
+}
+
+// abandon removes the candidate from the waiting list. If the counterpart
+// has already taken the candidate, its connection is returned.
+func (m *Server) abandon(c *candidate, peerType sonm.RelayHandshake_PeerType, addr common.Address) net.Conn {
+	m.mu.Lock()
+	removed := m.remove(peerType, addr, c)
+	m.mu.Unlock()
+
+	if removed {
+		return nil
+	}
+
+	return <-c.C
+}
+
+// relay notifies both peers about successful matching and splices their
+// streams until either of them is closed.
+func (m *Server) relay(addr common.Address, server, client net.Conn) {
+	defer server.Close()
+	defer client.Close()
+
+	log := m.log.With(zap.Stringer("addr", addr),
+		zap.Stringer("server", server.RemoteAddr()),
+		zap.Stringer("client", client.RemoteAddr()),
+	)
+
+	if err := writeFrame(server, &sonm.RelayHandshakeReply{}); err != nil {
+		log.Warn("failed to notify server", zap.Error(err))
+		return
+	}
+	if err := writeFrame(client, &sonm.RelayHandshakeReply{}); err != nil {
+		log.Warn("failed to notify client", zap.Error(err))
+		return
+	}
+
+	log.Info("relaying connection")
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(server, client)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(client, server)
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-m.ctx.Done():
+	}
+
+	log.Info("relayed connection closed")
+}
+
+// Synchronized by `m.mu`.
+func (m *Server) push(peerType sonm.RelayHandshake_PeerType, addr common.Address, c *candidate) {
+	rv, ok := m.meetings[addr]
+	if !ok {
+		rv = &meeting{}
+		m.meetings[addr] = rv
+	}
+
+	queue := rv.queue(peerType)
+	*queue = append(*queue, c)
+}
+
+// Synchronized by `m.mu`.
+func (m *Server) pop(peerType sonm.RelayHandshake_PeerType, addr common.Address) *candidate {
+	rv, ok := m.meetings[addr]
+	if !ok {
+		return nil
+	}
+
+	queue := rv.queue(peerType)
+	if len(*queue) == 0 {
+		return nil
+	}
+
+	c := (*queue)[0]
+	*queue = (*queue)[1:]
+
+	if rv.empty() {
+		delete(m.meetings, addr)
+	}
+
+	return c
+}
+
+// Synchronized by `m.mu`.
+func (m *Server) remove(peerType sonm.RelayHandshake_PeerType, addr common.Address, c *candidate) bool {
+	rv, ok := m.meetings[addr]
+	if !ok {
+		return false
+	}
+
+	queue := rv.queue(peerType)
+	for id, waiting := range *queue {
+		if waiting == c {
+			*queue = append((*queue)[:id], (*queue)[id+1:]...)
+			if rv.empty() {
+				delete(m.meetings, addr)
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+// Stop stops the server, closing all relayed and pending connections.
+func (m *Server) Stop() {
+	m.log.Info("relay is shutting down")
+	m.cancel()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.listener != nil {
+		m.listener.Close()
+	}
+}
+
+func opposite(peerType sonm.RelayHandshake_PeerType) sonm.RelayHandshake_PeerType {
+	if peerType == sonm.RelayHandshake_SERVER {
+		return sonm.RelayHandshake_CLIENT
+	}
+
+	return sonm.RelayHandshake_SERVER
+}
+
+// watch detects whether the waiting peer disconnects by reading from its
+// connection. Peers must not send anything until matched, so any read
+// result means that the peer is gone.
+func watch(conn net.Conn) <-chan error {
+	c := make(chan error, 1)
+	go func() {
+		_, err := conn.Read(make([]byte, 1))
+		if err == nil {
+			err = errors.New("unexpected data received while waiting")
+		}
+		c <- err
+	}()
+
+	return c
+}
+
+// unwatch interrupts watching started by watch, returning an error if the
+// peer has disconnected meanwhile.
+func unwatch(conn net.Conn, c <-chan error) error {
+	conn.SetReadDeadline(time.Now())
+	err := <-c
+	conn.SetReadDeadline(time.Time{})
+
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,116 @@
+package relay
+
+import (
+	"crypto/ecdsa"
+	"crypto/tls"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+)
+
+func newCredentials(t *testing.T, key *ecdsa.PrivateKey) credentials.TransportCredentials {
+	cert, certKey, err := util.GenerateCert(key, time.Hour)
+	require.NoError(t, err)
+
+	crt, err := tls.X509KeyPair(cert, certKey)
+	require.NoError(t, err)
+
+	return util.NewTLS(&tls.Config{
+		Certificates:       []tls.Certificate{crt},
+		ClientAuth:         tls.RequireAnyClientCert,
+		InsecureSkipVerify: true,
+	})
+}
+
+func newTestServer(t *testing.T, waitTimeout time.Duration) (*Server, auth.Endpoint) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server, err := NewServer(Config{WaitTimeout: waitTimeout}, WithCredentials(newCredentials(t, key)))
+	require.NoError(t, err)
+
+	go server.Serve(listener)
+
+	return server, auth.Endpoint{
+		EthAddress: util.PubKeyToAddr(key.PublicKey),
+		Endpoint:   listener.Addr().String(),
+	}
+}
+
+func TestRelay(t *testing.T) {
+	server, endpoint := newTestServer(t, 5*time.Second)
+	defer server.Stop()
+
+	serverKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	clientKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := Accept(context.Background(), endpoint, newCredentials(t, serverKey))
+		assert.NoError(t, err)
+		accepted <- conn
+	}()
+
+	client, err := Dial(context.Background(), endpoint, newCredentials(t, clientKey), util.PubKeyToAddr(serverKey.PublicKey))
+	require.NoError(t, err)
+	defer client.Close()
+
+	peer := <-accepted
+	require.NotNil(t, peer)
+	defer peer.Close()
+
+	_, err = client.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(peer, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf))
+
+	_, err = peer.Write([]byte("pong"))
+	require.NoError(t, err)
+
+	_, err = io.ReadFull(client, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "pong", string(buf))
+}
+
+func TestRelayNoServer(t *testing.T) {
+	server, endpoint := newTestServer(t, 100*time.Millisecond)
+	defer server.Stop()
+
+	clientKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	conn, err := Dial(context.Background(), endpoint, newCredentials(t, clientKey), util.PubKeyToAddr(clientKey.PublicKey))
+	assert.Error(t, err)
+	assert.Nil(t, conn)
+}
+
+func TestRelayWrongRelayAddress(t *testing.T) {
+	server, endpoint := newTestServer(t, time.Second)
+	defer server.Stop()
+
+	clientKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	endpoint.EthAddress = util.PubKeyToAddr(clientKey.PublicKey)
+
+	conn, err := Dial(context.Background(), endpoint, newCredentials(t, clientKey), endpoint.EthAddress)
+	assert.Error(t, err)
+	assert.Nil(t, conn)
+}
@@ -0,0 +1,314 @@
+// Rendezvous cluster implementation.
+//
+// A single rendezvous server can match only peers connected to it. To spread
+// the load across several servers every node of the cluster announces itself
+// in the shared key-value storage, while each meeting point is owned by
+// exactly one node, chosen using highest random weight hashing over the
+// alive nodes. Peers connected to other nodes are forwarded to the owner,
+// which matches them as if they were connected directly.
+
+package rendezvous
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/docker/libkv"
+	"github.com/docker/libkv/store"
+	"github.com/docker/libkv/store/boltdb"
+	"github.com/docker/libkv/store/consul"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util/xgrpc"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// member describes a cluster node record saved in the storage.
+type member struct {
+	EthAddr  common.Address
+	Endpoint string
+	TS       time.Time
+}
+
+// ID returns the unique identifier of the node.
+func (m member) ID() string {
+	return fmt.Sprintf("%s@%s", m.EthAddr.Hex(), m.Endpoint)
+}
+
+type cluster struct {
+	cfg         ClusterConfig
+	log         *zap.Logger
+	credentials credentials.TransportCredentials
+	store       store.Store
+	self        member
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	members map[string]member
+	conns   map[string]*grpc.ClientConn
+}
+
+func newCluster(cfg ClusterConfig, key *ecdsa.PrivateKey, credentials credentials.TransportCredentials, log *zap.Logger) (*cluster, error) {
+	if key == nil {
+		return nil, errors.New("private key is required for clustering")
+	}
+
+	storage, err := newStore(cfg.Store)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	self := member{
+		EthAddr:  crypto.PubkeyToAddress(key.PublicKey),
+		Endpoint: cfg.Endpoint,
+	}
+
+	return &cluster{
+		cfg:         cfg,
+		log:         log,
+		credentials: credentials,
+		store:       storage,
+		self:        self,
+		ctx:         ctx,
+		cancel:      cancel,
+		members:     map[string]member{self.ID(): self},
+		conns:       map[string]*grpc.ClientConn{},
+	}, nil
+}
+
+func newStore(cfg StoreConfig) (store.Store, error) {
+	consul.Register()
+	boltdb.Register()
+
+	config := store.Config{
+		Bucket: cfg.Bucket,
+	}
+
+	return libkv.NewStore(store.Backend(cfg.Type), []string{cfg.Endpoint}, &config)
+}
+
+// Run periodically announces this node and refreshes the member list until
+// the cluster is closed.
+func (m *cluster) Run() error {
+	ticker := time.NewTicker(m.cfg.AnnounceInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.announce(); err != nil {
+			m.log.Warn("failed to announce rendezvous node", zap.Error(err))
+		}
+		if err := m.refresh(); err != nil {
+			m.log.Warn("failed to refresh rendezvous cluster members", zap.Error(err))
+		}
+
+		select {
+		case <-m.ctx.Done():
+			return m.ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *cluster) announce() error {
+	self := m.self
+	self.TS = time.Now()
+
+	data, err := json.Marshal(self)
+	if err != nil {
+		return err
+	}
+
+	return m.store.Put(m.memberKey(self), data, &store.WriteOptions{TTL: m.cfg.MemberTTL})
+}
+
+func (m *cluster) refresh() error {
+	pairs, err := m.store.List(m.cfg.MemberListKey)
+	if err != nil && err != store.ErrKeyNotFound {
+		return err
+	}
+
+	notBefore := time.Now().Add(-m.cfg.MemberTTL)
+	members := map[string]member{m.self.ID(): m.self}
+	for _, pair := range pairs {
+		if pair.Value == nil {
+			continue
+		}
+
+		node := member{}
+		if err := json.Unmarshal(pair.Value, &node); err != nil {
+			m.log.Warn("trash data in rendezvous cluster members", zap.String("key", pair.Key), zap.Error(err))
+			continue
+		}
+
+		if node.TS.Before(notBefore) {
+			continue
+		}
+
+		members[node.ID()] = node
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, conn := range m.conns {
+		if _, ok := members[id]; !ok {
+			conn.Close()
+			delete(m.conns, id)
+		}
+	}
+
+	m.members = members
+	return nil
+}
+
+func (m *cluster) memberKey(node member) string {
+	return m.cfg.MemberListKey + "/" + node.EthAddr.Hex() + "_" + node.Endpoint
+}
+
+// Owner returns the node that owns the meeting point with the given ID.
+// The second value is false if it is this node.
+func (m *cluster) Owner(id string) (member, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var owner member
+	var ownerWeight uint64
+	for nodeID, node := range m.members {
+		w := weight(nodeID, id)
+		if owner.Endpoint == "" || w > ownerWeight || (w == ownerWeight && nodeID < owner.ID()) {
+			owner = node
+			ownerWeight = w
+		}
+	}
+
+	return owner, owner.ID() != m.self.ID()
+}
+
+func weight(nodeID, id string) uint64 {
+	hash := sha256.Sum256([]byte(nodeID + "/" + id))
+	return binary.BigEndian.Uint64(hash[:8])
+}
+
+// IsMember checks whether the given ETH address belongs to one of the
+// cluster nodes.
+func (m *cluster) IsMember(addr common.Address) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, node := range m.members {
+		if node.EthAddr == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Client returns a client to the given cluster node, connecting to it if
+// required.
+func (m *cluster) Client(ctx context.Context, node member) (sonm.RendezvousClusterClient, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if conn, ok := m.conns[node.ID()]; ok {
+		return sonm.NewRendezvousClusterClient(conn), nil
+	}
+
+	conn, err := xgrpc.NewClient(ctx, node.Endpoint, auth.NewWalletAuthenticator(m.credentials, node.EthAddr))
+	if err != nil {
+		return nil, err
+	}
+
+	m.conns[node.ID()] = conn
+	return sonm.NewRendezvousClusterClient(conn), nil
+}
+
+// Close stops announcing this node and closes all connections to other
+// nodes.
+func (m *cluster) Close() error {
+	m.cancel()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, conn := range m.conns {
+		conn.Close()
+		delete(m.conns, id)
+	}
+
+	if err := m.store.Delete(m.memberKey(m.self)); err != nil && err != store.ErrKeyNotFound {
+		return err
+	}
+
+	return nil
+}
+
+// clusterServer handles requests forwarded by other nodes of the cluster.
+type clusterServer struct {
+	server *Server
+}
+
+func (m *clusterServer) Meet(ctx context.Context, request *sonm.MeetRequest) (*sonm.RendezvousReply, error) {
+	if err := m.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	addr, err := intoNetAddr(request.GetPublicAddr())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid public address: %v", err)
+	}
+
+	peerHandle := NewPeer(peer.Peer{Addr: addr}, request.GetPrivateAddrs())
+
+	m.server.log.Info("meeting forwarded peer",
+		zap.String("id", request.GetID()),
+		zap.Stringer("role", request.GetRole()),
+		zap.Stringer("public_addr", addr),
+	)
+
+	p, err := m.server.meet(ctx, request.GetID(), request.GetRole(), peerHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.server.newReply(p)
+}
+
+func (m *clusterServer) ResolveAll(ctx context.Context, request *sonm.ID) (*sonm.ResolveMetaReply, error) {
+	if err := m.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	return m.server.resolveAll(request.GetId())
+}
+
+func (m *clusterServer) authorize(ctx context.Context) error {
+	addr, err := auth.ExtractWalletFromContext(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if !m.server.cluster.IsMember(*addr) {
+		return status.Errorf(codes.PermissionDenied, "%s is not a member of the rendezvous cluster", addr.Hex())
+	}
+
+	return nil
+}
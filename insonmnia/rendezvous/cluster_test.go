@@ -0,0 +1,203 @@
+package rendezvous
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/docker/libkv/store"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// listStore is a storage serving the given pairs under any key.
+type listStore struct {
+	store.Store
+	pairs []*store.KVPair
+}
+
+func (m *listStore) List(directory string) ([]*store.KVPair, error) {
+	return m.pairs, nil
+}
+
+func newTestMember(id int64) member {
+	return member{
+		EthAddr:  common.BigToAddress(big.NewInt(id)),
+		Endpoint: fmt.Sprintf("10.0.0.%d:14099", id),
+	}
+}
+
+func newTestCluster(self member, others ...member) *cluster {
+	members := map[string]member{self.ID(): self}
+	for _, node := range others {
+		members[node.ID()] = node
+	}
+
+	return &cluster{
+		cfg: ClusterConfig{
+			MemberListKey: "sonm/rendezvous/list",
+			MemberTTL:     30 * time.Second,
+		},
+		log:         zap.NewNop(),
+		credentials: credentials.NewTLS(&tls.Config{InsecureSkipVerify: true}),
+		self:        self,
+		members:     members,
+		conns:       map[string]*grpc.ClientConn{},
+	}
+}
+
+func owners(c *cluster, ids []string) map[string]member {
+	result := map[string]member{}
+	for _, id := range ids {
+		owner, _ := c.Owner(id)
+		result[id] = owner
+	}
+
+	return result
+}
+
+func TestClusterOwnerStableOnMembershipChange(t *testing.T) {
+	var nodes []member
+	for id := int64(1); id <= 5; id++ {
+		nodes = append(nodes, newTestMember(id))
+	}
+
+	var ids []string
+	for id := 0; id < 1000; id++ {
+		ids = append(ids, fmt.Sprintf("0x%040x", id))
+	}
+
+	before := owners(newTestCluster(nodes[0], nodes[1:]...), ids)
+	for _, id := range ids {
+		owner, _ := newTestCluster(nodes[0], nodes[1:]...).Owner(id)
+		assert.Equal(t, before[id], owner, "owner must be deterministic")
+	}
+
+	// Only points taken by the joined node change their owner.
+	joined := newTestMember(6)
+	moved := 0
+	for id, owner := range owners(newTestCluster(nodes[0], append(nodes[1:], joined)...), ids) {
+		if owner != before[id] {
+			assert.Equal(t, joined, owner)
+			moved++
+		}
+	}
+	assert.True(t, moved > 0 && moved < len(ids)/2, "moved %d points", moved)
+
+	// Only points of the left node change their owner.
+	left := nodes[2]
+	for id, owner := range owners(newTestCluster(nodes[0], nodes[1], nodes[3], nodes[4]), ids) {
+		if before[id] == left {
+			assert.NotEqual(t, left, owner)
+		} else {
+			assert.Equal(t, before[id], owner)
+		}
+	}
+}
+
+func TestClusterOwnerIsSelf(t *testing.T) {
+	self := newTestMember(1)
+	owner, remote := newTestCluster(self).Owner("0x0")
+	assert.Equal(t, self, owner)
+	assert.False(t, remote)
+}
+
+func TestClusterRefreshExpiresStaleMembers(t *testing.T) {
+	self := newTestMember(1)
+	alive := newTestMember(2)
+	stale := newTestMember(3)
+
+	c := newTestCluster(self, alive, stale)
+
+	conn, err := grpc.Dial(stale.Endpoint, grpc.WithInsecure())
+	require.NoError(t, err)
+	c.conns[stale.ID()] = conn
+
+	pairs := []*store.KVPair{{Key: "trash", Value: []byte("{")}, {Key: "empty"}}
+	alive.TS = time.Now()
+	stale.TS = time.Now().Add(-time.Minute)
+	for _, node := range []member{alive, stale} {
+		data, err := json.Marshal(node)
+		require.NoError(t, err)
+		pairs = append(pairs, &store.KVPair{Key: c.memberKey(node), Value: data})
+	}
+	c.store = &listStore{pairs: pairs}
+
+	require.NoError(t, c.refresh())
+
+	assert.Len(t, c.members, 2)
+	assert.Contains(t, c.members, self.ID())
+	assert.Contains(t, c.members, alive.ID())
+	assert.NotContains(t, c.members, stale.ID())
+	assert.Empty(t, c.conns)
+	assert.True(t, c.IsMember(alive.EthAddr))
+	assert.False(t, c.IsMember(stale.EthAddr))
+}
+
+func TestForwardFallsBackToLocalMeeting(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := listener.Addr().String()
+	listener.Close()
+
+	self := newTestMember(1)
+	owner := member{EthAddr: common.BigToAddress(big.NewInt(2)), Endpoint: unreachable}
+	c := newTestCluster(self, owner)
+	defer func() {
+		for _, conn := range c.conns {
+			conn.Close()
+		}
+	}()
+
+	id := ""
+	for i := 0; ; i++ {
+		id = fmt.Sprintf("0x%040x", i)
+		if _, remote := c.Owner(id); remote {
+			break
+		}
+	}
+
+	server := &Server{log: zap.NewNop(), cluster: c}
+	peerHandle := NewPeer(peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(8, 8, 8, 8), Port: 14099}}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	reply, ok, err := server.forward(ctx, id, sonm.MeetRequest_SERVER, peerHandle)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, reply)
+}
+
+func TestClusterServerAuthorize(t *testing.T) {
+	self := newTestMember(1)
+	other := newTestMember(2)
+	server := &clusterServer{server: &Server{cluster: newTestCluster(self, other)}}
+
+	withWallet := func(addr common.Address) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: auth.EthAuthInfo{Wallet: addr}})
+	}
+
+	assert.NoError(t, server.authorize(withWallet(self.EthAddr)))
+	assert.NoError(t, server.authorize(withWallet(other.EthAddr)))
+
+	err := server.authorize(withWallet(newTestMember(3).EthAddr))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	err = server.authorize(context.Background())
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -3,6 +3,7 @@ package rendezvous
 import (
 	"crypto/ecdsa"
 	"net"
+	"time"
 
 	"github.com/jinzhu/configor"
 	"github.com/sonm-io/core/accounts"
@@ -16,12 +17,40 @@ type LoggingConfig struct {
 	level zapcore.Level
 }
 
+// StoreConfig represents a shared key-value storage config.
+type StoreConfig struct {
+	Type     string `required:"true" default:"consul" yaml:"type"`
+	Endpoint string `required:"true" yaml:"endpoint"`
+	Bucket   string `required:"true" default:"sonm" yaml:"bucket"`
+}
+
+// ClusterConfig represents a rendezvous cluster config.
+//
+// Nodes of the same cluster share their membership using the storage, while
+// each meeting point is owned by exactly one of them, so peers can meet each
+// other regardless of the node they are connected to.
+type ClusterConfig struct {
+	// Endpoint is the address other nodes use to reach this node.
+	Endpoint string      `required:"true" yaml:"endpoint"`
+	Store    StoreConfig `required:"true" yaml:"store"`
+	// MemberListKey is the storage key under which nodes are registered.
+	MemberListKey string `required:"true" default:"sonm/rendezvous/list" yaml:"member_list_key"`
+	// AnnounceInterval specifies how often the node announces itself.
+	AnnounceInterval time.Duration `required:"true" default:"10s" yaml:"announce_interval"`
+	// MemberTTL specifies how long a node is considered alive after its
+	// last announcement.
+	MemberTTL time.Duration `required:"true" default:"30s" yaml:"member_ttl"`
+}
+
 // Config represents a Rendezvous server configuration.
 type Config struct {
 	// Listening address.
 	Addr       net.Addr
 	PrivateKey *ecdsa.PrivateKey
 	Logging    LoggingConfig
+	// Cluster is an optional cluster config. Nil means that the server
+	// works standalone.
+	Cluster *ClusterConfig
 }
 
 // LogLevel returns the minimum logging level configured.
@@ -33,6 +62,7 @@ type config struct {
 	Addr    string             `yaml:"endpoint" required:"true"`
 	Eth     accounts.EthConfig `yaml:"ethereum"`
 	Logging LoggingConfig      `yaml:"logging"`
+	Cluster *ClusterConfig     `yaml:"cluster"`
 }
 
 // NewConfig loads a new Rendezvous server config from a file.
@@ -63,5 +93,6 @@ func NewConfig(path string) (*Config, error) {
 		Addr:       addr,
 		PrivateKey: privateKey,
 		Logging:    cfg.Logging,
+		Cluster:    cfg.Cluster,
 	}, nil
 }
@@ -0,0 +1,144 @@
+// UDP address reflection.
+//
+// Unlike TCP, public addresses of UDP sockets cannot be observed from the
+// gRPC connection, so peers willing to punch UDP holes must discover them
+// beforehand. The rendezvous server listens for UDP datagrams on the same
+// port as for gRPC requests, replying with the source address observed,
+// which is the public address of the socket if it is located behind a NAT.
+//
+// Requests are not authenticated, so they are padded to a fixed size, which
+// is never exceeded by replies, while shorter requests are dropped. This
+// keeps the server useless for traffic amplification with spoofed sources.
+
+package rendezvous
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+)
+
+const (
+	reflectAttempts = 3
+	reflectTimeout  = 500 * time.Millisecond
+	reflectTxIDSize = 8
+	// reflectRequestSize is the size of padded requests, which is enough
+	// for replies with any IPv6 address.
+	reflectRequestSize = 128
+)
+
+var reflectMagic = []byte("SONMRV")
+
+// reflectHeaderSize is the size of the magic and the transaction ID, which
+// are echoed in replies.
+var reflectHeaderSize = len(reflectMagic) + reflectTxIDSize
+
+// serveReflection replies to reflection requests received on the given
+// connection until it is closed.
+func (m *Server) serveReflection(conn net.PacketConn) error {
+	buf := make([]byte, 1500)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+
+		packet := buf[:n]
+		if n != reflectRequestSize || !bytes.HasPrefix(packet, reflectMagic) {
+			continue
+		}
+
+		reply, err := newReflectionReply(packet, addr)
+		if err != nil {
+			m.log.Warn("failed to construct reflection reply", zap.Stringer("addr", addr), zap.Error(err))
+			continue
+		}
+
+		if _, err := conn.WriteTo(reply, addr); err != nil {
+			m.log.Warn("failed to send reflection reply", zap.Stringer("addr", addr), zap.Error(err))
+		}
+	}
+}
+
+// newReflectionReply constructs the reply to the given request, which is
+// never larger than the request itself.
+func newReflectionReply(request []byte, addr net.Addr) ([]byte, error) {
+	publicAddr, err := sonm.NewAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(publicAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := append(append([]byte{}, request[:reflectHeaderSize]...), data...)
+	if len(reply) > len(request) {
+		return nil, errors.New("reflection reply exceeds request size")
+	}
+
+	return reply, nil
+}
+
+// ReflectUDPAddr discovers the public address of the given UDP socket using
+// the rendezvous server located at the specified address.
+//
+// The socket must not be read concurrently while discovering.
+func ReflectUDPAddr(ctx context.Context, conn *net.UDPConn, addr *net.UDPAddr) (*net.UDPAddr, error) {
+	request := make([]byte, reflectRequestSize)
+	copy(request, reflectMagic)
+	if _, err := rand.Read(request[len(reflectMagic):reflectHeaderSize]); err != nil {
+		return nil, err
+	}
+	header := request[:reflectHeaderSize]
+
+	defer conn.SetReadDeadline(time.Time{})
+
+	buf := make([]byte, 1500)
+	for attempt := 0; attempt < reflectAttempts; attempt++ {
+		if _, err := conn.WriteToUDP(request, addr); err != nil {
+			return nil, err
+		}
+
+		deadline := time.Now().Add(reflectTimeout)
+		if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+			deadline = ctxDeadline
+		}
+		conn.SetReadDeadline(deadline)
+
+		for {
+			n, _, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+					break
+				}
+				return nil, err
+			}
+
+			if n <= len(header) || !bytes.Equal(buf[:len(header)], header) {
+				continue
+			}
+
+			publicAddr := &sonm.Addr{}
+			if err := proto.Unmarshal(buf[len(header):n], publicAddr); err != nil {
+				return nil, err
+			}
+
+			return publicAddr.IntoUDP()
+		}
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	return nil, errors.New("no reflection reply received")
+}
@@ -0,0 +1,70 @@
+package rendezvous
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newUDPSocket(t *testing.T) *net.UDPConn {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	return conn
+}
+
+func TestReflectUDPAddr(t *testing.T) {
+	serverConn := newUDPSocket(t)
+	defer serverConn.Close()
+
+	server := &Server{log: zap.NewNop()}
+	go server.serveReflection(serverConn)
+
+	conn := newUDPSocket(t)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	addr, err := ReflectUDPAddr(ctx, conn, serverConn.LocalAddr().(*net.UDPAddr))
+	require.NoError(t, err)
+	assert.Equal(t, conn.LocalAddr().String(), addr.String())
+}
+
+func TestReflectionDropsShortRequests(t *testing.T) {
+	serverConn := newUDPSocket(t)
+	defer serverConn.Close()
+
+	server := &Server{log: zap.NewNop()}
+	go server.serveReflection(serverConn)
+
+	conn := newUDPSocket(t)
+	defer conn.Close()
+
+	request := make([]byte, reflectHeaderSize)
+	copy(request, reflectMagic)
+	_, err := conn.WriteToUDP(request, serverConn.LocalAddr().(*net.UDPAddr))
+	require.NoError(t, err)
+
+	conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	_, _, err = conn.ReadFromUDP(make([]byte, 1500))
+	require.Error(t, err)
+	netErr, ok := err.(net.Error)
+	require.True(t, ok)
+	assert.True(t, netErr.Timeout())
+}
+
+func TestReflectionReplyNeverExceedsRequest(t *testing.T) {
+	request := make([]byte, reflectRequestSize)
+	copy(request, reflectMagic)
+
+	addr := &net.UDPAddr{IP: net.ParseIP("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"), Port: 65535, Zone: "eth0"}
+	reply, err := newReflectionReply(request, addr)
+	require.NoError(t, err)
+	assert.True(t, len(reply) <= len(request))
+	assert.Equal(t, request[:reflectHeaderSize], reply[:reflectHeaderSize])
+}
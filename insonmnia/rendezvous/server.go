@@ -26,7 +26,11 @@
 // they support.
 // Clients should specify the desired protocol and ID for resolution.
 //
-// Currently only TCP endpoints exchanging is supported.
+// Both TCP and UDP endpoints exchanging is supported. Public UDP addresses
+// are discovered by peers themselves using the address reflection.
+//
+// Several rendezvous servers can be joined into a cluster sharing their
+// meeting points, see cluster.go for details.
 // TODO: When resolving it's necessary to track also IP version. For example to be able not to return IPv6 when connecting socket is IPv4.
 
 package rendezvous
@@ -110,8 +114,12 @@ type Server struct {
 	server   *grpc.Server
 	resolver resolver
 
-	mu sync.Mutex
-	rv map[string]*meeting
+	mu      sync.Mutex
+	rv      map[string]*meeting
+	udpConn net.PacketConn
+
+	// Nil if the server works standalone.
+	cluster *cluster
 }
 
 // NewServer constructs a new rendezvous server using specified config and
@@ -141,6 +149,16 @@ func NewServer(cfg Config, options ...Option) (*Server, error) {
 
 	server.log.Debug("configured authentication settings", zap.Any("credentials", opts.credentials.Info()))
 
+	if cfg.Cluster != nil {
+		cluster, err := newCluster(*cfg.Cluster, cfg.PrivateKey, opts.credentials, opts.log)
+		if err != nil {
+			return nil, err
+		}
+
+		server.cluster = cluster
+		sonm.RegisterRendezvousClusterServer(server.server, &clusterServer{server: server})
+	}
+
 	sonm.RegisterRendezvousServer(server.server, server)
 	server.log.Debug("registered gRPC server")
 
@@ -157,32 +175,63 @@ func (m *Server) Resolve(ctx context.Context, request *sonm.ConnectRequest) (*so
 		return nil, errNoPeerInfo()
 	}
 
-	m.log.Info("resolving remote peer", zap.String("id", request.ID))
+	m.log.Info("resolving remote peer", zap.String("id", request.ID), zap.String("protocol", request.Protocol))
 
-	id := request.ID
-	peerHandle := NewPeer(*peerInfo, request.PrivateAddrs)
+	info, err := withPublicAddr(*peerInfo, request.Protocol, request.PublicAddr)
+	if err != nil {
+		return nil, err
+	}
 
-	c, deleter := m.addServerWatch(id, peerHandle)
-	defer deleter()
+	id := meetingID(request.ID, request.Protocol)
+	peerHandle := NewPeer(info, request.PrivateAddrs)
 
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	case p := <-c:
-		m.log.Info("providing remote server endpoint(s)",
-			zap.String("id", request.ID),
-			zap.Stringer("public_addr", p.Addr),
-			zap.Any("private_addrs", p.privateAddrs),
-		)
-		return m.newReply(p)
+	if reply, ok, err := m.forward(ctx, id, sonm.MeetRequest_CLIENT, peerHandle); ok {
+		return reply, err
 	}
+
+	p, err := m.meet(ctx, id, sonm.MeetRequest_CLIENT, peerHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	m.log.Info("providing remote server endpoint(s)",
+		zap.String("id", request.ID),
+		zap.Stringer("public_addr", p.Addr),
+		zap.Any("private_addrs", p.privateAddrs),
+	)
+	return m.newReply(p)
 }
 
 func (m *Server) ResolveAll(ctx context.Context, request *sonm.ID) (*sonm.ResolveMetaReply, error) {
+	if m.cluster != nil {
+		if owner, remote := m.cluster.Owner(request.Id); remote {
+			reply, err := m.resolveAllRemote(ctx, owner, request)
+			if err == nil || ctx.Err() != nil {
+				return reply, err
+			}
+
+			m.log.Warn("failed to resolve peers on the meeting point owner, resolving locally",
+				zap.String("owner", owner.ID()), zap.Error(err))
+		}
+	}
+
+	return m.resolveAll(request.Id)
+}
+
+func (m *Server) resolveAllRemote(ctx context.Context, owner member, request *sonm.ID) (*sonm.ResolveMetaReply, error) {
+	client, err := m.cluster.Client(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.ResolveAll(ctx, request)
+}
+
+func (m *Server) resolveAll(id string) (*sonm.ResolveMetaReply, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	meeting, ok := m.rv[request.Id]
+	meeting, ok := m.rv[id]
 	if !ok {
 		return nil, errPeerNotFound()
 	}
@@ -208,27 +257,139 @@ func (m *Server) Publish(ctx context.Context, request *sonm.PublishRequest) (*so
 		return nil, err
 	}
 
-	m.log.Info("publishing remote peer", zap.String("id", ethAddr.String()))
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	m.log.Info("publishing remote peer", zap.String("id", ethAddr.String()), zap.String("protocol", request.Protocol))
+
+	info, err := withPublicAddr(*peerInfo, request.Protocol, request.PublicAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	id := meetingID(ethAddr.String(), request.Protocol)
+	peerHandle := NewPeer(info, request.PrivateAddrs)
 
-	id := ethAddr.String()
-	peerHandle := NewPeer(*peerInfo, request.PrivateAddrs)
+	if reply, ok, err := m.forward(ctx, id, sonm.MeetRequest_SERVER, peerHandle); ok {
+		return reply, err
+	}
 
-	c, deleter := m.newClientWatch(id, peerHandle)
+	p, err := m.meet(ctx, id, sonm.MeetRequest_SERVER, peerHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	m.log.Info("providing remote client endpoint(s)",
+		zap.String("id", ethAddr.String()),
+		zap.Stringer("public_addr", p.Addr),
+		zap.Any("private_addrs", p.privateAddrs),
+	)
+	return m.newReply(p)
+}
+
+// meet registers the peer at the local meeting point with the given ID,
+// waiting for its counterpart.
+func (m *Server) meet(ctx context.Context, id string, role sonm.MeetRequest_Role, peer Peer) (Peer, error) {
+	var c <-chan Peer
+	var deleter deleter
+	if role == sonm.MeetRequest_CLIENT {
+		c, deleter = m.addServerWatch(id, peer)
+	} else {
+		c, deleter = m.newClientWatch(id, peer)
+	}
 	defer deleter()
 
 	select {
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return Peer{}, ctx.Err()
 	case p := <-c:
-		m.log.Info("providing remote client endpoint(s)",
-			zap.String("id", ethAddr.String()),
-			zap.Stringer("public_addr", p.Addr),
-			zap.Any("private_addrs", p.privateAddrs),
-		)
-		return m.newReply(p)
+		return p, nil
 	}
 }
 
+// forward forwards the peer to the node owning the meeting point when the
+// server is clustered.
+//
+// Returns false if the meeting point is owned by this node or its owner is
+// unavailable, meaning that the peer should meet locally.
+func (m *Server) forward(ctx context.Context, id string, role sonm.MeetRequest_Role, peer Peer) (*sonm.RendezvousReply, bool, error) {
+	if m.cluster == nil {
+		return nil, false, nil
+	}
+
+	owner, remote := m.cluster.Owner(id)
+	if !remote {
+		return nil, false, nil
+	}
+
+	publicAddr, err := m.publicAddr(peer.Addr)
+	if err != nil {
+		return nil, true, err
+	}
+
+	client, err := m.cluster.Client(ctx, owner)
+	if err != nil {
+		m.log.Warn("failed to connect to the meeting point owner, meeting locally",
+			zap.String("id", id), zap.String("owner", owner.ID()), zap.Error(err))
+		return nil, false, nil
+	}
+
+	m.log.Info("forwarding peer to the meeting point owner",
+		zap.String("id", id), zap.Stringer("role", role), zap.String("owner", owner.ID()))
+
+	request := &sonm.MeetRequest{
+		ID:           id,
+		Role:         role,
+		PublicAddr:   publicAddr,
+		PrivateAddrs: peer.privateAddrs,
+	}
+
+	reply, err := client.Meet(ctx, request)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, true, ctx.Err()
+		}
+
+		m.log.Warn("meeting point owner has failed, meeting locally",
+			zap.String("id", id), zap.String("owner", owner.ID()), zap.Error(err))
+		return nil, false, nil
+	}
+
+	return reply, true, nil
+}
+
+// meetingID returns the ID of the meeting point for the given peer ID and
+// protocol. TCP peers meet under their plain IDs for compatibility reasons.
+func meetingID(id, protocol string) string {
+	if protocol == "tcp" {
+		return id
+	}
+
+	return protocol + "://" + id
+}
+
+// withPublicAddr replaces the peer address observed from the gRPC
+// connection with the one the peer has discovered itself for connectionless
+// protocols.
+func withPublicAddr(peerInfo peer.Peer, protocol string, addr *sonm.Addr) (peer.Peer, error) {
+	if protocol != "udp" {
+		return peerInfo, nil
+	}
+
+	if !addr.IsValid() {
+		return peerInfo, errors.New("public address is required for UDP")
+	}
+
+	publicAddr, err := addr.IntoUDP()
+	if err != nil {
+		return peerInfo, err
+	}
+
+	peerInfo.Addr = publicAddr
+	return peerInfo, nil
+}
+
 func (m *Server) addServerWatch(id string, peer Peer) (<-chan Peer, deleter) {
 	c := make(chan Peer, 1)
 
@@ -307,7 +468,19 @@ func (m *Server) maybeCleanMeeting(id string, candidates *meeting) {
 }
 
 func (m *Server) newReply(peer Peer) (*sonm.RendezvousReply, error) {
-	addr, err := sonm.NewAddr(peer.Addr)
+	addr, err := m.publicAddr(peer.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sonm.RendezvousReply{
+		PublicAddr:   addr,
+		PrivateAddrs: peer.privateAddrs,
+	}, nil
+}
+
+func (m *Server) publicAddr(peerAddr net.Addr) (*sonm.Addr, error) {
+	addr, err := sonm.NewAddr(peerAddr)
 	if err != nil {
 		return nil, err
 	}
@@ -325,10 +498,19 @@ func (m *Server) newReply(peer Peer) (*sonm.RendezvousReply, error) {
 		addr.Addr.Addr = publicIP.String()
 	}
 
-	return &sonm.RendezvousReply{
-		PublicAddr:   addr,
-		PrivateAddrs: peer.privateAddrs,
-	}, nil
+	return addr, nil
+}
+
+func intoNetAddr(addr *sonm.Addr) (net.Addr, error) {
+	if !addr.IsValid() {
+		return nil, errors.New("no address provided")
+	}
+
+	if addr.Protocol == "udp" {
+		return addr.IntoUDP()
+	}
+
+	return addr.IntoTCP()
 }
 
 func (m *Server) Info(ctx context.Context, request *sonm.Empty) (*sonm.RendezvousState, error) {
@@ -380,6 +562,22 @@ func (m *Server) Run() error {
 		return err
 	}
 
+	udpConn, err := net.ListenPacket("udp", m.cfg.Addr.String())
+	if err != nil {
+		listener.Close()
+		return err
+	}
+
+	m.mu.Lock()
+	m.udpConn = udpConn
+	m.mu.Unlock()
+
+	go m.serveReflection(udpConn)
+
+	if m.cluster != nil {
+		go m.cluster.Run()
+	}
+
 	m.log.Info("rendezvous is ready to serve", zap.Stringer("endpoint", listener.Addr()))
 	return m.server.Serve(listener)
 }
@@ -392,6 +590,19 @@ func (m *Server) Run() error {
 func (m *Server) Stop() {
 	m.log.Info("rendezvous is shutting down")
 	m.server.Stop()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.udpConn != nil {
+		m.udpConn.Close()
+	}
+
+	if m.cluster != nil {
+		if err := m.cluster.Close(); err != nil {
+			m.log.Warn("failed to leave the rendezvous cluster", zap.Error(err))
+		}
+	}
 }
 
 func errNoPeerInfo() error {
@@ -0,0 +1,188 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/opentracing/basictracer-go"
+)
+
+const (
+	exportQueueSize     = 4096
+	exportBatchSize     = 512
+	exportFlushInterval = 5 * time.Second
+	exportTimeout       = 10 * time.Second
+)
+
+// sink delivers encoded batches of spans.
+type sink interface {
+	Send(ctx context.Context, payload []byte) error
+	io.Closer
+}
+
+// exporter records sampled spans, exporting them in batches in the
+// background. Spans are dropped when the queue is full, so a slow or
+// unavailable collector never blocks traced operations.
+type exporter struct {
+	service string
+	sink    sink
+
+	spans chan basictracer.RawSpan
+	stop  chan struct{}
+	done  chan struct{}
+	once  sync.Once
+}
+
+func newExporter(service string, sink sink) *exporter {
+	e := &exporter{
+		service: service,
+		sink:    sink,
+		spans:   make(chan basictracer.RawSpan, exportQueueSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go e.run()
+
+	return e
+}
+
+func (e *exporter) RecordSpan(span basictracer.RawSpan) {
+	if !span.Context.Sampled {
+		return
+	}
+
+	select {
+	case e.spans <- span:
+	default:
+	}
+}
+
+// Close flushes queued spans and closes the sink.
+func (e *exporter) Close() error {
+	e.once.Do(func() {
+		close(e.stop)
+	})
+	<-e.done
+
+	return e.sink.Close()
+}
+
+func (e *exporter) run() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(exportFlushInterval)
+	defer ticker.Stop()
+
+	var batch []basictracer.RawSpan
+	for {
+		select {
+		case span := <-e.spans:
+			batch = append(batch, span)
+			if len(batch) < exportBatchSize {
+				continue
+			}
+		case <-ticker.C:
+		case <-e.stop:
+			for {
+				select {
+				case span := <-e.spans:
+					batch = append(batch, span)
+				default:
+					e.flush(batch)
+					return
+				}
+			}
+		}
+
+		e.flush(batch)
+		batch = nil
+	}
+}
+
+func (e *exporter) flush(batch []basictracer.RawSpan) {
+	if len(batch) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(encodeSpans(e.service, batch))
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	// There is nowhere to report export failures to without producing
+	// even more noise, because logs are traced too.
+	e.sink.Send(ctx, payload)
+}
+
+// httpSink posts batches to the OTLP/HTTP receiver.
+type httpSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+func newHTTPSink(endpoint string) *httpSink {
+	return &httpSink{
+		endpoint: endpoint,
+		client:   &http.Client{},
+	}
+}
+
+func (s *httpSink) Send(ctx context.Context, payload []byte) error {
+	request, err := http.NewRequest("POST", s.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := s.client.Do(request.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected HTTP status %d", response.StatusCode)
+	}
+
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	return nil
+}
+
+// fileSink appends batches to the file, one per line.
+type fileSink struct {
+	file *os.File
+}
+
+func newFileSink(path string) (*fileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileSink{file: file}, nil
+}
+
+func (s *fileSink) Send(ctx context.Context, payload []byte) error {
+	_, err := s.file.Write(append(payload, '\n'))
+	return err
+}
+
+func (s *fileSink) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,206 @@
+package tracing
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/opentracing/basictracer-go"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+)
+
+// OTLP span kinds and status codes.
+const (
+	otlpSpanKindInternal = 1
+	otlpSpanKindServer   = 2
+	otlpSpanKindClient   = 3
+	otlpSpanKindProducer = 4
+	otlpSpanKindConsumer = 5
+
+	otlpStatusError = 2
+)
+
+// The following types are the JSON encoding of the OTLP trace export
+// request, which is accepted by OTLP/HTTP receivers.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func encodeSpans(service string, spans []basictracer.RawSpan) *otlpRequest {
+	encoded := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		encoded = append(encoded, encodeSpan(span))
+	}
+
+	return &otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: []otlpKeyValue{newKeyValue("service.name", service)},
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/sonm-io/core"},
+				Spans: encoded,
+			}},
+		}},
+	}
+}
+
+func encodeSpan(span basictracer.RawSpan) otlpSpan {
+	encoded := otlpSpan{
+		// Trace IDs are 64-bit here, while OTLP requires 128-bit ones.
+		TraceID:           fmt.Sprintf("%032x", span.Context.TraceID),
+		SpanID:            fmt.Sprintf("%016x", span.Context.SpanID),
+		Name:              span.Operation,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.Start.Add(span.Duration).UnixNano(), 10),
+	}
+
+	if span.ParentSpanID != 0 {
+		encoded.ParentSpanID = fmt.Sprintf("%016x", span.ParentSpanID)
+	}
+
+	for key, value := range span.Tags {
+		switch key {
+		case string(ext.SpanKind):
+			encoded.Kind = spanKind(value)
+		case string(ext.Error):
+			if failed, ok := value.(bool); ok && failed {
+				encoded.Status.Code = otlpStatusError
+			}
+		default:
+			encoded.Attributes = append(encoded.Attributes, newKeyValue(key, value))
+		}
+	}
+
+	for _, record := range span.Logs {
+		event := otlpEvent{
+			TimeUnixNano: strconv.FormatInt(record.Timestamp.UnixNano(), 10),
+			Name:         "log",
+		}
+
+		for _, field := range record.Fields {
+			switch field.Key() {
+			case "event":
+				event.Name = fmt.Sprint(field.Value())
+			case "error":
+				encoded.Status.Message = fmt.Sprint(field.Value())
+				fallthrough
+			default:
+				event.Attributes = append(event.Attributes, encodeField(field))
+			}
+		}
+
+		encoded.Events = append(encoded.Events, event)
+	}
+
+	return encoded
+}
+
+func spanKind(value interface{}) int {
+	switch fmt.Sprint(value) {
+	case string(ext.SpanKindRPCServerEnum):
+		return otlpSpanKindServer
+	case string(ext.SpanKindRPCClientEnum):
+		return otlpSpanKindClient
+	case string(ext.SpanKindProducerEnum):
+		return otlpSpanKindProducer
+	case string(ext.SpanKindConsumerEnum):
+		return otlpSpanKindConsumer
+	default:
+		return otlpSpanKindInternal
+	}
+}
+
+func encodeField(field otlog.Field) otlpKeyValue {
+	return newKeyValue(field.Key(), field.Value())
+}
+
+func newKeyValue(key string, value interface{}) otlpKeyValue {
+	var v otlpAnyValue
+
+	switch value := value.(type) {
+	case bool:
+		v.BoolValue = &value
+	case int:
+		v.IntValue = formatInt(int64(value))
+	case int32:
+		v.IntValue = formatInt(int64(value))
+	case int64:
+		v.IntValue = formatInt(value)
+	case uint16:
+		v.IntValue = formatInt(int64(value))
+	case uint32:
+		v.IntValue = formatInt(int64(value))
+	case float32:
+		double := float64(value)
+		v.DoubleValue = &double
+	case float64:
+		v.DoubleValue = &value
+	default:
+		str := fmt.Sprint(value)
+		v.StringValue = &str
+	}
+
+	return otlpKeyValue{Key: key, Value: v}
+}
+
+func formatInt(value int64) *string {
+	str := strconv.FormatInt(value, 10)
+	return &str
+}
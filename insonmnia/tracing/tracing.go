@@ -0,0 +1,146 @@
+// Package tracing provides the OpenTracing tracer shared by SONM services,
+// exporting finished spans to the configured collector.
+package tracing
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/opentracing/basictracer-go"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+)
+
+const (
+	// ExporterNone disables exporting spans. Trace context is still
+	// propagated and logged.
+	ExporterNone = ""
+	// ExporterOTLP exports spans to the OTLP/HTTP collector, like the
+	// OpenTelemetry Collector or Jaeger.
+	ExporterOTLP = "otlp"
+	// ExporterJaeger is the alias of ExporterOTLP, because Jaeger accepts
+	// OTLP natively.
+	ExporterJaeger = "jaeger"
+	// ExporterFile writes spans to the file as OTLP JSON lines for offline
+	// use.
+	ExporterFile = "file"
+
+	DefaultOTLPEndpoint = "http://127.0.0.1:4318/v1/traces"
+)
+
+// Config describes tracing settings.
+type Config struct {
+	// Exporter is either "otlp", "jaeger", "file" or empty to disable
+	// exporting.
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the URL of the OTLP/HTTP traces receiver.
+	Endpoint string `yaml:"endpoint"`
+	// Path is the path of the file spans are written to.
+	Path string `yaml:"path"`
+	// SampleRate is the fraction of traces to export, from 0 to 1.
+	SampleRate float64 `yaml:"sample_rate" default:"1"`
+}
+
+// Validate checks the config, returning an error if it is malformed.
+func (c *Config) Validate() error {
+	switch c.Exporter {
+	case ExporterNone, ExporterOTLP, ExporterJaeger:
+	case ExporterFile:
+		if c.Path == "" {
+			return fmt.Errorf("path is required for the file trace exporter")
+		}
+	default:
+		return fmt.Errorf("unknown trace exporter \"%s\"", c.Exporter)
+	}
+
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		return fmt.Errorf("trace sample rate must be in [0, 1] range, but %v specified", c.SampleRate)
+	}
+
+	return nil
+}
+
+func init() {
+	// Spans are always created to carry trace context through services
+	// and logs, even if they are not exported.
+	opentracing.SetGlobalTracer(newTracer(discardRecorder{}, 1))
+}
+
+// Setup installs the global tracer, exporting spans of the named service as
+// configured. The returned closer flushes spans that are not exported yet.
+func Setup(service string, cfg Config) (io.Closer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	var exporter *exporter
+	switch cfg.Exporter {
+	case ExporterNone:
+		return nopCloser{}, nil
+	case ExporterOTLP, ExporterJaeger:
+		endpoint := cfg.Endpoint
+		if endpoint == "" {
+			endpoint = DefaultOTLPEndpoint
+		}
+		exporter = newExporter(service, newHTTPSink(endpoint))
+	case ExporterFile:
+		sink, err := newFileSink(cfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		exporter = newExporter(service, sink)
+	}
+
+	opentracing.SetGlobalTracer(newTracer(exporter, cfg.SampleRate))
+
+	return exporter, nil
+}
+
+// Tracer returns the tracer installed by Setup or the default one, which
+// does not export spans.
+func Tracer() opentracing.Tracer {
+	return opentracing.GlobalTracer()
+}
+
+// Finish finishes the span, marking it as failed if the error is not nil.
+func Finish(span opentracing.Span, err error) {
+	if err != nil {
+		ext.Error.Set(span, true)
+		span.LogFields(otlog.Error(err))
+	}
+
+	span.Finish()
+}
+
+func newTracer(recorder basictracer.SpanRecorder, sampleRate float64) opentracing.Tracer {
+	return basictracer.NewWithOptions(basictracer.Options{
+		ShouldSample:   sampler(sampleRate),
+		MaxLogsPerSpan: 100,
+		Recorder:       recorder,
+	})
+}
+
+// sampler samples traces by their IDs, which are random, so services agree
+// on the decision for the same trace.
+func sampler(rate float64) func(traceID uint64) bool {
+	if rate >= 1 {
+		return func(uint64) bool { return true }
+	}
+
+	threshold := uint64(rate * math.MaxUint64)
+	return func(traceID uint64) bool {
+		return traceID < threshold
+	}
+}
+
+type discardRecorder struct{}
+
+func (discardRecorder) RecordSpan(basictracer.RawSpan) {}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error {
+	return nil
+}
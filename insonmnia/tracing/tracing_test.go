@@ -0,0 +1,122 @@
+package tracing
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, (&Config{}).Validate())
+	assert.NoError(t, (&Config{Exporter: ExporterJaeger, SampleRate: 0.5}).Validate())
+	assert.Error(t, (&Config{Exporter: "zipkin"}).Validate())
+	assert.Error(t, (&Config{Exporter: ExporterFile}).Validate())
+	assert.Error(t, (&Config{SampleRate: 2}).Validate())
+}
+
+func TestSampler(t *testing.T) {
+	assert.True(t, sampler(1)(^uint64(0)))
+	assert.False(t, sampler(0)(0))
+	assert.True(t, sampler(0.5)(1))
+	assert.False(t, sampler(0.5)(^uint64(0)))
+}
+
+func TestExportOTLP(t *testing.T) {
+	requests := make(chan otlpRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := otlpRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests <- request
+	}))
+	defer server.Close()
+
+	exporter := newExporter("hub", newHTTPSink(server.URL))
+	tracer := newTracer(exporter, 1)
+
+	parent := tracer.StartSpan("parent")
+	child := tracer.StartSpan("child", opentracing.ChildOf(parent.Context()), opentracing.Tag{Key: "task_id", Value: "task"})
+	Finish(child, errors.New("failed"))
+	Finish(parent, nil)
+
+	require.NoError(t, exporter.Close())
+
+	request := <-requests
+	require.Len(t, request.ResourceSpans, 1)
+	assert.Equal(t, "hub", *request.ResourceSpans[0].Resource.Attributes[0].Value.StringValue)
+
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, spans[1].SpanID, spans[0].ParentSpanID)
+	assert.Equal(t, spans[1].TraceID, spans[0].TraceID)
+	assert.Len(t, spans[0].TraceID, 32)
+	assert.Equal(t, otlpStatusError, spans[0].Status.Code)
+	assert.Equal(t, "failed", spans[0].Status.Message)
+	assert.Equal(t, "task_id", spans[0].Attributes[0].Key)
+
+	assert.Equal(t, "parent", spans[1].Name)
+	assert.Empty(t, spans[1].ParentSpanID)
+	assert.Equal(t, 0, spans[1].Status.Code)
+}
+
+func TestExportFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tracing")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "spans.json")
+	sink, err := newFileSink(path)
+	require.NoError(t, err)
+
+	exporter := newExporter("worker", sink)
+	tracer := newTracer(exporter, 1)
+	tracer.StartSpan("overseer.Spool", opentracing.Tag{Key: "span.kind", Value: "client"}).Finish()
+
+	require.NoError(t, exporter.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	require.True(t, scanner.Scan())
+
+	request := otlpRequest{}
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &request))
+	span := request.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	assert.Equal(t, "overseer.Spool", span.Name)
+	assert.Equal(t, otlpSpanKindClient, span.Kind)
+	assert.False(t, scanner.Scan())
+}
+
+func TestExportSkipsUnsampled(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tracing")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "spans.json")
+	sink, err := newFileSink(path)
+	require.NoError(t, err)
+
+	exporter := newExporter("node", sink)
+	newTracer(exporter, 0).StartSpan("span").Finish()
+	require.NoError(t, exporter.Close())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
@@ -10,6 +10,7 @@ It is generated from these files:
 	capabilities.proto
 	container.proto
 	deal.proto
+	dht.proto
 	hub.proto
 	insonmnia.proto
 	locator.proto
@@ -18,6 +19,8 @@ It is generated from these files:
 	nat.proto
 	net.proto
 	node.proto
+	rating.proto
+	relay.proto
 	rendezvous.proto
 	timestamp.proto
 	volume.proto
@@ -34,7 +37,18 @@ It has these top-level messages:
 	GPUDevice
 	NetworkSpec
 	Container
+	HealthCheck
 	Deal
+	DealCloseRequest
+	DealSettlement
+	DealExtendRequest
+	DealAutoRenewRequest
+	DHTContact
+	DHTPingRequest
+	DHTFindRequest
+	DHTFindNodeReply
+	DHTFindValueReply
+	DHTStoreRequest
 	ListReply
 	HubStartTaskRequest
 	HubJoinNetworkRequest
@@ -54,8 +68,11 @@ It has these top-level messages:
 	GPUDeviceInfo
 	DevicesReply
 	InsertSlotRequest
+	PricingPolicy
 	PullTaskRequest
 	DealInfoReply
+	TaskRoute
+	RoutesReply
 	Empty
 	ID
 	TaskID
@@ -66,15 +83,23 @@ It has these top-level messages:
 	ResourceUsage
 	InfoReply
 	TaskStatusReply
+	EndpointHealth
 	AvailableResources
 	StatusMapReply
 	ContainerRestartPolicy
 	TaskLogsRequest
 	TaskLogsChunk
+	ExecRequest
+	TerminalSize
+	ExecReply
+	ExecExit
+	CopyFromRequest
 	DiscoverHubRequest
 	TaskResourceRequirements
 	Chunk
 	Progress
+	Endpoint
+	LocatorRecord
 	AnnounceRequest
 	ResolveRequest
 	ResolveReply
@@ -96,13 +121,28 @@ It has these top-level messages:
 	TaskListRequest
 	DealListRequest
 	DealListReply
+	DealGroup
 	DealStatusReply
+	ResourceClass
+	MarketStatsRequest
+	PriceStats
+	PriceHistoryPoint
+	PriceHistoryReply
+	SupplyDepthLevel
+	SupplyDepth
+	SupplyDepthReply
+	RatingEntry
+	GetRatingRequest
+	RatingReply
+	RelayHandshake
+	RelayHandshakeReply
 	ConnectRequest
 	PublishRequest
 	RendezvousReply
 	RendezvousState
 	RendezvousMeeting
 	ResolveMetaReply
+	MeetRequest
 	Timestamp
 	Volume
 */
@@ -335,6 +375,9 @@ type Order struct {
 	Slot *Slot `protobuf:"bytes,6,opt,name=slot" json:"slot,omitempty"`
 	// PricePerSecond specifies order price for ordered resources per second.
 	PricePerSecond *BigInt `protobuf:"bytes,7,opt,name=pricePerSecond" json:"pricePerSecond,omitempty"`
+	// Count specifies how many identical slots must be dealt at once for
+	// BID orders, all or nothing. Zero means a single slot.
+	Count uint64 `protobuf:"varint,8,opt,name=count" json:"count,omitempty"`
 }
 
 func (m *Order) Reset()                    { *m = Order{} }
@@ -384,6 +427,13 @@ func (m *Order) GetPricePerSecond() *BigInt {
 	return nil
 }
 
+func (m *Order) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Geo)(nil), "sonm.Geo")
 	proto.RegisterType((*Resources)(nil), "sonm.Resources")
@@ -395,39 +445,40 @@ func init() {
 func init() { proto.RegisterFile("bid.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 540 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x93, 0xdd, 0x8a, 0xd3, 0x40,
-	0x14, 0xc7, 0xcd, 0x47, 0x77, 0x9b, 0x93, 0xda, 0xd6, 0xc1, 0x8b, 0x50, 0x61, 0x2d, 0x45, 0x96,
-	0xb2, 0x60, 0x2f, 0xb2, 0x5e, 0x88, 0x20, 0x62, 0xad, 0x2c, 0x45, 0xd8, 0x96, 0xe9, 0x8a, 0x78,
-	0x99, 0x26, 0xb3, 0x61, 0xd8, 0xee, 0x4c, 0x98, 0xcc, 0x28, 0x79, 0x03, 0x1f, 0xc9, 0x27, 0xf1,
-	0x79, 0x64, 0x4e, 0x9a, 0xb4, 0xdb, 0xbb, 0x39, 0xbf, 0xf3, 0x9f, 0x9c, 0x8f, 0xff, 0x04, 0x82,
-	0x2d, 0xcf, 0x66, 0x85, 0x92, 0x5a, 0x12, 0xbf, 0x94, 0xe2, 0x71, 0xd4, 0xdb, 0xf2, 0x9c, 0x0b,
-	0x5d, 0xb3, 0xd1, 0x80, 0x0b, 0x4b, 0x05, 0x4f, 0x6a, 0x30, 0xf9, 0x01, 0xde, 0x0d, 0x93, 0x24,
-	0x82, 0xf3, 0x54, 0x1a, 0xa1, 0x55, 0x15, 0x39, 0x63, 0x67, 0x1a, 0xd0, 0x26, 0x24, 0x04, 0xfc,
-	0x94, 0xeb, 0x2a, 0x72, 0x11, 0xe3, 0x99, 0x0c, 0xc1, 0xdb, 0x25, 0x3a, 0xf2, 0xc6, 0xce, 0xd4,
-	0xa5, 0xf6, 0x88, 0x44, 0x8a, 0xc8, 0xdf, 0x13, 0x29, 0x26, 0x7f, 0x3c, 0x08, 0x28, 0x2b, 0xa5,
-	0x51, 0x29, 0x2b, 0xc9, 0x08, 0xba, 0x69, 0x61, 0xbe, 0x48, 0xc5, 0x4a, 0x2c, 0xe0, 0xd3, 0x36,
-	0xb6, 0x39, 0x95, 0x3c, 0xce, 0x2b, 0xcd, 0x4a, 0xac, 0xe2, 0xd3, 0x36, 0x26, 0x57, 0xd0, 0xcd,
-	0xad, 0xce, 0x88, 0xba, 0x5c, 0x3f, 0xee, 0xcf, 0xec, 0x00, 0xb3, 0x9b, 0xf5, 0x77, 0xa4, 0xb4,
-	0xcd, 0xdb, 0x19, 0x4a, 0x2d, 0x55, 0x92, 0x33, 0xec, 0xc3, 0xa7, 0x4d, 0x48, 0x26, 0xd0, 0x13,
-	0x4c, 0xdf, 0xa9, 0xe4, 0xfe, 0x9e, 0xa7, 0x4b, 0x11, 0x75, 0x30, 0xfd, 0x84, 0x91, 0x37, 0xf0,
-	0xfc, 0x10, 0xaf, 0x8c, 0x8e, 0xce, 0x50, 0xf4, 0x14, 0x92, 0x6b, 0x08, 0x05, 0xd3, 0xbf, 0xa5,
-	0x7a, 0xb8, 0xab, 0x0a, 0x16, 0x9d, 0x63, 0x4b, 0x2f, 0xea, 0x96, 0x6e, 0x0f, 0x09, 0x7a, 0xac,
-	0x22, 0x9f, 0x00, 0x0a, 0x25, 0x0b, 0xa6, 0x34, 0x67, 0x65, 0xd4, 0x1d, 0x7b, 0xd3, 0x30, 0x7e,
-	0x5d, 0xdf, 0x69, 0x37, 0x34, 0x5b, 0xb7, 0x8a, 0xaf, 0x76, 0xef, 0xf4, 0xe8, 0xca, 0xe8, 0x23,
-	0x0c, 0x4e, 0xd2, 0x76, 0xe1, 0x0f, 0xac, 0x31, 0xcb, 0x1e, 0xc9, 0x4b, 0xe8, 0xfc, 0x4a, 0x76,
-	0x86, 0xe1, 0x0e, 0x1d, 0x5a, 0x07, 0x1f, 0xdc, 0xf7, 0xce, 0xe4, 0xaf, 0x03, 0xfe, 0x66, 0x27,
-	0x35, 0x19, 0x43, 0xb8, 0x35, 0x15, 0x53, 0x34, 0xd1, 0x5c, 0xe4, 0x78, 0xd9, 0xa3, 0xc7, 0x88,
-	0x5c, 0x42, 0xbf, 0x34, 0x45, 0xb1, 0xe3, 0xad, 0xc8, 0x45, 0xd1, 0x09, 0x25, 0xaf, 0xc0, 0xcb,
-	0x99, 0x44, 0x4b, 0xc2, 0x38, 0xd8, 0x5b, 0xc2, 0x24, 0xb5, 0x94, 0xbc, 0x85, 0x40, 0x35, 0x73,
-	0xa1, 0x15, 0x61, 0x3c, 0x38, 0x19, 0x97, 0x1e, 0x14, 0xd6, 0xff, 0xcc, 0xa8, 0x44, 0x73, 0xd9,
-	0x38, 0xd3, 0xc6, 0x93, 0x7f, 0x0e, 0x74, 0x56, 0x2a, 0x63, 0x8a, 0xf4, 0xc1, 0xe5, 0xd9, 0x7e,
-	0x5e, 0x97, 0x67, 0xd6, 0xed, 0x6d, 0x65, 0x98, 0x5a, 0x2e, 0xf6, 0x4f, 0xb3, 0x09, 0xc9, 0x05,
-	0x40, 0xd3, 0xed, 0x72, 0x81, 0x2d, 0x06, 0xf4, 0x88, 0xd8, 0xf6, 0xa4, 0xfd, 0x24, 0x3a, 0xd8,
-	0x41, 0x07, 0xf7, 0xed, 0xad, 0x1a, 0x4c, 0x0f, 0x0a, 0x72, 0x01, 0x7e, 0xb9, 0x93, 0xf5, 0x7b,
-	0x08, 0x63, 0xa8, 0x95, 0x76, 0x9d, 0x14, 0x39, 0x79, 0x07, 0xfd, 0x42, 0xf1, 0x94, 0xad, 0x99,
-	0xda, 0xb0, 0x54, 0x8a, 0x0c, 0x5f, 0x45, 0x18, 0xf7, 0x6a, 0xe5, 0x9c, 0xe7, 0x4b, 0xa1, 0xe9,
-	0x89, 0xe6, 0xea, 0x12, 0x82, 0xb6, 0x1a, 0x39, 0x07, 0xef, 0xf3, 0xed, 0xcf, 0xe1, 0x33, 0x7b,
-	0x98, 0x2f, 0x17, 0x43, 0x07, 0xc9, 0xe6, 0xdb, 0xd0, 0xdd, 0x9e, 0xe1, 0x6f, 0x7a, 0xfd, 0x3f,
-	0x00, 0x00, 0xff, 0xff, 0x0b, 0xfe, 0x90, 0xe8, 0xd8, 0x03, 0x00, 0x00,
+	// 546 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x53, 0x5d, 0x8b, 0xd3, 0x40,
+	0x14, 0x35, 0x1f, 0xdd, 0x6d, 0x6e, 0x6a, 0x5b, 0x07, 0x1f, 0x42, 0x85, 0xb5, 0x14, 0x59, 0xca,
+	0x82, 0x7d, 0xc8, 0xfa, 0x20, 0x82, 0x88, 0xb5, 0xb2, 0x14, 0x61, 0x5b, 0xa6, 0x2b, 0xe2, 0x63,
+	0x9a, 0xcc, 0x86, 0x61, 0xbb, 0x33, 0x61, 0x32, 0x51, 0xf2, 0x0f, 0xfc, 0x49, 0xfe, 0x28, 0x7f,
+	0x84, 0xcc, 0x9d, 0x26, 0xed, 0xf6, 0xed, 0x9e, 0x73, 0xcf, 0x64, 0xce, 0xbd, 0x67, 0x02, 0xc1,
+	0x96, 0x67, 0xb3, 0x42, 0x49, 0x2d, 0x89, 0x5f, 0x4a, 0xf1, 0x38, 0xea, 0x6d, 0x79, 0xce, 0x85,
+	0xb6, 0xdc, 0x68, 0xc0, 0x85, 0x61, 0x05, 0x4f, 0x2c, 0x31, 0xf9, 0x01, 0xde, 0x0d, 0x93, 0x24,
+	0x82, 0xf3, 0x54, 0x56, 0x42, 0xab, 0x3a, 0x72, 0xc6, 0xce, 0x34, 0xa0, 0x0d, 0x24, 0x04, 0xfc,
+	0x94, 0xeb, 0x3a, 0x72, 0x91, 0xc6, 0x9a, 0x0c, 0xc1, 0xdb, 0x25, 0x3a, 0xf2, 0xc6, 0xce, 0xd4,
+	0xa5, 0xa6, 0x44, 0x46, 0x8a, 0xc8, 0xdf, 0x33, 0x52, 0x4c, 0xfe, 0x78, 0x10, 0x50, 0x56, 0xca,
+	0x4a, 0xa5, 0xac, 0x24, 0x23, 0xe8, 0xa6, 0x45, 0xf5, 0x45, 0x2a, 0x56, 0xe2, 0x05, 0x3e, 0x6d,
+	0xb1, 0xe9, 0xa9, 0xe4, 0x71, 0x5e, 0x6b, 0x56, 0xe2, 0x2d, 0x3e, 0x6d, 0x31, 0xb9, 0x82, 0x6e,
+	0x6e, 0x74, 0x95, 0xb0, 0xd7, 0xf5, 0xe3, 0xfe, 0xcc, 0x0c, 0x30, 0xbb, 0x59, 0x7f, 0x47, 0x96,
+	0xb6, 0x7d, 0x33, 0x43, 0xa9, 0xa5, 0x4a, 0x72, 0x86, 0x3e, 0x7c, 0xda, 0x40, 0x32, 0x81, 0x9e,
+	0x60, 0xfa, 0x4e, 0x25, 0xf7, 0xf7, 0x3c, 0x5d, 0x8a, 0xa8, 0x83, 0xed, 0x27, 0x1c, 0x79, 0x03,
+	0xcf, 0x0f, 0x78, 0x55, 0xe9, 0xe8, 0x0c, 0x45, 0x4f, 0x49, 0x72, 0x0d, 0xa1, 0x60, 0xfa, 0xb7,
+	0x54, 0x0f, 0x77, 0x75, 0xc1, 0xa2, 0x73, 0xb4, 0xf4, 0xc2, 0x5a, 0xba, 0x3d, 0x34, 0xe8, 0xb1,
+	0x8a, 0x7c, 0x02, 0x28, 0x94, 0x2c, 0x98, 0xd2, 0x9c, 0x95, 0x51, 0x77, 0xec, 0x4d, 0xc3, 0xf8,
+	0xb5, 0x3d, 0xd3, 0x6e, 0x68, 0xb6, 0x6e, 0x15, 0x5f, 0xcd, 0xde, 0xe9, 0xd1, 0x91, 0xd1, 0x47,
+	0x18, 0x9c, 0xb4, 0xcd, 0xc2, 0x1f, 0x58, 0x13, 0x96, 0x29, 0xc9, 0x4b, 0xe8, 0xfc, 0x4a, 0x76,
+	0x15, 0xc3, 0x1d, 0x3a, 0xd4, 0x82, 0x0f, 0xee, 0x7b, 0x67, 0xf2, 0xd7, 0x01, 0x7f, 0xb3, 0x93,
+	0x9a, 0x8c, 0x21, 0xdc, 0x56, 0x35, 0x53, 0x34, 0xd1, 0x5c, 0xe4, 0x78, 0xd8, 0xa3, 0xc7, 0x14,
+	0xb9, 0x84, 0x7e, 0x59, 0x15, 0xc5, 0x8e, 0xb7, 0x22, 0x17, 0x45, 0x27, 0x2c, 0x79, 0x05, 0x5e,
+	0xce, 0x24, 0x46, 0x12, 0xc6, 0xc1, 0x3e, 0x12, 0x26, 0xa9, 0x61, 0xc9, 0x5b, 0x08, 0x54, 0x33,
+	0x17, 0x46, 0x11, 0xc6, 0x83, 0x93, 0x71, 0xe9, 0x41, 0x61, 0xf2, 0xcf, 0x2a, 0x95, 0x68, 0x2e,
+	0x9b, 0x64, 0x5a, 0x3c, 0xf9, 0xe7, 0x40, 0x67, 0xa5, 0x32, 0xa6, 0x48, 0x1f, 0x5c, 0x9e, 0xed,
+	0xe7, 0x75, 0x79, 0x66, 0xd2, 0xde, 0xd6, 0x15, 0x53, 0xcb, 0xc5, 0xfe, 0x69, 0x36, 0x90, 0x5c,
+	0x00, 0x34, 0x6e, 0x97, 0x0b, 0xb4, 0x18, 0xd0, 0x23, 0xc6, 0xd8, 0x93, 0xe6, 0x93, 0x98, 0x60,
+	0x07, 0x13, 0xdc, 0xdb, 0x5b, 0x35, 0x34, 0x3d, 0x28, 0xc8, 0x05, 0xf8, 0xe5, 0x4e, 0xda, 0xf7,
+	0x10, 0xc6, 0x60, 0x95, 0x66, 0x9d, 0x14, 0x79, 0xf2, 0x0e, 0xfa, 0x85, 0xe2, 0x29, 0x5b, 0x33,
+	0xb5, 0x61, 0xa9, 0x14, 0x19, 0xbe, 0x8a, 0x30, 0xee, 0x59, 0xe5, 0x9c, 0xe7, 0x4b, 0xa1, 0xe9,
+	0x89, 0xc6, 0xa4, 0x85, 0x7f, 0x58, 0xd4, 0xc5, 0x89, 0x2d, 0xb8, 0xba, 0x84, 0xa0, 0xf5, 0x40,
+	0xce, 0xc1, 0xfb, 0x7c, 0xfb, 0x73, 0xf8, 0xcc, 0x14, 0xf3, 0xe5, 0x62, 0xe8, 0x20, 0xb3, 0xf9,
+	0x36, 0x74, 0xb7, 0x67, 0xf8, 0xf3, 0x5e, 0xff, 0x1f, 0x00, 0xda, 0x00, 0x1b, 0x48, 0xee, 0x03,
+	0x00, 0x00,
 }
@@ -0,0 +1,67 @@
+package sonm
+
+import (
+	"io"
+)
+
+// ChunkStream describes a bidirectional gRPC stream of chunks, like both
+// client and server sides of Miner.Forward.
+type ChunkStream interface {
+	Send(*Chunk) error
+	Recv() (*Chunk, error)
+}
+
+// ChunkReceiver describes a gRPC stream receiving chunks, like the client
+// side of Hub.CopyFrom.
+type ChunkReceiver interface {
+	Recv() (*Chunk, error)
+}
+
+type chunkReader struct {
+	stream ChunkReceiver
+	buf    []byte
+}
+
+// NewChunkReader wraps the given chunk stream, allowing to read it as a
+// plain byte stream.
+func NewChunkReader(stream ChunkReceiver) io.Reader {
+	return &chunkReader{stream: stream}
+}
+
+func (m *chunkReader) Read(p []byte) (int, error) {
+	for len(m.buf) == 0 {
+		chunk, err := m.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+
+		m.buf = chunk.GetChunk()
+	}
+
+	n := copy(p, m.buf)
+	m.buf = m.buf[n:]
+
+	return n, nil
+}
+
+type chunkReadWriter struct {
+	*chunkReader
+	stream ChunkStream
+}
+
+// NewChunkReadWriter wraps the given chunk stream, allowing to use it as a
+// plain byte stream.
+//
+// Note that reads and writes may be performed concurrently, but neither of
+// them is safe for concurrent use by itself.
+func NewChunkReadWriter(stream ChunkStream) io.ReadWriter {
+	return &chunkReadWriter{chunkReader: &chunkReader{stream: stream}, stream: stream}
+}
+
+func (m *chunkReadWriter) Write(p []byte) (int, error) {
+	if err := m.stream.Send(&Chunk{Chunk: p}); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
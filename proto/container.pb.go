@@ -75,6 +75,12 @@ type Container struct {
 	// TODO: Dragons nearby - beware of injection attacks.
 	Mounts   []string       `protobuf:"bytes,9,rep,name=mounts" json:"mounts,omitempty"`
 	Networks []*NetworkSpec `protobuf:"bytes,10,rep,name=networks" json:"networks,omitempty"`
+	// HealthChecks describes checks of the container services liveness.
+	// Traffic is not routed to endpoints failing them.
+	HealthChecks []*HealthCheck `protobuf:"bytes,11,rep,name=healthChecks" json:"healthChecks,omitempty"`
+	// Name identifies a sidecar container within the task group. Unused for
+	// the main container.
+	Name string `protobuf:"bytes,12,opt,name=name" json:"name,omitempty"`
 }
 
 func (m *Container) Reset()                    { *m = Container{} }
@@ -145,37 +151,124 @@ func (m *Container) GetNetworks() []*NetworkSpec {
 	return nil
 }
 
+func (m *Container) GetHealthChecks() []*HealthCheck {
+	if m != nil {
+		return m.HealthChecks
+	}
+	return nil
+}
+
+func (m *Container) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type HealthCheck struct {
+	// Port describes the container port to check, like "80/tcp".
+	Port string `protobuf:"bytes,1,opt,name=port" json:"port,omitempty"`
+	// Type describes the check kind: "tcp" succeeds if the connection is
+	// established, while "http" requires GET of the path to respond with
+	// 2xx or 3xx status. Defaults to "tcp".
+	Type string `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	// Path describes the HTTP request path, which must start with a slash.
+	// Defaults to "/".
+	Path string `protobuf:"bytes,3,opt,name=path" json:"path,omitempty"`
+	// Interval between checks in seconds. Defaults to 10.
+	Interval uint64 `protobuf:"varint,4,opt,name=interval" json:"interval,omitempty"`
+	// Timeout of a single check in seconds. Defaults to 5.
+	Timeout uint64 `protobuf:"varint,5,opt,name=timeout" json:"timeout,omitempty"`
+	// Threshold describes the number of consecutive check results required
+	// to change the endpoint health. Defaults to 3.
+	Threshold uint32 `protobuf:"varint,6,opt,name=threshold" json:"threshold,omitempty"`
+}
+
+func (m *HealthCheck) Reset()                    { *m = HealthCheck{} }
+func (m *HealthCheck) String() string            { return proto.CompactTextString(m) }
+func (*HealthCheck) ProtoMessage()               {}
+func (*HealthCheck) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
+
+func (m *HealthCheck) GetPort() string {
+	if m != nil {
+		return m.Port
+	}
+	return ""
+}
+
+func (m *HealthCheck) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *HealthCheck) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *HealthCheck) GetInterval() uint64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *HealthCheck) GetTimeout() uint64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *HealthCheck) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*NetworkSpec)(nil), "sonm.NetworkSpec")
 	proto.RegisterType((*Container)(nil), "sonm.Container")
+	proto.RegisterType((*HealthCheck)(nil), "sonm.HealthCheck")
 }
 
 func init() { proto.RegisterFile("container.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 374 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x52, 0x4d, 0x6b, 0xe3, 0x30,
-	0x10, 0xc5, 0x71, 0x3e, 0x9c, 0x89, 0x97, 0xdd, 0x15, 0xcb, 0x22, 0x4c, 0x29, 0xc6, 0xf4, 0x10,
-	0x0a, 0xf5, 0x21, 0x85, 0x10, 0x72, 0x6d, 0x03, 0x85, 0x42, 0x03, 0x0e, 0xf4, 0xee, 0x38, 0x22,
-	0x35, 0x89, 0x25, 0x23, 0xc9, 0x2e, 0xfe, 0x7d, 0xbd, 0xf4, 0x67, 0x15, 0x7d, 0x38, 0x38, 0x6d,
-	0x2f, 0xbd, 0xcd, 0x1b, 0xbf, 0x79, 0x7a, 0xf3, 0xc6, 0xf0, 0x3b, 0x63, 0x54, 0xa6, 0x39, 0x25,
-	0x3c, 0x2e, 0x39, 0x93, 0x0c, 0xf5, 0x05, 0xa3, 0x45, 0xe0, 0xd7, 0xec, 0x58, 0x15, 0xc4, 0xf4,
-	0xa2, 0x37, 0x07, 0x26, 0x4f, 0x44, 0xbe, 0x32, 0x7e, 0xd8, 0x94, 0x24, 0x43, 0x08, 0xfa, 0xb2,
-	0x29, 0x09, 0x76, 0x42, 0x67, 0x3a, 0x4e, 0x74, 0x8d, 0x16, 0x30, 0x62, 0xa5, 0xcc, 0x19, 0x15,
-	0xb8, 0x17, 0xba, 0xd3, 0xc9, 0xec, 0x32, 0x56, 0x4a, 0x71, 0x67, 0x2e, 0x5e, 0x1b, 0xc2, 0x8a,
-	0x4a, 0xde, 0x24, 0x2d, 0x1d, 0xfd, 0x87, 0xa1, 0xa8, 0xb6, 0x94, 0x48, 0xec, 0x6a, 0x3d, 0x8b,
-	0xd4, 0x2b, 0xe9, 0x6e, 0xc7, 0x71, 0xdf, 0xbc, 0xa2, 0xea, 0x60, 0x09, 0x7e, 0x57, 0x04, 0xfd,
-	0x01, 0xf7, 0x40, 0x1a, 0x6b, 0x44, 0x95, 0xe8, 0x1f, 0x0c, 0xea, 0xf4, 0x58, 0x11, 0xdc, 0xd3,
-	0x3d, 0x03, 0x96, 0xbd, 0x85, 0x13, 0xbd, 0xbb, 0x30, 0xbe, 0x6b, 0xb7, 0x55, 0xbc, 0xbc, 0x48,
-	0xf7, 0xed, 0x12, 0x06, 0xa0, 0x00, 0x3c, 0x4e, 0xf6, 0xb9, 0x90, 0xbc, 0xb1, 0x02, 0x27, 0xac,
-	0xfd, 0x54, 0xf2, 0xc5, 0xba, 0xd4, 0x35, 0xba, 0x82, 0x5f, 0x65, 0xb5, 0x3d, 0xe6, 0xd9, 0x23,
-	0x69, 0xee, 0x53, 0x99, 0x5a, 0xb3, 0xe7, 0x4d, 0x14, 0x81, 0x9f, 0xb1, 0xa2, 0xc8, 0xe5, 0x9a,
-	0x6e, 0x24, 0x2b, 0xf1, 0x20, 0x74, 0xa6, 0x5e, 0x72, 0xd6, 0x43, 0xd7, 0xe0, 0x12, 0x5a, 0xe3,
-	0x91, 0xce, 0x0e, 0x9b, 0xec, 0x4e, 0x6e, 0xe3, 0x15, 0xad, 0x4d, 0x6a, 0x8a, 0x84, 0xe6, 0x30,
-	0x32, 0xf7, 0x11, 0xd8, 0xd3, 0xfc, 0x8b, 0xcf, 0xfc, 0x67, 0xf3, 0xd9, 0x26, 0x6d, 0xc9, 0x2a,
-	0xe9, 0x82, 0x55, 0x54, 0x0a, 0x3c, 0x0e, 0x5d, 0x95, 0xb4, 0x41, 0xe8, 0x06, 0x3c, 0x6a, 0xce,
-	0x24, 0x30, 0x68, 0xc1, 0xbf, 0x5f, 0x8e, 0x97, 0x9c, 0x28, 0xc1, 0x1c, 0xbc, 0xd6, 0xcf, 0x4f,
-	0x0e, 0x10, 0x3c, 0x80, 0xdf, 0xf5, 0xf5, 0xcd, 0x6c, 0xd4, 0x9d, 0x9d, 0xcc, 0x7c, 0xe3, 0xc2,
-	0x0c, 0x75, 0x94, 0xb6, 0x43, 0xfd, 0x5f, 0xde, 0x7e, 0x04, 0x00, 0x00, 0xff, 0xff, 0xcf, 0x3f,
-	0xbf, 0x2d, 0xbe, 0x02, 0x00, 0x00,
+	// 473 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xc1, 0x8a, 0x9c, 0x40,
+	0x10, 0xc5, 0xd1, 0x9d, 0x71, 0x4a, 0x97, 0x24, 0x4d, 0x08, 0x8d, 0x2c, 0x41, 0x86, 0x1c, 0x86,
+	0x40, 0x3c, 0x6c, 0xc8, 0xb2, 0xec, 0x75, 0xb3, 0xb0, 0x10, 0xc8, 0x82, 0x0b, 0xb9, 0xf7, 0x38,
+	0xcd, 0x28, 0xa3, 0xdd, 0xd2, 0x96, 0x06, 0xbf, 0x23, 0xe7, 0x7c, 0x4d, 0x7e, 0x2c, 0x74, 0xb7,
+	0x3a, 0x4e, 0x92, 0x4b, 0x6e, 0x55, 0xe5, 0xab, 0xea, 0x57, 0xaf, 0x9e, 0xf0, 0x22, 0x93, 0x02,
+	0x59, 0x21, 0xb8, 0x4a, 0x6a, 0x25, 0x51, 0x12, 0xaf, 0x91, 0xa2, 0x8a, 0xc2, 0x4e, 0x96, 0x6d,
+	0xc5, 0x6d, 0x6d, 0xf3, 0xcb, 0x81, 0xe0, 0x2b, 0xc7, 0xef, 0x52, 0x1d, 0x9f, 0x6b, 0x9e, 0x11,
+	0x02, 0x1e, 0xf6, 0x35, 0xa7, 0x4e, 0xec, 0x6c, 0xd7, 0xa9, 0x89, 0xc9, 0x2d, 0xac, 0x64, 0x8d,
+	0x85, 0x14, 0x0d, 0x5d, 0xc4, 0xee, 0x36, 0xb8, 0x7e, 0x9b, 0xe8, 0x49, 0xc9, 0xac, 0x2f, 0x79,
+	0xb2, 0x80, 0x07, 0x81, 0xaa, 0x4f, 0x47, 0x38, 0x79, 0x03, 0xcb, 0xa6, 0xdd, 0x09, 0x8e, 0xd4,
+	0x35, 0xf3, 0x86, 0x4c, 0xbf, 0xc2, 0xf6, 0x7b, 0x45, 0x3d, 0xfb, 0x8a, 0x8e, 0xa3, 0x3b, 0x08,
+	0xe7, 0x43, 0xc8, 0x4b, 0x70, 0x8f, 0xbc, 0x1f, 0x88, 0xe8, 0x90, 0xbc, 0x86, 0x8b, 0x8e, 0x95,
+	0x2d, 0xa7, 0x0b, 0x53, 0xb3, 0xc9, 0xdd, 0xe2, 0xd6, 0xd9, 0xfc, 0xf0, 0x60, 0x7d, 0x3f, 0x6e,
+	0xab, 0x71, 0x45, 0xc5, 0x0e, 0xe3, 0x12, 0x36, 0x21, 0x11, 0xf8, 0x8a, 0x1f, 0x8a, 0x06, 0x55,
+	0x3f, 0x0c, 0x98, 0x72, 0xc3, 0xa7, 0xc5, 0x7c, 0x60, 0x69, 0x62, 0xf2, 0x0e, 0x2e, 0xeb, 0x76,
+	0x57, 0x16, 0xd9, 0x17, 0xde, 0x7f, 0x66, 0xc8, 0x06, 0xb2, 0xe7, 0x45, 0xb2, 0x81, 0x30, 0x93,
+	0x55, 0x55, 0xe0, 0x93, 0x78, 0x46, 0x59, 0xd3, 0x8b, 0xd8, 0xd9, 0xfa, 0xe9, 0x59, 0x8d, 0xbc,
+	0x07, 0x97, 0x8b, 0x8e, 0xae, 0x8c, 0x76, 0xd4, 0x6a, 0x37, 0xb1, 0x4d, 0x1e, 0x44, 0x67, 0x55,
+	0xd3, 0x20, 0x72, 0x03, 0x2b, 0x7b, 0x9f, 0x86, 0xfa, 0x06, 0x7f, 0xf5, 0x27, 0xfe, 0x9b, 0xfd,
+	0x3c, 0x28, 0x3d, 0x80, 0xb5, 0xd2, 0x95, 0x6c, 0x05, 0x36, 0x74, 0x1d, 0xbb, 0x5a, 0x69, 0x9b,
+	0x91, 0x0f, 0xe0, 0x0b, 0x7b, 0xa6, 0x86, 0x82, 0x19, 0xf8, 0xea, 0xaf, 0xe3, 0xa5, 0x13, 0x84,
+	0x7c, 0x82, 0x30, 0xe7, 0xac, 0xc4, 0xfc, 0x3e, 0xe7, 0xd9, 0xb1, 0xa1, 0xc1, 0xbc, 0xe5, 0xf1,
+	0xf4, 0x25, 0x3d, 0x83, 0x69, 0xfd, 0x04, 0xab, 0x38, 0x0d, 0xad, 0x7e, 0x3a, 0x8e, 0x6e, 0xc0,
+	0x1f, 0x57, 0xfb, 0x9f, 0x5b, 0x46, 0x8f, 0x10, 0xce, 0x57, 0xfc, 0x47, 0xef, 0x66, 0xde, 0x1b,
+	0x5c, 0x87, 0x96, 0x9d, 0x6d, 0x9a, 0xbb, 0xe2, 0xa7, 0x03, 0xc1, 0x8c, 0xb3, 0x66, 0x59, 0x4b,
+	0x85, 0xa3, 0xb7, 0x75, 0x3c, 0xf9, 0x7d, 0x31, 0xf3, 0xbb, 0xc6, 0xb1, 0x93, 0x1b, 0x74, 0xac,
+	0xdd, 0x53, 0x08, 0xe4, 0xaa, 0x63, 0xa5, 0x31, 0x82, 0x97, 0x4e, 0x39, 0xa1, 0xb0, 0xc2, 0xa2,
+	0xe2, 0xb2, 0x45, 0x73, 0x7e, 0x2f, 0x1d, 0x53, 0x72, 0x05, 0x6b, 0xcc, 0x15, 0x6f, 0x72, 0x59,
+	0xee, 0xe9, 0x32, 0x76, 0xb6, 0x97, 0xe9, 0xa9, 0xb0, 0x5b, 0x9a, 0x5f, 0xf0, 0xe3, 0xef, 0x01,
+	0x00, 0xe6, 0xe9, 0x0f, 0xfe, 0xa9, 0x03, 0x00, 0x00,
 }
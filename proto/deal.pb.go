@@ -119,33 +119,186 @@ func (m *Deal) GetId() string {
 	return ""
 }
 
+type DealCloseRequest struct {
+	// Id is the deal ID.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Reason describes why the deal is closed before its end time.
+	Reason string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *DealCloseRequest) Reset()                    { *m = DealCloseRequest{} }
+func (m *DealCloseRequest) String() string            { return proto.CompactTextString(m) }
+func (*DealCloseRequest) ProtoMessage()               {}
+func (*DealCloseRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{1} }
+
+func (m *DealCloseRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DealCloseRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// DealSettlement describes how the payment is split between parties when
+// the deal is closed before its end time.
+type DealSettlement struct {
+	// Initiator is the Eth address of the party requested termination.
+	Initiator   string     `protobuf:"bytes,1,opt,name=initiator" json:"initiator,omitempty"`
+	Reason      string     `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+	RequestedAt *Timestamp `protobuf:"bytes,3,opt,name=requestedAt" json:"requestedAt,omitempty"`
+	// WorkTime is the number of seconds tasks were running within the deal.
+	WorkTime uint64 `protobuf:"varint,4,opt,name=workTime" json:"workTime,omitempty"`
+	// Amount is the payment for the work done, the supplier keeps it.
+	Amount *BigInt `protobuf:"bytes,5,opt,name=amount" json:"amount,omitempty"`
+	// Refund is the remaining part of the deal price returned to the buyer.
+	Refund *BigInt `protobuf:"bytes,6,opt,name=refund" json:"refund,omitempty"`
+}
+
+func (m *DealSettlement) Reset()                    { *m = DealSettlement{} }
+func (m *DealSettlement) String() string            { return proto.CompactTextString(m) }
+func (*DealSettlement) ProtoMessage()               {}
+func (*DealSettlement) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{2} }
+
+func (m *DealSettlement) GetInitiator() string {
+	if m != nil {
+		return m.Initiator
+	}
+	return ""
+}
+
+func (m *DealSettlement) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *DealSettlement) GetRequestedAt() *Timestamp {
+	if m != nil {
+		return m.RequestedAt
+	}
+	return nil
+}
+
+func (m *DealSettlement) GetWorkTime() uint64 {
+	if m != nil {
+		return m.WorkTime
+	}
+	return 0
+}
+
+func (m *DealSettlement) GetAmount() *BigInt {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+func (m *DealSettlement) GetRefund() *BigInt {
+	if m != nil {
+		return m.Refund
+	}
+	return nil
+}
+
+type DealExtendRequest struct {
+	// Id is the ID of the deal to extend.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// ContinuationID is the ID of the pending deal opened by the buyer to
+	// continue the given one. Filled by the Node.
+	ContinuationID string `protobuf:"bytes,2,opt,name=continuationID" json:"continuationID,omitempty"`
+}
+
+func (m *DealExtendRequest) Reset()                    { *m = DealExtendRequest{} }
+func (m *DealExtendRequest) String() string            { return proto.CompactTextString(m) }
+func (*DealExtendRequest) ProtoMessage()               {}
+func (*DealExtendRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{3} }
+
+func (m *DealExtendRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DealExtendRequest) GetContinuationID() string {
+	if m != nil {
+		return m.ContinuationID
+	}
+	return ""
+}
+
+type DealAutoRenewRequest struct {
+	Id        string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	AutoRenew bool   `protobuf:"varint,2,opt,name=autoRenew" json:"autoRenew,omitempty"`
+}
+
+func (m *DealAutoRenewRequest) Reset()                    { *m = DealAutoRenewRequest{} }
+func (m *DealAutoRenewRequest) String() string            { return proto.CompactTextString(m) }
+func (*DealAutoRenewRequest) ProtoMessage()               {}
+func (*DealAutoRenewRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{4} }
+
+func (m *DealAutoRenewRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DealAutoRenewRequest) GetAutoRenew() bool {
+	if m != nil {
+		return m.AutoRenew
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*Deal)(nil), "sonm.Deal")
+	proto.RegisterType((*DealCloseRequest)(nil), "sonm.DealCloseRequest")
+	proto.RegisterType((*DealSettlement)(nil), "sonm.DealSettlement")
+	proto.RegisterType((*DealExtendRequest)(nil), "sonm.DealExtendRequest")
+	proto.RegisterType((*DealAutoRenewRequest)(nil), "sonm.DealAutoRenewRequest")
 	proto.RegisterEnum("sonm.DealStatus", DealStatus_name, DealStatus_value)
 }
 
 func init() { proto.RegisterFile("deal.proto", fileDescriptor4) }
 
 var fileDescriptor4 = []byte{
-	// 315 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xcd, 0x4e, 0xf2, 0x40,
-	0x14, 0x86, 0xbf, 0x96, 0xd2, 0xc2, 0x81, 0x40, 0xbf, 0xb3, 0x9a, 0xb0, 0x30, 0x0d, 0xab, 0x6a,
-	0x94, 0x05, 0x5e, 0x01, 0xb4, 0x8d, 0x92, 0x18, 0x24, 0x6d, 0x5d, 0xb8, 0x32, 0x03, 0x1d, 0xf1,
-	0x44, 0xfa, 0x93, 0xce, 0x10, 0xe3, 0xed, 0x79, 0x65, 0x86, 0xa9, 0x88, 0x89, 0x2e, 0xcf, 0xfb,
-	0x3c, 0x73, 0x66, 0xde, 0x0c, 0x40, 0x26, 0xf8, 0x6e, 0x52, 0xd5, 0xa5, 0x2a, 0xd1, 0x92, 0x65,
-	0x91, 0x8f, 0xfa, 0x6b, 0xda, 0x52, 0xa1, 0x9a, 0x6c, 0x34, 0x54, 0x94, 0x0b, 0xa9, 0x78, 0x5e,
-	0x35, 0xc1, 0xf8, 0xc3, 0x04, 0x2b, 0x14, 0x7c, 0x87, 0x0c, 0x9c, 0xf9, 0xfe, 0x5d, 0xd4, 0x8b,
-	0x90, 0x19, 0x9e, 0xe1, 0x77, 0xe3, 0xe3, 0x88, 0x67, 0x00, 0xc9, 0xbe, 0xaa, 0x76, 0xa4, 0xa1,
-	0xa9, 0xe1, 0x8f, 0x04, 0x7d, 0xb0, 0xa5, 0xe2, 0x6a, 0x2f, 0x59, 0xcb, 0x33, 0xfc, 0xc1, 0xd4,
-	0x9d, 0x1c, 0x2e, 0x9e, 0x1c, 0xb6, 0x26, 0x3a, 0x8f, 0xbf, 0x38, 0x8e, 0xa1, 0x5d, 0xd5, 0xb4,
-	0x11, 0xcc, 0xf2, 0x0c, 0xbf, 0x37, 0xed, 0x37, 0xe2, 0x9c, 0xb6, 0x8b, 0x42, 0xc5, 0x0d, 0xc2,
-	0x2b, 0xe8, 0x4a, 0xc5, 0x6b, 0x95, 0x52, 0x2e, 0x58, 0x5b, 0x7b, 0xc3, 0xc6, 0x4b, 0x8f, 0x4f,
-	0x8f, 0x4f, 0x06, 0x9e, 0x83, 0x23, 0x8a, 0x4c, 0xcb, 0xf6, 0xdf, 0xf2, 0x91, 0xe3, 0x25, 0xfc,
-	0x4f, 0x2a, 0xb1, 0xa1, 0x67, 0xda, 0x70, 0x45, 0x65, 0x71, 0xcb, 0xe5, 0x0b, 0x73, 0x74, 0x9d,
-	0xdf, 0x00, 0x47, 0xd0, 0x79, 0x2b, 0xeb, 0x57, 0xbd, 0xb9, 0xe3, 0x19, 0xbe, 0x15, 0x7f, 0xcf,
-	0x38, 0x00, 0x93, 0x32, 0xd6, 0xd5, 0x47, 0x4d, 0xca, 0x2e, 0x02, 0x80, 0x53, 0x5b, 0x1c, 0x00,
-	0xcc, 0x96, 0x8f, 0x4f, 0x49, 0x3a, 0x4b, 0x1f, 0x12, 0xf7, 0x1f, 0xf6, 0xc0, 0x59, 0x45, 0xcb,
-	0x70, 0xb1, 0xbc, 0x71, 0x0d, 0xec, 0x43, 0x67, 0x16, 0x04, 0xd1, 0x2a, 0x8d, 0x42, 0xd7, 0x44,
-	0x00, 0x3b, 0xb8, 0xbb, 0x4f, 0xa2, 0xd0, 0x6d, 0xad, 0x6d, 0xfd, 0x21, 0xd7, 0x9f, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0x5b, 0x3d, 0x5b, 0x19, 0xc3, 0x01, 0x00, 0x00,
+	// 471 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x93, 0xcf, 0x6e, 0xd3, 0x4c,
+	0x14, 0xc5, 0x3f, 0xe7, 0x8f, 0x93, 0xdc, 0x44, 0xa9, 0x3b, 0xfa, 0x84, 0x46, 0x51, 0x85, 0x22,
+	0x0b, 0xa1, 0x80, 0x20, 0x12, 0x65, 0xc7, 0x2e, 0x8d, 0x2d, 0x88, 0x40, 0xa1, 0xb2, 0xcd, 0x82,
+	0x15, 0x9a, 0xc6, 0xb7, 0x65, 0x84, 0x3d, 0x63, 0xec, 0x6b, 0x15, 0x5e, 0x8f, 0x37, 0xe1, 0x4d,
+	0x90, 0xc7, 0x76, 0x53, 0x4a, 0xb2, 0xbc, 0xe7, 0xfc, 0x72, 0x26, 0xe7, 0xce, 0x18, 0x20, 0x46,
+	0x91, 0x2c, 0xb3, 0x5c, 0x93, 0x66, 0xbd, 0x42, 0xab, 0x74, 0x36, 0xb9, 0x92, 0x37, 0x52, 0x51,
+	0xad, 0xcd, 0x4e, 0x48, 0xa6, 0x58, 0x90, 0x48, 0xb3, 0x5a, 0x70, 0x7f, 0x75, 0xa0, 0xe7, 0xa1,
+	0x48, 0x18, 0x87, 0xc1, 0x45, 0xf9, 0x13, 0xf3, 0x8d, 0xc7, 0xad, 0xb9, 0xb5, 0x18, 0x05, 0xed,
+	0xc8, 0x1e, 0x03, 0x84, 0x65, 0x96, 0x25, 0xd2, 0x98, 0x1d, 0x63, 0xde, 0x53, 0xd8, 0x02, 0xec,
+	0x82, 0x04, 0x95, 0x05, 0xef, 0xce, 0xad, 0xc5, 0xf4, 0xdc, 0x59, 0x56, 0x07, 0x2f, 0xab, 0xd4,
+	0xd0, 0xe8, 0x41, 0xe3, 0x33, 0x17, 0xfa, 0x59, 0x2e, 0x77, 0xc8, 0x7b, 0x73, 0x6b, 0x31, 0x3e,
+	0x9f, 0xd4, 0xe0, 0x85, 0xbc, 0xd9, 0x28, 0x0a, 0x6a, 0x8b, 0xbd, 0x84, 0x51, 0x41, 0x22, 0xa7,
+	0x48, 0xa6, 0xc8, 0xfb, 0x86, 0x3b, 0xa9, 0xb9, 0xa8, 0xfd, 0xeb, 0xc1, 0x9e, 0x60, 0xcf, 0x60,
+	0x80, 0x2a, 0x36, 0xb0, 0x7d, 0x18, 0x6e, 0x7d, 0xf6, 0x02, 0x4e, 0xc3, 0x0c, 0x77, 0xf2, 0x5a,
+	0xee, 0x04, 0x49, 0xad, 0xde, 0x89, 0xe2, 0x2b, 0x1f, 0x98, 0x3a, 0xff, 0x1a, 0x6c, 0x06, 0xc3,
+	0x5b, 0x9d, 0x7f, 0x33, 0xc9, 0xc3, 0xb9, 0xb5, 0xe8, 0x05, 0x77, 0x33, 0x9b, 0x42, 0x47, 0xc6,
+	0x7c, 0x64, 0x7e, 0xda, 0x91, 0xb1, 0xfb, 0x06, 0x9c, 0xaa, 0xed, 0x3a, 0xd1, 0x05, 0x06, 0xf8,
+	0xbd, 0xc4, 0x82, 0x1a, 0xc6, 0x6a, 0x19, 0xf6, 0x08, 0xec, 0x1c, 0x45, 0xa1, 0x55, 0xb3, 0xc1,
+	0x66, 0x72, 0x7f, 0x5b, 0x30, 0x35, 0xab, 0x42, 0xa2, 0x04, 0x53, 0x54, 0xc4, 0xce, 0x60, 0x24,
+	0x95, 0x24, 0x29, 0x48, 0xe7, 0x4d, 0xc2, 0x5e, 0x38, 0x16, 0xc4, 0x5e, 0xc1, 0x38, 0xaf, 0xcf,
+	0xc6, 0x78, 0x45, 0xbc, 0x7b, 0x78, 0x1b, 0xf7, 0x99, 0xbf, 0x3a, 0xf6, 0x1e, 0x74, 0x7c, 0x02,
+	0xb6, 0x48, 0x75, 0xa9, 0x88, 0xf7, 0x0f, 0x5c, 0x56, 0xe3, 0x55, 0x54, 0x8e, 0xd7, 0xa5, 0x8a,
+	0xb9, 0x7d, 0x88, 0xaa, 0x3d, 0xf7, 0x3d, 0x9c, 0x56, 0x15, 0xfd, 0x1f, 0x84, 0x2a, 0x3e, 0xb6,
+	0xa0, 0xa7, 0x30, 0xdd, 0x69, 0x45, 0x52, 0x95, 0xe6, 0x12, 0xee, 0x9e, 0xda, 0x03, 0xd5, 0xf5,
+	0xe0, 0xff, 0x2a, 0x6c, 0x55, 0x92, 0x0e, 0x50, 0xe1, 0xed, 0xb1, 0xbc, 0x33, 0x18, 0x89, 0x96,
+	0x31, 0x51, 0xc3, 0x60, 0x2f, 0x3c, 0x5f, 0x03, 0xec, 0x1f, 0x28, 0x9b, 0x02, 0xac, 0xb6, 0x9f,
+	0xbf, 0x84, 0xd1, 0x2a, 0xfa, 0x14, 0x3a, 0xff, 0xb1, 0x31, 0x0c, 0x2e, 0xfd, 0xad, 0xb7, 0xd9,
+	0xbe, 0x75, 0x2c, 0x36, 0x81, 0xe1, 0x6a, 0xbd, 0xf6, 0x2f, 0x23, 0xdf, 0x73, 0x3a, 0x0c, 0xc0,
+	0x5e, 0x7f, 0xf8, 0x18, 0xfa, 0x9e, 0xd3, 0xbd, 0xb2, 0xcd, 0x37, 0xf4, 0xfa, 0xcf, 0x00, 0xf0,
+	0x9e, 0xc7, 0xb4, 0x76, 0x03, 0x00, 0x00,
 }
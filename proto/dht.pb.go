@@ -0,0 +1,478 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dht.proto
+
+package sonm
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// grpccmd imports
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/sshaman1101/grpccmd"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type DHTContact struct {
+	EthAddr string `protobuf:"bytes,1,opt,name=ethAddr" json:"ethAddr,omitempty"`
+	// Endpoint the node serves DHT requests on in "host:port" format.
+	Endpoint string `protobuf:"bytes,2,opt,name=endpoint" json:"endpoint,omitempty"`
+}
+
+func (m *DHTContact) Reset()                    { *m = DHTContact{} }
+func (m *DHTContact) String() string            { return proto.CompactTextString(m) }
+func (*DHTContact) ProtoMessage()               {}
+func (*DHTContact) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{0} }
+
+func (m *DHTContact) GetEthAddr() string {
+	if m != nil {
+		return m.EthAddr
+	}
+	return ""
+}
+
+func (m *DHTContact) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+type DHTPingRequest struct {
+	// Sender describes the caller. Callers without endpoint are not
+	// included in routing tables, which allows resolving without serving.
+	Sender *DHTContact `protobuf:"bytes,1,opt,name=sender" json:"sender,omitempty"`
+}
+
+func (m *DHTPingRequest) Reset()                    { *m = DHTPingRequest{} }
+func (m *DHTPingRequest) String() string            { return proto.CompactTextString(m) }
+func (*DHTPingRequest) ProtoMessage()               {}
+func (*DHTPingRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{1} }
+
+func (m *DHTPingRequest) GetSender() *DHTContact {
+	if m != nil {
+		return m.Sender
+	}
+	return nil
+}
+
+type DHTFindRequest struct {
+	Sender *DHTContact `protobuf:"bytes,1,opt,name=sender" json:"sender,omitempty"`
+	// Target is the Eth address to find closest contacts or the value for.
+	Target string `protobuf:"bytes,2,opt,name=target" json:"target,omitempty"`
+}
+
+func (m *DHTFindRequest) Reset()                    { *m = DHTFindRequest{} }
+func (m *DHTFindRequest) String() string            { return proto.CompactTextString(m) }
+func (*DHTFindRequest) ProtoMessage()               {}
+func (*DHTFindRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{2} }
+
+func (m *DHTFindRequest) GetSender() *DHTContact {
+	if m != nil {
+		return m.Sender
+	}
+	return nil
+}
+
+func (m *DHTFindRequest) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+type DHTFindNodeReply struct {
+	Contacts []*DHTContact `protobuf:"bytes,1,rep,name=contacts" json:"contacts,omitempty"`
+}
+
+func (m *DHTFindNodeReply) Reset()                    { *m = DHTFindNodeReply{} }
+func (m *DHTFindNodeReply) String() string            { return proto.CompactTextString(m) }
+func (*DHTFindNodeReply) ProtoMessage()               {}
+func (*DHTFindNodeReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{3} }
+
+func (m *DHTFindNodeReply) GetContacts() []*DHTContact {
+	if m != nil {
+		return m.Contacts
+	}
+	return nil
+}
+
+type DHTFindValueReply struct {
+	Record   *LocatorRecord `protobuf:"bytes,1,opt,name=record" json:"record,omitempty"`
+	Contacts []*DHTContact  `protobuf:"bytes,2,rep,name=contacts" json:"contacts,omitempty"`
+}
+
+func (m *DHTFindValueReply) Reset()                    { *m = DHTFindValueReply{} }
+func (m *DHTFindValueReply) String() string            { return proto.CompactTextString(m) }
+func (*DHTFindValueReply) ProtoMessage()               {}
+func (*DHTFindValueReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{4} }
+
+func (m *DHTFindValueReply) GetRecord() *LocatorRecord {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+func (m *DHTFindValueReply) GetContacts() []*DHTContact {
+	if m != nil {
+		return m.Contacts
+	}
+	return nil
+}
+
+type DHTStoreRequest struct {
+	Sender *DHTContact    `protobuf:"bytes,1,opt,name=sender" json:"sender,omitempty"`
+	Record *LocatorRecord `protobuf:"bytes,2,opt,name=record" json:"record,omitempty"`
+}
+
+func (m *DHTStoreRequest) Reset()                    { *m = DHTStoreRequest{} }
+func (m *DHTStoreRequest) String() string            { return proto.CompactTextString(m) }
+func (*DHTStoreRequest) ProtoMessage()               {}
+func (*DHTStoreRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{5} }
+
+func (m *DHTStoreRequest) GetSender() *DHTContact {
+	if m != nil {
+		return m.Sender
+	}
+	return nil
+}
+
+func (m *DHTStoreRequest) GetRecord() *LocatorRecord {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*DHTContact)(nil), "sonm.DHTContact")
+	proto.RegisterType((*DHTPingRequest)(nil), "sonm.DHTPingRequest")
+	proto.RegisterType((*DHTFindRequest)(nil), "sonm.DHTFindRequest")
+	proto.RegisterType((*DHTFindNodeReply)(nil), "sonm.DHTFindNodeReply")
+	proto.RegisterType((*DHTFindValueReply)(nil), "sonm.DHTFindValueReply")
+	proto.RegisterType((*DHTStoreRequest)(nil), "sonm.DHTStoreRequest")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for DHT service
+
+type DHTClient interface {
+	// Ping checks whether the node is alive.
+	Ping(ctx context.Context, in *DHTPingRequest, opts ...grpc.CallOption) (*Empty, error)
+	// FindNode returns the closest known contacts to the target.
+	FindNode(ctx context.Context, in *DHTFindRequest, opts ...grpc.CallOption) (*DHTFindNodeReply, error)
+	// FindValue returns the record stored for the target, or the closest
+	// known contacts to it if there is no such record.
+	FindValue(ctx context.Context, in *DHTFindRequest, opts ...grpc.CallOption) (*DHTFindValueReply, error)
+	// Store stores the signed record on the node.
+	Store(ctx context.Context, in *DHTStoreRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type dHTClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDHTClient(cc *grpc.ClientConn) DHTClient {
+	return &dHTClient{cc}
+}
+
+func (c *dHTClient) Ping(ctx context.Context, in *DHTPingRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/sonm.DHT/Ping", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTClient) FindNode(ctx context.Context, in *DHTFindRequest, opts ...grpc.CallOption) (*DHTFindNodeReply, error) {
+	out := new(DHTFindNodeReply)
+	err := grpc.Invoke(ctx, "/sonm.DHT/FindNode", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTClient) FindValue(ctx context.Context, in *DHTFindRequest, opts ...grpc.CallOption) (*DHTFindValueReply, error) {
+	out := new(DHTFindValueReply)
+	err := grpc.Invoke(ctx, "/sonm.DHT/FindValue", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTClient) Store(ctx context.Context, in *DHTStoreRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/sonm.DHT/Store", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for DHT service
+
+type DHTServer interface {
+	// Ping checks whether the node is alive.
+	Ping(context.Context, *DHTPingRequest) (*Empty, error)
+	// FindNode returns the closest known contacts to the target.
+	FindNode(context.Context, *DHTFindRequest) (*DHTFindNodeReply, error)
+	// FindValue returns the record stored for the target, or the closest
+	// known contacts to it if there is no such record.
+	FindValue(context.Context, *DHTFindRequest) (*DHTFindValueReply, error)
+	// Store stores the signed record on the node.
+	Store(context.Context, *DHTStoreRequest) (*Empty, error)
+}
+
+func RegisterDHTServer(s *grpc.Server, srv DHTServer) {
+	s.RegisterService(&_DHT_serviceDesc, srv)
+}
+
+func _DHT_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DHTPingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.DHT/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTServer).Ping(ctx, req.(*DHTPingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHT_FindNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DHTFindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTServer).FindNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.DHT/FindNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTServer).FindNode(ctx, req.(*DHTFindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHT_FindValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DHTFindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTServer).FindValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.DHT/FindValue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTServer).FindValue(ctx, req.(*DHTFindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHT_Store_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DHTStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTServer).Store(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.DHT/Store",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTServer).Store(ctx, req.(*DHTStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DHT_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sonm.DHT",
+	HandlerType: (*DHTServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ping",
+			Handler:    _DHT_Ping_Handler,
+		},
+		{
+			MethodName: "FindNode",
+			Handler:    _DHT_FindNode_Handler,
+		},
+		{
+			MethodName: "FindValue",
+			Handler:    _DHT_FindValue_Handler,
+		},
+		{
+			MethodName: "Store",
+			Handler:    _DHT_Store_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dht.proto",
+}
+
+// Begin grpccmd
+var _ = grpccmd.RunE
+
+// DHT
+var _DHTCmd = &cobra.Command{
+	Use:   "dHT [method]",
+	Short: "Subcommand for the DHT service.",
+}
+
+var _DHT_PingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Make the Ping method call, input-type: sonm.DHTPingRequest output-type: sonm.Empty",
+	RunE: grpccmd.RunE(
+		"Ping",
+		"sonm.DHTPingRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewDHTClient(cc)
+		},
+	),
+}
+
+var _DHT_PingCmd_gen = &cobra.Command{
+	Use:   "ping-gen",
+	Short: "Generate JSON for method call of Ping (input-type: sonm.DHTPingRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.DHTPingRequest"),
+}
+
+var _DHT_FindNodeCmd = &cobra.Command{
+	Use:   "findNode",
+	Short: "Make the FindNode method call, input-type: sonm.DHTFindRequest output-type: sonm.DHTFindNodeReply",
+	RunE: grpccmd.RunE(
+		"FindNode",
+		"sonm.DHTFindRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewDHTClient(cc)
+		},
+	),
+}
+
+var _DHT_FindNodeCmd_gen = &cobra.Command{
+	Use:   "findNode-gen",
+	Short: "Generate JSON for method call of FindNode (input-type: sonm.DHTFindRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.DHTFindRequest"),
+}
+
+var _DHT_FindValueCmd = &cobra.Command{
+	Use:   "findValue",
+	Short: "Make the FindValue method call, input-type: sonm.DHTFindRequest output-type: sonm.DHTFindValueReply",
+	RunE: grpccmd.RunE(
+		"FindValue",
+		"sonm.DHTFindRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewDHTClient(cc)
+		},
+	),
+}
+
+var _DHT_FindValueCmd_gen = &cobra.Command{
+	Use:   "findValue-gen",
+	Short: "Generate JSON for method call of FindValue (input-type: sonm.DHTFindRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.DHTFindRequest"),
+}
+
+var _DHT_StoreCmd = &cobra.Command{
+	Use:   "store",
+	Short: "Make the Store method call, input-type: sonm.DHTStoreRequest output-type: sonm.Empty",
+	RunE: grpccmd.RunE(
+		"Store",
+		"sonm.DHTStoreRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewDHTClient(cc)
+		},
+	),
+}
+
+var _DHT_StoreCmd_gen = &cobra.Command{
+	Use:   "store-gen",
+	Short: "Generate JSON for method call of Store (input-type: sonm.DHTStoreRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.DHTStoreRequest"),
+}
+
+// Register commands with the root command and service command
+func init() {
+	grpccmd.RegisterServiceCmd(_DHTCmd)
+	_DHTCmd.AddCommand(
+		_DHT_PingCmd,
+		_DHT_PingCmd_gen,
+		_DHT_FindNodeCmd,
+		_DHT_FindNodeCmd_gen,
+		_DHT_FindValueCmd,
+		_DHT_FindValueCmd_gen,
+		_DHT_StoreCmd,
+		_DHT_StoreCmd_gen,
+	)
+}
+
+// End grpccmd
+
+func init() { proto.RegisterFile("dht.proto", fileDescriptor5) }
+
+var fileDescriptor5 = []byte{
+	// 341 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x52, 0x4f, 0x4f, 0xfa, 0x40,
+	0x10, 0xa5, 0xc0, 0xaf, 0x3f, 0x18, 0xa2, 0xe0, 0xaa, 0xd8, 0xf4, 0x44, 0xf6, 0x44, 0x82, 0x72,
+	0xc0, 0x1b, 0xf1, 0xe0, 0x9f, 0x6a, 0x7a, 0x30, 0xc6, 0xac, 0x8d, 0xf7, 0xda, 0xdd, 0x40, 0x13,
+	0xd8, 0xad, 0xdb, 0xe5, 0xc0, 0x67, 0xf5, 0xcb, 0x18, 0xba, 0xcb, 0xd2, 0x1a, 0x0d, 0xe1, 0xb4,
+	0x99, 0x37, 0xf3, 0xde, 0xbc, 0xbc, 0x59, 0x68, 0xd3, 0xb9, 0x1a, 0x67, 0x52, 0x28, 0x81, 0x9a,
+	0xb9, 0xe0, 0x4b, 0xbf, 0x9b, 0xf2, 0xcd, 0xcb, 0xd3, 0x58, 0xc3, 0xfe, 0xd1, 0x42, 0x24, 0xb1,
+	0x12, 0x52, 0x97, 0xf8, 0x1e, 0x20, 0x08, 0xa3, 0x07, 0xc1, 0x55, 0x9c, 0x28, 0xe4, 0xc1, 0x7f,
+	0xa6, 0xe6, 0x77, 0x94, 0x4a, 0xcf, 0x19, 0x38, 0xc3, 0x36, 0xd9, 0x96, 0xc8, 0x87, 0x16, 0xe3,
+	0x34, 0x13, 0x29, 0x57, 0x5e, 0xbd, 0x68, 0xd9, 0x1a, 0x4f, 0xe1, 0x38, 0x08, 0xa3, 0xd7, 0x94,
+	0xcf, 0x08, 0xfb, 0x5c, 0xb1, 0x5c, 0xa1, 0x21, 0xb8, 0x39, 0xe3, 0x94, 0x69, 0x99, 0xce, 0xa4,
+	0x37, 0xde, 0x98, 0x18, 0xef, 0x36, 0x11, 0xd3, 0xc7, 0xa4, 0xe0, 0x3e, 0xa5, 0x9c, 0x1e, 0xcc,
+	0x45, 0x7d, 0x70, 0x55, 0x2c, 0x67, 0x6c, 0xeb, 0xc8, 0x54, 0xf8, 0x16, 0x7a, 0x46, 0xf3, 0x45,
+	0x50, 0x46, 0x58, 0xb6, 0x58, 0xa3, 0x4b, 0x68, 0x25, 0x9a, 0x9e, 0x7b, 0xce, 0xa0, 0xf1, 0xab,
+	0xae, 0x9d, 0xc0, 0x1c, 0x4e, 0x8c, 0xc2, 0x7b, 0xbc, 0x58, 0x19, 0x89, 0x11, 0xb8, 0x92, 0x25,
+	0x42, 0x52, 0x63, 0xec, 0x54, 0x0b, 0x3c, 0xeb, 0x3c, 0x49, 0xd1, 0x22, 0x66, 0xa4, 0xb2, 0xaf,
+	0xbe, 0x77, 0xdf, 0x1c, 0xba, 0x41, 0x18, 0xbd, 0x29, 0x21, 0xd9, 0xe1, 0x31, 0xec, 0x7c, 0xd5,
+	0xf7, 0xfa, 0x9a, 0x7c, 0x39, 0xd0, 0x08, 0xc2, 0x08, 0x8d, 0xa0, 0xb9, 0x39, 0x18, 0x3a, 0xb3,
+	0xb2, 0xa5, 0xfb, 0xf9, 0x1d, 0x8d, 0x3e, 0x2e, 0x33, 0xb5, 0xc6, 0x35, 0x34, 0x85, 0xd6, 0x36,
+	0xcd, 0x12, 0xa1, 0x74, 0x34, 0xbf, 0x5f, 0x41, 0x6d, 0xec, 0xb8, 0x86, 0x6e, 0xa0, 0x6d, 0x73,
+	0xfc, 0x83, 0x7c, 0x51, 0x41, 0x77, 0x89, 0xe3, 0x1a, 0xba, 0x82, 0x7f, 0x45, 0x2a, 0xe8, 0xdc,
+	0xce, 0x94, 0x53, 0xfa, 0x61, 0xf4, 0xc3, 0x2d, 0x3e, 0xf5, 0xf5, 0xf7, 0x00, 0x89, 0x80, 0xed,
+	0x18, 0x07, 0x03, 0x00, 0x00,
+}
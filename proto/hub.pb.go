@@ -32,7 +32,7 @@ type ListReply struct {
 func (m *ListReply) Reset()                    { *m = ListReply{} }
 func (m *ListReply) String() string            { return proto.CompactTextString(m) }
 func (*ListReply) ProtoMessage()               {}
-func (*ListReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{0} }
+func (*ListReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0} }
 
 func (m *ListReply) GetInfo() map[string]*ListReply_ListValue {
 	if m != nil {
@@ -48,7 +48,7 @@ type ListReply_ListValue struct {
 func (m *ListReply_ListValue) Reset()                    { *m = ListReply_ListValue{} }
 func (m *ListReply_ListValue) String() string            { return proto.CompactTextString(m) }
 func (*ListReply_ListValue) ProtoMessage()               {}
-func (*ListReply_ListValue) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{0, 0} }
+func (*ListReply_ListValue) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 0} }
 
 func (m *ListReply_ListValue) GetValues() []string {
 	if m != nil {
@@ -63,12 +63,16 @@ type HubStartTaskRequest struct {
 	Deal *Deal `protobuf:"bytes,1,opt,name=deal" json:"deal,omitempty"`
 	// Container describes container settings.
 	Container *Container `protobuf:"bytes,2,opt,name=container" json:"container,omitempty"`
+	// Sidecars describes containers started together with the main one,
+	// sharing its network namespace, volumes and resources. Sidecars
+	// can neither expose ports nor declare own volumes and networks.
+	Sidecars []*Container `protobuf:"bytes,3,rep,name=sidecars" json:"sidecars,omitempty"`
 }
 
 func (m *HubStartTaskRequest) Reset()                    { *m = HubStartTaskRequest{} }
 func (m *HubStartTaskRequest) String() string            { return proto.CompactTextString(m) }
 func (*HubStartTaskRequest) ProtoMessage()               {}
-func (*HubStartTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{1} }
+func (*HubStartTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{1} }
 
 func (m *HubStartTaskRequest) GetDeal() *Deal {
 	if m != nil {
@@ -84,6 +88,13 @@ func (m *HubStartTaskRequest) GetContainer() *Container {
 	return nil
 }
 
+func (m *HubStartTaskRequest) GetSidecars() []*Container {
+	if m != nil {
+		return m.Sidecars
+	}
+	return nil
+}
+
 type HubJoinNetworkRequest struct {
 	TaskID    string `protobuf:"bytes,1,opt,name=taskID" json:"taskID,omitempty"`
 	NetworkID string `protobuf:"bytes,2,opt,name=networkID" json:"networkID,omitempty"`
@@ -92,7 +103,7 @@ type HubJoinNetworkRequest struct {
 func (m *HubJoinNetworkRequest) Reset()                    { *m = HubJoinNetworkRequest{} }
 func (m *HubJoinNetworkRequest) String() string            { return proto.CompactTextString(m) }
 func (*HubJoinNetworkRequest) ProtoMessage()               {}
-func (*HubJoinNetworkRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{2} }
+func (*HubJoinNetworkRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{2} }
 
 func (m *HubJoinNetworkRequest) GetTaskID() string {
 	if m != nil {
@@ -118,7 +129,7 @@ type HubStartTaskReply struct {
 func (m *HubStartTaskReply) Reset()                    { *m = HubStartTaskReply{} }
 func (m *HubStartTaskReply) String() string            { return proto.CompactTextString(m) }
 func (*HubStartTaskReply) ProtoMessage()               {}
-func (*HubStartTaskReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{3} }
+func (*HubStartTaskReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{3} }
 
 func (m *HubStartTaskReply) GetId() string {
 	if m != nil {
@@ -162,7 +173,7 @@ type HubStatusReply struct {
 func (m *HubStatusReply) Reset()                    { *m = HubStatusReply{} }
 func (m *HubStatusReply) String() string            { return proto.CompactTextString(m) }
 func (*HubStatusReply) ProtoMessage()               {}
-func (*HubStatusReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{4} }
+func (*HubStatusReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{4} }
 
 func (m *HubStatusReply) GetMinerCount() uint64 {
 	if m != nil {
@@ -229,7 +240,7 @@ type DealRequest struct {
 func (m *DealRequest) Reset()                    { *m = DealRequest{} }
 func (m *DealRequest) String() string            { return proto.CompactTextString(m) }
 func (*DealRequest) ProtoMessage()               {}
-func (*DealRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{5} }
+func (*DealRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{5} }
 
 func (m *DealRequest) GetBidId() string {
 	if m != nil {
@@ -261,7 +272,7 @@ type ApproveDealRequest struct {
 func (m *ApproveDealRequest) Reset()                    { *m = ApproveDealRequest{} }
 func (m *ApproveDealRequest) String() string            { return proto.CompactTextString(m) }
 func (*ApproveDealRequest) ProtoMessage()               {}
-func (*ApproveDealRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{6} }
+func (*ApproveDealRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{6} }
 
 func (m *ApproveDealRequest) GetDealID() *BigInt {
 	if m != nil {
@@ -291,7 +302,7 @@ type GetDevicePropertiesReply struct {
 func (m *GetDevicePropertiesReply) Reset()                    { *m = GetDevicePropertiesReply{} }
 func (m *GetDevicePropertiesReply) String() string            { return proto.CompactTextString(m) }
 func (*GetDevicePropertiesReply) ProtoMessage()               {}
-func (*GetDevicePropertiesReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{7} }
+func (*GetDevicePropertiesReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{7} }
 
 func (m *GetDevicePropertiesReply) GetProperties() map[string]float64 {
 	if m != nil {
@@ -310,7 +321,7 @@ type SetDevicePropertiesRequest struct {
 func (m *SetDevicePropertiesRequest) Reset()                    { *m = SetDevicePropertiesRequest{} }
 func (m *SetDevicePropertiesRequest) String() string            { return proto.CompactTextString(m) }
 func (*SetDevicePropertiesRequest) ProtoMessage()               {}
-func (*SetDevicePropertiesRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{8} }
+func (*SetDevicePropertiesRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{8} }
 
 func (m *SetDevicePropertiesRequest) GetID() string {
 	if m != nil {
@@ -333,7 +344,7 @@ type SlotsReply struct {
 func (m *SlotsReply) Reset()                    { *m = SlotsReply{} }
 func (m *SlotsReply) String() string            { return proto.CompactTextString(m) }
 func (*SlotsReply) ProtoMessage()               {}
-func (*SlotsReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{9} }
+func (*SlotsReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{9} }
 
 func (m *SlotsReply) GetSlots() map[string]*Slot {
 	if m != nil {
@@ -349,7 +360,7 @@ type GetAllSlotsReply struct {
 func (m *GetAllSlotsReply) Reset()                    { *m = GetAllSlotsReply{} }
 func (m *GetAllSlotsReply) String() string            { return proto.CompactTextString(m) }
 func (*GetAllSlotsReply) ProtoMessage()               {}
-func (*GetAllSlotsReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{10} }
+func (*GetAllSlotsReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{10} }
 
 func (m *GetAllSlotsReply) GetSlots() map[string]*GetAllSlotsReply_SlotList {
 	if m != nil {
@@ -365,7 +376,7 @@ type GetAllSlotsReply_SlotList struct {
 func (m *GetAllSlotsReply_SlotList) Reset()                    { *m = GetAllSlotsReply_SlotList{} }
 func (m *GetAllSlotsReply_SlotList) String() string            { return proto.CompactTextString(m) }
 func (*GetAllSlotsReply_SlotList) ProtoMessage()               {}
-func (*GetAllSlotsReply_SlotList) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{10, 0} }
+func (*GetAllSlotsReply_SlotList) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{10, 0} }
 
 func (m *GetAllSlotsReply_SlotList) GetSlot() []*Slot {
 	if m != nil {
@@ -384,7 +395,7 @@ type AddSlotRequest struct {
 func (m *AddSlotRequest) Reset()                    { *m = AddSlotRequest{} }
 func (m *AddSlotRequest) String() string            { return proto.CompactTextString(m) }
 func (*AddSlotRequest) ProtoMessage()               {}
-func (*AddSlotRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{11} }
+func (*AddSlotRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{11} }
 
 func (m *AddSlotRequest) GetID() string {
 	if m != nil {
@@ -408,7 +419,7 @@ type RemoveSlotRequest struct {
 func (m *RemoveSlotRequest) Reset()                    { *m = RemoveSlotRequest{} }
 func (m *RemoveSlotRequest) String() string            { return proto.CompactTextString(m) }
 func (*RemoveSlotRequest) ProtoMessage()               {}
-func (*RemoveSlotRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{12} }
+func (*RemoveSlotRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{12} }
 
 func (m *RemoveSlotRequest) GetID() string {
 	if m != nil {
@@ -431,7 +442,7 @@ type GetRegisteredWorkersReply struct {
 func (m *GetRegisteredWorkersReply) Reset()                    { *m = GetRegisteredWorkersReply{} }
 func (m *GetRegisteredWorkersReply) String() string            { return proto.CompactTextString(m) }
 func (*GetRegisteredWorkersReply) ProtoMessage()               {}
-func (*GetRegisteredWorkersReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{13} }
+func (*GetRegisteredWorkersReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{13} }
 
 func (m *GetRegisteredWorkersReply) GetIds() []*ID {
 	if m != nil {
@@ -447,7 +458,7 @@ type TaskListReply struct {
 func (m *TaskListReply) Reset()                    { *m = TaskListReply{} }
 func (m *TaskListReply) String() string            { return proto.CompactTextString(m) }
 func (*TaskListReply) ProtoMessage()               {}
-func (*TaskListReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{14} }
+func (*TaskListReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{14} }
 
 func (m *TaskListReply) GetInfo() map[string]*TaskListReply_TaskInfo {
 	if m != nil {
@@ -463,7 +474,7 @@ type TaskListReply_TaskInfo struct {
 func (m *TaskListReply_TaskInfo) Reset()                    { *m = TaskListReply_TaskInfo{} }
 func (m *TaskListReply_TaskInfo) String() string            { return proto.CompactTextString(m) }
 func (*TaskListReply_TaskInfo) ProtoMessage()               {}
-func (*TaskListReply_TaskInfo) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{14, 0} }
+func (*TaskListReply_TaskInfo) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{14, 0} }
 
 func (m *TaskListReply_TaskInfo) GetTasks() map[string]*TaskStatusReply {
 	if m != nil {
@@ -482,7 +493,7 @@ type CPUDeviceInfo struct {
 func (m *CPUDeviceInfo) Reset()                    { *m = CPUDeviceInfo{} }
 func (m *CPUDeviceInfo) String() string            { return proto.CompactTextString(m) }
 func (*CPUDeviceInfo) ProtoMessage()               {}
-func (*CPUDeviceInfo) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{15} }
+func (*CPUDeviceInfo) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{15} }
 
 func (m *CPUDeviceInfo) GetMiners() []string {
 	if m != nil {
@@ -508,7 +519,7 @@ type GPUDeviceInfo struct {
 func (m *GPUDeviceInfo) Reset()                    { *m = GPUDeviceInfo{} }
 func (m *GPUDeviceInfo) String() string            { return proto.CompactTextString(m) }
 func (*GPUDeviceInfo) ProtoMessage()               {}
-func (*GPUDeviceInfo) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{16} }
+func (*GPUDeviceInfo) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{16} }
 
 func (m *GPUDeviceInfo) GetMiners() []string {
 	if m != nil {
@@ -534,7 +545,7 @@ type DevicesReply struct {
 func (m *DevicesReply) Reset()                    { *m = DevicesReply{} }
 func (m *DevicesReply) String() string            { return proto.CompactTextString(m) }
 func (*DevicesReply) ProtoMessage()               {}
-func (*DevicesReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{17} }
+func (*DevicesReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{17} }
 
 func (m *DevicesReply) GetCPUs() map[string]*CPUDeviceInfo {
 	if m != nil {
@@ -554,12 +565,15 @@ type InsertSlotRequest struct {
 	Slot           *Slot   `protobuf:"bytes,1,opt,name=slot" json:"slot,omitempty"`
 	PricePerSecond *BigInt `protobuf:"bytes,4,opt,name=pricePerSecond" json:"pricePerSecond,omitempty"`
 	BuyerID        string  `protobuf:"bytes,3,opt,name=buyerID" json:"buyerID,omitempty"`
+	// Pricing describes how the plan price is calculated. When omitted the
+	// plan is announced with the fixed price specified above.
+	Pricing *PricingPolicy `protobuf:"bytes,5,opt,name=pricing" json:"pricing,omitempty"`
 }
 
 func (m *InsertSlotRequest) Reset()                    { *m = InsertSlotRequest{} }
 func (m *InsertSlotRequest) String() string            { return proto.CompactTextString(m) }
 func (*InsertSlotRequest) ProtoMessage()               {}
-func (*InsertSlotRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{18} }
+func (*InsertSlotRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{18} }
 
 func (m *InsertSlotRequest) GetSlot() *Slot {
 	if m != nil {
@@ -582,6 +596,70 @@ func (m *InsertSlotRequest) GetBuyerID() string {
 	return ""
 }
 
+func (m *InsertSlotRequest) GetPricing() *PricingPolicy {
+	if m != nil {
+		return m.Pricing
+	}
+	return nil
+}
+
+// PricingPolicy describes how the price of an ask plan is calculated. All
+// prices are per second.
+type PricingPolicy struct {
+	// Price for a single CPU core.
+	PerCPUCore *BigInt `protobuf:"bytes,1,opt,name=perCPUCore" json:"perCPUCore,omitempty"`
+	// Price for a gigabyte of RAM.
+	PerRAMGigabyte *BigInt `protobuf:"bytes,2,opt,name=perRAMGigabyte" json:"perRAMGigabyte,omitempty"`
+	// Price for a single GPU device, keyed by the device name reported by
+	// workers. The "*" key matches any device.
+	PerGPU map[string]*BigInt `protobuf:"bytes,3,rep,name=perGPU" json:"perGPU,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Floor is the minimum price the plan can be announced with.
+	Floor *BigInt `protobuf:"bytes,4,opt,name=floor" json:"floor,omitempty"`
+	// FollowMarket enables periodic repricing of the plan to the highest
+	// price of the matching BID orders on the market.
+	FollowMarket bool `protobuf:"varint,5,opt,name=followMarket" json:"followMarket,omitempty"`
+}
+
+func (m *PricingPolicy) Reset()                    { *m = PricingPolicy{} }
+func (m *PricingPolicy) String() string            { return proto.CompactTextString(m) }
+func (*PricingPolicy) ProtoMessage()               {}
+func (*PricingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{19} }
+
+func (m *PricingPolicy) GetPerCPUCore() *BigInt {
+	if m != nil {
+		return m.PerCPUCore
+	}
+	return nil
+}
+
+func (m *PricingPolicy) GetPerRAMGigabyte() *BigInt {
+	if m != nil {
+		return m.PerRAMGigabyte
+	}
+	return nil
+}
+
+func (m *PricingPolicy) GetPerGPU() map[string]*BigInt {
+	if m != nil {
+		return m.PerGPU
+	}
+	return nil
+}
+
+func (m *PricingPolicy) GetFloor() *BigInt {
+	if m != nil {
+		return m.Floor
+	}
+	return nil
+}
+
+func (m *PricingPolicy) GetFollowMarket() bool {
+	if m != nil {
+		return m.FollowMarket
+	}
+	return false
+}
+
 type PullTaskRequest struct {
 	DealId string `protobuf:"bytes,1,opt,name=dealId" json:"dealId,omitempty"`
 	TaskId string `protobuf:"bytes,2,opt,name=taskId" json:"taskId,omitempty"`
@@ -590,7 +668,7 @@ type PullTaskRequest struct {
 func (m *PullTaskRequest) Reset()                    { *m = PullTaskRequest{} }
 func (m *PullTaskRequest) String() string            { return proto.CompactTextString(m) }
 func (*PullTaskRequest) ProtoMessage()               {}
-func (*PullTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{19} }
+func (*PullTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{20} }
 
 func (m *PullTaskRequest) GetDealId() string {
 	if m != nil {
@@ -615,12 +693,19 @@ type DealInfoReply struct {
 	Running *StatusMapReply `protobuf:"bytes,3,opt,name=running" json:"running,omitempty"`
 	// List of completed tasks.
 	Completed *StatusMapReply `protobuf:"bytes,4,opt,name=completed" json:"completed,omitempty"`
+	// Settlement is set when early termination of the deal is requested.
+	Settlement *DealSettlement `protobuf:"bytes,5,opt,name=settlement" json:"settlement,omitempty"`
+	// ActiveID is the ID of the deal currently paying for the resources,
+	// it differs from the ID after the deal has been extended.
+	ActiveID string `protobuf:"bytes,6,opt,name=activeID" json:"activeID,omitempty"`
+	// Extensions are IDs of continuation deals waiting for acceptance.
+	Extensions []string `protobuf:"bytes,7,rep,name=extensions" json:"extensions,omitempty"`
 }
 
 func (m *DealInfoReply) Reset()                    { *m = DealInfoReply{} }
 func (m *DealInfoReply) String() string            { return proto.CompactTextString(m) }
 func (*DealInfoReply) ProtoMessage()               {}
-func (*DealInfoReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{20} }
+func (*DealInfoReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{21} }
 
 func (m *DealInfoReply) GetId() *ID {
 	if m != nil {
@@ -650,6 +735,119 @@ func (m *DealInfoReply) GetCompleted() *StatusMapReply {
 	return nil
 }
 
+func (m *DealInfoReply) GetSettlement() *DealSettlement {
+	if m != nil {
+		return m.Settlement
+	}
+	return nil
+}
+
+func (m *DealInfoReply) GetActiveID() string {
+	if m != nil {
+		return m.ActiveID
+	}
+	return ""
+}
+
+func (m *DealInfoReply) GetExtensions() []string {
+	if m != nil {
+		return m.Extensions
+	}
+	return nil
+}
+
+// TaskRoute describes how a task service is reachable through the Hub.
+type TaskRoute struct {
+	TaskID  string `protobuf:"bytes,1,opt,name=taskID" json:"taskID,omitempty"`
+	MinerID string `protobuf:"bytes,2,opt,name=minerID" json:"minerID,omitempty"`
+	// ContainerPort is the exposed container port in "port/proto" format.
+	ContainerPort string `protobuf:"bytes,3,opt,name=containerPort" json:"containerPort,omitempty"`
+	Protocol      string `protobuf:"bytes,4,opt,name=protocol" json:"protocol,omitempty"`
+	// Host and port the service is reachable at.
+	Host string `protobuf:"bytes,5,opt,name=host" json:"host,omitempty"`
+	Port uint32 `protobuf:"varint,6,opt,name=port" json:"port,omitempty"`
+	// Backend host and port the traffic is routed to.
+	BackendHost string `protobuf:"bytes,7,opt,name=backendHost" json:"backendHost,omitempty"`
+	BackendPort uint32 `protobuf:"varint,8,opt,name=backendPort" json:"backendPort,omitempty"`
+}
+
+func (m *TaskRoute) Reset()                    { *m = TaskRoute{} }
+func (m *TaskRoute) String() string            { return proto.CompactTextString(m) }
+func (*TaskRoute) ProtoMessage()               {}
+func (*TaskRoute) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{22} }
+
+func (m *TaskRoute) GetTaskID() string {
+	if m != nil {
+		return m.TaskID
+	}
+	return ""
+}
+
+func (m *TaskRoute) GetMinerID() string {
+	if m != nil {
+		return m.MinerID
+	}
+	return ""
+}
+
+func (m *TaskRoute) GetContainerPort() string {
+	if m != nil {
+		return m.ContainerPort
+	}
+	return ""
+}
+
+func (m *TaskRoute) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+func (m *TaskRoute) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *TaskRoute) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *TaskRoute) GetBackendHost() string {
+	if m != nil {
+		return m.BackendHost
+	}
+	return ""
+}
+
+func (m *TaskRoute) GetBackendPort() uint32 {
+	if m != nil {
+		return m.BackendPort
+	}
+	return 0
+}
+
+type RoutesReply struct {
+	Routes []*TaskRoute `protobuf:"bytes,1,rep,name=routes" json:"routes,omitempty"`
+}
+
+func (m *RoutesReply) Reset()                    { *m = RoutesReply{} }
+func (m *RoutesReply) String() string            { return proto.CompactTextString(m) }
+func (*RoutesReply) ProtoMessage()               {}
+func (*RoutesReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{23} }
+
+func (m *RoutesReply) GetRoutes() []*TaskRoute {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ListReply)(nil), "sonm.ListReply")
 	proto.RegisterType((*ListReply_ListValue)(nil), "sonm.ListReply.ListValue")
@@ -673,8 +871,11 @@ func init() {
 	proto.RegisterType((*GPUDeviceInfo)(nil), "sonm.GPUDeviceInfo")
 	proto.RegisterType((*DevicesReply)(nil), "sonm.DevicesReply")
 	proto.RegisterType((*InsertSlotRequest)(nil), "sonm.InsertSlotRequest")
+	proto.RegisterType((*PricingPolicy)(nil), "sonm.PricingPolicy")
 	proto.RegisterType((*PullTaskRequest)(nil), "sonm.PullTaskRequest")
 	proto.RegisterType((*DealInfoReply)(nil), "sonm.DealInfoReply")
+	proto.RegisterType((*TaskRoute)(nil), "sonm.TaskRoute")
+	proto.RegisterType((*RoutesReply)(nil), "sonm.RoutesReply")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -707,8 +908,32 @@ type HubClient interface {
 	TaskStatus(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TaskStatusReply, error)
 	MinerStatus(ctx context.Context, in *ID, opts ...grpc.CallOption) (*StatusMapReply, error)
 	TaskLogs(ctx context.Context, in *TaskLogsRequest, opts ...grpc.CallOption) (Hub_TaskLogsClient, error)
+	// Exec executes a command inside the task container on the Worker.
+	Exec(ctx context.Context, opts ...grpc.CallOption) (Hub_ExecClient, error)
+	// PortForward proxies a TCP connection to the task port. The task ID
+	// and the port, like "22/tcp", are passed via "task" and "port"
+	// metadata keys.
+	PortForward(ctx context.Context, opts ...grpc.CallOption) (Hub_PortForwardClient, error)
+	// CopyTo extracts the tar archive streamed into the task container
+	// path. The task ID and the path are passed via "task" and "path"
+	// metadata keys.
+	CopyTo(ctx context.Context, opts ...grpc.CallOption) (Hub_CopyToClient, error)
+	// CopyFrom streams a tar archive of the task container path.
+	CopyFrom(ctx context.Context, in *CopyFromRequest, opts ...grpc.CallOption) (Hub_CopyFromClient, error)
 	ProposeDeal(ctx context.Context, in *DealRequest, opts ...grpc.CallOption) (*Empty, error)
 	ApproveDeal(ctx context.Context, in *ApproveDealRequest, opts ...grpc.CallOption) (*Empty, error)
+	// CancelProposal drops the deal proposed for the given BID, releasing
+	// the resources reserved for it, when the deal is not going to be
+	// opened.
+	CancelProposal(ctx context.Context, in *DealRequest, opts ...grpc.CallOption) (*Empty, error)
+	// TerminateDeal requests to close the deal before its end time.
+	// Returns the settlement proposal computed from recorded task usage,
+	// the deal is closed by the Hub asynchronously.
+	TerminateDeal(ctx context.Context, in *DealCloseRequest, opts ...grpc.CallOption) (*DealSettlement, error)
+	// ExtendDeal binds the pending continuation deal to the given one, so
+	// running tasks stay untouched after its end time. The continuation
+	// deal is accepted when the current one expires.
+	ExtendDeal(ctx context.Context, in *DealExtendRequest, opts ...grpc.CallOption) (*Empty, error)
 	// Note: currently used for testing pusposes.
 	GetDealInfo(ctx context.Context, in *ID, opts ...grpc.CallOption) (*DealInfoReply, error)
 	DiscoverHub(ctx context.Context, in *DiscoverHubRequest, opts ...grpc.CallOption) (*Empty, error)
@@ -743,6 +968,8 @@ type HubClient interface {
 	InsertSlot(ctx context.Context, in *InsertSlotRequest, opts ...grpc.CallOption) (*ID, error)
 	// RemoveSlot removes the speified slot if fully matches.
 	RemoveSlot(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Empty, error)
+	// Routes returns the routing table of task services exposed by the Hub.
+	Routes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RoutesReply, error)
 }
 
 type hubClient struct {
@@ -938,6 +1165,131 @@ func (x *hubTaskLogsClient) Recv() (*TaskLogsChunk, error) {
 	return m, nil
 }
 
+func (c *hubClient) Exec(ctx context.Context, opts ...grpc.CallOption) (Hub_ExecClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Hub_serviceDesc.Streams[3], c.cc, "/sonm.Hub/Exec", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hubExecClient{stream}
+	return x, nil
+}
+
+type Hub_ExecClient interface {
+	Send(*ExecRequest) error
+	Recv() (*ExecReply, error)
+	grpc.ClientStream
+}
+
+type hubExecClient struct {
+	grpc.ClientStream
+}
+
+func (x *hubExecClient) Send(m *ExecRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *hubExecClient) Recv() (*ExecReply, error) {
+	m := new(ExecReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *hubClient) PortForward(ctx context.Context, opts ...grpc.CallOption) (Hub_PortForwardClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Hub_serviceDesc.Streams[4], c.cc, "/sonm.Hub/PortForward", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hubPortForwardClient{stream}
+	return x, nil
+}
+
+type Hub_PortForwardClient interface {
+	Send(*Chunk) error
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type hubPortForwardClient struct {
+	grpc.ClientStream
+}
+
+func (x *hubPortForwardClient) Send(m *Chunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *hubPortForwardClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *hubClient) CopyTo(ctx context.Context, opts ...grpc.CallOption) (Hub_CopyToClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Hub_serviceDesc.Streams[5], c.cc, "/sonm.Hub/CopyTo", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hubCopyToClient{stream}
+	return x, nil
+}
+
+type Hub_CopyToClient interface {
+	Send(*Chunk) error
+	Recv() (*Progress, error)
+	grpc.ClientStream
+}
+
+type hubCopyToClient struct {
+	grpc.ClientStream
+}
+
+func (x *hubCopyToClient) Send(m *Chunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *hubCopyToClient) Recv() (*Progress, error) {
+	m := new(Progress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *hubClient) CopyFrom(ctx context.Context, in *CopyFromRequest, opts ...grpc.CallOption) (Hub_CopyFromClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Hub_serviceDesc.Streams[6], c.cc, "/sonm.Hub/CopyFrom", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hubCopyFromClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Hub_CopyFromClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type hubCopyFromClient struct {
+	grpc.ClientStream
+}
+
+func (x *hubCopyFromClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *hubClient) ProposeDeal(ctx context.Context, in *DealRequest, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
 	err := grpc.Invoke(ctx, "/sonm.Hub/ProposeDeal", in, out, c.cc, opts...)
@@ -956,6 +1308,33 @@ func (c *hubClient) ApproveDeal(ctx context.Context, in *ApproveDealRequest, opt
 	return out, nil
 }
 
+func (c *hubClient) CancelProposal(ctx context.Context, in *DealRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/sonm.Hub/CancelProposal", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) TerminateDeal(ctx context.Context, in *DealCloseRequest, opts ...grpc.CallOption) (*DealSettlement, error) {
+	out := new(DealSettlement)
+	err := grpc.Invoke(ctx, "/sonm.Hub/TerminateDeal", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) ExtendDeal(ctx context.Context, in *DealExtendRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/sonm.Hub/ExtendDeal", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hubClient) GetDealInfo(ctx context.Context, in *ID, opts ...grpc.CallOption) (*DealInfoReply, error) {
 	out := new(DealInfoReply)
 	err := grpc.Invoke(ctx, "/sonm.Hub/GetDealInfo", in, out, c.cc, opts...)
@@ -1064,6 +1443,15 @@ func (c *hubClient) RemoveSlot(ctx context.Context, in *ID, opts ...grpc.CallOpt
 	return out, nil
 }
 
+func (c *hubClient) Routes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RoutesReply, error) {
+	out := new(RoutesReply)
+	err := grpc.Invoke(ctx, "/sonm.Hub/Routes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Hub service
 
 type HubServer interface {
@@ -1086,8 +1474,32 @@ type HubServer interface {
 	TaskStatus(context.Context, *ID) (*TaskStatusReply, error)
 	MinerStatus(context.Context, *ID) (*StatusMapReply, error)
 	TaskLogs(*TaskLogsRequest, Hub_TaskLogsServer) error
+	// Exec executes a command inside the task container on the Worker.
+	Exec(Hub_ExecServer) error
+	// PortForward proxies a TCP connection to the task port. The task ID
+	// and the port, like "22/tcp", are passed via "task" and "port"
+	// metadata keys.
+	PortForward(Hub_PortForwardServer) error
+	// CopyTo extracts the tar archive streamed into the task container
+	// path. The task ID and the path are passed via "task" and "path"
+	// metadata keys.
+	CopyTo(Hub_CopyToServer) error
+	// CopyFrom streams a tar archive of the task container path.
+	CopyFrom(*CopyFromRequest, Hub_CopyFromServer) error
 	ProposeDeal(context.Context, *DealRequest) (*Empty, error)
 	ApproveDeal(context.Context, *ApproveDealRequest) (*Empty, error)
+	// CancelProposal drops the deal proposed for the given BID, releasing
+	// the resources reserved for it, when the deal is not going to be
+	// opened.
+	CancelProposal(context.Context, *DealRequest) (*Empty, error)
+	// TerminateDeal requests to close the deal before its end time.
+	// Returns the settlement proposal computed from recorded task usage,
+	// the deal is closed by the Hub asynchronously.
+	TerminateDeal(context.Context, *DealCloseRequest) (*DealSettlement, error)
+	// ExtendDeal binds the pending continuation deal to the given one, so
+	// running tasks stay untouched after its end time. The continuation
+	// deal is accepted when the current one expires.
+	ExtendDeal(context.Context, *DealExtendRequest) (*Empty, error)
 	// Note: currently used for testing pusposes.
 	GetDealInfo(context.Context, *ID) (*DealInfoReply, error)
 	DiscoverHub(context.Context, *DiscoverHubRequest) (*Empty, error)
@@ -1122,6 +1534,8 @@ type HubServer interface {
 	InsertSlot(context.Context, *InsertSlotRequest) (*ID, error)
 	// RemoveSlot removes the speified slot if fully matches.
 	RemoveSlot(context.Context, *ID) (*Empty, error)
+	// Routes returns the routing table of task services exposed by the Hub.
+	Routes(context.Context, *Empty) (*RoutesReply, error)
 }
 
 func RegisterHubServer(s *grpc.Server, srv HubServer) {
@@ -1376,6 +1790,105 @@ func (x *hubTaskLogsServer) Send(m *TaskLogsChunk) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Hub_Exec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HubServer).Exec(&hubExecServer{stream})
+}
+
+type Hub_ExecServer interface {
+	Send(*ExecReply) error
+	Recv() (*ExecRequest, error)
+	grpc.ServerStream
+}
+
+type hubExecServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubExecServer) Send(m *ExecReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *hubExecServer) Recv() (*ExecRequest, error) {
+	m := new(ExecRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Hub_PortForward_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HubServer).PortForward(&hubPortForwardServer{stream})
+}
+
+type Hub_PortForwardServer interface {
+	Send(*Chunk) error
+	Recv() (*Chunk, error)
+	grpc.ServerStream
+}
+
+type hubPortForwardServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubPortForwardServer) Send(m *Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *hubPortForwardServer) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Hub_CopyTo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HubServer).CopyTo(&hubCopyToServer{stream})
+}
+
+type Hub_CopyToServer interface {
+	Send(*Progress) error
+	Recv() (*Chunk, error)
+	grpc.ServerStream
+}
+
+type hubCopyToServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubCopyToServer) Send(m *Progress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *hubCopyToServer) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Hub_CopyFrom_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CopyFromRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServer).CopyFrom(m, &hubCopyFromServer{stream})
+}
+
+type Hub_CopyFromServer interface {
+	Send(*Chunk) error
+	grpc.ServerStream
+}
+
+type hubCopyFromServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubCopyFromServer) Send(m *Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Hub_ProposeDeal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DealRequest)
 	if err := dec(in); err != nil {
@@ -1412,6 +1925,60 @@ func _Hub_ApproveDeal_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Hub_CancelProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DealRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).CancelProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Hub/CancelProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).CancelProposal(ctx, req.(*DealRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_TerminateDeal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DealCloseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).TerminateDeal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Hub/TerminateDeal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).TerminateDeal(ctx, req.(*DealCloseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_ExtendDeal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DealExtendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).ExtendDeal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Hub/ExtendDeal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).ExtendDeal(ctx, req.(*DealExtendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Hub_GetDealInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ID)
 	if err := dec(in); err != nil {
@@ -1628,6 +2195,24 @@ func _Hub_RemoveSlot_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Hub_Routes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).Routes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Hub/Routes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).Routes(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Hub_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "sonm.Hub",
 	HandlerType: (*HubServer)(nil),
@@ -1680,6 +2265,18 @@ var _Hub_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ApproveDeal",
 			Handler:    _Hub_ApproveDeal_Handler,
 		},
+		{
+			MethodName: "CancelProposal",
+			Handler:    _Hub_CancelProposal_Handler,
+		},
+		{
+			MethodName: "TerminateDeal",
+			Handler:    _Hub_TerminateDeal_Handler,
+		},
+		{
+			MethodName: "ExtendDeal",
+			Handler:    _Hub_ExtendDeal_Handler,
+		},
 		{
 			MethodName: "GetDealInfo",
 			Handler:    _Hub_GetDealInfo_Handler,
@@ -1728,6 +2325,10 @@ var _Hub_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveSlot",
 			Handler:    _Hub_RemoveSlot_Handler,
 		},
+		{
+			MethodName: "Routes",
+			Handler:    _Hub_Routes_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1746,6 +2347,29 @@ var _Hub_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Hub_TaskLogs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Exec",
+			Handler:       _Hub_Exec_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PortForward",
+			Handler:       _Hub_PortForward_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "CopyTo",
+			Handler:       _Hub_CopyTo_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "CopyFrom",
+			Handler:       _Hub_CopyFrom_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "hub.proto",
 }
@@ -2006,6 +2630,82 @@ var _Hub_TaskLogsCmd_gen = &cobra.Command{
 	RunE:  grpccmd.TypeToJson("sonm.TaskLogsRequest"),
 }
 
+var _Hub_ExecCmd = &cobra.Command{
+	Use:   "exec",
+	Short: "Make the Exec method call, input-type: sonm.ExecRequest output-type: sonm.ExecReply",
+	RunE: grpccmd.RunE(
+		"Exec",
+		"sonm.ExecRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewHubClient(cc)
+		},
+	),
+}
+
+var _Hub_ExecCmd_gen = &cobra.Command{
+	Use:   "exec-gen",
+	Short: "Generate JSON for method call of Exec (input-type: sonm.ExecRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.ExecRequest"),
+}
+
+var _Hub_PortForwardCmd = &cobra.Command{
+	Use:   "portForward",
+	Short: "Make the PortForward method call, input-type: sonm.Chunk output-type: sonm.Chunk",
+	RunE: grpccmd.RunE(
+		"PortForward",
+		"sonm.Chunk",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewHubClient(cc)
+		},
+	),
+}
+
+var _Hub_PortForwardCmd_gen = &cobra.Command{
+	Use:   "portForward-gen",
+	Short: "Generate JSON for method call of PortForward (input-type: sonm.Chunk)",
+	RunE:  grpccmd.TypeToJson("sonm.Chunk"),
+}
+
+var _Hub_CopyToCmd = &cobra.Command{
+	Use:   "copyTo",
+	Short: "Make the CopyTo method call, input-type: sonm.Chunk output-type: sonm.Progress",
+	RunE: grpccmd.RunE(
+		"CopyTo",
+		"sonm.Chunk",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewHubClient(cc)
+		},
+	),
+}
+
+var _Hub_CopyToCmd_gen = &cobra.Command{
+	Use:   "copyTo-gen",
+	Short: "Generate JSON for method call of CopyTo (input-type: sonm.Chunk)",
+	RunE:  grpccmd.TypeToJson("sonm.Chunk"),
+}
+
+var _Hub_CopyFromCmd = &cobra.Command{
+	Use:   "copyFrom",
+	Short: "Make the CopyFrom method call, input-type: sonm.CopyFromRequest output-type: sonm.Chunk",
+	RunE: grpccmd.RunE(
+		"CopyFrom",
+		"sonm.CopyFromRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewHubClient(cc)
+		},
+	),
+}
+
+var _Hub_CopyFromCmd_gen = &cobra.Command{
+	Use:   "copyFrom-gen",
+	Short: "Generate JSON for method call of CopyFrom (input-type: sonm.CopyFromRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.CopyFromRequest"),
+}
+
 var _Hub_ProposeDealCmd = &cobra.Command{
 	Use:   "proposeDeal",
 	Short: "Make the ProposeDeal method call, input-type: sonm.DealRequest output-type: sonm.Empty",
@@ -2044,6 +2744,63 @@ var _Hub_ApproveDealCmd_gen = &cobra.Command{
 	RunE:  grpccmd.TypeToJson("sonm.ApproveDealRequest"),
 }
 
+var _Hub_CancelProposalCmd = &cobra.Command{
+	Use:   "cancelProposal",
+	Short: "Make the CancelProposal method call, input-type: sonm.DealRequest output-type: sonm.Empty",
+	RunE: grpccmd.RunE(
+		"CancelProposal",
+		"sonm.DealRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewHubClient(cc)
+		},
+	),
+}
+
+var _Hub_CancelProposalCmd_gen = &cobra.Command{
+	Use:   "cancelProposal-gen",
+	Short: "Generate JSON for method call of CancelProposal (input-type: sonm.DealRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.DealRequest"),
+}
+
+var _Hub_TerminateDealCmd = &cobra.Command{
+	Use:   "terminateDeal",
+	Short: "Make the TerminateDeal method call, input-type: sonm.DealCloseRequest output-type: sonm.DealSettlement",
+	RunE: grpccmd.RunE(
+		"TerminateDeal",
+		"sonm.DealCloseRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewHubClient(cc)
+		},
+	),
+}
+
+var _Hub_TerminateDealCmd_gen = &cobra.Command{
+	Use:   "terminateDeal-gen",
+	Short: "Generate JSON for method call of TerminateDeal (input-type: sonm.DealCloseRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.DealCloseRequest"),
+}
+
+var _Hub_ExtendDealCmd = &cobra.Command{
+	Use:   "extendDeal",
+	Short: "Make the ExtendDeal method call, input-type: sonm.DealExtendRequest output-type: sonm.Empty",
+	RunE: grpccmd.RunE(
+		"ExtendDeal",
+		"sonm.DealExtendRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewHubClient(cc)
+		},
+	),
+}
+
+var _Hub_ExtendDealCmd_gen = &cobra.Command{
+	Use:   "extendDeal-gen",
+	Short: "Generate JSON for method call of ExtendDeal (input-type: sonm.DealExtendRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.DealExtendRequest"),
+}
+
 var _Hub_GetDealInfoCmd = &cobra.Command{
 	Use:   "getDealInfo",
 	Short: "Make the GetDealInfo method call, input-type: sonm.ID output-type: sonm.DealInfoReply",
@@ -2272,6 +3029,25 @@ var _Hub_RemoveSlotCmd_gen = &cobra.Command{
 	RunE:  grpccmd.TypeToJson("sonm.ID"),
 }
 
+var _Hub_RoutesCmd = &cobra.Command{
+	Use:   "routes",
+	Short: "Make the Routes method call, input-type: sonm.Empty output-type: sonm.RoutesReply",
+	RunE: grpccmd.RunE(
+		"Routes",
+		"sonm.Empty",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewHubClient(cc)
+		},
+	),
+}
+
+var _Hub_RoutesCmd_gen = &cobra.Command{
+	Use:   "routes-gen",
+	Short: "Generate JSON for method call of Routes (input-type: sonm.Empty)",
+	RunE:  grpccmd.TypeToJson("sonm.Empty"),
+}
+
 // Register commands with the root command and service command
 func init() {
 	grpccmd.RegisterServiceCmd(_HubCmd)
@@ -2302,10 +3078,24 @@ func init() {
 		_Hub_MinerStatusCmd_gen,
 		_Hub_TaskLogsCmd,
 		_Hub_TaskLogsCmd_gen,
+		_Hub_ExecCmd,
+		_Hub_ExecCmd_gen,
+		_Hub_PortForwardCmd,
+		_Hub_PortForwardCmd_gen,
+		_Hub_CopyToCmd,
+		_Hub_CopyToCmd_gen,
+		_Hub_CopyFromCmd,
+		_Hub_CopyFromCmd_gen,
 		_Hub_ProposeDealCmd,
 		_Hub_ProposeDealCmd_gen,
 		_Hub_ApproveDealCmd,
 		_Hub_ApproveDealCmd_gen,
+		_Hub_CancelProposalCmd,
+		_Hub_CancelProposalCmd_gen,
+		_Hub_TerminateDealCmd,
+		_Hub_TerminateDealCmd_gen,
+		_Hub_ExtendDealCmd,
+		_Hub_ExtendDealCmd_gen,
 		_Hub_GetDealInfoCmd,
 		_Hub_GetDealInfoCmd_gen,
 		_Hub_DiscoverHubCmd,
@@ -2330,109 +3120,137 @@ func init() {
 		_Hub_InsertSlotCmd_gen,
 		_Hub_RemoveSlotCmd,
 		_Hub_RemoveSlotCmd_gen,
+		_Hub_RoutesCmd,
+		_Hub_RoutesCmd_gen,
 	)
 }
 
 // End grpccmd
 
-func init() { proto.RegisterFile("hub.proto", fileDescriptor5) }
-
-var fileDescriptor5 = []byte{
-	// 1526 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xcd, 0x6e, 0xdb, 0xc6,
-	0x16, 0x16, 0x65, 0x49, 0x96, 0x8e, 0x6c, 0xd9, 0x1e, 0x39, 0x8e, 0xc2, 0xe4, 0xfa, 0x3a, 0x4c,
-	0x6e, 0xe2, 0xdc, 0xdc, 0x28, 0x8e, 0x6e, 0x9b, 0x14, 0x01, 0x82, 0x56, 0xb5, 0x1c, 0x59, 0x45,
-	0x7e, 0x04, 0xba, 0x6e, 0xd1, 0x25, 0x25, 0x8e, 0x6d, 0xc2, 0x12, 0xc9, 0x0e, 0x87, 0x2e, 0xbc,
-	0x2e, 0xba, 0x2d, 0xba, 0xee, 0x1b, 0x74, 0x57, 0xa0, 0x40, 0x77, 0x7d, 0x89, 0xbe, 0x41, 0xdf,
-	0xa4, 0x98, 0x3f, 0x72, 0x28, 0x51, 0x4e, 0x8b, 0xa0, 0x3b, 0x9e, 0x33, 0xe7, 0xe7, 0x9b, 0x73,
-	0xe6, 0xfc, 0x48, 0x50, 0x3b, 0x8b, 0x47, 0xed, 0x90, 0x04, 0x34, 0x40, 0xa5, 0x28, 0xf0, 0xa7,
-	0x66, 0x6d, 0xe4, 0xb9, 0x82, 0x61, 0xae, 0x8c, 0xbc, 0x53, 0xcf, 0xa7, 0x92, 0x42, 0x63, 0x27,
-	0x74, 0x46, 0xde, 0xc4, 0xa3, 0x1e, 0x8e, 0x24, 0x6f, 0x6d, 0x1c, 0xf8, 0xd4, 0xf1, 0x7c, 0x4c,
-	0x24, 0x03, 0x5c, 0xec, 0x4c, 0xd4, 0xa1, 0xe7, 0x33, 0x8b, 0xbe, 0xe7, 0x08, 0x86, 0xf5, 0x8b,
-	0x01, 0xb5, 0x57, 0x5e, 0x44, 0x6d, 0x1c, 0x4e, 0x2e, 0xd1, 0x23, 0x28, 0x79, 0xfe, 0x49, 0xd0,
-	0x32, 0x76, 0x96, 0x76, 0xeb, 0x9d, 0x1b, 0x6d, 0x26, 0xdb, 0x4e, 0x8e, 0xdb, 0x03, 0xff, 0x24,
-	0x38, 0xf0, 0x29, 0xb9, 0xb4, 0xb9, 0x98, 0x79, 0x47, 0xe8, 0x7e, 0xe1, 0x4c, 0x62, 0x8c, 0xb6,
-	0xa0, 0x72, 0xc1, 0x3e, 0x22, 0xae, 0x5d, 0xb3, 0x25, 0x65, 0xda, 0x50, 0x4b, 0xf4, 0xd0, 0x3a,
-	0x2c, 0x9d, 0xe3, 0xcb, 0x96, 0xb1, 0x63, 0xec, 0xd6, 0x6c, 0xf6, 0x89, 0x1e, 0x43, 0x99, 0x0b,
-	0xb6, 0x8a, 0x3b, 0x46, 0x9e, 0xcf, 0xc4, 0x81, 0x2d, 0xe4, 0x9e, 0x17, 0x3f, 0x32, 0x2c, 0x17,
-	0x9a, 0x87, 0xf1, 0xe8, 0x88, 0x3a, 0x84, 0x7e, 0xee, 0x44, 0xe7, 0x36, 0xfe, 0x3a, 0xc6, 0x11,
-	0x45, 0xdb, 0x50, 0x62, 0x77, 0xe5, 0xe6, 0xeb, 0x1d, 0x10, 0xa6, 0x7a, 0xd8, 0x99, 0xd8, 0x9c,
-	0x8f, 0x1e, 0x41, 0x2d, 0x09, 0x8e, 0xf4, 0xb7, 0x26, 0x84, 0xf6, 0x15, 0xdb, 0x4e, 0x25, 0xac,
-	0xd7, 0x70, 0xed, 0x30, 0x1e, 0x7d, 0x16, 0x78, 0xfe, 0x1b, 0x4c, 0xbf, 0x09, 0x48, 0xe2, 0x67,
-	0x0b, 0x2a, 0xd4, 0x89, 0xce, 0x07, 0x3d, 0x79, 0x11, 0x49, 0xa1, 0x5b, 0x50, 0xf3, 0x85, 0xe4,
-	0xa0, 0xc7, 0xed, 0xd7, 0xec, 0x94, 0x61, 0x5d, 0xc2, 0x46, 0x16, 0x34, 0x8b, 0x78, 0x03, 0x8a,
-	0x9e, 0x2b, 0xcd, 0x14, 0x3d, 0x17, 0x99, 0x50, 0xc5, 0xbe, 0x1b, 0x06, 0x9e, 0x4f, 0x5b, 0x45,
-	0x1e, 0xc7, 0x84, 0x46, 0x2d, 0x58, 0x3e, 0x8b, 0x47, 0x5d, 0xd7, 0x25, 0xad, 0x25, 0xae, 0xa0,
-	0x48, 0xb4, 0x0d, 0x90, 0xf8, 0x89, 0x5a, 0x25, 0xae, 0xa7, 0x71, 0xac, 0x1f, 0x8a, 0xd0, 0x10,
-	0xbe, 0x69, 0x1c, 0x09, 0xc7, 0xdb, 0x00, 0x53, 0x76, 0xcb, 0xfd, 0x20, 0xf6, 0x29, 0x07, 0x50,
-	0xb2, 0x35, 0x0e, 0xbb, 0x63, 0x1c, 0x52, 0x6f, 0x2a, 0x12, 0x53, 0xb2, 0x25, 0xc5, 0x40, 0x5c,
-	0x60, 0x12, 0x79, 0x81, 0xaf, 0x40, 0x48, 0x92, 0x41, 0x0f, 0x27, 0x0e, 0x3d, 0x09, 0xc8, 0xb4,
-	0x55, 0xe2, 0x47, 0x09, 0xcd, 0xb4, 0x30, 0x3d, 0xe3, 0xd0, 0xcb, 0x42, 0x4b, 0x92, 0xe8, 0x1e,
-	0x34, 0xc6, 0x13, 0x0f, 0xfb, 0xf4, 0x40, 0x5d, 0xbb, 0xc2, 0xe1, 0xcf, 0x70, 0xd1, 0x2e, 0xac,
-	0xb1, 0xdb, 0x60, 0xa2, 0x38, 0x51, 0x6b, 0x99, 0x0b, 0xce, 0xb2, 0xd1, 0x5d, 0x58, 0x75, 0x7c,
-	0x3f, 0x88, 0xfd, 0x31, 0x3e, 0x20, 0x24, 0x20, 0xad, 0x2a, 0xf7, 0x98, 0x65, 0x5a, 0xc7, 0x50,
-	0xe7, 0x2f, 0x43, 0xa6, 0x74, 0x13, 0xca, 0x23, 0xcf, 0x1d, 0xa8, 0x54, 0x08, 0x82, 0x71, 0x59,
-	0x66, 0x5d, 0x99, 0x4c, 0x41, 0xb0, 0x8b, 0x46, 0x21, 0x1e, 0x1f, 0x3a, 0xd1, 0x99, 0xba, 0xa8,
-	0xa2, 0xad, 0x13, 0x40, 0xdd, 0x30, 0x24, 0xc1, 0x05, 0xd6, 0xad, 0xdf, 0x85, 0x0a, 0x7b, 0x80,
-	0xf2, 0xc1, 0xd4, 0x3b, 0x2b, 0xe2, 0xd5, 0x7d, 0xea, 0x9d, 0x0e, 0x7c, 0x6a, 0xcb, 0x33, 0x85,
-	0x41, 0x3d, 0x1d, 0x41, 0x28, 0x0c, 0x3d, 0x19, 0x6e, 0x41, 0x58, 0x3f, 0x19, 0xd0, 0xea, 0x63,
-	0xda, 0xc3, 0x17, 0xde, 0x18, 0x0f, 0x49, 0x10, 0x62, 0xc2, 0x7a, 0x80, 0xc8, 0xed, 0x1b, 0x80,
-	0x30, 0x61, 0xc9, 0x62, 0x6e, 0x0b, 0x97, 0x8b, 0x74, 0xda, 0x29, 0x2d, 0x2a, 0x5c, 0xb3, 0x60,
-	0xbe, 0x80, 0xb5, 0x99, 0xe3, 0x9c, 0x42, 0xde, 0xd4, 0x0b, 0xd9, 0xd0, 0xab, 0xf5, 0x37, 0x03,
-	0xcc, 0xa3, 0x3c, 0xbf, 0x22, 0x38, 0x0d, 0x28, 0x26, 0x95, 0x54, 0x1c, 0xf4, 0xd0, 0x30, 0x83,
-	0xbe, 0xc8, 0xd1, 0xef, 0x09, 0xf4, 0x8b, 0xad, 0xfc, 0x93, 0xf8, 0xbf, 0x33, 0x00, 0x8e, 0x26,
-	0x01, 0x95, 0xd1, 0x7d, 0x02, 0xe5, 0x88, 0x51, 0x32, 0xb0, 0x37, 0x25, 0xb4, 0x44, 0x40, 0x7c,
-	0x0a, 0x14, 0x42, 0xd2, 0xec, 0x49, 0x03, 0x8b, 0x7c, 0xef, 0x64, 0x9b, 0x20, 0xa4, 0x26, 0x75,
-	0x1c, 0xbf, 0x1b, 0xb0, 0xde, 0xc7, 0xb4, 0x3b, 0x99, 0x68, 0x68, 0x9e, 0x65, 0xd1, 0xdc, 0x4e,
-	0xd2, 0x9c, 0x11, 0xcb, 0xc1, 0xf4, 0x5f, 0xa8, 0x32, 0x26, 0xeb, 0xaf, 0xac, 0x71, 0x32, 0xa6,
-	0xb4, 0xa1, 0xbb, 0xe7, 0x7c, 0xf3, 0xab, 0x77, 0xe0, 0xff, 0x30, 0x8b, 0xff, 0xdf, 0x57, 0x80,
-	0xe0, 0x9d, 0x5d, 0xbb, 0xd4, 0x27, 0xd0, 0xe8, 0xba, 0x2e, 0xf7, 0xb5, 0xe0, 0x3d, 0x28, 0x70,
-	0xf3, 0xb1, 0xe1, 0x7c, 0x6b, 0x1f, 0x36, 0x6c, 0x3c, 0x0d, 0x2e, 0xf0, 0xfb, 0x18, 0x79, 0x06,
-	0x37, 0xfa, 0x98, 0xda, 0xf8, 0xd4, 0x8b, 0x28, 0x26, 0xd8, 0xfd, 0x92, 0x37, 0x15, 0x19, 0x63,
-	0x13, 0x96, 0x3c, 0x57, 0x45, 0xb8, 0x2a, 0x74, 0x07, 0x3d, 0x9b, 0x31, 0xad, 0x5f, 0x8b, 0xb0,
-	0xca, 0xda, 0x79, 0x3a, 0x44, 0x9f, 0x64, 0x86, 0xe8, 0xbf, 0x84, 0x78, 0x46, 0x64, 0x6e, 0x90,
-	0xfe, 0x68, 0x40, 0x95, 0x49, 0x30, 0x3e, 0x7a, 0x01, 0x65, 0x36, 0x4f, 0x94, 0xbf, 0xfb, 0x79,
-	0x06, 0x94, 0x30, 0xff, 0x50, 0x79, 0xe5, 0x5a, 0xe6, 0x5b, 0x80, 0x94, 0x99, 0x93, 0xab, 0x87,
-	0xd9, 0x5c, 0x5d, 0x4b, 0xcd, 0x6b, 0xe3, 0x41, 0xcb, 0x90, 0x79, 0x7c, 0xf5, 0x00, 0xef, 0x64,
-	0xed, 0xdd, 0xba, 0x0a, 0xae, 0x9e, 0xf8, 0x21, 0xac, 0xee, 0x0f, 0x8f, 0x45, 0x39, 0xf3, 0x7b,
-	0x6f, 0x41, 0x85, 0xcf, 0x9f, 0x64, 0x81, 0x10, 0x14, 0xba, 0xcf, 0x9a, 0x27, 0x93, 0x9a, 0x19,
-	0xd9, 0x4a, 0xd9, 0x96, 0xc7, 0xcc, 0x62, 0xff, 0x7d, 0x2c, 0xf6, 0xe7, 0x2c, 0x7e, 0x5f, 0x84,
-	0x15, 0xc1, 0x92, 0x2f, 0x61, 0x0f, 0x4a, 0xfb, 0xc3, 0x63, 0x95, 0x9a, 0x5b, 0x6a, 0xc3, 0x48,
-	0x25, 0x18, 0x2c, 0x99, 0x0f, 0x2e, 0xc9, 0x34, 0xfa, 0x4c, 0xa3, 0xb8, 0x50, 0xa3, 0x9f, 0x6a,
-	0xb0, 0x4f, 0xf3, 0x15, 0xd4, 0x12, 0x23, 0x39, 0xf1, 0x7e, 0x90, 0x8d, 0x77, 0x73, 0x26, 0x1a,
-	0x33, 0x61, 0x66, 0xd6, 0xfa, 0x7f, 0xdb, 0x5a, 0x7f, 0x81, 0x35, 0xeb, 0x5b, 0x03, 0x36, 0x06,
-	0x7e, 0x84, 0x09, 0xd5, 0x8b, 0x2d, 0x6d, 0x1f, 0xb9, 0xc5, 0x85, 0x3e, 0x80, 0x46, 0x48, 0x58,
-	0xd7, 0xc6, 0xe4, 0x08, 0x8f, 0x03, 0xdf, 0xe5, 0x63, 0x73, 0x76, 0x0c, 0xce, 0xc8, 0xb0, 0x9d,
-	0x61, 0x14, 0x5f, 0x62, 0x92, 0x8c, 0x3e, 0x45, 0x5a, 0x5d, 0x58, 0x1b, 0xc6, 0x93, 0x89, 0xbe,
-	0xfa, 0x6d, 0xc9, 0x09, 0xab, 0x06, 0xb8, 0xa4, 0x92, 0x55, 0x4d, 0x8d, 0x70, 0x49, 0x59, 0x3f,
-	0x1b, 0xb0, 0xca, 0x26, 0x34, 0xbf, 0x20, 0x4f, 0x6d, 0x2b, 0xd9, 0xc4, 0xf4, 0x1a, 0x67, 0x3b,
-	0xd9, 0x6d, 0x28, 0x07, 0xc4, 0x4d, 0x56, 0xc6, 0xba, 0x38, 0x7c, 0xcb, 0x58, 0xb6, 0x38, 0x41,
-	0x6d, 0x58, 0x26, 0xb1, 0xef, 0x7b, 0xfe, 0x29, 0xc7, 0x5a, 0xef, 0x6c, 0xca, 0x20, 0xf0, 0x92,
-	0x7a, 0xed, 0x84, 0xa2, 0xaa, 0x94, 0x10, 0xea, 0xb0, 0x4d, 0x74, 0x1a, 0x4e, 0x30, 0xc5, 0x2a,
-	0x18, 0xf9, 0x1a, 0xa9, 0x58, 0xe7, 0x0f, 0x80, 0xa5, 0xc3, 0x78, 0x84, 0xee, 0x41, 0x69, 0xc8,
-	0x6c, 0x48, 0x1c, 0x07, 0xd3, 0x90, 0x5e, 0x9a, 0xf2, 0x09, 0xb3, 0x03, 0xae, 0x68, 0x15, 0xd0,
-	0x23, 0xa8, 0x08, 0x63, 0x59, 0x49, 0xe9, 0x27, 0xbb, 0x0e, 0x5a, 0x05, 0x66, 0x96, 0xcf, 0x82,
-	0x3c, 0xb3, 0x49, 0x25, 0x5b, 0x05, 0x74, 0x07, 0x4a, 0xbc, 0xb8, 0x92, 0x18, 0x29, 0xa1, 0x24,
-	0x94, 0x56, 0x01, 0xb5, 0x45, 0x3f, 0x9b, 0x37, 0xd8, 0xcc, 0x69, 0x0f, 0x1c, 0x6b, 0x75, 0x18,
-	0x47, 0x67, 0x8c, 0xad, 0xe4, 0xf7, 0xcf, 0x62, 0xff, 0xdc, 0x6c, 0xc8, 0x7b, 0x91, 0xe0, 0x94,
-	0xe0, 0x28, 0xb2, 0x0a, 0xbb, 0xc6, 0x9e, 0x81, 0x3a, 0x4c, 0x5c, 0x3c, 0x00, 0x24, 0x1b, 0xd8,
-	0xcc, 0x83, 0x30, 0x75, 0x2b, 0x56, 0x61, 0xcf, 0x40, 0x5d, 0xa8, 0x25, 0xbb, 0x37, 0xba, 0xa1,
-	0x07, 0x21, 0xf3, 0x23, 0xc2, 0xbc, 0x9e, 0x77, 0x24, 0x50, 0x7e, 0x0c, 0x75, 0xed, 0xd7, 0x00,
-	0xba, 0x99, 0x48, 0xce, 0xff, 0x46, 0x30, 0x37, 0xc4, 0xa1, 0xe4, 0x1e, 0x85, 0x78, 0xcc, 0x63,
-	0x57, 0x3d, 0xa2, 0x41, 0xc8, 0x21, 0xa4, 0xf1, 0xd3, 0x03, 0x64, 0x15, 0xd0, 0x63, 0xd1, 0xc0,
-	0x65, 0xee, 0x52, 0xb1, 0xfc, 0x4e, 0xcd, 0x15, 0xea, 0xaf, 0x59, 0x63, 0x9b, 0xd3, 0xc8, 0x7d,
-	0x52, 0x56, 0x01, 0x3d, 0x97, 0xd9, 0x09, 0x4e, 0x23, 0xa4, 0x59, 0x65, 0xb4, 0x82, 0xdf, 0xcc,
-	0xb2, 0xd3, 0x30, 0x3e, 0x86, 0x3a, 0xdb, 0xa5, 0x82, 0x88, 0x2f, 0xb8, 0x68, 0x43, 0xfb, 0x91,
-	0x95, 0x8d, 0xbc, 0xba, 0xce, 0x53, 0xa8, 0x6b, 0x1b, 0x31, 0x6a, 0x89, 0xd3, 0xf9, 0x25, 0x79,
-	0x56, 0xaf, 0x0d, 0x75, 0xbe, 0xac, 0x8a, 0x1a, 0xd5, 0x6e, 0xd5, 0x4c, 0x5d, 0xea, 0x4f, 0xee,
-	0x29, 0xd4, 0x7b, 0x5e, 0x34, 0x0e, 0x2e, 0x30, 0x61, 0x55, 0x22, 0xfd, 0x68, 0xac, 0x05, 0x7e,
-	0xfe, 0x07, 0xcb, 0xb2, 0x1d, 0x67, 0x5f, 0x2a, 0x9a, 0x6f, 0xd5, 0x1c, 0xd5, 0x0a, 0x8f, 0xb5,
-	0x52, 0x49, 0x61, 0xe5, 0xcb, 0x77, 0xa1, 0x99, 0xb3, 0x72, 0x6b, 0x6a, 0xdb, 0x57, 0xef, 0xe5,
-	0x56, 0x01, 0xbd, 0x84, 0x66, 0xce, 0xde, 0x8b, 0x76, 0xde, 0xb5, 0x12, 0xcf, 0x5e, 0xf4, 0x25,
-	0x6c, 0xe6, 0xad, 0x38, 0xd9, 0x5b, 0xa7, 0xab, 0x5b, 0xfe, 0x2e, 0x64, 0x15, 0xd0, 0x03, 0x68,
-	0xa8, 0x33, 0x71, 0xb2, 0xf8, 0x29, 0x3f, 0x84, 0xf5, 0x1e, 0x26, 0x7f, 0x51, 0x78, 0x17, 0xca,
-	0x7c, 0x57, 0xcc, 0x02, 0x5a, 0x9f, 0x5d, 0xaf, 0xad, 0x02, 0x7a, 0x02, 0x90, 0x0e, 0x21, 0x74,
-	0x5d, 0xb5, 0x9f, 0x99, 0xb1, 0x64, 0x26, 0x9e, 0xac, 0x02, 0xfa, 0x0f, 0x40, 0xba, 0x24, 0x2e,
-	0xc4, 0x30, 0xaa, 0xf0, 0x7f, 0x45, 0xfe, 0xff, 0x67, 0x00, 0x00, 0x00, 0xff, 0xff, 0xcb, 0xa8,
-	0x90, 0xfe, 0x83, 0x11, 0x00, 0x00,
+func init() { proto.RegisterFile("hub.proto", fileDescriptor6) }
+
+var fileDescriptor6 = []byte{
+	// 1944 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x4f, 0x73, 0xdb, 0xc6,
+	0x15, 0x27, 0x28, 0x92, 0x22, 0x1f, 0xf5, 0xc7, 0x5e, 0x39, 0x0a, 0x8c, 0xb8, 0x8e, 0x82, 0xa4,
+	0xb1, 0x12, 0x47, 0xb4, 0xac, 0xa6, 0x76, 0x27, 0x33, 0x99, 0x54, 0x25, 0x65, 0x8a, 0x1d, 0x2b,
+	0xe1, 0x40, 0x56, 0x3b, 0x3d, 0x82, 0xc4, 0x4a, 0xc2, 0x08, 0xc4, 0xa2, 0x8b, 0x85, 0x1c, 0x7e,
+	0x80, 0x5e, 0x3b, 0xed, 0xb5, 0xc7, 0xde, 0x7a, 0x6d, 0x67, 0x7a, 0xcb, 0x97, 0xe8, 0xd7, 0xe8,
+	0x37, 0xe8, 0xa9, 0xb3, 0xff, 0x80, 0x05, 0x09, 0x3a, 0xee, 0x78, 0x7a, 0xc3, 0x7b, 0xfb, 0x7b,
+	0x6f, 0xdf, 0xbe, 0xb7, 0xfb, 0xfe, 0x00, 0x3a, 0xd7, 0xd9, 0xa4, 0x97, 0x50, 0xc2, 0x08, 0x6a,
+	0xa4, 0x24, 0x9e, 0x39, 0x9d, 0x49, 0x18, 0x48, 0x86, 0xb3, 0x31, 0x09, 0xaf, 0xc2, 0x98, 0x29,
+	0x0a, 0x4d, 0xfd, 0xc4, 0x9f, 0x84, 0x51, 0xc8, 0x42, 0x9c, 0x2a, 0xde, 0xf6, 0x94, 0xc4, 0xcc,
+	0x0f, 0x63, 0x4c, 0x15, 0x03, 0x02, 0xec, 0x47, 0x7a, 0x31, 0x8c, 0xb9, 0xc6, 0x38, 0xf4, 0x25,
+	0xc3, 0xfd, 0x87, 0x05, 0x9d, 0x97, 0x61, 0xca, 0x3c, 0x9c, 0x44, 0x73, 0x74, 0x00, 0x8d, 0x30,
+	0xbe, 0x24, 0xb6, 0xb5, 0xb7, 0xb6, 0xdf, 0x3d, 0xba, 0xdf, 0xe3, 0xd8, 0x5e, 0xbe, 0xdc, 0x1b,
+	0xc5, 0x97, 0xe4, 0x24, 0x66, 0x74, 0xee, 0x09, 0x98, 0xf3, 0xb1, 0x94, 0xfd, 0x8d, 0x1f, 0x65,
+	0x18, 0xed, 0x42, 0xeb, 0x96, 0x7f, 0xa4, 0x42, 0xba, 0xe3, 0x29, 0xca, 0xf1, 0xa0, 0x93, 0xcb,
+	0xa1, 0x3b, 0xb0, 0x76, 0x83, 0xe7, 0xb6, 0xb5, 0x67, 0xed, 0x77, 0x3c, 0xfe, 0x89, 0x9e, 0x40,
+	0x53, 0x00, 0xed, 0xfa, 0x9e, 0x55, 0xb5, 0x67, 0xbe, 0x81, 0x27, 0x71, 0x5f, 0xd5, 0x7f, 0x61,
+	0xb9, 0x7f, 0xb6, 0x60, 0xe7, 0x34, 0x9b, 0x9c, 0x33, 0x9f, 0xb2, 0x57, 0x7e, 0x7a, 0xe3, 0xe1,
+	0xdf, 0x67, 0x38, 0x65, 0xe8, 0x21, 0x34, 0xf8, 0x61, 0x85, 0xfe, 0xee, 0x11, 0x48, 0x5d, 0x03,
+	0xec, 0x47, 0x9e, 0xe0, 0xa3, 0x03, 0xe8, 0xe4, 0xde, 0x51, 0x1b, 0x6e, 0x4b, 0x50, 0x5f, 0xb3,
+	0xbd, 0x02, 0x81, 0x1e, 0x43, 0x3b, 0x0d, 0x03, 0x3c, 0xf5, 0x69, 0x6a, 0xaf, 0xed, 0xad, 0x55,
+	0xa1, 0x73, 0x80, 0x7b, 0x06, 0xef, 0x9d, 0x66, 0x93, 0x5f, 0x93, 0x30, 0xfe, 0x16, 0xb3, 0xd7,
+	0x84, 0xe6, 0x46, 0xed, 0x42, 0x8b, 0xf9, 0xe9, 0xcd, 0x68, 0xa0, 0x8e, 0xad, 0x28, 0xf4, 0x00,
+	0x3a, 0xb1, 0x44, 0x8e, 0x06, 0xc2, 0x98, 0x8e, 0x57, 0x30, 0xdc, 0x39, 0xdc, 0x2d, 0x9f, 0x90,
+	0xc7, 0x67, 0x0b, 0xea, 0x61, 0xa0, 0xd4, 0xd4, 0xc3, 0x00, 0x39, 0xd0, 0xc6, 0x71, 0x90, 0x90,
+	0x30, 0x66, 0x76, 0x5d, 0x78, 0x3d, 0xa7, 0x91, 0x0d, 0xeb, 0xd7, 0xd9, 0xe4, 0x38, 0x08, 0xa8,
+	0xbd, 0x26, 0x04, 0x34, 0x89, 0x1e, 0x02, 0xe4, 0xfb, 0xa4, 0x76, 0x43, 0xc8, 0x19, 0x1c, 0xf7,
+	0x4f, 0x75, 0xd8, 0x92, 0x7b, 0xb3, 0x2c, 0x95, 0x1b, 0x3f, 0x04, 0x98, 0xf1, 0xf3, 0xf6, 0x49,
+	0x16, 0x33, 0x61, 0x40, 0xc3, 0x33, 0x38, 0xfc, 0x8c, 0x59, 0xc2, 0xc2, 0x99, 0x0c, 0x63, 0xc3,
+	0x53, 0x14, 0x37, 0xe2, 0x16, 0xd3, 0x34, 0x24, 0xb1, 0x36, 0x42, 0x91, 0xdc, 0xf4, 0x24, 0xf2,
+	0xd9, 0x25, 0xa1, 0x33, 0xbb, 0x21, 0x96, 0x72, 0x9a, 0x4b, 0x61, 0x76, 0x2d, 0x4c, 0x6f, 0x4a,
+	0x29, 0x45, 0xa2, 0x4f, 0x61, 0x6b, 0x1a, 0x85, 0x38, 0x66, 0x27, 0xfa, 0xd8, 0x2d, 0x61, 0xfe,
+	0x02, 0x17, 0xed, 0xc3, 0x36, 0x3f, 0x0d, 0xa6, 0x9a, 0x93, 0xda, 0xeb, 0x02, 0xb8, 0xc8, 0x46,
+	0x9f, 0xc0, 0xa6, 0x1f, 0xc7, 0x24, 0x8b, 0xa7, 0xf8, 0x84, 0x52, 0x42, 0xed, 0xb6, 0xd8, 0xb1,
+	0xcc, 0x74, 0x2f, 0xa0, 0x2b, 0xae, 0x91, 0x0a, 0xe9, 0x3d, 0x68, 0x4e, 0xc2, 0x60, 0xa4, 0x43,
+	0x21, 0x09, 0xce, 0xe5, 0x91, 0x0d, 0x54, 0x30, 0x25, 0xc1, 0x0f, 0x9a, 0x26, 0x78, 0x7a, 0xea,
+	0xa7, 0xd7, 0xfa, 0xa0, 0x9a, 0x76, 0x2f, 0x01, 0x1d, 0x27, 0x09, 0x25, 0xb7, 0xd8, 0xd4, 0xfe,
+	0x09, 0xb4, 0xf8, 0x6d, 0x55, 0x17, 0xa6, 0x7b, 0xb4, 0x21, 0x2f, 0xdd, 0xaf, 0xc2, 0xab, 0x51,
+	0xcc, 0x3c, 0xb5, 0xa6, 0x6d, 0xd0, 0x57, 0x47, 0x12, 0xda, 0x86, 0x81, 0x72, 0xb7, 0x24, 0xdc,
+	0xbf, 0x59, 0x60, 0x0f, 0x31, 0x1b, 0xe0, 0xdb, 0x70, 0x8a, 0xc7, 0x94, 0x24, 0x98, 0xf2, 0x8c,
+	0x21, 0x63, 0xfb, 0x2d, 0x40, 0x92, 0xb3, 0xd4, 0xd3, 0xef, 0xc9, 0x2d, 0x57, 0xc9, 0xf4, 0x0a,
+	0x5a, 0xe6, 0x03, 0x43, 0x83, 0xf3, 0x35, 0x6c, 0x2f, 0x2c, 0x57, 0x3c, 0xfb, 0x7b, 0xe6, 0xb3,
+	0xb7, 0xcc, 0xb7, 0xfd, 0x83, 0x05, 0xce, 0x79, 0xd5, 0xbe, 0xd2, 0x39, 0x5b, 0x50, 0xcf, 0x5f,
+	0x52, 0x7d, 0x34, 0x40, 0xe3, 0x92, 0xf5, 0x75, 0x61, 0xfd, 0xa1, 0xb4, 0x7e, 0xb5, 0x96, 0xff,
+	0xa7, 0xfd, 0x7f, 0xb0, 0x00, 0xce, 0x23, 0xc2, 0x94, 0x77, 0x9f, 0x42, 0x33, 0xe5, 0x94, 0x72,
+	0xec, 0x07, 0xca, 0xb4, 0x1c, 0x20, 0x3f, 0xa5, 0x15, 0x12, 0xe9, 0x0c, 0x00, 0x0a, 0x66, 0xc5,
+	0xde, 0x7b, 0xe5, 0x94, 0x09, 0x85, 0x4a, 0xd3, 0x8e, 0x7f, 0x59, 0x70, 0x67, 0x88, 0xd9, 0x71,
+	0x14, 0x19, 0xd6, 0x3c, 0x2f, 0x5b, 0xf3, 0x51, 0x1e, 0xe6, 0x12, 0xac, 0xc2, 0xa6, 0xcf, 0xa1,
+	0xcd, 0x99, 0x2f, 0x43, 0x99, 0x65, 0x39, 0x53, 0xe9, 0x30, 0xb7, 0x17, 0x7c, 0xe7, 0x77, 0x3f,
+	0x62, 0xff, 0xcf, 0xcb, 0xf6, 0x7f, 0xf8, 0x06, 0x23, 0x44, 0x1d, 0x30, 0x0e, 0xf5, 0x4b, 0xd8,
+	0x3a, 0x0e, 0x02, 0xb1, 0xd7, 0x8a, 0xfb, 0xa0, 0x8d, 0x5b, 0xf6, 0x8d, 0xe0, 0xbb, 0x7d, 0xb8,
+	0xeb, 0xe1, 0x19, 0xb9, 0xc5, 0xef, 0xa2, 0xe4, 0x39, 0xdc, 0x1f, 0x62, 0xe6, 0xe1, 0xab, 0x30,
+	0x65, 0x98, 0xe2, 0xe0, 0xb7, 0x22, 0xa9, 0x28, 0x1f, 0x3b, 0xb0, 0x16, 0x06, 0xda, 0xc3, 0x6d,
+	0x29, 0x3b, 0x1a, 0x78, 0x9c, 0xe9, 0xfe, 0xb3, 0x0e, 0x9b, 0x3c, 0x9d, 0x17, 0x25, 0xf7, 0x69,
+	0xa9, 0xe4, 0xfe, 0x44, 0xc2, 0x4b, 0x90, 0xa5, 0xb2, 0xfb, 0x17, 0x0b, 0xda, 0x1c, 0xc1, 0xf9,
+	0xe8, 0x6b, 0x68, 0xf2, 0x7a, 0xa2, 0xf7, 0x7b, 0x54, 0xa5, 0x40, 0x83, 0xc5, 0x87, 0x8e, 0xab,
+	0x90, 0x72, 0xbe, 0x03, 0x28, 0x98, 0x15, 0xb1, 0x7a, 0x5c, 0x8e, 0xd5, 0x7b, 0x85, 0x7a, 0xa3,
+	0x3c, 0x18, 0x11, 0x72, 0x2e, 0xde, 0x5c, 0xee, 0x8f, 0xca, 0xfa, 0x1e, 0xbc, 0xc9, 0x5c, 0x33,
+	0xf0, 0x63, 0xd8, 0xec, 0x8f, 0x2f, 0xe4, 0x73, 0x16, 0xe7, 0xde, 0x85, 0x96, 0xa8, 0x3f, 0x79,
+	0xbb, 0x21, 0x29, 0xf4, 0x88, 0x27, 0x4f, 0x8e, 0x5a, 0xa8, 0xef, 0x5a, 0xd8, 0x53, 0xcb, 0x5c,
+	0xe3, 0xf0, 0x5d, 0x34, 0x0e, 0x97, 0x34, 0xfe, 0xb1, 0x0e, 0x1b, 0x92, 0xa5, 0x6e, 0xc2, 0x21,
+	0x34, 0xfa, 0xe3, 0x0b, 0x1d, 0x9a, 0x07, 0xba, 0x1d, 0x29, 0x10, 0xdc, 0x2c, 0x15, 0x0f, 0x81,
+	0xe4, 0x12, 0xc3, 0xf1, 0x85, 0xce, 0x63, 0x55, 0x12, 0xc3, 0x42, 0x82, 0x7f, 0x3a, 0x2f, 0xa1,
+	0x93, 0x2b, 0xa9, 0xf0, 0xf7, 0x67, 0x65, 0x7f, 0xef, 0x2c, 0x78, 0x63, 0xc1, 0xcd, 0x5c, 0xdb,
+	0xf0, 0x7f, 0xd6, 0x36, 0x5c, 0xa1, 0xcd, 0xfd, 0xbb, 0x05, 0x77, 0x47, 0x71, 0x8a, 0x29, 0x33,
+	0x1f, 0x5b, 0x91, 0x3e, 0x2a, 0x1f, 0x17, 0xfa, 0x12, 0xb6, 0x12, 0xca, 0xb3, 0x36, 0xa6, 0xe7,
+	0x78, 0x4a, 0xe2, 0xc0, 0x6e, 0x54, 0x94, 0xc1, 0x05, 0x0c, 0xef, 0x19, 0x26, 0xd9, 0x1c, 0xd3,
+	0xbc, 0xf4, 0x69, 0x12, 0x1d, 0xc0, 0x3a, 0xc7, 0x86, 0xf1, 0x95, 0xdd, 0x34, 0xcd, 0x1e, 0x4b,
+	0xe6, 0x98, 0x44, 0xe1, 0x74, 0xee, 0x69, 0x8c, 0xfb, 0x43, 0x1d, 0x36, 0x4b, 0x4b, 0xe8, 0x0b,
+	0x80, 0x04, 0xd3, 0xfe, 0xf8, 0xa2, 0x4f, 0x28, 0xae, 0xac, 0xc9, 0xc6, 0xba, 0x30, 0x1f, 0x53,
+	0xef, 0xf8, 0x6c, 0x18, 0x5e, 0xf9, 0x93, 0x39, 0xd3, 0xce, 0x5a, 0x34, 0xbf, 0x84, 0x41, 0xcf,
+	0xa1, 0x95, 0x60, 0x3a, 0x1c, 0x5f, 0xa8, 0x46, 0xf3, 0xc3, 0x0a, 0x1b, 0x7b, 0x63, 0x81, 0x90,
+	0xd1, 0x57, 0x70, 0xe4, 0x42, 0xf3, 0x32, 0x22, 0x84, 0x56, 0x3a, 0x49, 0x2e, 0x21, 0x17, 0x36,
+	0x2e, 0x49, 0x14, 0x91, 0xd7, 0x67, 0x3e, 0xbd, 0xc1, 0x4c, 0xb8, 0xa1, 0xed, 0x95, 0x78, 0xce,
+	0x10, 0xba, 0x86, 0xfa, 0x8a, 0xd8, 0xbb, 0xe5, 0xd8, 0x2f, 0x6c, 0x54, 0x04, 0xfd, 0x18, 0xb6,
+	0xc7, 0x59, 0x14, 0x99, 0x6d, 0xf9, 0xae, 0x6a, 0x68, 0x74, 0xbf, 0xa4, 0xa8, 0xbc, 0x33, 0xd6,
+	0x1d, 0x93, 0xa2, 0xdc, 0xbf, 0xd6, 0x61, 0x93, 0x37, 0x44, 0xe2, 0x3e, 0x89, 0x97, 0x64, 0xe7,
+	0x8d, 0xaf, 0x99, 0x52, 0x79, 0x0b, 0xfc, 0x11, 0x34, 0x09, 0x0d, 0xf2, 0x76, 0xbe, 0x2b, 0x17,
+	0xbf, 0xe3, 0x2c, 0x4f, 0xae, 0xa0, 0x1e, 0xac, 0xd3, 0x2c, 0x8e, 0xf9, 0x05, 0x58, 0x13, 0xa0,
+	0x7b, 0xea, 0xce, 0x89, 0x0c, 0x76, 0xe6, 0x27, 0x32, 0x89, 0x69, 0x10, 0x3a, 0xe2, 0x53, 0xc2,
+	0x2c, 0x89, 0x30, 0xc3, 0xfa, 0xee, 0x55, 0x4b, 0x14, 0x30, 0xf4, 0x25, 0x40, 0x8a, 0x19, 0x8b,
+	0xf0, 0x0c, 0xc7, 0xcc, 0x6e, 0x9a, 0x42, 0xfc, 0x24, 0xe7, 0xf9, 0x9a, 0x67, 0xe0, 0x78, 0x6f,
+	0xe8, 0x4f, 0x59, 0x78, 0x8b, 0x47, 0x03, 0xbb, 0x25, 0x7b, 0x43, 0x4d, 0xf3, 0x96, 0x1b, 0x7f,
+	0xcf, 0x70, 0xcc, 0xbb, 0x65, 0xdd, 0xbd, 0x1a, 0x1c, 0xf7, 0xdf, 0x16, 0x74, 0x84, 0x93, 0x49,
+	0xc6, 0xf0, 0xca, 0x21, 0xc3, 0x86, 0x75, 0x91, 0xc6, 0xf2, 0x3e, 0x51, 0x93, 0xbc, 0xf1, 0xcd,
+	0x27, 0x9d, 0x31, 0xa1, 0x4c, 0x3d, 0x9b, 0x32, 0x53, 0xb4, 0xe9, 0x94, 0x30, 0x32, 0x25, 0x51,
+	0xde, 0xa6, 0x2b, 0x1a, 0x21, 0x68, 0x5c, 0x93, 0x94, 0xa9, 0x1e, 0x5d, 0x7c, 0x73, 0x5e, 0xc2,
+	0x95, 0xf1, 0xd3, 0x6c, 0x7a, 0xe2, 0x1b, 0xed, 0x41, 0x77, 0xe2, 0x4f, 0x6f, 0x70, 0x1c, 0x9c,
+	0x72, 0xf8, 0xba, 0x80, 0x9b, 0x2c, 0x03, 0x21, 0x2c, 0x69, 0x0b, 0x61, 0x93, 0xe5, 0x3e, 0x83,
+	0xae, 0x38, 0xa8, 0xca, 0xac, 0x8f, 0xa0, 0x45, 0x05, 0xa9, 0x72, 0xeb, 0x76, 0x51, 0x47, 0x04,
+	0xcc, 0x53, 0xcb, 0x47, 0xff, 0xd9, 0x84, 0xb5, 0xd3, 0x6c, 0x82, 0x3e, 0x85, 0xc6, 0x98, 0xc7,
+	0x56, 0xdd, 0x8f, 0x93, 0x59, 0xc2, 0xe6, 0x8e, 0x92, 0xe2, 0x0b, 0x42, 0xad, 0x5b, 0x43, 0x07,
+	0xd0, 0x92, 0x41, 0x2e, 0x23, 0x55, 0x28, 0xcb, 0x53, 0x91, 0x5b, 0xe3, 0x6a, 0x45, 0x4b, 0x54,
+	0xa5, 0x36, 0x2f, 0x68, 0x6e, 0x0d, 0x7d, 0x0c, 0x0d, 0x51, 0x63, 0xf2, 0xbb, 0xab, 0x41, 0xf9,
+	0x15, 0x77, 0x6b, 0xa8, 0x27, 0xcb, 0xfa, 0xb2, 0xc2, 0x9d, 0x8a, 0x2a, 0x29, 0x6c, 0x6d, 0x8f,
+	0xb3, 0xf4, 0x9a, 0xb3, 0x35, 0xbe, 0x7f, 0x9d, 0xc5, 0x37, 0xce, 0x96, 0x4e, 0x1e, 0xe4, 0x8a,
+	0xe2, 0x34, 0x75, 0x6b, 0xfb, 0xd6, 0xa1, 0x85, 0x8e, 0xa0, 0xad, 0x1f, 0x26, 0x52, 0x75, 0x7c,
+	0xe1, 0xa1, 0x3a, 0xa6, 0x16, 0xb7, 0x76, 0x68, 0xa1, 0x63, 0xe8, 0xe4, 0x23, 0x28, 0xba, 0x6f,
+	0x3a, 0xa1, 0x34, 0x78, 0x3b, 0xef, 0x57, 0x2d, 0x49, 0x2b, 0xbf, 0x81, 0xae, 0x31, 0x14, 0xa3,
+	0x0f, 0x72, 0xe4, 0xf2, 0xa8, 0xec, 0xdc, 0x95, 0x8b, 0x8a, 0x7b, 0x9e, 0xe0, 0xa9, 0xf0, 0x5d,
+	0xfb, 0x9c, 0x91, 0x44, 0x98, 0x50, 0xf8, 0xcf, 0x74, 0x90, 0x5b, 0x43, 0x4f, 0x64, 0x1f, 0xa3,
+	0x62, 0x57, 0xc0, 0xaa, 0x1b, 0x16, 0x21, 0xd0, 0x3d, 0xe3, 0xb7, 0x7c, 0x49, 0xa2, 0xf2, 0xa9,
+	0xbb, 0x35, 0xf4, 0x95, 0x8a, 0x0e, 0xb9, 0x4a, 0x91, 0xa1, 0x95, 0xd3, 0xda, 0xfc, 0x9d, 0x32,
+	0xbb, 0x70, 0xe3, 0x21, 0x34, 0x4e, 0xbe, 0xc7, 0x53, 0xa4, 0xce, 0xc7, 0xbf, 0xb5, 0xcc, 0xb6,
+	0xc9, 0x12, 0x3b, 0x89, 0x60, 0x1d, 0x40, 0x97, 0xdf, 0xfb, 0x17, 0x84, 0xbe, 0xf6, 0x69, 0x50,
+	0x0e, 0x6f, 0x39, 0x4a, 0x02, 0xfe, 0x18, 0x5a, 0x7d, 0x92, 0xcc, 0x5f, 0x91, 0xb7, 0xbc, 0x08,
+	0x1c, 0xfc, 0x82, 0x92, 0x99, 0x3e, 0x89, 0xa6, 0x57, 0x5e, 0x84, 0x27, 0xd0, 0xe5, 0x43, 0x11,
+	0x49, 0xc5, 0xa4, 0xaa, 0x0f, 0x62, 0x4c, 0xad, 0x8b, 0x01, 0x79, 0x06, 0x5d, 0x63, 0xb4, 0x45,
+	0xb6, 0x5c, 0x5d, 0x9e, 0x76, 0x17, 0xe5, 0x8e, 0x60, 0xab, 0xef, 0xc7, 0x53, 0x1c, 0xc9, 0xed,
+	0xde, 0x6a, 0xaf, 0x6f, 0x60, 0xf3, 0x15, 0xa6, 0xb3, 0x30, 0xf6, 0x99, 0xdc, 0x6d, 0xb7, 0x10,
+	0xe9, 0x47, 0x24, 0xc5, 0x5a, 0xae, 0x32, 0x23, 0xbb, 0x35, 0x9e, 0xbd, 0x4f, 0x78, 0x66, 0x0d,
+	0x84, 0xf4, 0xfb, 0x05, 0x4a, 0x72, 0x57, 0x6c, 0xdb, 0x83, 0xae, 0x18, 0x90, 0x65, 0xa1, 0x32,
+	0xae, 0xd0, 0x4e, 0xa1, 0xc0, 0x7c, 0xdf, 0xcf, 0xa0, 0x3b, 0x08, 0xd3, 0x29, 0xb9, 0xc5, 0x94,
+	0xa7, 0x24, 0xe5, 0x12, 0x83, 0xb5, 0x62, 0x9f, 0x2f, 0x60, 0x5d, 0xb5, 0x80, 0xe5, 0xb4, 0x80,
+	0x96, 0xdb, 0x43, 0x61, 0xd5, 0x86, 0xb8, 0xd8, 0x5a, 0xa4, 0x30, 0xab, 0x1a, 0x7f, 0x0c, 0x3b,
+	0x15, 0x63, 0xbe, 0x21, 0xf6, 0xf0, 0xcd, 0xff, 0x02, 0xdc, 0x1a, 0x7a, 0x01, 0x3b, 0x15, 0xb3,
+	0x36, 0xda, 0xfb, 0xb1, 0x31, 0x7c, 0xf1, 0xa0, 0x2f, 0xe0, 0x5e, 0xd5, 0x58, 0x55, 0x3e, 0x75,
+	0x31, 0x2e, 0x56, 0xcf, 0x5f, 0x6e, 0x0d, 0x7d, 0x06, 0x5b, 0x7a, 0x4d, 0xae, 0xac, 0xce, 0x1b,
+	0x8f, 0xe1, 0xce, 0x00, 0xd3, 0xb7, 0x04, 0xef, 0x43, 0x53, 0xcc, 0xa7, 0x65, 0x83, 0xee, 0x2c,
+	0x8e, 0xf4, 0x6e, 0x0d, 0x3d, 0x05, 0x28, 0x1a, 0x5f, 0x7d, 0xa1, 0x96, 0x5a, 0x61, 0x27, 0xdf,
+	0xc9, 0xad, 0xa1, 0x9f, 0x02, 0x14, 0x83, 0xe9, 0x6a, 0x1b, 0x3e, 0x87, 0x96, 0x2c, 0x84, 0x65,
+	0x23, 0xd4, 0x23, 0x31, 0x6a, 0xa4, 0x5b, 0x9b, 0xb4, 0x44, 0xa9, 0xfe, 0xd9, 0x7f, 0x07, 0x00,
+	0x20, 0x2e, 0xa5, 0x4d, 0x51, 0x16, 0x00, 0x00,
 }
@@ -34,7 +34,7 @@ var NetworkType_value = map[string]int32{
 func (x NetworkType) String() string {
 	return proto.EnumName(NetworkType_name, int32(x))
 }
-func (NetworkType) EnumDescriptor() ([]byte, []int) { return fileDescriptor6, []int{0} }
+func (NetworkType) EnumDescriptor() ([]byte, []int) { return fileDescriptor7, []int{0} }
 
 type GPUCount int32
 
@@ -58,7 +58,7 @@ var GPUCount_value = map[string]int32{
 func (x GPUCount) String() string {
 	return proto.EnumName(GPUCount_name, int32(x))
 }
-func (GPUCount) EnumDescriptor() ([]byte, []int) { return fileDescriptor6, []int{1} }
+func (GPUCount) EnumDescriptor() ([]byte, []int) { return fileDescriptor7, []int{1} }
 
 type TaskStatusReply_Status int32
 
@@ -91,7 +91,7 @@ var TaskStatusReply_Status_value = map[string]int32{
 func (x TaskStatusReply_Status) String() string {
 	return proto.EnumName(TaskStatusReply_Status_name, int32(x))
 }
-func (TaskStatusReply_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor6, []int{9, 0} }
+func (TaskStatusReply_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor7, []int{9, 0} }
 
 type TaskLogsRequest_Type int32
 
@@ -115,7 +115,7 @@ var TaskLogsRequest_Type_value = map[string]int32{
 func (x TaskLogsRequest_Type) String() string {
 	return proto.EnumName(TaskLogsRequest_Type_name, int32(x))
 }
-func (TaskLogsRequest_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor6, []int{13, 0} }
+func (TaskLogsRequest_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor7, []int{14, 0} }
 
 type Empty struct {
 }
@@ -123,7 +123,7 @@ type Empty struct {
 func (m *Empty) Reset()                    { *m = Empty{} }
 func (m *Empty) String() string            { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0} }
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{0} }
 
 type ID struct {
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
@@ -132,7 +132,7 @@ type ID struct {
 func (m *ID) Reset()                    { *m = ID{} }
 func (m *ID) String() string            { return proto.CompactTextString(m) }
 func (*ID) ProtoMessage()               {}
-func (*ID) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{1} }
+func (*ID) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{1} }
 
 func (m *ID) GetId() string {
 	if m != nil {
@@ -151,7 +151,7 @@ type TaskID struct {
 func (m *TaskID) Reset()                    { *m = TaskID{} }
 func (m *TaskID) String() string            { return proto.CompactTextString(m) }
 func (*TaskID) ProtoMessage()               {}
-func (*TaskID) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{2} }
+func (*TaskID) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{2} }
 
 func (m *TaskID) GetId() string {
 	if m != nil {
@@ -174,7 +174,7 @@ type PingReply struct {
 func (m *PingReply) Reset()                    { *m = PingReply{} }
 func (m *PingReply) String() string            { return proto.CompactTextString(m) }
 func (*PingReply) ProtoMessage()               {}
-func (*PingReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{3} }
+func (*PingReply) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{3} }
 
 func (m *PingReply) GetStatus() string {
 	if m != nil {
@@ -190,7 +190,7 @@ type CPUUsage struct {
 func (m *CPUUsage) Reset()                    { *m = CPUUsage{} }
 func (m *CPUUsage) String() string            { return proto.CompactTextString(m) }
 func (*CPUUsage) ProtoMessage()               {}
-func (*CPUUsage) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{4} }
+func (*CPUUsage) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{4} }
 
 func (m *CPUUsage) GetTotal() uint64 {
 	if m != nil {
@@ -206,7 +206,7 @@ type MemoryUsage struct {
 func (m *MemoryUsage) Reset()                    { *m = MemoryUsage{} }
 func (m *MemoryUsage) String() string            { return proto.CompactTextString(m) }
 func (*MemoryUsage) ProtoMessage()               {}
-func (*MemoryUsage) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{5} }
+func (*MemoryUsage) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{5} }
 
 func (m *MemoryUsage) GetMaxUsage() uint64 {
 	if m != nil {
@@ -229,7 +229,7 @@ type NetworkUsage struct {
 func (m *NetworkUsage) Reset()                    { *m = NetworkUsage{} }
 func (m *NetworkUsage) String() string            { return proto.CompactTextString(m) }
 func (*NetworkUsage) ProtoMessage()               {}
-func (*NetworkUsage) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{6} }
+func (*NetworkUsage) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{6} }
 
 func (m *NetworkUsage) GetTxBytes() uint64 {
 	if m != nil {
@@ -296,7 +296,7 @@ type ResourceUsage struct {
 func (m *ResourceUsage) Reset()                    { *m = ResourceUsage{} }
 func (m *ResourceUsage) String() string            { return proto.CompactTextString(m) }
 func (*ResourceUsage) ProtoMessage()               {}
-func (*ResourceUsage) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{7} }
+func (*ResourceUsage) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{7} }
 
 func (m *ResourceUsage) GetCpu() *CPUUsage {
 	if m != nil {
@@ -328,7 +328,7 @@ type InfoReply struct {
 func (m *InfoReply) Reset()                    { *m = InfoReply{} }
 func (m *InfoReply) String() string            { return proto.CompactTextString(m) }
 func (*InfoReply) ProtoMessage()               {}
-func (*InfoReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{8} }
+func (*InfoReply) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{8} }
 
 func (m *InfoReply) GetUsage() map[string]*ResourceUsage {
 	if m != nil {
@@ -359,12 +359,17 @@ type TaskStatusReply struct {
 	Usage              *ResourceUsage         `protobuf:"bytes,5,opt,name=usage" json:"usage,omitempty"`
 	AvailableResources *AvailableResources    `protobuf:"bytes,6,opt,name=availableResources" json:"availableResources,omitempty"`
 	MinerID            string                 `protobuf:"bytes,7,opt,name=minerID" json:"minerID,omitempty"`
+	// Health describes health of the task endpoints having health checks.
+	Health []*EndpointHealth `protobuf:"bytes,8,rep,name=health" json:"health,omitempty"`
+	// Sidecars describes names of the sidecar containers sharing the task
+	// status.
+	Sidecars []string `protobuf:"bytes,9,rep,name=sidecars" json:"sidecars,omitempty"`
 }
 
 func (m *TaskStatusReply) Reset()                    { *m = TaskStatusReply{} }
 func (m *TaskStatusReply) String() string            { return proto.CompactTextString(m) }
 func (*TaskStatusReply) ProtoMessage()               {}
-func (*TaskStatusReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{9} }
+func (*TaskStatusReply) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{9} }
 
 func (m *TaskStatusReply) GetStatus() TaskStatusReply_Status {
 	if m != nil {
@@ -415,6 +420,72 @@ func (m *TaskStatusReply) GetMinerID() string {
 	return ""
 }
 
+func (m *TaskStatusReply) GetHealth() []*EndpointHealth {
+	if m != nil {
+		return m.Health
+	}
+	return nil
+}
+
+func (m *TaskStatusReply) GetSidecars() []string {
+	if m != nil {
+		return m.Sidecars
+	}
+	return nil
+}
+
+type EndpointHealth struct {
+	// Port is the container port, like "80/tcp".
+	Port string `protobuf:"bytes,1,opt,name=port" json:"port,omitempty"`
+	// Endpoint is the "host:port" address of the real service.
+	Endpoint string `protobuf:"bytes,2,opt,name=endpoint" json:"endpoint,omitempty"`
+	Healthy  bool   `protobuf:"varint,3,opt,name=healthy" json:"healthy,omitempty"`
+	// Weight is the current routing weight of the endpoint.
+	Weight int32 `protobuf:"varint,4,opt,name=weight" json:"weight,omitempty"`
+	// Error describes the last failed check.
+	Error string `protobuf:"bytes,5,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *EndpointHealth) Reset()                    { *m = EndpointHealth{} }
+func (m *EndpointHealth) String() string            { return proto.CompactTextString(m) }
+func (*EndpointHealth) ProtoMessage()               {}
+func (*EndpointHealth) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{10} }
+
+func (m *EndpointHealth) GetPort() string {
+	if m != nil {
+		return m.Port
+	}
+	return ""
+}
+
+func (m *EndpointHealth) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *EndpointHealth) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *EndpointHealth) GetWeight() int32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *EndpointHealth) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type AvailableResources struct {
 	NumCPUs            int64  `protobuf:"varint,1,opt,name=numCPUs" json:"numCPUs,omitempty"`
 	NumGPUs            int64  `protobuf:"varint,2,opt,name=numGPUs" json:"numGPUs,omitempty"`
@@ -437,7 +508,7 @@ type AvailableResources struct {
 func (m *AvailableResources) Reset()                    { *m = AvailableResources{} }
 func (m *AvailableResources) String() string            { return proto.CompactTextString(m) }
 func (*AvailableResources) ProtoMessage()               {}
-func (*AvailableResources) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{10} }
+func (*AvailableResources) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{11} }
 
 func (m *AvailableResources) GetNumCPUs() int64 {
 	if m != nil {
@@ -558,7 +629,7 @@ type StatusMapReply struct {
 func (m *StatusMapReply) Reset()                    { *m = StatusMapReply{} }
 func (m *StatusMapReply) String() string            { return proto.CompactTextString(m) }
 func (*StatusMapReply) ProtoMessage()               {}
-func (*StatusMapReply) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{11} }
+func (*StatusMapReply) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{12} }
 
 func (m *StatusMapReply) GetStatuses() map[string]*TaskStatusReply {
 	if m != nil {
@@ -575,7 +646,7 @@ type ContainerRestartPolicy struct {
 func (m *ContainerRestartPolicy) Reset()                    { *m = ContainerRestartPolicy{} }
 func (m *ContainerRestartPolicy) String() string            { return proto.CompactTextString(m) }
 func (*ContainerRestartPolicy) ProtoMessage()               {}
-func (*ContainerRestartPolicy) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{12} }
+func (*ContainerRestartPolicy) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{13} }
 
 func (m *ContainerRestartPolicy) GetName() string {
 	if m != nil {
@@ -605,7 +676,7 @@ type TaskLogsRequest struct {
 func (m *TaskLogsRequest) Reset()                    { *m = TaskLogsRequest{} }
 func (m *TaskLogsRequest) String() string            { return proto.CompactTextString(m) }
 func (*TaskLogsRequest) ProtoMessage()               {}
-func (*TaskLogsRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{13} }
+func (*TaskLogsRequest) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{14} }
 
 func (m *TaskLogsRequest) GetType() TaskLogsRequest_Type {
 	if m != nil {
@@ -670,7 +741,7 @@ type TaskLogsChunk struct {
 func (m *TaskLogsChunk) Reset()                    { *m = TaskLogsChunk{} }
 func (m *TaskLogsChunk) String() string            { return proto.CompactTextString(m) }
 func (*TaskLogsChunk) ProtoMessage()               {}
-func (*TaskLogsChunk) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{14} }
+func (*TaskLogsChunk) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{15} }
 
 func (m *TaskLogsChunk) GetData() []byte {
 	if m != nil {
@@ -679,6 +750,188 @@ func (m *TaskLogsChunk) GetData() []byte {
 	return nil
 }
 
+// ExecRequest is a message of an interactive command session. The first
+// message of the stream specifies the task and the command to be executed,
+// subsequent ones carry the standard input and terminal size changes.
+type ExecRequest struct {
+	Id      string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	HubAddr string   `protobuf:"bytes,2,opt,name=hubAddr" json:"hubAddr,omitempty"`
+	Cmd     []string `protobuf:"bytes,3,rep,name=cmd" json:"cmd,omitempty"`
+	Env     []string `protobuf:"bytes,4,rep,name=env" json:"env,omitempty"`
+	Tty     bool     `protobuf:"varint,5,opt,name=tty" json:"tty,omitempty"`
+	Stdin   []byte   `protobuf:"bytes,6,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	// StdinClosed notifies that no more input will follow.
+	StdinClosed bool          `protobuf:"varint,7,opt,name=stdinClosed" json:"stdinClosed,omitempty"`
+	Size        *TerminalSize `protobuf:"bytes,8,opt,name=size" json:"size,omitempty"`
+}
+
+func (m *ExecRequest) Reset()                    { *m = ExecRequest{} }
+func (m *ExecRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExecRequest) ProtoMessage()               {}
+func (*ExecRequest) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{16} }
+
+func (m *ExecRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetHubAddr() string {
+	if m != nil {
+		return m.HubAddr
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetCmd() []string {
+	if m != nil {
+		return m.Cmd
+	}
+	return nil
+}
+
+func (m *ExecRequest) GetEnv() []string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *ExecRequest) GetTty() bool {
+	if m != nil {
+		return m.Tty
+	}
+	return false
+}
+
+func (m *ExecRequest) GetStdin() []byte {
+	if m != nil {
+		return m.Stdin
+	}
+	return nil
+}
+
+func (m *ExecRequest) GetStdinClosed() bool {
+	if m != nil {
+		return m.StdinClosed
+	}
+	return false
+}
+
+func (m *ExecRequest) GetSize() *TerminalSize {
+	if m != nil {
+		return m.Size
+	}
+	return nil
+}
+
+type TerminalSize struct {
+	Width  uint32 `protobuf:"varint,1,opt,name=width" json:"width,omitempty"`
+	Height uint32 `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *TerminalSize) Reset()                    { *m = TerminalSize{} }
+func (m *TerminalSize) String() string            { return proto.CompactTextString(m) }
+func (*TerminalSize) ProtoMessage()               {}
+func (*TerminalSize) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{17} }
+
+func (m *TerminalSize) GetWidth() uint32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *TerminalSize) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type ExecReply struct {
+	Stdout []byte `protobuf:"bytes,1,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr []byte `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	// Exit is sent in the final reply once the command has finished.
+	Exit *ExecExit `protobuf:"bytes,3,opt,name=exit" json:"exit,omitempty"`
+}
+
+func (m *ExecReply) Reset()                    { *m = ExecReply{} }
+func (m *ExecReply) String() string            { return proto.CompactTextString(m) }
+func (*ExecReply) ProtoMessage()               {}
+func (*ExecReply) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{18} }
+
+func (m *ExecReply) GetStdout() []byte {
+	if m != nil {
+		return m.Stdout
+	}
+	return nil
+}
+
+func (m *ExecReply) GetStderr() []byte {
+	if m != nil {
+		return m.Stderr
+	}
+	return nil
+}
+
+func (m *ExecReply) GetExit() *ExecExit {
+	if m != nil {
+		return m.Exit
+	}
+	return nil
+}
+
+type ExecExit struct {
+	Code int32 `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+}
+
+func (m *ExecExit) Reset()                    { *m = ExecExit{} }
+func (m *ExecExit) String() string            { return proto.CompactTextString(m) }
+func (*ExecExit) ProtoMessage()               {}
+func (*ExecExit) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{19} }
+
+func (m *ExecExit) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+// CopyFromRequest specifies the task container path to be archived.
+type CopyFromRequest struct {
+	Id      string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	HubAddr string `protobuf:"bytes,2,opt,name=hubAddr" json:"hubAddr,omitempty"`
+	Path    string `protobuf:"bytes,3,opt,name=path" json:"path,omitempty"`
+}
+
+func (m *CopyFromRequest) Reset()                    { *m = CopyFromRequest{} }
+func (m *CopyFromRequest) String() string            { return proto.CompactTextString(m) }
+func (*CopyFromRequest) ProtoMessage()               {}
+func (*CopyFromRequest) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{20} }
+
+func (m *CopyFromRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CopyFromRequest) GetHubAddr() string {
+	if m != nil {
+		return m.HubAddr
+	}
+	return ""
+}
+
+func (m *CopyFromRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
 type DiscoverHubRequest struct {
 	Endpoint string `protobuf:"bytes,1,opt,name=endpoint" json:"endpoint,omitempty"`
 }
@@ -686,7 +939,7 @@ type DiscoverHubRequest struct {
 func (m *DiscoverHubRequest) Reset()                    { *m = DiscoverHubRequest{} }
 func (m *DiscoverHubRequest) String() string            { return proto.CompactTextString(m) }
 func (*DiscoverHubRequest) ProtoMessage()               {}
-func (*DiscoverHubRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{15} }
+func (*DiscoverHubRequest) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{21} }
 
 func (m *DiscoverHubRequest) GetEndpoint() string {
 	if m != nil {
@@ -707,7 +960,7 @@ type TaskResourceRequirements struct {
 func (m *TaskResourceRequirements) Reset()                    { *m = TaskResourceRequirements{} }
 func (m *TaskResourceRequirements) String() string            { return proto.CompactTextString(m) }
 func (*TaskResourceRequirements) ProtoMessage()               {}
-func (*TaskResourceRequirements) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{16} }
+func (*TaskResourceRequirements) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{22} }
 
 func (m *TaskResourceRequirements) GetCPUCores() uint64 {
 	if m != nil {
@@ -737,7 +990,7 @@ type Chunk struct {
 func (m *Chunk) Reset()                    { *m = Chunk{} }
 func (m *Chunk) String() string            { return proto.CompactTextString(m) }
 func (*Chunk) ProtoMessage()               {}
-func (*Chunk) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{17} }
+func (*Chunk) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{23} }
 
 func (m *Chunk) GetChunk() []byte {
 	if m != nil {
@@ -753,7 +1006,7 @@ type Progress struct {
 func (m *Progress) Reset()                    { *m = Progress{} }
 func (m *Progress) String() string            { return proto.CompactTextString(m) }
 func (*Progress) ProtoMessage()               {}
-func (*Progress) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{18} }
+func (*Progress) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{24} }
 
 func (m *Progress) GetSize() int64 {
 	if m != nil {
@@ -773,11 +1026,17 @@ func init() {
 	proto.RegisterType((*ResourceUsage)(nil), "sonm.ResourceUsage")
 	proto.RegisterType((*InfoReply)(nil), "sonm.InfoReply")
 	proto.RegisterType((*TaskStatusReply)(nil), "sonm.TaskStatusReply")
+	proto.RegisterType((*EndpointHealth)(nil), "sonm.EndpointHealth")
 	proto.RegisterType((*AvailableResources)(nil), "sonm.AvailableResources")
 	proto.RegisterType((*StatusMapReply)(nil), "sonm.StatusMapReply")
 	proto.RegisterType((*ContainerRestartPolicy)(nil), "sonm.ContainerRestartPolicy")
 	proto.RegisterType((*TaskLogsRequest)(nil), "sonm.TaskLogsRequest")
 	proto.RegisterType((*TaskLogsChunk)(nil), "sonm.TaskLogsChunk")
+	proto.RegisterType((*ExecRequest)(nil), "sonm.ExecRequest")
+	proto.RegisterType((*TerminalSize)(nil), "sonm.TerminalSize")
+	proto.RegisterType((*ExecReply)(nil), "sonm.ExecReply")
+	proto.RegisterType((*ExecExit)(nil), "sonm.ExecExit")
+	proto.RegisterType((*CopyFromRequest)(nil), "sonm.CopyFromRequest")
 	proto.RegisterType((*DiscoverHubRequest)(nil), "sonm.DiscoverHubRequest")
 	proto.RegisterType((*TaskResourceRequirements)(nil), "sonm.TaskResourceRequirements")
 	proto.RegisterType((*Chunk)(nil), "sonm.Chunk")
@@ -788,90 +1047,107 @@ func init() {
 	proto.RegisterEnum("sonm.TaskLogsRequest_Type", TaskLogsRequest_Type_name, TaskLogsRequest_Type_value)
 }
 
-func init() { proto.RegisterFile("insonmnia.proto", fileDescriptor6) }
-
-var fileDescriptor6 = []byte{
-	// 1311 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x56, 0x4d, 0x6f, 0x1b, 0x37,
-	0x13, 0x8e, 0xbe, 0xa5, 0x91, 0x6c, 0x2b, 0x7c, 0xfd, 0x06, 0x0b, 0x21, 0x0d, 0x8c, 0x4d, 0x0f,
-	0x4e, 0x1a, 0x08, 0x81, 0x5b, 0x14, 0x69, 0x0e, 0x05, 0x6c, 0x49, 0xb1, 0x05, 0x5b, 0xab, 0x2d,
-	0xa5, 0x45, 0x8a, 0x5e, 0x02, 0x5a, 0x62, 0x9d, 0x85, 0xb5, 0x1f, 0xe5, 0x72, 0x1d, 0xab, 0xe7,
-	0xfe, 0x87, 0xde, 0x8b, 0xfe, 0xa8, 0xfe, 0x84, 0x9e, 0x7b, 0xee, 0xa1, 0x20, 0x87, 0xbb, 0x5a,
-	0xd5, 0x42, 0x2f, 0x0b, 0x3e, 0xf3, 0x0c, 0x97, 0x33, 0x43, 0xce, 0x43, 0xc2, 0x81, 0x1f, 0x26,
-	0x51, 0x18, 0x84, 0x3e, 0xeb, 0xc7, 0x22, 0x92, 0x11, 0xa9, 0x2a, 0xd8, 0x23, 0x0b, 0x16, 0xb3,
-	0x6b, 0x7f, 0xe5, 0x4b, 0x9f, 0x27, 0xc8, 0xf4, 0x0e, 0xa4, 0x1f, 0xf0, 0x44, 0xb2, 0x20, 0x46,
-	0x83, 0xdd, 0x80, 0xda, 0x28, 0x88, 0xe5, 0xda, 0x3e, 0x84, 0xf2, 0x78, 0x48, 0xf6, 0xa1, 0xec,
-	0x2f, 0xad, 0xd2, 0x51, 0xe9, 0xb8, 0x45, 0xcb, 0xfe, 0xd2, 0x3e, 0x81, 0xfa, 0x9c, 0x25, 0xb7,
-	0x0f, 0x19, 0x62, 0x41, 0xe3, 0x63, 0x7a, 0x7d, 0xba, 0x5c, 0x0a, 0xab, 0xac, 0x8d, 0x19, 0xb4,
-	0x9f, 0x43, 0xcb, 0xf5, 0xc3, 0x1b, 0xca, 0xe3, 0xd5, 0x9a, 0x3c, 0x81, 0x7a, 0x22, 0x99, 0x4c,
-	0x13, 0x33, 0xd5, 0x20, 0xfb, 0x08, 0x9a, 0x03, 0xd7, 0xf3, 0x12, 0x76, 0xc3, 0xc9, 0x21, 0xd4,
-	0x64, 0x24, 0xd9, 0x4a, 0xbb, 0x54, 0x29, 0x02, 0xfb, 0x05, 0xb4, 0x27, 0x3c, 0x88, 0xc4, 0x1a,
-	0x9d, 0x7a, 0xd0, 0x0c, 0xd8, 0xbd, 0x1e, 0x1b, 0xbf, 0x1c, 0xdb, 0x7f, 0x95, 0xa0, 0xe3, 0x70,
-	0xf9, 0x29, 0x12, 0xb7, 0xe8, 0x6c, 0x41, 0x43, 0xde, 0x9f, 0xad, 0x25, 0x4f, 0x8c, 0x6f, 0x06,
-	0x15, 0x23, 0x0c, 0x53, 0x46, 0xc6, 0x40, 0xf2, 0x14, 0x5a, 0xf2, 0xde, 0x65, 0x8b, 0x5b, 0x2e,
-	0x13, 0xab, 0xa2, 0xb9, 0x8d, 0x41, 0xb1, 0x22, 0x67, 0xab, 0xc8, 0xe6, 0x06, 0x15, 0x9c, 0xbc,
-	0x1f, 0x09, 0x11, 0x89, 0xc4, 0xaa, 0x61, 0x70, 0x19, 0x56, 0x9c, 0xc8, 0xb8, 0x3a, 0x72, 0x19,
-	0xc6, 0x35, 0x87, 0x22, 0x8a, 0x63, 0xbe, 0xb4, 0x1a, 0xd9, 0x9a, 0xc6, 0x80, 0x6b, 0x66, 0x6c,
-	0x33, 0x5b, 0xd3, 0x18, 0xec, 0x3f, 0x4b, 0xb0, 0x47, 0x79, 0x12, 0xa5, 0x62, 0xc1, 0x31, 0xeb,
-	0x23, 0xa8, 0x2c, 0xe2, 0x54, 0x67, 0xdc, 0x3e, 0xd9, 0xef, 0xab, 0x43, 0xd0, 0xcf, 0x8a, 0x4c,
-	0x15, 0x45, 0x5e, 0x40, 0x3d, 0xd0, 0x35, 0xd5, 0xc9, 0xb7, 0x4f, 0x1e, 0xa3, 0x53, 0xa1, 0xce,
-	0xd4, 0x38, 0x90, 0xb7, 0xd0, 0x08, 0xb1, 0xa4, 0x56, 0xe5, 0xa8, 0x72, 0xdc, 0x3e, 0x39, 0x42,
-	0xdf, 0xad, 0x25, 0xfb, 0xa6, 0xea, 0xa3, 0x50, 0x8a, 0x35, 0xcd, 0x26, 0xf4, 0x9c, 0x7c, 0x3b,
-	0x34, 0x41, 0xba, 0x50, 0xb9, 0xe5, 0x6b, 0x73, 0x02, 0xd4, 0x90, 0x1c, 0x43, 0xed, 0x8e, 0xad,
-	0x52, 0x6e, 0xe2, 0x20, 0xf8, 0xef, 0xe2, 0x1e, 0x52, 0x74, 0x78, 0x5b, 0x7e, 0x53, 0xb2, 0xff,
-	0x28, 0x41, 0x6b, 0x1c, 0xfe, 0x18, 0xe1, 0x91, 0x7a, 0x0d, 0xb5, 0xd4, 0x1c, 0x03, 0x15, 0x57,
-	0x0f, 0xe7, 0xe6, 0x7c, 0x5f, 0x4f, 0xc7, 0x88, 0xd0, 0x91, 0x10, 0xa8, 0x86, 0x2c, 0xe0, 0xe6,
-	0xa0, 0xea, 0x31, 0xf9, 0x1a, 0x3a, 0xc5, 0xfe, 0xd0, 0x3b, 0x9e, 0x07, 0x32, 0x28, 0x30, 0x74,
-	0xcb, 0xaf, 0x37, 0x01, 0xd8, 0x2c, 0xb0, 0x23, 0xb3, 0x17, 0xdb, 0x99, 0xfd, 0x6f, 0x47, 0xd5,
-	0x8a, 0xa9, 0xfd, 0x5d, 0x86, 0x03, 0xd5, 0x61, 0x33, 0xdd, 0x16, 0x98, 0xe0, 0x57, 0x5b, 0x3d,
-	0xb3, 0x7f, 0xf2, 0x14, 0xff, 0xf1, 0x2f, 0xb7, 0xbe, 0x19, 0x1b, 0x5f, 0x75, 0x5a, 0xfc, 0x80,
-	0xdd, 0x70, 0x67, 0x93, 0xe9, 0xc6, 0xa0, 0x7a, 0x2c, 0x8e, 0x84, 0x39, 0xd9, 0x2d, 0x8a, 0x40,
-	0x75, 0x67, 0x1a, 0x2b, 0x49, 0x30, 0x47, 0xda, 0x20, 0x95, 0x04, 0x96, 0xb8, 0xf6, 0x1f, 0x49,
-	0x60, 0x6d, 0x2f, 0x80, 0xb0, 0x3b, 0xe6, 0xaf, 0xd8, 0xf5, 0x8a, 0x67, 0x0e, 0x78, 0xd0, 0xdb,
-	0x27, 0x16, 0xce, 0x3b, 0x7d, 0xc0, 0xd3, 0x1d, 0x73, 0x54, 0x6b, 0x06, 0x7e, 0xc8, 0xc5, 0x78,
-	0xa8, 0x5b, 0xa1, 0x45, 0x33, 0x68, 0x7f, 0x0f, 0x75, 0x4c, 0x96, 0xb4, 0xa1, 0xe1, 0x39, 0x97,
-	0xce, 0xf4, 0xbd, 0xd3, 0x7d, 0x44, 0x3a, 0xd0, 0x9c, 0xb9, 0xd3, 0xe9, 0xd5, 0xd8, 0x39, 0xef,
-	0x96, 0x10, 0x9d, 0xbe, 0x77, 0x14, 0x2a, 0x2b, 0x47, 0xea, 0x39, 0x1a, 0x54, 0x14, 0xf5, 0x6e,
-	0xec, 0x8c, 0x67, 0x17, 0xa3, 0x61, 0xb7, 0x4a, 0x00, 0xea, 0x67, 0x74, 0x7a, 0x39, 0x72, 0xba,
-	0x35, 0xfb, 0xb7, 0x2a, 0x90, 0xd3, 0x9d, 0xa1, 0x84, 0x69, 0x30, 0x70, 0x3d, 0xdc, 0x82, 0x0a,
-	0xcd, 0xa0, 0x61, 0xce, 0x15, 0x53, 0xce, 0x19, 0x05, 0x55, 0x2d, 0x4d, 0x6f, 0xa1, 0x78, 0x64,
-	0x8d, 0xf4, 0x14, 0x5a, 0x03, 0xd7, 0x73, 0xb9, 0xf0, 0xa3, 0xa5, 0x2e, 0x73, 0x85, 0x6e, 0x0c,
-	0x4a, 0x1d, 0x06, 0xae, 0xf7, 0x5d, 0x1a, 0x49, 0xa6, 0x8b, 0x5d, 0xa1, 0x39, 0x26, 0xaf, 0xe0,
-	0xf1, 0xc0, 0xf5, 0x28, 0x67, 0x2b, 0xb5, 0x29, 0xe6, 0x0f, 0x75, 0xed, 0xf4, 0x90, 0x20, 0x7d,
-	0x20, 0x05, 0x23, 0x4d, 0x43, 0xbd, 0xaf, 0x0d, 0xed, 0xbe, 0x83, 0x21, 0xcf, 0x00, 0x06, 0x71,
-	0x9a, 0x70, 0xa9, 0xbe, 0x5a, 0x5e, 0x5a, 0xb4, 0x60, 0xd9, 0xf0, 0x13, 0x1e, 0x24, 0x56, 0xab,
-	0xc8, 0x2b, 0x8b, 0xca, 0x6b, 0xe8, 0x27, 0xb7, 0x18, 0x3a, 0x60, 0x5e, 0xb9, 0x81, 0xd8, 0xd0,
-	0xb9, 0xe4, 0x22, 0xe4, 0x2b, 0xd4, 0x16, 0xab, 0xad, 0x1d, 0xb6, 0x6c, 0x2a, 0x3f, 0x1c, 0x51,
-	0x9e, 0x70, 0x71, 0xc7, 0xa4, 0x1f, 0x85, 0x56, 0x07, 0xf3, 0x7b, 0x40, 0xa8, 0x78, 0xd0, 0x38,
-	0xfb, 0xc4, 0x62, 0x6b, 0x4f, 0xbb, 0x15, 0x2c, 0x2a, 0x1e, 0xd7, 0x5f, 0x26, 0x57, 0x7e, 0xe0,
-	0x4b, 0x6b, 0x1f, 0xe3, 0xc9, 0x0d, 0x6a, 0x77, 0x16, 0x37, 0x22, 0x4a, 0x63, 0xeb, 0x00, 0xef,
-	0x21, 0x44, 0x2a, 0x4e, 0x1c, 0xb9, 0x4c, 0xf0, 0x50, 0x5a, 0x5d, 0xcd, 0x6e, 0xd9, 0xec, 0xdf,
-	0x4b, 0xb0, 0x8f, 0xe7, 0x6f, 0xc2, 0x62, 0x6c, 0xd1, 0x6f, 0xa1, 0x89, 0x6d, 0xa7, 0x6f, 0x18,
-	0x25, 0x43, 0x36, 0x9e, 0xf5, 0x6d, 0x3f, 0x03, 0x79, 0x82, 0x72, 0x94, 0xcf, 0xe9, 0x51, 0xd8,
-	0xdb, 0xa2, 0x76, 0x08, 0xc9, 0x17, 0xdb, 0x42, 0xf2, 0xff, 0x9d, 0x22, 0x50, 0x94, 0x92, 0x1f,
-	0xe0, 0xc9, 0x20, 0x0a, 0x25, 0x53, 0x4d, 0x43, 0xd5, 0x25, 0x2f, 0xa4, 0x1b, 0xad, 0xfc, 0xc5,
-	0x3a, 0xd7, 0xbf, 0x52, 0x41, 0xff, 0x5e, 0xc1, 0xe3, 0x80, 0xdd, 0xfb, 0x41, 0x1a, 0x50, 0x2e,
-	0xc5, 0x7a, 0x10, 0xa5, 0xa1, 0xd4, 0x4b, 0xed, 0xd1, 0x87, 0x84, 0xfd, 0xab, 0x91, 0xa9, 0xab,
-	0xe8, 0x26, 0xa1, 0xfc, 0xa7, 0x94, 0x27, 0x92, 0xf4, 0xa1, 0x2a, 0xd7, 0x31, 0x37, 0x22, 0xd5,
-	0xdb, 0xc4, 0x57, 0x70, 0xea, 0xcf, 0xd7, 0x31, 0xa7, 0xda, 0xcf, 0xbc, 0x20, 0xca, 0xf9, 0x0b,
-	0xe2, 0x10, 0x6a, 0x89, 0x1f, 0x2e, 0x78, 0x26, 0x49, 0x1a, 0x90, 0xcf, 0x61, 0x8f, 0x2d, 0x97,
-	0xf3, 0xec, 0x99, 0x82, 0x97, 0x6d, 0x93, 0x6e, 0x1b, 0xd5, 0x76, 0xbe, 0x8b, 0x56, 0xab, 0xe8,
-	0x93, 0x6e, 0x9a, 0x26, 0x35, 0x48, 0x65, 0x3a, 0x67, 0xfe, 0x4a, 0x77, 0x49, 0x8b, 0xea, 0xb1,
-	0x6a, 0xd9, 0x21, 0x97, 0xcc, 0x5f, 0x25, 0xba, 0x1b, 0x9a, 0x34, 0x83, 0xc5, 0x37, 0x4c, 0x73,
-	0xfb, 0x0d, 0x73, 0x0c, 0x55, 0x15, 0xb9, 0xd2, 0x8a, 0xd9, 0x7c, 0x38, 0xf5, 0xe6, 0xdd, 0x47,
-	0x66, 0x3c, 0xa2, 0xb4, 0x5b, 0x22, 0x4d, 0xa8, 0x9e, 0x4d, 0xe7, 0x17, 0xdd, 0xb2, 0xfd, 0x1c,
-	0xf6, 0xb2, 0x9c, 0x07, 0x1f, 0xd3, 0xf0, 0x56, 0x85, 0xb0, 0x64, 0x92, 0xe9, 0xb2, 0x74, 0xa8,
-	0x1e, 0xdb, 0xaf, 0x81, 0x0c, 0xfd, 0x64, 0x11, 0xdd, 0x71, 0x71, 0x91, 0x5e, 0x67, 0x05, 0xec,
-	0x41, 0x93, 0x87, 0xcb, 0x38, 0xf2, 0x43, 0x69, 0xb6, 0x26, 0xc7, 0xf6, 0x2f, 0x25, 0xb0, 0xd4,
-	0x7f, 0x33, 0x4d, 0x52, 0x73, 0x7c, 0xc1, 0x03, 0x1e, 0xe2, 0x73, 0x63, 0xe0, 0x7a, 0x83, 0x48,
-	0xe4, 0xef, 0x9b, 0x1c, 0xab, 0x36, 0x08, 0xd8, 0xfd, 0x64, 0x73, 0xcb, 0x57, 0xe8, 0xc6, 0x40,
-	0xfa, 0x00, 0xe7, 0xae, 0x37, 0x4b, 0x63, 0xa5, 0xff, 0xba, 0xf0, 0xfb, 0xd9, 0x4b, 0xe1, 0x5c,
-	0xfd, 0x21, 0x0d, 0x25, 0x2d, 0x78, 0xd8, 0x9f, 0x41, 0x0d, 0xb3, 0x3a, 0x84, 0xda, 0x42, 0x0d,
-	0x4c, 0x5a, 0x08, 0xec, 0x67, 0xd0, 0x74, 0x45, 0x74, 0x23, 0x78, 0x92, 0xa8, 0xbc, 0x13, 0xff,
-	0x67, 0x6e, 0x04, 0x53, 0x8f, 0x5f, 0x7e, 0x03, 0x6d, 0x73, 0xa7, 0xcf, 0xf1, 0x04, 0x80, 0x33,
-	0xfd, 0xe0, 0x8c, 0xe6, 0xef, 0xa7, 0xf4, 0x12, 0x05, 0x7c, 0xea, 0xcd, 0xcf, 0xa6, 0x9e, 0x33,
-	0x44, 0x01, 0x1f, 0x3b, 0x83, 0xe9, 0x44, 0x0b, 0xf8, 0xcb, 0x37, 0xd0, 0xcc, 0x22, 0x52, 0x95,
-	0x77, 0xa6, 0x1f, 0xce, 0x5d, 0xaf, 0xfb, 0x48, 0xfd, 0x63, 0x36, 0x76, 0xce, 0xaf, 0x46, 0x1a,
-	0x97, 0x48, 0x17, 0x3a, 0x13, 0xef, 0x6a, 0x3e, 0x76, 0x8d, 0xa5, 0x7c, 0x5d, 0xd7, 0x2f, 0xdb,
-	0x2f, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0x01, 0x6b, 0xe8, 0xe1, 0x17, 0x0b, 0x00, 0x00,
+func init() { proto.RegisterFile("insonmnia.proto", fileDescriptor7) }
+
+var fileDescriptor7 = []byte{
+	// 1583 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0x4f, 0x6f, 0x23, 0x4b,
+	0x11, 0x5f, 0xff, 0x8d, 0x5d, 0x76, 0x12, 0x6f, 0x13, 0x9e, 0x46, 0xd1, 0xe3, 0x29, 0x9a, 0x87,
+	0x50, 0xf6, 0xb1, 0xb2, 0x9e, 0x02, 0x42, 0x8f, 0x27, 0x84, 0xb4, 0x6b, 0x7b, 0x13, 0x2b, 0x89,
+	0x3d, 0x74, 0x6c, 0x2d, 0xe2, 0xb2, 0xea, 0x78, 0x9a, 0xa4, 0x15, 0xcf, 0x1f, 0x7a, 0x7a, 0x12,
+	0x7b, 0xcf, 0x1c, 0xf8, 0x06, 0xdc, 0x11, 0x9f, 0x85, 0x1b, 0x77, 0x3e, 0x02, 0x67, 0x3e, 0x01,
+	0xaa, 0xae, 0x1e, 0x7b, 0x4c, 0x22, 0x04, 0x97, 0xa4, 0x7e, 0xf5, 0xab, 0x99, 0xa9, 0xaa, 0xae,
+	0xaa, 0x2e, 0xc3, 0xa1, 0x8a, 0xb3, 0x24, 0x8e, 0x62, 0x25, 0xfa, 0xa9, 0x4e, 0x4c, 0xc2, 0xea,
+	0x08, 0x8f, 0xd9, 0x42, 0xa4, 0xe2, 0x56, 0x2d, 0x95, 0x51, 0x32, 0x23, 0xe6, 0xf8, 0xd0, 0xa8,
+	0x48, 0x66, 0x46, 0x44, 0x29, 0x29, 0xfc, 0x3d, 0x68, 0x8c, 0xa2, 0xd4, 0xac, 0xfd, 0x23, 0xa8,
+	0x8e, 0x87, 0xec, 0x00, 0xaa, 0x2a, 0xf4, 0x2a, 0x27, 0x95, 0xd3, 0x36, 0xaf, 0xaa, 0xd0, 0x3f,
+	0x83, 0xe6, 0x4c, 0x64, 0x0f, 0xcf, 0x19, 0xe6, 0xc1, 0xde, 0x7d, 0x7e, 0xfb, 0x2e, 0x0c, 0xb5,
+	0x57, 0xb5, 0xca, 0x02, 0xfa, 0x5f, 0x43, 0x3b, 0x50, 0xf1, 0x1d, 0x97, 0xe9, 0x72, 0xcd, 0xbe,
+	0x80, 0x66, 0x66, 0x84, 0xc9, 0x33, 0xf7, 0xa8, 0x43, 0xfe, 0x09, 0xb4, 0x06, 0xc1, 0x7c, 0x9e,
+	0x89, 0x3b, 0xc9, 0x8e, 0xa0, 0x61, 0x12, 0x23, 0x96, 0xd6, 0xa4, 0xce, 0x09, 0xf8, 0x6f, 0xa0,
+	0x73, 0x2d, 0xa3, 0x44, 0xaf, 0xc9, 0xe8, 0x18, 0x5a, 0x91, 0x58, 0x59, 0xd9, 0xd9, 0x6d, 0xb0,
+	0xff, 0xaf, 0x0a, 0x74, 0x27, 0xd2, 0x3c, 0x25, 0xfa, 0x81, 0x8c, 0x3d, 0xd8, 0x33, 0xab, 0xf7,
+	0x6b, 0x23, 0x33, 0x67, 0x5b, 0x40, 0x64, 0xb4, 0x63, 0xaa, 0xc4, 0x38, 0xc8, 0xbe, 0x84, 0xb6,
+	0x59, 0x05, 0x62, 0xf1, 0x20, 0x4d, 0xe6, 0xd5, 0x2c, 0xb7, 0x55, 0x20, 0xab, 0x37, 0x6c, 0x9d,
+	0xd8, 0x8d, 0x02, 0x9d, 0x33, 0xab, 0x91, 0xd6, 0x89, 0xce, 0xbc, 0x06, 0x39, 0x57, 0x60, 0xe4,
+	0x74, 0xc1, 0x35, 0x89, 0x2b, 0x30, 0x7d, 0x73, 0xa8, 0x93, 0x34, 0x95, 0xa1, 0xb7, 0x57, 0x7c,
+	0xd3, 0x29, 0xe8, 0x9b, 0x05, 0xdb, 0x2a, 0xbe, 0xe9, 0x14, 0xfe, 0x3f, 0x2b, 0xb0, 0xcf, 0x65,
+	0x96, 0xe4, 0x7a, 0x21, 0x29, 0xea, 0x13, 0xa8, 0x2d, 0xd2, 0xdc, 0x46, 0xdc, 0x39, 0x3b, 0xe8,
+	0x63, 0x11, 0xf4, 0x8b, 0x24, 0x73, 0xa4, 0xd8, 0x1b, 0x68, 0x46, 0x36, 0xa7, 0x36, 0xf8, 0xce,
+	0xd9, 0x6b, 0x32, 0x2a, 0xe5, 0x99, 0x3b, 0x03, 0xf6, 0x3d, 0xec, 0xc5, 0x94, 0x52, 0xaf, 0x76,
+	0x52, 0x3b, 0xed, 0x9c, 0x9d, 0x90, 0xed, 0xce, 0x27, 0xfb, 0x2e, 0xeb, 0xa3, 0xd8, 0xe8, 0x35,
+	0x2f, 0x1e, 0x38, 0x9e, 0x40, 0xb7, 0x4c, 0xb0, 0x1e, 0xd4, 0x1e, 0xe4, 0xda, 0x55, 0x00, 0x8a,
+	0xec, 0x14, 0x1a, 0x8f, 0x62, 0x99, 0x4b, 0xe7, 0x07, 0xa3, 0x77, 0x97, 0xcf, 0x90, 0x93, 0xc1,
+	0xf7, 0xd5, 0xef, 0x2a, 0xfe, 0x3f, 0x2a, 0xd0, 0x1e, 0xc7, 0xbf, 0x4f, 0xa8, 0xa4, 0xbe, 0x85,
+	0x46, 0xee, 0xca, 0x00, 0xfd, 0x3a, 0xa6, 0x67, 0x37, 0x7c, 0xdf, 0x3e, 0x4e, 0x1e, 0x91, 0x21,
+	0x63, 0x50, 0x8f, 0x45, 0x24, 0x5d, 0xa1, 0x5a, 0x99, 0xfd, 0x02, 0xba, 0xe5, 0xfe, 0xf0, 0x6a,
+	0x65, 0x47, 0x06, 0x25, 0x86, 0xef, 0xd8, 0x1d, 0x5f, 0x03, 0x6c, 0x3f, 0xf0, 0x42, 0x64, 0x6f,
+	0x76, 0x23, 0xfb, 0xc1, 0x0b, 0x59, 0x2b, 0x87, 0xf6, 0xb7, 0x1a, 0x1c, 0x62, 0x87, 0xdd, 0xd8,
+	0xb6, 0xa0, 0x00, 0x7f, 0xbe, 0xd3, 0x33, 0x07, 0x67, 0x5f, 0xd2, 0x3b, 0xfe, 0xc3, 0xac, 0xef,
+	0x64, 0x67, 0x8b, 0xd5, 0xa2, 0x22, 0x71, 0x27, 0x27, 0xdb, 0x48, 0xb7, 0x0a, 0xec, 0xb1, 0x34,
+	0xd1, 0xae, 0xb2, 0xdb, 0x9c, 0x00, 0x76, 0x67, 0x9e, 0xe2, 0x48, 0x70, 0x25, 0xed, 0x10, 0x06,
+	0x41, 0x29, 0x6e, 0xfc, 0x97, 0x20, 0x28, 0xb7, 0x17, 0xc0, 0xc4, 0xa3, 0x50, 0x4b, 0x71, 0xbb,
+	0x94, 0x85, 0x01, 0x15, 0x7a, 0xe7, 0xcc, 0xa3, 0xe7, 0xde, 0x3d, 0xe3, 0xf9, 0x0b, 0xcf, 0x60,
+	0x6b, 0x46, 0x2a, 0x96, 0x7a, 0x3c, 0xb4, 0xad, 0xd0, 0xe6, 0x05, 0x64, 0x6f, 0xa1, 0x79, 0x2f,
+	0xc5, 0xd2, 0xdc, 0x7b, 0x2d, 0x7b, 0xe4, 0x47, 0xf4, 0xde, 0x51, 0x1c, 0xa6, 0x89, 0x8a, 0xcd,
+	0x85, 0xe5, 0xb8, 0xb3, 0xc1, 0x86, 0xcb, 0x54, 0x28, 0x17, 0x42, 0x67, 0x5e, 0xfb, 0xa4, 0x76,
+	0xda, 0xe6, 0x1b, 0xec, 0xff, 0x16, 0x9a, 0x94, 0x36, 0xd6, 0x81, 0xbd, 0xf9, 0xe4, 0x72, 0x32,
+	0xfd, 0x38, 0xe9, 0xbd, 0x62, 0x5d, 0x68, 0xdd, 0x04, 0xd3, 0xe9, 0xd5, 0x78, 0x72, 0xde, 0xab,
+	0x10, 0x7a, 0xf7, 0x71, 0x82, 0xa8, 0x8a, 0x86, 0x7c, 0x3e, 0xb1, 0xa0, 0x86, 0xd4, 0x87, 0xf1,
+	0x64, 0x7c, 0x73, 0x31, 0x1a, 0xf6, 0xea, 0x0c, 0xa0, 0xf9, 0x9e, 0x4f, 0x2f, 0x47, 0x93, 0x5e,
+	0xc3, 0xff, 0x53, 0x05, 0x0e, 0x76, 0x1d, 0xc2, 0xb2, 0xc3, 0x34, 0xbb, 0xea, 0xb0, 0x32, 0x3a,
+	0x27, 0x9d, 0x95, 0x3b, 0xa4, 0x0d, 0xb6, 0x23, 0xd5, 0x3e, 0xb9, 0xb6, 0xa7, 0xd4, 0xe2, 0x05,
+	0xc4, 0x73, 0x7a, 0x92, 0xea, 0xee, 0xde, 0xd8, 0x73, 0x6a, 0x70, 0x87, 0xf0, 0x54, 0x25, 0x4e,
+	0x12, 0x7b, 0x4e, 0x6d, 0x4e, 0xc0, 0xff, 0x4b, 0x1d, 0xd8, 0xbb, 0x17, 0xf3, 0x1b, 0xe7, 0xd1,
+	0x20, 0x98, 0x53, 0x5d, 0xd5, 0x78, 0x01, 0x1d, 0x73, 0x8e, 0x4c, 0x75, 0xc3, 0x20, 0xc4, 0x0f,
+	0xbb, 0x81, 0x41, 0x13, 0xd1, 0x21, 0x2c, 0xb6, 0x41, 0x30, 0x0f, 0xa4, 0x56, 0x49, 0x68, 0x7d,
+	0xaa, 0xf1, 0xad, 0x02, 0x83, 0x1c, 0x04, 0xf3, 0xdf, 0xe4, 0x89, 0x11, 0xd6, 0xb3, 0x1a, 0xdf,
+	0x60, 0xf6, 0x16, 0x5e, 0x0f, 0x82, 0x39, 0xc7, 0xc0, 0x54, 0x24, 0xdd, 0x1b, 0x9a, 0xd6, 0xe8,
+	0x39, 0xc1, 0xfa, 0xc0, 0x4a, 0x4a, 0x9e, 0xc7, 0xf8, 0xcf, 0x96, 0x47, 0x8d, 0xbf, 0xc0, 0xb0,
+	0xaf, 0x00, 0x06, 0x69, 0x9e, 0x49, 0x83, 0x7f, 0xed, 0xcc, 0x6c, 0xf3, 0x92, 0x66, 0xcb, 0x5f,
+	0xcb, 0x08, 0xab, 0xa3, 0xc4, 0xa3, 0x06, 0xe3, 0x1a, 0xaa, 0xec, 0x81, 0x5c, 0x07, 0x8a, 0x6b,
+	0xa3, 0x60, 0x3e, 0x74, 0x2f, 0xa5, 0x8e, 0xe5, 0x92, 0x06, 0xa6, 0xd7, 0xb1, 0x06, 0x3b, 0x3a,
+	0x8c, 0x8f, 0x24, 0x2e, 0x33, 0xa9, 0x1f, 0x85, 0x51, 0x49, 0xec, 0x75, 0x29, 0xbe, 0x67, 0x04,
+	0xfa, 0x43, 0xca, 0x9b, 0x27, 0x91, 0x7a, 0xfb, 0xd6, 0xac, 0xa4, 0x41, 0x7f, 0x02, 0x15, 0x66,
+	0x57, 0x2a, 0x52, 0xc6, 0x3b, 0x20, 0x7f, 0x36, 0x0a, 0x3c, 0x9d, 0xc5, 0x9d, 0x4e, 0xf2, 0xd4,
+	0x3b, 0xa4, 0xcb, 0x95, 0x10, 0xfa, 0x49, 0x52, 0x20, 0xb4, 0x8c, 0x8d, 0xd7, 0xb3, 0xec, 0x8e,
+	0xce, 0xff, 0x6b, 0x05, 0x0e, 0xa8, 0x15, 0xae, 0x45, 0x4a, 0x73, 0xe7, 0xd7, 0xd0, 0xa2, 0x59,
+	0x62, 0xaf, 0x4d, 0x6c, 0x34, 0x9f, 0x1a, 0x6d, 0xd7, 0xce, 0x41, 0x99, 0xd1, 0x8c, 0xdd, 0x3c,
+	0x73, 0xcc, 0x61, 0x7f, 0x87, 0x7a, 0x61, 0x3a, 0xfe, 0x74, 0x77, 0x3a, 0xfe, 0xf0, 0xc5, 0xc9,
+	0x56, 0x9e, 0x8f, 0xbf, 0x83, 0x2f, 0x06, 0x49, 0x6c, 0x04, 0x4e, 0x02, 0x8e, 0x9b, 0x8b, 0x36,
+	0x41, 0xb2, 0x54, 0x8b, 0xf5, 0x66, 0xa8, 0x57, 0x4a, 0x43, 0xfd, 0x2d, 0xbc, 0x8e, 0xc4, 0x4a,
+	0x45, 0x79, 0xc4, 0xa5, 0xd1, 0xeb, 0x41, 0x92, 0xbb, 0x36, 0xdb, 0xe7, 0xcf, 0x09, 0xff, 0xcf,
+	0x55, 0x9a, 0xbd, 0x57, 0xc9, 0x5d, 0xc6, 0xe5, 0x1f, 0x72, 0x99, 0x19, 0xd6, 0x87, 0xba, 0x59,
+	0xa7, 0xd2, 0x4d, 0xde, 0xe3, 0xad, 0x7f, 0x25, 0xa3, 0xfe, 0x6c, 0x9d, 0x4a, 0x6e, 0xed, 0xdc,
+	0x5a, 0x54, 0xdd, 0xac, 0x45, 0x47, 0xd0, 0xc8, 0x54, 0xbc, 0x90, 0xc5, 0x9c, 0xb5, 0x80, 0xfd,
+	0x18, 0xf6, 0x45, 0x18, 0xce, 0x8a, 0xdd, 0x8b, 0x36, 0x88, 0x16, 0xdf, 0x55, 0xe2, 0x71, 0x7e,
+	0x48, 0x96, 0xcb, 0xe4, 0xc9, 0x36, 0x4d, 0x8b, 0x3b, 0x84, 0x91, 0xce, 0x84, 0x5a, 0xda, 0x2e,
+	0x69, 0x73, 0x2b, 0x63, 0xcb, 0x0e, 0xa5, 0x11, 0x6a, 0x99, 0xd9, 0x6e, 0x68, 0xf1, 0x02, 0x96,
+	0x17, 0xb3, 0xd6, 0xee, 0x62, 0x76, 0x0a, 0x75, 0xf4, 0x1c, 0xc7, 0xd6, 0xcd, 0x6c, 0x38, 0x9d,
+	0xcf, 0x7a, 0xaf, 0x9c, 0x3c, 0xe2, 0xbc, 0x57, 0x61, 0x2d, 0xa8, 0xbf, 0x9f, 0xce, 0x2e, 0x7a,
+	0x55, 0xff, 0x6b, 0xd8, 0x2f, 0x62, 0x1e, 0xdc, 0xe7, 0xf1, 0x03, 0xba, 0x10, 0x0a, 0x23, 0x6c,
+	0x5a, 0xba, 0xdc, 0xca, 0xfe, 0xdf, 0x2b, 0xd0, 0x19, 0xad, 0xe4, 0xa2, 0x48, 0xdd, 0xff, 0xbc,
+	0x21, 0x62, 0x5d, 0x2c, 0xa2, 0xd0, 0xee, 0x15, 0x6d, 0x8e, 0x22, 0x6a, 0x64, 0xfc, 0xe8, 0xd5,
+	0x49, 0x23, 0xe3, 0x47, 0xd4, 0x18, 0xb3, 0x76, 0x99, 0x40, 0xd1, 0xa6, 0xd6, 0x84, 0x2a, 0xb6,
+	0x79, 0xe8, 0x72, 0x02, 0xec, 0x04, 0x3a, 0x56, 0x18, 0x2c, 0x93, 0xcc, 0x2d, 0x51, 0x2d, 0x5e,
+	0x56, 0xb1, 0x9f, 0x40, 0x3d, 0x53, 0x9f, 0xa5, 0xd7, 0x2a, 0xdf, 0xf0, 0x33, 0xa9, 0x23, 0x15,
+	0x8b, 0xe5, 0x8d, 0xfa, 0x2c, 0xb9, 0xe5, 0xfd, 0x5f, 0x41, 0xb7, 0xac, 0xc5, 0xef, 0x3d, 0xa9,
+	0xd0, 0xdc, 0xdb, 0x90, 0xf6, 0x39, 0x01, 0x3c, 0xa4, 0x7b, 0x1a, 0xc5, 0x54, 0x57, 0x0e, 0xf9,
+	0x9f, 0xa0, 0x4d, 0xc9, 0xd8, 0x6c, 0xbd, 0x61, 0x92, 0x1b, 0x97, 0x30, 0x87, 0x9c, 0x5e, 0x6a,
+	0xca, 0x08, 0xe9, 0xa5, 0xd6, 0xcc, 0x87, 0xba, 0x5c, 0x29, 0xe3, 0xd5, 0xca, 0xab, 0x1b, 0xbe,
+	0x6e, 0xb4, 0x52, 0x86, 0x5b, 0xce, 0xff, 0x0a, 0x5a, 0x85, 0x06, 0x8f, 0x63, 0x91, 0x84, 0x54,
+	0xa5, 0x0d, 0x6e, 0x65, 0x7f, 0x0a, 0x87, 0x83, 0x24, 0x5d, 0x7f, 0xd0, 0x49, 0xf4, 0xff, 0x9f,
+	0x08, 0x5e, 0x55, 0xc2, 0xdc, 0xbb, 0xaa, 0xb5, 0xb2, 0xff, 0x2d, 0xb0, 0xa1, 0xca, 0x16, 0xc9,
+	0xa3, 0xd4, 0x17, 0xf9, 0x6d, 0xf1, 0xce, 0xf2, 0x05, 0x56, 0xd9, 0xbd, 0xc0, 0xfc, 0x3f, 0x56,
+	0xc0, 0xc3, 0xba, 0x29, 0xee, 0x1c, 0x7c, 0x46, 0x69, 0x19, 0xc9, 0x98, 0x76, 0xe4, 0x41, 0x30,
+	0x1f, 0x24, 0x7a, 0xb3, 0x94, 0x6f, 0x30, 0x8e, 0xb9, 0x48, 0xac, 0xae, 0xb7, 0xab, 0x69, 0x8d,
+	0x6f, 0x15, 0xac, 0x0f, 0x70, 0x1e, 0xcc, 0x6f, 0xf2, 0xd4, 0xde, 0xa6, 0x35, 0xdb, 0x99, 0x2e,
+	0x47, 0xe7, 0xf8, 0x86, 0x3c, 0x36, 0xbc, 0x64, 0xe1, 0xff, 0x08, 0x1a, 0x54, 0xb5, 0x47, 0xd0,
+	0x58, 0xa0, 0xe0, 0x4e, 0x81, 0x00, 0x26, 0x32, 0xd0, 0xc9, 0x9d, 0x96, 0x59, 0x86, 0x71, 0xdb,
+	0xda, 0xa0, 0x0b, 0xd1, 0xca, 0xdf, 0xfc, 0x12, 0x3a, 0x6e, 0x11, 0x9d, 0x51, 0x87, 0xc3, 0x64,
+	0xfa, 0x69, 0x32, 0x9a, 0x7d, 0x9c, 0xf2, 0x4b, 0xda, 0x15, 0xa6, 0xf3, 0xd9, 0xfb, 0xe9, 0x7c,
+	0x32, 0xa4, 0x5d, 0x61, 0x3c, 0x19, 0x4c, 0xaf, 0xed, 0xae, 0xf0, 0xcd, 0x77, 0xd0, 0x2a, 0x3c,
+	0xc2, 0xce, 0x9a, 0x4c, 0x3f, 0x9d, 0x07, 0xf3, 0xde, 0x2b, 0x7c, 0xc7, 0xcd, 0x78, 0x72, 0x7e,
+	0x35, 0xb2, 0xb8, 0xc2, 0x7a, 0xd0, 0xbd, 0x9e, 0x5f, 0xcd, 0xc6, 0x81, 0xd3, 0x54, 0x6f, 0x9b,
+	0xf6, 0xe7, 0xd8, 0xcf, 0xfe, 0x3d, 0x00, 0x07, 0xa2, 0x35, 0x2d, 0xcc, 0x0d, 0x00, 0x00,
 }
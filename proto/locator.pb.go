@@ -48,18 +48,126 @@ func (x ResolveRequest_EndpointType) String() string {
 	return proto.EnumName(ResolveRequest_EndpointType_name, int32(x))
 }
 func (ResolveRequest_EndpointType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor7, []int{1, 0}
+	return fileDescriptor8, []int{3, 0}
+}
+
+type Endpoint struct {
+	// Addr is the endpoint address in "host:port" format.
+	Addr string `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	// Priority of the endpoint, the lower value is preferred.
+	Priority uint32 `protobuf:"varint,2,opt,name=priority" json:"priority,omitempty"`
+	// Weight of the endpoint among endpoints with the same priority, the
+	// higher value is preferred.
+	Weight uint32 `protobuf:"varint,3,opt,name=weight" json:"weight,omitempty"`
+	// Region the endpoint is located in.
+	Region string `protobuf:"bytes,4,opt,name=region" json:"region,omitempty"`
+}
+
+func (m *Endpoint) Reset()                    { *m = Endpoint{} }
+func (m *Endpoint) String() string            { return proto.CompactTextString(m) }
+func (*Endpoint) ProtoMessage()               {}
+func (*Endpoint) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{0} }
+
+func (m *Endpoint) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *Endpoint) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Endpoint) GetWeight() uint32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *Endpoint) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+// LocatorRecord is a signed announcement of the party endpoints, which can
+// be verified, cached and passed along by anyone.
+type LocatorRecord struct {
+	EthAddr         string      `protobuf:"bytes,1,opt,name=ethAddr" json:"ethAddr,omitempty"`
+	ClientEndpoints []*Endpoint `protobuf:"bytes,2,rep,name=clientEndpoints" json:"clientEndpoints,omitempty"`
+	WorkerEndpoints []*Endpoint `protobuf:"bytes,3,rep,name=workerEndpoints" json:"workerEndpoints,omitempty"`
+	Ts              *Timestamp  `protobuf:"bytes,4,opt,name=ts" json:"ts,omitempty"`
+	// TTL of the record in seconds since its timestamp.
+	Ttl uint64 `protobuf:"varint,5,opt,name=ttl" json:"ttl,omitempty"`
+	// Signature is the announcer's signature of all fields above.
+	Signature []byte `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *LocatorRecord) Reset()                    { *m = LocatorRecord{} }
+func (m *LocatorRecord) String() string            { return proto.CompactTextString(m) }
+func (*LocatorRecord) ProtoMessage()               {}
+func (*LocatorRecord) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{1} }
+
+func (m *LocatorRecord) GetEthAddr() string {
+	if m != nil {
+		return m.EthAddr
+	}
+	return ""
+}
+
+func (m *LocatorRecord) GetClientEndpoints() []*Endpoint {
+	if m != nil {
+		return m.ClientEndpoints
+	}
+	return nil
+}
+
+func (m *LocatorRecord) GetWorkerEndpoints() []*Endpoint {
+	if m != nil {
+		return m.WorkerEndpoints
+	}
+	return nil
+}
+
+func (m *LocatorRecord) GetTs() *Timestamp {
+	if m != nil {
+		return m.Ts
+	}
+	return nil
+}
+
+func (m *LocatorRecord) GetTtl() uint64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *LocatorRecord) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
 }
 
 type AnnounceRequest struct {
+	// Deprecated: use record instead.
 	ClientEndpoints []string `protobuf:"bytes,1,rep,name=clientEndpoints" json:"clientEndpoints,omitempty"`
-	WorkerEndpoints []string `protobuf:"bytes,2,rep,name=workerEndpoints" json:"workerEndpoints,omitempty"`
+	// Deprecated: use record instead.
+	WorkerEndpoints []string       `protobuf:"bytes,2,rep,name=workerEndpoints" json:"workerEndpoints,omitempty"`
+	Record          *LocatorRecord `protobuf:"bytes,3,opt,name=record" json:"record,omitempty"`
 }
 
 func (m *AnnounceRequest) Reset()                    { *m = AnnounceRequest{} }
 func (m *AnnounceRequest) String() string            { return proto.CompactTextString(m) }
 func (*AnnounceRequest) ProtoMessage()               {}
-func (*AnnounceRequest) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{0} }
+func (*AnnounceRequest) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{2} }
 
 func (m *AnnounceRequest) GetClientEndpoints() []string {
 	if m != nil {
@@ -75,15 +183,25 @@ func (m *AnnounceRequest) GetWorkerEndpoints() []string {
 	return nil
 }
 
+func (m *AnnounceRequest) GetRecord() *LocatorRecord {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
 type ResolveRequest struct {
 	EthAddr      string                      `protobuf:"bytes,1,opt,name=ethAddr" json:"ethAddr,omitempty"`
 	EndpointType ResolveRequest_EndpointType `protobuf:"varint,2,opt,name=endpointType,enum=sonm.ResolveRequest_EndpointType" json:"endpointType,omitempty"`
+	// Region preferred by the caller. Endpoints located in this region go
+	// first among endpoints with the same priority.
+	Region string `protobuf:"bytes,3,opt,name=region" json:"region,omitempty"`
 }
 
 func (m *ResolveRequest) Reset()                    { *m = ResolveRequest{} }
 func (m *ResolveRequest) String() string            { return proto.CompactTextString(m) }
 func (*ResolveRequest) ProtoMessage()               {}
-func (*ResolveRequest) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{1} }
+func (*ResolveRequest) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{3} }
 
 func (m *ResolveRequest) GetEthAddr() string {
 	if m != nil {
@@ -99,14 +217,25 @@ func (m *ResolveRequest) GetEndpointType() ResolveRequest_EndpointType {
 	return ResolveRequest_CLIENT
 }
 
+func (m *ResolveRequest) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
 type ResolveReply struct {
+	// Endpoints ordered by preference.
 	Endpoints []string `protobuf:"bytes,1,rep,name=endpoints" json:"endpoints,omitempty"`
+	// Record is the announced record the endpoints are resolved from. It is
+	// not signed for parties still using deprecated announcements.
+	Record *LocatorRecord `protobuf:"bytes,2,opt,name=record" json:"record,omitempty"`
 }
 
 func (m *ResolveReply) Reset()                    { *m = ResolveReply{} }
 func (m *ResolveReply) String() string            { return proto.CompactTextString(m) }
 func (*ResolveReply) ProtoMessage()               {}
-func (*ResolveReply) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{2} }
+func (*ResolveReply) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{4} }
 
 func (m *ResolveReply) GetEndpoints() []string {
 	if m != nil {
@@ -115,7 +244,16 @@ func (m *ResolveReply) GetEndpoints() []string {
 	return nil
 }
 
+func (m *ResolveReply) GetRecord() *LocatorRecord {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterType((*Endpoint)(nil), "sonm.Endpoint")
+	proto.RegisterType((*LocatorRecord)(nil), "sonm.LocatorRecord")
 	proto.RegisterType((*AnnounceRequest)(nil), "sonm.AnnounceRequest")
 	proto.RegisterType((*ResolveRequest)(nil), "sonm.ResolveRequest")
 	proto.RegisterType((*ResolveReply)(nil), "sonm.ResolveReply")
@@ -135,6 +273,9 @@ const _ = grpc.SupportPackageIsVersion4
 type LocatorClient interface {
 	Announce(ctx context.Context, in *AnnounceRequest, opts ...grpc.CallOption) (*Empty, error)
 	Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*ResolveReply, error)
+	// WatchResolve resolves the given Eth address, sending the result each
+	// time it changes, including when the record expires.
+	WatchResolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (Locator_WatchResolveClient, error)
 }
 
 type locatorClient struct {
@@ -163,11 +304,46 @@ func (c *locatorClient) Resolve(ctx context.Context, in *ResolveRequest, opts ..
 	return out, nil
 }
 
+func (c *locatorClient) WatchResolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (Locator_WatchResolveClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Locator_serviceDesc.Streams[0], c.cc, "/sonm.Locator/WatchResolve", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &locatorWatchResolveClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Locator_WatchResolveClient interface {
+	Recv() (*ResolveReply, error)
+	grpc.ClientStream
+}
+
+type locatorWatchResolveClient struct {
+	grpc.ClientStream
+}
+
+func (x *locatorWatchResolveClient) Recv() (*ResolveReply, error) {
+	m := new(ResolveReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Locator service
 
 type LocatorServer interface {
 	Announce(context.Context, *AnnounceRequest) (*Empty, error)
 	Resolve(context.Context, *ResolveRequest) (*ResolveReply, error)
+	// WatchResolve resolves the given Eth address, sending the result each
+	// time it changes, including when the record expires.
+	WatchResolve(*ResolveRequest, Locator_WatchResolveServer) error
 }
 
 func RegisterLocatorServer(s *grpc.Server, srv LocatorServer) {
@@ -210,6 +386,27 @@ func _Locator_Resolve_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Locator_WatchResolve_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ResolveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LocatorServer).WatchResolve(m, &locatorWatchResolveServer{stream})
+}
+
+type Locator_WatchResolveServer interface {
+	Send(*ResolveReply) error
+	grpc.ServerStream
+}
+
+type locatorWatchResolveServer struct {
+	grpc.ServerStream
+}
+
+func (x *locatorWatchResolveServer) Send(m *ResolveReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Locator_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "sonm.Locator",
 	HandlerType: (*LocatorServer)(nil),
@@ -223,7 +420,13 @@ var _Locator_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Locator_Resolve_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchResolve",
+			Handler:       _Locator_WatchResolve_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "locator.proto",
 }
 
@@ -274,6 +477,25 @@ var _Locator_ResolveCmd_gen = &cobra.Command{
 	RunE:  grpccmd.TypeToJson("sonm.ResolveRequest"),
 }
 
+var _Locator_WatchResolveCmd = &cobra.Command{
+	Use:   "watchResolve",
+	Short: "Make the WatchResolve method call, input-type: sonm.ResolveRequest output-type: sonm.ResolveReply",
+	RunE: grpccmd.RunE(
+		"WatchResolve",
+		"sonm.ResolveRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewLocatorClient(cc)
+		},
+	),
+}
+
+var _Locator_WatchResolveCmd_gen = &cobra.Command{
+	Use:   "watchResolve-gen",
+	Short: "Generate JSON for method call of WatchResolve (input-type: sonm.ResolveRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.ResolveRequest"),
+}
+
 // Register commands with the root command and service command
 func init() {
 	grpccmd.RegisterServiceCmd(_LocatorCmd)
@@ -282,31 +504,45 @@ func init() {
 		_Locator_AnnounceCmd_gen,
 		_Locator_ResolveCmd,
 		_Locator_ResolveCmd_gen,
+		_Locator_WatchResolveCmd,
+		_Locator_WatchResolveCmd_gen,
 	)
 }
 
 // End grpccmd
 
-func init() { proto.RegisterFile("locator.proto", fileDescriptor7) }
-
-var fileDescriptor7 = []byte{
-	// 288 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x5f, 0x4b, 0xf3, 0x30,
-	0x14, 0xc6, 0x97, 0xed, 0x65, 0x7d, 0x7b, 0xac, 0x6b, 0x09, 0x0a, 0xa5, 0x78, 0x51, 0x73, 0xd5,
-	0x0b, 0xa9, 0x32, 0xf1, 0x03, 0x14, 0xc9, 0x85, 0x38, 0x26, 0x84, 0x81, 0x78, 0x39, 0xdb, 0x03,
-	0x16, 0xbb, 0x24, 0xa6, 0x99, 0xd2, 0xaf, 0xe3, 0x27, 0x95, 0xda, 0xfd, 0x2d, 0x5e, 0x25, 0xf9,
-	0xe5, 0x39, 0xe7, 0x3c, 0x79, 0x02, 0xa7, 0x95, 0xca, 0x97, 0x56, 0x99, 0x54, 0x1b, 0x65, 0x15,
-	0xfd, 0x57, 0x2b, 0xb9, 0x8a, 0xfc, 0x52, 0xb6, 0xab, 0x2c, 0x97, 0x1d, 0x66, 0x08, 0x7e, 0x26,
-	0xa5, 0x5a, 0xcb, 0x1c, 0x05, 0x7e, 0xac, 0xb1, 0xb6, 0x34, 0x01, 0x3f, 0xaf, 0x4a, 0x94, 0x96,
-	0xcb, 0x42, 0xab, 0x52, 0xda, 0x3a, 0x24, 0xf1, 0x28, 0x71, 0x45, 0x1f, 0xb7, 0xca, 0x2f, 0x65,
-	0xde, 0xd1, 0xec, 0x95, 0xc3, 0x4e, 0xd9, 0xc3, 0xec, 0x9b, 0xc0, 0x44, 0x60, 0xad, 0xaa, 0xcf,
-	0xdd, 0x98, 0x10, 0x1c, 0xb4, 0x6f, 0x59, 0x51, 0x98, 0x90, 0xc4, 0x24, 0x71, 0xc5, 0xf6, 0x48,
-	0x39, 0x78, 0xb8, 0xa9, 0x5c, 0x34, 0x1a, 0xc3, 0x61, 0x4c, 0x92, 0xc9, 0xf4, 0x32, 0x6d, 0x9d,
-	0xa7, 0xc7, 0x5d, 0x52, 0x7e, 0x20, 0x14, 0x47, 0x65, 0xec, 0x1a, 0xbc, 0xc3, 0x5b, 0x0a, 0x30,
-	0xbe, 0x9f, 0x3d, 0xf0, 0xf9, 0x22, 0x18, 0xb4, 0xfb, 0xe7, 0x27, 0xf1, 0xc8, 0x45, 0x40, 0xa8,
-	0x03, 0xa3, 0x6c, 0xfe, 0x12, 0x0c, 0xd9, 0x15, 0x78, 0xbb, 0xee, 0xba, 0x6a, 0xe8, 0x05, 0xb8,
-	0xd8, 0x8b, 0x60, 0x0f, 0xa6, 0x06, 0x9c, 0x59, 0x97, 0x30, 0xbd, 0x81, 0xff, 0xdb, 0x10, 0xe9,
-	0x79, 0x67, 0xb3, 0x17, 0x6a, 0x74, 0xd2, 0x61, 0xbe, 0xd2, 0xb6, 0x61, 0x03, 0x7a, 0x07, 0xce,
-	0x66, 0x14, 0x3d, 0xfb, 0xeb, 0x5d, 0x11, 0xed, 0x51, 0x5d, 0x35, 0x6c, 0xf0, 0x3a, 0xfe, 0xfd,
-	0xb4, 0xdb, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x16, 0x09, 0x90, 0x83, 0xdc, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("locator.proto", fileDescriptor8) }
+
+var fileDescriptor8 = []byte{
+	// 477 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0x5d, 0xab, 0xd3, 0x4c,
+	0x10, 0x3e, 0x9b, 0xf4, 0xed, 0xc7, 0xf4, 0x23, 0x65, 0x5e, 0x95, 0x10, 0x04, 0x63, 0xae, 0x02,
+	0x42, 0x3d, 0x44, 0x04, 0x2f, 0xbc, 0x29, 0x92, 0x0b, 0xf1, 0x70, 0x84, 0xa5, 0x70, 0x38, 0x97,
+	0x31, 0x5d, 0xda, 0xd5, 0x74, 0x37, 0x6e, 0xb6, 0x1e, 0xfa, 0x37, 0xfc, 0x25, 0x5e, 0xfb, 0xa3,
+	0xfc, 0x0d, 0xb2, 0xf9, 0xe8, 0x47, 0x5a, 0x11, 0xbc, 0xca, 0xce, 0xec, 0xf3, 0xec, 0x3c, 0xf3,
+	0xcc, 0x04, 0xc6, 0x99, 0x4c, 0x13, 0x2d, 0xd5, 0x2c, 0x57, 0x52, 0x4b, 0xec, 0x14, 0x52, 0x6c,
+	0x3c, 0x87, 0x0b, 0xf3, 0x15, 0x3c, 0xa9, 0xd2, 0x9e, 0xa3, 0xf9, 0x86, 0x15, 0x3a, 0xd9, 0xe4,
+	0x55, 0x22, 0xf8, 0x0c, 0xfd, 0x58, 0x2c, 0x73, 0xc9, 0x85, 0x46, 0x84, 0x4e, 0xb2, 0x5c, 0x2a,
+	0x97, 0xf8, 0x24, 0x1c, 0xd0, 0xf2, 0x8c, 0x1e, 0xf4, 0x73, 0xc5, 0xa5, 0xe2, 0x7a, 0xe7, 0x5a,
+	0x3e, 0x09, 0xc7, 0x74, 0x1f, 0xe3, 0x13, 0xe8, 0x3e, 0x30, 0xbe, 0x5a, 0x6b, 0xd7, 0x2e, 0x6f,
+	0xea, 0xc8, 0xe4, 0x15, 0x5b, 0x71, 0x29, 0xdc, 0x4e, 0xf9, 0x52, 0x1d, 0x05, 0xbf, 0x08, 0x8c,
+	0x6f, 0x2a, 0x95, 0x94, 0xa5, 0x52, 0x2d, 0xd1, 0x85, 0x1e, 0xd3, 0xeb, 0xf9, 0xa1, 0x68, 0x13,
+	0xe2, 0x1b, 0x70, 0xd2, 0x8c, 0x33, 0xa1, 0x1b, 0x75, 0x85, 0x6b, 0xf9, 0x76, 0x38, 0x8c, 0x26,
+	0x33, 0xd3, 0xd1, 0xac, 0x49, 0xd3, 0x36, 0xcc, 0x30, 0x1f, 0xa4, 0xfa, 0xc2, 0xd4, 0x81, 0x69,
+	0x5f, 0x66, 0xb6, 0x60, 0xf8, 0x0c, 0x2c, 0x5d, 0x94, 0x9a, 0x87, 0x91, 0x53, 0x81, 0x17, 0x8d,
+	0x5d, 0xd4, 0xd2, 0x05, 0x4e, 0xc1, 0xd6, 0x3a, 0x73, 0xff, 0xf3, 0x49, 0xd8, 0xa1, 0xe6, 0x88,
+	0x4f, 0x61, 0x50, 0xf0, 0x95, 0x48, 0xf4, 0x56, 0x31, 0xb7, 0xeb, 0x93, 0x70, 0x44, 0x0f, 0x89,
+	0xe0, 0x3b, 0x01, 0x67, 0x2e, 0x84, 0xdc, 0x8a, 0x94, 0x51, 0xf6, 0x75, 0xcb, 0x0a, 0x8d, 0xe1,
+	0x79, 0x63, 0xc4, 0xb7, 0xc3, 0xc1, 0x79, 0x23, 0xe1, 0x79, 0x23, 0x56, 0x85, 0x6c, 0x0b, 0x7f,
+	0x61, 0x0c, 0x37, 0x86, 0x96, 0x83, 0x18, 0x46, 0xff, 0x57, 0xe2, 0x4f, 0xbc, 0xa6, 0x35, 0x24,
+	0xf8, 0x49, 0x60, 0x42, 0x59, 0x21, 0xb3, 0x6f, 0x7b, 0x4d, 0x7f, 0x1e, 0x43, 0x0c, 0x23, 0x56,
+	0x97, 0x59, 0xec, 0x72, 0x56, 0xae, 0xc0, 0x24, 0x7a, 0x5e, 0xbd, 0x7f, 0xfa, 0xca, 0x2c, 0x3e,
+	0x02, 0xd2, 0x13, 0xda, 0xd1, 0x46, 0xd8, 0x27, 0x1b, 0xf1, 0x12, 0x46, 0xc7, 0x2c, 0x04, 0xe8,
+	0xbe, 0xbb, 0x79, 0x1f, 0xdf, 0x2e, 0xa6, 0x57, 0xe6, 0x7c, 0xf7, 0x91, 0x7e, 0x88, 0xe9, 0x94,
+	0x60, 0x0f, 0xec, 0xf9, 0xed, 0xfd, 0xd4, 0x0a, 0xee, 0x61, 0xb4, 0xaf, 0x9a, 0x67, 0x3b, 0xe3,
+	0x3f, 0x6b, 0xf9, 0x38, 0x60, 0x17, 0x7c, 0xb1, 0xfe, 0xea, 0x4b, 0xf4, 0x83, 0x40, 0xaf, 0xbe,
+	0xc1, 0x6b, 0xe8, 0x37, 0x73, 0xc3, 0xc7, 0x15, 0xa9, 0x35, 0x47, 0x6f, 0x58, 0x6f, 0xd3, 0x26,
+	0xd7, 0xbb, 0xe0, 0x0a, 0x5f, 0x43, 0xaf, 0x16, 0x86, 0x8f, 0x2e, 0xb9, 0xe3, 0x61, 0x2b, 0x9b,
+	0x67, 0x86, 0xf6, 0x16, 0x46, 0x77, 0x89, 0x4e, 0xd7, 0xff, 0xc0, 0xbd, 0x26, 0x9f, 0xba, 0xe5,
+	0x3f, 0xfc, 0xea, 0xf7, 0x00, 0x9b, 0x68, 0xb6, 0x20, 0xfc, 0x03, 0x00, 0x00,
 }
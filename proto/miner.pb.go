@@ -33,7 +33,7 @@ type MinerHandshakeRequest struct {
 func (m *MinerHandshakeRequest) Reset()                    { *m = MinerHandshakeRequest{} }
 func (m *MinerHandshakeRequest) String() string            { return proto.CompactTextString(m) }
 func (*MinerHandshakeRequest) ProtoMessage()               {}
-func (*MinerHandshakeRequest) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{0} }
+func (*MinerHandshakeRequest) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{0} }
 
 func (m *MinerHandshakeRequest) GetHub() string {
 	if m != nil {
@@ -58,7 +58,7 @@ type MinerHandshakeReply struct {
 func (m *MinerHandshakeReply) Reset()                    { *m = MinerHandshakeReply{} }
 func (m *MinerHandshakeReply) String() string            { return proto.CompactTextString(m) }
 func (*MinerHandshakeReply) ProtoMessage()               {}
-func (*MinerHandshakeReply) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{1} }
+func (*MinerHandshakeReply) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{1} }
 
 func (m *MinerHandshakeReply) GetMiner() string {
 	if m != nil {
@@ -90,12 +90,14 @@ type MinerStartRequest struct {
 	// OrderId describes an unique order identifier.
 	// It is here for proper resource allocation and limitation.
 	OrderId string `protobuf:"bytes,5,opt,name=orderId" json:"orderId,omitempty"`
+	// Sidecars describes containers started together with the main one.
+	Sidecars []*Container `protobuf:"bytes,6,rep,name=sidecars" json:"sidecars,omitempty"`
 }
 
 func (m *MinerStartRequest) Reset()                    { *m = MinerStartRequest{} }
 func (m *MinerStartRequest) String() string            { return proto.CompactTextString(m) }
 func (*MinerStartRequest) ProtoMessage()               {}
-func (*MinerStartRequest) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{2} }
+func (*MinerStartRequest) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{2} }
 
 func (m *MinerStartRequest) GetId() string {
 	if m != nil {
@@ -132,6 +134,13 @@ func (m *MinerStartRequest) GetOrderId() string {
 	return ""
 }
 
+func (m *MinerStartRequest) GetSidecars() []*Container {
+	if m != nil {
+		return m.Sidecars
+	}
+	return nil
+}
+
 type MinerStartReply struct {
 	Container string `protobuf:"bytes,1,opt,name=container" json:"container,omitempty"`
 	// PortMap represent port mapping between container network and host ones.
@@ -142,7 +151,7 @@ type MinerStartReply struct {
 func (m *MinerStartReply) Reset()                    { *m = MinerStartReply{} }
 func (m *MinerStartReply) String() string            { return proto.CompactTextString(m) }
 func (*MinerStartReply) ProtoMessage()               {}
-func (*MinerStartReply) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{3} }
+func (*MinerStartReply) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{3} }
 
 func (m *MinerStartReply) GetContainer() string {
 	if m != nil {
@@ -173,7 +182,7 @@ type TaskInfo struct {
 func (m *TaskInfo) Reset()                    { *m = TaskInfo{} }
 func (m *TaskInfo) String() string            { return proto.CompactTextString(m) }
 func (*TaskInfo) ProtoMessage()               {}
-func (*TaskInfo) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{4} }
+func (*TaskInfo) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{4} }
 
 func (m *TaskInfo) GetRequest() *MinerStartRequest {
 	if m != nil {
@@ -196,7 +205,7 @@ type Endpoints struct {
 func (m *Endpoints) Reset()                    { *m = Endpoints{} }
 func (m *Endpoints) String() string            { return proto.CompactTextString(m) }
 func (*Endpoints) ProtoMessage()               {}
-func (*Endpoints) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{5} }
+func (*Endpoints) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{5} }
 
 func (m *Endpoints) GetEndpoints() []*SocketAddr {
 	if m != nil {
@@ -211,7 +220,7 @@ type MinerStatusMapRequest struct {
 func (m *MinerStatusMapRequest) Reset()                    { *m = MinerStatusMapRequest{} }
 func (m *MinerStatusMapRequest) String() string            { return proto.CompactTextString(m) }
 func (*MinerStatusMapRequest) ProtoMessage()               {}
-func (*MinerStatusMapRequest) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{6} }
+func (*MinerStatusMapRequest) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{6} }
 
 type SaveRequest struct {
 	ImageID string `protobuf:"bytes,1,opt,name=imageID" json:"imageID,omitempty"`
@@ -220,7 +229,7 @@ type SaveRequest struct {
 func (m *SaveRequest) Reset()                    { *m = SaveRequest{} }
 func (m *SaveRequest) String() string            { return proto.CompactTextString(m) }
 func (*SaveRequest) ProtoMessage()               {}
-func (*SaveRequest) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{7} }
+func (*SaveRequest) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{7} }
 
 func (m *SaveRequest) GetImageID() string {
 	if m != nil {
@@ -263,6 +272,19 @@ type MinerClient interface {
 	TaskDetails(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TaskStatusReply, error)
 	TaskLogs(ctx context.Context, in *TaskLogsRequest, opts ...grpc.CallOption) (Miner_TaskLogsClient, error)
 	DiscoverHub(ctx context.Context, in *DiscoverHubRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Forward proxies a TCP connection to one of the ports published by
+	// tasks on the miner. The target "host:port" is passed via "target"
+	// metadata key.
+	Forward(ctx context.Context, opts ...grpc.CallOption) (Miner_ForwardClient, error)
+	// Exec executes a command inside the task container, streaming its
+	// standard input and output.
+	Exec(ctx context.Context, opts ...grpc.CallOption) (Miner_ExecClient, error)
+	// CopyTo extracts the tar archive streamed into the task container
+	// path. The task ID and the path are passed via "task" and "path"
+	// metadata keys.
+	CopyTo(ctx context.Context, opts ...grpc.CallOption) (Miner_CopyToClient, error)
+	// CopyFrom streams a tar archive of the task container path.
+	CopyFrom(ctx context.Context, in *CopyFromRequest, opts ...grpc.CallOption) (Miner_CopyFromClient, error)
 }
 
 type minerClient struct {
@@ -471,6 +493,131 @@ func (c *minerClient) DiscoverHub(ctx context.Context, in *DiscoverHubRequest, o
 	return out, nil
 }
 
+func (c *minerClient) Forward(ctx context.Context, opts ...grpc.CallOption) (Miner_ForwardClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Miner_serviceDesc.Streams[4], c.cc, "/sonm.Miner/Forward", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &minerForwardClient{stream}
+	return x, nil
+}
+
+type Miner_ForwardClient interface {
+	Send(*Chunk) error
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type minerForwardClient struct {
+	grpc.ClientStream
+}
+
+func (x *minerForwardClient) Send(m *Chunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *minerForwardClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *minerClient) Exec(ctx context.Context, opts ...grpc.CallOption) (Miner_ExecClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Miner_serviceDesc.Streams[5], c.cc, "/sonm.Miner/Exec", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &minerExecClient{stream}
+	return x, nil
+}
+
+type Miner_ExecClient interface {
+	Send(*ExecRequest) error
+	Recv() (*ExecReply, error)
+	grpc.ClientStream
+}
+
+type minerExecClient struct {
+	grpc.ClientStream
+}
+
+func (x *minerExecClient) Send(m *ExecRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *minerExecClient) Recv() (*ExecReply, error) {
+	m := new(ExecReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *minerClient) CopyTo(ctx context.Context, opts ...grpc.CallOption) (Miner_CopyToClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Miner_serviceDesc.Streams[6], c.cc, "/sonm.Miner/CopyTo", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &minerCopyToClient{stream}
+	return x, nil
+}
+
+type Miner_CopyToClient interface {
+	Send(*Chunk) error
+	Recv() (*Progress, error)
+	grpc.ClientStream
+}
+
+type minerCopyToClient struct {
+	grpc.ClientStream
+}
+
+func (x *minerCopyToClient) Send(m *Chunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *minerCopyToClient) Recv() (*Progress, error) {
+	m := new(Progress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *minerClient) CopyFrom(ctx context.Context, in *CopyFromRequest, opts ...grpc.CallOption) (Miner_CopyFromClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Miner_serviceDesc.Streams[7], c.cc, "/sonm.Miner/CopyFrom", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &minerCopyFromClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Miner_CopyFromClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type minerCopyFromClient struct {
+	grpc.ClientStream
+}
+
+func (x *minerCopyFromClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Miner service
 
 type MinerServer interface {
@@ -486,6 +633,19 @@ type MinerServer interface {
 	TaskDetails(context.Context, *ID) (*TaskStatusReply, error)
 	TaskLogs(*TaskLogsRequest, Miner_TaskLogsServer) error
 	DiscoverHub(context.Context, *DiscoverHubRequest) (*Empty, error)
+	// Forward proxies a TCP connection to one of the ports published by
+	// tasks on the miner. The target "host:port" is passed via "target"
+	// metadata key.
+	Forward(Miner_ForwardServer) error
+	// Exec executes a command inside the task container, streaming its
+	// standard input and output.
+	Exec(Miner_ExecServer) error
+	// CopyTo extracts the tar archive streamed into the task container
+	// path. The task ID and the path are passed via "task" and "path"
+	// metadata keys.
+	CopyTo(Miner_CopyToServer) error
+	// CopyFrom streams a tar archive of the task container path.
+	CopyFrom(*CopyFromRequest, Miner_CopyFromServer) error
 }
 
 func RegisterMinerServer(s *grpc.Server, srv MinerServer) {
@@ -730,6 +890,105 @@ func _Miner_DiscoverHub_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Miner_Forward_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MinerServer).Forward(&minerForwardServer{stream})
+}
+
+type Miner_ForwardServer interface {
+	Send(*Chunk) error
+	Recv() (*Chunk, error)
+	grpc.ServerStream
+}
+
+type minerForwardServer struct {
+	grpc.ServerStream
+}
+
+func (x *minerForwardServer) Send(m *Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *minerForwardServer) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Miner_Exec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MinerServer).Exec(&minerExecServer{stream})
+}
+
+type Miner_ExecServer interface {
+	Send(*ExecReply) error
+	Recv() (*ExecRequest, error)
+	grpc.ServerStream
+}
+
+type minerExecServer struct {
+	grpc.ServerStream
+}
+
+func (x *minerExecServer) Send(m *ExecReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *minerExecServer) Recv() (*ExecRequest, error) {
+	m := new(ExecRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Miner_CopyTo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MinerServer).CopyTo(&minerCopyToServer{stream})
+}
+
+type Miner_CopyToServer interface {
+	Send(*Progress) error
+	Recv() (*Chunk, error)
+	grpc.ServerStream
+}
+
+type minerCopyToServer struct {
+	grpc.ServerStream
+}
+
+func (x *minerCopyToServer) Send(m *Progress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *minerCopyToServer) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Miner_CopyFrom_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CopyFromRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MinerServer).CopyFrom(m, &minerCopyFromServer{stream})
+}
+
+type Miner_CopyFromServer interface {
+	Send(*Chunk) error
+	grpc.ServerStream
+}
+
+type minerCopyFromServer struct {
+	grpc.ServerStream
+}
+
+func (x *minerCopyFromServer) Send(m *Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Miner_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "sonm.Miner",
 	HandlerType: (*MinerServer)(nil),
@@ -790,6 +1049,29 @@ var _Miner_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Miner_TaskLogs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Forward",
+			Handler:       _Miner_Forward_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Exec",
+			Handler:       _Miner_Exec_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "CopyTo",
+			Handler:       _Miner_CopyTo_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "CopyFrom",
+			Handler:       _Miner_CopyFrom_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "miner.proto",
 }
@@ -1031,6 +1313,82 @@ var _Miner_DiscoverHubCmd_gen = &cobra.Command{
 	RunE:  grpccmd.TypeToJson("sonm.DiscoverHubRequest"),
 }
 
+var _Miner_ForwardCmd = &cobra.Command{
+	Use:   "forward",
+	Short: "Make the Forward method call, input-type: sonm.Chunk output-type: sonm.Chunk",
+	RunE: grpccmd.RunE(
+		"Forward",
+		"sonm.Chunk",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewMinerClient(cc)
+		},
+	),
+}
+
+var _Miner_ForwardCmd_gen = &cobra.Command{
+	Use:   "forward-gen",
+	Short: "Generate JSON for method call of Forward (input-type: sonm.Chunk)",
+	RunE:  grpccmd.TypeToJson("sonm.Chunk"),
+}
+
+var _Miner_ExecCmd = &cobra.Command{
+	Use:   "exec",
+	Short: "Make the Exec method call, input-type: sonm.ExecRequest output-type: sonm.ExecReply",
+	RunE: grpccmd.RunE(
+		"Exec",
+		"sonm.ExecRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewMinerClient(cc)
+		},
+	),
+}
+
+var _Miner_ExecCmd_gen = &cobra.Command{
+	Use:   "exec-gen",
+	Short: "Generate JSON for method call of Exec (input-type: sonm.ExecRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.ExecRequest"),
+}
+
+var _Miner_CopyToCmd = &cobra.Command{
+	Use:   "copyTo",
+	Short: "Make the CopyTo method call, input-type: sonm.Chunk output-type: sonm.Progress",
+	RunE: grpccmd.RunE(
+		"CopyTo",
+		"sonm.Chunk",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewMinerClient(cc)
+		},
+	),
+}
+
+var _Miner_CopyToCmd_gen = &cobra.Command{
+	Use:   "copyTo-gen",
+	Short: "Generate JSON for method call of CopyTo (input-type: sonm.Chunk)",
+	RunE:  grpccmd.TypeToJson("sonm.Chunk"),
+}
+
+var _Miner_CopyFromCmd = &cobra.Command{
+	Use:   "copyFrom",
+	Short: "Make the CopyFrom method call, input-type: sonm.CopyFromRequest output-type: sonm.Chunk",
+	RunE: grpccmd.RunE(
+		"CopyFrom",
+		"sonm.CopyFromRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewMinerClient(cc)
+		},
+	),
+}
+
+var _Miner_CopyFromCmd_gen = &cobra.Command{
+	Use:   "copyFrom-gen",
+	Short: "Generate JSON for method call of CopyFrom (input-type: sonm.CopyFromRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.CopyFromRequest"),
+}
+
 // Register commands with the root command and service command
 func init() {
 	grpccmd.RegisterServiceCmd(_MinerCmd)
@@ -1059,61 +1417,73 @@ func init() {
 		_Miner_TaskLogsCmd_gen,
 		_Miner_DiscoverHubCmd,
 		_Miner_DiscoverHubCmd_gen,
+		_Miner_ForwardCmd,
+		_Miner_ForwardCmd_gen,
+		_Miner_ExecCmd,
+		_Miner_ExecCmd_gen,
+		_Miner_CopyToCmd,
+		_Miner_CopyToCmd_gen,
+		_Miner_CopyFromCmd,
+		_Miner_CopyFromCmd_gen,
 	)
 }
 
 // End grpccmd
 
-func init() { proto.RegisterFile("miner.proto", fileDescriptor9) }
-
-var fileDescriptor9 = []byte{
-	// 760 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xed, 0x72, 0xe3, 0x34,
-	0x14, 0x8d, 0xf3, 0x41, 0xd6, 0xd7, 0xbb, 0xcd, 0xae, 0x76, 0x3b, 0x35, 0xa6, 0x53, 0x32, 0x1e,
-	0xa0, 0xe1, 0x2b, 0x53, 0xc2, 0x4c, 0x07, 0x4a, 0xff, 0x94, 0xa6, 0x4c, 0x03, 0x6d, 0xc9, 0x38,
-	0x7d, 0x01, 0x25, 0x16, 0xa9, 0x70, 0x22, 0x19, 0x49, 0x29, 0x93, 0x77, 0xe0, 0x09, 0x79, 0x07,
-	0x78, 0x06, 0x46, 0x1f, 0x8e, 0x9d, 0x90, 0xfd, 0x67, 0xdd, 0x7b, 0xee, 0xd5, 0x39, 0xe7, 0x5e,
-	0x25, 0x10, 0x2c, 0x29, 0x23, 0xa2, 0x9f, 0x0b, 0xae, 0x38, 0x6a, 0x4a, 0xce, 0x96, 0x11, 0x9a,
-	0xe1, 0x1c, 0x4f, 0xe9, 0x82, 0x2a, 0x4a, 0xa4, 0xcd, 0x44, 0x9d, 0x19, 0x67, 0x0a, 0x97, 0xd0,
-	0xa8, 0x43, 0x99, 0x06, 0x33, 0x8a, 0x5d, 0xc0, 0x67, 0x58, 0x6d, 0x3e, 0x89, 0xfb, 0x8c, 0x7f,
-	0x85, 0xc3, 0x7b, 0x5d, 0x75, 0x8b, 0x59, 0x2a, 0x9f, 0x70, 0x46, 0x12, 0xf2, 0xc7, 0x8a, 0x48,
-	0x85, 0x5e, 0x43, 0xe3, 0x69, 0x35, 0x0d, 0xbd, 0xae, 0xd7, 0xf3, 0x13, 0xfd, 0x89, 0x3e, 0x81,
-	0x96, 0xc2, 0x32, 0x93, 0x61, 0xbd, 0xdb, 0xe8, 0x05, 0x83, 0x83, 0xbe, 0xee, 0xdf, 0x7f, 0xc4,
-	0x32, 0x1b, 0xb1, 0xdf, 0x78, 0x62, 0x93, 0xf1, 0x5f, 0x1e, 0xbc, 0xdd, 0xed, 0x98, 0x2f, 0xd6,
-	0xe8, 0x1d, 0xb4, 0x8c, 0x12, 0xd7, 0xd1, 0x1e, 0xd0, 0x39, 0xbc, 0xac, 0x8a, 0x09, 0xeb, 0x5d,
-	0xaf, 0x17, 0x0c, 0x90, 0x6d, 0x7d, 0x5d, 0xc9, 0x24, 0x5b, 0x38, 0x74, 0x0a, 0x6d, 0x86, 0xd5,
-	0xe3, 0x3a, 0x27, 0x61, 0xa3, 0xeb, 0xf5, 0x0e, 0x06, 0xaf, 0x6c, 0xc9, 0xc3, 0xd5, 0xa3, 0x0e,
-	0x26, 0x45, 0x36, 0xfe, 0xd7, 0x83, 0x37, 0x86, 0xce, 0x44, 0x61, 0xa1, 0x0a, 0x71, 0x07, 0x50,
-	0xa7, 0xa9, 0x63, 0x52, 0xa7, 0x29, 0xfa, 0x1a, 0xfc, 0x8d, 0x7f, 0x8e, 0x43, 0xc7, 0x71, 0x28,
-	0xc2, 0x49, 0x89, 0x40, 0x3f, 0xc2, 0x2b, 0x41, 0xa4, 0x6e, 0x38, 0xe6, 0x0b, 0x3a, 0x5b, 0x1b,
-	0x0e, 0xc1, 0xe0, 0x78, 0xb7, 0xa4, 0x8a, 0x49, 0xb6, 0x4b, 0xd0, 0x25, 0xf8, 0x82, 0x48, 0xbe,
-	0x12, 0x33, 0x22, 0xc3, 0xa6, 0xa9, 0x3f, 0x29, 0x1d, 0x4d, 0x5c, 0x4a, 0x13, 0xa6, 0x82, 0x2c,
-	0x09, 0x53, 0x32, 0x29, 0x0b, 0x50, 0x08, 0x6d, 0x2e, 0x52, 0x22, 0x46, 0x69, 0xd8, 0x32, 0x2a,
-	0x8a, 0x63, 0xfc, 0xb7, 0x07, 0x9d, 0xaa, 0x60, 0xed, 0xfd, 0x71, 0x55, 0x9e, 0x55, 0x5d, 0x51,
-	0x73, 0x09, 0xed, 0x9c, 0x0b, 0x75, 0x8f, 0x73, 0x37, 0xd9, 0xd8, 0xf2, 0xd8, 0xe9, 0xd2, 0x1f,
-	0x5b, 0xd0, 0x0d, 0x53, 0x62, 0x9d, 0x14, 0x25, 0xe8, 0x04, 0x80, 0x11, 0xf5, 0x27, 0x17, 0xd9,
-	0x68, 0x28, 0xc3, 0x46, 0xb7, 0xd1, 0xf3, 0x93, 0x4a, 0x24, 0xfa, 0x05, 0x5e, 0x56, 0x0b, 0xf5,
-	0x5e, 0x65, 0x64, 0x5d, 0xec, 0x55, 0x46, 0xd6, 0xe8, 0x53, 0x68, 0x3d, 0xe3, 0xc5, 0x8a, 0x6c,
-	0x1b, 0x7f, 0xc3, 0xd2, 0x9c, 0x53, 0x2d, 0xdb, 0x66, 0x2f, 0xea, 0xdf, 0x79, 0xf1, 0xef, 0xf0,
-	0xa2, 0xd8, 0x37, 0xf4, 0x0d, 0xb4, 0x85, 0x1d, 0xa7, 0x69, 0x16, 0x0c, 0x8e, 0xfe, 0x4f, 0xdb,
-	0xa4, 0x93, 0x02, 0x87, 0xbe, 0x84, 0x96, 0xd0, 0x52, 0xdc, 0x4d, 0x87, 0x7b, 0x75, 0x26, 0x16,
-	0x13, 0xff, 0x00, 0xfe, 0x86, 0x03, 0xea, 0x83, 0x4f, 0x8a, 0x43, 0xe8, 0x19, 0x97, 0x5e, 0xdb,
-	0xea, 0x09, 0x9f, 0x65, 0x44, 0x5d, 0xa5, 0xa9, 0x48, 0x4a, 0x48, 0x7c, 0xe4, 0x9e, 0xd5, 0x44,
-	0x61, 0xb5, 0x92, 0xf7, 0x38, 0x77, 0x5c, 0xe2, 0x53, 0x08, 0x26, 0xf8, 0x79, 0xf3, 0xca, 0x42,
-	0x68, 0xd3, 0x25, 0x9e, 0x93, 0xd1, 0xd0, 0x39, 0x52, 0x1c, 0x07, 0xff, 0x34, 0xa1, 0x65, 0x5a,
-	0xa0, 0xcf, 0xa0, 0x39, 0xa6, 0x6c, 0x8e, 0x02, 0x67, 0xcc, 0x32, 0x57, 0xeb, 0xc8, 0xb9, 0xa4,
-	0x13, 0x86, 0x75, 0x5c, 0xd3, 0x38, 0x63, 0xcc, 0x3e, 0x9c, 0x79, 0xa1, 0x0e, 0x77, 0x03, 0xfe,
-	0xe6, 0x6d, 0xa2, 0x8f, 0x2a, 0x1e, 0xec, 0xfe, 0x06, 0x44, 0x1f, 0xee, 0x4f, 0xda, 0x36, 0x5f,
-	0x40, 0x53, 0x2b, 0x41, 0x6f, 0x9c, 0x0f, 0xa5, 0xaa, 0xc8, 0x31, 0xb8, 0x7e, 0x5a, 0xb1, 0x2c,
-	0xae, 0x9d, 0x79, 0xe8, 0x73, 0x68, 0xde, 0x71, 0x9c, 0xa2, 0x6a, 0x22, 0x72, 0x3f, 0x20, 0x63,
-	0xc1, 0xe7, 0x82, 0x48, 0x19, 0xd7, 0x7a, 0xde, 0x99, 0x87, 0xbe, 0x87, 0x96, 0x99, 0x05, 0x7a,
-	0xdf, 0x38, 0xa3, 0xfd, 0x63, 0x8b, 0x6b, 0xe8, 0x63, 0x68, 0x4e, 0x14, 0xcf, 0xd1, 0x0b, 0xa7,
-	0x79, 0x18, 0x55, 0xad, 0x88, 0x6b, 0xe8, 0x2b, 0x08, 0x7e, 0xe6, 0x94, 0x3d, 0xd8, 0xed, 0xac,
-	0xe0, 0x9c, 0x06, 0x97, 0x98, 0xe4, 0x64, 0x16, 0xd7, 0xd0, 0x4f, 0x10, 0xe8, 0x65, 0x93, 0x76,
-	0x86, 0x5b, 0x4e, 0xed, 0x8e, 0x35, 0x7a, 0xe7, 0x4c, 0x28, 0xe3, 0x86, 0x92, 0x51, 0x74, 0x66,
-	0xfb, 0x0c, 0x89, 0xc2, 0x74, 0x21, 0x2b, 0xb7, 0x1e, 0x96, 0xef, 0xdd, 0x16, 0x16, 0x42, 0x2e,
-	0xec, 0x9a, 0xdf, 0xf1, 0xb9, 0x44, 0x15, 0x90, 0x3e, 0x17, 0x17, 0xbe, 0xdd, 0x0e, 0x97, 0x56,
-	0x9f, 0x43, 0x30, 0xa4, 0x72, 0xc6, 0x9f, 0x89, 0xb8, 0x5d, 0x4d, 0x51, 0x68, 0x71, 0x95, 0xd0,
-	0xce, 0x90, 0x9c, 0x37, 0xd3, 0x0f, 0xcc, 0xff, 0xc1, 0xb7, 0xff, 0x05, 0x00, 0x00, 0xff, 0xff,
-	0x81, 0x39, 0xc1, 0xb4, 0x70, 0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("miner.proto", fileDescriptor10) }
+
+var fileDescriptor10 = []byte{
+	// 830 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xff, 0x72, 0xdb, 0x44,
+	0x10, 0xb6, 0xfc, 0x23, 0x8e, 0x57, 0x6d, 0xdc, 0x6e, 0x9b, 0xa9, 0x10, 0x9d, 0xe2, 0xd1, 0x00,
+	0x75, 0x09, 0x78, 0x82, 0x99, 0xe9, 0x40, 0xe9, 0x3f, 0x25, 0x4e, 0xa6, 0x81, 0xa6, 0x64, 0xe4,
+	0xbc, 0xc0, 0x45, 0x3a, 0x9c, 0xc3, 0xb6, 0x4e, 0xdc, 0x9d, 0x53, 0xf4, 0x0e, 0xbc, 0x01, 0x8f,
+	0xc0, 0x1b, 0xf1, 0x34, 0xcc, 0xe9, 0xee, 0x2c, 0xd9, 0x38, 0x33, 0xfd, 0xef, 0x6e, 0xf7, 0xdb,
+	0xd5, 0x7e, 0xdf, 0xb7, 0x92, 0xc0, 0x5f, 0xb2, 0x8c, 0x8a, 0x51, 0x2e, 0xb8, 0xe2, 0xd8, 0x96,
+	0x3c, 0x5b, 0x86, 0x98, 0x90, 0x9c, 0x5c, 0xb3, 0x05, 0x53, 0x8c, 0x4a, 0x93, 0x09, 0xfb, 0x09,
+	0xcf, 0x14, 0xa9, 0xa0, 0x61, 0x9f, 0x65, 0x1a, 0x9c, 0x31, 0x62, 0x03, 0xbd, 0x8c, 0xa8, 0xf5,
+	0x91, 0xda, 0x63, 0xf4, 0x2b, 0x1c, 0x5e, 0xe8, 0xaa, 0xb7, 0x24, 0x4b, 0xe5, 0x0d, 0x99, 0xd3,
+	0x98, 0xfe, 0xb1, 0xa2, 0x52, 0xe1, 0x03, 0x68, 0xdd, 0xac, 0xae, 0x03, 0x6f, 0xe0, 0x0d, 0x7b,
+	0xb1, 0x3e, 0xe2, 0xe7, 0xd0, 0x51, 0x44, 0xce, 0x65, 0xd0, 0x1c, 0xb4, 0x86, 0xfe, 0xf8, 0x60,
+	0xa4, 0xfb, 0x8f, 0xae, 0x88, 0x9c, 0x9f, 0x67, 0xbf, 0xf1, 0xd8, 0x24, 0xa3, 0xbf, 0x3c, 0x78,
+	0xb4, 0xdd, 0x31, 0x5f, 0x14, 0xf8, 0x18, 0x3a, 0x25, 0x13, 0xdb, 0xd1, 0x5c, 0xf0, 0x25, 0xdc,
+	0xab, 0x93, 0x09, 0x9a, 0x03, 0x6f, 0xe8, 0x8f, 0xd1, 0xb4, 0x3e, 0xa9, 0x65, 0xe2, 0x0d, 0x1c,
+	0x3e, 0x87, 0x6e, 0x46, 0xd4, 0x55, 0x91, 0xd3, 0xa0, 0x35, 0xf0, 0x86, 0x07, 0xe3, 0xfb, 0xa6,
+	0xe4, 0xfd, 0x9b, 0x2b, 0x1d, 0x8c, 0x5d, 0x36, 0xfa, 0xbb, 0x09, 0x0f, 0xcb, 0x71, 0xa6, 0x8a,
+	0x08, 0xe5, 0xc8, 0x1d, 0x40, 0x93, 0xa5, 0x76, 0x92, 0x26, 0x4b, 0xf1, 0x1b, 0xe8, 0xad, 0xf5,
+	0xb3, 0x33, 0xf4, 0xed, 0x0c, 0x2e, 0x1c, 0x57, 0x08, 0xfc, 0x09, 0xee, 0x0b, 0x2a, 0x75, 0xc3,
+	0x4b, 0xbe, 0x60, 0x49, 0x51, 0xce, 0xe0, 0x8f, 0x9f, 0x6e, 0x97, 0xd4, 0x31, 0xf1, 0x66, 0x09,
+	0xbe, 0x86, 0x9e, 0xa0, 0x92, 0xaf, 0x44, 0x42, 0x65, 0xd0, 0x2e, 0xeb, 0x9f, 0x55, 0x8a, 0xc6,
+	0x36, 0xa5, 0x07, 0x66, 0x82, 0x2e, 0x69, 0xa6, 0x64, 0x5c, 0x15, 0x60, 0x00, 0x5d, 0x2e, 0x52,
+	0x2a, 0xce, 0xd3, 0xa0, 0x53, 0xb2, 0x70, 0x57, 0x3c, 0x82, 0x7d, 0xc9, 0x52, 0x9a, 0x10, 0x21,
+	0x83, 0xbd, 0x41, 0x6b, 0x17, 0x93, 0x35, 0x20, 0xfa, 0xd7, 0x83, 0x7e, 0x5d, 0x1d, 0x6d, 0xd4,
+	0xd3, 0xba, 0x16, 0x46, 0xa2, 0x1a, 0xf5, 0xd7, 0xd0, 0xcd, 0xb9, 0x50, 0x17, 0x24, 0xb7, 0x6b,
+	0x10, 0x99, 0xee, 0x5b, 0x5d, 0x46, 0x97, 0x06, 0x74, 0x9a, 0x29, 0x51, 0xc4, 0xae, 0x04, 0x9f,
+	0x01, 0x64, 0x54, 0x7d, 0xe0, 0x62, 0x7e, 0x3e, 0x91, 0x41, 0x6b, 0xd0, 0x1a, 0xf6, 0xe2, 0x5a,
+	0x24, 0xfc, 0x05, 0xee, 0xd5, 0x0b, 0xf5, 0x12, 0xce, 0x69, 0xe1, 0x96, 0x70, 0x4e, 0x0b, 0xfc,
+	0x02, 0x3a, 0xb7, 0x64, 0xb1, 0xa2, 0x9b, 0x2e, 0x9d, 0x66, 0x69, 0xce, 0x99, 0xd6, 0xc8, 0x64,
+	0x5f, 0x35, 0xbf, 0xf7, 0xa2, 0xdf, 0x61, 0xdf, 0x2d, 0x27, 0x7e, 0x0b, 0x5d, 0x61, 0xbc, 0x2f,
+	0x9b, 0xf9, 0xe3, 0x27, 0xff, 0x1f, 0xbb, 0x4c, 0xc7, 0x0e, 0x87, 0x47, 0xd0, 0x11, 0x9a, 0x8a,
+	0x7d, 0xd2, 0xe1, 0x4e, 0x9e, 0xb1, 0xc1, 0x44, 0x3f, 0x42, 0x6f, 0x3d, 0x03, 0x8e, 0xa0, 0x47,
+	0xdd, 0x25, 0xf0, 0x4a, 0x95, 0x1e, 0x98, 0xea, 0x29, 0x4f, 0xe6, 0x54, 0xbd, 0x49, 0x53, 0x11,
+	0x57, 0x90, 0xe8, 0x89, 0x7d, 0x07, 0xa7, 0x8a, 0xa8, 0x95, 0xbc, 0x20, 0xb9, 0x9d, 0x25, 0x7a,
+	0x0e, 0xfe, 0x94, 0xdc, 0xae, 0x5f, 0xc9, 0x00, 0xba, 0x6c, 0x49, 0x66, 0xf4, 0x7c, 0x62, 0x15,
+	0x71, 0xd7, 0xf1, 0x3f, 0x7b, 0xd0, 0x29, 0x5b, 0xe0, 0x97, 0xd0, 0xbe, 0x64, 0xd9, 0x0c, 0x7d,
+	0x2b, 0xcc, 0x32, 0x57, 0x45, 0x68, 0x55, 0xd2, 0x89, 0x72, 0xea, 0xa8, 0xa1, 0x71, 0xa5, 0x30,
+	0xbb, 0x70, 0x3a, 0xe1, 0x70, 0xa7, 0xd0, 0x5b, 0xbf, 0xc8, 0xf8, 0x69, 0x4d, 0x83, 0xed, 0x0f,
+	0x46, 0xf8, 0xc9, 0xee, 0xa4, 0x69, 0xf3, 0x15, 0xb4, 0x35, 0x13, 0x7c, 0x68, 0x75, 0xa8, 0x58,
+	0x85, 0x76, 0x82, 0x93, 0x9b, 0x55, 0x36, 0x8f, 0x1a, 0xc7, 0x1e, 0xbe, 0x80, 0xf6, 0x3b, 0x4e,
+	0x52, 0xac, 0x27, 0x42, 0xfb, 0xb5, 0xb9, 0x14, 0x7c, 0x26, 0xa8, 0x94, 0x51, 0x63, 0xe8, 0x1d,
+	0x7b, 0xf8, 0x03, 0x74, 0x4a, 0x2f, 0xf0, 0x2e, 0x3b, 0xc3, 0xdd, 0xb6, 0x45, 0x0d, 0xfc, 0x0c,
+	0xda, 0x53, 0xc5, 0x73, 0xdc, 0xb7, 0x9c, 0x27, 0x61, 0x5d, 0x8a, 0xa8, 0x81, 0x5f, 0x83, 0xff,
+	0x33, 0x67, 0xd9, 0x7b, 0xb3, 0x9d, 0x35, 0x9c, 0xe5, 0x60, 0x13, 0xd3, 0x9c, 0x26, 0x51, 0x03,
+	0xcf, 0xc0, 0xd7, 0xcb, 0x26, 0x8d, 0x87, 0x1b, 0x4a, 0x6d, 0xdb, 0x1a, 0x3e, 0xb6, 0x22, 0x54,
+	0xf1, 0x72, 0xa4, 0x92, 0xd1, 0xb1, 0xe9, 0x33, 0xa1, 0x8a, 0xb0, 0x85, 0xac, 0x3d, 0xf5, 0xb0,
+	0xfa, 0x38, 0x98, 0x42, 0x47, 0xe4, 0x95, 0x59, 0xf3, 0x77, 0x7c, 0x26, 0xb1, 0x06, 0xd2, 0x77,
+	0xf7, 0xc0, 0x47, 0x9b, 0xe1, 0x4a, 0xea, 0x97, 0xe0, 0x4f, 0x98, 0x4c, 0xf8, 0x2d, 0x15, 0x6f,
+	0x57, 0xd7, 0x18, 0x18, 0x5c, 0x2d, 0xb4, 0x65, 0x92, 0xd3, 0xe6, 0x05, 0x74, 0xcf, 0xb8, 0xf8,
+	0x40, 0xc4, 0x96, 0x4b, 0x9b, 0x5e, 0x5a, 0x42, 0xed, 0xd3, 0x3f, 0x69, 0xe2, 0x9c, 0xd7, 0x67,
+	0xd7, 0xb4, 0x5f, 0x0f, 0x55, 0x12, 0x1c, 0xc1, 0xde, 0x09, 0xcf, 0x8b, 0x2b, 0xfe, 0x31, 0x1b,
+	0x30, 0x86, 0x7d, 0x0d, 0x3e, 0x13, 0x7c, 0xe9, 0xd8, 0xbb, 0xfb, 0x5d, 0x0b, 0x76, 0xbd, 0x57,
+	0xfe, 0xfa, 0xbe, 0xfb, 0x6f, 0x00, 0xfa, 0x29, 0x52, 0x40, 0x5b, 0x07, 0x00, 0x00,
 }
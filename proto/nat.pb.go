@@ -49,15 +49,15 @@ var NATType_value = map[string]int32{
 func (x NATType) String() string {
 	return proto.EnumName(NATType_name, int32(x))
 }
-func (NATType) EnumDescriptor() ([]byte, []int) { return fileDescriptor10, []int{0} }
+func (NATType) EnumDescriptor() ([]byte, []int) { return fileDescriptor11, []int{0} }
 
 func init() {
 	proto.RegisterEnum("sonm.NATType", NATType_name, NATType_value)
 }
 
-func init() { proto.RegisterFile("nat.proto", fileDescriptor10) }
+func init() { proto.RegisterFile("nat.proto", fileDescriptor11) }
 
-var fileDescriptor10 = []byte{
+var fileDescriptor11 = []byte{
 	// 162 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcc, 0x4b, 0x2c, 0xd1,
 	0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x29, 0xce, 0xcf, 0xcb, 0xd5, 0x6a, 0x67, 0xe4, 0x62,
@@ -31,6 +31,13 @@ func (m *Addr) IntoTCP() (net.Addr, error) {
 	return m.Addr.IntoTCP()
 }
 
+func (m *Addr) IntoUDP() (*net.UDPAddr, error) {
+	if m.Protocol != "udp" {
+		return nil, fmt.Errorf("invalid protocol: %s", m.Protocol)
+	}
+	return m.Addr.IntoUDP()
+}
+
 // IsPrivate returns true if this address can't be reached from the Internet directly.
 func (m *Addr) IsPrivate() bool {
 	return m.Addr.IsPrivate()
@@ -60,6 +67,10 @@ func (m *SocketAddr) IntoTCP() (net.Addr, error) {
 	return m.intoNet("tcp")
 }
 
+func (m *SocketAddr) IntoUDP() (*net.UDPAddr, error) {
+	return net.ResolveUDPAddr("udp", net.JoinHostPort(m.Addr, strconv.Itoa(int(m.Port))))
+}
+
 func (m *SocketAddr) intoNet(protocol string) (net.Addr, error) {
 	return net.ResolveTCPAddr(protocol, fmt.Sprintf("%s:%d", m.Addr, m.Port))
 }
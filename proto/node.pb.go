@@ -33,7 +33,7 @@ type JoinNetworkRequest struct {
 func (m *JoinNetworkRequest) Reset()                    { *m = JoinNetworkRequest{} }
 func (m *JoinNetworkRequest) String() string            { return proto.CompactTextString(m) }
 func (*JoinNetworkRequest) ProtoMessage()               {}
-func (*JoinNetworkRequest) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{0} }
+func (*JoinNetworkRequest) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{0} }
 
 func (m *JoinNetworkRequest) GetTaskID() *TaskID {
 	if m != nil {
@@ -58,7 +58,7 @@ type TaskListRequest struct {
 func (m *TaskListRequest) Reset()                    { *m = TaskListRequest{} }
 func (m *TaskListRequest) String() string            { return proto.CompactTextString(m) }
 func (*TaskListRequest) ProtoMessage()               {}
-func (*TaskListRequest) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{1} }
+func (*TaskListRequest) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{1} }
 
 func (m *TaskListRequest) GetHubID() string {
 	if m != nil {
@@ -75,7 +75,7 @@ type DealListRequest struct {
 func (m *DealListRequest) Reset()                    { *m = DealListRequest{} }
 func (m *DealListRequest) String() string            { return proto.CompactTextString(m) }
 func (*DealListRequest) ProtoMessage()               {}
-func (*DealListRequest) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{2} }
+func (*DealListRequest) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{2} }
 
 func (m *DealListRequest) GetOwner() string {
 	if m != nil {
@@ -93,12 +93,15 @@ func (m *DealListRequest) GetStatus() DealStatus {
 
 type DealListReply struct {
 	Deal []*Deal `protobuf:"bytes,1,rep,name=deal" json:"deal,omitempty"`
+	// Groups contains deals opened together for BID orders with
+	// multiple slots.
+	Groups []*DealGroup `protobuf:"bytes,2,rep,name=groups" json:"groups,omitempty"`
 }
 
 func (m *DealListReply) Reset()                    { *m = DealListReply{} }
 func (m *DealListReply) String() string            { return proto.CompactTextString(m) }
 func (*DealListReply) ProtoMessage()               {}
-func (*DealListReply) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{3} }
+func (*DealListReply) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{3} }
 
 func (m *DealListReply) GetDeal() []*Deal {
 	if m != nil {
@@ -107,15 +110,49 @@ func (m *DealListReply) GetDeal() []*Deal {
 	return nil
 }
 
+func (m *DealListReply) GetGroups() []*DealGroup {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+// DealGroup binds deals opened for the same BID order.
+type DealGroup struct {
+	BidID   string   `protobuf:"bytes,1,opt,name=bidID" json:"bidID,omitempty"`
+	DealIDs []string `protobuf:"bytes,2,rep,name=dealIDs" json:"dealIDs,omitempty"`
+}
+
+func (m *DealGroup) Reset()                    { *m = DealGroup{} }
+func (m *DealGroup) String() string            { return proto.CompactTextString(m) }
+func (*DealGroup) ProtoMessage()               {}
+func (*DealGroup) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{4} }
+
+func (m *DealGroup) GetBidID() string {
+	if m != nil {
+		return m.BidID
+	}
+	return ""
+}
+
+func (m *DealGroup) GetDealIDs() []string {
+	if m != nil {
+		return m.DealIDs
+	}
+	return nil
+}
+
 type DealStatusReply struct {
-	Deal *Deal          `protobuf:"bytes,1,opt,name=deal" json:"deal,omitempty"`
-	Info *DealInfoReply `protobuf:"bytes,2,opt,name=info" json:"info,omitempty"`
+	Deal       *Deal           `protobuf:"bytes,1,opt,name=deal" json:"deal,omitempty"`
+	Info       *DealInfoReply  `protobuf:"bytes,2,opt,name=info" json:"info,omitempty"`
+	Settlement *DealSettlement `protobuf:"bytes,3,opt,name=settlement" json:"settlement,omitempty"`
+	AutoRenew  bool            `protobuf:"varint,4,opt,name=autoRenew" json:"autoRenew,omitempty"`
 }
 
 func (m *DealStatusReply) Reset()                    { *m = DealStatusReply{} }
 func (m *DealStatusReply) String() string            { return proto.CompactTextString(m) }
 func (*DealStatusReply) ProtoMessage()               {}
-func (*DealStatusReply) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{4} }
+func (*DealStatusReply) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{5} }
 
 func (m *DealStatusReply) GetDeal() *Deal {
 	if m != nil {
@@ -131,12 +168,305 @@ func (m *DealStatusReply) GetInfo() *DealInfoReply {
 	return nil
 }
 
+func (m *DealStatusReply) GetSettlement() *DealSettlement {
+	if m != nil {
+		return m.Settlement
+	}
+	return nil
+}
+
+func (m *DealStatusReply) GetAutoRenew() bool {
+	if m != nil {
+		return m.AutoRenew
+	}
+	return false
+}
+
+// ResourceClass groups orders having the same resources.
+type ResourceClass struct {
+	CpuCores uint64   `protobuf:"varint,1,opt,name=cpuCores" json:"cpuCores,omitempty"`
+	RamBytes uint64   `protobuf:"varint,2,opt,name=ramBytes" json:"ramBytes,omitempty"`
+	GpuCount GPUCount `protobuf:"varint,3,opt,name=gpuCount,enum=sonm.GPUCount" json:"gpuCount,omitempty"`
+}
+
+func (m *ResourceClass) Reset()                    { *m = ResourceClass{} }
+func (m *ResourceClass) String() string            { return proto.CompactTextString(m) }
+func (*ResourceClass) ProtoMessage()               {}
+func (*ResourceClass) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{6} }
+
+func (m *ResourceClass) GetCpuCores() uint64 {
+	if m != nil {
+		return m.CpuCores
+	}
+	return 0
+}
+
+func (m *ResourceClass) GetRamBytes() uint64 {
+	if m != nil {
+		return m.RamBytes
+	}
+	return 0
+}
+
+func (m *ResourceClass) GetGpuCount() GPUCount {
+	if m != nil {
+		return m.GpuCount
+	}
+	return GPUCount_NO_GPU
+}
+
+type MarketStatsRequest struct {
+	// OrderType specifies orders to analyze, ASK if not set.
+	OrderType OrderType `protobuf:"varint,1,opt,name=orderType,enum=sonm.OrderType" json:"orderType,omitempty"`
+	// Resources filters resource classes providing at least the given
+	// resources, zero values match any.
+	Resources *Resources `protobuf:"bytes,2,opt,name=resources" json:"resources,omitempty"`
+	// From and To limit the time range, the last day by default.
+	From *Timestamp `protobuf:"bytes,3,opt,name=from" json:"from,omitempty"`
+	To   *Timestamp `protobuf:"bytes,4,opt,name=to" json:"to,omitempty"`
+	// Step is the time series resolution in seconds, one hour by default.
+	Step uint64 `protobuf:"varint,5,opt,name=step" json:"step,omitempty"`
+}
+
+func (m *MarketStatsRequest) Reset()                    { *m = MarketStatsRequest{} }
+func (m *MarketStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*MarketStatsRequest) ProtoMessage()               {}
+func (*MarketStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{7} }
+
+func (m *MarketStatsRequest) GetOrderType() OrderType {
+	if m != nil {
+		return m.OrderType
+	}
+	return OrderType_ANY
+}
+
+func (m *MarketStatsRequest) GetResources() *Resources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *MarketStatsRequest) GetFrom() *Timestamp {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *MarketStatsRequest) GetTo() *Timestamp {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *MarketStatsRequest) GetStep() uint64 {
+	if m != nil {
+		return m.Step
+	}
+	return 0
+}
+
+// PriceStats describes prices per second of orders of the same resource
+// class.
+type PriceStats struct {
+	Class *ResourceClass `protobuf:"bytes,1,opt,name=class" json:"class,omitempty"`
+	// Count is how many orders have been observed.
+	Count uint64  `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+	Min   *BigInt `protobuf:"bytes,3,opt,name=min" json:"min,omitempty"`
+	P50   *BigInt `protobuf:"bytes,4,opt,name=p50" json:"p50,omitempty"`
+	P90   *BigInt `protobuf:"bytes,5,opt,name=p90" json:"p90,omitempty"`
+	Max   *BigInt `protobuf:"bytes,6,opt,name=max" json:"max,omitempty"`
+}
+
+func (m *PriceStats) Reset()                    { *m = PriceStats{} }
+func (m *PriceStats) String() string            { return proto.CompactTextString(m) }
+func (*PriceStats) ProtoMessage()               {}
+func (*PriceStats) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{8} }
+
+func (m *PriceStats) GetClass() *ResourceClass {
+	if m != nil {
+		return m.Class
+	}
+	return nil
+}
+
+func (m *PriceStats) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *PriceStats) GetMin() *BigInt {
+	if m != nil {
+		return m.Min
+	}
+	return nil
+}
+
+func (m *PriceStats) GetP50() *BigInt {
+	if m != nil {
+		return m.P50
+	}
+	return nil
+}
+
+func (m *PriceStats) GetP90() *BigInt {
+	if m != nil {
+		return m.P90
+	}
+	return nil
+}
+
+func (m *PriceStats) GetMax() *BigInt {
+	if m != nil {
+		return m.Max
+	}
+	return nil
+}
+
+type PriceHistoryPoint struct {
+	Timestamp *Timestamp    `protobuf:"bytes,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	Stats     []*PriceStats `protobuf:"bytes,2,rep,name=stats" json:"stats,omitempty"`
+}
+
+func (m *PriceHistoryPoint) Reset()                    { *m = PriceHistoryPoint{} }
+func (m *PriceHistoryPoint) String() string            { return proto.CompactTextString(m) }
+func (*PriceHistoryPoint) ProtoMessage()               {}
+func (*PriceHistoryPoint) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{9} }
+
+func (m *PriceHistoryPoint) GetTimestamp() *Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *PriceHistoryPoint) GetStats() []*PriceStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type PriceHistoryReply struct {
+	Points []*PriceHistoryPoint `protobuf:"bytes,1,rep,name=points" json:"points,omitempty"`
+}
+
+func (m *PriceHistoryReply) Reset()                    { *m = PriceHistoryReply{} }
+func (m *PriceHistoryReply) String() string            { return proto.CompactTextString(m) }
+func (*PriceHistoryReply) ProtoMessage()               {}
+func (*PriceHistoryReply) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{10} }
+
+func (m *PriceHistoryReply) GetPoints() []*PriceHistoryPoint {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+type SupplyDepthLevel struct {
+	PricePerSecond *BigInt `protobuf:"bytes,1,opt,name=pricePerSecond" json:"pricePerSecond,omitempty"`
+	// Count is how many orders are available at this price or better.
+	Count uint64 `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *SupplyDepthLevel) Reset()                    { *m = SupplyDepthLevel{} }
+func (m *SupplyDepthLevel) String() string            { return proto.CompactTextString(m) }
+func (*SupplyDepthLevel) ProtoMessage()               {}
+func (*SupplyDepthLevel) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{11} }
+
+func (m *SupplyDepthLevel) GetPricePerSecond() *BigInt {
+	if m != nil {
+		return m.PricePerSecond
+	}
+	return nil
+}
+
+func (m *SupplyDepthLevel) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type SupplyDepth struct {
+	Class *ResourceClass `protobuf:"bytes,1,opt,name=class" json:"class,omitempty"`
+	// Owners is how many distinct suppliers of ASKs or buyers of BIDs
+	// have placed orders.
+	Owners uint64 `protobuf:"varint,2,opt,name=owners" json:"owners,omitempty"`
+	// Levels are sorted from the best price.
+	Levels []*SupplyDepthLevel `protobuf:"bytes,3,rep,name=levels" json:"levels,omitempty"`
+}
+
+func (m *SupplyDepth) Reset()                    { *m = SupplyDepth{} }
+func (m *SupplyDepth) String() string            { return proto.CompactTextString(m) }
+func (*SupplyDepth) ProtoMessage()               {}
+func (*SupplyDepth) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{12} }
+
+func (m *SupplyDepth) GetClass() *ResourceClass {
+	if m != nil {
+		return m.Class
+	}
+	return nil
+}
+
+func (m *SupplyDepth) GetOwners() uint64 {
+	if m != nil {
+		return m.Owners
+	}
+	return 0
+}
+
+func (m *SupplyDepth) GetLevels() []*SupplyDepthLevel {
+	if m != nil {
+		return m.Levels
+	}
+	return nil
+}
+
+type SupplyDepthReply struct {
+	// Timestamp is the time the snapshot has been taken at.
+	Timestamp *Timestamp     `protobuf:"bytes,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	Depth     []*SupplyDepth `protobuf:"bytes,2,rep,name=depth" json:"depth,omitempty"`
+}
+
+func (m *SupplyDepthReply) Reset()                    { *m = SupplyDepthReply{} }
+func (m *SupplyDepthReply) String() string            { return proto.CompactTextString(m) }
+func (*SupplyDepthReply) ProtoMessage()               {}
+func (*SupplyDepthReply) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{13} }
+
+func (m *SupplyDepthReply) GetTimestamp() *Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *SupplyDepthReply) GetDepth() []*SupplyDepth {
+	if m != nil {
+		return m.Depth
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*JoinNetworkRequest)(nil), "sonm.JoinNetworkRequest")
 	proto.RegisterType((*TaskListRequest)(nil), "sonm.TaskListRequest")
 	proto.RegisterType((*DealListRequest)(nil), "sonm.DealListRequest")
 	proto.RegisterType((*DealListReply)(nil), "sonm.DealListReply")
+	proto.RegisterType((*DealGroup)(nil), "sonm.DealGroup")
 	proto.RegisterType((*DealStatusReply)(nil), "sonm.DealStatusReply")
+	proto.RegisterType((*ResourceClass)(nil), "sonm.ResourceClass")
+	proto.RegisterType((*MarketStatsRequest)(nil), "sonm.MarketStatsRequest")
+	proto.RegisterType((*PriceStats)(nil), "sonm.PriceStats")
+	proto.RegisterType((*PriceHistoryPoint)(nil), "sonm.PriceHistoryPoint")
+	proto.RegisterType((*PriceHistoryReply)(nil), "sonm.PriceHistoryReply")
+	proto.RegisterType((*SupplyDepthLevel)(nil), "sonm.SupplyDepthLevel")
+	proto.RegisterType((*SupplyDepth)(nil), "sonm.SupplyDepth")
+	proto.RegisterType((*SupplyDepthReply)(nil), "sonm.SupplyDepthReply")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -166,6 +496,18 @@ type TaskManagementClient interface {
 	Stop(ctx context.Context, in *TaskID, opts ...grpc.CallOption) (*Empty, error)
 	// PullTask pulls task image back
 	PullTask(ctx context.Context, in *PullTaskRequest, opts ...grpc.CallOption) (TaskManagement_PullTaskClient, error)
+	// Exec executes a command inside the task container
+	Exec(ctx context.Context, opts ...grpc.CallOption) (TaskManagement_ExecClient, error)
+	// PortForward proxies a TCP connection to the task port. The task ID,
+	// the port and the Hub address are passed via "task", "port" and "hub"
+	// metadata keys
+	PortForward(ctx context.Context, opts ...grpc.CallOption) (TaskManagement_PortForwardClient, error)
+	// CopyTo extracts the tar archive streamed into the task container
+	// path. The task ID, the path and the Hub address are passed via "task",
+	// "path" and "hub" metadata keys
+	CopyTo(ctx context.Context, opts ...grpc.CallOption) (TaskManagement_CopyToClient, error)
+	// CopyFrom streams a tar archive of the task container path
+	CopyFrom(ctx context.Context, in *CopyFromRequest, opts ...grpc.CallOption) (TaskManagement_CopyFromClient, error)
 }
 
 type taskManagementClient struct {
@@ -316,6 +658,131 @@ func (x *taskManagementPullTaskClient) Recv() (*Chunk, error) {
 	return m, nil
 }
 
+func (c *taskManagementClient) Exec(ctx context.Context, opts ...grpc.CallOption) (TaskManagement_ExecClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_TaskManagement_serviceDesc.Streams[3], c.cc, "/sonm.TaskManagement/Exec", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taskManagementExecClient{stream}
+	return x, nil
+}
+
+type TaskManagement_ExecClient interface {
+	Send(*ExecRequest) error
+	Recv() (*ExecReply, error)
+	grpc.ClientStream
+}
+
+type taskManagementExecClient struct {
+	grpc.ClientStream
+}
+
+func (x *taskManagementExecClient) Send(m *ExecRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *taskManagementExecClient) Recv() (*ExecReply, error) {
+	m := new(ExecReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taskManagementClient) PortForward(ctx context.Context, opts ...grpc.CallOption) (TaskManagement_PortForwardClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_TaskManagement_serviceDesc.Streams[4], c.cc, "/sonm.TaskManagement/PortForward", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taskManagementPortForwardClient{stream}
+	return x, nil
+}
+
+type TaskManagement_PortForwardClient interface {
+	Send(*Chunk) error
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type taskManagementPortForwardClient struct {
+	grpc.ClientStream
+}
+
+func (x *taskManagementPortForwardClient) Send(m *Chunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *taskManagementPortForwardClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taskManagementClient) CopyTo(ctx context.Context, opts ...grpc.CallOption) (TaskManagement_CopyToClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_TaskManagement_serviceDesc.Streams[5], c.cc, "/sonm.TaskManagement/CopyTo", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taskManagementCopyToClient{stream}
+	return x, nil
+}
+
+type TaskManagement_CopyToClient interface {
+	Send(*Chunk) error
+	Recv() (*Progress, error)
+	grpc.ClientStream
+}
+
+type taskManagementCopyToClient struct {
+	grpc.ClientStream
+}
+
+func (x *taskManagementCopyToClient) Send(m *Chunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *taskManagementCopyToClient) Recv() (*Progress, error) {
+	m := new(Progress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taskManagementClient) CopyFrom(ctx context.Context, in *CopyFromRequest, opts ...grpc.CallOption) (TaskManagement_CopyFromClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_TaskManagement_serviceDesc.Streams[6], c.cc, "/sonm.TaskManagement/CopyFrom", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taskManagementCopyFromClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TaskManagement_CopyFromClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type taskManagementCopyFromClient struct {
+	grpc.ClientStream
+}
+
+func (x *taskManagementCopyFromClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for TaskManagement service
 
 type TaskManagementServer interface {
@@ -335,6 +802,18 @@ type TaskManagementServer interface {
 	Stop(context.Context, *TaskID) (*Empty, error)
 	// PullTask pulls task image back
 	PullTask(*PullTaskRequest, TaskManagement_PullTaskServer) error
+	// Exec executes a command inside the task container
+	Exec(TaskManagement_ExecServer) error
+	// PortForward proxies a TCP connection to the task port. The task ID,
+	// the port and the Hub address are passed via "task", "port" and "hub"
+	// metadata keys
+	PortForward(TaskManagement_PortForwardServer) error
+	// CopyTo extracts the tar archive streamed into the task container
+	// path. The task ID, the path and the Hub address are passed via "task",
+	// "path" and "hub" metadata keys
+	CopyTo(TaskManagement_CopyToServer) error
+	// CopyFrom streams a tar archive of the task container path
+	CopyFrom(*CopyFromRequest, TaskManagement_CopyFromServer) error
 }
 
 func RegisterTaskManagementServer(s *grpc.Server, srv TaskManagementServer) {
@@ -499,6 +978,105 @@ func (x *taskManagementPullTaskServer) Send(m *Chunk) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TaskManagement_Exec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TaskManagementServer).Exec(&taskManagementExecServer{stream})
+}
+
+type TaskManagement_ExecServer interface {
+	Send(*ExecReply) error
+	Recv() (*ExecRequest, error)
+	grpc.ServerStream
+}
+
+type taskManagementExecServer struct {
+	grpc.ServerStream
+}
+
+func (x *taskManagementExecServer) Send(m *ExecReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *taskManagementExecServer) Recv() (*ExecRequest, error) {
+	m := new(ExecRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _TaskManagement_PortForward_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TaskManagementServer).PortForward(&taskManagementPortForwardServer{stream})
+}
+
+type TaskManagement_PortForwardServer interface {
+	Send(*Chunk) error
+	Recv() (*Chunk, error)
+	grpc.ServerStream
+}
+
+type taskManagementPortForwardServer struct {
+	grpc.ServerStream
+}
+
+func (x *taskManagementPortForwardServer) Send(m *Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *taskManagementPortForwardServer) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _TaskManagement_CopyTo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TaskManagementServer).CopyTo(&taskManagementCopyToServer{stream})
+}
+
+type TaskManagement_CopyToServer interface {
+	Send(*Progress) error
+	Recv() (*Chunk, error)
+	grpc.ServerStream
+}
+
+type taskManagementCopyToServer struct {
+	grpc.ServerStream
+}
+
+func (x *taskManagementCopyToServer) Send(m *Progress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *taskManagementCopyToServer) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _TaskManagement_CopyFrom_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CopyFromRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaskManagementServer).CopyFrom(m, &taskManagementCopyFromServer{stream})
+}
+
+type TaskManagement_CopyFromServer interface {
+	Send(*Chunk) error
+	grpc.ServerStream
+}
+
+type taskManagementCopyFromServer struct {
+	grpc.ServerStream
+}
+
+func (x *taskManagementCopyFromServer) Send(m *Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _TaskManagement_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "sonm.TaskManagement",
 	HandlerType: (*TaskManagementServer)(nil),
@@ -541,6 +1119,29 @@ var _TaskManagement_serviceDesc = grpc.ServiceDesc{
 			Handler:       _TaskManagement_PullTask_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Exec",
+			Handler:       _TaskManagement_Exec_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PortForward",
+			Handler:       _TaskManagement_PortForward_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "CopyTo",
+			Handler:       _TaskManagement_CopyTo_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "CopyFrom",
+			Handler:       _TaskManagement_CopyFrom_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "node.proto",
 }
@@ -554,6 +1155,15 @@ type DealManagementClient interface {
 	Status(ctx context.Context, in *ID, opts ...grpc.CallOption) (*DealStatusReply, error)
 	// Finish finishes a deal with given ID
 	Finish(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Empty, error)
+	// Close requests early termination of a deal with given ID,
+	// returning the proposed payment settlement
+	Close(ctx context.Context, in *DealCloseRequest, opts ...grpc.CallOption) (*DealSettlement, error)
+	// Extend opens a continuation deal for a deal with given ID,
+	// so running tasks are kept after its end time
+	Extend(ctx context.Context, in *DealExtendRequest, opts ...grpc.CallOption) (*ID, error)
+	// AutoRenew marks a deal with given ID to be extended automatically
+	// while balance and allowance permit
+	AutoRenew(ctx context.Context, in *DealAutoRenewRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type dealManagementClient struct {
@@ -564,27 +1174,54 @@ func NewDealManagementClient(cc *grpc.ClientConn) DealManagementClient {
 	return &dealManagementClient{cc}
 }
 
-func (c *dealManagementClient) List(ctx context.Context, in *DealListRequest, opts ...grpc.CallOption) (*DealListReply, error) {
-	out := new(DealListReply)
-	err := grpc.Invoke(ctx, "/sonm.DealManagement/List", in, out, c.cc, opts...)
+func (c *dealManagementClient) List(ctx context.Context, in *DealListRequest, opts ...grpc.CallOption) (*DealListReply, error) {
+	out := new(DealListReply)
+	err := grpc.Invoke(ctx, "/sonm.DealManagement/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dealManagementClient) Status(ctx context.Context, in *ID, opts ...grpc.CallOption) (*DealStatusReply, error) {
+	out := new(DealStatusReply)
+	err := grpc.Invoke(ctx, "/sonm.DealManagement/Status", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dealManagementClient) Finish(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/sonm.DealManagement/Finish", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dealManagementClient) Close(ctx context.Context, in *DealCloseRequest, opts ...grpc.CallOption) (*DealSettlement, error) {
+	out := new(DealSettlement)
+	err := grpc.Invoke(ctx, "/sonm.DealManagement/Close", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dealManagementClient) Status(ctx context.Context, in *ID, opts ...grpc.CallOption) (*DealStatusReply, error) {
-	out := new(DealStatusReply)
-	err := grpc.Invoke(ctx, "/sonm.DealManagement/Status", in, out, c.cc, opts...)
+func (c *dealManagementClient) Extend(ctx context.Context, in *DealExtendRequest, opts ...grpc.CallOption) (*ID, error) {
+	out := new(ID)
+	err := grpc.Invoke(ctx, "/sonm.DealManagement/Extend", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dealManagementClient) Finish(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Empty, error) {
+func (c *dealManagementClient) AutoRenew(ctx context.Context, in *DealAutoRenewRequest, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
-	err := grpc.Invoke(ctx, "/sonm.DealManagement/Finish", in, out, c.cc, opts...)
+	err := grpc.Invoke(ctx, "/sonm.DealManagement/AutoRenew", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -600,6 +1237,15 @@ type DealManagementServer interface {
 	Status(context.Context, *ID) (*DealStatusReply, error)
 	// Finish finishes a deal with given ID
 	Finish(context.Context, *ID) (*Empty, error)
+	// Close requests early termination of a deal with given ID,
+	// returning the proposed payment settlement
+	Close(context.Context, *DealCloseRequest) (*DealSettlement, error)
+	// Extend opens a continuation deal for a deal with given ID,
+	// so running tasks are kept after its end time
+	Extend(context.Context, *DealExtendRequest) (*ID, error)
+	// AutoRenew marks a deal with given ID to be extended automatically
+	// while balance and allowance permit
+	AutoRenew(context.Context, *DealAutoRenewRequest) (*Empty, error)
 }
 
 func RegisterDealManagementServer(s *grpc.Server, srv DealManagementServer) {
@@ -660,6 +1306,60 @@ func _DealManagement_Finish_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DealManagement_Close_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DealCloseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DealManagementServer).Close(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.DealManagement/Close",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DealManagementServer).Close(ctx, req.(*DealCloseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DealManagement_Extend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DealExtendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DealManagementServer).Extend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.DealManagement/Extend",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DealManagementServer).Extend(ctx, req.(*DealExtendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DealManagement_AutoRenew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DealAutoRenewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DealManagementServer).AutoRenew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.DealManagement/AutoRenew",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DealManagementServer).AutoRenew(ctx, req.(*DealAutoRenewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DealManagement_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "sonm.DealManagement",
 	HandlerType: (*DealManagementServer)(nil),
@@ -676,6 +1376,18 @@ var _DealManagement_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Finish",
 			Handler:    _DealManagement_Finish_Handler,
 		},
+		{
+			MethodName: "Close",
+			Handler:    _DealManagement_Close_Handler,
+		},
+		{
+			MethodName: "Extend",
+			Handler:    _DealManagement_Extend_Handler,
+		},
+		{
+			MethodName: "AutoRenew",
+			Handler:    _DealManagement_AutoRenew_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "node.proto",
@@ -720,6 +1432,8 @@ type HubManagementClient interface {
 	TaskList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TaskListReply, error)
 	// Status produces a detailed info about task on the Hub
 	TaskStatus(ctx context.Context, in *ID, opts ...grpc.CallOption) (*TaskStatusReply, error)
+	// Routes produces the routing table of task services exposed by the Hub
+	Routes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RoutesReply, error)
 }
 
 type hubManagementClient struct {
@@ -856,6 +1570,15 @@ func (c *hubManagementClient) TaskStatus(ctx context.Context, in *ID, opts ...gr
 	return out, nil
 }
 
+func (c *hubManagementClient) Routes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RoutesReply, error) {
+	out := new(RoutesReply)
+	err := grpc.Invoke(ctx, "/sonm.HubManagement/Routes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for HubManagement service
 
 type HubManagementServer interface {
@@ -895,6 +1618,8 @@ type HubManagementServer interface {
 	TaskList(context.Context, *Empty) (*TaskListReply, error)
 	// Status produces a detailed info about task on the Hub
 	TaskStatus(context.Context, *ID) (*TaskStatusReply, error)
+	// Routes produces the routing table of task services exposed by the Hub
+	Routes(context.Context, *Empty) (*RoutesReply, error)
 }
 
 func RegisterHubManagementServer(s *grpc.Server, srv HubManagementServer) {
@@ -1153,6 +1878,24 @@ func _HubManagement_TaskStatus_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HubManagement_Routes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubManagementServer).Routes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.HubManagement/Routes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubManagementServer).Routes(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _HubManagement_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "sonm.HubManagement",
 	HandlerType: (*HubManagementServer)(nil),
@@ -1213,6 +1956,115 @@ var _HubManagement_serviceDesc = grpc.ServiceDesc{
 			MethodName: "TaskStatus",
 			Handler:    _HubManagement_TaskStatus_Handler,
 		},
+		{
+			MethodName: "Routes",
+			Handler:    _HubManagement_Routes_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "node.proto",
+}
+
+// Client API for MarketAnalytics service
+
+type MarketAnalyticsClient interface {
+	// PriceHistory produces order price percentiles per resource class
+	// over time
+	PriceHistory(ctx context.Context, in *MarketStatsRequest, opts ...grpc.CallOption) (*PriceHistoryReply, error)
+	// SupplyDepth produces how many orders are available per resource
+	// class at each price level according to the latest snapshot
+	SupplyDepth(ctx context.Context, in *MarketStatsRequest, opts ...grpc.CallOption) (*SupplyDepthReply, error)
+}
+
+type marketAnalyticsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMarketAnalyticsClient(cc *grpc.ClientConn) MarketAnalyticsClient {
+	return &marketAnalyticsClient{cc}
+}
+
+func (c *marketAnalyticsClient) PriceHistory(ctx context.Context, in *MarketStatsRequest, opts ...grpc.CallOption) (*PriceHistoryReply, error) {
+	out := new(PriceHistoryReply)
+	err := grpc.Invoke(ctx, "/sonm.MarketAnalytics/PriceHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketAnalyticsClient) SupplyDepth(ctx context.Context, in *MarketStatsRequest, opts ...grpc.CallOption) (*SupplyDepthReply, error) {
+	out := new(SupplyDepthReply)
+	err := grpc.Invoke(ctx, "/sonm.MarketAnalytics/SupplyDepth", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for MarketAnalytics service
+
+type MarketAnalyticsServer interface {
+	// PriceHistory produces order price percentiles per resource class
+	// over time
+	PriceHistory(context.Context, *MarketStatsRequest) (*PriceHistoryReply, error)
+	// SupplyDepth produces how many orders are available per resource
+	// class at each price level according to the latest snapshot
+	SupplyDepth(context.Context, *MarketStatsRequest) (*SupplyDepthReply, error)
+}
+
+func RegisterMarketAnalyticsServer(s *grpc.Server, srv MarketAnalyticsServer) {
+	s.RegisterService(&_MarketAnalytics_serviceDesc, srv)
+}
+
+func _MarketAnalytics_PriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarketStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketAnalyticsServer).PriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.MarketAnalytics/PriceHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketAnalyticsServer).PriceHistory(ctx, req.(*MarketStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketAnalytics_SupplyDepth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarketStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketAnalyticsServer).SupplyDepth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.MarketAnalytics/SupplyDepth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketAnalyticsServer).SupplyDepth(ctx, req.(*MarketStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MarketAnalytics_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sonm.MarketAnalytics",
+	HandlerType: (*MarketAnalyticsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PriceHistory",
+			Handler:    _MarketAnalytics_PriceHistory_Handler,
+		},
+		{
+			MethodName: "SupplyDepth",
+			Handler:    _MarketAnalytics_SupplyDepth_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "node.proto",
@@ -1379,6 +2231,82 @@ var _TaskManagement_PullTaskCmd_gen = &cobra.Command{
 	RunE:  grpccmd.TypeToJson("sonm.PullTaskRequest"),
 }
 
+var _TaskManagement_ExecCmd = &cobra.Command{
+	Use:   "exec",
+	Short: "Make the Exec method call, input-type: sonm.ExecRequest output-type: sonm.ExecReply",
+	RunE: grpccmd.RunE(
+		"Exec",
+		"sonm.ExecRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewTaskManagementClient(cc)
+		},
+	),
+}
+
+var _TaskManagement_ExecCmd_gen = &cobra.Command{
+	Use:   "exec-gen",
+	Short: "Generate JSON for method call of Exec (input-type: sonm.ExecRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.ExecRequest"),
+}
+
+var _TaskManagement_PortForwardCmd = &cobra.Command{
+	Use:   "portForward",
+	Short: "Make the PortForward method call, input-type: sonm.Chunk output-type: sonm.Chunk",
+	RunE: grpccmd.RunE(
+		"PortForward",
+		"sonm.Chunk",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewTaskManagementClient(cc)
+		},
+	),
+}
+
+var _TaskManagement_PortForwardCmd_gen = &cobra.Command{
+	Use:   "portForward-gen",
+	Short: "Generate JSON for method call of PortForward (input-type: sonm.Chunk)",
+	RunE:  grpccmd.TypeToJson("sonm.Chunk"),
+}
+
+var _TaskManagement_CopyToCmd = &cobra.Command{
+	Use:   "copyTo",
+	Short: "Make the CopyTo method call, input-type: sonm.Chunk output-type: sonm.Progress",
+	RunE: grpccmd.RunE(
+		"CopyTo",
+		"sonm.Chunk",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewTaskManagementClient(cc)
+		},
+	),
+}
+
+var _TaskManagement_CopyToCmd_gen = &cobra.Command{
+	Use:   "copyTo-gen",
+	Short: "Generate JSON for method call of CopyTo (input-type: sonm.Chunk)",
+	RunE:  grpccmd.TypeToJson("sonm.Chunk"),
+}
+
+var _TaskManagement_CopyFromCmd = &cobra.Command{
+	Use:   "copyFrom",
+	Short: "Make the CopyFrom method call, input-type: sonm.CopyFromRequest output-type: sonm.Chunk",
+	RunE: grpccmd.RunE(
+		"CopyFrom",
+		"sonm.CopyFromRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewTaskManagementClient(cc)
+		},
+	),
+}
+
+var _TaskManagement_CopyFromCmd_gen = &cobra.Command{
+	Use:   "copyFrom-gen",
+	Short: "Generate JSON for method call of CopyFrom (input-type: sonm.CopyFromRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.CopyFromRequest"),
+}
+
 // Register commands with the root command and service command
 func init() {
 	grpccmd.RegisterServiceCmd(_TaskManagementCmd)
@@ -1399,6 +2327,14 @@ func init() {
 		_TaskManagement_StopCmd_gen,
 		_TaskManagement_PullTaskCmd,
 		_TaskManagement_PullTaskCmd_gen,
+		_TaskManagement_ExecCmd,
+		_TaskManagement_ExecCmd_gen,
+		_TaskManagement_PortForwardCmd,
+		_TaskManagement_PortForwardCmd_gen,
+		_TaskManagement_CopyToCmd,
+		_TaskManagement_CopyToCmd_gen,
+		_TaskManagement_CopyFromCmd,
+		_TaskManagement_CopyFromCmd_gen,
 	)
 }
 
@@ -1465,6 +2401,63 @@ var _DealManagement_FinishCmd_gen = &cobra.Command{
 	RunE:  grpccmd.TypeToJson("sonm.ID"),
 }
 
+var _DealManagement_CloseCmd = &cobra.Command{
+	Use:   "close",
+	Short: "Make the Close method call, input-type: sonm.DealCloseRequest output-type: sonm.DealSettlement",
+	RunE: grpccmd.RunE(
+		"Close",
+		"sonm.DealCloseRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewDealManagementClient(cc)
+		},
+	),
+}
+
+var _DealManagement_CloseCmd_gen = &cobra.Command{
+	Use:   "close-gen",
+	Short: "Generate JSON for method call of Close (input-type: sonm.DealCloseRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.DealCloseRequest"),
+}
+
+var _DealManagement_ExtendCmd = &cobra.Command{
+	Use:   "extend",
+	Short: "Make the Extend method call, input-type: sonm.DealExtendRequest output-type: sonm.ID",
+	RunE: grpccmd.RunE(
+		"Extend",
+		"sonm.DealExtendRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewDealManagementClient(cc)
+		},
+	),
+}
+
+var _DealManagement_ExtendCmd_gen = &cobra.Command{
+	Use:   "extend-gen",
+	Short: "Generate JSON for method call of Extend (input-type: sonm.DealExtendRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.DealExtendRequest"),
+}
+
+var _DealManagement_AutoRenewCmd = &cobra.Command{
+	Use:   "autoRenew",
+	Short: "Make the AutoRenew method call, input-type: sonm.DealAutoRenewRequest output-type: sonm.Empty",
+	RunE: grpccmd.RunE(
+		"AutoRenew",
+		"sonm.DealAutoRenewRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewDealManagementClient(cc)
+		},
+	),
+}
+
+var _DealManagement_AutoRenewCmd_gen = &cobra.Command{
+	Use:   "autoRenew-gen",
+	Short: "Generate JSON for method call of AutoRenew (input-type: sonm.DealAutoRenewRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.DealAutoRenewRequest"),
+}
+
 // Register commands with the root command and service command
 func init() {
 	grpccmd.RegisterServiceCmd(_DealManagementCmd)
@@ -1475,6 +2468,12 @@ func init() {
 		_DealManagement_StatusCmd_gen,
 		_DealManagement_FinishCmd,
 		_DealManagement_FinishCmd_gen,
+		_DealManagement_CloseCmd,
+		_DealManagement_CloseCmd_gen,
+		_DealManagement_ExtendCmd,
+		_DealManagement_ExtendCmd_gen,
+		_DealManagement_AutoRenewCmd,
+		_DealManagement_AutoRenewCmd_gen,
 	)
 }
 
@@ -1750,6 +2749,25 @@ var _HubManagement_TaskStatusCmd_gen = &cobra.Command{
 	RunE:  grpccmd.TypeToJson("sonm.ID"),
 }
 
+var _HubManagement_RoutesCmd = &cobra.Command{
+	Use:   "routes",
+	Short: "Make the Routes method call, input-type: sonm.Empty output-type: sonm.RoutesReply",
+	RunE: grpccmd.RunE(
+		"Routes",
+		"sonm.Empty",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewHubManagementClient(cc)
+		},
+	),
+}
+
+var _HubManagement_RoutesCmd_gen = &cobra.Command{
+	Use:   "routes-gen",
+	Short: "Generate JSON for method call of Routes (input-type: sonm.Empty)",
+	RunE:  grpccmd.TypeToJson("sonm.Empty"),
+}
+
 // Register commands with the root command and service command
 func init() {
 	grpccmd.RegisterServiceCmd(_HubManagementCmd)
@@ -1782,58 +2800,155 @@ func init() {
 		_HubManagement_TaskListCmd_gen,
 		_HubManagement_TaskStatusCmd,
 		_HubManagement_TaskStatusCmd_gen,
+		_HubManagement_RoutesCmd,
+		_HubManagement_RoutesCmd_gen,
+	)
+}
+
+// MarketAnalytics
+var _MarketAnalyticsCmd = &cobra.Command{
+	Use:   "marketAnalytics [method]",
+	Short: "Subcommand for the MarketAnalytics service.",
+}
+
+var _MarketAnalytics_PriceHistoryCmd = &cobra.Command{
+	Use:   "priceHistory",
+	Short: "Make the PriceHistory method call, input-type: sonm.MarketStatsRequest output-type: sonm.PriceHistoryReply",
+	RunE: grpccmd.RunE(
+		"PriceHistory",
+		"sonm.MarketStatsRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewMarketAnalyticsClient(cc)
+		},
+	),
+}
+
+var _MarketAnalytics_PriceHistoryCmd_gen = &cobra.Command{
+	Use:   "priceHistory-gen",
+	Short: "Generate JSON for method call of PriceHistory (input-type: sonm.MarketStatsRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.MarketStatsRequest"),
+}
+
+var _MarketAnalytics_SupplyDepthCmd = &cobra.Command{
+	Use:   "supplyDepth",
+	Short: "Make the SupplyDepth method call, input-type: sonm.MarketStatsRequest output-type: sonm.SupplyDepthReply",
+	RunE: grpccmd.RunE(
+		"SupplyDepth",
+		"sonm.MarketStatsRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewMarketAnalyticsClient(cc)
+		},
+	),
+}
+
+var _MarketAnalytics_SupplyDepthCmd_gen = &cobra.Command{
+	Use:   "supplyDepth-gen",
+	Short: "Generate JSON for method call of SupplyDepth (input-type: sonm.MarketStatsRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.MarketStatsRequest"),
+}
+
+// Register commands with the root command and service command
+func init() {
+	grpccmd.RegisterServiceCmd(_MarketAnalyticsCmd)
+	_MarketAnalyticsCmd.AddCommand(
+		_MarketAnalytics_PriceHistoryCmd,
+		_MarketAnalytics_PriceHistoryCmd_gen,
+		_MarketAnalytics_SupplyDepthCmd,
+		_MarketAnalytics_SupplyDepthCmd_gen,
 	)
 }
 
 // End grpccmd
 
-func init() { proto.RegisterFile("node.proto", fileDescriptor12) }
-
-var fileDescriptor12 = []byte{
-	// 709 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xdd, 0x6e, 0xd3, 0x4c,
-	0x10, 0xb5, 0xbf, 0xa6, 0x51, 0x33, 0x6e, 0x92, 0x7e, 0x9b, 0xa2, 0x06, 0x0b, 0x95, 0x60, 0x90,
-	0x1a, 0x54, 0x35, 0xa9, 0x4c, 0xc5, 0x15, 0x5c, 0x54, 0x0d, 0xfd, 0x41, 0x05, 0x05, 0x1b, 0x09,
-	0xc4, 0x9d, 0xd3, 0x6e, 0x13, 0x2b, 0xce, 0xae, 0xd9, 0x5d, 0xb7, 0xea, 0x8b, 0xf0, 0x24, 0xbc,
-	0x13, 0xaf, 0x81, 0xd6, 0xeb, 0xff, 0x24, 0x12, 0x97, 0x73, 0xe6, 0xcc, 0x9c, 0x99, 0xb3, 0x13,
-	0x07, 0x80, 0xd0, 0x5b, 0x3c, 0x08, 0x19, 0x15, 0x14, 0xd5, 0x38, 0x25, 0x0b, 0x13, 0x6e, 0xb1,
-	0x17, 0x28, 0xc4, 0x6c, 0xfb, 0x44, 0x62, 0xc4, 0xf7, 0x12, 0xa0, 0x31, 0x8b, 0x26, 0x69, 0xee,
-	0x86, 0x12, 0xe1, 0xf9, 0x04, 0x33, 0x05, 0x58, 0xdf, 0x01, 0x7d, 0xa4, 0x3e, 0xf9, 0x8c, 0xc5,
-	0x03, 0x65, 0x73, 0x07, 0xff, 0x8c, 0x30, 0x17, 0xe8, 0x15, 0xd4, 0x85, 0xc7, 0xe7, 0x57, 0xa3,
-	0xae, 0xde, 0xd3, 0xfb, 0x86, 0xbd, 0x3d, 0x90, 0x1d, 0x07, 0x5f, 0x63, 0xcc, 0x49, 0x72, 0xe8,
-	0x19, 0x34, 0x92, 0xba, 0xab, 0x51, 0xf7, 0xbf, 0x9e, 0xde, 0x6f, 0x38, 0x39, 0x60, 0x1d, 0x40,
-	0x5b, 0xf2, 0xaf, 0x7d, 0x2e, 0xd2, 0xb6, 0xbb, 0xb0, 0x39, 0x8b, 0x26, 0x49, 0xd7, 0x86, 0xa3,
-	0x02, 0xeb, 0x0b, 0xb4, 0x47, 0xd8, 0x0b, 0x2a, 0x44, 0xfa, 0x40, 0x30, 0x4b, 0x89, 0x71, 0x80,
-	0xfa, 0x50, 0xe7, 0xc2, 0x13, 0x11, 0x8f, 0xc5, 0x5a, 0xf6, 0x8e, 0x9a, 0x4a, 0x16, 0xbb, 0x31,
-	0xee, 0x24, 0x79, 0x6b, 0x08, 0xcd, 0xbc, 0x65, 0x18, 0x3c, 0xa2, 0x7d, 0xa8, 0x49, 0x87, 0xba,
-	0x7a, 0x6f, 0xa3, 0x6f, 0xd8, 0x90, 0x17, 0x3a, 0x31, 0x6e, 0xfd, 0x50, 0x33, 0x24, 0x6d, 0x2a,
-	0x25, 0xfa, 0xaa, 0x12, 0x74, 0x00, 0x35, 0x9f, 0xdc, 0xd1, 0x78, 0x16, 0xc3, 0xee, 0xe4, 0xf9,
-	0x2b, 0x72, 0x47, 0xe3, 0x16, 0x4e, 0x4c, 0xb0, 0x7f, 0x6f, 0x40, 0x4b, 0x3a, 0xf1, 0xc9, 0x23,
-	0xde, 0x14, 0x2f, 0x30, 0x11, 0xe8, 0x04, 0x6a, 0x72, 0x36, 0xf4, 0x24, 0xf7, 0xb5, 0xb0, 0xbe,
-	0xd9, 0xa9, 0xc2, 0x61, 0xf0, 0x68, 0x69, 0xe8, 0x08, 0xb6, 0xc6, 0x11, 0x9f, 0x49, 0x18, 0x19,
-	0x8a, 0x72, 0x36, 0x8b, 0xc8, 0xdc, 0x6c, 0xa9, 0x60, 0xcc, 0xe8, 0x94, 0x61, 0xce, 0x2d, 0xad,
-	0xaf, 0x1f, 0xeb, 0xe8, 0x3d, 0x6c, 0xba, 0xc2, 0x63, 0x02, 0x3d, 0x55, 0xe9, 0xcb, 0x68, 0x12,
-	0xc7, 0xb2, 0x3e, 0x55, 0xda, 0x5b, 0x95, 0x52, 0x6a, 0xef, 0xc0, 0x28, 0x5c, 0x06, 0xea, 0x2a,
-	0xe6, 0xf2, 0xb1, 0x98, 0xff, 0xab, 0x4c, 0x82, 0xba, 0x21, 0xbe, 0xb1, 0x34, 0x34, 0x84, 0xba,
-	0x32, 0x13, 0x95, 0x6e, 0xc7, 0x2c, 0x6c, 0x5c, 0x30, 0xdb, 0xd2, 0xd0, 0x5b, 0xa8, 0x5d, 0xd3,
-	0x29, 0x2f, 0x59, 0x42, 0xa7, 0x7c, 0x95, 0x25, 0x74, 0xca, 0xe3, 0xbd, 0x2d, 0xed, 0x58, 0x47,
-	0x2f, 0xa1, 0xe6, 0x0a, 0x1a, 0x56, 0x64, 0x12, 0x7b, 0x3e, 0x2c, 0x42, 0x21, 0x9b, 0xdb, 0xd2,
-	0xb9, 0x20, 0x88, 0x9d, 0x4b, 0x04, 0xd2, 0x38, 0x15, 0x28, 0x1a, 0x2a, 0x1b, 0xdb, 0xbf, 0x74,
-	0x68, 0xc9, 0xe7, 0x5c, 0xff, 0x6c, 0x95, 0xab, 0x35, 0x3b, 0x55, 0x58, 0x6d, 0x76, 0x98, 0x59,
-	0xb1, 0xa5, 0x08, 0xb9, 0x0d, 0x95, 0x9b, 0xb3, 0x34, 0xf4, 0x02, 0xea, 0xe7, 0x3e, 0xf1, 0xf9,
-	0xac, 0x40, 0x2e, 0x2f, 0x63, 0xff, 0xd9, 0x84, 0xe6, 0x65, 0x34, 0x29, 0xcc, 0x75, 0x94, 0x29,
-	0x14, 0xa9, 0xe6, 0x6e, 0xf1, 0x71, 0x0b, 0x1a, 0x47, 0x60, 0x7c, 0xa3, 0x6c, 0x8e, 0x19, 0x8f,
-	0xb7, 0x29, 0xd5, 0xb4, 0x55, 0x50, 0x9e, 0x7f, 0x5b, 0xd1, 0x97, 0xb6, 0x48, 0xc8, 0xd9, 0xc1,
-	0x5b, 0x1a, 0x3a, 0x87, 0xdd, 0x0b, 0x2c, 0x1c, 0x3c, 0xf5, 0xb9, 0xc0, 0x0c, 0xdf, 0x26, 0x42,
-	0x65, 0x91, 0xe7, 0x2a, 0x58, 0x45, 0x4c, 0xfb, 0xbc, 0x86, 0x56, 0x9a, 0x53, 0x99, 0xb5, 0x7e,
-	0xa0, 0x43, 0xd8, 0x19, 0x61, 0xf6, 0x8f, 0xe4, 0x21, 0xc0, 0x08, 0xdf, 0xfb, 0x37, 0x78, 0x79,
-	0x75, 0x94, 0xbe, 0x89, 0x4c, 0x67, 0x83, 0x9c, 0x42, 0xe7, 0x02, 0x0b, 0x05, 0x8e, 0x19, 0x0d,
-	0x31, 0x13, 0x3e, 0x2e, 0x9a, 0xb0, 0x9f, 0x2d, 0x53, 0x25, 0xe5, 0x9e, 0x74, 0xdc, 0x15, 0x2d,
-	0x7a, 0xaa, 0xd0, 0x5d, 0x55, 0x58, 0xba, 0xc9, 0x74, 0xf6, 0x01, 0x18, 0x17, 0x58, 0x9c, 0xf2,
-	0xf9, 0x38, 0xf0, 0x48, 0xc5, 0xd2, 0xe4, 0x5b, 0xe8, 0x06, 0x54, 0x64, 0xba, 0x27, 0xd0, 0x3c,
-	0x63, 0xd8, 0x13, 0x38, 0x29, 0x41, 0x7b, 0xe9, 0x7b, 0x71, 0xcc, 0x84, 0xa4, 0xa6, 0x42, 0xd9,
-	0x36, 0x96, 0x86, 0xfa, 0xd0, 0x74, 0xf0, 0x82, 0xde, 0x67, 0x55, 0x6b, 0xbd, 0x1c, 0xc0, 0x56,
-	0xfa, 0x89, 0x2a, 0x0f, 0xb3, 0xe6, 0xfb, 0x35, 0x04, 0xc8, 0x7f, 0xf7, 0xcb, 0x3f, 0x86, 0xa5,
-	0x6f, 0xc2, 0xa4, 0x1e, 0xff, 0x47, 0xbd, 0xf9, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x77, 0x15, 0xca,
-	0xc7, 0xf0, 0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("node.proto", fileDescriptor13) }
+
+var fileDescriptor13 = []byte{
+	// 1345 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0xdd, 0x72, 0xdb, 0xc4,
+	0x17, 0xb7, 0x12, 0xd9, 0x7f, 0xfb, 0x38, 0xb1, 0xdb, 0x4d, 0xfe, 0xad, 0xf0, 0x30, 0x6d, 0x10,
+	0x0c, 0x75, 0xdb, 0x49, 0x9a, 0x31, 0x29, 0x4c, 0x07, 0xb8, 0x48, 0xed, 0x26, 0x0d, 0xd3, 0x82,
+	0x91, 0xc3, 0xd0, 0x2b, 0x66, 0x64, 0x7b, 0xeb, 0xa8, 0x91, 0xb5, 0x62, 0x77, 0x95, 0xc4, 0x77,
+	0xbc, 0x03, 0x57, 0xbc, 0x04, 0x97, 0x3c, 0x05, 0xbc, 0x0e, 0xd7, 0xcc, 0x7e, 0x49, 0xb2, 0x2c,
+	0xcf, 0xb4, 0x77, 0xde, 0x73, 0x7e, 0xe7, 0xeb, 0x77, 0xb4, 0xe7, 0xac, 0x01, 0x22, 0x32, 0xc5,
+	0x07, 0x31, 0x25, 0x9c, 0x20, 0x9b, 0x91, 0x68, 0xde, 0x69, 0x8c, 0x83, 0xa9, 0x12, 0x74, 0xb6,
+	0xc6, 0xc1, 0x2c, 0x88, 0xb8, 0x3e, 0xc1, 0x14, 0xfb, 0xa1, 0xfe, 0xdd, 0x0e, 0x22, 0x01, 0x8e,
+	0x02, 0x5f, 0x0b, 0x1a, 0x17, 0xc9, 0xd8, 0xe8, 0x26, 0x24, 0xe2, 0x7e, 0x10, 0x61, 0x6a, 0x04,
+	0x3c, 0x98, 0x63, 0xc6, 0xfd, 0x79, 0xac, 0x04, 0xee, 0x1b, 0x40, 0xdf, 0x91, 0x20, 0xfa, 0x1e,
+	0xf3, 0x6b, 0x42, 0x2f, 0x3d, 0xfc, 0x6b, 0x82, 0x19, 0x47, 0x9f, 0x41, 0x8d, 0xfb, 0xec, 0xf2,
+	0x6c, 0xe0, 0x58, 0x7b, 0x56, 0xb7, 0xd9, 0xdb, 0x3a, 0x10, 0x21, 0x0e, 0xce, 0xa5, 0xcc, 0xd3,
+	0x3a, 0xf4, 0x31, 0x34, 0xb4, 0xdd, 0xd9, 0xc0, 0xd9, 0xd8, 0xb3, 0xba, 0x0d, 0x2f, 0x13, 0xb8,
+	0x0f, 0xa0, 0x2d, 0xf0, 0xaf, 0x02, 0xc6, 0x8d, 0xdb, 0x5d, 0xa8, 0x5e, 0x24, 0x63, 0xed, 0xb5,
+	0xe1, 0xa9, 0x83, 0xfb, 0x23, 0xb4, 0x07, 0xd8, 0x0f, 0x0b, 0x40, 0x72, 0x1d, 0x61, 0x6a, 0x80,
+	0xf2, 0x80, 0xba, 0x50, 0x63, 0xdc, 0xe7, 0x09, 0x93, 0xc1, 0x5a, 0xbd, 0x5b, 0x2a, 0x2b, 0x61,
+	0x3c, 0x92, 0x72, 0x4f, 0xeb, 0xdd, 0x37, 0xb0, 0x9d, 0xb9, 0x8c, 0xc3, 0x05, 0xba, 0x07, 0xb6,
+	0xa0, 0xcc, 0xb1, 0xf6, 0x36, 0xbb, 0xcd, 0x1e, 0x64, 0x86, 0x9e, 0x94, 0xa3, 0x07, 0x50, 0x9b,
+	0x51, 0x92, 0xc4, 0xc2, 0xb5, 0x40, 0xb4, 0x33, 0xc4, 0xa9, 0x90, 0x7b, 0x5a, 0xed, 0x7e, 0x0d,
+	0x8d, 0x54, 0x28, 0xd2, 0x1c, 0x07, 0xd3, 0xac, 0x1e, 0x79, 0x40, 0x0e, 0xfc, 0x4f, 0xf8, 0x3c,
+	0x1b, 0x28, 0x67, 0x0d, 0xcf, 0x1c, 0xdd, 0x3f, 0x2d, 0x68, 0xe7, 0xb2, 0x2d, 0x64, 0x66, 0xad,
+	0xc9, 0xcc, 0x0e, 0xa2, 0xb7, 0x44, 0x96, 0xdc, 0xec, 0xed, 0x64, 0xfa, 0xb3, 0xe8, 0x2d, 0x91,
+	0x2e, 0x3c, 0x09, 0x40, 0x47, 0x00, 0x0c, 0x73, 0x1e, 0xe2, 0x39, 0x8e, 0xb8, 0xb3, 0x29, 0xe1,
+	0xbb, 0x39, 0x86, 0x52, 0x9d, 0x97, 0xc3, 0x89, 0x1e, 0xfa, 0x09, 0x27, 0x1e, 0x8e, 0xf0, 0xb5,
+	0x63, 0xef, 0x59, 0xdd, 0xba, 0x97, 0x09, 0x5c, 0x06, 0xdb, 0x1e, 0x66, 0x24, 0xa1, 0x13, 0xdc,
+	0x0f, 0x7d, 0xc6, 0x50, 0x07, 0xea, 0x93, 0x38, 0xe9, 0x13, 0x8a, 0x99, 0xcc, 0xd8, 0xf6, 0xd2,
+	0xb3, 0xd0, 0x51, 0x7f, 0xfe, 0x7c, 0xc1, 0xb1, 0x6a, 0x90, 0xed, 0xa5, 0x67, 0xf4, 0x08, 0xea,
+	0x33, 0x81, 0x4b, 0x74, 0x6a, 0xad, 0x5e, 0x4b, 0xa5, 0x76, 0x3a, 0xfc, 0x49, 0x4a, 0xbd, 0x54,
+	0xef, 0xfe, 0x6d, 0x01, 0x7a, 0xed, 0xd3, 0x4b, 0xcc, 0x05, 0x4f, 0xcc, 0x7c, 0x13, 0xfb, 0xd0,
+	0x20, 0x74, 0x8a, 0xe9, 0xf9, 0x22, 0xc6, 0x32, 0x76, 0xcb, 0x74, 0xe9, 0x07, 0x23, 0xf6, 0x32,
+	0x84, 0x80, 0x53, 0x9d, 0x3a, 0xd3, 0xe4, 0x69, 0xb8, 0xa9, 0x88, 0x79, 0x19, 0x02, 0x7d, 0x0a,
+	0xf6, 0x5b, 0x4a, 0xe6, 0xce, 0x66, 0x1e, 0x79, 0x6e, 0x2e, 0x8b, 0x27, 0x95, 0xe8, 0x3e, 0x6c,
+	0x70, 0xe2, 0xd8, 0xe5, 0x90, 0x0d, 0x4e, 0x10, 0x02, 0x9b, 0x71, 0x1c, 0x3b, 0x55, 0x59, 0xbe,
+	0xfc, 0xed, 0xfe, 0x63, 0x01, 0x0c, 0x69, 0x30, 0xc1, 0xb2, 0x1a, 0xf4, 0x10, 0xaa, 0x13, 0x41,
+	0xa5, 0x63, 0xe5, 0x1b, 0xba, 0xc4, 0xb2, 0xa7, 0x10, 0xe2, 0xf3, 0x9a, 0x48, 0xc6, 0x14, 0x9b,
+	0xea, 0x80, 0xee, 0xc1, 0xe6, 0x3c, 0x88, 0x9c, 0xcd, 0xfc, 0xc5, 0x7c, 0x1e, 0xcc, 0xce, 0x22,
+	0xee, 0x09, 0x85, 0xd0, 0xc7, 0x4f, 0x0f, 0x1d, 0xbb, 0x4c, 0x1f, 0x3f, 0x3d, 0x94, 0xfa, 0x67,
+	0x87, 0x4e, 0xb5, 0x54, 0xff, 0x4c, 0xea, 0xe7, 0xfe, 0x8d, 0x53, 0x2b, 0xf5, 0xef, 0xdf, 0xb8,
+	0xef, 0xe0, 0xb6, 0x2c, 0xe7, 0x65, 0xc0, 0x38, 0xa1, 0x8b, 0x21, 0x09, 0x22, 0xd9, 0x9c, 0x74,
+	0xb2, 0x38, 0x56, 0x39, 0x41, 0x19, 0x02, 0x7d, 0x0e, 0x55, 0x71, 0x53, 0xcd, 0x6d, 0xd3, 0x17,
+	0x39, 0x63, 0xc9, 0x53, 0x6a, 0x77, 0xb0, 0x1c, 0x4b, 0xdd, 0x98, 0x27, 0x50, 0x8b, 0x45, 0x50,
+	0xa6, 0x6f, 0xf3, 0xdd, 0x9c, 0x75, 0x3e, 0x29, 0x4f, 0xc3, 0xdc, 0x5f, 0xe0, 0xd6, 0x28, 0x89,
+	0xe3, 0x70, 0x31, 0xc0, 0x31, 0xbf, 0x78, 0x85, 0xaf, 0x70, 0x88, 0x8e, 0xa0, 0x15, 0x0b, 0x83,
+	0x21, 0xa6, 0x23, 0x3c, 0x21, 0xd1, 0xd4, 0xb1, 0x4a, 0x0a, 0x2e, 0x60, 0xca, 0x3b, 0xe2, 0xfe,
+	0x66, 0x41, 0x33, 0x17, 0xe0, 0x43, 0x5a, 0x7c, 0x07, 0x6a, 0x72, 0xb6, 0x99, 0x1b, 0xa3, 0x4f,
+	0xe8, 0x00, 0x6a, 0xa1, 0xc8, 0x93, 0x39, 0x9b, 0xb2, 0xc6, 0x3b, 0xca, 0x47, 0xb1, 0x0c, 0x4f,
+	0xa3, 0xdc, 0x77, 0x4b, 0x25, 0x2a, 0x9e, 0x3e, 0xb0, 0x27, 0x0f, 0xa0, 0x3a, 0x15, 0xc6, 0xba,
+	0x27, 0xb7, 0x57, 0x22, 0x7a, 0x4a, 0xdf, 0xfb, 0xd7, 0x86, 0x96, 0x98, 0xec, 0xaf, 0xfd, 0xc8,
+	0x9f, 0xa9, 0x29, 0x72, 0x04, 0xb6, 0x98, 0xb5, 0xe8, 0xff, 0xd9, 0x9e, 0xc8, 0x8d, 0xf3, 0xce,
+	0x4e, 0x51, 0x1c, 0x87, 0x0b, 0xb7, 0x82, 0xf6, 0xa1, 0x3e, 0x4c, 0xd8, 0x85, 0x10, 0xa3, 0xa6,
+	0x82, 0xf4, 0x2f, 0x92, 0xe8, 0xb2, 0xd3, 0x32, 0x1d, 0x25, 0x33, 0x8a, 0x19, 0x73, 0x2b, 0x5d,
+	0xeb, 0xd0, 0x42, 0xdf, 0x42, 0x75, 0xc4, 0x7d, 0xca, 0xd1, 0x47, 0x4a, 0xfd, 0x32, 0x19, 0xcb,
+	0xb3, 0xb0, 0x37, 0x91, 0xee, 0x96, 0xa9, 0x54, 0xb4, 0x6f, 0xa0, 0x99, 0xdb, 0x74, 0xc8, 0x51,
+	0xc8, 0xd5, 0xe5, 0xd7, 0xd1, 0x95, 0x6b, 0xe9, 0x28, 0xc6, 0x13, 0xb7, 0x22, 0x3e, 0x3a, 0x35,
+	0xb5, 0xd1, 0xd2, 0x2e, 0xec, 0xe4, 0x2a, 0xce, 0x4d, 0x75, 0xb7, 0x82, 0xbe, 0x04, 0xfb, 0x15,
+	0x99, 0xb1, 0x25, 0x4a, 0xc8, 0x8c, 0x95, 0x51, 0x42, 0x66, 0x4c, 0xd6, 0xed, 0x56, 0x0e, 0x2d,
+	0x31, 0x88, 0x46, 0x9c, 0xc4, 0x85, 0x30, 0x9a, 0x9e, 0x17, 0xf3, 0x98, 0x0b, 0xe7, 0x3d, 0xc1,
+	0x5c, 0x18, 0x4a, 0xe6, 0x74, 0x00, 0x73, 0x36, 0x01, 0xf2, 0x84, 0x4a, 0xc7, 0x87, 0x60, 0xbf,
+	0xb8, 0xc1, 0x13, 0xa4, 0xcb, 0x13, 0xbf, 0x0d, 0xb6, 0x9d, 0x17, 0xc9, 0xf4, 0x25, 0xe1, 0xfb,
+	0xd0, 0x1c, 0x12, 0xca, 0x4f, 0x08, 0xbd, 0xf6, 0xe9, 0x74, 0xb9, 0x45, 0xcb, 0xee, 0x25, 0xfc,
+	0x31, 0xd4, 0xfa, 0x24, 0x5e, 0x9c, 0x93, 0xf7, 0x69, 0x66, 0x0f, 0xea, 0x02, 0x7c, 0x22, 0xc6,
+	0xaa, 0xae, 0xc0, 0x9c, 0xd7, 0x55, 0xd0, 0xfb, 0x6b, 0x03, 0x5a, 0x62, 0x95, 0xad, 0xff, 0xf0,
+	0x0a, 0xef, 0x88, 0xce, 0x4e, 0x51, 0xac, 0x7a, 0xf3, 0x38, 0x6d, 0x66, 0x5d, 0x01, 0xb2, 0x46,
+	0x16, 0xd6, 0xb3, 0x5b, 0x41, 0x9f, 0x40, 0xed, 0x24, 0x88, 0x02, 0x76, 0x91, 0x03, 0x17, 0xda,
+	0xf1, 0x15, 0x54, 0xfb, 0x21, 0x61, 0x18, 0xdd, 0xc9, 0x9c, 0x48, 0x81, 0xc9, 0xa3, 0x74, 0x0f,
+	0xcb, 0x1b, 0x50, 0x7b, 0x71, 0xc3, 0x71, 0x34, 0x45, 0x77, 0x33, 0x84, 0x92, 0x18, 0xd3, 0x34,
+	0xa8, 0xfc, 0xa6, 0x1a, 0xc7, 0x66, 0x37, 0xa3, 0x4e, 0x66, 0x91, 0x0a, 0x0b, 0xd4, 0xe9, 0xfc,
+	0x7a, 0xbf, 0xd7, 0x60, 0xfb, 0x65, 0x32, 0xce, 0xf1, 0xb6, 0x9f, 0x32, 0x90, 0x87, 0x76, 0x76,
+	0xf3, 0xd7, 0x27, 0xc7, 0xc1, 0x3e, 0x34, 0x7f, 0x26, 0xf4, 0x12, 0x53, 0x26, 0xd9, 0x5e, 0xb2,
+	0xd1, 0x1f, 0xcf, 0x32, 0xbf, 0x5b, 0x0a, 0xbe, 0xc2, 0xb2, 0x06, 0xa7, 0x6f, 0x17, 0xb7, 0x82,
+	0x4e, 0x60, 0xf7, 0x14, 0x73, 0x0f, 0xcf, 0x02, 0xc6, 0x31, 0xc5, 0x53, 0x1d, 0x68, 0x39, 0xc8,
+	0x7d, 0xfd, 0x5a, 0x28, 0x01, 0x1a, 0x3f, 0x0f, 0xa1, 0x65, 0x74, 0x4a, 0xb3, 0xbe, 0x5f, 0x8f,
+	0xe1, 0xd6, 0x00, 0xd3, 0xf7, 0x04, 0x3f, 0x01, 0x18, 0xe0, 0xab, 0x60, 0x82, 0x57, 0x4b, 0x47,
+	0xa6, 0x05, 0x42, 0x9d, 0x26, 0x72, 0x0c, 0x3b, 0xa7, 0x98, 0x2b, 0xe1, 0x90, 0x92, 0x18, 0x53,
+	0x1e, 0xe0, 0x3c, 0x09, 0xf7, 0xd2, 0x62, 0x8a, 0xa0, 0x8c, 0x93, 0x9d, 0x51, 0x89, 0x8b, 0x3d,
+	0x3d, 0x93, 0xcb, 0x0c, 0xcb, 0x1a, 0x8f, 0x0e, 0xa0, 0x79, 0x8a, 0xf9, 0x31, 0xbb, 0x1c, 0x86,
+	0x7e, 0x54, 0xa0, 0x54, 0x2f, 0xdd, 0x51, 0x48, 0x78, 0x1a, 0xf7, 0x08, 0xb6, 0xfb, 0x14, 0xfb,
+	0x1c, 0x6b, 0x13, 0xf3, 0x59, 0x9e, 0x45, 0x0c, 0x53, 0x2e, 0xa0, 0x65, 0x9f, 0x65, 0x57, 0xbc,
+	0x12, 0xe7, 0xe4, 0x2a, 0xb5, 0x5a, 0xcb, 0xe5, 0x01, 0xd4, 0xcd, 0x12, 0x58, 0x4e, 0x66, 0xcd,
+	0x86, 0x78, 0x02, 0x90, 0x4d, 0xd6, 0xd5, 0xcb, 0xba, 0x3a, 0x75, 0x1f, 0x41, 0xcd, 0x23, 0x09,
+	0xc7, 0x85, 0x5a, 0xf5, 0xcc, 0x53, 0x2a, 0x8d, 0xed, 0xfd, 0x61, 0x41, 0x5b, 0xbd, 0x33, 0x8f,
+	0x23, 0x3f, 0x5c, 0xf0, 0x60, 0xc2, 0x50, 0x1f, 0xb6, 0xf2, 0xef, 0x08, 0xb3, 0x25, 0x56, 0x9f,
+	0xa3, 0x9d, 0x92, 0x57, 0x47, 0xf6, 0x01, 0x2c, 0x3d, 0x07, 0xd6, 0xfb, 0x58, 0xdd, 0xea, 0xda,
+	0xc5, 0xb8, 0x26, 0xff, 0x9d, 0x7d, 0xf1, 0xdf, 0x00, 0xef, 0x9d, 0x9b, 0x7d, 0x14, 0x0e, 0x00,
+	0x00,
 }
@@ -0,0 +1,384 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: rating.proto
+
+package sonm
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// grpccmd imports
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/sshaman1101/grpccmd"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type DealOutcome int32
+
+const (
+	DealOutcome_COMPLETED    DealOutcome = 0
+	DealOutcome_CLOSED_EARLY DealOutcome = 1
+	DealOutcome_TASK_FAILED  DealOutcome = 2
+	DealOutcome_SLA_BREACH   DealOutcome = 3
+)
+
+var DealOutcome_name = map[int32]string{
+	0: "COMPLETED",
+	1: "CLOSED_EARLY",
+	2: "TASK_FAILED",
+	3: "SLA_BREACH",
+}
+var DealOutcome_value = map[string]int32{
+	"COMPLETED":    0,
+	"CLOSED_EARLY": 1,
+	"TASK_FAILED":  2,
+	"SLA_BREACH":   3,
+}
+
+func (x DealOutcome) String() string {
+	return proto.EnumName(DealOutcome_name, int32(x))
+}
+func (DealOutcome) EnumDescriptor() ([]byte, []int) { return fileDescriptor14, []int{0} }
+
+type RatingEntry struct {
+	DealID string `protobuf:"bytes,1,opt,name=dealID" json:"dealID,omitempty"`
+	// Reporter is the Eth address of the party reporting the outcome.
+	Reporter string `protobuf:"bytes,2,opt,name=reporter" json:"reporter,omitempty"`
+	// Subject is the Eth address of the party being rated.
+	Subject string      `protobuf:"bytes,3,opt,name=subject" json:"subject,omitempty"`
+	Outcome DealOutcome `protobuf:"varint,4,opt,name=outcome,enum=sonm.DealOutcome" json:"outcome,omitempty"`
+	// Details describe the outcome, for example IDs of the failed tasks.
+	Details string     `protobuf:"bytes,5,opt,name=details" json:"details,omitempty"`
+	Ts      *Timestamp `protobuf:"bytes,6,opt,name=ts" json:"ts,omitempty"`
+	// Signature is the reporter's signature of all fields above.
+	Signature []byte `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *RatingEntry) Reset()                    { *m = RatingEntry{} }
+func (m *RatingEntry) String() string            { return proto.CompactTextString(m) }
+func (*RatingEntry) ProtoMessage()               {}
+func (*RatingEntry) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{0} }
+
+func (m *RatingEntry) GetDealID() string {
+	if m != nil {
+		return m.DealID
+	}
+	return ""
+}
+
+func (m *RatingEntry) GetReporter() string {
+	if m != nil {
+		return m.Reporter
+	}
+	return ""
+}
+
+func (m *RatingEntry) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *RatingEntry) GetOutcome() DealOutcome {
+	if m != nil {
+		return m.Outcome
+	}
+	return DealOutcome_COMPLETED
+}
+
+func (m *RatingEntry) GetDetails() string {
+	if m != nil {
+		return m.Details
+	}
+	return ""
+}
+
+func (m *RatingEntry) GetTs() *Timestamp {
+	if m != nil {
+		return m.Ts
+	}
+	return nil
+}
+
+func (m *RatingEntry) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetRatingRequest struct {
+	EthAddr string `protobuf:"bytes,1,opt,name=ethAddr" json:"ethAddr,omitempty"`
+}
+
+func (m *GetRatingRequest) Reset()                    { *m = GetRatingRequest{} }
+func (m *GetRatingRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetRatingRequest) ProtoMessage()               {}
+func (*GetRatingRequest) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{1} }
+
+func (m *GetRatingRequest) GetEthAddr() string {
+	if m != nil {
+		return m.EthAddr
+	}
+	return ""
+}
+
+type RatingReply struct {
+	EthAddr string `protobuf:"bytes,1,opt,name=ethAddr" json:"ethAddr,omitempty"`
+	// Rating is the percentage of deals reported without any failures,
+	// neutral 50 if nothing has been reported yet.
+	Rating int64 `protobuf:"varint,2,opt,name=rating" json:"rating,omitempty"`
+	// Outcomes maps outcome names to the number of their reports.
+	Outcomes map[string]uint64 `protobuf:"bytes,3,rep,name=outcomes" json:"outcomes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+}
+
+func (m *RatingReply) Reset()                    { *m = RatingReply{} }
+func (m *RatingReply) String() string            { return proto.CompactTextString(m) }
+func (*RatingReply) ProtoMessage()               {}
+func (*RatingReply) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{2} }
+
+func (m *RatingReply) GetEthAddr() string {
+	if m != nil {
+		return m.EthAddr
+	}
+	return ""
+}
+
+func (m *RatingReply) GetRating() int64 {
+	if m != nil {
+		return m.Rating
+	}
+	return 0
+}
+
+func (m *RatingReply) GetOutcomes() map[string]uint64 {
+	if m != nil {
+		return m.Outcomes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*RatingEntry)(nil), "sonm.RatingEntry")
+	proto.RegisterType((*GetRatingRequest)(nil), "sonm.GetRatingRequest")
+	proto.RegisterType((*RatingReply)(nil), "sonm.RatingReply")
+	proto.RegisterEnum("sonm.DealOutcome", DealOutcome_name, DealOutcome_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Rating service
+
+type RatingClient interface {
+	// Report records signed outcome of a deal made with the given party.
+	// Both parties of the deal are able to report it once.
+	Report(ctx context.Context, in *RatingEntry, opts ...grpc.CallOption) (*Empty, error)
+	// GetRating returns rating of a party with given Eth address.
+	GetRating(ctx context.Context, in *GetRatingRequest, opts ...grpc.CallOption) (*RatingReply, error)
+}
+
+type ratingClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRatingClient(cc *grpc.ClientConn) RatingClient {
+	return &ratingClient{cc}
+}
+
+func (c *ratingClient) Report(ctx context.Context, in *RatingEntry, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/sonm.Rating/Report", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratingClient) GetRating(ctx context.Context, in *GetRatingRequest, opts ...grpc.CallOption) (*RatingReply, error) {
+	out := new(RatingReply)
+	err := grpc.Invoke(ctx, "/sonm.Rating/GetRating", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Rating service
+
+type RatingServer interface {
+	// Report records signed outcome of a deal made with the given party.
+	// Both parties of the deal are able to report it once.
+	Report(context.Context, *RatingEntry) (*Empty, error)
+	// GetRating returns rating of a party with given Eth address.
+	GetRating(context.Context, *GetRatingRequest) (*RatingReply, error)
+}
+
+func RegisterRatingServer(s *grpc.Server, srv RatingServer) {
+	s.RegisterService(&_Rating_serviceDesc, srv)
+}
+
+func _Rating_Report_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RatingEntry)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServer).Report(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Rating/Report",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServer).Report(ctx, req.(*RatingEntry))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rating_GetRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServer).GetRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Rating/GetRating",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServer).GetRating(ctx, req.(*GetRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Rating_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sonm.Rating",
+	HandlerType: (*RatingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Report",
+			Handler:    _Rating_Report_Handler,
+		},
+		{
+			MethodName: "GetRating",
+			Handler:    _Rating_GetRating_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rating.proto",
+}
+
+// Begin grpccmd
+var _ = grpccmd.RunE
+
+// Rating
+var _RatingCmd = &cobra.Command{
+	Use:   "rating [method]",
+	Short: "Subcommand for the Rating service.",
+}
+
+var _Rating_ReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Make the Report method call, input-type: sonm.RatingEntry output-type: sonm.Empty",
+	RunE: grpccmd.RunE(
+		"Report",
+		"sonm.RatingEntry",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewRatingClient(cc)
+		},
+	),
+}
+
+var _Rating_ReportCmd_gen = &cobra.Command{
+	Use:   "report-gen",
+	Short: "Generate JSON for method call of Report (input-type: sonm.RatingEntry)",
+	RunE:  grpccmd.TypeToJson("sonm.RatingEntry"),
+}
+
+var _Rating_GetRatingCmd = &cobra.Command{
+	Use:   "getRating",
+	Short: "Make the GetRating method call, input-type: sonm.GetRatingRequest output-type: sonm.RatingReply",
+	RunE: grpccmd.RunE(
+		"GetRating",
+		"sonm.GetRatingRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewRatingClient(cc)
+		},
+	),
+}
+
+var _Rating_GetRatingCmd_gen = &cobra.Command{
+	Use:   "getRating-gen",
+	Short: "Generate JSON for method call of GetRating (input-type: sonm.GetRatingRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.GetRatingRequest"),
+}
+
+// Register commands with the root command and service command
+func init() {
+	grpccmd.RegisterServiceCmd(_RatingCmd)
+	_RatingCmd.AddCommand(
+		_Rating_ReportCmd,
+		_Rating_ReportCmd_gen,
+		_Rating_GetRatingCmd,
+		_Rating_GetRatingCmd_gen,
+	)
+}
+
+// End grpccmd
+
+func init() { proto.RegisterFile("rating.proto", fileDescriptor14) }
+
+var fileDescriptor14 = []byte{
+	// 437 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x8d, 0xed, 0xd4, 0x69, 0xc6, 0x69, 0xe3, 0x8c, 0x50, 0xb5, 0xb2, 0x90, 0x6a, 0xf9, 0x64,
+	0x15, 0x94, 0x43, 0xb8, 0x54, 0xf4, 0x64, 0x62, 0x03, 0x15, 0x46, 0x41, 0x9b, 0x5c, 0x38, 0x45,
+	0x6e, 0xb3, 0x0a, 0x06, 0x7f, 0xe1, 0x5d, 0x23, 0xe5, 0xa7, 0xf1, 0x73, 0xf8, 0x27, 0xc8, 0xbb,
+	0x8e, 0x09, 0xa0, 0x9e, 0xec, 0x37, 0x6f, 0x77, 0xe6, 0xbd, 0x37, 0x0b, 0x93, 0x3a, 0x11, 0x69,
+	0xb1, 0x9f, 0x57, 0x75, 0x29, 0x4a, 0x1c, 0xf2, 0xb2, 0xc8, 0x9d, 0x69, 0x5a, 0xb4, 0xdf, 0x22,
+	0x4d, 0x54, 0xd9, 0x99, 0x8a, 0x34, 0x67, 0x5c, 0x24, 0x79, 0xa5, 0x0a, 0xde, 0x2f, 0x0d, 0x2c,
+	0x2a, 0x2f, 0x46, 0x85, 0xa8, 0x0f, 0x78, 0x05, 0xe6, 0x8e, 0x25, 0xd9, 0x7d, 0x48, 0x34, 0x57,
+	0xf3, 0xc7, 0xb4, 0x43, 0xe8, 0xc0, 0x79, 0xcd, 0xaa, 0xb2, 0x16, 0xac, 0x26, 0xba, 0x64, 0x7a,
+	0x8c, 0x04, 0x46, 0xbc, 0x79, 0xf8, 0xca, 0x1e, 0x05, 0x31, 0x24, 0x75, 0x84, 0xf8, 0x02, 0x46,
+	0x65, 0x23, 0x1e, 0xcb, 0x9c, 0x91, 0xa1, 0xab, 0xf9, 0x97, 0x8b, 0xd9, 0xbc, 0xd5, 0x33, 0x0f,
+	0x59, 0x92, 0xad, 0x14, 0x41, 0x8f, 0x27, 0xda, 0x36, 0x3b, 0x26, 0x92, 0x34, 0xe3, 0xe4, 0x4c,
+	0xb5, 0xe9, 0x20, 0x5e, 0x83, 0x2e, 0x38, 0x31, 0x5d, 0xcd, 0xb7, 0x16, 0x53, 0xd5, 0x61, 0x73,
+	0xf4, 0x41, 0x75, 0xc1, 0xf1, 0x39, 0x8c, 0x79, 0xba, 0x2f, 0x12, 0xd1, 0xd4, 0x8c, 0x8c, 0x5c,
+	0xcd, 0x9f, 0xd0, 0x3f, 0x05, 0xef, 0x25, 0xd8, 0xef, 0x98, 0x50, 0x2e, 0x29, 0xfb, 0xde, 0x30,
+	0x2e, 0xda, 0x61, 0x4c, 0x7c, 0x09, 0x76, 0xbb, 0xba, 0x33, 0x7a, 0x84, 0xde, 0xcf, 0x3e, 0x11,
+	0xca, 0xaa, 0xec, 0xf0, 0xf4, 0xc9, 0x36, 0x2b, 0x95, 0xb9, 0x4c, 0xc4, 0xa0, 0x1d, 0xc2, 0x3b,
+	0x38, 0xef, 0x3c, 0x71, 0x62, 0xb8, 0x86, 0x6f, 0x2d, 0xae, 0x95, 0xe8, 0x93, 0xb6, 0xf3, 0xce,
+	0x3e, 0x97, 0xb1, 0xd3, 0xfe, 0x82, 0x73, 0x07, 0x17, 0x7f, 0x51, 0x68, 0x83, 0xf1, 0x8d, 0x1d,
+	0xba, 0xd9, 0xed, 0x2f, 0x3e, 0x83, 0xb3, 0x1f, 0x49, 0xd6, 0x30, 0x39, 0x76, 0x48, 0x15, 0x78,
+	0xad, 0xdf, 0x6a, 0x37, 0x2b, 0xb0, 0x4e, 0xa2, 0xc5, 0x0b, 0x18, 0x2f, 0x57, 0x1f, 0x3f, 0xc5,
+	0xd1, 0x26, 0x0a, 0xed, 0x01, 0xda, 0x30, 0x59, 0xc6, 0xab, 0x75, 0x14, 0x6e, 0xa3, 0x80, 0xc6,
+	0x9f, 0x6d, 0x0d, 0xa7, 0x60, 0x6d, 0x82, 0xf5, 0x87, 0xed, 0xdb, 0xe0, 0x3e, 0x8e, 0x42, 0x5b,
+	0xc7, 0x4b, 0x80, 0x75, 0x1c, 0x6c, 0xdf, 0xd0, 0x28, 0x58, 0xbe, 0xb7, 0x8d, 0x45, 0x01, 0xa6,
+	0x12, 0x8d, 0x37, 0x60, 0x52, 0xb9, 0x70, 0x9c, 0x9d, 0x9a, 0x91, 0x1a, 0x1d, 0x4b, 0x95, 0xa2,
+	0xbc, 0x12, 0x07, 0x6f, 0x80, 0xb7, 0x30, 0xee, 0x03, 0xc7, 0x2b, 0xc5, 0xfd, 0xbb, 0x01, 0x67,
+	0xf6, 0x5f, 0x26, 0xde, 0xe0, 0xc1, 0x94, 0xaf, 0xf2, 0xd5, 0xef, 0x01, 0x00, 0x59, 0xfd, 0x0b,
+	0xfa, 0xcd, 0x02, 0x00, 0x00,
+}
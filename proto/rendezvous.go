@@ -15,6 +15,14 @@ func (m *ConnectRequest) Validate() error {
 	return nil
 }
 
+func (m *PublishRequest) Validate() error {
+	if m.Protocol == "" {
+		m.Protocol = "tcp"
+	}
+
+	return nil
+}
+
 func (m *RendezvousReply) Empty() bool {
 	return (m.PublicAddr == nil || m.PublicAddr.Addr == nil) && len(m.PrivateAddrs) == 0
 }
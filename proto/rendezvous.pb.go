@@ -25,6 +25,29 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+type MeetRequest_Role int32
+
+const (
+	// SERVER means that the peer publishes itself.
+	MeetRequest_SERVER MeetRequest_Role = 0
+	// CLIENT means that the peer resolves a server.
+	MeetRequest_CLIENT MeetRequest_Role = 1
+)
+
+var MeetRequest_Role_name = map[int32]string{
+	0: "SERVER",
+	1: "CLIENT",
+}
+var MeetRequest_Role_value = map[string]int32{
+	"SERVER": 0,
+	"CLIENT": 1,
+}
+
+func (x MeetRequest_Role) String() string {
+	return proto.EnumName(MeetRequest_Role_name, int32(x))
+}
+func (MeetRequest_Role) EnumDescriptor() ([]byte, []int) { return fileDescriptor16, []int{6, 0} }
+
 // ConnectRequest describres a connection request to a remote target, possibly
 // located under the NAT.
 type ConnectRequest struct {
@@ -34,12 +57,16 @@ type ConnectRequest struct {
 	Protocol string `protobuf:"bytes,2,opt,name=protocol" json:"protocol,omitempty"`
 	// PrivateAddrs describes source private addresses.
 	PrivateAddrs []*Addr `protobuf:"bytes,3,rep,name=privateAddrs" json:"privateAddrs,omitempty"`
+	// PublicAddr describes source public address discovered by the peer
+	// itself. Required for connectionless protocols, like UDP, because their
+	// public address cannot be observed from the gRPC connection.
+	PublicAddr *Addr `protobuf:"bytes,4,opt,name=publicAddr" json:"publicAddr,omitempty"`
 }
 
 func (m *ConnectRequest) Reset()                    { *m = ConnectRequest{} }
 func (m *ConnectRequest) String() string            { return proto.CompactTextString(m) }
 func (*ConnectRequest) ProtoMessage()               {}
-func (*ConnectRequest) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{0} }
+func (*ConnectRequest) Descriptor() ([]byte, []int) { return fileDescriptor16, []int{0} }
 
 func (m *ConnectRequest) GetID() string {
 	if m != nil {
@@ -62,17 +89,28 @@ func (m *ConnectRequest) GetPrivateAddrs() []*Addr {
 	return nil
 }
 
+func (m *ConnectRequest) GetPublicAddr() *Addr {
+	if m != nil {
+		return m.PublicAddr
+	}
+	return nil
+}
+
 type PublishRequest struct {
 	// Protocol describes network protocol the peer wants to publish.
 	Protocol string `protobuf:"bytes,1,opt,name=protocol" json:"protocol,omitempty"`
 	// PrivateAddrs describes source private addresses.
 	PrivateAddrs []*Addr `protobuf:"bytes,2,rep,name=privateAddrs" json:"privateAddrs,omitempty"`
+	// PublicAddr describes source public address discovered by the peer
+	// itself. Required for connectionless protocols, like UDP, because their
+	// public address cannot be observed from the gRPC connection.
+	PublicAddr *Addr `protobuf:"bytes,3,opt,name=publicAddr" json:"publicAddr,omitempty"`
 }
 
 func (m *PublishRequest) Reset()                    { *m = PublishRequest{} }
 func (m *PublishRequest) String() string            { return proto.CompactTextString(m) }
 func (*PublishRequest) ProtoMessage()               {}
-func (*PublishRequest) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{1} }
+func (*PublishRequest) Descriptor() ([]byte, []int) { return fileDescriptor16, []int{1} }
 
 func (m *PublishRequest) GetProtocol() string {
 	if m != nil {
@@ -88,6 +126,13 @@ func (m *PublishRequest) GetPrivateAddrs() []*Addr {
 	return nil
 }
 
+func (m *PublishRequest) GetPublicAddr() *Addr {
+	if m != nil {
+		return m.PublicAddr
+	}
+	return nil
+}
+
 // RendezvousReply describes a rendezvous point reply.
 type RendezvousReply struct {
 	// PublicAddr is a public network address of a target.
@@ -102,7 +147,7 @@ type RendezvousReply struct {
 func (m *RendezvousReply) Reset()                    { *m = RendezvousReply{} }
 func (m *RendezvousReply) String() string            { return proto.CompactTextString(m) }
 func (*RendezvousReply) ProtoMessage()               {}
-func (*RendezvousReply) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{2} }
+func (*RendezvousReply) Descriptor() ([]byte, []int) { return fileDescriptor16, []int{2} }
 
 func (m *RendezvousReply) GetPublicAddr() *Addr {
 	if m != nil {
@@ -126,7 +171,7 @@ type RendezvousState struct {
 func (m *RendezvousState) Reset()                    { *m = RendezvousState{} }
 func (m *RendezvousState) String() string            { return proto.CompactTextString(m) }
 func (*RendezvousState) ProtoMessage()               {}
-func (*RendezvousState) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{3} }
+func (*RendezvousState) Descriptor() ([]byte, []int) { return fileDescriptor16, []int{3} }
 
 func (m *RendezvousState) GetState() map[string]*RendezvousMeeting {
 	if m != nil {
@@ -144,7 +189,7 @@ type RendezvousMeeting struct {
 func (m *RendezvousMeeting) Reset()                    { *m = RendezvousMeeting{} }
 func (m *RendezvousMeeting) String() string            { return proto.CompactTextString(m) }
 func (*RendezvousMeeting) ProtoMessage()               {}
-func (*RendezvousMeeting) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{4} }
+func (*RendezvousMeeting) Descriptor() ([]byte, []int) { return fileDescriptor16, []int{4} }
 
 func (m *RendezvousMeeting) GetClients() map[string]*RendezvousReply {
 	if m != nil {
@@ -167,7 +212,7 @@ type ResolveMetaReply struct {
 func (m *ResolveMetaReply) Reset()                    { *m = ResolveMetaReply{} }
 func (m *ResolveMetaReply) String() string            { return proto.CompactTextString(m) }
 func (*ResolveMetaReply) ProtoMessage()               {}
-func (*ResolveMetaReply) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{5} }
+func (*ResolveMetaReply) Descriptor() ([]byte, []int) { return fileDescriptor16, []int{5} }
 
 func (m *ResolveMetaReply) GetIDs() []string {
 	if m != nil {
@@ -176,6 +221,51 @@ func (m *ResolveMetaReply) GetIDs() []string {
 	return nil
 }
 
+// MeetRequest describes a peer forwarded from one rendezvous node to another.
+type MeetRequest struct {
+	// ID describes the meeting point ID.
+	ID   string           `protobuf:"bytes,1,opt,name=ID" json:"ID,omitempty"`
+	Role MeetRequest_Role `protobuf:"varint,2,opt,name=role,enum=sonm.MeetRequest_Role" json:"role,omitempty"`
+	// PublicAddr describes the peer's public address observed by the
+	// forwarding node.
+	PublicAddr *Addr `protobuf:"bytes,3,opt,name=publicAddr" json:"publicAddr,omitempty"`
+	// PrivateAddrs describes the peer's private addresses.
+	PrivateAddrs []*Addr `protobuf:"bytes,4,rep,name=privateAddrs" json:"privateAddrs,omitempty"`
+}
+
+func (m *MeetRequest) Reset()                    { *m = MeetRequest{} }
+func (m *MeetRequest) String() string            { return proto.CompactTextString(m) }
+func (*MeetRequest) ProtoMessage()               {}
+func (*MeetRequest) Descriptor() ([]byte, []int) { return fileDescriptor16, []int{6} }
+
+func (m *MeetRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *MeetRequest) GetRole() MeetRequest_Role {
+	if m != nil {
+		return m.Role
+	}
+	return MeetRequest_SERVER
+}
+
+func (m *MeetRequest) GetPublicAddr() *Addr {
+	if m != nil {
+		return m.PublicAddr
+	}
+	return nil
+}
+
+func (m *MeetRequest) GetPrivateAddrs() []*Addr {
+	if m != nil {
+		return m.PrivateAddrs
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ConnectRequest)(nil), "sonm.ConnectRequest")
 	proto.RegisterType((*PublishRequest)(nil), "sonm.PublishRequest")
@@ -183,6 +273,8 @@ func init() {
 	proto.RegisterType((*RendezvousState)(nil), "sonm.RendezvousState")
 	proto.RegisterType((*RendezvousMeeting)(nil), "sonm.RendezvousMeeting")
 	proto.RegisterType((*ResolveMetaReply)(nil), "sonm.ResolveMetaReply")
+	proto.RegisterType((*MeetRequest)(nil), "sonm.MeetRequest")
+	proto.RegisterEnum("sonm.MeetRequest_Role", MeetRequest_Role_name, MeetRequest_Role_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -378,6 +470,109 @@ var _Rendezvous_serviceDesc = grpc.ServiceDesc{
 	Metadata: "rendezvous.proto",
 }
 
+// Client API for RendezvousCluster service
+
+type RendezvousClusterClient interface {
+	// Meet registers the peer forwarded by another node at the meeting
+	// point, waiting for its counterpart.
+	Meet(ctx context.Context, in *MeetRequest, opts ...grpc.CallOption) (*RendezvousReply, error)
+	// ResolveAll resolves servers waiting at the meeting point of this node.
+	ResolveAll(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ResolveMetaReply, error)
+}
+
+type rendezvousClusterClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRendezvousClusterClient(cc *grpc.ClientConn) RendezvousClusterClient {
+	return &rendezvousClusterClient{cc}
+}
+
+func (c *rendezvousClusterClient) Meet(ctx context.Context, in *MeetRequest, opts ...grpc.CallOption) (*RendezvousReply, error) {
+	out := new(RendezvousReply)
+	err := grpc.Invoke(ctx, "/sonm.RendezvousCluster/Meet", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rendezvousClusterClient) ResolveAll(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ResolveMetaReply, error) {
+	out := new(ResolveMetaReply)
+	err := grpc.Invoke(ctx, "/sonm.RendezvousCluster/ResolveAll", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for RendezvousCluster service
+
+type RendezvousClusterServer interface {
+	// Meet registers the peer forwarded by another node at the meeting
+	// point, waiting for its counterpart.
+	Meet(context.Context, *MeetRequest) (*RendezvousReply, error)
+	// ResolveAll resolves servers waiting at the meeting point of this node.
+	ResolveAll(context.Context, *ID) (*ResolveMetaReply, error)
+}
+
+func RegisterRendezvousClusterServer(s *grpc.Server, srv RendezvousClusterServer) {
+	s.RegisterService(&_RendezvousCluster_serviceDesc, srv)
+}
+
+func _RendezvousCluster_Meet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RendezvousClusterServer).Meet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.RendezvousCluster/Meet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RendezvousClusterServer).Meet(ctx, req.(*MeetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RendezvousCluster_ResolveAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RendezvousClusterServer).ResolveAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.RendezvousCluster/ResolveAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RendezvousClusterServer).ResolveAll(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RendezvousCluster_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sonm.RendezvousCluster",
+	HandlerType: (*RendezvousClusterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Meet",
+			Handler:    _RendezvousCluster_Meet_Handler,
+		},
+		{
+			MethodName: "ResolveAll",
+			Handler:    _RendezvousCluster_ResolveAll_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rendezvous.proto",
+}
+
 // Begin grpccmd
 var _ = grpccmd.RunE
 
@@ -478,39 +673,100 @@ func init() {
 	)
 }
 
+// RendezvousCluster
+var _RendezvousClusterCmd = &cobra.Command{
+	Use:   "rendezvousCluster [method]",
+	Short: "Subcommand for the RendezvousCluster service.",
+}
+
+var _RendezvousCluster_MeetCmd = &cobra.Command{
+	Use:   "meet",
+	Short: "Make the Meet method call, input-type: sonm.MeetRequest output-type: sonm.RendezvousReply",
+	RunE: grpccmd.RunE(
+		"Meet",
+		"sonm.MeetRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewRendezvousClusterClient(cc)
+		},
+	),
+}
+
+var _RendezvousCluster_MeetCmd_gen = &cobra.Command{
+	Use:   "meet-gen",
+	Short: "Generate JSON for method call of Meet (input-type: sonm.MeetRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.MeetRequest"),
+}
+
+var _RendezvousCluster_ResolveAllCmd = &cobra.Command{
+	Use:   "resolveAll",
+	Short: "Make the ResolveAll method call, input-type: sonm.ID output-type: sonm.ResolveMetaReply",
+	RunE: grpccmd.RunE(
+		"ResolveAll",
+		"sonm.ID",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewRendezvousClusterClient(cc)
+		},
+	),
+}
+
+var _RendezvousCluster_ResolveAllCmd_gen = &cobra.Command{
+	Use:   "resolveAll-gen",
+	Short: "Generate JSON for method call of ResolveAll (input-type: sonm.ID)",
+	RunE:  grpccmd.TypeToJson("sonm.ID"),
+}
+
+// Register commands with the root command and service command
+func init() {
+	grpccmd.RegisterServiceCmd(_RendezvousClusterCmd)
+	_RendezvousClusterCmd.AddCommand(
+		_RendezvousCluster_MeetCmd,
+		_RendezvousCluster_MeetCmd_gen,
+		_RendezvousCluster_ResolveAllCmd,
+		_RendezvousCluster_ResolveAllCmd_gen,
+	)
+}
+
 // End grpccmd
 
-func init() { proto.RegisterFile("rendezvous.proto", fileDescriptor13) }
-
-var fileDescriptor13 = []byte{
-	// 453 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0xcd, 0x6a, 0xdb, 0x40,
-	0x10, 0xb6, 0xe4, 0xa4, 0x89, 0xc7, 0xc1, 0x51, 0x97, 0xfe, 0x08, 0x9d, 0x8c, 0xc8, 0x21, 0xf4,
-	0x47, 0x14, 0x17, 0x4a, 0xe8, 0xa1, 0x10, 0xa2, 0x1c, 0x74, 0x08, 0x34, 0x9b, 0x6b, 0x2f, 0x8a,
-	0x3c, 0x6d, 0x45, 0xd7, 0xbb, 0xaa, 0x76, 0x25, 0x50, 0x9f, 0xa5, 0x2f, 0xd2, 0xb7, 0xe9, 0xa3,
-	0x94, 0xdd, 0x95, 0x1c, 0xc9, 0x89, 0x31, 0x85, 0x5c, 0xec, 0xd1, 0xec, 0xf7, 0x33, 0x33, 0xbb,
-	0x03, 0x5e, 0x89, 0x7c, 0x89, 0xbf, 0x6a, 0x51, 0xc9, 0xa8, 0x28, 0x85, 0x12, 0x64, 0x4f, 0x0a,
-	0xbe, 0x0a, 0x8e, 0x73, 0xae, 0xff, 0x79, 0x9e, 0xda, 0x74, 0x30, 0xe1, 0xa8, 0x6c, 0x18, 0x32,
-	0x98, 0x5d, 0x08, 0xce, 0x31, 0x53, 0x14, 0x7f, 0x56, 0x28, 0x15, 0x99, 0x81, 0x9b, 0xc4, 0xbe,
-	0x33, 0x77, 0x4e, 0x27, 0xd4, 0x4d, 0x62, 0x12, 0xc0, 0xa1, 0x81, 0x66, 0x82, 0xf9, 0xae, 0xc9,
-	0xae, 0xbf, 0x49, 0x04, 0x47, 0x45, 0x99, 0xd7, 0xa9, 0xc2, 0xf3, 0xe5, 0xb2, 0x94, 0xfe, 0x78,
-	0x3e, 0x3e, 0x9d, 0x2e, 0x20, 0xd2, 0x76, 0x91, 0x4e, 0xd1, 0xc1, 0x79, 0xf8, 0x05, 0x66, 0x9f,
-	0xab, 0x5b, 0x96, 0xcb, 0xef, 0x9d, 0x5b, 0x5f, 0xdd, 0xd9, 0xa1, 0xee, 0xee, 0x50, 0x5f, 0xc1,
-	0x31, 0x5d, 0x4f, 0x80, 0x62, 0xc1, 0x1a, 0xf2, 0x0a, 0xa0, 0xd0, 0x86, 0x99, 0x46, 0x18, 0x83,
-	0xa1, 0x40, 0xef, 0xf4, 0xbf, 0xed, 0x7e, 0x3b, 0x7d, 0xbf, 0x1b, 0x95, 0x2a, 0x24, 0x1f, 0x60,
-	0x5f, 0xea, 0xc0, 0x77, 0x0c, 0x79, 0x6e, 0xc9, 0x1b, 0xa8, 0xc8, 0xfc, 0x5e, 0x72, 0x55, 0x36,
-	0xd4, 0xc2, 0x83, 0x6b, 0x80, 0xbb, 0x24, 0xf1, 0x60, 0xfc, 0x03, 0x9b, 0x76, 0x1e, 0x3a, 0x24,
-	0x6f, 0x61, 0xbf, 0x4e, 0x59, 0x85, 0xe6, 0x06, 0xa6, 0x8b, 0x97, 0x9b, 0xba, 0x57, 0x88, 0x2a,
-	0xe7, 0xdf, 0xa8, 0x45, 0x7d, 0x74, 0xcf, 0x9c, 0xf0, 0x8f, 0x0b, 0x4f, 0xef, 0x01, 0xc8, 0x27,
-	0x38, 0xc8, 0x58, 0x8e, 0x5c, 0xc9, 0xb6, 0xc4, 0x93, 0x2d, 0x52, 0xd1, 0x85, 0x85, 0xd9, 0x32,
-	0x3b, 0x92, 0xe6, 0x4b, 0x2c, 0x6b, 0x5c, 0xcf, 0x67, 0x2b, 0xff, 0xc6, 0xc2, 0x5a, 0x7e, 0x4b,
-	0x0a, 0xae, 0xe1, 0xa8, 0x2f, 0xfc, 0x40, 0xab, 0xaf, 0x87, 0xad, 0x3e, 0xdf, 0xd4, 0x37, 0x17,
-	0xdb, 0x6b, 0x54, 0x4b, 0xf6, 0xbd, 0x1e, 0x41, 0x32, 0x3c, 0x01, 0x8f, 0xa2, 0x14, 0xac, 0xc6,
-	0x2b, 0x54, 0xa9, 0x7d, 0x4a, 0x1e, 0x8c, 0x93, 0xd8, 0x4e, 0x6d, 0x42, 0x75, 0xb8, 0xf8, 0xeb,
-	0x00, 0xdc, 0x89, 0x90, 0x33, 0x38, 0x68, 0x49, 0xe4, 0x99, 0x75, 0x18, 0x6e, 0x56, 0xf0, 0xb0,
-	0x6f, 0x38, 0x22, 0xef, 0xb4, 0x8e, 0x61, 0x9e, 0x33, 0x46, 0x0e, 0x2d, 0x2c, 0x89, 0x83, 0x17,
-	0x1d, 0x61, 0x58, 0x4a, 0x38, 0xd2, 0x5e, 0xed, 0x22, 0x75, 0x5e, 0xc3, 0xbd, 0xda, 0xee, 0xf5,
-	0x06, 0xf6, 0x12, 0xfe, 0x55, 0x90, 0xa9, 0x05, 0x5c, 0xae, 0x0a, 0xd5, 0xdc, 0x47, 0x9b, 0xc7,
-	0x18, 0x8e, 0x6e, 0x9f, 0x98, 0x65, 0x7c, 0xff, 0x2f, 0x00, 0x00, 0xff, 0xff, 0xca, 0x64, 0x7d,
-	0x43, 0x5b, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("rendezvous.proto", fileDescriptor16) }
+
+var fileDescriptor16 = []byte{
+	// 550 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xdd, 0x6e, 0xd3, 0x30,
+	0x14, 0xae, 0xd3, 0xec, 0xa7, 0xa7, 0x53, 0xd7, 0x59, 0x30, 0xaa, 0x5c, 0xa0, 0x2a, 0xda, 0xc5,
+	0x34, 0x20, 0x42, 0x41, 0x42, 0x13, 0x17, 0x48, 0x53, 0xdb, 0x8b, 0x48, 0x0c, 0x31, 0x17, 0x71,
+	0xdf, 0xb5, 0x07, 0x88, 0x70, 0xed, 0x12, 0xbb, 0x95, 0xca, 0x13, 0xf0, 0x08, 0x5c, 0xf0, 0x22,
+	0x3c, 0x00, 0xef, 0xc1, 0xa3, 0x20, 0xdb, 0x69, 0x97, 0xa6, 0xeb, 0xa6, 0x4a, 0xbb, 0x49, 0x8e,
+	0xed, 0xef, 0x3b, 0xdf, 0x77, 0x8e, 0x7f, 0xa0, 0x99, 0xa1, 0x18, 0xe1, 0x8f, 0x99, 0x9c, 0xaa,
+	0x68, 0x92, 0x49, 0x2d, 0xa9, 0xaf, 0xa4, 0x18, 0x07, 0x87, 0xa9, 0x30, 0x7f, 0x91, 0x0e, 0xdc,
+	0x74, 0x50, 0x13, 0xa8, 0x5d, 0x18, 0xfe, 0x22, 0xd0, 0xe8, 0x48, 0x21, 0x70, 0xa8, 0x19, 0x7e,
+	0x9f, 0xa2, 0xd2, 0xb4, 0x01, 0x5e, 0xd2, 0x6d, 0x91, 0x36, 0x39, 0xad, 0x31, 0x2f, 0xe9, 0xd2,
+	0x00, 0xf6, 0x2d, 0x76, 0x28, 0x79, 0xcb, 0xb3, 0xb3, 0xcb, 0x31, 0x8d, 0xe0, 0x60, 0x92, 0xa5,
+	0xb3, 0x81, 0xc6, 0x8b, 0xd1, 0x28, 0x53, 0xad, 0x6a, 0xbb, 0x7a, 0x5a, 0x8f, 0x21, 0x32, 0x7a,
+	0x91, 0x99, 0x62, 0x2b, 0xeb, 0xf4, 0x0c, 0x60, 0x32, 0xbd, 0xe6, 0xe9, 0xd0, 0x0c, 0x5b, 0x7e,
+	0x9b, 0x94, 0xd0, 0x85, 0xd5, 0xf0, 0x27, 0x81, 0xc6, 0x07, 0x33, 0x54, 0x5f, 0x17, 0xd6, 0x8a,
+	0x56, 0xc8, 0x3d, 0x56, 0xbc, 0xad, 0xac, 0x54, 0xef, 0xb4, 0x32, 0x86, 0x43, 0xb6, 0xec, 0x2d,
+	0xc3, 0x09, 0x9f, 0x97, 0xe8, 0xe4, 0x2e, 0xfa, 0xb6, 0xd6, 0xc2, 0xdf, 0xa4, 0xa8, 0xd7, 0xd7,
+	0x03, 0x8d, 0xf4, 0x35, 0xec, 0x28, 0x13, 0xb4, 0x88, 0x25, 0xb7, 0x1d, 0xb9, 0x84, 0x8a, 0xec,
+	0xb7, 0x27, 0x74, 0x36, 0x67, 0x0e, 0x1e, 0x5c, 0x01, 0xdc, 0x4c, 0xd2, 0x26, 0x54, 0xbf, 0xe1,
+	0x3c, 0xef, 0x9d, 0x09, 0xe9, 0x0b, 0xd8, 0x99, 0x0d, 0xf8, 0x14, 0xed, 0xd6, 0xd6, 0xe3, 0x27,
+	0xe5, 0xbc, 0x97, 0x88, 0x3a, 0x15, 0x5f, 0x98, 0x43, 0xbd, 0xf1, 0xce, 0x49, 0xf8, 0xc7, 0x83,
+	0xa3, 0x35, 0x00, 0x7d, 0x0b, 0x7b, 0x43, 0x9e, 0xa2, 0xd0, 0x2a, 0xb7, 0x78, 0xb2, 0x21, 0x55,
+	0xd4, 0x71, 0x30, 0x67, 0x73, 0x41, 0x32, 0x7c, 0x85, 0xd9, 0x0c, 0x97, 0xfd, 0xd9, 0xc8, 0xef,
+	0x3b, 0x58, 0xce, 0xcf, 0x49, 0xc1, 0x15, 0x1c, 0x14, 0x13, 0xdf, 0x52, 0xea, 0xb3, 0xd5, 0x52,
+	0x1f, 0x97, 0xf3, 0xdb, 0x8d, 0x2d, 0x14, 0x6a, 0x52, 0x16, 0xb5, 0x1e, 0x20, 0x65, 0x78, 0x02,
+	0x4d, 0x86, 0x4a, 0xf2, 0x19, 0x5e, 0xa2, 0x1e, 0xd8, 0x65, 0x93, 0x36, 0xe9, 0xba, 0xae, 0xd5,
+	0x98, 0x09, 0xc3, 0xbf, 0x04, 0xea, 0xa6, 0xda, 0x4d, 0x57, 0xf2, 0x0c, 0xfc, 0x4c, 0x72, 0xa7,
+	0xda, 0x88, 0x8f, 0x9d, 0x6a, 0x81, 0x10, 0x31, 0xc9, 0x91, 0x59, 0xcc, 0x36, 0xe7, 0x7c, 0xed,
+	0xa0, 0xfa, 0xf7, 0x1c, 0xd4, 0xa7, 0xe0, 0x1b, 0x25, 0x0a, 0xb0, 0xdb, 0xef, 0xb1, 0x4f, 0x3d,
+	0xd6, 0xac, 0x98, 0xb8, 0xf3, 0x2e, 0xe9, 0xbd, 0xff, 0xd8, 0x24, 0xf1, 0x3f, 0x02, 0x70, 0xd3,
+	0x0c, 0x7a, 0x0e, 0x7b, 0x79, 0xf1, 0xf4, 0x91, 0xcb, 0xb9, 0xfa, 0xf4, 0x04, 0xb7, 0xf7, 0x2f,
+	0xac, 0xd0, 0x97, 0x00, 0x39, 0xf3, 0x82, 0x73, 0xba, 0xef, 0x60, 0x49, 0x37, 0x38, 0x5e, 0x10,
+	0x56, 0x5b, 0x1a, 0x56, 0x8c, 0x56, 0xfe, 0x78, 0x2c, 0xb4, 0x56, 0xdf, 0x92, 0xcd, 0x5a, 0xcf,
+	0xc1, 0x4f, 0xc4, 0x67, 0x49, 0xeb, 0x0e, 0xd0, 0x1b, 0x4f, 0xf4, 0x7c, 0x1d, 0x6d, 0x2f, 0x55,
+	0x58, 0x89, 0xe7, 0xc5, 0xbb, 0xd0, 0xe1, 0x53, 0xa5, 0x31, 0xa3, 0x31, 0xf8, 0x66, 0x37, 0xe8,
+	0xd1, 0xda, 0xce, 0x3c, 0x60, 0x89, 0xd7, 0xbb, 0xf6, 0xed, 0x7b, 0xf5, 0x7f, 0x00, 0x00, 0xde,
+	0x77, 0xa8, 0xf8, 0x05, 0x00, 0x00,
 }
@@ -2,19 +2,70 @@ package util
 
 import (
 	"context"
+	"strings"
 
+	"github.com/opentracing/opentracing-go"
 	"google.golang.org/grpc/metadata"
 )
 
+// Prefixes of the trace context keys used by the tracer for propagation.
+var traceMetadataPrefixes = []string{"ot-tracer-", "ot-baggage-"}
+
 // ForwardMetadata is a helper function for gRPC proxy that chains incoming
 // request with some outgoing request.
-// It simply forwards incoming context metadata without changes by toggling
-// internal outgoing key.
+// It forwards incoming context metadata by toggling internal outgoing key,
+// replacing the incoming trace context with the one of the current span,
+// so the outgoing request continues the trace as its child.
 func ForwardMetadata(ctx context.Context) context.Context {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return ctx
 	}
 
+	span := opentracing.SpanFromContext(ctx)
+	if span == nil {
+		return metadata.NewOutgoingContext(ctx, md)
+	}
+
+	md = md.Copy()
+	for key := range md {
+		if isTraceMetadataKey(key) {
+			delete(md, key)
+		}
+	}
+
+	// Failing to inject leaves the request untraced, which is not a reason
+	// to fail the request itself.
+	span.Tracer().Inject(span.Context(), opentracing.HTTPHeaders, metadataTextMap(md))
+
 	return metadata.NewOutgoingContext(ctx, md)
 }
+
+func isTraceMetadataKey(key string) bool {
+	for _, prefix := range traceMetadataPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// metadataTextMap adapts gRPC metadata to be an OpenTracing carrier.
+type metadataTextMap metadata.MD
+
+func (m metadataTextMap) Set(key, value string) {
+	m[strings.ToLower(key)] = []string{value}
+}
+
+func (m metadataTextMap) ForeachKey(handler func(key, value string) error) error {
+	for key, values := range m {
+		for _, value := range values {
+			if err := handler(key, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
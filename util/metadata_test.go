@@ -0,0 +1,47 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opentracing/basictracer-go"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestForwardMetadata(t *testing.T) {
+	tracer := basictracer.New(basictracer.NewInMemoryRecorder())
+
+	// Trace context of the request received by the proxy.
+	client := tracer.StartSpan("client")
+	md := metadata.Pairs("wallet", "0x8125721c2413d99a33e351e1f6bb4e56b6b633fd")
+	require.NoError(t, tracer.Inject(client.Context(), opentracing.HTTPHeaders, metadataTextMap(md)))
+
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	server := tracer.StartSpan("server", opentracing.ChildOf(client.Context()))
+	ctx = opentracing.ContextWithSpan(ctx, server)
+
+	outgoing, ok := metadata.FromOutgoingContext(ForwardMetadata(ctx))
+	require.True(t, ok)
+	assert.Equal(t, []string{"0x8125721c2413d99a33e351e1f6bb4e56b6b633fd"}, outgoing["wallet"])
+
+	spanContext, err := tracer.Extract(opentracing.HTTPHeaders, metadataTextMap(outgoing))
+	require.NoError(t, err)
+	assert.Equal(t, server.Context().(basictracer.SpanContext).SpanID, spanContext.(basictracer.SpanContext).SpanID)
+
+	// The incoming metadata must stay intact.
+	incomingContext, err := tracer.Extract(opentracing.HTTPHeaders, metadataTextMap(md))
+	require.NoError(t, err)
+	assert.Equal(t, client.Context().(basictracer.SpanContext).SpanID, incomingContext.(basictracer.SpanContext).SpanID)
+}
+
+func TestForwardMetadataWithoutSpan(t *testing.T) {
+	md := metadata.Pairs("key", "value")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	outgoing, ok := metadata.FromOutgoingContext(ForwardMetadata(ctx))
+	require.True(t, ok)
+	assert.Equal(t, md, outgoing)
+}
@@ -2,22 +2,13 @@ package xgrpc
 
 import (
 	"github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
-	"github.com/opentracing/basictracer-go"
-	"github.com/opentracing/opentracing-go"
 	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/insonmnia/tracing"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
-func newTracer() opentracing.Tracer {
-	return basictracer.NewWithOptions(basictracer.Options{
-		ShouldSample:   func(traceID uint64) bool { return true },
-		MaxLogsPerSpan: 100,
-		Recorder:       basictracer.NewInMemoryRecorder(),
-	})
-}
-
 // NewClient creates new gRPC client connection on given addr and wraps it
 // with given credentials (if provided).
 func NewClient(ctx context.Context, addr string, creds credentials.TransportCredentials, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
@@ -29,8 +20,8 @@ func NewClient(ctx context.Context, addr string, creds credentials.TransportCred
 	var extraOpts = append(opts, secureOpt,
 		grpc.WithCompressor(grpc.NewGZIPCompressor()),
 		grpc.WithDecompressor(grpc.NewGZIPDecompressor()),
-		grpc.WithUnaryInterceptor(grpc_opentracing.UnaryClientInterceptor(grpc_opentracing.WithTracer(newTracer()))),
-		grpc.WithStreamInterceptor(grpc_opentracing.StreamClientInterceptor()),
+		grpc.WithUnaryInterceptor(grpc_opentracing.UnaryClientInterceptor(grpc_opentracing.WithTracer(tracing.Tracer()))),
+		grpc.WithStreamInterceptor(grpc_opentracing.StreamClientInterceptor(grpc_opentracing.WithTracer(tracing.Tracer()))),
 	)
 	cc, err := grpc.DialContext(ctx, addr, extraOpts...)
 	if err != nil {
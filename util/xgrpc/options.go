@@ -16,6 +16,7 @@ import (
 	"github.com/opentracing/basictracer-go"
 	"github.com/opentracing/opentracing-go"
 	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/insonmnia/tracing"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -51,8 +52,10 @@ func TraceInterceptor(tracer opentracing.Tracer) ServerOption {
 	}
 }
 
+// DefaultTraceInterceptor traces requests using the shared tracer, which
+// exports spans as configured by tracing.Setup.
 func DefaultTraceInterceptor() ServerOption {
-	return TraceInterceptor(basictracer.New(basictracer.NewInMemoryRecorder()))
+	return TraceInterceptor(tracing.Tracer())
 }
 
 // UnaryServerInterceptor adds an unary interceptor to the chain.